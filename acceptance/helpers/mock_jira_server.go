@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 )
 
 // MockJiraServer provides a mock JIRA API for testing
@@ -13,6 +14,20 @@ type MockJiraServer struct {
 	*httptest.Server
 	validTokens map[string]bool
 	projects    map[string]JiraProject
+
+	issuesMu sync.Mutex
+	issues   []JiraIssue
+}
+
+// JiraIssue represents an issue created through the mock
+type JiraIssue struct {
+	ID          string
+	Key         string
+	ProjectKey  string
+	IssueType   string
+	Summary     string
+	Description string
+	Labels      []string
 }
 
 // JiraProject represents a JIRA project
@@ -89,6 +104,9 @@ func (m *MockJiraServer) setupRoutes(mux *http.ServeMux) {
 	
 	// Issue type endpoint
 	mux.HandleFunc("/rest/api/2/issuetype", m.handleIssueTypes)
+
+	// Issue creation endpoint
+	mux.HandleFunc("/rest/api/2/issue", m.handleCreateIssue)
 	
 	// Server info endpoint (for JIRA version detection)
 	mux.HandleFunc("/rest/api/2/serverInfo", m.handleServerInfo)
@@ -150,6 +168,15 @@ func (m *MockJiraServer) handleFields(w http.ResponseWriter, r *http.Request) {
 			ClauseNames: []string{"summary"},
 			Schema:      Schema{Type: "string"},
 		},
+		{
+			ID:          "description",
+			Name:        "Description",
+			Custom:      false,
+			Navigable:   true,
+			Searchable:  true,
+			ClauseNames: []string{"description"},
+			Schema:      Schema{Type: "string"},
+		},
 		{
 			ID:          "issuetype",
 			Name:        "Issue Type",
@@ -251,6 +278,69 @@ func (m *MockJiraServer) handleIssueTypes(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(issueTypes)
 }
 
+func (m *MockJiraServer) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
+	if !m.authenticate(r) {
+		http.Error(w, `{"errorMessages":["Unauthorized"],"errors":{}}`, http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"errorMessages":["Method not allowed"],"errors":{}}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Fields struct {
+			Project     struct{ Key string } `json:"project"`
+			IssueType   struct{ Name string } `json:"issuetype"`
+			Summary     string               `json:"summary"`
+			Description string               `json:"description"`
+			Labels      []string             `json:"labels"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"errorMessages":["Invalid request body"],"errors":{}}`, http.StatusBadRequest)
+		return
+	}
+	if _, exists := m.projects[req.Fields.Project.Key]; !exists || req.Fields.Summary == "" {
+		http.Error(w, `{"errorMessages":[],"errors":{"project":"valid project and summary are required"}}`, http.StatusBadRequest)
+		return
+	}
+
+	m.issuesMu.Lock()
+	issue := JiraIssue{
+		ID:          fmt.Sprintf("%d", 10000+len(m.issues)+1),
+		ProjectKey:  req.Fields.Project.Key,
+		IssueType:   req.Fields.IssueType.Name,
+		Summary:     req.Fields.Summary,
+		Description: req.Fields.Description,
+		Labels:      req.Fields.Labels,
+	}
+	count := 1
+	for _, existing := range m.issues {
+		if existing.ProjectKey == issue.ProjectKey {
+			count++
+		}
+	}
+	issue.Key = fmt.Sprintf("%s-%d", issue.ProjectKey, count)
+	m.issues = append(m.issues, issue)
+	m.issuesMu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{
+		"id":   issue.ID,
+		"key":  issue.Key,
+		"self": m.URL + "/rest/api/2/issue/" + issue.ID,
+	})
+}
+
+// CreatedIssues returns the issues created through the mock
+func (m *MockJiraServer) CreatedIssues() []JiraIssue {
+	m.issuesMu.Lock()
+	defer m.issuesMu.Unlock()
+	return append([]JiraIssue(nil), m.issues...)
+}
+
 func (m *MockJiraServer) handleServerInfo(w http.ResponseWriter, r *http.Request) {
 	// No auth required for server info
 	serverInfo := map[string]interface{}{
@@ -11,19 +11,77 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	api "github.com/guidewire-oss/fern-platform/internal/api"
 	"github.com/guidewire-oss/fern-platform/internal/domains"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingApp "github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql"
 	"github.com/guidewire-oss/fern-platform/pkg/config"
 	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/metrics"
 	"github.com/guidewire-oss/fern-platform/pkg/middleware"
+	"github.com/guidewire-oss/fern-platform/pkg/ratelimit"
+	"github.com/guidewire-oss/fern-platform/pkg/scheduler"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+)
+
+const (
+	// flakyLifecycleInterval is how often flaky tests are checked for auto-resolution and reactivation
+	flakyLifecycleInterval = 15 * time.Minute
+
+	// similarFailuresInterval is how often failures ingested by other instances are indexed
+	similarFailuresInterval = 5 * time.Minute
+
+	// jiraIssueSyncInterval is how often issues linked to flaky tests are polled in JIRA
+	jiraIssueSyncInterval = 5 * time.Minute
+
+	// webhookDeliveryInterval is how often due webhook deliveries are attempted
+	webhookDeliveryInterval = 10 * time.Second
+
+	// webhookCleanupInterval is how often old webhook deliveries are removed from the delivery log
+	webhookCleanupInterval = 24 * time.Hour
+
+	// notificationDeliveryInterval is how often due Slack and Teams notifications are posted
+	notificationDeliveryInterval = 10 * time.Second
+
+	// notificationCleanupInterval is how often old notifications are removed from the notification log
+	notificationCleanupInterval = 24 * time.Hour
+
+	// vcsReportInterval is how often due GitHub and GitLab reports are sent
+	vcsReportInterval = 10 * time.Second
+
+	// vcsReportCleanupInterval is how often old reports are removed from the report log
+	vcsReportCleanupInterval = 24 * time.Hour
+
+	// digestInterval is how often due email digests are sent; digests go out
+	// at the first check after their scheduled time
+	digestInterval = 15 * time.Minute
+
+	// digestCleanupInterval is how often old digest deliveries are removed
+	digestCleanupInterval = 24 * time.Hour
+
+	// projectPermissionCleanupInterval is how often expired project permissions
+	// are removed; they stop applying when they expire regardless
+	projectPermissionCleanupInterval = time.Hour
+
+	// auditRetentionInterval is how often audit log entries older than the
+	// retention period are removed
+	auditRetentionInterval = time.Hour
+
+	// sessionCleanupInterval is how often expired and ended sessions are
+	// removed; they stop authenticating when they end regardless
+	sessionCleanupInterval = time.Hour
 )
 
 func main() {
 	configPath := flag.String("config", "", "Path to configuration file")
+	reencryptCredentials := flag.Bool("reencrypt-credentials", false, "Re-encrypt all integration credentials with the primary encryption key and exit")
 	flag.Parse()
 
 	// Load configuration
@@ -42,12 +100,23 @@ func main() {
 	logger := logging.GetLogger()
 	logger.WithService("fern-platform").Info("Starting Fern Platform")
 
+	// Initialize tracing before anything that creates spans
+	shutdownTracing, err := tracing.Init(context.Background(), &cfg.Monitoring.Tracing)
+	if err != nil {
+		logger.WithService("fern-platform").WithError(err).Fatal("Failed to initialize tracing")
+	}
+
 	// Initialize database
 	db, err := database.NewDatabase(&cfg.Database)
 	if err != nil {
 		logger.WithService("fern-platform").WithError(err).Fatal("Failed to connect to database")
 	}
 	defer db.Close()
+	if cfg.Monitoring.Tracing.Enabled {
+		if err := tracing.InstrumentDatabase(db.DB); err != nil {
+			logger.WithService("fern-platform").WithError(err).Warn("Database tracing disabled")
+		}
+	}
 
 	// Run migrations
 	logger.WithService("fern-platform").Info("Starting database migrations from path: migrations")
@@ -57,7 +126,31 @@ func main() {
 	logger.WithService("fern-platform").Info("Database migrations completed successfully")
 
 	// Initialize domain factory for DDD architecture
-	domainFactory := domains.NewDomainFactory(db.DB, logger, &cfg.Auth)
+	keyRing, err := domains.NewCredentialKeyRing(cfg.Integrations.Encryption, logger)
+	if err != nil {
+		logger.WithService("fern-platform").WithError(err).Fatal("Failed to load credential encryption keys")
+	}
+	domainFactory := domains.NewDomainFactory(db.DB, logger, &cfg.Auth, keyRing)
+
+	if *reencryptCredentials {
+		runCredentialReencryption(domainFactory, logger)
+		return
+	}
+
+	// Prometheus metrics are served on their own port
+	var metricsRegistry *metrics.Registry
+	if cfg.Monitoring.Metrics.Enabled {
+		metricsRegistry = metrics.NewRegistry()
+		if err := metricsRegistry.RegisterDatabase(db.DB); err != nil {
+			logger.WithService("fern-platform").WithError(err).Warn("Database metrics disabled")
+		}
+		domainFactory.EnableMetrics(metricsRegistry)
+	}
+
+	if cfg.Audit.Enabled {
+		domainFactory.EnableAuditLog(cfg.Audit.Retention)
+	}
+	auditService := domainFactory.GetAuditService()
 
 	// Get domain services directly
 	testingService := domainFactory.GetTestingService()
@@ -65,7 +158,244 @@ func main() {
 	tagService := domainFactory.GetTagDomainService()
 	flakyDetectionService := domainFactory.GetFlakyDetectionService()
 	jiraConnectionService := domainFactory.GetJiraConnectionService()
+	trackerConnectionService := domainFactory.GetTrackerConnectionService()
 	authMiddleware := domainFactory.GetAuthMiddleware()
+	flakyLifecycleService := domainFactory.GetFlakyLifecycleService()
+	similarFailureService := domainFactory.GetSimilarFailureService()
+	webhookService := domainFactory.GetWebhookService()
+	notificationService := domainFactory.GetNotificationService()
+	vcsReportService := domainFactory.GetVCSReportService()
+
+	// Webhook payloads, notifications and VCS reports link back to the Fern UI; enabled
+	// before JIRA so the issue sync publishes the flaky tests it resolves
+	domainFactory.EnableEventPublishing(cfg.Services.UI.URL)
+
+	// JIRA issues filed from test results link back to the Fern UI
+	domainFactory.EnableJiraIssueCreation(cfg.Services.UI.URL)
+
+	// JIRA connections can be authorized with OAuth once an Atlassian app is configured
+	if cfg.Integrations.Jira.OAuth.ClientID != "" {
+		if err := domainFactory.EnableJiraOAuth(cfg.Integrations.Jira.OAuth); err != nil {
+			logger.WithService("fern-platform").WithError(err).Warn("JIRA OAuth disabled")
+		}
+	}
+
+	// Email digests need an SMTP server, so they are only sent when enabled
+	var digestService *integrations.DigestService
+	if cfg.Integrations.Email.Digest.Enabled {
+		var err error
+		if digestService, err = domainFactory.EnableEmailDigests(cfg.Integrations.Email, cfg.Services.UI.URL); err != nil {
+			logger.WithService("fern-platform").WithError(err).Warn("Email digests disabled")
+		}
+	}
+
+	// On-demand failure explanations are only available with a supported LLM provider
+	var explanationService *testingApp.FailureExplanationService
+	if llmProvider, err := llm.NewDefaultProvider(cfg.LLM); err != nil {
+		logger.WithService("fern-platform").WithError(err).Info("Failure explanations disabled")
+	} else {
+		explanationService = domainFactory.EnableFailureExplanations(llm.NewExplainer(llmProvider, cfg.LLM))
+		logger.WithService("fern-platform").WithFields(map[string]interface{}{
+			"provider": llmProvider.Name(),
+			"model":    llmProvider.Model(),
+		}).Info("Failure explanations enabled")
+	}
+
+	// Background jobs
+	jobScheduler := scheduler.New(logger)
+	// registerJob schedules a job to run every interval, exiting if it cannot be registered
+	registerJob := func(name string, interval time.Duration, job scheduler.JobFunc) {
+		if err := jobScheduler.Register(name, interval, job); err != nil {
+			logger.WithService("fern-platform").WithError(err).WithField("job", name).Fatal("Failed to register background job")
+		}
+	}
+	// cleanupJob runs a cleanup and logs how many records it removed
+	cleanupJob := func(cleanup func(ctx context.Context) (int64, error), message string) scheduler.JobFunc {
+		return func(ctx context.Context) error {
+			removed, err := cleanup(ctx)
+			if err != nil {
+				return err
+			}
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"removed": removed,
+			}).Debug(message)
+			return nil
+		}
+	}
+
+	registerJob("flaky-lifecycle", flakyLifecycleInterval, func(ctx context.Context) error {
+		result, err := flakyLifecycleService.RunLifecycle(ctx)
+		if err != nil {
+			return err
+		}
+		for _, jobErr := range result.Errors {
+			logger.WithService("fern-platform").WithError(jobErr).Warn("Flaky lifecycle check failed")
+		}
+		if result.Resolved > 0 || result.Reactivated > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"checked":     result.Checked,
+				"resolved":    result.Resolved,
+				"reactivated": result.Reactivated,
+			}).Info("Flaky test lifecycle updated")
+		}
+		return nil
+	})
+	registerJob("similar-failures-index", similarFailuresInterval, func(ctx context.Context) error {
+		indexed, err := similarFailureService.Refresh(ctx)
+		if err != nil {
+			return err
+		}
+		logger.WithService("fern-platform").WithFields(map[string]interface{}{
+			"indexed": indexed,
+		}).Debug("Similar failure index refreshed")
+		return nil
+	})
+	registerJob("jira-issue-sync", jiraIssueSyncInterval, func(ctx context.Context) error {
+		result, err := jiraConnectionService.SyncIssues(ctx)
+		if err != nil {
+			return err
+		}
+		if result.FailedConnections > 0 || result.Errors > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"failed_connections": result.FailedConnections,
+				"errors":             result.Errors,
+			}).Warn("JIRA issue sync had failures")
+		}
+		if result.FlakyTestsResolved > 0 || result.IssuesCommented > 0 || result.IssuesReopened > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"checked":   result.IssuesChecked,
+				"resolved":  result.FlakyTestsResolved,
+				"commented": result.IssuesCommented,
+				"reopened":  result.IssuesReopened,
+			}).Info("JIRA issues synced")
+		}
+		return nil
+	})
+	registerJob("webhook-delivery", webhookDeliveryInterval, func(ctx context.Context) error {
+		result, err := webhookService.DeliverDue(ctx)
+		if err != nil {
+			return err
+		}
+		for _, deliveryErr := range result.Errors {
+			logger.WithService("fern-platform").WithError(deliveryErr).Warn("Webhook delivery failed")
+		}
+		if result.Failed > 0 || result.Disabled > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"failed":   result.Failed,
+				"disabled": result.Disabled,
+			}).Warn("Webhook deliveries gave up")
+		}
+		if result.Attempted > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"attempted": result.Attempted,
+				"succeeded": result.Succeeded,
+				"retrying":  result.Retrying,
+			}).Debug("Webhook deliveries attempted")
+		}
+		return nil
+	})
+	registerJob("webhook-delivery-cleanup", webhookCleanupInterval, cleanupJob(webhookService.PruneDeliveries, "Old webhook deliveries removed"))
+	registerJob("notification-delivery", notificationDeliveryInterval, func(ctx context.Context) error {
+		result, err := notificationService.DeliverDue(ctx)
+		if err != nil {
+			return err
+		}
+		for _, deliveryErr := range result.Errors {
+			logger.WithService("fern-platform").WithError(deliveryErr).Warn("Notification delivery failed")
+		}
+		if result.Failed > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"failed": result.Failed,
+			}).Warn("Notification deliveries gave up")
+		}
+		if result.Attempted > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"attempted": result.Attempted,
+				"sent":      result.Sent,
+				"retrying":  result.Retrying,
+			}).Debug("Notification deliveries attempted")
+		}
+		return nil
+	})
+	registerJob("notification-delivery-cleanup", notificationCleanupInterval, cleanupJob(notificationService.PruneDeliveries, "Old notifications removed"))
+	registerJob("vcs-reports", vcsReportInterval, func(ctx context.Context) error {
+		result, err := vcsReportService.ReportDue(ctx)
+		if err != nil {
+			return err
+		}
+		for _, reportErr := range result.Errors {
+			logger.WithService("fern-platform").WithError(reportErr).Warn("VCS report failed")
+		}
+		if result.Failed > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"failed": result.Failed,
+			}).Warn("VCS reports gave up")
+		}
+		if result.Attempted > 0 {
+			logger.WithService("fern-platform").WithFields(map[string]interface{}{
+				"attempted": result.Attempted,
+				"sent":      result.Sent,
+				"retrying":  result.Retrying,
+			}).Debug("VCS reports attempted")
+		}
+		return nil
+	})
+	registerJob("vcs-report-cleanup", vcsReportCleanupInterval, cleanupJob(vcsReportService.PruneReports, "Old VCS reports removed"))
+	registerJob("project-permission-cleanup", projectPermissionCleanupInterval, cleanupJob(projectService.DeleteExpiredPermissions, "Expired project permissions removed"))
+	registerJob("session-cleanup", sessionCleanupInterval, cleanupJob(domainFactory.GetAuthService().CleanupExpiredSessions, "Expired sessions removed"))
+	if auditService != nil {
+		registerJob("audit-log-retention", auditRetentionInterval, cleanupJob(auditService.PurgeExpired, "Expired audit log entries removed"))
+	}
+	if digestService != nil {
+		registerJob("email-digests", digestInterval, func(ctx context.Context) error {
+			result, err := digestService.SendDue(ctx)
+			if err != nil {
+				return err
+			}
+			for _, digestErr := range result.Errors {
+				logger.WithService("fern-platform").WithError(digestErr).Warn("Email digest failed")
+			}
+			if result.Failed > 0 {
+				logger.WithService("fern-platform").WithFields(map[string]interface{}{
+					"failed": result.Failed,
+				}).Warn("Email digests gave up")
+			}
+			if result.Attempted > 0 {
+				logger.WithService("fern-platform").WithFields(map[string]interface{}{
+					"attempted": result.Attempted,
+					"sent":      result.Sent,
+					"skipped":   result.Skipped,
+					"retrying":  result.Retrying,
+				}).Info("Email digests sent")
+			}
+			return nil
+		})
+		registerJob("email-digest-cleanup", digestCleanupInterval, cleanupJob(digestService.PruneDeliveries, "Old email digests removed"))
+	}
+	jobScheduler.Start(context.Background())
+	// Load recent failures into the empty index without waiting for the first interval
+	if err := jobScheduler.RunNow("similar-failures-index"); err != nil {
+		logger.WithService("fern-platform").WithError(err).Warn("Failed to start similar failures indexing")
+	}
+
+	// Health checks for probes and the admin view
+	healthChecks := health.NewRegistry(&cfg.Monitoring.Health)
+	healthChecks.Register("database", health.Critical, health.Database(db))
+	if latestMigration, err := database.LatestMigration("migrations"); err != nil {
+		logger.WithService("fern-platform").WithError(err).Warn("Migration health check disabled")
+	} else {
+		healthChecks.Register("migrations", health.Critical, health.Migrations(db, latestMigration))
+	}
+	healthChecks.Register("jira", health.Optional, jiraConnectionService.CheckReachability)
+	healthChecks.Register("background-jobs", health.Liveness, health.Workers(jobScheduler.Status))
+
+	var rateLimiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	if cfg.RateLimit.Enabled && cfg.RateLimit.Backend == "redis" {
+		redisLimiter := ratelimit.NewRedisLimiter(&cfg.Redis)
+		defer redisLimiter.Close()
+		healthChecks.Register("redis", health.Optional, redisLimiter.Ping)
+		rateLimiter = redisLimiter
+	}
 
 	// Initialize HTTP server
 	if cfg.Server.Host == "0.0.0.0" {
@@ -78,10 +408,18 @@ func main() {
 
 	// Add middleware
 	router.Use(middleware.RequestIDMiddleware())
+	if cfg.Monitoring.Tracing.Enabled {
+		// Ahead of logging, so request logs carry the trace ID
+		router.Use(tracing.Middleware(cfg.Monitoring.Tracing.ServiceName))
+	}
+	if metricsRegistry != nil {
+		// Ahead of recovery, so requests that panic are recorded with their 500
+		router.Use(metricsRegistry.HTTPMiddleware())
+	}
 	router.Use(middleware.LoggingMiddleware(logger))
 	router.Use(middleware.RecoveryMiddleware(logger))
 	router.Use(middleware.SecurityHeadersMiddleware())
-	router.Use(middleware.HealthCheckMiddleware())
+	router.Use(middleware.HealthCheckMiddleware(healthChecks, cfg.Monitoring.Health.Path))
 
 	// CORS configuration
 	if gin.Mode() == gin.DebugMode {
@@ -90,6 +428,10 @@ func main() {
 		corsConfig := middleware.DefaultCORSConfig()
 		router.Use(middleware.NewCORSMiddleware(corsConfig))
 	}
+	if cfg.RateLimit.Enabled {
+		// After CORS, so limited browser requests can read the response
+		router.Use(middleware.RateLimitMiddleware(rateLimiter, cfg.RateLimit, authMiddleware.IdentifyUser, logger))
+	}
 
 	// Use the new domain-based API handler
 	// Check environment variable to determine which handler version to use
@@ -100,10 +442,22 @@ func main() {
 		// Use the new split handler architecture
 		domainHandler := api.NewDomainHandlerV2(
 			testingService,
+			flakyLifecycleService,
+			explanationService,
 			projectService,
 			tagService,
 			flakyDetectionService,
 			jiraConnectionService,
+			trackerConnectionService,
+			domainFactory.GetCredentialReencryptionService(),
+			webhookService,
+			notificationService,
+			vcsReportService,
+			digestService,
+			healthChecks,
+			domainFactory.GetUserAdminService(),
+			domainFactory.GetAuthService(),
+			auditService,
 			authMiddleware,
 			logger,
 		)
@@ -126,7 +480,7 @@ func main() {
 
 	// GraphQL routes with role group names from config
 	// Initialize GraphQL resolver with domain services
-	resolver := graphql.NewResolver(testingService, projectService, tagService, flakyDetectionService, jiraConnectionService, db.DB, logger)
+	resolver := graphql.NewResolver(testingService, flakyLifecycleService, projectService, tagService, flakyDetectionService, jiraConnectionService, trackerConnectionService, similarFailureService, explanationService, domainFactory.GetUserAdminService(), db.DB, logger)
 
 	roleGroupNames := &graphql.RoleGroupNames{
 		AdminGroup:   cfg.Auth.OAuth.AdminGroupName,
@@ -135,8 +489,24 @@ func main() {
 	}
 
 	gqlHandler := graphql.NewHandler(resolver, roleGroupNames)
+	if metricsRegistry != nil {
+		gqlHandler.Use(metricsRegistry.GraphQLExtension())
+	}
+	if cfg.Monitoring.Tracing.Enabled {
+		gqlHandler.Use(tracing.GraphQLExtension())
+	}
+	if auditService != nil {
+		gqlHandler.Use(graphql.AuditExtension(auditService, logger))
+	}
 	gqlHandler.RegisterRoutes(router, authMiddleware)
 
+	// SCIM routes for the identity provider to provision users and groups
+	if cfg.Auth.SCIM.Enabled {
+		scimHandler := api.NewSCIMHandler(domainFactory.GetProvisioningService(), cfg.Auth.SCIM.Token, auditService, logger)
+		scimHandler.RegisterRoutes(router)
+		logger.WithService("fern-platform").Info("SCIM provisioning enabled at /scim/v2")
+	}
+
 	// Note: Static file serving is handled by the API handler
 
 	// Create HTTP server
@@ -167,6 +537,22 @@ func main() {
 		}
 	}()
 
+	var metricsServer *http.Server
+	if metricsRegistry != nil {
+		metricsServer = metrics.NewServer(&cfg.Monitoring.Metrics, metricsRegistry)
+		go func() {
+			logger.WithService("fern-platform").
+				WithFields(map[string]interface{}{
+					"port": cfg.Monitoring.Metrics.Port,
+					"path": cfg.Monitoring.Metrics.Path,
+				}).Info("Starting metrics server")
+
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.WithService("fern-platform").WithError(err).Fatal("Failed to start metrics server")
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -174,6 +560,9 @@ func main() {
 
 	logger.WithService("fern-platform").Info("Shutting down server...")
 
+	// Stop background jobs before closing the database
+	jobScheduler.Stop()
+
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
@@ -181,6 +570,50 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.WithService("fern-platform").WithError(err).Fatal("Server forced to shutdown")
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.WithService("fern-platform").WithError(err).Warn("Metrics server forced to shutdown")
+		}
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.WithService("fern-platform").WithError(err).Warn("Failed to flush traces")
+	}
 
 	logger.WithService("fern-platform").Info("Server exited")
 }
+
+// runCredentialReencryption re-encrypts all integration credentials with the
+// primary encryption key, logging progress as it goes
+func runCredentialReencryption(domainFactory *domains.DomainFactory, logger *logging.Logger) {
+	reencryptionLog := logger.WithService("fern-platform")
+	reencryptionService := domainFactory.GetCredentialReencryptionService()
+	reencryptionLog.WithFields(map[string]interface{}{
+		"primary_key_id": reencryptionService.KeyRing().PrimaryKeyID(),
+	}).Info("Re-encrypting integration credentials")
+
+	progress, err := reencryptionService.Run(context.Background(), func(progress integrations.ReencryptionProgress) {
+		if progress.Processed%100 == 0 || progress.Processed == progress.Total {
+			reencryptionLog.WithFields(map[string]interface{}{
+				"processed": progress.Processed,
+				"total":     progress.Total,
+			}).Info("Credential re-encryption progress")
+		}
+	})
+	if err != nil {
+		reencryptionLog.WithError(err).Fatal("Credential re-encryption failed")
+	}
+	for _, failure := range progress.Errors {
+		reencryptionLog.WithFields(map[string]interface{}{"error": failure}).Warn("Failed to re-encrypt credential")
+	}
+
+	fields := reencryptionLog.WithFields(map[string]interface{}{
+		"total":       progress.Total,
+		"reencrypted": progress.Reencrypted,
+		"skipped":     progress.Skipped,
+		"failed":      progress.Failed,
+	})
+	if progress.Failed > 0 {
+		fields.Fatal("Credential re-encryption finished with failures")
+	}
+	fields.Info("Credential re-encryption completed")
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	auditInfra "github.com/guidewire-oss/fern-platform/internal/domains/audit/infrastructure"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var _ = Describe("Audit log", func() {
+	var (
+		auditService   *auditApp.AuditService
+		projectHandler *ProjectHandler
+		systemHandler  *SystemHandler
+		admin          *authDomain.User
+	)
+
+	// request serves a request on behalf of the user through audited routes
+	request := func(user *authDomain.User, method, path, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user", user)
+			c.Set("user_id", user.UserID)
+			c.Set("request_id", "req-42")
+		})
+		v1 := router.Group("/api/v1")
+		v1.Use(auditInterfaces.Middleware(auditService, projectHandler.logger))
+		adminGroup := v1.Group("/admin")
+		projectHandler.RegisterRoutes(v1, v1, adminGroup)
+		systemHandler.RegisterRoutes(adminGroup)
+
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "audit-test")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// listEntries returns the entries listed by the audit log endpoint for the query
+	listEntries := func(query string) []auditDomain.Entry {
+		recorder := request(admin, http.MethodGet, "/api/v1/admin/audit-logs?"+query, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var page struct {
+			Items []auditDomain.Entry `json:"items"`
+			Total int64               `json:"total"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &page)).To(Succeed())
+		Expect(page.Items).To(HaveLen(int(page.Total)))
+		return page.Items
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.ProjectDetails{},
+			&projectsInfra.ProjectPermissionDB{}, &projectsInfra.ProjectGroupPermissionDB{},
+			&auditInfra.AuditLogDB{},
+		)).To(Succeed())
+
+		projectService := projectsApp.NewProjectService(
+			projectsInfra.NewGormProjectRepository(db),
+			projectsInfra.NewGormProjectPermissionRepository(db),
+		)
+		_, err = projectService.CreateProject(context.Background(), "shop", "Shop", "team-shop", "admin-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		auditService = auditApp.NewAuditService(auditInfra.NewGormAuditRepository(db), 0)
+		auditService.RegisterSnapshotter(auditDomain.TargetProject, func(ctx context.Context, id string) (interface{}, error) {
+			project, err := projectService.GetProject(ctx, projectsDomain.ProjectID(id))
+			if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return project.ToSnapshot(), nil
+		})
+
+		projectHandler = NewProjectHandler(projectService, logger)
+		systemHandler = NewSystemHandler(nil, auditService, logger)
+		admin = &authDomain.User{UserID: "admin-1", Email: "admin@example.com", Role: authDomain.RoleAdmin}
+	})
+
+	It("should record who changed a project and what changed", func() {
+		recorder := request(admin, http.MethodPut, "/api/v1/projects/shop", `{"name":"Storefront"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		entries := listEntries("targetType=project&targetId=shop")
+		Expect(entries).To(HaveLen(1))
+		entry := entries[0]
+		Expect(entry.Action).To(Equal("update_project"))
+		Expect(entry.ActorID).To(Equal("admin-1"))
+		Expect(entry.ActorEmail).To(Equal("admin@example.com"))
+		Expect(entry.Source).To(Equal(auditDomain.SourceREST))
+		Expect(entry.RequestID).To(Equal("req-42"))
+		Expect(entry.UserAgent).To(Equal("audit-test"))
+		Expect(entry.IPAddress).NotTo(BeEmpty())
+		Expect(entry.Changes).To(HaveKeyWithValue("Name", auditDomain.Change{Before: "Shop", After: "Storefront"}))
+	})
+
+	It("should identify created targets from the response", func() {
+		recorder := request(admin, http.MethodPost, "/api/v1/projects",
+			`{"projectId":"search","name":"Search","team":"team-search"}`)
+		Expect(recorder.Code).To(BeNumerically("<", http.StatusBadRequest))
+
+		entries := listEntries("action=create_project")
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].TargetID).To(Equal("search"))
+		Expect(entries[0].Changes).To(HaveKey("Name"))
+		Expect(entries[0].Changes["Name"].Before).To(BeNil())
+	})
+
+	It("should record project access under the project and grantee", func() {
+		recorder := request(admin, http.MethodPost, "/api/v1/admin/projects/shop/users/dana/access", `{"permission":"write"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		entries := listEntries("targetType=project_access")
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Action).To(Equal("grant_project_access"))
+		Expect(entries[0].TargetID).To(Equal("shop/users/dana"))
+	})
+
+	It("should not record failed or read-only requests", func() {
+		Expect(request(admin, http.MethodPut, "/api/v1/projects/missing", `{"name":"x"}`).Code).
+			To(BeNumerically(">=", http.StatusBadRequest))
+		Expect(request(admin, http.MethodGet, "/api/v1/projects/shop", "").Code).To(Equal(http.StatusOK))
+
+		Expect(listEntries("")).To(BeEmpty())
+	})
+
+	It("should reject invalid filters", func() {
+		Expect(request(admin, http.MethodGet, "/api/v1/admin/audit-logs?from=yesterday", "").Code).
+			To(Equal(http.StatusBadRequest))
+		Expect(request(admin, http.MethodGet, "/api/v1/admin/audit-logs?limit=-1", "").Code).
+			To(Equal(http.StatusBadRequest))
+	})
+
+	It("should export matching entries as CSV and JSON", func() {
+		Expect(request(admin, http.MethodPost, "/api/v1/projects/shop/deactivate", "").Code).To(Equal(http.StatusOK))
+
+		recorder := request(admin, http.MethodGet, "/api/v1/admin/audit-logs/export?format=csv&targetId=shop", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/csv"))
+		Expect(recorder.Header().Get("Content-Disposition")).To(MatchRegexp(`attachment; filename="audit-log-.*\.csv"`))
+		rows, err := csv.NewReader(recorder.Body).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(HaveLen(2))
+		Expect(rows[1][4]).To(Equal("deactivate_project"))
+
+		recorder = request(admin, http.MethodGet, "/api/v1/admin/audit-logs/export?format=json", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var exported []auditDomain.Entry
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &exported)).To(Succeed())
+		// The export itself is a GET and is not recorded
+		Expect(exported).To(HaveLen(1))
+
+		Expect(request(admin, http.MethodGet, "/api/v1/admin/audit-logs/export?format=xml", "").Code).
+			To(Equal(http.StatusBadRequest))
+	})
+})
@@ -2,10 +2,15 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
@@ -13,17 +18,57 @@ import (
 // AuthHandler handles authentication and user related endpoints
 type AuthHandler struct {
 	*BaseHandler
-	authMiddleware *interfaces.AuthMiddlewareAdapter
+	authMiddleware   *interfaces.AuthMiddlewareAdapter
+	authService      *authApp.AuthenticationService
+	userAdminService *authApp.UserAdminService
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(authMiddleware *interfaces.AuthMiddlewareAdapter, logger *logging.Logger) *AuthHandler {
+func NewAuthHandler(authMiddleware *interfaces.AuthMiddlewareAdapter, authService *authApp.AuthenticationService, userAdminService *authApp.UserAdminService, logger *logging.Logger) *AuthHandler {
 	return &AuthHandler{
-		BaseHandler:    NewBaseHandler(logger),
-		authMiddleware: authMiddleware,
+		BaseHandler:      NewBaseHandler(logger),
+		authMiddleware:   authMiddleware,
+		authService:      authService,
+		userAdminService: userAdminService,
 	}
 }
 
+// UserResponse represents a user account as seen by admins
+type UserResponse struct {
+	UserID         string   `json:"userId"`
+	Email          string   `json:"email"`
+	Name           string   `json:"name"`
+	FirstName      string   `json:"firstName,omitempty"`
+	LastName       string   `json:"lastName,omitempty"`
+	Role           string   `json:"role"`
+	Status         string   `json:"status"`
+	ProfileURL     string   `json:"profileUrl,omitempty"`
+	EmailVerified  bool     `json:"emailVerified"`
+	Groups         []string `json:"groups"`
+	LastLoginAt    *string  `json:"lastLoginAt,omitempty"`
+	UpdatedBy      string   `json:"updatedBy,omitempty"`
+	RoleAssignedBy string   `json:"roleAssignedBy,omitempty"`
+	CreatedAt      string   `json:"createdAt"`
+	UpdatedAt      string   `json:"updatedAt"`
+}
+
+// SessionResponse represents a signed-in session, without the tokens that
+// authenticate it
+type SessionResponse struct {
+	ID           uint   `json:"id"`
+	IPAddress    string `json:"ipAddress,omitempty"`
+	UserAgent    string `json:"userAgent,omitempty"`
+	Current      bool   `json:"current"`
+	CreatedAt    string `json:"createdAt"`
+	LastActivity string `json:"lastActivity"`
+	ExpiresAt    string `json:"expiresAt"`
+}
+
+// UpdateUserRoleRequest represents the request to change the role of a user
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
 // showLoginPage handles GET /auth/login
 func (h *AuthHandler) showLoginPage(c *gin.Context) {
 	// Check if already authenticated
@@ -127,43 +172,238 @@ func (h *AuthHandler) getUserProjects(c *gin.Context) {
 
 // listUsers handles GET /api/v1/admin/users
 func (h *AuthHandler) listUsers(c *gin.Context) {
-	// TODO: Implement user listing from auth service
-	h.respondWithJSON(c, http.StatusOK, gin.H{"items": []gin.H{}, "total": 0})
+	filter := authDomain.UserFilter{
+		Search: c.Query("search"),
+		Role:   authDomain.UserRole(c.Query("role")),
+		Status: authDomain.UserStatus(c.Query("status")),
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil {
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(c.Query("offset")); err == nil {
+		filter.Offset = offset
+	}
+
+	users, total, err := h.userAdminService.ListUsers(c.Request.Context(), filter)
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+
+	items := make([]UserResponse, len(users))
+	for i, user := range users {
+		items[i] = toUserResponse(user)
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{"items": items, "total": total})
 }
 
 // getUser handles GET /api/v1/admin/users/:userId
 func (h *AuthHandler) getUser(c *gin.Context) {
-	userID := c.Param("userId")
-	// TODO: Implement get user from auth service
-	h.respondWithJSON(c, http.StatusOK, gin.H{"id": userID})
+	user, err := h.userAdminService.GetUser(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, toUserResponse(user))
 }
 
 // updateUserRole handles PUT /api/v1/admin/users/:userId/role
 func (h *AuthHandler) updateUserRole(c *gin.Context) {
-	// TODO: Implement update user role
-	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Role updated successfully"})
+	var req UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.userAdminService.UpdateUserRole(c.Request.Context(), h.getUserID(c), c.Param("userId"), authDomain.UserRole(req.Role))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, toUserResponse(user))
 }
 
 // suspendUser handles POST /api/v1/admin/users/:userId/suspend
 func (h *AuthHandler) suspendUser(c *gin.Context) {
-	// TODO: Implement suspend user
-	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "User suspended successfully"})
+	user, err := h.userAdminService.SuspendUser(c.Request.Context(), h.getUserID(c), c.Param("userId"))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, toUserResponse(user))
 }
 
 // activateUser handles POST /api/v1/admin/users/:userId/activate
 func (h *AuthHandler) activateUser(c *gin.Context) {
-	// TODO: Implement activate user
-	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "User activated successfully"})
+	user, err := h.userAdminService.ActivateUser(c.Request.Context(), h.getUserID(c), c.Param("userId"))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, toUserResponse(user))
 }
 
-// deleteUser handles DELETE /api/v1/admin/users/:userId
+// deleteUser handles DELETE /api/v1/admin/users/:userId. The resources the
+// user created are reassigned to the user given by ?reassignTo, or to the
+// acting admin.
 func (h *AuthHandler) deleteUser(c *gin.Context) {
-	// TODO: Implement delete user
+	if err := h.userAdminService.DeleteUser(c.Request.Context(), h.getUserID(c), c.Param("userId"), c.Query("reassignTo")); err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
 	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
 
+// listOwnSessions handles GET /api/v1/user/sessions
+func (h *AuthHandler) listOwnSessions(c *gin.Context) {
+	h.listSessions(c, h.getUserID(c))
+}
+
+// revokeOwnSession handles DELETE /api/v1/user/sessions/:sessionId
+func (h *AuthHandler) revokeOwnSession(c *gin.Context) {
+	h.revokeSession(c, h.getUserID(c))
+}
+
+// revokeOtherSessions handles DELETE /api/v1/user/sessions, signing the user
+// out everywhere but the current session
+func (h *AuthHandler) revokeOtherSessions(c *gin.Context) {
+	var current string
+	if session, ok := interfaces.GetAuthSession(c); ok {
+		current = session.SessionID
+	}
+
+	revoked, err := h.authService.RevokeOtherSessions(c.Request.Context(), h.getUserID(c), current)
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// listUserSessions handles GET /api/v1/admin/users/:userId/sessions
+func (h *AuthHandler) listUserSessions(c *gin.Context) {
+	user, err := h.userAdminService.GetUser(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.listSessions(c, user.UserID)
+}
+
+// revokeUserSession handles DELETE /api/v1/admin/users/:userId/sessions/:sessionId
+func (h *AuthHandler) revokeUserSession(c *gin.Context) {
+	h.revokeSession(c, c.Param("userId"))
+}
+
+// revokeUserSessions handles DELETE /api/v1/admin/users/:userId/sessions
+func (h *AuthHandler) revokeUserSessions(c *gin.Context) {
+	user, err := h.userAdminService.GetUser(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+
+	if err := h.authService.LogoutAllSessions(c.Request.Context(), user.UserID); err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Sessions revoked successfully"})
+}
+
+// listSessions responds with a user's active sessions
+func (h *AuthHandler) listSessions(c *gin.Context, userID string) {
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+
+	var current string
+	if session, ok := interfaces.GetAuthSession(c); ok {
+		current = session.SessionID
+	}
+
+	items := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		items[i] = toSessionResponse(session, current)
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{"items": items, "total": len(items)})
+}
+
+// revokeSession ends the session given by :sessionId if it belongs to the user
+func (h *AuthHandler) revokeSession(c *gin.Context, userID string) {
+	id, err := strconv.ParseUint(c.Param("sessionId"), 10, 0)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, uint(id)); err != nil {
+		h.respondWithUserError(c, err)
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
 // Helper methods
 
+// respondWithUserError maps user administration errors to HTTP responses
+func (h *AuthHandler) respondWithUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authDomain.ErrUserNotFound),
+		errors.Is(err, authDomain.ErrSessionNotFound):
+		h.respondWithError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, authApp.ErrSelfAdministration):
+		h.respondWithError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, authDomain.ErrInvalidRole),
+		errors.Is(err, authDomain.ErrInvalidStatus),
+		errors.Is(err, authApp.ErrInvalidReassignment):
+		h.respondWithError(c, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.WithError(err).Error("User administration failed")
+		h.respondWithError(c, http.StatusInternalServerError, "Failed to manage user")
+	}
+}
+
+// toUserResponse converts a user to its API response
+func toUserResponse(user *authDomain.User) UserResponse {
+	groups := make([]string, len(user.Groups))
+	for i, group := range user.Groups {
+		groups[i] = group.GroupName
+	}
+
+	return UserResponse{
+		UserID:         user.UserID,
+		Email:          user.Email,
+		Name:           user.Name,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Role:           string(user.Role),
+		Status:         string(user.Status),
+		ProfileURL:     user.ProfileURL,
+		EmailVerified:  user.EmailVerified,
+		Groups:         groups,
+		LastLoginAt:    formatOptionalTime(user.LastLoginAt),
+		UpdatedBy:      user.UpdatedBy,
+		RoleAssignedBy: user.RoleAssignedBy,
+		CreatedAt:      user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      user.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toSessionResponse converts a session, marking the one making the request
+func toSessionResponse(session *authDomain.Session, currentSessionID string) SessionResponse {
+	return SessionResponse{
+		ID:           session.ID,
+		IPAddress:    session.IPAddress,
+		UserAgent:    session.UserAgent,
+		Current:      session.SessionID == currentSessionID,
+		CreatedAt:    session.CreatedAt.Format(time.RFC3339),
+		LastActivity: session.LastActivity.Format(time.RFC3339),
+		ExpiresAt:    session.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
 // isUserAuthenticated checks if the user is authenticated
 func (h *AuthHandler) isUserAuthenticated(c *gin.Context) bool {
 	userID, exists := c.Get("user_id")
@@ -584,6 +824,9 @@ func (h *AuthHandler) RegisterRoutes(router *gin.Engine, authGroup, userGroup, a
 	userGroup.GET("/user/preferences", h.getUserPreferences)
 	userGroup.PUT("/user/preferences", h.updateUserPreferences)
 	userGroup.GET("/user/projects", h.getUserProjects)
+	userGroup.GET("/user/sessions", h.listOwnSessions)
+	userGroup.DELETE("/user/sessions", h.revokeOtherSessions)
+	userGroup.DELETE("/user/sessions/:sessionId", h.revokeOwnSession)
 
 	// Admin routes for user management
 	adminGroup.GET("/users", h.listUsers)
@@ -592,4 +835,7 @@ func (h *AuthHandler) RegisterRoutes(router *gin.Engine, authGroup, userGroup, a
 	adminGroup.POST("/users/:userId/suspend", h.suspendUser)
 	adminGroup.POST("/users/:userId/activate", h.activateUser)
 	adminGroup.DELETE("/users/:userId", h.deleteUser)
+	adminGroup.GET("/users/:userId/sessions", h.listUserSessions)
+	adminGroup.DELETE("/users/:userId/sessions", h.revokeUserSessions)
+	adminGroup.DELETE("/users/:userId/sessions/:sessionId", h.revokeUserSession)
 }
\ No newline at end of file
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	authInfra "github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var _ = Describe("AuthHandler user administration", func() {
+	var (
+		router *gin.Engine
+		db     *gorm.DB
+	)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.User{}, &database.UserGroup{}, &database.UserScope{}, &database.UserSession{},
+			&database.ProjectAccess{}, &database.ProjectPermission{},
+			&database.JiraIssueLink{}, &database.JiraFieldMapping{},
+			&database.WebhookSubscription{}, &database.NotificationRule{}, &database.VCSConnection{},
+		)).To(Succeed())
+
+		userRepo := authInfra.NewGormUserRepository(db)
+		sessionRepo := authInfra.NewGormSessionRepository(db)
+		for _, user := range []*authDomain.User{
+			{UserID: "admin-1", Email: "admin@example.com", Name: "Admin", Role: authDomain.RoleAdmin, Status: authDomain.StatusActive},
+			{UserID: "user-1", Email: "dana@example.com", Name: "Dana", Role: authDomain.RoleUser, Status: authDomain.StatusActive},
+		} {
+			Expect(userRepo.Create(context.Background(), user)).To(Succeed())
+		}
+		for _, session := range []*database.UserSession{
+			{UserID: "user-1", SessionID: "session-1", IPAddress: "10.0.0.1", UserAgent: "Firefox"},
+			{UserID: "user-1", SessionID: "session-2", IPAddress: "10.0.0.2", UserAgent: "Chrome"},
+			{UserID: "admin-1", SessionID: "admin-session"},
+		} {
+			session.IsActive = true
+			session.ExpiresAt = time.Now().Add(time.Hour)
+			session.LastActivity = time.Now()
+			Expect(db.Create(session).Error).To(Succeed())
+		}
+
+		adapter := interfaces.NewAuthMiddlewareAdapter(nil, nil, nil, &config.AuthConfig{}, logger)
+		handler := NewAuthHandler(adapter, authApp.NewAuthenticationService(userRepo, sessionRepo), authApp.NewUserAdminService(userRepo, sessionRepo), logger)
+
+		router = gin.New()
+		adminGroup := router.Group("/api/v1/admin", func(c *gin.Context) {
+			c.Set("user_id", "admin-1")
+		})
+		userGroup := router.Group("/api/v1", func(c *gin.Context) {
+			c.Set("user_id", "user-1")
+			c.Set("session", &authDomain.Session{SessionID: "session-1", UserID: "user-1"})
+		})
+		handler.RegisterRoutes(router, router.Group("/auth"), userGroup, adminGroup)
+	})
+
+	It("should list users with filters", func() {
+		recorder := request(http.MethodGet, "/api/v1/admin/users?role=user&search=dana", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var body struct {
+			Items []UserResponse `json:"items"`
+			Total int64          `json:"total"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Total).To(Equal(int64(1)))
+		Expect(body.Items[0].UserID).To(Equal("user-1"))
+		Expect(body.Items[0].Status).To(Equal("active"))
+	})
+
+	It("should reject unknown role filters", func() {
+		Expect(request(http.MethodGet, "/api/v1/admin/users?role=owner", "").Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 404 for unknown users", func() {
+		Expect(request(http.MethodGet, "/api/v1/admin/users/nobody", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should change roles on behalf of the admin", func() {
+		recorder := request(http.MethodPut, "/api/v1/admin/users/user-1/role", `{"role":"admin"}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var user UserResponse
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &user)).To(Succeed())
+		Expect(user.Role).To(Equal("admin"))
+		Expect(user.RoleAssignedBy).To(Equal("admin-1"))
+	})
+
+	It("should not let admins demote themselves", func() {
+		Expect(request(http.MethodPut, "/api/v1/admin/users/admin-1/role", `{"role":"user"}`).Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should end the sessions of suspended users", func() {
+		recorder := request(http.MethodPost, "/api/v1/admin/users/user-1/suspend", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var session database.UserSession
+		Expect(db.Where("session_id = ?", "session-1").First(&session).Error).To(Succeed())
+		Expect(session.IsActive).To(BeFalse())
+
+		recorder = request(http.MethodPost, "/api/v1/admin/users/user-1/activate", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(ContainSubstring(`"status":"active"`))
+	})
+
+	It("should soft-delete users", func() {
+		Expect(request(http.MethodDelete, "/api/v1/admin/users/user-1", "").Code).To(Equal(http.StatusOK))
+
+		Expect(request(http.MethodGet, "/api/v1/admin/users/user-1", "").Code).To(Equal(http.StatusNotFound))
+		Expect(request(http.MethodDelete, "/api/v1/admin/users/user-2?reassignTo=user-1", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	Describe("sessions", func() {
+		listSessions := func(path string) []SessionResponse {
+			recorder := request(http.MethodGet, path, "")
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var body struct {
+				Items []SessionResponse `json:"items"`
+			}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+			return body.Items
+		}
+
+		It("should list the user's own sessions without their tokens", func() {
+			recorder := request(http.MethodGet, "/api/v1/user/sessions", "")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).NotTo(ContainSubstring("session-1"))
+			sessions := listSessions("/api/v1/user/sessions")
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions).To(ContainElement(And(
+				HaveField("UserAgent", "Firefox"), HaveField("IPAddress", "10.0.0.1"), HaveField("Current", true),
+			)))
+			Expect(sessions).To(ContainElement(And(HaveField("UserAgent", "Chrome"), HaveField("Current", false))))
+		})
+
+		It("should only let users revoke their own sessions", func() {
+			var admin database.UserSession
+			Expect(db.Where("session_id = ?", "admin-session").First(&admin).Error).To(Succeed())
+
+			path := "/api/v1/user/sessions/" + strconv.FormatUint(uint64(admin.ID), 10)
+			Expect(request(http.MethodDelete, path, "").Code).To(Equal(http.StatusNotFound))
+			Expect(request(http.MethodDelete, "/api/v1/user/sessions/abc", "").Code).To(Equal(http.StatusBadRequest))
+
+			var other database.UserSession
+			Expect(db.Where("session_id = ?", "session-2").First(&other).Error).To(Succeed())
+			Expect(request(http.MethodDelete, "/api/v1/user/sessions/"+strconv.FormatUint(uint64(other.ID), 10), "").Code).To(Equal(http.StatusOK))
+			sessions := listSessions("/api/v1/user/sessions")
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].Current).To(BeTrue())
+		})
+
+		It("should sign users out everywhere but the current session", func() {
+			recorder := request(http.MethodDelete, "/api/v1/user/sessions", "")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(ContainSubstring(`"revoked":1`))
+			sessions := listSessions("/api/v1/user/sessions")
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].Current).To(BeTrue())
+		})
+
+		It("should let admins list and revoke any user's sessions", func() {
+			sessions := listSessions("/api/v1/admin/users/user-1/sessions")
+			Expect(sessions).To(HaveLen(2))
+
+			path := "/api/v1/admin/users/user-1/sessions/" + strconv.FormatUint(uint64(sessions[0].ID), 10)
+			Expect(request(http.MethodDelete, path, "").Code).To(Equal(http.StatusOK))
+			Expect(listSessions("/api/v1/admin/users/user-1/sessions")).To(HaveLen(1))
+
+			Expect(request(http.MethodDelete, "/api/v1/admin/users/user-1/sessions", "").Code).To(Equal(http.StatusOK))
+			Expect(listSessions("/api/v1/admin/users/user-1/sessions")).To(BeEmpty())
+			Expect(listSessions("/api/v1/admin/users/admin-1/sessions")).To(HaveLen(1))
+		})
+
+		It("should return 404 for the sessions of unknown users", func() {
+			Expect(request(http.MethodGet, "/api/v1/admin/users/nobody/sessions", "").Code).To(Equal(http.StatusNotFound))
+			Expect(request(http.MethodDelete, "/api/v1/admin/users/nobody/sessions", "").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -2,7 +2,13 @@
 package api
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
 
@@ -66,4 +72,49 @@ func (h *BaseHandler) getUserEmail(c *gin.Context) string {
 // ErrorResponse sends an error response with the given status code and message
 func (h *BaseHandler) ErrorResponse(c *gin.Context, code int, message string) {
 	h.respondWithError(c, code, message)
-}
\ No newline at end of file
+}
+
+// canAccessProject checks whether the user is an admin, a manager of the
+// project's team, or has been granted at least the required permission on
+// the project directly or through one of their groups
+func (h *BaseHandler) canAccessProject(c *gin.Context, projectService *projectsApp.ProjectService, projectID string, required projectsDomain.PermissionType) (bool, error) {
+	userID := h.getUserID(c)
+	var groups []string
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*authDomain.User); ok {
+			if u.IsAdmin() {
+				return true, nil
+			}
+			for _, group := range u.Groups {
+				groups = append(groups, group.GroupName)
+			}
+
+			project, err := projectService.GetProject(c.Request.Context(), projectsDomain.ProjectID(projectID))
+			if err != nil && !errors.Is(err, projectsDomain.ErrProjectNotFound) {
+				return false, err
+			}
+			if err == nil && project.Team() != "" && u.IsManagerForTeam(string(project.Team())) {
+				return true, nil
+			}
+		}
+	}
+
+	return projectService.HasPermission(c.Request.Context(), projectsDomain.ProjectID(projectID), userID, groups, required)
+}
+
+// requireProjectAccess checks that the user has at least the required
+// permission on a project, as canAccessProject does. It writes an error
+// response and returns false otherwise.
+func (h *BaseHandler) requireProjectAccess(c *gin.Context, projectService *projectsApp.ProjectService, projectID string, required projectsDomain.PermissionType) bool {
+	allowed, err := h.canAccessProject(c, projectService, projectID, required)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to check project access")
+		h.ErrorResponse(c, http.StatusInternalServerError, "failed to get permissions")
+		return false
+	}
+	if !allowed {
+		h.ErrorResponse(c, http.StatusForbidden, "forbidden")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+)
+
+// CredentialEncryptionHandler handles administration of the keys integration credentials are encrypted with
+type CredentialEncryptionHandler struct {
+	*BaseHandler
+	reencryptionService *integrations.CredentialReencryptionService
+}
+
+// NewCredentialEncryptionHandler creates a new credential encryption handler
+func NewCredentialEncryptionHandler(
+	baseHandler *BaseHandler,
+	reencryptionService *integrations.CredentialReencryptionService,
+) *CredentialEncryptionHandler {
+	return &CredentialEncryptionHandler{
+		BaseHandler:         baseHandler,
+		reencryptionService: reencryptionService,
+	}
+}
+
+// ReencryptionProgressResponse represents the progress of a credential re-encryption run
+type ReencryptionProgressResponse struct {
+	Status       string     `json:"status"`
+	PrimaryKeyID string     `json:"primaryKeyId"`
+	KeyIDs       []string   `json:"keyIds"`
+	Total        int        `json:"total"`
+	Processed    int        `json:"processed"`
+	Reencrypted  int        `json:"reencrypted"`
+	Skipped      int        `json:"skipped"`
+	Failed       int        `json:"failed"`
+	Errors       []string   `json:"errors"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+}
+
+// RegisterRoutes registers credential encryption routes
+func (h *CredentialEncryptionHandler) RegisterRoutes(adminGroup *gin.RouterGroup) {
+	adminGroup.GET("/integrations/credentials/reencrypt", h.GetReencryptionProgress)
+	adminGroup.POST("/integrations/credentials/reencrypt", h.StartReencryption)
+}
+
+// GetReencryptionProgress handles GET /api/v1/admin/integrations/credentials/reencrypt
+func (h *CredentialEncryptionHandler) GetReencryptionProgress(c *gin.Context) {
+	h.respondWithJSON(c, http.StatusOK, h.toResponse(h.reencryptionService.Progress()))
+}
+
+// StartReencryption handles POST /api/v1/admin/integrations/credentials/reencrypt
+func (h *CredentialEncryptionHandler) StartReencryption(c *gin.Context) {
+	progress, err := h.reencryptionService.Start(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, integrations.ErrReencryptionRunning) {
+			h.respondWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		h.respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusAccepted, h.toResponse(progress))
+}
+
+// toResponse converts re-encryption progress to its response
+func (h *CredentialEncryptionHandler) toResponse(progress integrations.ReencryptionProgress) ReencryptionProgressResponse {
+	errs := progress.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+	return ReencryptionProgressResponse{
+		Status:       string(progress.Status),
+		PrimaryKeyID: progress.PrimaryKeyID,
+		KeyIDs:       h.reencryptionService.KeyRing().KeyIDs(),
+		Total:        progress.Total,
+		Processed:    progress.Processed,
+		Reencrypted:  progress.Reencrypted,
+		Skipped:      progress.Skipped,
+		Failed:       progress.Failed,
+		Errors:       errs,
+		StartedAt:    progress.StartedAt,
+		FinishedAt:   progress.FinishedAt,
+	}
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+)
+
+const (
+	// defaultDigestDeliveryLimit is how many past digests are returned by default
+	defaultDigestDeliveryLimit = 10
+
+	// maxDigestDeliveryLimit caps how many past digests are returned
+	maxDigestDeliveryLimit = 100
+)
+
+// DigestHandler handles the email digest subscription of the signed-in user
+// and the unsubscribe links in digests
+type DigestHandler struct {
+	*BaseHandler
+	digestService *integrations.DigestService
+}
+
+// NewDigestHandler creates a new digest handler. A nil service, when
+// digests are not enabled, makes the endpoints respond with 503.
+func NewDigestHandler(baseHandler *BaseHandler, digestService *integrations.DigestService) *DigestHandler {
+	return &DigestHandler{
+		BaseHandler:   baseHandler,
+		digestService: digestService,
+	}
+}
+
+// DigestSubscriptionRequest represents the request to subscribe to or
+// unsubscribe from email digests
+type DigestSubscriptionRequest struct {
+	Subscribed *bool `json:"subscribed" binding:"required"`
+}
+
+// DigestSubscriptionResponse represents the digest subscription of a user
+type DigestSubscriptionResponse struct {
+	Subscribed bool                     `json:"subscribed"`
+	Frequency  string                   `json:"frequency"`
+	NextDigest string                   `json:"nextDigestAt"`
+	Deliveries []DigestDeliveryResponse `json:"deliveries"`
+}
+
+// DigestDeliveryResponse represents a digest sent, or due to be sent, to a user
+type DigestDeliveryResponse struct {
+	ID            string  `json:"id"`
+	Email         string  `json:"email"`
+	PeriodEnd     string  `json:"periodEnd"`
+	Status        string  `json:"status"`
+	Projects      int     `json:"projects"`
+	Attempts      int     `json:"attempts"`
+	LastAttemptAt *string `json:"lastAttemptAt,omitempty"`
+	LastError     string  `json:"lastError,omitempty"`
+}
+
+// DigestPreviewResponse represents the rendered digest of a user
+type DigestPreviewResponse struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
+
+// RegisterRoutes registers the digest subscription routes for signed-in
+// users and the unsubscribe routes, which digests link to without a session
+func (h *DigestHandler) RegisterRoutes(publicGroup, userGroup *gin.RouterGroup) {
+	userGroup.GET("/user/digest", h.GetSubscription)
+	userGroup.PUT("/user/digest", h.UpdateSubscription)
+	userGroup.GET("/user/digest/preview", h.Preview)
+
+	publicGroup.GET("/digests/unsubscribe", h.ConfirmUnsubscribe)
+	publicGroup.POST("/digests/unsubscribe", h.Unsubscribe)
+}
+
+// GetSubscription returns whether the user receives digests, when the next
+// one is due, and the latest digests
+func (h *DigestHandler) GetSubscription(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	limit := defaultDigestDeliveryLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.ErrorResponse(c, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = min(parsed, maxDigestDeliveryLimit)
+	}
+
+	h.respondWithSubscription(c, limit)
+}
+
+// UpdateSubscription subscribes the user to or unsubscribes them from digests
+func (h *DigestHandler) UpdateSubscription(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	var req DigestSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.digestService.SetSubscribed(c.Request.Context(), h.getUserID(c), *req.Subscribed); err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithSubscription(c, defaultDigestDeliveryLimit)
+}
+
+// Preview renders the latest digest of the user, including projects where
+// nothing happened. With format=html the HTML body is returned as a page.
+func (h *DigestHandler) Preview(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	rendered, err := h.digestService.Preview(c.Request.Context(), h.getUserID(c))
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if c.Query("format") == "html" {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(rendered.HTML))
+		return
+	}
+	h.respondWithJSON(c, http.StatusOK, DigestPreviewResponse{
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	})
+}
+
+// ConfirmUnsubscribe shows a page asking to confirm the unsubscribe link.
+// Following the link does not unsubscribe by itself, since mail scanners
+// follow links in emails.
+func (h *DigestHandler) ConfirmUnsubscribe(c *gin.Context) {
+	if h.digestService == nil {
+		h.unsubscribePage(c, http.StatusServiceUnavailable, unsubscribeDisabled, "")
+		return
+	}
+	h.unsubscribePage(c, http.StatusOK, unsubscribeConfirm, c.Query("token"))
+}
+
+// Unsubscribe unsubscribes the user an unsubscribe link was created for. It
+// serves both the confirmation page and one-click unsubscribe from mail
+// clients (RFC 8058).
+func (h *DigestHandler) Unsubscribe(c *gin.Context) {
+	if h.digestService == nil {
+		h.unsubscribePage(c, http.StatusServiceUnavailable, unsubscribeDisabled, "")
+		return
+	}
+
+	err := h.digestService.Unsubscribe(c.Request.Context(), c.Query("token"))
+	switch {
+	case errors.Is(err, integrations.ErrInvalidUnsubscribeToken):
+		h.unsubscribePage(c, http.StatusBadRequest, unsubscribeInvalid, "")
+	case err != nil:
+		h.logger.WithError(err).Warn("Failed to unsubscribe from email digests")
+		h.unsubscribePage(c, http.StatusInternalServerError, unsubscribeFailed, "")
+	default:
+		h.unsubscribePage(c, http.StatusOK, unsubscribeDone, "")
+	}
+}
+
+// enabled responds with 503 when digests are not enabled
+func (h *DigestHandler) enabled(c *gin.Context) bool {
+	if h.digestService == nil {
+		h.ErrorResponse(c, http.StatusServiceUnavailable, "Email digests are not enabled")
+		return false
+	}
+	return true
+}
+
+// respondWithSubscription responds with the digest subscription of the user
+func (h *DigestHandler) respondWithSubscription(c *gin.Context, limit int) {
+	ctx := c.Request.Context()
+	userID := h.getUserID(c)
+
+	subscribed, err := h.digestService.Subscribed(ctx, userID)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	deliveries, err := h.digestService.ListDeliveries(ctx, userID, limit)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	schedule := h.digestService.Schedule()
+	response := DigestSubscriptionResponse{
+		Subscribed: subscribed,
+		Frequency:  schedule.Name(),
+		NextDigest: schedule.Next(time.Now()).Format(time.RFC3339),
+		Deliveries: make([]DigestDeliveryResponse, len(deliveries)),
+	}
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = DigestDeliveryResponse{
+			ID:            delivery.ID,
+			Email:         delivery.Email,
+			PeriodEnd:     delivery.PeriodEnd.Format(time.RFC3339),
+			Status:        string(delivery.Status),
+			Projects:      delivery.Projects,
+			Attempts:      delivery.Attempts,
+			LastAttemptAt: formatOptionalTime(delivery.LastAttemptAt),
+			LastError:     delivery.LastError,
+		}
+	}
+	h.respondWithJSON(c, http.StatusOK, response)
+}
+
+// unsubscribeState selects the message of the unsubscribe page
+type unsubscribeState string
+
+const (
+	unsubscribeConfirm  unsubscribeState = "confirm"
+	unsubscribeDone     unsubscribeState = "done"
+	unsubscribeInvalid  unsubscribeState = "invalid"
+	unsubscribeFailed   unsubscribeState = "failed"
+	unsubscribeDisabled unsubscribeState = "disabled"
+)
+
+// unsubscribePage renders the page unsubscribe links open
+func (h *DigestHandler) unsubscribePage(c *gin.Context, code int, state unsubscribeState, token string) {
+	c.Status(code)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if err := unsubscribeTemplate.Execute(c.Writer, map[string]string{"State": string(state), "Token": token}); err != nil {
+		h.logger.WithError(err).Warn("Failed to render unsubscribe page")
+	}
+}
+
+var unsubscribeTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Fern email digests</title></head>
+<body style="font-family: -apple-system, 'Segoe UI', Helvetica, Arial, sans-serif; max-width: 480px; margin: 48px auto; color: #1f2933;">
+<h1 style="font-size: 20px;">Fern email digests</h1>
+{{- if eq .State "confirm"}}
+<p>Stop sending you email digests of project test health?</p>
+<form method="post" action="?token={{.Token}}">
+<button type="submit" style="padding: 8px 16px;">Unsubscribe</button>
+</form>
+{{- else if eq .State "done"}}
+<p>You are unsubscribed and will no longer receive email digests. You can subscribe again from your Fern preferences.</p>
+{{- else if eq .State "invalid"}}
+<p>This unsubscribe link is invalid. Unsubscribe from your Fern preferences instead.</p>
+{{- else if eq .State "disabled"}}
+<p>Email digests are not enabled.</p>
+{{- else}}
+<p>Something went wrong. Please try again later.</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -4,51 +4,85 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	analyticsApp "github.com/guidewire-oss/fern-platform/internal/domains/analytics/application"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
 	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
 	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
 	tagsApp "github.com/guidewire-oss/fern-platform/internal/domains/tags/application"
 	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
 
 // DomainHandlerV2 provides REST API handlers using domain services with split handlers
 type DomainHandlerV2 struct {
 	// Sub-handlers
-	authHandler           *AuthHandler
-	healthHandler         *HealthHandler
-	testRunHandler        *TestRunHandler
-	projectHandler        *ProjectHandler
-	tagHandler            *TagHandler
-	systemHandler         *SystemHandler
-	jiraConnectionHandler *JiraConnectionHandler
+	authHandler              *AuthHandler
+	healthHandler            *HealthHandler
+	testRunHandler           *TestRunHandler
+	projectHandler           *ProjectHandler
+	tagHandler               *TagHandler
+	systemHandler            *SystemHandler
+	jiraConnectionHandler    *JiraConnectionHandler
+	trackerConnectionHandler *TrackerConnectionHandler
+	credentialHandler        *CredentialEncryptionHandler
+	webhookHandler           *WebhookHandler
+	notificationHandler      *NotificationHandler
+	vcsConnectionHandler     *VCSConnectionHandler
+	digestHandler            *DigestHandler
+	flakyTestHandler         *FlakyTestHandler
+	explanationHandler       *FailureExplanationHandler
 
 	// Middleware
 	authMiddleware *interfaces.AuthMiddlewareAdapter
+	auditService   *auditApp.AuditService
 	logger         *logging.Logger
 }
 
 // NewDomainHandlerV2 creates a new domain-based API handler with split handlers
 func NewDomainHandlerV2(
 	testingService *application.TestRunService,
+	flakyLifecycleService *application.FlakyLifecycleService,
+	explanationService *application.FailureExplanationService,
 	projectService *projectsApp.ProjectService,
 	tagService *tagsApp.TagService,
 	flakyDetectionService *analyticsApp.FlakyDetectionService,
 	jiraConnectionService *integrations.JiraConnectionService,
+	trackerConnectionService *integrations.TrackerConnectionService,
+	reencryptionService *integrations.CredentialReencryptionService,
+	webhookService *integrations.WebhookService,
+	notificationService *integrations.NotificationService,
+	vcsReportService *integrations.VCSReportService,
+	digestService *integrations.DigestService,
+	healthChecks *health.Registry,
+	userAdminService *authApp.UserAdminService,
+	authService *authApp.AuthenticationService,
+	auditService *auditApp.AuditService,
 	authMiddleware *interfaces.AuthMiddlewareAdapter,
 	logger *logging.Logger,
 ) *DomainHandlerV2 {
 	baseHandler := NewBaseHandler(logger)
 	return &DomainHandlerV2{
-		authHandler:           NewAuthHandler(authMiddleware, logger),
-		healthHandler:         NewHealthHandler(logger),
-		testRunHandler:        NewTestRunHandler(testingService, logger),
-		projectHandler:        NewProjectHandler(projectService, logger),
-		tagHandler:            NewTagHandler(tagService, logger),
-		systemHandler:         NewSystemHandler(logger),
-		jiraConnectionHandler: NewJiraConnectionHandler(baseHandler, jiraConnectionService, projectService),
-		authMiddleware:        authMiddleware,
-		logger:                logger,
+		authHandler:              NewAuthHandler(authMiddleware, authService, userAdminService, logger),
+		healthHandler:            NewHealthHandler(healthChecks, logger),
+		testRunHandler:           NewTestRunHandler(testingService, logger),
+		projectHandler:           NewProjectHandler(projectService, logger),
+		tagHandler:               NewTagHandler(tagService, logger),
+		systemHandler:            NewSystemHandler(healthChecks, auditService, logger),
+		jiraConnectionHandler:    NewJiraConnectionHandler(baseHandler, jiraConnectionService, projectService),
+		trackerConnectionHandler: NewTrackerConnectionHandler(baseHandler, trackerConnectionService, projectService),
+		credentialHandler:        NewCredentialEncryptionHandler(baseHandler, reencryptionService),
+		webhookHandler:           NewWebhookHandler(baseHandler, webhookService, projectService),
+		notificationHandler:      NewNotificationHandler(baseHandler, notificationService, projectService),
+		vcsConnectionHandler:     NewVCSConnectionHandler(baseHandler, vcsReportService, projectService),
+		digestHandler:            NewDigestHandler(baseHandler, digestService),
+		flakyTestHandler:         NewFlakyTestHandler(testingService, flakyLifecycleService, logger),
+		explanationHandler:       NewFailureExplanationHandler(explanationService, projectService, logger),
+		authMiddleware:           authMiddleware,
+		auditService:             auditService,
+		logger:                   logger,
 	}
 }
 
@@ -92,16 +126,34 @@ func (h *DomainHandlerV2) RegisterRoutes(router *gin.Engine) {
 	adminGroup := v1.Group("/admin")
 	adminGroup.Use(h.authMiddleware.RequireAdmin())
 
+	// Record changes made through authenticated routes in the audit log
+	if h.auditService != nil {
+		auditMiddleware := auditInterfaces.Middleware(h.auditService, h.logger)
+		userGroup.Use(auditMiddleware)
+		managerGroup.Use(auditMiddleware)
+		adminGroup.Use(auditMiddleware)
+	}
+
 	// Register all handler routes
 	h.authHandler.RegisterRoutes(router, authGroup, userGroup, adminGroup)
 	h.testRunHandler.RegisterRoutes(userGroup, adminGroup)
 	h.projectHandler.RegisterRoutes(userGroup, managerGroup, adminGroup)
 	h.tagHandler.RegisterRoutes(userGroup, adminGroup)
+	h.flakyTestHandler.RegisterRoutes(userGroup)
+	h.explanationHandler.RegisterRoutes(userGroup)
 	h.systemHandler.RegisterRoutes(adminGroup)
+	h.credentialHandler.RegisterRoutes(adminGroup)
+	h.webhookHandler.RegisterRoutes(managerGroup, adminGroup)
+	h.notificationHandler.RegisterRoutes(managerGroup)
+	h.vcsConnectionHandler.RegisterRoutes(managerGroup)
+	h.digestHandler.RegisterRoutes(publicGroup, userGroup)
 
 	// Register JIRA connection routes
 	h.registerJiraConnectionRoutes(managerGroup)
 
+	// Register issue tracker connection routes
+	h.registerTrackerConnectionRoutes(managerGroup)
+
 	// Log route registration
 	h.logger.Info("All routes registered successfully with split handlers")
 }
@@ -125,6 +177,36 @@ func (h *DomainHandlerV2) registerJiraConnectionRoutes(managerGroup *gin.RouterG
 		jira.PUT("/connections/:connectionId/credentials", h.jiraConnectionHandler.UpdateCredentials)
 		jira.POST("/connections/:connectionId/test", h.jiraConnectionHandler.TestConnection)
 		jira.DELETE("/connections/:connectionId", h.jiraConnectionHandler.DeleteConnection)
+		jira.GET("/connections/:connectionId/oauth/authorize", h.jiraConnectionHandler.AuthorizeOAuth)
+		jira.GET("/connections/:connectionId/fields", h.jiraConnectionHandler.GetFields)
+		jira.GET("/connections/:connectionId/issue-types", h.jiraConnectionHandler.GetIssueTypes)
+		jira.GET("/connections/:connectionId/field-mapping", h.jiraConnectionHandler.GetFieldMapping)
+		jira.PUT("/connections/:connectionId/field-mapping", h.jiraConnectionHandler.UpdateFieldMapping)
+		jira.GET("/issues", h.jiraConnectionHandler.GetIssues)
+		jira.POST("/issues", h.jiraConnectionHandler.CreateIssue)
+	}
+
+	// The Atlassian OAuth app redirects here, so the path is the same for every connection
+	managerGroup.GET("/integrations/jira/oauth/callback", h.jiraConnectionHandler.OAuthCallback)
+}
+
+// registerTrackerConnectionRoutes registers routes for connections to any registered issue tracker
+func (h *DomainHandlerV2) registerTrackerConnectionRoutes(managerGroup *gin.RouterGroup) {
+	managerGroup.GET("/integrations/connector-types", h.trackerConnectionHandler.GetConnectorTypes)
+
+	trackers := managerGroup.Group("/projects/:projectId/integrations/trackers")
+	{
+		trackers.GET("/connections", h.trackerConnectionHandler.GetConnections)
+		trackers.POST("/connections", h.trackerConnectionHandler.CreateConnection)
+		trackers.PUT("/connections/:connectionId", h.trackerConnectionHandler.UpdateConnection)
+		trackers.PUT("/connections/:connectionId/credentials", h.trackerConnectionHandler.UpdateCredential)
+		trackers.POST("/connections/:connectionId/test", h.trackerConnectionHandler.TestConnection)
+		trackers.POST("/connections/:connectionId/activate", h.trackerConnectionHandler.ActivateConnection)
+		trackers.POST("/connections/:connectionId/deactivate", h.trackerConnectionHandler.DeactivateConnection)
+		trackers.DELETE("/connections/:connectionId", h.trackerConnectionHandler.DeleteConnection)
+		trackers.POST("/connections/:connectionId/issues", h.trackerConnectionHandler.CreateIssue)
+		trackers.GET("/connections/:connectionId/issues/:issueKey", h.trackerConnectionHandler.GetIssueStatus)
+		trackers.POST("/connections/:connectionId/issues/:issueKey/comments", h.trackerConnectionHandler.AddComment)
 	}
 }
 
@@ -0,0 +1,109 @@
+// Package api provides domain-based REST API handlers
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// FailureExplanationHandler handles on-demand LLM explanations of failures
+type FailureExplanationHandler struct {
+	*BaseHandler
+	explanationService *application.FailureExplanationService
+	projectService     *projectsApp.ProjectService
+}
+
+// NewFailureExplanationHandler creates a new failure explanation handler.
+// A nil service disables the endpoints, which then respond with 503.
+func NewFailureExplanationHandler(explanationService *application.FailureExplanationService, projectService *projectsApp.ProjectService, logger *logging.Logger) *FailureExplanationHandler {
+	return &FailureExplanationHandler{
+		BaseHandler:        NewBaseHandler(logger),
+		explanationService: explanationService,
+		projectService:     projectService,
+	}
+}
+
+// explainSpecRun handles POST /api/v1/spec-runs/:specRunId/explanation
+func (h *FailureExplanationHandler) explainSpecRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("specRunId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid spec run ID"})
+		return
+	}
+	h.explain(c, []uint{uint(id)})
+}
+
+// explainCluster handles POST /api/v1/failures/explanation
+func (h *FailureExplanationHandler) explainCluster(c *gin.Context) {
+	var input struct {
+		SpecRunIDs []uint `json:"specRunIds" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.explain(c, input.SpecRunIDs)
+}
+
+func (h *FailureExplanationHandler) explain(c *gin.Context, specRunIDs []uint) {
+	if h.explanationService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failure explanations are not configured"})
+		return
+	}
+
+	failures, err := h.explanationService.GetFailures(c.Request.Context(), specRunIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Report spec runs of projects the user cannot read as missing, so their
+	// existence is not revealed
+	for _, failure := range failures {
+		allowed, err := h.canAccessProject(c, h.projectService, failure.ProjectID, projectsDomain.PermissionRead)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to check project access")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project access"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Spec run %d not found", failure.SpecRun.ID)})
+			return
+		}
+	}
+
+	explanation, err := h.explanationService.Explain(c.Request.Context(), failures)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to explain failure")
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertExplanationToAPI(specRunIDs, explanation))
+}
+
+func convertExplanationToAPI(specRunIDs []uint, explanation *llm.Explanation) gin.H {
+	return gin.H{
+		"specRunIds":  specRunIDs,
+		"summary":     explanation.Summary,
+		"provider":    explanation.Provider,
+		"model":       explanation.Model,
+		"cached":      explanation.Cached,
+		"truncated":   explanation.Truncated,
+		"generatedAt": explanation.GeneratedAt,
+	}
+}
+
+// RegisterRoutes registers failure explanation routes
+func (h *FailureExplanationHandler) RegisterRoutes(userGroup *gin.RouterGroup) {
+	userGroup.POST("/spec-runs/:specRunId/explanation", h.explainSpecRun)
+	userGroup.POST("/failures/explanation", h.explainCluster)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func (m *MockFlakyTestRepository) FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]domain.SpecExecution, error) {
+	args := m.Called(ctx, projectID, testName, suiteName, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SpecExecution), args.Error(1)
+}
+
+var _ = Describe("FailureExplanationHandler", func() {
+	var (
+		router      *gin.Engine
+		logger      *logging.Logger
+		user        *authDomain.User
+		newRouter   func(service *application.FailureExplanationService) *gin.Engine
+		llmServer   *httptest.Server
+		prompts     []string
+		testRunRepo *MockTestRunRepository
+		suiteRepo   *MockSuiteRunRepository
+		specRunRepo *MockSpecRunRepository
+		flakyRepo   *MockFlakyTestRepository
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		var err error
+		logger, err = logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		prompts = nil
+		llmServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Messages []struct {
+					Content string `json:"content"`
+				} `json:"messages"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			prompts = append(prompts, body.Messages[len(body.Messages)-1].Content)
+			_, _ = w.Write([]byte(`{"model":"llama3","choices":[{"message":{"role":"assistant","content":"The checkout service timed out."}}]}`))
+		}))
+
+		testRunRepo = new(MockTestRunRepository)
+		suiteRepo = new(MockSuiteRunRepository)
+		specRunRepo = new(MockSpecRunRepository)
+		flakyRepo = new(MockFlakyTestRepository)
+
+		testRunRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.TestRun{ID: 1, RunID: "run-1", ProjectID: "project-1"}, nil)
+		suiteRepo.On("GetByID", mock.Anything, uint(10)).Return(&domain.SuiteRun{ID: 10, TestRunID: 1, Name: "Checkout"}, nil)
+		specRunRepo.On("GetByID", mock.Anything, uint(100)).Return(&domain.SpecRun{
+			ID: 100, SuiteRunID: 10, Name: "pays", Status: "failed", ErrorMessage: "timeout contacting ops@example.com",
+		}, nil)
+		flakyRepo.On("FindRecentExecutions", mock.Anything, "project-1", "pays", "Checkout", mock.Anything).
+			Return([]domain.SpecExecution{{SpecRunID: 99, Status: "passed", ExecutedAt: time.Now()}}, nil)
+
+		provider, err := llm.NewOpenAIProvider("local", llmServer.URL, "", "llama3")
+		Expect(err).NotTo(HaveOccurred())
+		explainer := llm.NewExplainer(provider, config.LLMConfig{CacheEnabled: true, CacheTTL: time.Hour, MaxTokens: 1000})
+		service := application.NewFailureExplanationService(testRunRepo, suiteRepo, specRunRepo, flakyRepo, explainer)
+
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.ProjectDetails{},
+			&projectsInfra.ProjectPermissionDB{}, &projectsInfra.ProjectGroupPermissionDB{},
+		)).To(Succeed())
+		projectService := projectsApp.NewProjectService(
+			projectsInfra.NewGormProjectRepository(db),
+			projectsInfra.NewGormProjectPermissionRepository(db),
+		)
+		_, err = projectService.CreateProject(context.Background(), "project-1", "Shop", "team-shop", "admin-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		user = &authDomain.User{UserID: "admin-1", Role: authDomain.RoleAdmin}
+		newRouter = func(service *application.FailureExplanationService) *gin.Engine {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user", user)
+				c.Set("user_id", user.UserID)
+			})
+			NewFailureExplanationHandler(service, projectService, logger).RegisterRoutes(router.Group("/api/v1"))
+			return router
+		}
+		router = newRouter(service)
+	})
+
+	AfterEach(func() {
+		llmServer.Close()
+	})
+
+	It("should explain a spec run using the provider", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/spec-runs/100/explanation", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var response map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		Expect(response["summary"]).To(Equal("The checkout service timed out."))
+		Expect(response["provider"]).To(Equal("local"))
+		Expect(response["cached"]).To(BeFalse())
+
+		Expect(prompts).To(HaveLen(1))
+		Expect(prompts[0]).To(ContainSubstring("Test: pays"))
+		Expect(prompts[0]).NotTo(ContainSubstring("ops@example.com"))
+	})
+
+	It("should serve repeated requests from the cache", func() {
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/failures/explanation", strings.NewReader(`{"specRunIds":[100]}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+		}
+		Expect(prompts).To(HaveLen(1))
+	})
+
+	It("should reject invalid input", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/spec-runs/abc/explanation", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/failures/explanation", strings.NewReader(`{"specRunIds":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should report spec runs of projects the user cannot read as not found", func() {
+		user = &authDomain.User{UserID: "dana", Role: authDomain.RoleUser, Groups: []authDomain.UserGroup{{GroupName: "/contractors"}}}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/failures/explanation", strings.NewReader(`{"specRunIds":[100]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(prompts).To(BeEmpty())
+	})
+
+	It("should respond with 503 when explanations are not configured", func() {
+		router = newRouter(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/spec-runs/100/explanation", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,171 @@
+// Package api provides domain-based REST API handlers
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// maxFlakyListLimit caps the page size of flaky test and failure listings
+const maxFlakyListLimit = 500
+
+// FlakyTestHandler handles flaky test and failure listing endpoints
+type FlakyTestHandler struct {
+	*BaseHandler
+	testingService        *application.TestRunService
+	flakyLifecycleService *application.FlakyLifecycleService
+}
+
+// NewFlakyTestHandler creates a new flaky test handler
+func NewFlakyTestHandler(
+	testingService *application.TestRunService,
+	flakyLifecycleService *application.FlakyLifecycleService,
+	logger *logging.Logger,
+) *FlakyTestHandler {
+	return &FlakyTestHandler{
+		BaseHandler:           NewBaseHandler(logger),
+		testingService:        testingService,
+		flakyLifecycleService: flakyLifecycleService,
+	}
+}
+
+// listFlakyTests handles GET /api/v1/projects/:projectId/flaky-tests
+func (h *FlakyTestHandler) listFlakyTests(c *gin.Context) {
+	filter := domain.FlakyTestFilter{
+		ProjectID: c.Param("projectId"),
+		Owner:     c.Query("owner"),
+		Limit:     queryLimit(c),
+		Offset:    queryInt(c, "offset", 0),
+	}
+	for _, status := range splitQueryList(c.Query("status")) {
+		filter.Statuses = append(filter.Statuses, domain.FlakyStatus(status))
+	}
+	for _, severity := range splitQueryList(c.Query("severity")) {
+		filter.Severities = append(filter.Severities, domain.FlakySeverity(severity))
+	}
+
+	flakyTests, err := h.flakyLifecycleService.ListFlakyTests(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := make([]gin.H, len(flakyTests))
+	for i, flakyTest := range flakyTests {
+		result[i] = h.convertFlakyTestToAPI(flakyTest)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"flakyTests": result,
+		"limit":      filter.Limit,
+		"offset":     filter.Offset,
+	})
+}
+
+// listFailures handles GET /api/v1/projects/:projectId/failures
+func (h *FlakyTestHandler) listFailures(c *gin.Context) {
+	days := queryInt(c, "days", 7)
+	filter := domain.FailureFilter{
+		ProjectID: c.Param("projectId"),
+		Owner:     c.Query("owner"),
+		Since:     time.Now().AddDate(0, 0, -days),
+		Limit:     queryLimit(c),
+		Offset:    queryInt(c, "offset", 0),
+	}
+
+	failures, err := h.testingService.ListFailures(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := make([]gin.H, len(failures))
+	for i, failure := range failures {
+		result[i] = gin.H{
+			"specRunId":    failure.SpecRun.ID,
+			"specName":     failure.SpecRun.Name,
+			"suiteName":    failure.SuiteName,
+			"testRunId":    failure.TestRunID,
+			"branch":       failure.Branch,
+			"errorMessage": failure.SpecRun.ErrorMessage,
+			"owners":       nonNilOwners(failure.SpecRun.Owners),
+			"startTime":    failure.SpecRun.StartTime,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"failures": result,
+		"days":     days,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	})
+}
+
+// convertFlakyTestToAPI converts a domain flaky test to the API representation
+func (h *FlakyTestHandler) convertFlakyTestToAPI(flakyTest *domain.FlakyTest) gin.H {
+	snapshot := flakyTest.ToSnapshot()
+	return gin.H{
+		"id":                snapshot.ID,
+		"projectId":         snapshot.ProjectID,
+		"testName":          snapshot.TestName,
+		"suiteName":         snapshot.SuiteName,
+		"flakeRate":         snapshot.FlakeRate,
+		"totalExecutions":   snapshot.TotalExecutions,
+		"flakyExecutions":   snapshot.FlakyExecutions,
+		"consecutivePasses": snapshot.ConsecutivePasses,
+		"status":            string(snapshot.Status),
+		"severity":          string(snapshot.Severity),
+		"owners":            nonNilOwners(snapshot.Owners),
+		"firstSeenAt":       snapshot.FirstSeenAt,
+		"lastSeenAt":        snapshot.LastSeenAt,
+	}
+}
+
+// RegisterRoutes registers flaky test routes
+func (h *FlakyTestHandler) RegisterRoutes(userGroup *gin.RouterGroup) {
+	userGroup.GET("/projects/:projectId/flaky-tests", h.listFlakyTests)
+	userGroup.GET("/projects/:projectId/failures", h.listFailures)
+}
+
+// queryLimit parses the limit query parameter, capped at maxFlakyListLimit
+func queryLimit(c *gin.Context) int {
+	limit := queryInt(c, "limit", 50)
+	if limit == 0 || limit > maxFlakyListLimit {
+		return maxFlakyListLimit
+	}
+	return limit
+}
+
+// queryInt parses a non-negative integer query parameter, falling back to def
+func queryInt(c *gin.Context, name string, def int) int {
+	value, err := strconv.Atoi(c.Query(name))
+	if err != nil || value < 0 {
+		return def
+	}
+	return value
+}
+
+// splitQueryList splits a comma separated query parameter
+func splitQueryList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func nonNilOwners(owners []string) []string {
+	if owners == nil {
+		return []string{}
+	}
+	return owners
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFlakyTestRepository provides a mock implementation of FlakyTestRepository
+type MockFlakyTestRepository struct {
+	mock.Mock
+	domain.FlakyTestRepository
+}
+
+func (m *MockFlakyTestRepository) FindByFilter(ctx context.Context, filter domain.FlakyTestFilter) ([]*domain.FlakyTest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlakyTest), args.Error(1)
+}
+
+var _ = Describe("FlakyTestHandler", func() {
+	var (
+		router      *gin.Engine
+		flakyRepo   *MockFlakyTestRepository
+		specRunRepo *MockSpecRunRepository
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		flakyRepo = new(MockFlakyTestRepository)
+		specRunRepo = new(MockSpecRunRepository)
+		testingService := application.NewTestRunService(new(MockTestRunRepository), new(MockSuiteRunRepository), specRunRepo)
+		lifecycleService := application.NewFlakyLifecycleService(flakyRepo, nil)
+
+		router = gin.New()
+		NewFlakyTestHandler(testingService, lifecycleService, logger).RegisterRoutes(router.Group("/api/v1"))
+	})
+
+	Describe("listFlakyTests", func() {
+		It("should filter flaky tests by owner and status", func() {
+			flakyTest := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+				ID:        5,
+				ProjectID: "project-1",
+				TestName:  "pays",
+				SuiteName: "Checkout",
+				Status:    domain.FlakyStatusActive,
+				Owners:    []string{"@payments"},
+			})
+			flakyRepo.On("FindByFilter", mock.Anything, domain.FlakyTestFilter{
+				ProjectID: "project-1",
+				Owner:     "@payments",
+				Statuses:  []domain.FlakyStatus{domain.FlakyStatusActive},
+				Limit:     50,
+			}).Return([]*domain.FlakyTest{flakyTest}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/project-1/flaky-tests?owner=@payments&status=active", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var response struct {
+				FlakyTests []struct {
+					TestName string   `json:"testName"`
+					Owners   []string `json:"owners"`
+				} `json:"flakyTests"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.FlakyTests).To(HaveLen(1))
+			Expect(response.FlakyTests[0].Owners).To(Equal([]string{"@payments"}))
+			flakyRepo.AssertExpectations(GinkgoT())
+		})
+	})
+
+	Describe("listFailures", func() {
+		It("should filter failures by owner within the requested window", func() {
+			specRunRepo.On("FindFailures", mock.Anything, mock.MatchedBy(func(filter domain.FailureFilter) bool {
+				return filter.ProjectID == "project-1" && filter.Owner == "@payments" && filter.Limit == 10 && !filter.Since.IsZero()
+			})).Return([]*domain.SpecFailure{{
+				SpecRun:   &domain.SpecRun{ID: 9, Name: "pays", Status: "failed", Owners: []string{"@payments"}},
+				SuiteName: "Checkout",
+				TestRunID: "run-1",
+				Branch:    "main",
+			}}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/project-1/failures?owner=@payments&days=3&limit=10", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var response struct {
+				Failures []struct {
+					SpecName  string   `json:"specName"`
+					SuiteName string   `json:"suiteName"`
+					Owners    []string `json:"owners"`
+				} `json:"failures"`
+				Days int `json:"days"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Days).To(Equal(3))
+			Expect(response.Failures).To(HaveLen(1))
+			Expect(response.Failures[0].SuiteName).To(Equal("Checkout"))
+			specRunRepo.AssertExpectations(GinkgoT())
+		})
+	})
+})
@@ -3,35 +3,38 @@ package api
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
 	*BaseHandler
+	checks *health.Registry
 }
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler(logger *logging.Logger) *HealthHandler {
+func NewHealthHandler(checks *health.Registry, logger *logging.Logger) *HealthHandler {
 	return &HealthHandler{
 		BaseHandler: NewBaseHandler(logger),
+		checks:      checks,
 	}
 }
 
-// healthCheck handles GET /api/v1/health
+// healthCheck handles GET /api/v1/health with the readiness of the instance
 func (h *HealthHandler) healthCheck(c *gin.Context) {
-	h.respondWithJSON(c, http.StatusOK, gin.H{
-		"status":    "healthy",
-		"timestamp": time.Now().Unix(),
-		"service":   "fern-platform",
-		"version":   "1.0.0", // TODO: Get from build info
-	})
+	report := h.checks.Ready(c.Request.Context())
+
+	code := http.StatusOK
+	if report.Status == health.StatusUnhealthy {
+		code = http.StatusServiceUnavailable
+	}
+	h.respondWithJSON(c, code, report.WithoutErrors())
 }
 
 // RegisterRoutes registers health routes
 func (h *HealthHandler) RegisterRoutes(publicGroup *gin.RouterGroup) {
 	publicGroup.GET("/health", h.healthCheck)
-}
\ No newline at end of file
+}
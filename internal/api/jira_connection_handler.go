@@ -1,7 +1,10 @@
 package api
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -37,7 +40,7 @@ type CreateJiraConnectionRequest struct {
 	AuthenticationType string `json:"authenticationType" binding:"required"`
 	ProjectKey         string `json:"projectKey" binding:"required"`
 	Username           string `json:"username"`
-	Credential         string `json:"credential" binding:"required"`
+	Credential         string `json:"credential"` // Not used with OAuth, which is authorized afterwards
 }
 
 // UpdateJiraConnectionRequest represents the request to update a JIRA connection
@@ -51,7 +54,7 @@ type UpdateJiraConnectionRequest struct {
 type UpdateJiraCredentialsRequest struct {
 	AuthenticationType string `json:"authenticationType" binding:"required"`
 	Username           string `json:"username"`
-	Credential         string `json:"credential" binding:"required"`
+	Credential         string `json:"credential"` // Not used with OAuth, which is authorized afterwards
 }
 
 // JiraConnectionResponse represents a JIRA connection response
@@ -64,12 +67,93 @@ type JiraConnectionResponse struct {
 	ProjectKey         string  `json:"projectKey"`
 	Username           string  `json:"username"`
 	Status             string  `json:"status"`
+	StatusReason       string  `json:"statusReason,omitempty"`
 	IsActive           bool    `json:"isActive"`
+	OAuthAuthorized    bool    `json:"oauthAuthorized"`
+	TokenExpiresAt     *string `json:"tokenExpiresAt,omitempty"`
 	LastTestedAt       *string `json:"lastTestedAt,omitempty"`
 	CreatedAt          string  `json:"createdAt"`
 	UpdatedAt          string  `json:"updatedAt"`
 }
 
+// CreateJiraIssueRequest represents the request to file a JIRA issue from test results
+type CreateJiraIssueRequest struct {
+	SourceType  string `json:"sourceType" binding:"required"`
+	SpecRunIDs  []uint `json:"specRunIds"`
+	FlakyTestID uint   `json:"flakyTestId"`
+}
+
+// JiraIssueLinkResponse represents a JIRA issue filed for a failure or flaky test
+type JiraIssueLinkResponse struct {
+	ID           uint   `json:"id"`
+	ConnectionID string `json:"connectionId"`
+	IssueKey     string `json:"issueKey"`
+	IssueURL     string `json:"issueUrl"`
+	SourceType   string `json:"sourceType"`
+	TargetType   string `json:"targetType"`
+	TargetID     string `json:"targetId"`
+	TestName     string `json:"testName"`
+	SuiteName    string `json:"suiteName"`
+	CreatedBy    string `json:"createdBy"`
+	CreatedAt    string `json:"createdAt"`
+
+	// Issue state as of the last sync
+	IssueStatus         string  `json:"issueStatus,omitempty"`
+	IssueStatusCategory string  `json:"issueStatusCategory,omitempty"`
+	SyncedAt            *string `json:"syncedAt,omitempty"`
+}
+
+// JiraIssueResponse represents the result of filing a JIRA issue
+type JiraIssueResponse struct {
+	IssueKey string                  `json:"issueKey"`
+	IssueURL string                  `json:"issueUrl"`
+	Created  bool                    `json:"created"`
+	Links    []JiraIssueLinkResponse `json:"links"`
+}
+
+// JiraFieldResponse represents a field of a JIRA instance
+type JiraFieldResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Custom      bool   `json:"custom"`
+	SchemaType  string `json:"schemaType"`
+	SchemaItems string `json:"schemaItems,omitempty"`
+}
+
+// JiraIssueTypeResponse represents an issue type of a JIRA instance
+type JiraIssueTypeResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IconURL     string `json:"iconUrl"`
+	Subtask     bool   `json:"subtask"`
+}
+
+// FernFieldResponse represents a Fern field available for mapping
+type FernFieldResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// UpdateJiraFieldMappingRequest represents the request to update a field mapping
+type UpdateJiraFieldMappingRequest struct {
+	IssueType string            `json:"issueType" binding:"required"`
+	Fields    map[string]string `json:"fields"`
+}
+
+// JiraFieldMappingResponse represents the field mapping of a JIRA connection
+type JiraFieldMappingResponse struct {
+	ConnectionID string              `json:"connectionId"`
+	IssueType    string              `json:"issueType"`
+	Fields       map[string]string   `json:"fields"`
+	FernFields   []FernFieldResponse `json:"fernFields"`
+	IsDefault    bool                `json:"isDefault"`
+	UpdatedBy    string              `json:"updatedBy,omitempty"`
+	UpdatedAt    *string             `json:"updatedAt,omitempty"`
+}
+
 // CreateConnection creates a new JIRA connection
 func (h *JiraConnectionHandler) CreateConnection(c *gin.Context) {
 	projectID := c.Param("projectId")
@@ -411,6 +495,11 @@ func (h *JiraConnectionHandler) convertToResponse(conn *integrations.JiraConnect
 		formatted := snapshot.LastTestedAt.Format(time.RFC3339)
 		lastTested = &formatted
 	}
+	var tokenExpiresAt *string
+	if snapshot.TokenExpiresAt != nil {
+		formatted := snapshot.TokenExpiresAt.Format(time.RFC3339)
+		tokenExpiresAt = &formatted
+	}
 	
 	return &JiraConnectionResponse{
 		ID:                 snapshot.ID,
@@ -421,9 +510,381 @@ func (h *JiraConnectionHandler) convertToResponse(conn *integrations.JiraConnect
 		ProjectKey:         snapshot.ProjectKey,
 		Username:           snapshot.Username,
 		Status:             string(snapshot.Status),
+		StatusReason:       snapshot.StatusReason,
 		IsActive:           snapshot.IsActive,
+		OAuthAuthorized:    conn.IsOAuthAuthorized(),
+		TokenExpiresAt:     tokenExpiresAt,
 		LastTestedAt:       lastTested,
 		CreatedAt:          snapshot.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:          snapshot.UpdatedAt.Format(time.RFC3339),
 	}
-}
\ No newline at end of file
+}
+
+// CreateIssue files a JIRA issue for a spec run, failure cluster or flaky test
+// in the project. Failures and flaky tests that were already filed return the
+// existing issue with status 200 instead of filing it again.
+func (h *JiraConnectionHandler) CreateIssue(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
+
+	var req CreateJiraIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	source, err := h.jiraService.LoadIssueSource(c.Request.Context(), integrations.IssueSourceRequest{
+		Type:        integrations.IssueSourceType(req.SourceType),
+		SpecRunIDs:  req.SpecRunIDs,
+		FlakyTestID: req.FlakyTestID,
+	})
+	if errors.Is(err, integrations.ErrIssueCreationNotConfigured) {
+		h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if source.ProjectID != projectID {
+		h.ErrorResponse(c, http.StatusNotFound, "test results not found in project")
+		return
+	}
+
+	creation, err := h.jiraService.CreateIssue(c.Request.Context(), source, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, integrations.ErrIssueCreationNotConfigured):
+			h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, integrations.ErrNoActiveJiraConnection):
+			h.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.WithError(err).Warn("Failed to create JIRA issue")
+			h.ErrorResponse(c, http.StatusBadGateway, err.Error())
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if creation.Created {
+		status = http.StatusCreated
+	}
+	h.respondWithJSON(c, status, JiraIssueResponse{
+		IssueKey: creation.IssueKey,
+		IssueURL: creation.IssueURL,
+		Created:  creation.Created,
+		Links:    h.convertIssueLinksToResponse(creation.Links),
+	})
+}
+
+// GetIssues retrieves the JIRA issues filed for a project
+func (h *JiraConnectionHandler) GetIssues(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionRead) {
+		return
+	}
+
+	links, err := h.jiraService.GetIssueLinks(c.Request.Context(), projectID)
+	if errors.Is(err, integrations.ErrIssueCreationNotConfigured) {
+		h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertIssueLinksToResponse(links))
+}
+
+// GetFields lists the fields of the connection's JIRA instance. Fields are
+// cached per connection; pass refresh=true to fetch them again.
+func (h *JiraConnectionHandler) GetFields(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	fields, err := h.jiraService.GetJiraFields(c.Request.Context(), connection.ID(), c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get JIRA fields")
+		h.ErrorResponse(c, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	response := make([]JiraFieldResponse, len(fields))
+	for i, field := range fields {
+		response[i] = JiraFieldResponse{
+			ID:          field.ID,
+			Name:        field.Name,
+			Custom:      field.Custom,
+			SchemaType:  field.SchemaType,
+			SchemaItems: field.SchemaItems,
+		}
+	}
+	h.respondWithJSON(c, http.StatusOK, response)
+}
+
+// GetIssueTypes lists the issue types of the connection's JIRA instance.
+// Issue types are cached per connection; pass refresh=true to fetch them again.
+func (h *JiraConnectionHandler) GetIssueTypes(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	issueTypes, err := h.jiraService.GetJiraIssueTypes(c.Request.Context(), connection.ID(), c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get JIRA issue types")
+		h.ErrorResponse(c, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	response := make([]JiraIssueTypeResponse, len(issueTypes))
+	for i, issueType := range issueTypes {
+		response[i] = JiraIssueTypeResponse{
+			ID:          issueType.ID,
+			Name:        issueType.Name,
+			Description: issueType.Description,
+			IconURL:     issueType.IconURL,
+			Subtask:     issueType.Subtask,
+		}
+	}
+	h.respondWithJSON(c, http.StatusOK, response)
+}
+
+// GetFieldMapping retrieves the field mapping of a connection
+func (h *JiraConnectionHandler) GetFieldMapping(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionRead)
+	if !ok {
+		return
+	}
+
+	mapping, err := h.jiraService.GetFieldMapping(c.Request.Context(), connection.ID())
+	if errors.Is(err, integrations.ErrFieldMappingNotConfigured) {
+		h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertFieldMappingToResponse(mapping))
+}
+
+// UpdateFieldMapping validates and saves the field mapping of a connection
+func (h *JiraConnectionHandler) UpdateFieldMapping(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	var req UpdateJiraFieldMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fields := make(map[integrations.FernField]string, len(req.Fields))
+	for fernField, jiraFieldID := range req.Fields {
+		fields[integrations.FernField(fernField)] = jiraFieldID
+	}
+
+	mapping, err := h.jiraService.UpdateFieldMapping(c.Request.Context(), connection.ID(), req.IssueType, fields, h.getUserID(c))
+	if err != nil {
+		var validationErr *integrations.FieldMappingValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			h.respondWithJSON(c, http.StatusBadRequest, gin.H{"error": validationErr.Error(), "problems": validationErr.Problems})
+		case errors.Is(err, integrations.ErrFieldMappingNotConfigured):
+			h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		default:
+			h.logger.WithError(err).Warn("Failed to update JIRA field mapping")
+			h.ErrorResponse(c, http.StatusBadGateway, err.Error())
+		}
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertFieldMappingToResponse(mapping))
+}
+
+// AuthorizeOAuth redirects the user to the Atlassian consent screen to
+// authorize an OAuth connection. returnTo is an optional local path the user
+// is sent back to once the callback completes.
+func (h *JiraConnectionHandler) AuthorizeOAuth(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	authorizationURL, err := h.jiraService.StartOAuthAuthorization(
+		c.Request.Context(),
+		connection.ID(),
+		h.getUserID(c),
+		localRedirectPath(c.Query("returnTo")),
+	)
+	if err != nil {
+		if errors.Is(err, integrations.ErrJiraOAuthNotConfigured) {
+			h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusFound, authorizationURL)
+}
+
+// OAuthCallback completes an authorization started by AuthorizeOAuth and
+// sends the user back with jiraOAuth=connected or jiraOAuth=failed. The
+// reason of a failure is recorded on the connection.
+func (h *JiraConnectionHandler) OAuthCallback(c *gin.Context) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var completion *integrations.JiraOAuthCompletion
+	var err error
+	if reason := c.Query("error"); reason != "" {
+		if description := c.Query("error_description"); description != "" {
+			reason = reason + ": " + description
+		}
+		completion, err = h.jiraService.RejectOAuthAuthorization(c.Request.Context(), c.Query("state"), userID, reason)
+	} else {
+		completion, err = h.jiraService.CompleteOAuthAuthorization(c.Request.Context(), c.Query("state"), userID, c.Query("code"))
+	}
+
+	if completion == nil {
+		switch {
+		case errors.Is(err, integrations.ErrJiraOAuthNotConfigured):
+			h.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, integrations.ErrInvalidOAuthState):
+			h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			h.ErrorResponse(c, http.StatusNotFound, "connection not found")
+		}
+		return
+	}
+
+	result := "connected"
+	if err != nil {
+		h.logger.WithError(err).Warn("JIRA OAuth authorization failed")
+		result = "failed"
+	}
+
+	returnTo, err := url.Parse(completion.ReturnTo)
+	if err != nil || completion.ReturnTo == "" {
+		returnTo = &url.URL{Path: "/"}
+	}
+	query := returnTo.Query()
+	query.Set("jiraOAuth", result)
+	query.Set("connectionId", completion.Connection.ID())
+	returnTo.RawQuery = query.Encode()
+
+	c.Redirect(http.StatusFound, returnTo.String())
+}
+
+// localRedirectPath returns path when it is a path on this host, so it is
+// safe to redirect to, and "" otherwise
+func localRedirectPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return ""
+	}
+	return path
+}
+
+// authorizeConnection loads the connection named in the path and checks that
+// it belongs to the project and that the user has at least the required
+// permission on the project. It writes an error response and returns false otherwise.
+func (h *JiraConnectionHandler) authorizeConnection(c *gin.Context, required projectsDomain.PermissionType) (*integrations.JiraConnection, bool) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	connection, err := h.jiraService.GetConnection(c.Request.Context(), c.Param("connectionId"))
+	if err != nil || connection.ProjectID() != c.Param("projectId") {
+		h.ErrorResponse(c, http.StatusNotFound, "connection not found")
+		return nil, false
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, connection.ProjectID(), required) {
+		return nil, false
+	}
+	return connection, true
+}
+
+// convertIssueLinksToResponse converts issue links to response format
+func (h *JiraConnectionHandler) convertIssueLinksToResponse(links []*integrations.JiraIssueLink) []JiraIssueLinkResponse {
+	responses := make([]JiraIssueLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = JiraIssueLinkResponse{
+			ID:           link.ID,
+			ConnectionID: link.ConnectionID,
+			IssueKey:     link.IssueKey,
+			IssueURL:     link.IssueURL,
+			SourceType:   string(link.SourceType),
+			TargetType:   string(link.Target.Type),
+			TargetID:     link.Target.ID,
+			TestName:     link.Target.TestName,
+			SuiteName:    link.Target.SuiteName,
+			CreatedBy:    link.CreatedBy,
+			CreatedAt:    link.CreatedAt.Format(time.RFC3339),
+
+			IssueStatus:         link.IssueStatus,
+			IssueStatusCategory: link.IssueStatusCategory,
+		}
+		if link.SyncedAt != nil {
+			syncedAt := link.SyncedAt.Format(time.RFC3339)
+			responses[i].SyncedAt = &syncedAt
+		}
+	}
+	return responses
+}
+
+// convertFieldMappingToResponse converts a field mapping to response format
+func (h *JiraConnectionHandler) convertFieldMappingToResponse(mapping *integrations.JiraFieldMapping) JiraFieldMappingResponse {
+	response := JiraFieldMappingResponse{
+		ConnectionID: mapping.ConnectionID,
+		IssueType:    mapping.IssueType,
+		Fields:       make(map[string]string, len(mapping.Fields)),
+		IsDefault:    mapping.IsDefault,
+		UpdatedBy:    mapping.UpdatedBy,
+	}
+	for fernField, jiraFieldID := range mapping.Fields {
+		response.Fields[string(fernField)] = jiraFieldID
+	}
+	for _, field := range integrations.FernFields() {
+		response.FernFields = append(response.FernFields, FernFieldResponse{
+			ID:          string(field.Field),
+			Name:        field.Name,
+			Description: field.Description,
+			Required:    field.Required,
+		})
+	}
+	if !mapping.UpdatedAt.IsZero() {
+		updatedAt := mapping.UpdatedAt.Format(time.RFC3339)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
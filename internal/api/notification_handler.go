@@ -0,0 +1,434 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+const (
+	// defaultNotificationDeliveryLimit is how many notifications the notification log returns by default
+	defaultNotificationDeliveryLimit = 50
+
+	// maxNotificationDeliveryLimit caps how many notifications the notification log returns
+	maxNotificationDeliveryLimit = 200
+)
+
+// NotificationHandler handles the Slack and Microsoft Teams notification
+// rules of a project, which are managed by project managers
+type NotificationHandler struct {
+	*BaseHandler
+	notificationService *integrations.NotificationService
+	projectService      *projectsApp.ProjectService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(
+	baseHandler *BaseHandler,
+	notificationService *integrations.NotificationService,
+	projectService *projectsApp.ProjectService,
+) *NotificationHandler {
+	return &NotificationHandler{
+		BaseHandler:         baseHandler,
+		notificationService: notificationService,
+		projectService:      projectService,
+	}
+}
+
+// NotificationFilterBody represents the filter of a notification rule
+type NotificationFilterBody struct {
+	Branches     []string `json:"branches,omitempty"`
+	Environments []string `json:"environments,omitempty"`
+	Statuses     []string `json:"statuses,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Severities   []string `json:"severities,omitempty"`
+}
+
+// QuietHoursBody represents the quiet hours of a notification rule
+type QuietHoursBody struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// NotificationRuleRequest represents the request to create or update a
+// notification rule. The webhook URL may be left out of updates to keep the
+// current one; a missing deduplication window uses the default of one hour.
+type NotificationRuleRequest struct {
+	Name               string                 `json:"name" binding:"required"`
+	ChannelType        string                 `json:"channelType" binding:"required"`
+	WebhookURL         string                 `json:"webhookUrl"`
+	Events             []string               `json:"events" binding:"required"`
+	Filter             NotificationFilterBody `json:"filter"`
+	QuietHours         *QuietHoursBody        `json:"quietHours,omitempty"`
+	DedupWindowMinutes *int                   `json:"dedupWindowMinutes,omitempty"`
+	TitleTemplate      string                 `json:"titleTemplate,omitempty"`
+	TextTemplate       string                 `json:"textTemplate,omitempty"`
+}
+
+// NotificationRuleResponse represents a notification rule. The webhook URL
+// is a credential and is never returned.
+type NotificationRuleResponse struct {
+	ID                 string                 `json:"id"`
+	ProjectID          string                 `json:"projectId"`
+	Name               string                 `json:"name"`
+	ChannelType        string                 `json:"channelType"`
+	Events             []string               `json:"events"`
+	Filter             NotificationFilterBody `json:"filter"`
+	QuietHours         *QuietHoursBody        `json:"quietHours,omitempty"`
+	DedupWindowMinutes int                    `json:"dedupWindowMinutes"`
+	TitleTemplate      string                 `json:"titleTemplate,omitempty"`
+	TextTemplate       string                 `json:"textTemplate,omitempty"`
+	IsActive           bool                   `json:"isActive"`
+	CreatedBy          string                 `json:"createdBy"`
+	CreatedAt          string                 `json:"createdAt"`
+	UpdatedAt          string                 `json:"updatedAt"`
+}
+
+// NotificationDeliveryResponse represents an entry of the notification log
+type NotificationDeliveryResponse struct {
+	ID             string  `json:"id"`
+	RuleID         string  `json:"ruleId"`
+	EventType      string  `json:"eventType"`
+	Title          string  `json:"title"`
+	Payload        string  `json:"payload"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *string `json:"lastAttemptAt,omitempty"`
+	ResponseStatus int     `json:"responseStatus,omitempty"`
+	LastError      string  `json:"lastError,omitempty"`
+	HeldUntil      *string `json:"heldUntil,omitempty"`
+	SuppressedBy   string  `json:"suppressedBy,omitempty"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+// RegisterRoutes registers notification rule routes for managers
+func (h *NotificationHandler) RegisterRoutes(managerGroup *gin.RouterGroup) {
+	rules := managerGroup.Group("/projects/:projectId/notifications/rules")
+	rules.GET("", h.GetRules)
+	rules.POST("", h.CreateRule)
+	rules.GET("/:ruleId", h.GetRule)
+	rules.PUT("/:ruleId", h.UpdateRule)
+	rules.DELETE("/:ruleId", h.DeleteRule)
+	rules.POST("/:ruleId/activate", h.ActivateRule)
+	rules.POST("/:ruleId/deactivate", h.DeactivateRule)
+	rules.POST("/:ruleId/test", h.TestRule)
+	rules.GET("/:ruleId/deliveries", h.GetDeliveries)
+}
+
+// GetRules lists the notification rules of a project
+func (h *NotificationHandler) GetRules(c *gin.Context) {
+	if _, ok := h.authorizeProject(c); !ok {
+		return
+	}
+
+	rules, err := h.notificationService.ListRules(c.Request.Context(), c.Param("projectId"))
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]NotificationRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = h.convertToResponse(rule)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// CreateRule creates a notification rule
+func (h *NotificationHandler) CreateRule(c *gin.Context) {
+	userID, ok := h.authorizeProject(c)
+	if !ok {
+		return
+	}
+
+	var req NotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.WebhookURL == "" {
+		h.ErrorResponse(c, http.StatusBadRequest, "webhookUrl is required")
+		return
+	}
+
+	rule, err := h.notificationService.CreateRule(c.Request.Context(), c.Param("projectId"), req.settings(), req.WebhookURL, userID)
+	if err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, h.convertToResponse(rule))
+}
+
+// GetRule retrieves a notification rule
+func (h *NotificationHandler) GetRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(rule))
+}
+
+// UpdateRule replaces the settings of a notification rule
+func (h *NotificationHandler) UpdateRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	var req NotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.notificationService.UpdateRule(c.Request.Context(), rule.ID(), req.settings(), req.WebhookURL)
+	if err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DeleteRule deletes a notification rule along with its notification log
+func (h *NotificationHandler) DeleteRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.DeleteRule(c.Request.Context(), rule.ID()); err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusNoContent, nil)
+}
+
+// ActivateRule resumes posting a rule's notifications
+func (h *NotificationHandler) ActivateRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.notificationService.ActivateRule(c.Request.Context(), rule.ID())
+	if err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DeactivateRule stops posting a rule's notifications
+func (h *NotificationHandler) DeactivateRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.notificationService.DeactivateRule(c.Request.Context(), rule.ID())
+	if err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// TestRule posts a sample message to a rule's channel right away
+func (h *NotificationHandler) TestRule(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.SendTestNotification(c.Request.Context(), rule.ID()); err != nil {
+		h.ErrorResponse(c, notificationErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, gin.H{"status": "sent"})
+}
+
+// GetDeliveries retrieves the notification log of a rule, newest first
+func (h *NotificationHandler) GetDeliveries(c *gin.Context) {
+	rule, ok := h.authorizeRule(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultNotificationDeliveryLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.ErrorResponse(c, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = min(parsed, maxNotificationDeliveryLimit)
+	}
+
+	deliveries, err := h.notificationService.ListDeliveries(c.Request.Context(), rule.ID(), limit)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]NotificationDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = h.convertDeliveryToResponse(delivery)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// authorizeProject checks that the user can manage the project in the path.
+// It writes an error response and returns false otherwise.
+func (h *NotificationHandler) authorizeProject(c *gin.Context) (string, bool) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return "", false
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, c.Param("projectId"), projectsDomain.PermissionWrite) {
+		return "", false
+	}
+	return userID, true
+}
+
+// authorizeRule loads the rule named in the path and checks that it belongs
+// to the project in the path and that the user can manage it. It writes an
+// error response and returns false otherwise.
+func (h *NotificationHandler) authorizeRule(c *gin.Context) (*integrations.NotificationRule, bool) {
+	if _, ok := h.authorizeProject(c); !ok {
+		return nil, false
+	}
+
+	rule, err := h.notificationService.GetRule(c.Request.Context(), c.Param("ruleId"))
+	if err != nil || rule.ProjectID() != c.Param("projectId") {
+		h.ErrorResponse(c, http.StatusNotFound, "notification rule not found")
+		return nil, false
+	}
+	return rule, true
+}
+
+// notificationErrorStatus maps notification errors to HTTP status codes
+func notificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, integrations.ErrInvalidNotificationRule):
+		return http.StatusBadRequest
+	case errors.Is(err, integrations.ErrNotificationRuleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, integrations.ErrNotificationDeliveryFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// settings converts the request to rule settings
+func (r NotificationRuleRequest) settings() integrations.NotificationRuleSettings {
+	dedupWindow := integrations.DefaultNotificationDedupWindow
+	if r.DedupWindowMinutes != nil {
+		dedupWindow = time.Duration(*r.DedupWindowMinutes) * time.Minute
+	}
+
+	settings := integrations.NotificationRuleSettings{
+		Name:        r.Name,
+		ChannelType: integrations.NotificationChannelType(r.ChannelType),
+		Events:      toWebhookEventTypes(r.Events),
+		Filter: integrations.NotificationFilter{
+			Branches:     r.Filter.Branches,
+			Environments: r.Filter.Environments,
+			Statuses:     r.Filter.Statuses,
+			Tags:         r.Filter.Tags,
+			Severities:   r.Filter.Severities,
+		},
+		DedupWindow:   dedupWindow,
+		TitleTemplate: r.TitleTemplate,
+		TextTemplate:  r.TextTemplate,
+	}
+	if r.QuietHours != nil {
+		settings.QuietHours = &integrations.QuietHours{
+			Start:    r.QuietHours.Start,
+			End:      r.QuietHours.End,
+			TimeZone: r.QuietHours.TimeZone,
+		}
+	}
+	return settings
+}
+
+// convertToResponse converts a domain entity to response format
+func (h *NotificationHandler) convertToResponse(rule *integrations.NotificationRule) NotificationRuleResponse {
+	snapshot := rule.Snapshot()
+	settings := snapshot.Settings
+
+	events := make([]string, len(settings.Events))
+	for i, event := range settings.Events {
+		events[i] = string(event)
+	}
+
+	response := NotificationRuleResponse{
+		ID:          snapshot.ID,
+		ProjectID:   snapshot.ProjectID,
+		Name:        settings.Name,
+		ChannelType: string(settings.ChannelType),
+		Events:      events,
+		Filter: NotificationFilterBody{
+			Branches:     settings.Filter.Branches,
+			Environments: settings.Filter.Environments,
+			Statuses:     settings.Filter.Statuses,
+			Tags:         settings.Filter.Tags,
+			Severities:   settings.Filter.Severities,
+		},
+		DedupWindowMinutes: int(settings.DedupWindow / time.Minute),
+		TitleTemplate:      settings.TitleTemplate,
+		TextTemplate:       settings.TextTemplate,
+		IsActive:           snapshot.IsActive,
+		CreatedBy:          snapshot.CreatedBy,
+		CreatedAt:          snapshot.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          snapshot.UpdatedAt.Format(time.RFC3339),
+	}
+	if settings.QuietHours != nil {
+		response.QuietHours = &QuietHoursBody{
+			Start:    settings.QuietHours.Start,
+			End:      settings.QuietHours.End,
+			TimeZone: settings.QuietHours.TimeZone,
+		}
+	}
+	return response
+}
+
+// convertDeliveryToResponse converts a notification to response format
+func (h *NotificationHandler) convertDeliveryToResponse(delivery *integrations.NotificationDelivery) NotificationDeliveryResponse {
+	response := NotificationDeliveryResponse{
+		ID:             delivery.ID,
+		RuleID:         delivery.RuleID,
+		EventType:      string(delivery.EventType),
+		Title:          delivery.Title,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  formatOptionalTime(delivery.LastAttemptAt),
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		HeldUntil:      formatOptionalTime(delivery.HeldUntil),
+		SuppressedBy:   delivery.SuppressedBy,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == integrations.NotificationPending {
+		response.NextAttemptAt = formatOptionalTime(&delivery.NextAttemptAt)
+	}
+	return response
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var _ = Describe("ProjectHandler project access", func() {
+	var (
+		handler *ProjectHandler
+		admin   *authDomain.User
+		dana    *authDomain.User
+	)
+
+	// request serves a request on behalf of the user
+	request := func(user *authDomain.User, method, path, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user", user)
+			c.Set("user_id", user.UserID)
+		})
+		v1 := router.Group("/api/v1")
+		handler.RegisterRoutes(v1, v1, v1.Group("/admin"))
+
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.ProjectDetails{},
+			&projectsInfra.ProjectPermissionDB{}, &projectsInfra.ProjectGroupPermissionDB{},
+		)).To(Succeed())
+
+		projectService := projectsApp.NewProjectService(
+			projectsInfra.NewGormProjectRepository(db),
+			projectsInfra.NewGormProjectPermissionRepository(db),
+		)
+		_, err = projectService.CreateProject(context.Background(), "shop", "Shop", "team-shop", "admin-1")
+		Expect(err).NotTo(HaveOccurred())
+		handler = NewProjectHandler(projectService, logger)
+
+		admin = &authDomain.User{UserID: "admin-1", Role: authDomain.RoleAdmin}
+		dana = &authDomain.User{UserID: "dana", Role: authDomain.RoleUser, Groups: []authDomain.UserGroup{{GroupName: "/contractors"}}}
+	})
+
+	It("should grant, list and revoke access for users", func() {
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+		recorder := request(admin, http.MethodPost, "/api/v1/admin/projects/shop/users/dana/access",
+			`{"permission":"write","expiresAt":"`+expiresAt+`"}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		recorder = request(admin, http.MethodGet, "/api/v1/admin/projects/shop/users", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		var body struct {
+			Users []ProjectAccessResponse `json:"users"`
+		}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Users).To(ContainElement(SatisfyAll(
+			HaveField("UserID", "dana"),
+			HaveField("Permission", "write"),
+			HaveField("GrantedBy", "admin-1"),
+			HaveField("ExpiresAt", HaveValue(Equal(expiresAt))),
+		)))
+
+		Expect(request(admin, http.MethodDelete, "/api/v1/admin/projects/shop/users/dana/access?permission=write", "").Code).To(Equal(http.StatusOK))
+		Expect(request(admin, http.MethodDelete, "/api/v1/admin/projects/shop/users/dana/access", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should grant and list access for groups", func() {
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/shop/groups/contractors/access", `{"permission":"read"}`).Code).To(Equal(http.StatusOK))
+
+		recorder := request(admin, http.MethodGet, "/api/v1/admin/projects/shop/groups", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(ContainSubstring(`"groupName":"contractors"`))
+	})
+
+	It("should validate grants", func() {
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/shop/users/dana/access", `{"permission":"owner"}`).Code).To(Equal(http.StatusBadRequest))
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/shop/users/dana/access", `{"permission":"read","expiresAt":"2001-01-01T00:00:00Z"}`).Code).To(Equal(http.StatusBadRequest))
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/missing/users/dana/access", `{"permission":"read"}`).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should enforce granted permissions on project changes", func() {
+		ownership := `{"rules":"* @qa"}`
+		Expect(request(dana, http.MethodPut, "/api/v1/projects/shop/ownership", ownership).Code).To(Equal(http.StatusForbidden))
+
+		// Read access does not allow changes
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/shop/groups/contractors/access", `{"permission":"read"}`).Code).To(Equal(http.StatusOK))
+		Expect(request(dana, http.MethodPut, "/api/v1/projects/shop/ownership", ownership).Code).To(Equal(http.StatusForbidden))
+
+		Expect(request(admin, http.MethodPost, "/api/v1/admin/projects/shop/groups/contractors/access", `{"permission":"write"}`).Code).To(Equal(http.StatusOK))
+		Expect(request(dana, http.MethodPut, "/api/v1/projects/shop/ownership", ownership).Code).To(Equal(http.StatusOK))
+		Expect(request(dana, http.MethodDelete, "/api/v1/projects/shop", "").Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should let managers of the project's team change it", func() {
+		manager := &authDomain.User{UserID: "mia", Role: authDomain.RoleUser, Groups: []authDomain.UserGroup{{GroupName: "team-shop-managers"}}}
+		other := &authDomain.User{UserID: "omar", Role: authDomain.RoleUser, Groups: []authDomain.UserGroup{{GroupName: "team-search-managers"}}}
+
+		Expect(request(manager, http.MethodPut, "/api/v1/projects/shop/jira-sync", `{"reflakeAction":"reopen"}`).Code).To(Equal(http.StatusOK))
+		Expect(request(other, http.MethodPut, "/api/v1/projects/shop/jira-sync", `{"reflakeAction":"reopen"}`).Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -2,8 +2,12 @@
 package api
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -128,6 +132,9 @@ func (h *ProjectHandler) getProjectByProjectID(c *gin.Context) {
 // updateProject handles PUT /api/v1/projects/:projectId
 func (h *ProjectHandler) updateProject(c *gin.Context) {
 	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
 
 	var input struct {
 		Name          string                 `json:"name"`
@@ -167,6 +174,10 @@ func (h *ProjectHandler) updateProject(c *gin.Context) {
 
 	// Update project
 	if err := h.projectService.UpdateProject(c.Request.Context(), projectsDomain.ProjectID(projectID), updates); err != nil {
+		if errors.Is(err, projectsDomain.ErrInvalidFlakyDetectionSettings) || errors.Is(err, projectsDomain.ErrInvalidJiraSyncSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -184,6 +195,9 @@ func (h *ProjectHandler) updateProject(c *gin.Context) {
 // deleteProject handles DELETE /api/v1/projects/:projectId
 func (h *ProjectHandler) deleteProject(c *gin.Context) {
 	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionDelete) {
+		return
+	}
 
 	if err := h.projectService.DeleteProject(c.Request.Context(), projectsDomain.ProjectID(projectID)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -228,6 +242,9 @@ func (h *ProjectHandler) listProjects(c *gin.Context) {
 // activateProject handles POST /api/v1/projects/:projectId/activate
 func (h *ProjectHandler) activateProject(c *gin.Context) {
 	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
 
 	if err := h.projectService.ActivateProject(c.Request.Context(), projectsDomain.ProjectID(projectID)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -240,6 +257,9 @@ func (h *ProjectHandler) activateProject(c *gin.Context) {
 // deactivateProject handles POST /api/v1/projects/:projectId/deactivate
 func (h *ProjectHandler) deactivateProject(c *gin.Context) {
 	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
 
 	if err := h.projectService.DeactivateProject(c.Request.Context(), projectsDomain.ProjectID(projectID)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -268,22 +288,386 @@ func (h *ProjectHandler) getProjectStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// getFlakyDetectionSettings handles GET /api/v1/projects/:projectId/flaky-detection
+func (h *ProjectHandler) getFlakyDetectionSettings(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	project, err := h.projectService.GetProject(c.Request.Context(), projectsDomain.ProjectID(projectID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	settings, err := project.FlakyDetectionSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projectId": projectID,
+		"overrides": settings.ToSettingValue(),
+	})
+}
+
+// updateFlakyDetectionSettings handles PUT /api/v1/projects/:projectId/flaky-detection
+func (h *ProjectHandler) updateFlakyDetectionSettings(c *gin.Context) {
+	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
+
+	var input map[string]interface{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := projectsDomain.ParseFlakyDetectionSettings(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := projectsApp.UpdateProjectRequest{FlakyDetection: &settings}
+	if err := h.projectService.UpdateProject(c.Request.Context(), projectsDomain.ProjectID(projectID), updates); err != nil {
+		if errors.Is(err, projectsDomain.ErrInvalidFlakyDetectionSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projectId": projectID,
+		"overrides": settings.ToSettingValue(),
+	})
+}
+
+// getJiraSyncSettings handles GET /api/v1/projects/:projectId/jira-sync
+func (h *ProjectHandler) getJiraSyncSettings(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	project, err := h.projectService.GetProject(c.Request.Context(), projectsDomain.ProjectID(projectID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	settings, err := project.JiraSyncSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projectId":     projectID,
+		"reflakeAction": settings.EffectiveReflakeAction(),
+	})
+}
+
+// updateJiraSyncSettings handles PUT /api/v1/projects/:projectId/jira-sync
+func (h *ProjectHandler) updateJiraSyncSettings(c *gin.Context) {
+	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
+
+	var input map[string]interface{}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := projectsDomain.ParseJiraSyncSettings(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := projectsApp.UpdateProjectRequest{JiraSync: &settings}
+	if err := h.projectService.UpdateProject(c.Request.Context(), projectsDomain.ProjectID(projectID), updates); err != nil {
+		if errors.Is(err, projectsDomain.ErrInvalidJiraSyncSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projectId":     projectID,
+		"reflakeAction": settings.EffectiveReflakeAction(),
+	})
+}
+
+// getOwnershipRules handles GET /api/v1/projects/:projectId/ownership
+func (h *ProjectHandler) getOwnershipRules(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	project, err := h.projectService.GetProject(c.Request.Context(), projectsDomain.ProjectID(projectID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	rules, err := project.OwnershipRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.convertOwnershipRulesToAPI(projectID, rules))
+}
+
+// updateOwnershipRules handles PUT /api/v1/projects/:projectId/ownership.
+// The rules are accepted as a text/plain body or as JSON {"rules": "..."}.
+func (h *ProjectHandler) updateOwnershipRules(c *gin.Context) {
+	projectID := c.Param("projectId")
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
+
+	var text string
+	if strings.HasPrefix(c.ContentType(), "text/") {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, projectsDomain.MaxOwnershipRulesSize+1))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		text = string(body)
+	} else {
+		var input struct {
+			Rules *string `json:"rules" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		text = *input.Rules
+	}
+
+	updates := projectsApp.UpdateProjectRequest{OwnershipRules: &text}
+	if err := h.projectService.UpdateProject(c.Request.Context(), projectsDomain.ProjectID(projectID), updates); err != nil {
+		if errors.Is(err, projectsDomain.ErrInvalidOwnershipRules) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := projectsDomain.ParseOwnershipRules(text)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.convertOwnershipRulesToAPI(projectID, rules))
+}
+
+// convertOwnershipRulesToAPI converts parsed ownership rules to the API representation
+func (h *ProjectHandler) convertOwnershipRulesToAPI(projectID string, rules *projectsDomain.OwnershipRules) gin.H {
+	source := ""
+	parsed := []gin.H{}
+	if !rules.IsEmpty() {
+		source = rules.Source()
+		for _, rule := range rules.Rules() {
+			owners := rule.Owners
+			if owners == nil {
+				owners = []string{}
+			}
+			parsed = append(parsed, gin.H{
+				"line":    rule.Line,
+				"field":   string(rule.Field),
+				"pattern": rule.Pattern,
+				"owners":  owners,
+			})
+		}
+	}
+
+	return gin.H{
+		"projectId": projectID,
+		"rules":     source,
+		"parsed":    parsed,
+	}
+}
+
+// ProjectAccessRequest represents a request to grant a permission on a project
+type ProjectAccessRequest struct {
+	Permission string     `json:"permission" binding:"required"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+// ProjectAccessResponse represents a permission granted on a project to a user or a group
+type ProjectAccessResponse struct {
+	ProjectID  string  `json:"projectId"`
+	UserID     string  `json:"userId,omitempty"`
+	GroupName  string  `json:"groupName,omitempty"`
+	Permission string  `json:"permission"`
+	GrantedBy  string  `json:"grantedBy"`
+	GrantedAt  string  `json:"grantedAt"`
+	ExpiresAt  *string `json:"expiresAt"`
+	Expired    bool    `json:"expired"`
+}
+
 // grantProjectAccess handles POST /api/v1/admin/projects/:projectId/users/:userId/access
 func (h *ProjectHandler) grantProjectAccess(c *gin.Context) {
-	// TODO: Implement project access management in domain service
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "Project access management not yet implemented"})
+	h.grantAccess(c, projectsApp.GrantAccessRequest{UserID: c.Param("userId")})
 }
 
-// revokeProjectAccess handles DELETE /api/v1/admin/projects/:projectId/users/:userId/access
+// revokeProjectAccess handles DELETE /api/v1/admin/projects/:projectId/users/:userId/access.
+// Only the permission in ?permission= is revoked if given.
 func (h *ProjectHandler) revokeProjectAccess(c *gin.Context) {
-	// TODO: Implement project access management in domain service
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "Project access management not yet implemented"})
+	permission, ok := h.permissionQuery(c)
+	if !ok {
+		return
+	}
+
+	err := h.projectService.RevokeUserAccess(c.Request.Context(), projectsDomain.ProjectID(c.Param("projectId")), c.Param("userId"), permission)
+	if err != nil {
+		h.respondWithAccessError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project access revoked"})
 }
 
 // getProjectUsers handles GET /api/v1/admin/projects/:projectId/users
 func (h *ProjectHandler) getProjectUsers(c *gin.Context) {
-	// TODO: Implement get project users
-	c.JSON(http.StatusOK, gin.H{"users": []gin.H{}})
+	permissions, ok := h.listAccess(c)
+	if !ok {
+		return
+	}
+
+	users := []ProjectAccessResponse{}
+	for _, permission := range permissions {
+		if !permission.IsGroupPermission() {
+			users = append(users, toProjectAccessResponse(permission))
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// grantProjectGroupAccess handles POST /api/v1/admin/projects/:projectId/groups/:groupName/access
+func (h *ProjectHandler) grantProjectGroupAccess(c *gin.Context) {
+	h.grantAccess(c, projectsApp.GrantAccessRequest{GroupName: c.Param("groupName")})
+}
+
+// revokeProjectGroupAccess handles DELETE /api/v1/admin/projects/:projectId/groups/:groupName/access.
+// Only the permission in ?permission= is revoked if given.
+func (h *ProjectHandler) revokeProjectGroupAccess(c *gin.Context) {
+	permission, ok := h.permissionQuery(c)
+	if !ok {
+		return
+	}
+
+	err := h.projectService.RevokeGroupAccess(c.Request.Context(), projectsDomain.ProjectID(c.Param("projectId")), c.Param("groupName"), permission)
+	if err != nil {
+		h.respondWithAccessError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project access revoked"})
+}
+
+// getProjectGroups handles GET /api/v1/admin/projects/:projectId/groups
+func (h *ProjectHandler) getProjectGroups(c *gin.Context) {
+	permissions, ok := h.listAccess(c)
+	if !ok {
+		return
+	}
+
+	groups := []ProjectAccessResponse{}
+	for _, permission := range permissions {
+		if permission.IsGroupPermission() {
+			groups = append(groups, toProjectAccessResponse(permission))
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"groups": groups})
+}
+
+// grantAccess grants the permission in the request body to the user or group in req
+func (h *ProjectHandler) grantAccess(c *gin.Context, req projectsApp.GrantAccessRequest) {
+	var input ProjectAccessRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	permission, err := projectsDomain.ParsePermissionType(input.Permission)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.ProjectID = projectsDomain.ProjectID(c.Param("projectId"))
+	req.Permission = permission
+	req.ExpiresAt = input.ExpiresAt
+	req.GrantedBy = h.getUserID(c)
+
+	granted, err := h.projectService.GrantAccess(c.Request.Context(), req)
+	if err != nil {
+		h.respondWithAccessError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProjectAccessResponse(granted))
+}
+
+// listAccess lists the permissions granted on the project in the path
+func (h *ProjectHandler) listAccess(c *gin.Context) ([]*projectsDomain.ProjectPermission, bool) {
+	permissions, err := h.projectService.ListAccess(c.Request.Context(), projectsDomain.ProjectID(c.Param("projectId")))
+	if err != nil {
+		h.respondWithAccessError(c, err)
+		return nil, false
+	}
+	return permissions, true
+}
+
+// permissionQuery parses the optional ?permission= query parameter
+func (h *ProjectHandler) permissionQuery(c *gin.Context) (projectsDomain.PermissionType, bool) {
+	value := c.Query("permission")
+	if value == "" {
+		return "", true
+	}
+
+	permission, err := projectsDomain.ParsePermissionType(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return permission, true
+}
+
+// respondWithAccessError maps project access errors to HTTP responses
+func (h *ProjectHandler) respondWithAccessError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, projectsDomain.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, projectsDomain.ErrPermissionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Permission not found"})
+	case errors.Is(err, projectsDomain.ErrInvalidPermission), errors.Is(err, projectsApp.ErrInvalidGrant):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error("Failed to manage project access")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to manage project access"})
+	}
+}
+
+// toProjectAccessResponse converts a project permission to API response format
+func toProjectAccessResponse(permission *projectsDomain.ProjectPermission) ProjectAccessResponse {
+	return ProjectAccessResponse{
+		ProjectID:  string(permission.ProjectID()),
+		UserID:     permission.UserID(),
+		GroupName:  permission.GroupName(),
+		Permission: string(permission.Permission()),
+		GrantedBy:  permission.GrantedBy(),
+		GrantedAt:  permission.GrantedAt().Format(time.RFC3339),
+		ExpiresAt:  formatOptionalTime(permission.ExpiresAt()),
+		Expired:    permission.IsExpired(),
+	}
 }
 
 // convertProjectToAPI converts a domain project to API response format
@@ -312,16 +696,28 @@ func (h *ProjectHandler) RegisterRoutes(userGroup, managerGroup, adminGroup *gin
 	userGroup.GET("/projects/:projectId", h.getProject)
 	userGroup.GET("/projects/by-project-id/:projectId", h.getProjectByProjectID)
 	userGroup.GET("/projects/stats/:projectId", h.getProjectStats)
+	userGroup.GET("/projects/:projectId/flaky-detection", h.getFlakyDetectionSettings)
+	userGroup.GET("/projects/:projectId/ownership", h.getOwnershipRules)
+	userGroup.GET("/projects/:projectId/jira-sync", h.getJiraSyncSettings)
 
-	// Manager routes (create/update/delete)
+	// Manager routes (create)
 	managerGroup.POST("/projects", h.createProject)
-	managerGroup.PUT("/projects/:projectId", h.updateProject)
-	managerGroup.DELETE("/projects/:projectId", h.deleteProject)
-	managerGroup.POST("/projects/:projectId/activate", h.activateProject)
-	managerGroup.POST("/projects/:projectId/deactivate", h.deactivateProject)
+
+	// Project routes (update/delete) - admins, managers of the project's team
+	// and users granted access to the project
+	userGroup.PUT("/projects/:projectId", h.updateProject)
+	userGroup.DELETE("/projects/:projectId", h.deleteProject)
+	userGroup.POST("/projects/:projectId/activate", h.activateProject)
+	userGroup.POST("/projects/:projectId/deactivate", h.deactivateProject)
+	userGroup.PUT("/projects/:projectId/flaky-detection", h.updateFlakyDetectionSettings)
+	userGroup.PUT("/projects/:projectId/ownership", h.updateOwnershipRules)
+	userGroup.PUT("/projects/:projectId/jira-sync", h.updateJiraSyncSettings)
 
 	// Admin routes (access management)
 	adminGroup.POST("/projects/:projectId/users/:userId/access", h.grantProjectAccess)
 	adminGroup.DELETE("/projects/:projectId/users/:userId/access", h.revokeProjectAccess)
 	adminGroup.GET("/projects/:projectId/users", h.getProjectUsers)
-}
\ No newline at end of file
+	adminGroup.POST("/projects/:projectId/groups/:groupName/access", h.grantProjectGroupAccess)
+	adminGroup.DELETE("/projects/:projectId/groups/:groupName/access", h.revokeProjectGroupAccess)
+	adminGroup.GET("/projects/:projectId/groups", h.getProjectGroups)
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// errInvalidSCIMFilter is returned for filters and PATCH paths that cannot be parsed
+var errInvalidSCIMFilter = errors.New("invalid filter")
+
+// scimCaseExactAttributes are compared case-sensitively; other string
+// attributes, such as user names and emails, ignore case
+var scimCaseExactAttributes = map[string]bool{
+	"id":            true,
+	"externalid":    true,
+	"members.value": true,
+	"groups.value":  true,
+}
+
+// scimFilter is a parsed SCIM filter (RFC 7644 section 3.4.2.2), matched
+// against resources as decoded from their JSON
+type scimFilter interface {
+	matches(resource map[string]interface{}) bool
+}
+
+// scimLogical joins two filters with "and" or "or"
+type scimLogical struct {
+	and         bool
+	left, right scimFilter
+}
+
+func (f *scimLogical) matches(resource map[string]interface{}) bool {
+	if f.and {
+		return f.left.matches(resource) && f.right.matches(resource)
+	}
+	return f.left.matches(resource) || f.right.matches(resource)
+}
+
+// scimNot negates a filter
+type scimNot struct {
+	filter scimFilter
+}
+
+func (f *scimNot) matches(resource map[string]interface{}) bool {
+	return !f.filter.matches(resource)
+}
+
+// scimComparison compares an attribute with a value, or checks it is present
+type scimComparison struct {
+	// path is the lower case attribute path, such as name.givenname
+	path  string
+	op    string
+	value interface{}
+}
+
+func (f *scimComparison) matches(resource map[string]interface{}) bool {
+	for _, actual := range scimAttributeValues(resource, f.path) {
+		if f.op == "pr" {
+			if actual != nil && actual != "" {
+				return true
+			}
+			continue
+		}
+		if compareSCIMValues(actual, f.op, f.value, scimCaseExactAttributes[f.path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// scimValuePath matches resources with a value of a multi-valued attribute
+// matching a filter, as in emails[type eq "work"]
+type scimValuePath struct {
+	path   string
+	filter scimFilter
+}
+
+func (f *scimValuePath) matches(resource map[string]interface{}) bool {
+	for _, value := range scimAttributes(resource, f.path) {
+		if object, ok := value.(map[string]interface{}); ok && f.filter.matches(object) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSCIMFilter parses a SCIM filter
+func parseSCIMFilter(filter string) (scimFilter, error) {
+	tokens, err := tokenizeSCIMFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &scimFilterParser{tokens: tokens}
+	parsed, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", errInvalidSCIMFilter, p.tokens[p.pos].text)
+	}
+	return parsed, nil
+}
+
+// scimToken is a token of a SCIM filter: a parenthesis or bracket, a quoted
+// string, or a word such as an attribute path, operator or literal
+type scimToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeSCIMFilter splits a SCIM filter into tokens
+func tokenizeSCIMFilter(filter string) ([]scimToken, error) {
+	var tokens []scimToken
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("()[]", c) >= 0:
+			tokens = append(tokens, scimToken{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", errInvalidSCIMFilter)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", errInvalidSCIMFilter, filter[i:end+1])
+			}
+			tokens = append(tokens, scimToken{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !unicode.IsSpace(rune(filter[end])) && strings.IndexByte("()[]\"", filter[end]) < 0 {
+				end++
+			}
+			tokens = append(tokens, scimToken{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// scimFilterParser parses filter tokens by recursive descent, with "not"
+// binding tighter than "and", and "and" tighter than "or"
+type scimFilterParser struct {
+	tokens []scimToken
+	pos    int
+}
+
+func (p *scimFilterParser) peek() (scimToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return scimToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *scimFilterParser) next() (scimToken, error) {
+	token, ok := p.peek()
+	if !ok {
+		return token, fmt.Errorf("%w: unexpected end of filter", errInvalidSCIMFilter)
+	}
+	p.pos++
+	return token, nil
+}
+
+func (p *scimFilterParser) isKeyword(keyword string) bool {
+	token, ok := p.peek()
+	return ok && !token.quoted && strings.EqualFold(token.text, keyword)
+}
+
+func (p *scimFilterParser) expect(text string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if token.quoted || token.text != text {
+		return fmt.Errorf("%w: expected %q, got %q", errInvalidSCIMFilter, text, token.text)
+	}
+	return nil
+}
+
+func (p *scimFilterParser) parseOr() (scimFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimLogical{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseAnd() (scimFilter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimLogical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseNot() (scimFilter, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &scimNot{filter: filter}, nil
+	}
+	return p.parseTerm()
+}
+
+func (p *scimFilterParser) parseTerm() (scimFilter, error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if !token.quoted && token.text == "(" {
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filter, p.expect(")")
+	}
+	if token.quoted || strings.ContainsAny(token.text, "()[]") {
+		return nil, fmt.Errorf("%w: expected an attribute, got %q", errInvalidSCIMFilter, token.text)
+	}
+	path := normalizeSCIMPath(token.text)
+
+	if next, ok := p.peek(); ok && !next.quoted && next.text == "[" {
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return &scimValuePath{path: path, filter: filter}, nil
+	}
+
+	opToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opToken.text)
+	switch op {
+	case "pr":
+		return &scimComparison{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", errInvalidSCIMFilter, opToken.text)
+	}
+	if opToken.quoted {
+		return nil, fmt.Errorf("%w: unknown operator %q", errInvalidSCIMFilter, opToken.text)
+	}
+
+	valueToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := scimLiteral(valueToken)
+	if err != nil {
+		return nil, err
+	}
+	return &scimComparison{path: path, op: op, value: value}, nil
+}
+
+// scimLiteral converts a comparison value token to a string, bool, number or nil
+func scimLiteral(token scimToken) (interface{}, error) {
+	if token.quoted {
+		return token.text, nil
+	}
+	switch strings.ToLower(token.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if number, err := strconv.ParseFloat(token.text, 64); err == nil {
+		return number, nil
+	}
+	return nil, fmt.Errorf("%w: invalid value %q", errInvalidSCIMFilter, token.text)
+}
+
+// normalizeSCIMPath lower-cases an attribute path and removes the core
+// schema URN that may prefix it
+func normalizeSCIMPath(path string) string {
+	path = strings.ToLower(path)
+	for _, schema := range []string{scimUserSchema, scimGroupSchema} {
+		if prefix := strings.ToLower(schema) + ":"; strings.HasPrefix(path, prefix) {
+			return strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// scimAttributeValues returns the values of an attribute path in a resource
+// to compare. Complex values, such as those of multi-valued attributes
+// without a sub-attribute, stand for their "value".
+func scimAttributeValues(resource map[string]interface{}, path string) []interface{} {
+	values := scimAttributes(resource, path)
+	for i, value := range values {
+		if object, ok := value.(map[string]interface{}); ok {
+			values[i], _ = lookupSCIMAttribute(object, "value")
+		}
+	}
+	return values
+}
+
+// scimAttributes returns the values of an attribute path in a resource,
+// looking into every value of multi-valued attributes
+func scimAttributes(resource map[string]interface{}, path string) []interface{} {
+	values := []interface{}{resource}
+	for _, name := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, value := range values {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			attribute, found := lookupSCIMAttribute(object, name)
+			if !found {
+				continue
+			}
+			if list, ok := attribute.([]interface{}); ok {
+				next = append(next, list...)
+			} else {
+				next = append(next, attribute)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// lookupSCIMAttribute finds an attribute ignoring the case of its name
+func lookupSCIMAttribute(object map[string]interface{}, name string) (interface{}, bool) {
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// compareSCIMValues applies a comparison operator to an attribute value
+func compareSCIMValues(actual interface{}, op string, expected interface{}, caseExact bool) bool {
+	switch expectedValue := expected.(type) {
+	case nil:
+		isNull := actual == nil || actual == ""
+		return (op == "eq" && isNull) || (op == "ne" && !isNull)
+
+	case bool:
+		actualValue, ok := actual.(bool)
+		if !ok {
+			return op == "ne"
+		}
+		return (op == "eq" && actualValue == expectedValue) || (op == "ne" && actualValue != expectedValue)
+
+	case float64:
+		actualValue, ok := actual.(float64)
+		if !ok {
+			return op == "ne"
+		}
+		return compareOrdered(op, actualValue < expectedValue, actualValue == expectedValue)
+
+	case string:
+		actualValue, ok := actual.(string)
+		if !ok {
+			return op == "ne"
+		}
+		if !caseExact {
+			actualValue = strings.ToLower(actualValue)
+			expectedValue = strings.ToLower(expectedValue)
+		}
+		switch op {
+		case "co":
+			return strings.Contains(actualValue, expectedValue)
+		case "sw":
+			return strings.HasPrefix(actualValue, expectedValue)
+		case "ew":
+			return strings.HasSuffix(actualValue, expectedValue)
+		}
+		// Timestamps are RFC 3339 strings, which order as they read
+		return compareOrdered(op, actualValue < expectedValue, actualValue == expectedValue)
+	}
+	return false
+}
+
+// compareOrdered applies eq, ne, gt, ge, lt or le given how two values order
+func compareOrdered(op string, less, equal bool) bool {
+	switch op {
+	case "eq":
+		return equal
+	case "ne":
+		return !equal
+	case "gt":
+		return !less && !equal
+	case "ge":
+		return !less
+	case "lt":
+		return less
+	case "le":
+		return less || equal
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCIM filters", func() {
+	user := map[string]interface{}{
+		"id":          "u1",
+		"userName":    "JDoe@example.com",
+		"displayName": "Jane Doe",
+		"active":      true,
+		"name":        map[string]interface{}{"givenName": "Jane", "familyName": "Doe"},
+		"emails": []interface{}{
+			map[string]interface{}{"value": "jdoe@example.com", "type": "work", "primary": true},
+			map[string]interface{}{"value": "jane@home.example", "type": "home"},
+		},
+		"meta": map[string]interface{}{"lastModified": "2026-05-01T10:00:00Z"},
+	}
+
+	matches := func(filter string) bool {
+		parsed, err := parseSCIMFilter(filter)
+		Expect(err).NotTo(HaveOccurred(), filter)
+		return parsed.matches(user)
+	}
+
+	It("should compare attributes with each operator", func() {
+		Expect(matches(`userName eq "jdoe@example.com"`)).To(BeTrue())
+		Expect(matches(`id eq "U1"`)).To(BeFalse())
+		Expect(matches(`displayName ne "Jane Doe"`)).To(BeFalse())
+		Expect(matches(`name.familyName co "o"`)).To(BeTrue())
+		Expect(matches(`userName sw "jdoe"`)).To(BeTrue())
+		Expect(matches(`userName ew ".org"`)).To(BeFalse())
+		Expect(matches(`active eq true`)).To(BeTrue())
+		Expect(matches(`meta.lastModified gt "2026-01-01T00:00:00Z"`)).To(BeTrue())
+		Expect(matches(`meta.lastModified le "2026-01-01T00:00:00Z"`)).To(BeFalse())
+		Expect(matches(`title pr`)).To(BeFalse())
+		Expect(matches(`urn:ietf:params:scim:schemas:core:2.0:User:userName pr`)).To(BeTrue())
+	})
+
+	It("should match any value of multi-valued attributes", func() {
+		Expect(matches(`emails co "home.example"`)).To(BeTrue())
+		Expect(matches(`emails.type eq "home"`)).To(BeTrue())
+		Expect(matches(`emails[type eq "home" and value sw "jdoe"]`)).To(BeFalse())
+		Expect(matches(`emails[type eq "work" and primary eq true]`)).To(BeTrue())
+	})
+
+	It("should join filters with and, or, not and parentheses", func() {
+		Expect(matches(`userName eq "x" or active eq true and name.givenName eq "Jane"`)).To(BeTrue())
+		Expect(matches(`(userName eq "x" or active eq true) and name.givenName eq "Bob"`)).To(BeFalse())
+		Expect(matches(`not (active eq false) AND displayName pr`)).To(BeTrue())
+	})
+
+	It("should reject invalid filters", func() {
+		for _, filter := range []string{
+			`userName`,
+			`userName eq`,
+			`userName is "x"`,
+			`userName eq "x`,
+			`(userName eq "x"`,
+			`userName eq "x" extra`,
+			`emails[type eq "work"`,
+			`userName eq jdoe`,
+		} {
+			_, err := parseSCIMFilter(filter)
+			Expect(err).To(MatchError(errInvalidSCIMFilter), filter)
+		}
+	})
+
+	It("should find equality comparisons to look up directly", func() {
+		filter, err := parseSCIMFilter(`displayName eq "ops" and members[value eq "u1"]`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(scimEqualityOn(filter, "displayname")).To(Equal("ops"))
+		Expect(scimEqualityOn(filter, "members.value")).To(Equal("u1"))
+		Expect(scimEqualityOn(filter, "externalid")).To(BeEmpty())
+
+		filter, err = parseSCIMFilter(`displayName eq "ops" or displayName eq "dev"`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scimEqualityOn(filter, "displayname")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,806 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// SCIM schemas (RFC 7643 and RFC 7644)
+const (
+	scimUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	scimListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimPatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+const (
+	scimContentType = "application/scim+json"
+
+	// defaultSCIMPageSize and maxSCIMPageSize bound the resources in a list response
+	defaultSCIMPageSize = 100
+	maxSCIMPageSize     = 1000
+)
+
+// SCIMHandler serves the SCIM 2.0 API the identity provider uses to provision
+// users and groups. It authenticates the identity provider with its own bearer
+// token rather than user sessions.
+type SCIMHandler struct {
+	*BaseHandler
+	provisioningService *authApp.ProvisioningService
+	tokenDigest         [sha256.Size]byte
+	auditService        *auditApp.AuditService
+}
+
+// NewSCIMHandler creates a new SCIM handler accepting the bearer token. The
+// audit service is optional.
+func NewSCIMHandler(provisioningService *authApp.ProvisioningService, token string, auditService *auditApp.AuditService, logger *logging.Logger) *SCIMHandler {
+	return &SCIMHandler{
+		BaseHandler:         NewBaseHandler(logger),
+		provisioningService: provisioningService,
+		tokenDigest:         sha256.Sum256([]byte(token)),
+		auditService:        auditService,
+	}
+}
+
+// RegisterRoutes registers the SCIM routes under /scim/v2
+func (h *SCIMHandler) RegisterRoutes(router *gin.Engine) {
+	scim := router.Group("/scim/v2")
+	scim.Use(h.authenticate)
+	if h.auditService != nil {
+		scim.Use(auditInterfaces.Middleware(h.auditService, h.logger))
+	}
+
+	scim.GET("/ServiceProviderConfig", h.getServiceProviderConfig)
+	scim.GET("/ResourceTypes", h.listResourceTypes)
+
+	scim.GET("/Users", h.listSCIMUsers)
+	scim.POST("/Users", h.createSCIMUser)
+	scim.GET("/Users/:id", h.getSCIMUser)
+	scim.PUT("/Users/:id", h.replaceSCIMUser)
+	scim.PATCH("/Users/:id", h.patchSCIMUser)
+	scim.DELETE("/Users/:id", h.deleteSCIMUser)
+
+	scim.GET("/Groups", h.listSCIMGroups)
+	scim.POST("/Groups", h.createSCIMGroup)
+	scim.GET("/Groups/:id", h.getSCIMGroup)
+	scim.PUT("/Groups/:id", h.replaceSCIMGroup)
+	scim.PATCH("/Groups/:id", h.patchSCIMGroup)
+	scim.DELETE("/Groups/:id", h.deleteSCIMGroup)
+}
+
+// authenticate checks the request carries the SCIM bearer token, and acts on
+// behalf of the provisioning actor
+func (h *SCIMHandler) authenticate(c *gin.Context) {
+	scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+	digest := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	if !strings.EqualFold(scheme, "Bearer") || token == "" ||
+		subtle.ConstantTimeCompare(digest[:], h.tokenDigest[:]) != 1 {
+		c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+		h.respondWithSCIMError(c, http.StatusUnauthorized, "", "Invalid SCIM bearer token")
+		c.Abort()
+		return
+	}
+
+	c.Set("user", &authDomain.User{UserID: authApp.ProvisioningActor, Name: "SCIM provisioning"})
+	c.Set("user_id", authApp.ProvisioningActor)
+	c.Next()
+}
+
+// scimName is the name of a SCIM user
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// scimEmail is an email of a SCIM user
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// scimMember is a member of a SCIM group, or a group of a SCIM user
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// scimMeta describes a SCIM resource
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// scimUser is a SCIM user resource
+type scimUser struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *scimName    `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []scimEmail  `json:"emails,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Groups      []scimMember `json:"groups,omitempty"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// scimGroup is a SCIM group resource
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members,omitempty"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// scimListResponse is a page of SCIM resources
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// scimPatchRequest is a SCIM PATCH request
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// scimPatchOperation adds, replaces or removes the attribute at a path, or
+// the attributes of its value when it has no path
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// getServiceProviderConfig handles GET /scim/v2/ServiceProviderConfig
+func (h *SCIMHandler) getServiceProviderConfig(c *gin.Context) {
+	h.respondWithSCIM(c, http.StatusOK, gin.H{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          gin.H{"supported": true},
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": maxSCIMPageSize},
+		"changePassword": gin.H{"supported": false},
+		"sort":           gin.H{"supported": false},
+		"etag":           gin.H{"supported": false},
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Authentication with the SCIM token configured in Fern",
+			"primary":     true,
+		}},
+		"meta": scimMeta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     scimBaseURL(c) + "/ServiceProviderConfig",
+		},
+	})
+}
+
+// listResourceTypes handles GET /scim/v2/ResourceTypes
+func (h *SCIMHandler) listResourceTypes(c *gin.Context) {
+	baseURL := scimBaseURL(c)
+	resourceTypes := []interface{}{
+		gin.H{
+			"schemas":  []string{scimResourceTypeSchema},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scimUserSchema,
+			"meta":     scimMeta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/User"},
+		},
+		gin.H{
+			"schemas":  []string{scimResourceTypeSchema},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   scimGroupSchema,
+			"meta":     scimMeta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/Group"},
+		},
+	}
+	h.respondWithSCIM(c, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: int64(len(resourceTypes)),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+// listSCIMUsers handles GET /scim/v2/Users. Filters on the user name,
+// external ID or ID are looked up directly; other filters are applied to
+// every user.
+func (h *SCIMHandler) listSCIMUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	startIndex, count := scimPage(c)
+	filter, ok := h.parseFilter(c)
+	if !ok {
+		return
+	}
+
+	userFilter := authDomain.UserFilter{Limit: count, Offset: startIndex - 1}
+	if filter != nil {
+		userFilter = authDomain.UserFilter{Limit: -1}
+	}
+	var users []*authDomain.User
+	var total int64
+	var err error
+	if comparison := scimEqualityOn(filter, "id"); comparison != "" {
+		var user *authDomain.User
+		if user, err = h.provisioningService.GetUser(ctx, comparison); err == nil {
+			users = []*authDomain.User{user}
+		} else if errors.Is(err, authDomain.ErrUserNotFound) {
+			err = nil
+		}
+	} else {
+		userFilter.UserName = scimEqualityOn(filter, "username")
+		userFilter.ExternalID = scimEqualityOn(filter, "externalid")
+		users, total, err = h.provisioningService.ListUsers(ctx, userFilter)
+	}
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+
+	groups, err := h.groupsByMember(ctx)
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	baseURL := scimBaseURL(c)
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, toSCIMUser(user, groups[user.UserID], baseURL))
+	}
+	h.respondWithSCIMList(c, resources, total, filter, startIndex, count)
+}
+
+// getSCIMUser handles GET /scim/v2/Users/:id
+func (h *SCIMHandler) getSCIMUser(c *gin.Context) {
+	user, err := h.provisioningService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIMUser(c, http.StatusOK, user)
+}
+
+// createSCIMUser handles POST /scim/v2/Users
+func (h *SCIMHandler) createSCIMUser(c *gin.Context) {
+	var resource scimUser
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	user, err := h.provisioningService.CreateUser(c.Request.Context(), fromSCIMUser(&resource))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	c.Header("Location", scimBaseURL(c)+"/Users/"+user.UserID)
+	h.respondWithSCIMUser(c, http.StatusCreated, user)
+}
+
+// replaceSCIMUser handles PUT /scim/v2/Users/:id. Users replaced without an
+// active attribute keep their status.
+func (h *SCIMHandler) replaceSCIMUser(c *gin.Context) {
+	var resource scimUser
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.provisioningService.GetUser(ctx, c.Param("id"))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	if resource.Active == nil {
+		active := existing.IsActive()
+		resource.Active = &active
+	}
+
+	user := fromSCIMUser(&resource)
+	user.UserID = existing.UserID
+	if user, err = h.provisioningService.ReplaceUser(ctx, user); err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIMUser(c, http.StatusOK, user)
+}
+
+// patchSCIMUser handles PATCH /scim/v2/Users/:id
+func (h *SCIMHandler) patchSCIMUser(c *gin.Context) {
+	var request scimPatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.provisioningService.GetUser(ctx, c.Param("id"))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+
+	resource := toSCIMUser(user, nil, "")
+	for _, operation := range request.Operations {
+		if err := applySCIMUserPatch(resource, operation); err != nil {
+			h.respondWithPatchError(c, err)
+			return
+		}
+	}
+
+	patched := fromSCIMUser(resource)
+	patched.UserID = user.UserID
+	if user, err = h.provisioningService.ReplaceUser(ctx, patched); err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIMUser(c, http.StatusOK, user)
+}
+
+// deleteSCIMUser handles DELETE /scim/v2/Users/:id. Deprovisioned users are
+// suspended rather than deleted, and their sessions end.
+func (h *SCIMHandler) deleteSCIMUser(c *gin.Context) {
+	if err := h.provisioningService.DeprovisionUser(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// listSCIMGroups handles GET /scim/v2/Groups. Filters on the display name,
+// external ID, ID or members are looked up directly; other filters are
+// applied to every group.
+func (h *SCIMHandler) listSCIMGroups(c *gin.Context) {
+	ctx := c.Request.Context()
+	startIndex, count := scimPage(c)
+	filter, ok := h.parseFilter(c)
+	if !ok {
+		return
+	}
+
+	groupFilter := authDomain.GroupFilter{Limit: count, Offset: startIndex - 1}
+	if filter != nil {
+		groupFilter = authDomain.GroupFilter{Limit: -1}
+	}
+	var groups []*authDomain.Group
+	var total int64
+	var err error
+	if comparison := scimEqualityOn(filter, "id"); comparison != "" {
+		var group *authDomain.Group
+		if group, err = h.provisioningService.GetGroup(ctx, comparison); err == nil {
+			groups = []*authDomain.Group{group}
+		} else if errors.Is(err, authDomain.ErrGroupNotFound) {
+			err = nil
+		}
+	} else {
+		groupFilter.DisplayName = scimEqualityOn(filter, "displayname")
+		groupFilter.ExternalID = scimEqualityOn(filter, "externalid")
+		groupFilter.Member = scimEqualityOn(filter, "members.value")
+		groups, total, err = h.provisioningService.ListGroups(ctx, groupFilter)
+	}
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+
+	baseURL := scimBaseURL(c)
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		resources = append(resources, toSCIMGroup(group, baseURL))
+	}
+	h.respondWithSCIMList(c, resources, total, filter, startIndex, count)
+}
+
+// getSCIMGroup handles GET /scim/v2/Groups/:id
+func (h *SCIMHandler) getSCIMGroup(c *gin.Context) {
+	group, err := h.provisioningService.GetGroup(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIM(c, http.StatusOK, toSCIMGroup(group, scimBaseURL(c)))
+}
+
+// createSCIMGroup handles POST /scim/v2/Groups
+func (h *SCIMHandler) createSCIMGroup(c *gin.Context) {
+	var resource scimGroup
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	group, err := h.provisioningService.CreateGroup(c.Request.Context(), fromSCIMGroup(&resource))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	baseURL := scimBaseURL(c)
+	c.Header("Location", baseURL+"/Groups/"+group.GroupID)
+	h.respondWithSCIM(c, http.StatusCreated, toSCIMGroup(group, baseURL))
+}
+
+// replaceSCIMGroup handles PUT /scim/v2/Groups/:id
+func (h *SCIMHandler) replaceSCIMGroup(c *gin.Context) {
+	var resource scimGroup
+	if err := c.ShouldBindJSON(&resource); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	group := fromSCIMGroup(&resource)
+	group.GroupID = c.Param("id")
+	group, err := h.provisioningService.ReplaceGroup(c.Request.Context(), group)
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIM(c, http.StatusOK, toSCIMGroup(group, scimBaseURL(c)))
+}
+
+// patchSCIMGroup handles PATCH /scim/v2/Groups/:id
+func (h *SCIMHandler) patchSCIMGroup(c *gin.Context) {
+	var request scimPatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidSyntax", "Invalid request body: "+err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	group, err := h.provisioningService.GetGroup(ctx, c.Param("id"))
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+
+	for _, operation := range request.Operations {
+		if err := applySCIMGroupPatch(group, operation); err != nil {
+			h.respondWithPatchError(c, err)
+			return
+		}
+	}
+
+	if group, err = h.provisioningService.ReplaceGroup(ctx, group); err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIM(c, http.StatusOK, toSCIMGroup(group, scimBaseURL(c)))
+}
+
+// deleteSCIMGroup handles DELETE /scim/v2/Groups/:id
+func (h *SCIMHandler) deleteSCIMGroup(c *gin.Context) {
+	if err := h.provisioningService.DeleteGroup(c.Request.Context(), c.Param("id")); err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseFilter parses the filter query parameter, responding with an error
+// if it is invalid. It returns nil without a filter.
+func (h *SCIMHandler) parseFilter(c *gin.Context) (scimFilter, bool) {
+	query := strings.TrimSpace(c.Query("filter"))
+	if query == "" {
+		return nil, true
+	}
+	filter, err := parseSCIMFilter(query)
+	if err != nil {
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+		return nil, false
+	}
+	return filter, true
+}
+
+// groupsByMember lists the groups of every user
+func (h *SCIMHandler) groupsByMember(ctx context.Context) (map[string][]*authDomain.Group, error) {
+	groups, _, err := h.provisioningService.ListGroups(ctx, authDomain.GroupFilter{Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+	byMember := make(map[string][]*authDomain.Group)
+	for _, group := range groups {
+		for _, userID := range group.Members {
+			byMember[userID] = append(byMember[userID], group)
+		}
+	}
+	return byMember, nil
+}
+
+// respondWithSCIMUser sends a user with their groups
+func (h *SCIMHandler) respondWithSCIMUser(c *gin.Context, code int, user *authDomain.User) {
+	groups, _, err := h.provisioningService.ListGroups(c.Request.Context(), authDomain.GroupFilter{Member: user.UserID, Limit: -1})
+	if err != nil {
+		h.respondWithProvisioningError(c, err)
+		return
+	}
+	h.respondWithSCIM(c, code, toSCIMUser(user, groups, scimBaseURL(c)))
+}
+
+// respondWithSCIMList sends a page of resources. Resources listed for a
+// filter are all the candidates for it, which are filtered and paged here;
+// otherwise they are the page already.
+func (h *SCIMHandler) respondWithSCIMList(c *gin.Context, resources []interface{}, total int64, filter scimFilter, startIndex, count int) {
+	if filter != nil {
+		matched := make([]interface{}, 0, len(resources))
+		for _, resource := range resources {
+			if filter.matches(toSCIMAttributes(resource)) {
+				matched = append(matched, resource)
+			}
+		}
+		total = int64(len(matched))
+		start := startIndex - 1
+		if start > len(matched) {
+			start = len(matched)
+		}
+		end := start + count
+		if end > len(matched) {
+			end = len(matched)
+		}
+		resources = matched[start:end]
+	}
+
+	h.respondWithSCIM(c, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// respondWithSCIM sends a SCIM response
+func (h *SCIMHandler) respondWithSCIM(c *gin.Context, code int, payload interface{}) {
+	c.Header("Content-Type", scimContentType)
+	h.respondWithJSON(c, code, payload)
+}
+
+// respondWithSCIMError sends a SCIM error response
+func (h *SCIMHandler) respondWithSCIMError(c *gin.Context, code int, scimType, detail string) {
+	body := gin.H{
+		"schemas": []string{scimErrorSchema},
+		"status":  strconv.Itoa(code),
+		"detail":  detail,
+	}
+	if scimType != "" {
+		body["scimType"] = scimType
+	}
+	h.respondWithSCIM(c, code, body)
+}
+
+// respondWithProvisioningError maps provisioning errors to SCIM errors
+func (h *SCIMHandler) respondWithProvisioningError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authDomain.ErrUserNotFound),
+		errors.Is(err, authDomain.ErrGroupNotFound):
+		h.respondWithSCIMError(c, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, authDomain.ErrUserExists),
+		errors.Is(err, authDomain.ErrGroupExists):
+		h.respondWithSCIMError(c, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, authApp.ErrInvalidUser),
+		errors.Is(err, authApp.ErrInvalidGroup):
+		h.respondWithSCIMError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		h.logger.WithError(err).Error("SCIM provisioning failed")
+		h.respondWithSCIMError(c, http.StatusInternalServerError, "", "Failed to provision resource")
+	}
+}
+
+// respondWithPatchError sends the error for a PATCH operation that cannot be applied
+func (h *SCIMHandler) respondWithPatchError(c *gin.Context, err error) {
+	scimType := "invalidValue"
+	if errors.Is(err, errInvalidSCIMFilter) {
+		scimType = "invalidPath"
+	}
+	h.respondWithSCIMError(c, http.StatusBadRequest, scimType, err.Error())
+}
+
+// scimPage reads the 1-based start index and page size of a list request
+func scimPage(c *gin.Context) (int, int) {
+	startIndex, err := strconv.Atoi(c.Query("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(c.Query("count"))
+	if err != nil {
+		count = defaultSCIMPageSize
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > maxSCIMPageSize {
+		count = maxSCIMPageSize
+	}
+	return startIndex, count
+}
+
+// scimBaseURL returns the URL of the SCIM API as the client reached it
+func scimBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.Request.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + "/scim/v2"
+}
+
+// scimEqualityOn returns the value an attribute must equal for resources to
+// match a filter, if the filter or one of the filters it joins with "and"
+// compares the attribute with eq. Filters on a value path such as
+// members[value eq "id"] compare the path's sub-attribute.
+func scimEqualityOn(filter scimFilter, path string) string {
+	switch f := filter.(type) {
+	case *scimComparison:
+		if value, ok := f.value.(string); ok && f.op == "eq" && f.path == path {
+			return value
+		}
+	case *scimValuePath:
+		if attribute, sub, ok := strings.Cut(path, "."); ok && f.path == attribute {
+			return scimEqualityOn(f.filter, sub)
+		}
+	case *scimLogical:
+		if f.and {
+			if value := scimEqualityOn(f.left, path); value != "" {
+				return value
+			}
+			return scimEqualityOn(f.right, path)
+		}
+	}
+	return ""
+}
+
+// toSCIMAttributes converts a resource to its JSON attributes, for filters
+func toSCIMAttributes(resource interface{}) map[string]interface{} {
+	var attributes map[string]interface{}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil
+	}
+	return attributes
+}
+
+// toSCIMUser converts a user to a SCIM user. Users not provisioned through
+// SCIM are known by their email.
+func toSCIMUser(user *authDomain.User, groups []*authDomain.Group, baseURL string) *scimUser {
+	active := user.IsActive()
+	resource := &scimUser{
+		Schemas:     []string{scimUserSchema},
+		ID:          user.UserID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.UserName,
+		DisplayName: user.Name,
+		Active:      &active,
+		Meta:        toSCIMMeta("User", user.CreatedAt, user.UpdatedAt, baseURL+"/Users/"+user.UserID),
+	}
+	if resource.UserName == "" {
+		resource.UserName = user.Email
+	}
+	if user.Name != "" || user.FirstName != "" || user.LastName != "" {
+		resource.Name = &scimName{Formatted: user.Name, GivenName: user.FirstName, FamilyName: user.LastName}
+	}
+	if user.Email != "" {
+		resource.Emails = []scimEmail{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	for _, group := range groups {
+		resource.Groups = append(resource.Groups, scimMember{
+			Value:   group.GroupID,
+			Display: group.DisplayName,
+			Ref:     baseURL + "/Groups/" + group.GroupID,
+		})
+	}
+	return resource
+}
+
+// fromSCIMUser converts a SCIM user to a user. The user's email is their
+// primary email, or their user name if it is an email; their name is the
+// display name or, failing that, their formatted, given or user name.
+func fromSCIMUser(resource *scimUser) *authDomain.User {
+	user := &authDomain.User{
+		UserName:   strings.TrimSpace(resource.UserName),
+		ExternalID: resource.ExternalID,
+		Name:       strings.TrimSpace(resource.DisplayName),
+		Status:     authDomain.StatusActive,
+	}
+	if resource.Active != nil && !*resource.Active {
+		user.Status = authDomain.StatusSuspended
+	}
+
+	for _, email := range resource.Emails {
+		if email.Primary || user.Email == "" {
+			user.Email = strings.TrimSpace(email.Value)
+		}
+		if email.Primary {
+			break
+		}
+	}
+	if user.Email == "" && strings.Contains(user.UserName, "@") {
+		user.Email = user.UserName
+	}
+
+	if resource.Name != nil {
+		user.FirstName = resource.Name.GivenName
+		user.LastName = resource.Name.FamilyName
+		if user.Name == "" {
+			user.Name = strings.TrimSpace(resource.Name.Formatted)
+		}
+		if user.Name == "" {
+			user.Name = strings.TrimSpace(resource.Name.GivenName + " " + resource.Name.FamilyName)
+		}
+	}
+	if user.Name == "" {
+		user.Name = user.UserName
+	}
+	return user
+}
+
+// toSCIMGroup converts a group to a SCIM group
+func toSCIMGroup(group *authDomain.Group, baseURL string) *scimGroup {
+	resource := &scimGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          group.GroupID,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Meta:        toSCIMMeta("Group", group.CreatedAt, group.UpdatedAt, baseURL+"/Groups/"+group.GroupID),
+	}
+	for _, userID := range group.Members {
+		resource.Members = append(resource.Members, scimMember{
+			Value: userID,
+			Ref:   baseURL + "/Users/" + userID,
+		})
+	}
+	return resource
+}
+
+// fromSCIMGroup converts a SCIM group to a group
+func fromSCIMGroup(resource *scimGroup) *authDomain.Group {
+	group := &authDomain.Group{
+		DisplayName: strings.TrimSpace(resource.DisplayName),
+		ExternalID:  resource.ExternalID,
+		Members:     []string{},
+	}
+	for _, member := range resource.Members {
+		group.Members = append(group.Members, member.Value)
+	}
+	return group
+}
+
+// toSCIMMeta describes a resource
+func toSCIMMeta(resourceType string, created, lastModified time.Time, location string) *scimMeta {
+	return &scimMeta{
+		ResourceType: resourceType,
+		Created:      created.UTC().Format(time.RFC3339),
+		LastModified: lastModified.UTC().Format(time.RFC3339),
+		Location:     location,
+	}
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	auditInfra "github.com/guidewire-oss/fern-platform/internal/domains/audit/infrastructure"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	authInfra "github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var _ = Describe("SCIMHandler", func() {
+	const token = "scim-token-0123456789abcdef0123456789"
+
+	var (
+		router       *gin.Engine
+		db           *gorm.DB
+		auditService *auditApp.AuditService
+	)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", scimContentType)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	decode := func(recorder *httptest.ResponseRecorder) map[string]interface{} {
+		var body map[string]interface{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+		return body
+	}
+
+	// createUser provisions a user and returns their ID
+	createUser := func(userName, email string) string {
+		recorder := request(http.MethodPost, "/scim/v2/Users", `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName": "`+userName+`",
+			"externalId": "ext-`+userName+`",
+			"name": {"givenName": "Jane", "familyName": "Doe"},
+			"emails": [{"value": "`+email+`", "type": "work", "primary": true}],
+			"active": true
+		}`)
+		Expect(recorder.Code).To(Equal(http.StatusCreated), recorder.Body.String())
+		return decode(recorder)["id"].(string)
+	}
+
+	// listTotal lists resources and returns the total number of matches
+	listTotal := func(path string) float64 {
+		recorder := request(http.MethodGet, path, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK), recorder.Body.String())
+		return decode(recorder)["totalResults"].(float64)
+	}
+
+	activeSessions := func(userID string) int64 {
+		var count int64
+		Expect(db.Model(&database.UserSession{}).Where("user_id = ? AND is_active = ?", userID, true).
+			Count(&count).Error).To(Succeed())
+		return count
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.User{}, &database.UserGroup{}, &database.UserScope{}, &database.UserSession{}, &database.Group{},
+			&projectsInfra.ProjectGroupPermissionDB{}, &auditInfra.AuditLogDB{},
+		)).To(Succeed())
+
+		userRepo := authInfra.NewGormUserRepository(db)
+		sessionRepo := authInfra.NewGormSessionRepository(db)
+		Expect(userRepo.Create(context.Background(), &authDomain.User{
+			UserID: "oidc-sub-1", Email: "dana@example.com", Name: "Dana", Role: authDomain.RoleAdmin, Status: authDomain.StatusActive,
+		})).To(Succeed())
+
+		provisioningService := authApp.NewProvisioningService(userRepo, authInfra.NewGormGroupRepository(db),
+			authApp.NewUserAdminService(userRepo, sessionRepo))
+		auditService = auditApp.NewAuditService(auditInfra.NewGormAuditRepository(db), 0)
+		auditService.RegisterSnapshotter(auditDomain.TargetUser, func(ctx context.Context, id string) (interface{}, error) {
+			return provisioningService.GetUser(ctx, id)
+		})
+
+		router = gin.New()
+		NewSCIMHandler(provisioningService, token, auditService, logger).RegisterRoutes(router)
+	})
+
+	It("should reject requests without the SCIM token", func() {
+		for _, authorization := range []string{"", "Bearer wrong", "Basic " + token} {
+			req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal(scimContentType))
+			Expect(decode(recorder)["schemas"]).To(ConsistOf(scimErrorSchema))
+		}
+	})
+
+	It("should describe the service provider", func() {
+		recorder := request(http.MethodGet, "/scim/v2/ServiceProviderConfig", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(decode(recorder)["patch"]).To(HaveKeyWithValue("supported", true))
+		Expect(listTotal("/scim/v2/ResourceTypes")).To(BeEquivalentTo(2))
+	})
+
+	It("should create users and find them by user name", func() {
+		id := createUser("jdoe", "jdoe@example.com")
+
+		recorder := request(http.MethodGet, "/scim/v2/Users/"+id, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		user := decode(recorder)
+		Expect(user["userName"]).To(Equal("jdoe"))
+		Expect(user["externalId"]).To(Equal("ext-jdoe"))
+		Expect(user["displayName"]).To(Equal("Jane Doe"))
+		Expect(user["active"]).To(BeTrue())
+		Expect(user["meta"]).To(HaveKeyWithValue("location", "http://example.com/scim/v2/Users/"+id))
+
+		Expect(listTotal(`/scim/v2/Users?filter=userName+eq+%22JDOE%22`)).To(BeEquivalentTo(1))
+		Expect(listTotal(`/scim/v2/Users?filter=externalId+eq+%22ext-jdoe%22`)).To(BeEquivalentTo(1))
+		Expect(listTotal(`/scim/v2/Users?filter=emails%5Btype+eq+%22work%22+and+value+co+%22example.com%22%5D`)).To(BeEquivalentTo(2))
+		Expect(listTotal(`/scim/v2/Users?filter=not+(userName+sw+%22j%22)`)).To(BeEquivalentTo(1))
+
+		// Users who signed in before provisioning are known by their email
+		Expect(listTotal(`/scim/v2/Users?filter=userName+eq+%22dana@example.com%22`)).To(BeEquivalentTo(1))
+	})
+
+	It("should page through users", func() {
+		createUser("jdoe", "jdoe@example.com")
+
+		recorder := request(http.MethodGet, "/scim/v2/Users?startIndex=2&count=1", "")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		page := decode(recorder)
+		Expect(page["totalResults"]).To(BeEquivalentTo(2))
+		Expect(page["startIndex"]).To(BeEquivalentTo(2))
+		Expect(page["itemsPerPage"]).To(BeEquivalentTo(1))
+		Expect(page["Resources"].([]interface{})[0]).To(HaveKeyWithValue("userName", "jdoe"))
+	})
+
+	It("should reject invalid filters", func() {
+		recorder := request(http.MethodGet, `/scim/v2/Users?filter=userName+is+%22x%22`, "")
+
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(decode(recorder)["scimType"]).To(Equal("invalidFilter"))
+	})
+
+	It("should reject taken user names", func() {
+		createUser("jdoe", "jdoe@example.com")
+
+		recorder := request(http.MethodPost, "/scim/v2/Users", `{"userName": "JDoe", "emails": [{"value": "other@example.com"}]}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusConflict))
+		Expect(decode(recorder)["scimType"]).To(Equal("uniqueness"))
+	})
+
+	It("should replace users, keeping their status without an active attribute", func() {
+		id := createUser("jdoe", "jdoe@example.com")
+
+		recorder := request(http.MethodPut, "/scim/v2/Users/"+id, `{"userName": "jdoe", "displayName": "Jane Smith", "emails": [{"value": "jsmith@example.com"}]}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK), recorder.Body.String())
+		user := decode(recorder)
+		Expect(user["displayName"]).To(Equal("Jane Smith"))
+		Expect(user["active"]).To(BeTrue())
+		Expect(user["emails"].([]interface{})[0]).To(HaveKeyWithValue("value", "jsmith@example.com"))
+	})
+
+	It("should suspend users patched as inactive and end their sessions", func() {
+		Expect(db.Create(&database.UserSession{
+			UserID: "oidc-sub-1", SessionID: "session-1", IsActive: true,
+			ExpiresAt: time.Now().Add(time.Hour), LastActivity: time.Now(),
+		}).Error).To(Succeed())
+
+		recorder := request(http.MethodPatch, "/scim/v2/Users/oidc-sub-1", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "Replace", "path": "active", "value": "False"},
+				{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "dana@example.org"},
+				{"op": "replace", "value": {"name.givenName": "Dana", "externalId": "ext-dana"}}
+			]
+		}`)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK), recorder.Body.String())
+		user := decode(recorder)
+		Expect(user["active"]).To(BeFalse())
+		Expect(user["externalId"]).To(Equal("ext-dana"))
+		Expect(user["emails"].([]interface{})[0]).To(HaveKeyWithValue("value", "dana@example.org"))
+		Expect(activeSessions("oidc-sub-1")).To(BeZero())
+
+		var dbUser database.User
+		Expect(db.Where("user_id = ?", "oidc-sub-1").First(&dbUser).Error).To(Succeed())
+		Expect(dbUser.Status).To(Equal(string(authDomain.StatusSuspended)))
+		Expect(dbUser.Role).To(Equal(string(authDomain.RoleAdmin)))
+	})
+
+	It("should deprovision users by suspending them", func() {
+		Expect(db.Create(&database.UserSession{
+			UserID: "oidc-sub-1", SessionID: "session-1", IsActive: true,
+			ExpiresAt: time.Now().Add(time.Hour), LastActivity: time.Now(),
+		}).Error).To(Succeed())
+
+		Expect(request(http.MethodDelete, "/scim/v2/Users/oidc-sub-1", "").Code).To(Equal(http.StatusNoContent))
+
+		recorder := request(http.MethodGet, "/scim/v2/Users/oidc-sub-1", "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(decode(recorder)["active"]).To(BeFalse())
+		Expect(activeSessions("oidc-sub-1")).To(BeZero())
+
+		entries, _, err := auditService.List(context.Background(), auditDomain.Filter{Action: "delete_scim_user"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ActorID).To(Equal(authApp.ProvisioningActor))
+		Expect(entries[0].TargetType).To(Equal(auditDomain.TargetUser))
+		Expect(entries[0].TargetID).To(Equal("oidc-sub-1"))
+	})
+
+	It("should report unknown users", func() {
+		Expect(request(http.MethodGet, "/scim/v2/Users/nobody", "").Code).To(Equal(http.StatusNotFound))
+		Expect(request(http.MethodDelete, "/scim/v2/Users/nobody", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	Describe("groups", func() {
+		var userID, groupID string
+
+		BeforeEach(func() {
+			userID = createUser("jdoe", "jdoe@example.com")
+
+			recorder := request(http.MethodPost, "/scim/v2/Groups", `{
+				"displayName": "shop-users",
+				"members": [{"value": "`+userID+`"}]
+			}`)
+			Expect(recorder.Code).To(Equal(http.StatusCreated), recorder.Body.String())
+			groupID = decode(recorder)["id"].(string)
+		})
+
+		It("should create groups and list them with their users", func() {
+			recorder := request(http.MethodGet, "/scim/v2/Groups/"+groupID, "")
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(decode(recorder)["members"].([]interface{})[0]).To(HaveKeyWithValue("value", userID))
+
+			recorder = request(http.MethodGet, "/scim/v2/Users/"+userID, "")
+			Expect(decode(recorder)["groups"].([]interface{})[0]).To(HaveKeyWithValue("display", "shop-users"))
+
+			Expect(listTotal(`/scim/v2/Groups?filter=displayName+eq+%22Shop-Users%22`)).To(BeEquivalentTo(1))
+			Expect(listTotal(`/scim/v2/Groups?filter=members%5Bvalue+eq+%22` + userID + `%22%5D`)).To(BeEquivalentTo(1))
+			Expect(listTotal(`/scim/v2/Groups?filter=members+eq+%22oidc-sub-1%22`)).To(BeZero())
+		})
+
+		It("should reject taken names and unknown members", func() {
+			recorder := request(http.MethodPost, "/scim/v2/Groups", `{"displayName": "shop-users"}`)
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+
+			recorder = request(http.MethodPost, "/scim/v2/Groups", `{"displayName": "billing", "members": [{"value": "ghost"}]}`)
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(decode(recorder)["scimType"]).To(Equal("invalidValue"))
+		})
+
+		It("should patch members and rename groups", func() {
+			recorder := request(http.MethodPatch, "/scim/v2/Groups/"+groupID, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [
+					{"op": "add", "path": "members", "value": [{"value": "oidc-sub-1"}]},
+					{"op": "remove", "path": "members[value eq \"`+userID+`\"]"},
+					{"op": "replace", "path": "displayName", "value": "store-users"}
+				]
+			}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK), recorder.Body.String())
+			group := decode(recorder)
+			Expect(group["displayName"]).To(Equal("store-users"))
+			Expect(group["members"]).To(HaveLen(1))
+			Expect(group["members"].([]interface{})[0]).To(HaveKeyWithValue("value", "oidc-sub-1"))
+
+			var memberships []database.UserGroup
+			Expect(db.Find(&memberships).Error).To(Succeed())
+			Expect(memberships).To(HaveLen(1))
+			Expect(memberships[0].UserID).To(Equal("oidc-sub-1"))
+			Expect(memberships[0].GroupName).To(Equal("store-users"))
+		})
+
+		It("should replace and delete groups", func() {
+			recorder := request(http.MethodPut, "/scim/v2/Groups/"+groupID, `{"displayName": "shop-users", "members": []}`)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(decode(recorder)).NotTo(HaveKey("members"))
+
+			Expect(request(http.MethodDelete, "/scim/v2/Groups/"+groupID, "").Code).To(Equal(http.StatusNoContent))
+			Expect(request(http.MethodGet, "/scim/v2/Groups/"+groupID, "").Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
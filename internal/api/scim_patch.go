@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+// errInvalidSCIMPatch is returned for PATCH operations that cannot be applied
+var errInvalidSCIMPatch = errors.New("invalid patch")
+
+// scimPatchPath is a parsed PATCH path such as emails[type eq "work"].value
+type scimPatchPath struct {
+	// attribute and subAttribute are lower case
+	attribute    string
+	filter       scimFilter
+	subAttribute string
+}
+
+// parseSCIMPatchPath parses a PATCH path. Filter values keep their case.
+func parseSCIMPatchPath(path string) (scimPatchPath, error) {
+	open := strings.IndexByte(path, '[')
+	if open < 0 {
+		attribute, subAttribute, _ := strings.Cut(normalizeSCIMPath(path), ".")
+		return scimPatchPath{attribute: attribute, subAttribute: subAttribute}, nil
+	}
+
+	end := strings.LastIndexByte(path, ']')
+	if end < open {
+		return scimPatchPath{}, fmt.Errorf("%w: unterminated value filter in path %q", errInvalidSCIMFilter, path)
+	}
+	filter, err := parseSCIMFilter(path[open+1 : end])
+	if err != nil {
+		return scimPatchPath{}, err
+	}
+	rest := path[end+1:]
+	if rest != "" && !strings.HasPrefix(rest, ".") {
+		return scimPatchPath{}, fmt.Errorf("%w: invalid path %q", errInvalidSCIMFilter, path)
+	}
+	return scimPatchPath{
+		attribute:    normalizeSCIMPath(path[:open]),
+		filter:       filter,
+		subAttribute: strings.ToLower(strings.TrimPrefix(rest, ".")),
+	}, nil
+}
+
+// applySCIMPatch applies a PATCH operation with the function applying it to
+// one attribute. Operations without a path apply to each attribute of their
+// value.
+func applySCIMPatch(operation scimPatchOperation, apply func(op string, path scimPatchPath, value json.RawMessage) error) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return fmt.Errorf("%w: unknown operation %q", errInvalidSCIMPatch, operation.Op)
+	}
+
+	if operation.Path != "" {
+		path, err := parseSCIMPatchPath(operation.Path)
+		if err != nil {
+			return err
+		}
+		return apply(op, path, operation.Value)
+	}
+
+	if op == "remove" {
+		return fmt.Errorf("%w: remove requires a path", errInvalidSCIMPatch)
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+		return fmt.Errorf("%w: operations without a path need an object value", errInvalidSCIMPatch)
+	}
+	for name, value := range attributes {
+		path, err := parseSCIMPatchPath(name)
+		if err != nil {
+			return err
+		}
+		if err := apply(op, path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applySCIMUserPatch applies a PATCH operation to a SCIM user. Attributes
+// that users do not have, such as phone numbers, are ignored, as they are
+// when users are created.
+func applySCIMUserPatch(user *scimUser, operation scimPatchOperation) error {
+	return applySCIMPatch(operation, func(op string, path scimPatchPath, value json.RawMessage) error {
+		remove := op == "remove"
+		switch path.attribute {
+		case "username":
+			return patchSCIMString(&user.UserName, remove, value)
+		case "externalid":
+			return patchSCIMString(&user.ExternalID, remove, value)
+		case "displayname":
+			return patchSCIMString(&user.DisplayName, remove, value)
+		case "active":
+			if remove {
+				user.Active = nil
+				return nil
+			}
+			active, err := decodeSCIMBool(value)
+			if err != nil {
+				return err
+			}
+			user.Active = &active
+		case "name":
+			return patchSCIMName(user, op, path.subAttribute, value)
+		case "emails":
+			return patchSCIMEmails(user, op, path, value)
+		}
+		return nil
+	})
+}
+
+// patchSCIMName patches a user's name or one of its parts
+func patchSCIMName(user *scimUser, op, subAttribute string, value json.RawMessage) error {
+	if user.Name == nil {
+		user.Name = &scimName{}
+	}
+	remove := op == "remove"
+	switch subAttribute {
+	case "":
+		if remove {
+			user.Name = nil
+			return nil
+		}
+		name := scimName{}
+		if op == "add" {
+			name = *user.Name
+		}
+		if err := json.Unmarshal(value, &name); err != nil {
+			return fmt.Errorf("%w: invalid name", errInvalidSCIMPatch)
+		}
+		user.Name = &name
+	case "formatted":
+		return patchSCIMString(&user.Name.Formatted, remove, value)
+	case "givenname":
+		return patchSCIMString(&user.Name.GivenName, remove, value)
+	case "familyname":
+		return patchSCIMString(&user.Name.FamilyName, remove, value)
+	}
+	return nil
+}
+
+// patchSCIMEmails patches a user's emails, or the emails matching the
+// path's filter. Values set for a filter that no email matches are added as
+// a new email.
+func patchSCIMEmails(user *scimUser, op string, path scimPatchPath, value json.RawMessage) error {
+	if path.subAttribute != "" && path.subAttribute != "value" {
+		return nil
+	}
+	matches := func(email scimEmail) bool {
+		return path.filter == nil || path.filter.matches(toSCIMAttributes(email))
+	}
+
+	if op == "remove" {
+		kept := user.Emails[:0]
+		for _, email := range user.Emails {
+			if !matches(email) {
+				kept = append(kept, email)
+			}
+		}
+		user.Emails = kept
+		return nil
+	}
+
+	if path.subAttribute == "value" {
+		var address string
+		if err := patchSCIMString(&address, false, value); err != nil {
+			return err
+		}
+		found := false
+		for i := range user.Emails {
+			if matches(user.Emails[i]) {
+				user.Emails[i].Value = address
+				found = true
+			}
+		}
+		if !found {
+			user.Emails = append(user.Emails, scimEmail{Value: address, Type: "work", Primary: len(user.Emails) == 0})
+		}
+		return nil
+	}
+
+	var emails []scimEmail
+	if err := json.Unmarshal(value, &emails); err != nil {
+		var email scimEmail
+		if err := json.Unmarshal(value, &email); err != nil {
+			return fmt.Errorf("%w: invalid emails", errInvalidSCIMPatch)
+		}
+		emails = []scimEmail{email}
+	}
+	if op == "add" {
+		user.Emails = append(user.Emails, emails...)
+		return nil
+	}
+	if path.filter == nil {
+		user.Emails = emails
+		return nil
+	}
+	kept := user.Emails[:0]
+	for _, email := range user.Emails {
+		if !matches(email) {
+			kept = append(kept, email)
+		}
+	}
+	user.Emails = append(kept, emails...)
+	return nil
+}
+
+// applySCIMGroupPatch applies a PATCH operation to a group. Members are
+// removed by value, by a filter such as members[value eq "id"], or all at
+// once.
+func applySCIMGroupPatch(group *authDomain.Group, operation scimPatchOperation) error {
+	return applySCIMPatch(operation, func(op string, path scimPatchPath, value json.RawMessage) error {
+		remove := op == "remove"
+		switch path.attribute {
+		case "displayname":
+			return patchSCIMString(&group.DisplayName, remove, value)
+		case "externalid":
+			return patchSCIMString(&group.ExternalID, remove, value)
+		case "members":
+			if path.subAttribute != "" && path.subAttribute != "value" {
+				return nil
+			}
+			var members []string
+			if !isSCIMNull(value) {
+				var err error
+				if members, err = decodeSCIMMembers(value); err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case op == "add":
+				group.Members = append(group.Members, members...)
+			case op == "replace" && path.filter == nil:
+				group.Members = members
+			case remove && path.filter == nil && len(members) == 0:
+				group.Members = []string{}
+			default:
+				// Remove, or replace, the members matching the filter or value
+				kept := make([]string, 0, len(group.Members))
+				for _, userID := range group.Members {
+					matched := containsMember(members, userID)
+					if path.filter != nil {
+						matched = path.filter.matches(map[string]interface{}{"value": userID})
+					}
+					if !matched {
+						kept = append(kept, userID)
+					}
+				}
+				if op == "replace" {
+					kept = append(kept, members...)
+				}
+				group.Members = kept
+			}
+		}
+		return nil
+	})
+}
+
+// decodeSCIMMembers decodes a list of members, or a single member, to their IDs
+func decodeSCIMMembers(value json.RawMessage) ([]string, error) {
+	var members []scimMember
+	if err := json.Unmarshal(value, &members); err != nil {
+		var member scimMember
+		if err := json.Unmarshal(value, &member); err != nil {
+			return nil, fmt.Errorf("%w: invalid members", errInvalidSCIMPatch)
+		}
+		members = []scimMember{member}
+	}
+
+	userIDs := make([]string, len(members))
+	for i, member := range members {
+		userIDs[i] = member.Value
+	}
+	return userIDs, nil
+}
+
+// containsMember checks if a user is in a list of members
+func containsMember(members []string, userID string) bool {
+	for _, member := range members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// patchSCIMString sets or clears a string attribute
+func patchSCIMString(target *string, remove bool, value json.RawMessage) error {
+	if remove || isSCIMNull(value) {
+		*target = ""
+		return nil
+	}
+	if err := json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("%w: expected a string, got %s", errInvalidSCIMPatch, value)
+	}
+	return nil
+}
+
+// decodeSCIMBool decodes a boolean, also accepting the strings "true" and
+// "false" in any case, which some identity providers send
+func decodeSCIMBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("%w: expected a boolean, got %s", errInvalidSCIMPatch, value)
+}
+
+// isSCIMNull checks if a PATCH value is missing or null
+func isSCIMNull(value json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(value)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
@@ -2,23 +2,33 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
 
 // SystemHandler handles system administration endpoints
 type SystemHandler struct {
 	*BaseHandler
+	checks       *health.Registry
+	auditService *auditApp.AuditService
 }
 
-// NewSystemHandler creates a new system handler
-func NewSystemHandler(logger *logging.Logger) *SystemHandler {
+// NewSystemHandler creates a new system handler. The audit service is nil
+// when the audit log is disabled.
+func NewSystemHandler(checks *health.Registry, auditService *auditApp.AuditService, logger *logging.Logger) *SystemHandler {
 	return &SystemHandler{
-		BaseHandler: NewBaseHandler(logger),
+		BaseHandler:  NewBaseHandler(logger),
+		checks:       checks,
+		auditService: auditService,
 	}
 }
 
@@ -43,25 +53,11 @@ func (h *SystemHandler) getSystemStats(c *gin.Context) {
 	h.respondWithJSON(c, http.StatusOK, stats)
 }
 
-// getSystemHealth handles GET /api/v1/admin/system/health
+// getSystemHealth handles GET /api/v1/admin/system/health with every check,
+// its latency and its last error. ?refresh=true bypasses cached results.
 func (h *SystemHandler) getSystemHealth(c *gin.Context) {
-	// TODO: Add actual health checks (database, redis, etc.)
-	health := gin.H{
-		"status": "healthy",
-		"checks": gin.H{
-			"database": gin.H{
-				"status":  "healthy",
-				"latency": "2ms",
-			},
-			"redis": gin.H{
-				"status":  "healthy",
-				"latency": "1ms",
-			},
-		},
-		"timestamp": time.Now().Unix(),
-	}
-
-	h.respondWithJSON(c, http.StatusOK, health)
+	refresh, _ := strconv.ParseBool(c.Query("refresh"))
+	h.respondWithJSON(c, http.StatusOK, h.checks.All(c.Request.Context(), refresh))
 }
 
 // performSystemCleanup handles POST /api/v1/admin/system/cleanup
@@ -76,16 +72,117 @@ func (h *SystemHandler) performSystemCleanup(c *gin.Context) {
 	})
 }
 
-// getAuditLogs handles GET /api/v1/admin/audit-logs
+// getAuditLogs handles GET /api/v1/admin/audit-logs, newest first, filtered by
+// actorId, action, targetType, targetId, source and an RFC 3339 from/to range,
+// and paged with limit and offset
 func (h *SystemHandler) getAuditLogs(c *gin.Context) {
-	// TODO: Implement audit log retrieval
-	// For now, return empty logs
-	logs := gin.H{
-		"items": []gin.H{},
-		"total": 0,
+	filter, err := auditFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if h.auditService == nil {
+		h.respondWithJSON(c, http.StatusOK, gin.H{
+			"items":  []*auditDomain.Entry{},
+			"total":  0,
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+		})
+		return
+	}
+
+	entries, total, err := h.auditService.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list audit logs")
+		h.respondWithError(c, http.StatusInternalServerError, "Failed to list audit logs")
+		return
 	}
 
-	h.respondWithJSON(c, http.StatusOK, logs)
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditApp.DefaultPageSize
+	}
+	if limit > auditApp.MaxPageSize {
+		limit = auditApp.MaxPageSize
+	}
+	h.respondWithJSON(c, http.StatusOK, gin.H{
+		"items":  entries,
+		"total":  total,
+		"limit":  limit,
+		"offset": filter.Offset,
+	})
+}
+
+// exportAuditLogs handles GET /api/v1/admin/audit-logs/export, downloading
+// every entry matching the same filters as getAuditLogs as CSV or JSON
+// (?format=csv|json, CSV by default)
+func (h *SystemHandler) exportAuditLogs(c *gin.Context) {
+	filter, err := auditFilter(c)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if h.auditService == nil {
+		h.respondWithError(c, http.StatusNotFound, "Audit log is disabled")
+		return
+	}
+
+	format := auditApp.ExportFormat(c.DefaultQuery("format", string(auditApp.ExportCSV)))
+	contentType := "text/csv"
+	switch format {
+	case auditApp.ExportCSV:
+	case auditApp.ExportJSON:
+		contentType = "application/json"
+	default:
+		h.respondWithError(c, http.StatusBadRequest, "format must be csv or json")
+		return
+	}
+
+	filename := fmt.Sprintf("audit-log-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	if err := h.auditService.Export(c.Request.Context(), filter, format, c.Writer); err != nil {
+		// The response has started, so the download is left incomplete
+		h.logger.WithError(err).Error("Failed to export audit logs")
+	}
+}
+
+// auditFilter reads audit log filters from the query string
+func auditFilter(c *gin.Context) (auditDomain.Filter, error) {
+	filter := auditDomain.Filter{
+		ActorID:    c.Query("actorId"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("targetType"),
+		TargetID:   c.Query("targetId"),
+		Source:     auditDomain.Source(c.Query("source")),
+	}
+
+	for name, bound := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 time", name)
+		}
+		*bound = &t
+	}
+
+	for name, number := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		*number = n
+	}
+	return filter, nil
 }
 
 // RegisterRoutes registers system routes
@@ -94,4 +191,5 @@ func (h *SystemHandler) RegisterRoutes(adminGroup *gin.RouterGroup) {
 	adminGroup.GET("/system/health", h.getSystemHealth)
 	adminGroup.POST("/system/cleanup", h.performSystemCleanup)
 	adminGroup.GET("/audit-logs", h.getAuditLogs)
+	adminGroup.GET("/audit-logs/export", h.exportAuditLogs)
 }
\ No newline at end of file
@@ -11,6 +11,8 @@ import (
 	tagsApp "github.com/guidewire-oss/fern-platform/internal/domains/tags/application"
 	tagsDomain "github.com/guidewire-oss/fern-platform/internal/domains/tags/domain"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // TagHandler handles tag related endpoints
@@ -28,7 +30,10 @@ func NewTagHandler(tagService *tagsApp.TagService, logger *logging.Logger) *TagH
 }
 
 // ProcessTestRunTags processes tags for all suite runs and spec runs in a test run request
-func ProcessTestRunTags(c context.Context, tagService *tagsApp.TagService, req *TestRunRequest) error {
+func ProcessTestRunTags(c context.Context, tagService *tagsApp.TagService, req *TestRunRequest) (err error) {
+	c, span := tracing.Start(c, "ProcessTestRunTags", attribute.String("fern.project_id", req.TestProjectID))
+	defer func() { tracing.End(span, err) }()
+
 	// Process run-level tags first
 	if len(req.Tags) > 0 {
 		processedTags, err := processTagList(c, tagService, req.Tags)
@@ -192,6 +192,14 @@ func (m *MockSpecRunRepository) FindBySuiteRunID(ctx context.Context, suiteRunID
 	return args.Get(0).([]*domain.SpecRun), args.Error(1)
 }
 
+func (m *MockSpecRunRepository) FindFailures(ctx context.Context, filter domain.FailureFilter) ([]*domain.SpecFailure, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SpecFailure), args.Error(1)
+}
+
 func (m *MockSpecRunRepository) GetFailedByTestRunID(ctx context.Context, testRunID uint, limit int) ([]*domain.SpecRun, error) {
 	args := m.Called(ctx, testRunID, limit)
 	if args.Get(0) == nil {
@@ -0,0 +1,452 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+// TrackerConnectionHandler handles issue tracker connection HTTP requests
+type TrackerConnectionHandler struct {
+	*BaseHandler
+	trackerService *integrations.TrackerConnectionService
+	projectService *projectsApp.ProjectService
+}
+
+// NewTrackerConnectionHandler creates a new issue tracker connection handler
+func NewTrackerConnectionHandler(
+	baseHandler *BaseHandler,
+	trackerService *integrations.TrackerConnectionService,
+	projectService *projectsApp.ProjectService,
+) *TrackerConnectionHandler {
+	return &TrackerConnectionHandler{
+		BaseHandler:    baseHandler,
+		trackerService: trackerService,
+		projectService: projectService,
+	}
+}
+
+// ConnectorTypeResponse represents a connector type and its config schema
+type ConnectorTypeResponse struct {
+	Type            string                `json:"type"`
+	DisplayName     string                `json:"displayName"`
+	Description     string                `json:"description"`
+	CredentialLabel string                `json:"credentialLabel"`
+	ConfigFields    []ConfigFieldResponse `json:"configFields"`
+}
+
+// ConfigFieldResponse represents one setting of a connector type
+type ConfigFieldResponse struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// CreateTrackerConnectionRequest represents the request to create an issue tracker connection
+type CreateTrackerConnectionRequest struct {
+	Name          string            `json:"name" binding:"required"`
+	ConnectorType string            `json:"connectorType" binding:"required"`
+	Config        map[string]string `json:"config"`
+	Credential    string            `json:"credential" binding:"required"`
+}
+
+// UpdateTrackerConnectionRequest represents the request to update an issue tracker connection
+type UpdateTrackerConnectionRequest struct {
+	Name   string            `json:"name" binding:"required"`
+	Config map[string]string `json:"config"`
+}
+
+// UpdateTrackerCredentialRequest represents the request to replace a connection's credential
+type UpdateTrackerCredentialRequest struct {
+	Credential string `json:"credential" binding:"required"`
+}
+
+// TrackerConnectionResponse represents an issue tracker connection response
+type TrackerConnectionResponse struct {
+	ID            string            `json:"id"`
+	ProjectID     string            `json:"projectId"`
+	Name          string            `json:"name"`
+	ConnectorType string            `json:"connectorType"`
+	Config        map[string]string `json:"config"`
+	Status        string            `json:"status"`
+	LastError     string            `json:"lastError,omitempty"`
+	IsActive      bool              `json:"isActive"`
+	LastTestedAt  *string           `json:"lastTestedAt,omitempty"`
+	CreatedAt     string            `json:"createdAt"`
+	UpdatedAt     string            `json:"updatedAt"`
+}
+
+// CreateTrackerIssueRequest represents the request to file an issue through a connection
+type CreateTrackerIssueRequest struct {
+	Title       string   `json:"title" binding:"required"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+// TrackerIssueResponse represents an issue filed through a connection
+type TrackerIssueResponse struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+// TrackerIssueStatusResponse represents the workflow state of an issue
+type TrackerIssueStatusResponse struct {
+	Key        string  `json:"key"`
+	Status     string  `json:"status"`
+	State      string  `json:"state"`
+	ResolvedAt *string `json:"resolvedAt,omitempty"`
+}
+
+// AddTrackerCommentRequest represents the request to comment on an issue
+type AddTrackerCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// GetConnectorTypes lists the issue trackers connections can be created for
+func (h *TrackerConnectionHandler) GetConnectorTypes(c *gin.Context) {
+	definitions := h.trackerService.ConnectorTypes()
+
+	responses := make([]ConnectorTypeResponse, len(definitions))
+	for i, definition := range definitions {
+		fields := make([]ConfigFieldResponse, len(definition.ConfigFields))
+		for j, field := range definition.ConfigFields {
+			fields[j] = ConfigFieldResponse{
+				Key:         field.Key,
+				Label:       field.Label,
+				Description: field.Description,
+				Type:        string(field.Type),
+				Required:    field.Required,
+				Default:     field.Default,
+				Options:     field.Options,
+			}
+		}
+		responses[i] = ConnectorTypeResponse{
+			Type:            string(definition.Type),
+			DisplayName:     definition.DisplayName,
+			Description:     definition.Description,
+			CredentialLabel: definition.CredentialLabel,
+			ConfigFields:    fields,
+		}
+	}
+
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// GetConnections retrieves all issue tracker connections for a project
+func (h *TrackerConnectionHandler) GetConnections(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionRead) {
+		return
+	}
+
+	connections, err := h.trackerService.GetProjectConnections(c.Request.Context(), projectID)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]TrackerConnectionResponse, len(connections))
+	for i, conn := range connections {
+		responses[i] = *h.convertToResponse(conn)
+	}
+
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// CreateConnection creates a new issue tracker connection
+func (h *TrackerConnectionHandler) CreateConnection(c *gin.Context) {
+	projectID := c.Param("projectId")
+
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return
+	}
+
+	var req CreateTrackerConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	connection, err := h.trackerService.CreateConnection(
+		c.Request.Context(),
+		projectID,
+		req.Name,
+		integrations.ConnectorType(req.ConnectorType),
+		integrations.ConnectorConfig(req.Config),
+		req.Credential,
+	)
+	if err != nil {
+		h.ErrorResponse(c, connectionErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, h.convertToResponse(connection))
+}
+
+// UpdateConnection renames a connection and replaces its config
+func (h *TrackerConnectionHandler) UpdateConnection(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	var req UpdateTrackerConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.trackerService.UpdateConnection(c.Request.Context(), connection.ID(), req.Name, integrations.ConnectorConfig(req.Config))
+	if err != nil {
+		h.ErrorResponse(c, connectionErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// UpdateCredential replaces the credential of a connection
+func (h *TrackerConnectionHandler) UpdateCredential(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	var req UpdateTrackerCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.trackerService.UpdateCredential(c.Request.Context(), connection.ID(), req.Credential)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// TestConnection tests a connection
+func (h *TrackerConnectionHandler) TestConnection(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	if err := h.trackerService.TestConnection(c.Request.Context(), connection.ID()); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Connection test successful"})
+}
+
+// ActivateConnection activates a connection
+func (h *TrackerConnectionHandler) ActivateConnection(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	if err := h.trackerService.ActivateConnection(c.Request.Context(), connection.ID()); err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Connection activated"})
+}
+
+// DeactivateConnection deactivates a connection
+func (h *TrackerConnectionHandler) DeactivateConnection(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	if err := h.trackerService.DeactivateConnection(c.Request.Context(), connection.ID()); err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, gin.H{"message": "Connection deactivated"})
+}
+
+// DeleteConnection deletes a connection
+func (h *TrackerConnectionHandler) DeleteConnection(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	if err := h.trackerService.DeleteConnection(c.Request.Context(), connection.ID()); err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusNoContent, nil)
+}
+
+// CreateIssue files an issue through a connection
+func (h *TrackerConnectionHandler) CreateIssue(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	var req CreateTrackerIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	issue, err := h.trackerService.CreateIssue(c.Request.Context(), connection.ID(), integrations.TrackerIssueRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		Labels:      req.Labels,
+	})
+	if err != nil {
+		h.issueErrorResponse(c, err, "Failed to create tracker issue")
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, TrackerIssueResponse{
+		ID:  issue.ID,
+		Key: issue.Key,
+		URL: issue.URL,
+	})
+}
+
+// GetIssueStatus reads the workflow state of an issue through a connection
+func (h *TrackerConnectionHandler) GetIssueStatus(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionRead)
+	if !ok {
+		return
+	}
+
+	status, err := h.trackerService.GetIssueStatus(c.Request.Context(), connection.ID(), c.Param("issueKey"))
+	if err != nil {
+		h.issueErrorResponse(c, err, "Failed to get tracker issue status")
+		return
+	}
+
+	response := TrackerIssueStatusResponse{
+		Key:    status.Key,
+		Status: status.Status,
+		State:  string(status.State),
+	}
+	if status.ResolvedAt != nil {
+		resolvedAt := status.ResolvedAt.Format(time.RFC3339)
+		response.ResolvedAt = &resolvedAt
+	}
+	h.respondWithJSON(c, http.StatusOK, response)
+}
+
+// AddComment comments on an issue through a connection
+func (h *TrackerConnectionHandler) AddComment(c *gin.Context) {
+	connection, ok := h.authorizeConnection(c, projectsDomain.PermissionWrite)
+	if !ok {
+		return
+	}
+
+	var req AddTrackerCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.trackerService.AddComment(c.Request.Context(), connection.ID(), c.Param("issueKey"), req.Body); err != nil {
+		h.issueErrorResponse(c, err, "Failed to comment on tracker issue")
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, gin.H{"message": "Comment added"})
+}
+
+// issueErrorResponse writes the response for a failed issue operation
+func (h *TrackerConnectionHandler) issueErrorResponse(c *gin.Context, err error, message string) {
+	if errors.Is(err, integrations.ErrTrackerConnectionInactive) {
+		h.ErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	}
+	h.logger.WithError(err).Warn(message)
+	h.ErrorResponse(c, http.StatusBadGateway, err.Error())
+}
+
+// connectionErrorStatus maps connection validation errors to 400 and anything else to 500
+func connectionErrorStatus(err error) int {
+	if errors.Is(err, integrations.ErrUnknownConnectorType) || errors.Is(err, integrations.ErrInvalidConnectorConfig) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// authorizeConnection loads the connection named in the path and checks that
+// it belongs to the project and that the user has at least the required
+// permission on the project. It writes an error response and returns false otherwise.
+func (h *TrackerConnectionHandler) authorizeConnection(c *gin.Context, required projectsDomain.PermissionType) (*integrations.TrackerConnection, bool) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	connection, err := h.trackerService.GetConnection(c.Request.Context(), c.Param("connectionId"))
+	if err != nil || connection.ProjectID() != c.Param("projectId") {
+		h.ErrorResponse(c, http.StatusNotFound, "connection not found")
+		return nil, false
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, connection.ProjectID(), required) {
+		return nil, false
+	}
+	return connection, true
+}
+
+// convertToResponse converts a domain entity to response format
+func (h *TrackerConnectionHandler) convertToResponse(conn *integrations.TrackerConnection) *TrackerConnectionResponse {
+	snapshot := conn.Snapshot()
+
+	var lastTested *string
+	if snapshot.LastTestedAt != nil {
+		formatted := snapshot.LastTestedAt.Format(time.RFC3339)
+		lastTested = &formatted
+	}
+
+	return &TrackerConnectionResponse{
+		ID:            snapshot.ID,
+		ProjectID:     snapshot.ProjectID,
+		Name:          snapshot.Name,
+		ConnectorType: string(snapshot.ConnectorType),
+		Config:        snapshot.Config,
+		Status:        string(snapshot.Status),
+		LastError:     snapshot.LastError,
+		IsActive:      snapshot.IsActive,
+		LastTestedAt:  lastTested,
+		CreatedAt:     snapshot.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     snapshot.UpdatedAt.Format(time.RFC3339),
+	}
+}
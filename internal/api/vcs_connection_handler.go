@@ -0,0 +1,390 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+const (
+	// defaultVCSReportLimit is how many reports the report log returns by default
+	defaultVCSReportLimit = 50
+
+	// maxVCSReportLimit caps how many reports the report log returns
+	maxVCSReportLimit = 200
+)
+
+// VCSConnectionHandler handles the GitHub and GitLab connections of a
+// project, which are managed by project managers
+type VCSConnectionHandler struct {
+	*BaseHandler
+	vcsReportService *integrations.VCSReportService
+	projectService   *projectsApp.ProjectService
+}
+
+// NewVCSConnectionHandler creates a new VCS connection handler
+func NewVCSConnectionHandler(
+	baseHandler *BaseHandler,
+	vcsReportService *integrations.VCSReportService,
+	projectService *projectsApp.ProjectService,
+) *VCSConnectionHandler {
+	return &VCSConnectionHandler{
+		BaseHandler:      baseHandler,
+		vcsReportService: vcsReportService,
+		projectService:   projectService,
+	}
+}
+
+// VCSConnectionRequest represents the request to create or update a VCS
+// connection. The token may be left out of updates to keep the current one;
+// comments on pull requests are enabled unless turned off.
+type VCSConnectionRequest struct {
+	Name                  string `json:"name" binding:"required"`
+	Provider              string `json:"provider" binding:"required"`
+	APIURL                string `json:"apiUrl,omitempty"`
+	Repository            string `json:"repository" binding:"required"`
+	Token                 string `json:"token"`
+	StatusContext         string `json:"statusContext,omitempty"`
+	StatusMode            string `json:"statusMode,omitempty"`
+	CommentOnPullRequests *bool  `json:"commentOnPullRequests,omitempty"`
+}
+
+// VCSConnectionResponse represents a VCS connection. The token is a
+// credential and is never returned.
+type VCSConnectionResponse struct {
+	ID                    string  `json:"id"`
+	ProjectID             string  `json:"projectId"`
+	Name                  string  `json:"name"`
+	Provider              string  `json:"provider"`
+	APIURL                string  `json:"apiUrl"`
+	Repository            string  `json:"repository"`
+	StatusContext         string  `json:"statusContext"`
+	StatusMode            string  `json:"statusMode"`
+	CommentOnPullRequests bool    `json:"commentOnPullRequests"`
+	Status                string  `json:"status"`
+	LastError             string  `json:"lastError,omitempty"`
+	IsActive              bool    `json:"isActive"`
+	LastTestedAt          *string `json:"lastTestedAt,omitempty"`
+	CreatedBy             string  `json:"createdBy"`
+	CreatedAt             string  `json:"createdAt"`
+	UpdatedAt             string  `json:"updatedAt"`
+}
+
+// VCSReportResponse represents an entry of the report log
+type VCSReportResponse struct {
+	ID            string   `json:"id"`
+	ConnectionID  string   `json:"connectionId"`
+	TestRunID     uint     `json:"testRunId"`
+	RunID         string   `json:"runId"`
+	CommitSHA     string   `json:"commitSha"`
+	Final         bool     `json:"final"`
+	Status        string   `json:"status"`
+	Attempts      int      `json:"attempts"`
+	NextAttemptAt *string  `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt *string  `json:"lastAttemptAt,omitempty"`
+	LastError     string   `json:"lastError,omitempty"`
+	State         string   `json:"state,omitempty"`
+	CommentURLs   []string `json:"commentUrls,omitempty"`
+	CreatedAt     string   `json:"createdAt"`
+}
+
+// RegisterRoutes registers VCS connection routes for managers
+func (h *VCSConnectionHandler) RegisterRoutes(managerGroup *gin.RouterGroup) {
+	connections := managerGroup.Group("/projects/:projectId/integrations/vcs/connections")
+	connections.GET("", h.GetConnections)
+	connections.POST("", h.CreateConnection)
+	connections.GET("/:connectionId", h.GetConnection)
+	connections.PUT("/:connectionId", h.UpdateConnection)
+	connections.DELETE("/:connectionId", h.DeleteConnection)
+	connections.POST("/:connectionId/test", h.TestConnection)
+	connections.POST("/:connectionId/activate", h.ActivateConnection)
+	connections.POST("/:connectionId/deactivate", h.DeactivateConnection)
+	connections.GET("/:connectionId/reports", h.GetReports)
+}
+
+// GetConnections lists the VCS connections of a project
+func (h *VCSConnectionHandler) GetConnections(c *gin.Context) {
+	if _, ok := h.authorizeProject(c); !ok {
+		return
+	}
+
+	connections, err := h.vcsReportService.ListConnections(c.Request.Context(), c.Param("projectId"))
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]VCSConnectionResponse, len(connections))
+	for i, conn := range connections {
+		responses[i] = h.convertToResponse(conn)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// CreateConnection creates a VCS connection
+func (h *VCSConnectionHandler) CreateConnection(c *gin.Context) {
+	userID, ok := h.authorizeProject(c)
+	if !ok {
+		return
+	}
+
+	var req VCSConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Token == "" {
+		h.ErrorResponse(c, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	conn, err := h.vcsReportService.CreateConnection(c.Request.Context(), c.Param("projectId"), req.settings(), req.Token, userID)
+	if err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, h.convertToResponse(conn))
+}
+
+// GetConnection retrieves a VCS connection
+func (h *VCSConnectionHandler) GetConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(conn))
+}
+
+// UpdateConnection replaces the settings of a VCS connection
+func (h *VCSConnectionHandler) UpdateConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	var req VCSConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.vcsReportService.UpdateConnection(c.Request.Context(), conn.ID(), req.settings(), req.Token)
+	if err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DeleteConnection deletes a VCS connection along with its report log
+func (h *VCSConnectionHandler) DeleteConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	if err := h.vcsReportService.DeleteConnection(c.Request.Context(), conn.ID()); err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusNoContent, nil)
+}
+
+// TestConnection checks that the token can see the repository
+func (h *VCSConnectionHandler) TestConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	tested, err := h.vcsReportService.TestConnection(c.Request.Context(), conn.ID())
+	if err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(tested))
+}
+
+// ActivateConnection resumes reporting test runs
+func (h *VCSConnectionHandler) ActivateConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.vcsReportService.ActivateConnection(c.Request.Context(), conn.ID())
+	if err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DeactivateConnection stops reporting test runs
+func (h *VCSConnectionHandler) DeactivateConnection(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.vcsReportService.DeactivateConnection(c.Request.Context(), conn.ID())
+	if err != nil {
+		h.ErrorResponse(c, vcsErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// GetReports retrieves the report log of a connection, newest first
+func (h *VCSConnectionHandler) GetReports(c *gin.Context) {
+	conn, ok := h.authorizeConnection(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultVCSReportLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.ErrorResponse(c, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = min(parsed, maxVCSReportLimit)
+	}
+
+	reports, err := h.vcsReportService.ListReports(c.Request.Context(), conn.ID(), limit)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]VCSReportResponse, len(reports))
+	for i, report := range reports {
+		responses[i] = h.convertReportToResponse(report)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// authorizeProject checks that the user can manage the project in the path.
+// It writes an error response and returns false otherwise.
+func (h *VCSConnectionHandler) authorizeProject(c *gin.Context) (string, bool) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return "", false
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, c.Param("projectId"), projectsDomain.PermissionWrite) {
+		return "", false
+	}
+	return userID, true
+}
+
+// authorizeConnection loads the connection named in the path and checks that
+// it belongs to the project in the path and that the user can manage it. It
+// writes an error response and returns false otherwise.
+func (h *VCSConnectionHandler) authorizeConnection(c *gin.Context) (*integrations.VCSConnection, bool) {
+	if _, ok := h.authorizeProject(c); !ok {
+		return nil, false
+	}
+
+	conn, err := h.vcsReportService.GetConnection(c.Request.Context(), c.Param("connectionId"))
+	if err != nil || conn.ProjectID() != c.Param("projectId") {
+		h.ErrorResponse(c, http.StatusNotFound, "VCS connection not found")
+		return nil, false
+	}
+	return conn, true
+}
+
+// vcsErrorStatus maps VCS connection errors to HTTP status codes
+func vcsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, integrations.ErrInvalidVCSConnection):
+		return http.StatusBadRequest
+	case errors.Is(err, integrations.ErrVCSConnectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, integrations.ErrVCSConnectionFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// settings converts the request to connection settings
+func (r VCSConnectionRequest) settings() integrations.VCSConnectionSettings {
+	commentOnPullRequests := true
+	if r.CommentOnPullRequests != nil {
+		commentOnPullRequests = *r.CommentOnPullRequests
+	}
+
+	return integrations.VCSConnectionSettings{
+		Name:                  r.Name,
+		Provider:              integrations.VCSProviderType(r.Provider),
+		APIURL:                r.APIURL,
+		Repository:            r.Repository,
+		StatusContext:         r.StatusContext,
+		StatusMode:            integrations.VCSStatusMode(r.StatusMode),
+		CommentOnPullRequests: commentOnPullRequests,
+	}
+}
+
+// convertToResponse converts a domain entity to response format
+func (h *VCSConnectionHandler) convertToResponse(conn *integrations.VCSConnection) VCSConnectionResponse {
+	snapshot := conn.Snapshot()
+	settings := snapshot.Settings
+
+	return VCSConnectionResponse{
+		ID:                    snapshot.ID,
+		ProjectID:             snapshot.ProjectID,
+		Name:                  settings.Name,
+		Provider:              string(settings.Provider),
+		APIURL:                settings.APIURL,
+		Repository:            settings.Repository,
+		StatusContext:         settings.StatusContext,
+		StatusMode:            string(settings.StatusMode),
+		CommentOnPullRequests: settings.CommentOnPullRequests,
+		Status:                string(snapshot.Status),
+		LastError:             snapshot.LastError,
+		IsActive:              snapshot.IsActive,
+		LastTestedAt:          formatOptionalTime(snapshot.LastTestedAt),
+		CreatedBy:             snapshot.CreatedBy,
+		CreatedAt:             snapshot.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             snapshot.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// convertReportToResponse converts a report to response format
+func (h *VCSConnectionHandler) convertReportToResponse(report *integrations.VCSReport) VCSReportResponse {
+	response := VCSReportResponse{
+		ID:            report.ID,
+		ConnectionID:  report.ConnectionID,
+		TestRunID:     report.TestRunID,
+		RunID:         report.RunID,
+		CommitSHA:     report.CommitSHA,
+		Final:         report.Final,
+		Status:        string(report.Status),
+		Attempts:      report.Attempts,
+		LastAttemptAt: formatOptionalTime(report.LastAttemptAt),
+		LastError:     report.LastError,
+		State:         string(report.State),
+		CommentURLs:   report.CommentURLs,
+		CreatedAt:     report.CreatedAt.Format(time.RFC3339),
+	}
+	if report.Status == integrations.VCSReportPending {
+		response.NextAttemptAt = formatOptionalTime(&report.NextAttemptAt)
+	}
+	return response
+}
@@ -0,0 +1,464 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+const (
+	// defaultWebhookDeliveryLimit is how many deliveries the delivery log returns by default
+	defaultWebhookDeliveryLimit = 50
+
+	// maxWebhookDeliveryLimit caps how many deliveries the delivery log returns
+	maxWebhookDeliveryLimit = 200
+)
+
+// WebhookHandler handles webhook subscription HTTP requests. Project
+// webhooks are managed by project managers; global webhooks, which receive
+// events from every project, by admins.
+type WebhookHandler struct {
+	*BaseHandler
+	webhookService *integrations.WebhookService
+	projectService *projectsApp.ProjectService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(
+	baseHandler *BaseHandler,
+	webhookService *integrations.WebhookService,
+	projectService *projectsApp.ProjectService,
+) *WebhookHandler {
+	return &WebhookHandler{
+		BaseHandler:    baseHandler,
+		webhookService: webhookService,
+		projectService: projectService,
+	}
+}
+
+// WebhookRequest represents the request to create or update a webhook
+type WebhookRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+}
+
+// WebhookResponse represents a webhook subscription
+type WebhookResponse struct {
+	ID                  string   `json:"id"`
+	ProjectID           string   `json:"projectId,omitempty"`
+	Name                string   `json:"name"`
+	URL                 string   `json:"url"`
+	Events              []string `json:"events"`
+	IsActive            bool     `json:"isActive"`
+	DisabledReason      string   `json:"disabledReason,omitempty"`
+	ConsecutiveFailures int      `json:"consecutiveFailures"`
+	LastDeliveryAt      *string  `json:"lastDeliveryAt,omitempty"`
+	CreatedBy           string   `json:"createdBy"`
+	CreatedAt           string   `json:"createdAt"`
+	UpdatedAt           string   `json:"updatedAt"`
+}
+
+// WebhookSecretResponse represents a webhook with its signing secret, which is
+// only returned when the webhook is created or its secret rotated
+type WebhookSecretResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse represents an entry of the delivery log
+type WebhookDeliveryResponse struct {
+	ID             string  `json:"id"`
+	WebhookID      string  `json:"webhookId"`
+	EventID        string  `json:"eventId"`
+	EventType      string  `json:"eventType"`
+	ProjectID      string  `json:"projectId"`
+	Payload        string  `json:"payload"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	NextAttemptAt  *string `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *string `json:"lastAttemptAt,omitempty"`
+	ResponseStatus int     `json:"responseStatus,omitempty"`
+	ResponseBody   string  `json:"responseBody,omitempty"`
+	LastError      string  `json:"lastError,omitempty"`
+	DurationMs     int64   `json:"durationMs"`
+	RedeliveryOf   string  `json:"redeliveryOf,omitempty"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+// RegisterRoutes registers project webhook routes for managers and global webhook routes for admins
+func (h *WebhookHandler) RegisterRoutes(managerGroup, adminGroup *gin.RouterGroup) {
+	managerGroup.GET("/webhooks/event-types", h.GetEventTypes)
+	h.registerSubscriptionRoutes(managerGroup.Group("/projects/:projectId/webhooks"))
+	h.registerSubscriptionRoutes(adminGroup.Group("/webhooks"))
+}
+
+// registerSubscriptionRoutes registers the routes shared by project and global webhooks
+func (h *WebhookHandler) registerSubscriptionRoutes(webhooks *gin.RouterGroup) {
+	webhooks.GET("", h.GetWebhooks)
+	webhooks.POST("", h.CreateWebhook)
+	webhooks.GET("/:webhookId", h.GetWebhook)
+	webhooks.PUT("/:webhookId", h.UpdateWebhook)
+	webhooks.DELETE("/:webhookId", h.DeleteWebhook)
+	webhooks.POST("/:webhookId/enable", h.EnableWebhook)
+	webhooks.POST("/:webhookId/disable", h.DisableWebhook)
+	webhooks.POST("/:webhookId/rotate-secret", h.RotateSecret)
+	webhooks.GET("/:webhookId/deliveries", h.GetDeliveries)
+	webhooks.GET("/:webhookId/deliveries/:deliveryId", h.GetDelivery)
+	webhooks.POST("/:webhookId/deliveries/:deliveryId/redeliver", h.Redeliver)
+}
+
+// GetEventTypes lists the events webhooks can subscribe to
+func (h *WebhookHandler) GetEventTypes(c *gin.Context) {
+	eventTypes := integrations.WebhookEventTypes()
+
+	names := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		names[i] = string(eventType)
+	}
+	h.respondWithJSON(c, http.StatusOK, names)
+}
+
+// GetWebhooks lists the webhooks of a project, or the global webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	if _, ok := h.authorizeScope(c); !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), c.Param("projectId"))
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = h.convertToResponse(subscription)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// CreateWebhook creates a webhook and returns its signing secret
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, ok := h.authorizeScope(c)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subscription, secret, err := h.webhookService.CreateSubscription(
+		c.Request.Context(),
+		c.Param("projectId"),
+		req.Name,
+		req.URL,
+		toWebhookEventTypes(req.Events),
+		userID,
+	)
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusCreated, WebhookSecretResponse{
+		WebhookResponse: h.convertToResponse(subscription),
+		Secret:          secret,
+	})
+}
+
+// GetWebhook retrieves a webhook
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(subscription))
+}
+
+// UpdateWebhook replaces the name, URL and events of a webhook
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.webhookService.UpdateSubscription(c.Request.Context(), subscription.ID(), req.Name, req.URL, toWebhookEventTypes(req.Events))
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DeleteWebhook deletes a webhook along with its delivery log
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), subscription.ID()); err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusNoContent, nil)
+}
+
+// EnableWebhook re-enables a webhook, including one disabled for failing deliveries
+func (h *WebhookHandler) EnableWebhook(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.webhookService.EnableSubscription(c.Request.Context(), subscription.ID())
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// DisableWebhook stops deliveries to a webhook
+func (h *WebhookHandler) DisableWebhook(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.webhookService.DisableSubscription(c.Request.Context(), subscription.ID())
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertToResponse(updated))
+}
+
+// RotateSecret replaces the signing secret of a webhook and returns the new secret
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	updated, secret, err := h.webhookService.RotateSecret(c.Request.Context(), subscription.ID())
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, WebhookSecretResponse{
+		WebhookResponse: h.convertToResponse(updated),
+		Secret:          secret,
+	})
+}
+
+// GetDeliveries retrieves the delivery log of a webhook, newest first
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultWebhookDeliveryLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.ErrorResponse(c, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		limit = min(parsed, maxWebhookDeliveryLimit)
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), subscription.ID(), limit)
+	if err != nil {
+		h.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = h.convertDeliveryToResponse(delivery)
+	}
+	h.respondWithJSON(c, http.StatusOK, responses)
+}
+
+// GetDelivery retrieves one entry of the delivery log
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	delivery, ok := h.authorizeDelivery(c)
+	if !ok {
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusOK, h.convertDeliveryToResponse(delivery))
+}
+
+// Redeliver queues the payload of a delivery again
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	delivery, ok := h.authorizeDelivery(c)
+	if !ok {
+		return
+	}
+
+	redelivery, err := h.webhookService.Redeliver(c.Request.Context(), delivery.ID)
+	if err != nil {
+		h.ErrorResponse(c, webhookErrorStatus(err), err.Error())
+		return
+	}
+
+	h.respondWithJSON(c, http.StatusAccepted, h.convertDeliveryToResponse(redelivery))
+}
+
+// authorizeScope checks that the user can manage the project in the path. On
+// the admin routes there is no project in the path and the admin group has
+// already authorized the user. It writes an error response and returns false otherwise.
+func (h *WebhookHandler) authorizeScope(c *gin.Context) (string, bool) {
+	userID := h.getUserID(c)
+	if userID == "" {
+		h.ErrorResponse(c, http.StatusUnauthorized, "unauthorized")
+		return "", false
+	}
+
+	projectID := c.Param("projectId")
+	if projectID == "" {
+		return userID, true
+	}
+
+	if !h.requireProjectAccess(c, h.projectService, projectID, projectsDomain.PermissionWrite) {
+		return "", false
+	}
+	return userID, true
+}
+
+// authorizeWebhook loads the webhook named in the path and checks that it
+// belongs to the project in the path, or is global on the admin routes, and
+// that the user can manage it. It writes an error response and returns false otherwise.
+func (h *WebhookHandler) authorizeWebhook(c *gin.Context) (*integrations.WebhookSubscription, bool) {
+	if _, ok := h.authorizeScope(c); !ok {
+		return nil, false
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), c.Param("webhookId"))
+	if err != nil || subscription.ProjectID() != c.Param("projectId") {
+		h.ErrorResponse(c, http.StatusNotFound, "webhook not found")
+		return nil, false
+	}
+	return subscription, true
+}
+
+// authorizeDelivery loads the delivery named in the path and checks that it
+// belongs to a webhook the user can manage. It writes an error response and returns false otherwise.
+func (h *WebhookHandler) authorizeDelivery(c *gin.Context) (*integrations.WebhookDelivery, bool) {
+	subscription, ok := h.authorizeWebhook(c)
+	if !ok {
+		return nil, false
+	}
+
+	delivery, err := h.webhookService.GetDelivery(c.Request.Context(), c.Param("deliveryId"))
+	if err != nil || delivery.SubscriptionID != subscription.ID() {
+		h.ErrorResponse(c, http.StatusNotFound, "webhook delivery not found")
+		return nil, false
+	}
+	return delivery, true
+}
+
+// webhookErrorStatus maps webhook errors to HTTP status codes
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, integrations.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	case errors.Is(err, integrations.ErrWebhookNotFound), errors.Is(err, integrations.ErrWebhookDeliveryNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// toWebhookEventTypes converts event names from a request
+func toWebhookEventTypes(names []string) []integrations.WebhookEventType {
+	eventTypes := make([]integrations.WebhookEventType, len(names))
+	for i, name := range names {
+		eventTypes[i] = integrations.WebhookEventType(name)
+	}
+	return eventTypes
+}
+
+// convertToResponse converts a domain entity to response format
+func (h *WebhookHandler) convertToResponse(subscription *integrations.WebhookSubscription) WebhookResponse {
+	snapshot := subscription.Snapshot()
+
+	events := make([]string, len(snapshot.Events))
+	for i, event := range snapshot.Events {
+		events[i] = string(event)
+	}
+
+	return WebhookResponse{
+		ID:                  snapshot.ID,
+		ProjectID:           snapshot.ProjectID,
+		Name:                snapshot.Name,
+		URL:                 snapshot.URL,
+		Events:              events,
+		IsActive:            snapshot.IsActive,
+		DisabledReason:      snapshot.DisabledReason,
+		ConsecutiveFailures: snapshot.ConsecutiveFailures,
+		LastDeliveryAt:      formatOptionalTime(snapshot.LastDeliveryAt),
+		CreatedBy:           snapshot.CreatedBy,
+		CreatedAt:           snapshot.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           snapshot.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// convertDeliveryToResponse converts a delivery to response format
+func (h *WebhookHandler) convertDeliveryToResponse(delivery *integrations.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		ProjectID:      delivery.ProjectID,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  formatOptionalTime(delivery.LastAttemptAt),
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DurationMs:     delivery.DurationMs,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == integrations.WebhookDeliveryPending {
+		response.NextAttemptAt = formatOptionalTime(&delivery.NextAttemptAt)
+	}
+	return response
+}
+
+// formatOptionalTime formats a time as RFC 3339, or returns nil when it is not set
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -3,36 +3,115 @@ package application
 import (
 	"context"
 	"fmt"
+	"log"
 	"math"
 	"time"
 
 	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // FlakyDetectionService handles flaky test detection and analysis
 type FlakyDetectionService struct {
-	repo   domain.FlakyDetectionRepository
-	config domain.FlakyTestDetectionConfig
+	repo           domain.FlakyDetectionRepository
+	config         domain.FlakyTestDetectionConfig
+	configResolver domain.FlakyDetectionConfigResolver
+	changeStatus   FlakyStatusChangeFunc
+
+	// Optional publishing of detected and resolved flaky tests
+	eventPublisher FlakyEventPublisher
+
+	// Optional recording of how long analyses take
+	analysisRecorder AnalysisRecorder
 }
 
-// NewFlakyDetectionService creates a new flaky detection service
-func NewFlakyDetectionService(repo domain.FlakyDetectionRepository, config domain.FlakyTestDetectionConfig) *FlakyDetectionService {
+// AnalysisRecorder is told how long each flaky test analysis took
+type AnalysisRecorder interface {
+	FlakyAnalysisCompleted(kind string, duration time.Duration, err error)
+}
+
+// FlakyEventPublisher is told when analysis detects a new flaky test or
+// resolves one. Publishing must not fail the analysis, so implementations
+// handle their own errors.
+type FlakyEventPublisher interface {
+	FlakyTestDetected(ctx context.Context, flaky *domain.FlakyTest)
+	FlakyTestResolved(ctx context.Context, flaky *domain.FlakyTest)
+}
+
+// FlakyStatusChangeFunc changes the status of a project's flaky test through
+// the flaky test lifecycle, which records who changed it and why. An empty
+// user ID stands for an automatic change by the system.
+type FlakyStatusChangeFunc func(ctx context.Context, projectID, testName string, status domain.FlakyTestStatus, userID, reason string) error
+
+// NewFlakyDetectionService creates a new flaky detection service.
+// The config resolver is optional; without it the default config applies to every project.
+// Every status change, manual or found by analysis, is made with changeStatus.
+func NewFlakyDetectionService(repo domain.FlakyDetectionRepository, config domain.FlakyTestDetectionConfig, configResolver domain.FlakyDetectionConfigResolver, changeStatus FlakyStatusChangeFunc) *FlakyDetectionService {
 	return &FlakyDetectionService{
-		repo:   repo,
-		config: config,
+		repo:           repo,
+		config:         config,
+		configResolver: configResolver,
+		changeStatus:   changeStatus,
+	}
+}
+
+// SetEventPublisher enables publishing flaky tests as analysis detects and resolves them
+func (s *FlakyDetectionService) SetEventPublisher(publisher FlakyEventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// SetAnalysisRecorder enables recording how long test run analyses take
+func (s *FlakyDetectionService) SetAnalysisRecorder(recorder AnalysisRecorder) {
+	s.analysisRecorder = recorder
+}
+
+// EffectiveConfig returns the detection config that applies to a project.
+// If the project's overrides cannot be resolved it returns the defaults with the error.
+func (s *FlakyDetectionService) EffectiveConfig(ctx context.Context, projectID string) (domain.FlakyTestDetectionConfig, error) {
+	if s.configResolver == nil {
+		return s.config, nil
+	}
+
+	config, err := s.configResolver.ResolveConfig(ctx, projectID, s.config)
+	if err != nil {
+		return s.config, fmt.Errorf("failed to resolve flaky detection config: %w", err)
 	}
+	return config, nil
 }
 
 // AnalyzeTestRun analyzes a test run for flaky tests
 func (s *FlakyDetectionService) AnalyzeTestRun(ctx context.Context, projectID string, testRunID string) (*domain.TestRunAnalysis, error) {
+	ctx, span := tracing.Start(ctx, "FlakyDetectionService.AnalyzeTestRun",
+		attribute.String("fern.project_id", projectID),
+		attribute.String("fern.test_run.id", testRunID),
+	)
+	start := time.Now()
+	analysis, err := s.analyzeTestRun(ctx, projectID, testRunID)
+	tracing.End(span, err)
+	if s.analysisRecorder != nil {
+		s.analysisRecorder.FlakyAnalysisCompleted("test_run", time.Since(start), err)
+	}
+	return analysis, err
+}
+
+// analyzeTestRun analyzes every test of the project seen in the analysis window
+func (s *FlakyDetectionService) analyzeTestRun(ctx context.Context, projectID string, testRunID string) (*domain.TestRunAnalysis, error) {
+	// A project whose overrides cannot be resolved is still analyzed, with the defaults
+	config, err := s.EffectiveConfig(ctx, projectID)
+	if err != nil {
+		log.Printf("[FlakyDetectionService] Using default flaky detection config for project %s: %v", projectID, err)
+	}
+
 	analysis := &domain.TestRunAnalysis{
 		TestRunID:  testRunID,
 		ProjectID:  projectID,
 		AnalyzedAt: time.Now(),
+		Config:     config,
 	}
 
 	// Get all unique test names from recent history
-	since := time.Now().Add(-s.config.AnalysisWindow)
+	since := time.Now().Add(-config.AnalysisWindow)
 	testNames, err := s.getUniqueTestNames(ctx, projectID, since)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get test names: %w", err)
@@ -42,7 +121,7 @@ func (s *FlakyDetectionService) AnalyzeTestRun(ctx context.Context, projectID st
 
 	// Analyze each test
 	for _, testName := range testNames {
-		result, err := s.analyzeTest(ctx, projectID, testName, since)
+		result, err := s.analyzeTest(ctx, config, projectID, testName, since)
 		if err != nil {
 			// Log error but continue with other tests
 			continue
@@ -71,14 +150,22 @@ func (s *FlakyDetectionService) GetFlakyTests(ctx context.Context, projectID str
 	return s.repo.FindFlakyTestsByProject(ctx, projectID, domain.StatusActive)
 }
 
-// MarkTestResolved marks a flaky test as resolved
-func (s *FlakyDetectionService) MarkTestResolved(ctx context.Context, testID string) error {
-	return s.repo.UpdateFlakyTestStatus(ctx, testID, domain.StatusResolved)
+// MarkTestResolved marks a flaky test as resolved on behalf of a user
+func (s *FlakyDetectionService) MarkTestResolved(ctx context.Context, testID, userID string) error {
+	return s.changeTestStatus(ctx, testID, domain.StatusResolved, userID)
+}
+
+// IgnoreTest marks a flaky test as ignored on behalf of a user
+func (s *FlakyDetectionService) IgnoreTest(ctx context.Context, testID, userID string) error {
+	return s.changeTestStatus(ctx, testID, domain.StatusIgnored, userID)
 }
 
-// IgnoreTest marks a flaky test as ignored
-func (s *FlakyDetectionService) IgnoreTest(ctx context.Context, testID string) error {
-	return s.repo.UpdateFlakyTestStatus(ctx, testID, domain.StatusIgnored)
+func (s *FlakyDetectionService) changeTestStatus(ctx context.Context, testID string, status domain.FlakyTestStatus, userID string) error {
+	projectID, testName, ok := domain.ParseFlakyTestID(testID)
+	if !ok {
+		return fmt.Errorf("invalid flaky test ID %q", testID)
+	}
+	return s.changeStatus(ctx, projectID, testName, status, userID, "")
 }
 
 // Internal types and methods
@@ -97,7 +184,7 @@ type testAnalysisResult struct {
 	action analysisAction
 }
 
-func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID string, testName string, since time.Time) (*testAnalysisResult, error) {
+func (s *FlakyDetectionService) analyzeTest(ctx context.Context, config domain.FlakyTestDetectionConfig, projectID string, testName string, since time.Time) (*testAnalysisResult, error) {
 	// Get test execution history
 	history, err := s.repo.GetTestRunHistory(ctx, projectID, testName, since)
 	if err != nil {
@@ -105,7 +192,7 @@ func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID strin
 	}
 
 	// Not enough runs to determine flakiness
-	if len(history) < s.config.MinimumRuns {
+	if len(history) < config.MinimumRuns {
 		return &testAnalysisResult{action: actionNone}, nil
 	}
 
@@ -140,7 +227,7 @@ func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID strin
 	}
 
 	failureRate := float64(failureCount) / float64(len(history))
-	testID := generateTestID(projectID, testName)
+	testID := domain.FlakyTestID(projectID, testName)
 
 	// Check if test is already tracked
 	existingFlaky, err := s.repo.GetFlakyTest(ctx, testID)
@@ -149,7 +236,7 @@ func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID strin
 	}
 
 	// Determine action based on failure rate and existing status
-	if failureRate >= s.config.MinFailureRate && failureRate <= s.config.MaxFailureRate {
+	if failureRate >= config.MinFailureRate && failureRate <= config.MaxFailureRate {
 		// Test is flaky
 		flakeScore := s.calculateFlakeScore(failureRate, len(history), consecutivePasses)
 
@@ -179,15 +266,23 @@ func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID strin
 			if err := s.repo.SaveFlakyTest(ctx, flaky); err != nil {
 				return nil, fmt.Errorf("failed to save new flaky test: %w", err)
 			}
+			if s.eventPublisher != nil {
+				s.eventPublisher.FlakyTestDetected(ctx, flaky)
+			}
 
 			return &testAnalysisResult{testID: testID, action: actionNewFlaky}, nil
 		} else {
-			// Update existing flaky test
+			// Update existing flaky test, reactivating it if it had been resolved or ignored
+			if existingFlaky.Status != domain.StatusActive {
+				if err := s.changeStatus(ctx, projectID, testName, domain.StatusActive, "", "flaky again"); err != nil {
+					return nil, fmt.Errorf("failed to reactivate flaky test: %w", err)
+				}
+				existingFlaky.Status = domain.StatusActive
+			}
 			existingFlaky.LastSeen = time.Now()
 			existingFlaky.TotalRuns = len(history)
 			existingFlaky.FailureCount = failureCount
 			existingFlaky.FlakeScore = flakeScore
-			existingFlaky.Status = domain.StatusActive
 
 			if lastFailure != nil {
 				// Add to recent failures, keep only last 10
@@ -205,10 +300,14 @@ func (s *FlakyDetectionService) analyzeTest(ctx context.Context, projectID strin
 		}
 	} else if existingFlaky != nil && existingFlaky.Status == domain.StatusActive {
 		// Test is no longer flaky
-		if consecutivePasses >= s.config.ConsecutivePassesForResolution {
+		if consecutivePasses >= config.ConsecutivePassesForResolution {
+			reason := fmt.Sprintf("passed %d consecutive runs", consecutivePasses)
+			if err := s.changeStatus(ctx, projectID, testName, domain.StatusResolved, "", reason); err != nil {
+				return nil, fmt.Errorf("failed to resolve flaky test: %w", err)
+			}
 			existingFlaky.Status = domain.StatusResolved
-			if err := s.repo.SaveFlakyTest(ctx, existingFlaky); err != nil {
-				return nil, fmt.Errorf("failed to update resolved test: %w", err)
+			if s.eventPublisher != nil {
+				s.eventPublisher.FlakyTestResolved(ctx, existingFlaky)
 			}
 			return &testAnalysisResult{testID: testID, action: actionResolved}, nil
 		}
@@ -238,10 +337,6 @@ func (s *FlakyDetectionService) getUniqueTestNames(ctx context.Context, projectI
 	return s.repo.GetUniqueTestNames(ctx, projectID, since)
 }
 
-func generateTestID(projectID, testName string) string {
-	return fmt.Sprintf("%s_%s", projectID, testName)
-}
-
 // GetFlakyTestTrends returns trend data for flaky tests over time
 func (s *FlakyDetectionService) GetFlakyTestTrends(ctx context.Context, projectID string, period time.Duration) ([]FlakyTestTrend, error) {
 	// Get all analyses for the project within the period
@@ -0,0 +1,231 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+)
+
+func TestAnalyticsApplication(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Analytics Application Suite")
+}
+
+// MockFlakyDetectionRepository is a mock implementation of FlakyDetectionRepository
+type MockFlakyDetectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockFlakyDetectionRepository) SaveFlakyTest(ctx context.Context, flaky *domain.FlakyTest) error {
+	args := m.Called(ctx, flaky)
+	return args.Error(0)
+}
+
+func (m *MockFlakyDetectionRepository) GetFlakyTest(ctx context.Context, testID string) (*domain.FlakyTest, error) {
+	args := m.Called(ctx, testID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlakyTest), args.Error(1)
+}
+
+func (m *MockFlakyDetectionRepository) FindFlakyTestsByProject(ctx context.Context, projectID string, status domain.FlakyTestStatus) ([]*domain.FlakyTest, error) {
+	args := m.Called(ctx, projectID, status)
+	return args.Get(0).([]*domain.FlakyTest), args.Error(1)
+}
+
+func (m *MockFlakyDetectionRepository) SaveTestRunAnalysis(ctx context.Context, analysis *domain.TestRunAnalysis) error {
+	args := m.Called(ctx, analysis)
+	return args.Error(0)
+}
+
+func (m *MockFlakyDetectionRepository) GetTestRunHistory(ctx context.Context, projectID string, testName string, since time.Time) ([]domain.TestExecutionResult, error) {
+	args := m.Called(ctx, projectID, testName, since)
+	return args.Get(0).([]domain.TestExecutionResult), args.Error(1)
+}
+
+func (m *MockFlakyDetectionRepository) GetUniqueTestNames(ctx context.Context, projectID string, since time.Time) ([]string, error) {
+	args := m.Called(ctx, projectID, since)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// statusChange is a status change made through the flaky test lifecycle
+type statusChange struct {
+	projectID, testName string
+	status              domain.FlakyTestStatus
+	userID, reason      string
+}
+
+// configResolverFunc resolves project configs with a function
+type configResolverFunc func(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error)
+
+func (f configResolverFunc) ResolveConfig(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error) {
+	return f(ctx, projectID, defaults)
+}
+
+// history returns executions of a test, newest first
+func history(statuses ...string) []domain.TestExecutionResult {
+	now := time.Now()
+	results := make([]domain.TestExecutionResult, len(statuses))
+	for i, status := range statuses {
+		results[i] = domain.TestExecutionResult{
+			TestRunID:  "run",
+			TestName:   "test-login",
+			SuiteName:  "auth-suite",
+			Status:     status,
+			ExecutedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return results
+}
+
+var _ = Describe("FlakyDetectionService", func() {
+	var (
+		ctx     context.Context
+		repo    *MockFlakyDetectionRepository
+		changes []statusChange
+		service *application.FlakyDetectionService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = new(MockFlakyDetectionRepository)
+		changes = nil
+		config := domain.DefaultFlakyTestDetectionConfig()
+		config.MinimumRuns = 4
+		config.ConsecutivePassesForResolution = 4
+		service = application.NewFlakyDetectionService(repo, config, nil,
+			func(ctx context.Context, projectID, testName string, status domain.FlakyTestStatus, userID, reason string) error {
+				changes = append(changes, statusChange{projectID, testName, status, userID, reason})
+				return nil
+			})
+	})
+
+	// analyze runs an analysis of a project with one test
+	analyze := func(executions []domain.TestExecutionResult) *domain.TestRunAnalysis {
+		repo.On("GetUniqueTestNames", ctx, "project-1", mock.Anything).Return([]string{"test-login"}, nil)
+		repo.On("GetTestRunHistory", ctx, "project-1", "test-login", mock.Anything).Return(executions, nil)
+		repo.On("SaveTestRunAnalysis", ctx, mock.Anything).Return(nil)
+
+		analysis, err := service.AnalyzeTestRun(ctx, "project-1", "run-1")
+		Expect(err).NotTo(HaveOccurred())
+		return analysis
+	}
+
+	Describe("status changes", func() {
+		It("should resolve and ignore tests on behalf of the user", func() {
+			Expect(service.MarkTestResolved(ctx, "project-1:test-login", "user-1")).To(Succeed())
+			Expect(service.IgnoreTest(ctx, "project-1:test-login", "user-1")).To(Succeed())
+
+			Expect(changes).To(Equal([]statusChange{
+				{"project-1", "test-login", domain.StatusResolved, "user-1", ""},
+				{"project-1", "test-login", domain.StatusIgnored, "user-1", ""},
+			}))
+		})
+
+		It("should reject invalid test IDs", func() {
+			Expect(service.MarkTestResolved(ctx, "test-login", "user-1")).To(MatchError(ContainSubstring("invalid flaky test ID")))
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("should report failed status changes", func() {
+			service = application.NewFlakyDetectionService(repo, domain.DefaultFlakyTestDetectionConfig(), nil,
+				func(context.Context, string, string, domain.FlakyTestStatus, string, string) error {
+					return errors.New("can only resolve active flaky tests")
+				})
+
+			Expect(service.MarkTestResolved(ctx, "project-1:test-login", "user-1")).To(HaveOccurred())
+		})
+	})
+
+	Describe("AnalyzeTestRun", func() {
+		It("should apply the project's thresholds and save them with the analysis", func() {
+			service = application.NewFlakyDetectionService(repo, domain.DefaultFlakyTestDetectionConfig(),
+				configResolverFunc(func(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error) {
+					Expect(projectID).To(Equal("project-1"))
+					defaults.MinimumRuns = 3
+					defaults.MaxFailureRate = 0.5
+					return defaults, nil
+				}), nil)
+			repo.On("GetFlakyTest", ctx, "project-1:test-login").Return(nil, errors.New("flaky test not found"))
+			repo.On("SaveFlakyTest", ctx, mock.Anything).Return(nil)
+
+			// Too few runs for the default minimum of 10
+			analysis := analyze(history("failed", "passed", "passed"))
+
+			Expect(analysis.NewFlaky).To(ConsistOf("project-1:test-login"))
+			Expect(analysis.Config.MinimumRuns).To(Equal(3))
+			Expect(analysis.Config.MaxFailureRate).To(Equal(0.5))
+			repo.AssertCalled(GinkgoT(), "SaveTestRunAnalysis", ctx, analysis)
+		})
+
+		It("should not report tests failing more often than the project's maximum failure rate", func() {
+			service = application.NewFlakyDetectionService(repo, domain.DefaultFlakyTestDetectionConfig(),
+				configResolverFunc(func(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error) {
+					defaults.MinimumRuns = 3
+					defaults.MaxFailureRate = 0.5
+					return defaults, nil
+				}), nil)
+			repo.On("GetFlakyTest", ctx, "project-1:test-login").Return(nil, errors.New("flaky test not found"))
+
+			analysis := analyze(history("failed", "failed", "passed"))
+
+			Expect(analysis.NewFlaky).To(BeEmpty())
+			repo.AssertNotCalled(GinkgoT(), "SaveFlakyTest", mock.Anything, mock.Anything)
+		})
+
+		It("should analyze with the default config when the project config cannot be resolved", func() {
+			config := domain.DefaultFlakyTestDetectionConfig()
+			config.MinimumRuns = 4
+			service = application.NewFlakyDetectionService(repo, config,
+				configResolverFunc(func(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error) {
+					return defaults, errors.New("invalid flaky detection settings")
+				}), nil)
+			repo.On("GetFlakyTest", ctx, "project-1:test-login").Return(nil, errors.New("flaky test not found"))
+			repo.On("SaveFlakyTest", ctx, mock.Anything).Return(nil)
+
+			analysis := analyze(history("failed", "passed", "passed", "passed"))
+
+			Expect(analysis.Config).To(Equal(config))
+			Expect(analysis.NewFlaky).To(ConsistOf("project-1:test-login"))
+		})
+
+		It("should resolve tests through the lifecycle once they pass enough runs", func() {
+			repo.On("GetFlakyTest", ctx, "project-1:test-login").Return(&domain.FlakyTest{
+				TestID: "project-1:test-login", ProjectID: "project-1", TestName: "test-login", Status: domain.StatusActive,
+			}, nil)
+
+			analysis := analyze(history("passed", "passed", "passed", "passed", "passed"))
+
+			Expect(analysis.ResolvedFlaky).To(ConsistOf("project-1:test-login"))
+			Expect(changes).To(Equal([]statusChange{
+				{"project-1", "test-login", domain.StatusResolved, "", "passed 5 consecutive runs"},
+			}))
+			repo.AssertNotCalled(GinkgoT(), "SaveFlakyTest", mock.Anything, mock.Anything)
+		})
+
+		It("should reactivate resolved tests through the lifecycle when they flake again", func() {
+			repo.On("GetFlakyTest", ctx, "project-1:test-login").Return(&domain.FlakyTest{
+				TestID: "project-1:test-login", ProjectID: "project-1", TestName: "test-login", Status: domain.StatusResolved,
+			}, nil)
+			repo.On("SaveFlakyTest", ctx, mock.MatchedBy(func(flaky *domain.FlakyTest) bool {
+				return flaky.Status == domain.StatusActive
+			})).Return(nil)
+
+			analysis := analyze(history("failed", "passed", "passed", "passed", "passed", "passed"))
+
+			Expect(analysis.StillFlaky).To(ConsistOf("project-1:test-login"))
+			Expect(changes).To(Equal([]statusChange{
+				{"project-1", "test-login", domain.StatusActive, "", "flaky again"},
+			}))
+		})
+	})
+})
@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"context"
+	"strings"
 	"time"
+
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
 )
 
 // FlakyTest represents a test that has been identified as flaky
@@ -20,6 +24,17 @@ type FlakyTest struct {
 	Metadata     FlakyTestMetadata
 }
 
+// FlakyTestID returns the ID of a project's flaky test, "<project ID>:<test name>"
+func FlakyTestID(projectID, testName string) string {
+	return projectID + ":" + testName
+}
+
+// ParseFlakyTestID splits a flaky test ID into its project ID and test name
+func ParseFlakyTestID(testID string) (projectID, testName string, ok bool) {
+	projectID, testName, ok = strings.Cut(testID, ":")
+	return projectID, testName, ok && projectID != "" && testName != ""
+}
+
 // FlakyTestStatus represents the current status of a flaky test
 type FlakyTestStatus string
 
@@ -55,6 +70,9 @@ type TestRunAnalysis struct {
 	NewFlaky      []string // Test IDs newly identified as flaky
 	StillFlaky    []string // Test IDs that remain flaky
 	ResolvedFlaky []string // Test IDs no longer flaky
+
+	// Config is the effective detection config used for this analysis
+	Config FlakyTestDetectionConfig
 }
 
 // FlakyTestDetectionConfig contains configuration for flaky test detection
@@ -76,12 +94,18 @@ type FlakyTestDetectionConfig struct {
 	ConsecutivePassesForResolution int
 }
 
+// FlakyDetectionConfigResolver resolves the effective detection config for a project
+type FlakyDetectionConfigResolver interface {
+	// ResolveConfig applies any project-level overrides on top of defaults
+	ResolveConfig(ctx context.Context, projectID string, defaults FlakyTestDetectionConfig) (FlakyTestDetectionConfig, error)
+}
+
 // DefaultFlakyTestDetectionConfig returns the default configuration
 func DefaultFlakyTestDetectionConfig() FlakyTestDetectionConfig {
 	return FlakyTestDetectionConfig{
 		MinimumRuns:                    10,
-		MinFailureRate:                 0.05,               // 5%
-		MaxFailureRate:                 0.95,               // 95%
+		MinFailureRate:                 projectsDomain.DefaultMinFailureRate,
+		MaxFailureRate:                 projectsDomain.DefaultMaxFailureRate,
 		AnalysisWindow:                 7 * 24 * time.Hour, // 7 days
 		ConsecutivePassesForResolution: 20,
 	}
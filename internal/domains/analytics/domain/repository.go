@@ -16,9 +16,6 @@ type FlakyDetectionRepository interface {
 	// Find flaky tests for a project
 	FindFlakyTestsByProject(ctx context.Context, projectID string, status FlakyTestStatus) ([]*FlakyTest, error)
 
-	// Update flaky test status
-	UpdateFlakyTestStatus(ctx context.Context, testID string, status FlakyTestStatus) error
-
 	// Record a test run analysis
 	SaveTestRunAnalysis(ctx context.Context, analysis *TestRunAnalysis) error
 
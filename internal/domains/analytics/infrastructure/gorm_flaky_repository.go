@@ -48,8 +48,13 @@ func (r *GormFlakyDetectionRepository) SaveFlakyTest(ctx context.Context, flaky
 
 // GetFlakyTest retrieves a flaky test by ID
 func (r *GormFlakyDetectionRepository) GetFlakyTest(ctx context.Context, testID string) (*domain.FlakyTest, error) {
+	projectID, testName, ok := domain.ParseFlakyTestID(testID)
+	if !ok {
+		return nil, fmt.Errorf("flaky test not found")
+	}
+
 	var dbFlaky database.FlakyTest
-	if err := r.db.WithContext(ctx).Where("test_id = ?", testID).First(&dbFlaky).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND test_name = ?", projectID, testName).First(&dbFlaky).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("flaky test not found")
 		}
@@ -85,30 +90,30 @@ func (r *GormFlakyDetectionRepository) FindFlakyTestsByProject(ctx context.Conte
 	return flakyTests, nil
 }
 
-// UpdateFlakyTestStatus updates the status of a flaky test
-func (r *GormFlakyDetectionRepository) UpdateFlakyTestStatus(ctx context.Context, testID string, status domain.FlakyTestStatus) error {
-	result := r.db.WithContext(ctx).Model(&database.FlakyTest{}).
-		Where("test_id = ?", testID).
-		Update("status", string(status))
-
-	if result.Error != nil {
-		return fmt.Errorf("failed to update flaky test status: %w", result.Error)
+// SaveTestRunAnalysis saves a test run analysis
+func (r *GormFlakyDetectionRepository) SaveTestRunAnalysis(ctx context.Context, analysis *domain.TestRunAnalysis) error {
+	dbAnalysis := &database.FlakyTestAnalysis{
+		ProjectID:                      analysis.ProjectID,
+		TestRunID:                      analysis.TestRunID,
+		AnalyzedAt:                     analysis.AnalyzedAt,
+		TotalTests:                     analysis.TotalTests,
+		NewFlakyCount:                  len(analysis.NewFlaky),
+		StillFlakyCount:                len(analysis.StillFlaky),
+		ResolvedFlakyCount:             len(analysis.ResolvedFlaky),
+		MinimumRuns:                    analysis.Config.MinimumRuns,
+		MinFailureRate:                 analysis.Config.MinFailureRate,
+		MaxFailureRate:                 analysis.Config.MaxFailureRate,
+		AnalysisWindowSeconds:          int64(analysis.Config.AnalysisWindow / time.Second),
+		ConsecutivePassesForResolution: analysis.Config.ConsecutivePassesForResolution,
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("flaky test not found")
+	if err := r.db.WithContext(ctx).Create(dbAnalysis).Error; err != nil {
+		return fmt.Errorf("failed to save test run analysis: %w", err)
 	}
 
 	return nil
 }
 
-// SaveTestRunAnalysis saves a test run analysis
-func (r *GormFlakyDetectionRepository) SaveTestRunAnalysis(ctx context.Context, analysis *domain.TestRunAnalysis) error {
-	// For now, we'll just log this. In a real implementation, we'd have a dedicated table
-	// This could be used for tracking analysis history and generating reports
-	return nil
-}
-
 // GetTestRunHistory retrieves test execution history for a specific test
 func (r *GormFlakyDetectionRepository) GetTestRunHistory(ctx context.Context, projectID string, testName string, since time.Time) ([]domain.TestExecutionResult, error) {
 	// Use a raw query to get all the needed data in one query
@@ -244,11 +249,8 @@ func (r *GormFlakyDetectionRepository) toDomainFlakyTest(dbFlaky *database.Flaky
 		metadata.FailurePatterns = []string{dbFlaky.LastErrorMessage}
 	}
 
-	// Generate TestID from project and test name
-	testID := fmt.Sprintf("%s:%s", dbFlaky.ProjectID, dbFlaky.TestName)
-
 	return &domain.FlakyTest{
-		TestID:       testID,
+		TestID:       domain.FlakyTestID(dbFlaky.ProjectID, dbFlaky.TestName),
 		ProjectID:    dbFlaky.ProjectID,
 		TestName:     dbFlaky.TestName,
 		SuiteName:    dbFlaky.SuiteName,
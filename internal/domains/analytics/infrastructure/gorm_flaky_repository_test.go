@@ -0,0 +1,56 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormFlakyDetectionRepository_SaveTestRunAnalysis(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FlakyTestAnalysis{}))
+	repo := infrastructure.NewGormFlakyDetectionRepository(db)
+
+	config := domain.DefaultFlakyTestDetectionConfig()
+	config.MinimumRuns = 3
+	config.AnalysisWindow = 30 * 24 * time.Hour
+	analyzedAt := time.Now()
+
+	require.NoError(t, repo.SaveTestRunAnalysis(ctx, &domain.TestRunAnalysis{
+		TestRunID:     "run-1",
+		ProjectID:     "nightly",
+		AnalyzedAt:    analyzedAt,
+		TotalTests:    12,
+		NewFlaky:      []string{"nightly:a", "nightly:b"},
+		StillFlaky:    []string{"nightly:c"},
+		ResolvedFlaky: []string{},
+		Config:        config,
+	}))
+
+	var saved database.FlakyTestAnalysis
+	require.NoError(t, db.First(&saved).Error)
+	assert.Equal(t, "nightly", saved.ProjectID)
+	assert.Equal(t, "run-1", saved.TestRunID)
+	assert.WithinDuration(t, analyzedAt, saved.AnalyzedAt, time.Second)
+	assert.Equal(t, 12, saved.TotalTests)
+	assert.Equal(t, 2, saved.NewFlakyCount)
+	assert.Equal(t, 1, saved.StillFlakyCount)
+	assert.Equal(t, 0, saved.ResolvedFlakyCount)
+	// The config that was in effect, with the project's overrides
+	assert.Equal(t, 3, saved.MinimumRuns)
+	assert.Equal(t, config.MinFailureRate, saved.MinFailureRate)
+	assert.Equal(t, config.MaxFailureRate, saved.MaxFailureRate)
+	assert.Equal(t, int64(30*24*60*60), saved.AnalysisWindowSeconds)
+	assert.Equal(t, config.ConsecutivePassesForResolution, saved.ConsecutivePassesForResolution)
+}
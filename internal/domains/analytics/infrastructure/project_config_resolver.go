@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+// ProjectSettingsConfigResolver resolves flaky detection config from project settings
+type ProjectSettingsConfigResolver struct {
+	projectRepo projectsDomain.ProjectRepository
+}
+
+// NewProjectSettingsConfigResolver creates a resolver backed by the project repository
+func NewProjectSettingsConfigResolver(projectRepo projectsDomain.ProjectRepository) *ProjectSettingsConfigResolver {
+	return &ProjectSettingsConfigResolver{projectRepo: projectRepo}
+}
+
+// ResolveConfig applies the project's flaky detection overrides on top of defaults
+func (r *ProjectSettingsConfigResolver) ResolveConfig(ctx context.Context, projectID string, defaults domain.FlakyTestDetectionConfig) (domain.FlakyTestDetectionConfig, error) {
+	project, err := r.projectRepo.FindByProjectID(ctx, projectsDomain.ProjectID(projectID))
+	if err != nil {
+		return defaults, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	overrides, err := project.FlakyDetectionSettings()
+	if err != nil {
+		return defaults, fmt.Errorf("failed to read flaky detection settings: %w", err)
+	}
+	if err := overrides.Validate(); err != nil {
+		return defaults, fmt.Errorf("invalid flaky detection settings: %w", err)
+	}
+
+	return ApplyFlakyDetectionOverrides(defaults, overrides), nil
+}
+
+// ApplyFlakyDetectionOverrides returns defaults with any non-nil overrides applied.
+// Overrides are expected to be valid; see FlakyDetectionSettings.Validate.
+func ApplyFlakyDetectionOverrides(defaults domain.FlakyTestDetectionConfig, overrides projectsDomain.FlakyDetectionSettings) domain.FlakyTestDetectionConfig {
+	config := defaults
+
+	if overrides.MinimumRuns != nil {
+		config.MinimumRuns = *overrides.MinimumRuns
+	}
+	if overrides.MinFailureRate != nil {
+		config.MinFailureRate = *overrides.MinFailureRate
+	}
+	if overrides.MaxFailureRate != nil {
+		config.MaxFailureRate = *overrides.MaxFailureRate
+	}
+	if overrides.AnalysisWindow != nil {
+		config.AnalysisWindow = *overrides.AnalysisWindow
+	}
+	if overrides.ConsecutivePassesForResolution != nil {
+		config.ConsecutivePassesForResolution = *overrides.ConsecutivePassesForResolution
+	}
+
+	return config
+}
@@ -0,0 +1,103 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/analytics/infrastructure"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedProjects serves projects by ID
+type storedProjects struct {
+	projectsDomain.ProjectRepository
+	projects map[projectsDomain.ProjectID]*projectsDomain.Project
+}
+
+func (r *storedProjects) FindByProjectID(ctx context.Context, projectID projectsDomain.ProjectID) (*projectsDomain.Project, error) {
+	project, ok := r.projects[projectID]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	return project, nil
+}
+
+func TestApplyFlakyDetectionOverrides(t *testing.T) {
+	defaults := domain.DefaultFlakyTestDetectionConfig()
+
+	t.Run("should keep the defaults without overrides", func(t *testing.T) {
+		assert.Equal(t, defaults, infrastructure.ApplyFlakyDetectionOverrides(defaults, projectsDomain.FlakyDetectionSettings{}))
+	})
+
+	t.Run("should apply only the overrides that are set", func(t *testing.T) {
+		minimumRuns := 3
+		maxFailureRate := 0.5
+		window := 30 * 24 * time.Hour
+
+		config := infrastructure.ApplyFlakyDetectionOverrides(defaults, projectsDomain.FlakyDetectionSettings{
+			MinimumRuns:    &minimumRuns,
+			MaxFailureRate: &maxFailureRate,
+			AnalysisWindow: &window,
+		})
+
+		assert.Equal(t, 3, config.MinimumRuns)
+		assert.Equal(t, defaults.MinFailureRate, config.MinFailureRate)
+		assert.Equal(t, 0.5, config.MaxFailureRate)
+		assert.Equal(t, window, config.AnalysisWindow)
+		assert.Equal(t, defaults.ConsecutivePassesForResolution, config.ConsecutivePassesForResolution)
+	})
+}
+
+func TestProjectSettingsConfigResolver_ResolveConfig(t *testing.T) {
+	ctx := context.Background()
+	defaults := domain.DefaultFlakyTestDetectionConfig()
+
+	nightly, err := projectsDomain.NewProject("nightly", "Nightly", "fern")
+	require.NoError(t, err)
+	minimumRuns := 3
+	require.NoError(t, nightly.UpdateFlakyDetectionSettings(projectsDomain.FlakyDetectionSettings{MinimumRuns: &minimumRuns}))
+
+	plain, err := projectsDomain.NewProject("plain", "Plain", "fern")
+	require.NoError(t, err)
+
+	// Saved before the failure-rate range was validated against the defaults
+	legacy, err := projectsDomain.NewProject("legacy", "Legacy", "fern")
+	require.NoError(t, err)
+	legacy.SetSetting(projectsDomain.FlakyDetectionSettingsKey, map[string]interface{}{"minFailureRate": 0.96})
+
+	resolver := infrastructure.NewProjectSettingsConfigResolver(&storedProjects{projects: map[projectsDomain.ProjectID]*projectsDomain.Project{
+		"nightly": nightly,
+		"plain":   plain,
+		"legacy":  legacy,
+	}})
+
+	t.Run("should apply the project's overrides", func(t *testing.T) {
+		config, err := resolver.ResolveConfig(ctx, "nightly", defaults)
+		require.NoError(t, err)
+		assert.Equal(t, 3, config.MinimumRuns)
+		assert.Equal(t, defaults.AnalysisWindow, config.AnalysisWindow)
+	})
+
+	t.Run("should return the defaults for projects without overrides", func(t *testing.T) {
+		config, err := resolver.ResolveConfig(ctx, "plain", defaults)
+		require.NoError(t, err)
+		assert.Equal(t, defaults, config)
+	})
+
+	t.Run("should return the defaults with an error for invalid overrides", func(t *testing.T) {
+		config, err := resolver.ResolveConfig(ctx, "legacy", defaults)
+		assert.ErrorContains(t, err, "minFailureRate (0.96) must be less than maxFailureRate (0.95)")
+		assert.Equal(t, defaults, config)
+	})
+
+	t.Run("should return the defaults with an error for unknown projects", func(t *testing.T) {
+		config, err := resolver.ResolveConfig(ctx, "missing", defaults)
+		assert.ErrorContains(t, err, "failed to get project")
+		assert.Equal(t, defaults, config)
+	})
+}
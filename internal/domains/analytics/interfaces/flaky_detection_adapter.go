@@ -82,7 +82,13 @@ func (a *FlakyDetectionAdapter) MarkTestResolved() gin.HandlerFunc {
 			return
 		}
 
-		if err := a.service.MarkTestResolved(c.Request.Context(), testID); err != nil {
+		userID := c.GetString("user_id")
+		if userID == "" {
+			c.JSON(401, gin.H{"error": "authentication required"})
+			return
+		}
+
+		if err := a.service.MarkTestResolved(c.Request.Context(), testID, userID); err != nil {
 			a.logger.WithError(err).Error("Failed to mark test as resolved")
 			c.JSON(500, gin.H{"error": "Failed to mark test as resolved"})
 			return
@@ -103,7 +109,13 @@ func (a *FlakyDetectionAdapter) IgnoreTest() gin.HandlerFunc {
 			return
 		}
 
-		if err := a.service.IgnoreTest(c.Request.Context(), testID); err != nil {
+		userID := c.GetString("user_id")
+		if userID == "" {
+			c.JSON(401, gin.H{"error": "authentication required"})
+			return
+		}
+
+		if err := a.service.IgnoreTest(c.Request.Context(), testID, userID); err != nil {
 			a.logger.WithError(err).Error("Failed to ignore test")
 			c.JSON(500, gin.H{"error": "Failed to ignore test"})
 			return
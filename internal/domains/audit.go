@@ -0,0 +1,145 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	auditInfra "github.com/guidewire-oss/fern-platform/internal/domains/audit/infrastructure"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	tagsDomain "github.com/guidewire-oss/fern-platform/internal/domains/tags/domain"
+)
+
+// EnableAuditLog records changes, sign-ins and sign-outs in the audit log,
+// keeping entries for the retention period, or forever if it is zero
+func (f *DomainFactory) EnableAuditLog(retention time.Duration) *auditApp.AuditService {
+	f.auditService = auditApp.NewAuditService(auditInfra.NewGormAuditRepository(f.db), retention)
+	f.registerAuditSnapshotters()
+	f.authMiddleware.SetEventRecorder(auditInterfaces.NewAuthEventRecorder(f.auditService, f.logger))
+	return f.auditService
+}
+
+// GetAuditService returns the audit log service, or nil when the audit log is not enabled
+func (f *DomainFactory) GetAuditService() *auditApp.AuditService {
+	return f.auditService
+}
+
+// registerAuditSnapshotters lets audit entries record how projects, project
+// access, users, groups, tags, flaky tests and integration connections changed.
+// Targets that do not exist snapshot as nil.
+func (f *DomainFactory) registerAuditSnapshotters() {
+	projectRepo := projectsInfra.NewGormProjectRepository(f.db)
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetProject, func(ctx context.Context, id string) (interface{}, error) {
+		project, err := f.projectService.GetProject(ctx, projectsDomain.ProjectID(id))
+		if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+			// GraphQL also identifies projects by their database ID
+			if dbID, parseErr := strconv.ParseUint(id, 10, 32); parseErr == nil {
+				project, err = projectRepo.FindByID(ctx, uint(dbID))
+			}
+		}
+		if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return project.ToSnapshot(), nil
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetProjectAccess, f.snapshotProjectAccess)
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetUser, func(ctx context.Context, id string) (interface{}, error) {
+		user, err := f.userAdminService.GetUser(ctx, id)
+		if errors.Is(err, authDomain.ErrUserNotFound) {
+			return nil, nil
+		}
+		return user, err
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetGroup, func(ctx context.Context, id string) (interface{}, error) {
+		group, err := f.provisioningService.GetGroup(ctx, id)
+		if errors.Is(err, authDomain.ErrGroupNotFound) {
+			return nil, nil
+		}
+		return group, err
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetTag, func(ctx context.Context, id string) (interface{}, error) {
+		tag, err := f.tagService.GetTag(ctx, tagsDomain.TagID(id))
+		if err != nil {
+			return nil, err
+		}
+		return tag.ToSnapshot(), nil
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetFlakyTest, func(ctx context.Context, id string) (interface{}, error) {
+		flakyTestID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flaky test ID: %w", err)
+		}
+		flakyTest, err := f.flakyLifecycleService.GetFlakyTest(ctx, uint(flakyTestID))
+		if err != nil {
+			return nil, err
+		}
+		return flakyTest.ToSnapshot(), nil
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetJiraConnection, func(ctx context.Context, id string) (interface{}, error) {
+		connection, err := f.jiraConnectionService.GetConnection(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return connection.Snapshot(), nil
+	})
+
+	f.auditService.RegisterSnapshotter(auditDomain.TargetTrackerConnection, func(ctx context.Context, id string) (interface{}, error) {
+		connection, err := f.trackerConnectionService.GetConnection(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return connection.Snapshot(), nil
+	})
+}
+
+// snapshotProjectAccess snapshots the permissions granted to a user or group
+// on a project, identified as "<projectId>/users/<userId>" or
+// "<projectId>/groups/<groupName>"
+func (f *DomainFactory) snapshotProjectAccess(ctx context.Context, id string) (interface{}, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid project access ID %q", id)
+	}
+	projectID, kind, name := projectsDomain.ProjectID(parts[0]), parts[1], parts[2]
+
+	permissions, err := f.projectService.ListAccess(ctx, projectID)
+	if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	granted := auditDomain.Snapshot{}
+	for _, permission := range permissions {
+		if (kind == "users" && permission.UserID() == name) ||
+			(kind == "groups" && permission.GroupName() == projectsDomain.NormalizeGroupName(name)) {
+			granted[string(permission.Permission())] = map[string]interface{}{
+				"grantedBy": permission.GrantedBy(),
+				"expiresAt": permission.ExpiresAt(),
+			}
+		}
+	}
+	if len(granted) == 0 {
+		return nil, nil
+	}
+	return granted, nil
+}
@@ -0,0 +1,247 @@
+// Package application provides the audit log services
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+)
+
+const (
+	// DefaultPageSize is the number of entries listed when no limit is given
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of entries listed at once
+	MaxPageSize = 500
+	// exportBatchSize is the number of entries read at a time when exporting
+	exportBatchSize = 1000
+)
+
+// ExportFormat is the file format audit log entries are exported in
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+)
+
+// ErrUnsupportedFormat is returned when exporting in an unknown format
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Snapshotter returns the current state of a target, or nil if it does not exist
+type Snapshotter func(ctx context.Context, targetID string) (interface{}, error)
+
+// AuditService records and retrieves the audit log
+type AuditService struct {
+	repo         domain.Repository
+	retention    time.Duration
+	snapshotters map[string]Snapshotter
+}
+
+// NewAuditService creates a new audit service keeping entries for the
+// retention period, or forever if it is zero
+func NewAuditService(repo domain.Repository, retention time.Duration) *AuditService {
+	return &AuditService{
+		repo:         repo,
+		retention:    retention,
+		snapshotters: make(map[string]Snapshotter),
+	}
+}
+
+// RegisterSnapshotter sets how the state of targets of a type is read, so
+// entries record what an action changed. Call it before serving requests.
+func (s *AuditService) RegisterSnapshotter(targetType string, snapshotter Snapshotter) {
+	s.snapshotters[targetType] = snapshotter
+}
+
+// CanSnapshot reports whether the state of targets of a type can be read
+func (s *AuditService) CanSnapshot(targetType string) bool {
+	_, ok := s.snapshotters[targetType]
+	return ok
+}
+
+// Snapshot reads the current state of a target. It returns nil for targets
+// that do not exist and types without a snapshotter.
+func (s *AuditService) Snapshot(ctx context.Context, targetType, targetID string) (domain.Snapshot, error) {
+	snapshotter, ok := s.snapshotters[targetType]
+	if !ok || targetID == "" {
+		return nil, nil
+	}
+
+	value, err := snapshotter(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s: %w", targetType, targetID, err)
+	}
+	return domain.NewSnapshot(value)
+}
+
+// Record appends an entry to the audit log. Request details missing from the
+// entry are taken from the context.
+func (s *AuditService) Record(ctx context.Context, entry *domain.Entry) error {
+	if entry.Action == "" {
+		return errors.New("audit entry action is required")
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = time.Now()
+	}
+	if info, ok := domain.RequestInfoFromContext(ctx); ok {
+		if entry.ActorID == "" {
+			entry.ActorID = info.ActorID
+			entry.ActorEmail = info.ActorEmail
+		}
+		if entry.IPAddress == "" {
+			entry.IPAddress = info.IPAddress
+		}
+		if entry.UserAgent == "" {
+			entry.UserAgent = info.UserAgent
+		}
+		if entry.RequestID == "" {
+			entry.RequestID = info.RequestID
+		}
+	}
+	if entry.Changes == nil {
+		entry.Changes = domain.Changes{}
+	}
+
+	if err := s.repo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of the entries matching the filter, newest first, and
+// how many match in total
+func (s *AuditService) List(ctx context.Context, filter domain.Filter) ([]*domain.Entry, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit > MaxPageSize {
+		filter.Limit = MaxPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.Find(ctx, filter)
+}
+
+// Export writes every entry matching the filter, newest first. The filter's
+// limit and offset are ignored.
+func (s *AuditService) Export(ctx context.Context, filter domain.Filter, format ExportFormat, w io.Writer) error {
+	switch format {
+	case ExportCSV:
+		return s.exportCSV(ctx, filter, w)
+	case ExportJSON:
+		return s.exportJSON(ctx, filter, w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// exportCSV writes entries as CSV with a header row; changes are JSON-encoded
+func (s *AuditService) exportCSV(ctx context.Context, filter domain.Filter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"id", "occurred_at", "actor_id", "actor_email", "action", "target_type", "target_id",
+		"changes", "source", "ip_address", "user_agent", "request_id",
+	}); err != nil {
+		return err
+	}
+
+	err := s.eachEntry(ctx, filter, func(entry *domain.Entry) error {
+		changes, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return err
+		}
+		return writer.Write([]string{
+			fmt.Sprint(entry.ID),
+			entry.OccurredAt.UTC().Format(time.RFC3339),
+			entry.ActorID,
+			entry.ActorEmail,
+			entry.Action,
+			entry.TargetType,
+			entry.TargetID,
+			string(changes),
+			string(entry.Source),
+			entry.IPAddress,
+			entry.UserAgent,
+			entry.RequestID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportJSON writes entries as a JSON array
+func (s *AuditService) exportJSON(ctx context.Context, filter domain.Filter, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.eachEntry(ctx, filter, func(entry *domain.Entry) error {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]\n")
+	return err
+}
+
+// eachEntry calls fn for every entry matching the filter, reading them in batches
+func (s *AuditService) eachEntry(ctx context.Context, filter domain.Filter, fn func(*domain.Entry) error) error {
+	filter.Limit = exportBatchSize
+	filter.Offset = 0
+	// Entries recorded while exporting would shift the pages, so the export
+	// stops at the entries that existed when it started
+	if filter.To == nil {
+		now := time.Now()
+		filter.To = &now
+	}
+
+	for {
+		entries, _, err := s.repo.Find(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to read audit entries: %w", err)
+		}
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		if len(entries) < filter.Limit {
+			return nil
+		}
+		filter.Offset += len(entries)
+	}
+}
+
+// PurgeExpired removes entries older than the retention period and returns
+// how many were removed
+func (s *AuditService) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeleteBefore(ctx, time.Now().Add(-s.retention))
+}
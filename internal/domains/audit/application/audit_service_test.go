@@ -0,0 +1,192 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupAuditService(t *testing.T, retention time.Duration) *application.AuditService {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&infrastructure.AuditLogDB{}))
+	return application.NewAuditService(infrastructure.NewGormAuditRepository(db), retention)
+}
+
+// record appends an entry that occurred the given time ago
+func record(t *testing.T, service *application.AuditService, ago time.Duration, entry domain.Entry) {
+	entry.OccurredAt = time.Now().Add(-ago)
+	if entry.Source == "" {
+		entry.Source = domain.SourceREST
+	}
+	require.NoError(t, service.Record(context.Background(), &entry))
+}
+
+func TestAuditService_RecordFillsRequestInfo(t *testing.T) {
+	service := setupAuditService(t, 0)
+	ctx := domain.WithRequestInfo(context.Background(), domain.RequestInfo{
+		ActorID:    "u1",
+		ActorEmail: "u1@example.com",
+		IPAddress:  "10.0.0.1",
+		UserAgent:  "curl/8.0",
+		RequestID:  "req-1",
+	})
+
+	entry := &domain.Entry{
+		Action:     "update_project",
+		TargetType: domain.TargetProject,
+		TargetID:   "shop",
+		Changes:    domain.Changes{"name": {Before: "Shop", After: "Store"}},
+		Source:     domain.SourceREST,
+	}
+	require.NoError(t, service.Record(ctx, entry))
+	assert.NotZero(t, entry.ID)
+
+	entries, total, err := service.List(context.Background(), domain.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(1), total)
+
+	got := entries[0]
+	assert.Equal(t, "u1", got.ActorID)
+	assert.Equal(t, "u1@example.com", got.ActorEmail)
+	assert.Equal(t, "10.0.0.1", got.IPAddress)
+	assert.Equal(t, "curl/8.0", got.UserAgent)
+	assert.Equal(t, "req-1", got.RequestID)
+	assert.Equal(t, domain.Changes{"name": {Before: "Shop", After: "Store"}}, got.Changes)
+	assert.WithinDuration(t, time.Now(), got.OccurredAt, time.Minute)
+
+	assert.Error(t, service.Record(ctx, &domain.Entry{}), "entries need an action")
+}
+
+func TestAuditService_List(t *testing.T) {
+	service := setupAuditService(t, 0)
+	record(t, service, 3*time.Hour, domain.Entry{ActorID: "u1", Action: "create_project", TargetType: domain.TargetProject, TargetID: "shop"})
+	record(t, service, 2*time.Hour, domain.Entry{ActorID: "u2", Action: "update_project", TargetType: domain.TargetProject, TargetID: "shop"})
+	record(t, service, time.Hour, domain.Entry{ActorID: "u1", Action: "create_tag", TargetType: domain.TargetTag, TargetID: "t1"})
+	record(t, service, time.Minute, domain.Entry{ActorID: "u1", Action: domain.ActionLogout, TargetType: domain.TargetUser, TargetID: "u1", Source: domain.SourceAuth})
+
+	ctx := context.Background()
+
+	entries, total, err := service.List(ctx, domain.Filter{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.ActionLogout, entries[0].Action, "newest first")
+	assert.Equal(t, "create_tag", entries[1].Action)
+
+	entries, _, err = service.List(ctx, domain.Filter{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "update_project", entries[0].Action)
+
+	entries, total, err = service.List(ctx, domain.Filter{ActorID: "u1", TargetType: domain.TargetProject})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "create_project", entries[0].Action)
+
+	from := time.Now().Add(-150 * time.Minute)
+	to := time.Now().Add(-30 * time.Minute)
+	entries, _, err = service.List(ctx, domain.Filter{From: &from, To: &to})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "create_tag", entries[0].Action)
+	assert.Equal(t, "update_project", entries[1].Action)
+
+	_, total, err = service.List(ctx, domain.Filter{Source: domain.SourceAuth})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestAuditService_Export(t *testing.T) {
+	service := setupAuditService(t, 0)
+	record(t, service, time.Hour, domain.Entry{ActorID: "u1", Action: "create_tag", TargetType: domain.TargetTag, TargetID: "t1",
+		Changes: domain.Changes{"name": {After: "smoke"}}})
+	record(t, service, time.Minute, domain.Entry{ActorID: "u2", Action: "delete_tag", TargetType: domain.TargetTag, TargetID: "t1",
+		IPAddress: "10.0.0.2"})
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	require.NoError(t, service.Export(ctx, domain.Filter{TargetID: "t1"}, application.ExportCSV, &out))
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"id", "occurred_at", "actor_id", "actor_email", "action", "target_type", "target_id",
+		"changes", "source", "ip_address", "user_agent", "request_id"}, rows[0])
+	assert.Equal(t, "delete_tag", rows[1][4])
+	assert.Equal(t, "10.0.0.2", rows[1][9])
+	assert.Equal(t, "create_tag", rows[2][4])
+	assert.JSONEq(t, `{"name":{"before":null,"after":"smoke"}}`, rows[2][7])
+
+	out.Reset()
+	require.NoError(t, service.Export(ctx, domain.Filter{ActorID: "u1"}, application.ExportJSON, &out))
+	var exported []domain.Entry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+	require.Len(t, exported, 1)
+	assert.Equal(t, "create_tag", exported[0].Action)
+
+	out.Reset()
+	require.NoError(t, service.Export(ctx, domain.Filter{ActorID: "nobody"}, application.ExportJSON, &out))
+	assert.JSONEq(t, `[]`, out.String())
+
+	assert.ErrorIs(t, service.Export(ctx, domain.Filter{}, "xml", &out), application.ErrUnsupportedFormat)
+}
+
+func TestAuditService_PurgeExpired(t *testing.T) {
+	service := setupAuditService(t, 24*time.Hour)
+	record(t, service, 48*time.Hour, domain.Entry{Action: "create_project"})
+	record(t, service, time.Hour, domain.Entry{Action: "update_project"})
+
+	removed, err := service.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	entries, _, err := service.List(context.Background(), domain.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "update_project", entries[0].Action)
+
+	// Without a retention period entries are kept forever
+	keepForever := setupAuditService(t, 0)
+	record(t, keepForever, 10*365*24*time.Hour, domain.Entry{Action: "create_project"})
+	removed, err = keepForever.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func TestAuditService_Snapshot(t *testing.T) {
+	service := setupAuditService(t, 0)
+	service.RegisterSnapshotter(domain.TargetTag, func(ctx context.Context, id string) (interface{}, error) {
+		if id != "t1" {
+			return nil, nil
+		}
+		return map[string]interface{}{"name": "smoke"}, nil
+	})
+
+	assert.True(t, service.CanSnapshot(domain.TargetTag))
+	assert.False(t, service.CanSnapshot(domain.TargetWebhook))
+
+	snapshot, err := service.Snapshot(context.Background(), domain.TargetTag, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.Snapshot{"name": "smoke"}, snapshot)
+
+	snapshot, err = service.Snapshot(context.Background(), domain.TargetTag, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	snapshot, err = service.Snapshot(context.Background(), domain.TargetWebhook, "w1")
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
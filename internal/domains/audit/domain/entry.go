@@ -0,0 +1,233 @@
+// Package domain defines the audit log of changes, sign-ins and sign-outs
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Source is the interface an audited action came through
+type Source string
+
+const (
+	SourceREST    Source = "rest"
+	SourceGraphQL Source = "graphql"
+	SourceAuth    Source = "auth"
+)
+
+// Actions recorded for sign-ins and sign-outs. Other actions are named after
+// the REST handler or GraphQL mutation that performed them, such as
+// "update_project".
+const (
+	ActionLogin       = "login"
+	ActionLoginFailed = "login_failed"
+	ActionLogout      = "logout"
+)
+
+// Target types of audited actions
+const (
+	TargetProject           = "project"
+	TargetProjectAccess     = "project_access"
+	TargetUser              = "user"
+	TargetGroup             = "group"
+	TargetTag               = "tag"
+	TargetFlakyTest         = "flaky_test"
+	TargetTestRun           = "test_run"
+	TargetSpecRun           = "spec_run"
+	TargetJiraConnection    = "jira_connection"
+	TargetTrackerConnection = "tracker_connection"
+	TargetVCSConnection     = "vcs_connection"
+	TargetWebhook           = "webhook"
+	TargetNotificationRule  = "notification_rule"
+	TargetSession           = "session"
+)
+
+// Entry is a record of the audit log. Entries are never changed once recorded.
+type Entry struct {
+	ID         uint      `json:"id"`
+	OccurredAt time.Time `json:"occurredAt"`
+	ActorID    string    `json:"actorId"`
+	ActorEmail string    `json:"actorEmail"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"targetType"`
+	TargetID   string    `json:"targetId"`
+	Changes    Changes   `json:"changes"`
+	Source     Source    `json:"source"`
+	IPAddress  string    `json:"ipAddress"`
+	UserAgent  string    `json:"userAgent"`
+	RequestID  string    `json:"requestId"`
+}
+
+// Change is the value of a field before and after an action; nil when the
+// field did not exist, such as before a create or after a delete
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes are the changed fields of an action's target by field name
+type Changes map[string]Change
+
+// Filter selects audit log entries. Empty fields match every entry; From is
+// inclusive and To exclusive.
+type Filter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	Source     Source
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// Repository defines the interface for audit log persistence. It can append
+// and remove entries, but not change them.
+type Repository interface {
+	// Append records an entry, setting its ID
+	Append(ctx context.Context, entry *Entry) error
+
+	// Find returns the entries matching the filter, newest first, and how many match in total
+	Find(ctx context.Context, filter Filter) ([]*Entry, int64, error)
+
+	// DeleteBefore removes entries older than the cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// RequestInfo identifies who made a request and where it came from
+type RequestInfo struct {
+	ActorID    string
+	ActorEmail string
+	IPAddress  string
+	UserAgent  string
+	RequestID  string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a context carrying the request info for audit entries
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info of a context, if any
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// Snapshot is the state of a target as JSON-like values by field name
+type Snapshot map[string]interface{}
+
+// NewSnapshot converts a value to a snapshot through its JSON encoding.
+// Values that do not encode to a JSON object are stored under "value".
+func NewSnapshot(value interface{}) (Snapshot, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if snapshot, ok := value.(Snapshot); ok {
+		return snapshot, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	if fields, ok := decoded.(map[string]interface{}); ok {
+		return fields, nil
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+	return Snapshot{"value": decoded}, nil
+}
+
+// Diff returns the fields that differ between two snapshots of a target.
+// Either snapshot may be nil, for targets that were created or deleted.
+// Values of sensitive fields, such as credentials, are redacted.
+func Diff(before, after Snapshot) Changes {
+	changes := Changes{}
+	for field, value := range before {
+		afterValue, exists := after[field]
+		if !exists || !reflect.DeepEqual(value, afterValue) {
+			changes[field] = Change{Before: value, After: afterValue}
+		}
+	}
+	for field, value := range after {
+		if _, exists := before[field]; !exists {
+			changes[field] = Change{After: value}
+		}
+	}
+
+	for field, change := range changes {
+		if IsSensitive(field) {
+			changes[field] = Change{Before: redactValue(change.Before), After: redactValue(change.After)}
+			continue
+		}
+		changes[field] = Change{Before: Redact(change.Before), After: Redact(change.After)}
+	}
+	return changes
+}
+
+// Redacted replaces the values of sensitive fields
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are parts of field names whose values are never recorded
+var sensitiveFields = []string{
+	"password", "secret", "token", "credential", "apikey", "api_key", "privatekey", "private_key",
+	// Slack and Teams incoming webhook URLs let anyone post to the channel
+	"webhookurl", "webhook_url",
+}
+
+// IsSensitive reports whether a field holds a secret, judging by its name
+func IsSensitive(field string) bool {
+	field = strings.ToLower(field)
+	for _, sensitive := range sensitiveFields {
+		if strings.Contains(field, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces the values of sensitive fields nested in a value
+func Redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for field, fieldValue := range v {
+			if IsSensitive(field) {
+				redacted[field] = redactValue(fieldValue)
+			} else {
+				redacted[field] = Redact(fieldValue)
+			}
+		}
+		return redacted
+	case Snapshot:
+		return Redact(map[string]interface{}(v))
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = Redact(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// redactValue hides a secret, keeping whether one was set
+func redactValue(value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	return Redacted
+}
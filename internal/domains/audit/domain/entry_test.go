@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	before := domain.Snapshot{"name": "Shop", "isActive": true, "team": "payments"}
+	after := domain.Snapshot{"name": "Shop", "isActive": false, "repository": "github.com/acme/shop"}
+
+	changes := domain.Diff(before, after)
+
+	assert.Equal(t, domain.Changes{
+		"isActive":   {Before: true, After: false},
+		"team":       {Before: "payments"},
+		"repository": {After: "github.com/acme/shop"},
+	}, changes)
+}
+
+func TestDiff_CreatedAndDeleted(t *testing.T) {
+	snapshot := domain.Snapshot{"name": "flaky"}
+
+	assert.Equal(t, domain.Changes{"name": {After: "flaky"}}, domain.Diff(nil, snapshot))
+	assert.Equal(t, domain.Changes{"name": {Before: "flaky"}}, domain.Diff(snapshot, nil))
+	assert.Empty(t, domain.Diff(nil, nil))
+}
+
+func TestDiff_RedactsSecrets(t *testing.T) {
+	before := domain.Snapshot{"apiToken": "", "settings": map[string]interface{}{"webhookSecret": "old"}}
+	after := domain.Snapshot{
+		"apiToken":    "abc123",
+		"settings":    map[string]interface{}{"webhookSecret": "new", "channel": "#qa"},
+		"Credentials": []interface{}{map[string]interface{}{"password": "hunter2"}},
+	}
+
+	changes := domain.Diff(before, after)
+
+	// Whether a secret was set is kept, but never its value
+	assert.Equal(t, domain.Change{Before: "", After: domain.Redacted}, changes["apiToken"])
+	assert.Equal(t, domain.Change{
+		Before: map[string]interface{}{"webhookSecret": domain.Redacted},
+		After:  map[string]interface{}{"webhookSecret": domain.Redacted, "channel": "#qa"},
+	}, changes["settings"])
+	assert.Equal(t, domain.Change{After: domain.Redacted}, changes["Credentials"])
+}
+
+func TestDiff_RedactsWebhookURLs(t *testing.T) {
+	// Notification rules are recorded from the request body
+	rule, err := domain.NewSnapshot(json.RawMessage(`{
+		"name": "QA channel",
+		"channelType": "slack",
+		"webhookUrl": "https://hooks.slack.com/services/T000/B000/XXXX"
+	}`))
+	require.NoError(t, err)
+
+	changes := domain.Diff(nil, rule)
+
+	assert.Equal(t, domain.Change{After: domain.Redacted}, changes["webhookUrl"])
+	assert.Equal(t, domain.Change{After: "QA channel"}, changes["name"])
+	assert.True(t, domain.IsSensitive("webhook_url"))
+}
+
+func TestNewSnapshot(t *testing.T) {
+	type project struct {
+		ProjectID string `json:"projectId"`
+		Active    bool   `json:"active"`
+	}
+
+	snapshot, err := domain.NewSnapshot(&project{ProjectID: "shop", Active: true})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Snapshot{"projectId": "shop", "active": true}, snapshot)
+
+	snapshot, err = domain.NewSnapshot([]string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Snapshot{"value": []interface{}{"a", "b"}}, snapshot)
+
+	snapshot, err = domain.NewSnapshot(nil)
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func TestRequestInfoContext(t *testing.T) {
+	_, ok := domain.RequestInfoFromContext(context.Background())
+	assert.False(t, ok)
+
+	info := domain.RequestInfo{ActorID: "u1", IPAddress: "10.0.0.1", RequestID: "req-1"}
+	got, ok := domain.RequestInfoFromContext(domain.WithRequestInfo(context.Background(), info))
+	assert.True(t, ok)
+	assert.Equal(t, info, got)
+}
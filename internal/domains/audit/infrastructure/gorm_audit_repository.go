@@ -0,0 +1,153 @@
+// Package infrastructure provides persistence for the audit log
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	"gorm.io/gorm"
+)
+
+// AuditLogDB represents the database model for audit log entries
+type AuditLogDB struct {
+	ID         uint      `gorm:"primaryKey"`
+	OccurredAt time.Time `gorm:"not null;index"`
+	ActorID    string    `gorm:"index"`
+	ActorEmail string
+	Action     string          `gorm:"not null;index"`
+	TargetType string          `gorm:"index:idx_audit_logs_target"`
+	TargetID   string          `gorm:"index:idx_audit_logs_target"`
+	Changes    json.RawMessage `gorm:"type:jsonb"`
+	Source     string          `gorm:"not null"`
+	IPAddress  string
+	UserAgent  string
+	RequestID  string
+}
+
+// TableName specifies the table name
+func (AuditLogDB) TableName() string {
+	return "audit_logs"
+}
+
+// GormAuditRepository is a GORM implementation of the audit log Repository
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditRepository creates a new GORM audit log repository
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+// Append records an entry, setting its ID
+func (r *GormAuditRepository) Append(ctx context.Context, entry *domain.Entry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	dbEntry := &AuditLogDB{
+		OccurredAt: entry.OccurredAt,
+		ActorID:    entry.ActorID,
+		ActorEmail: entry.ActorEmail,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Changes:    changes,
+		Source:     string(entry.Source),
+		IPAddress:  entry.IPAddress,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+	}
+	if err := r.db.WithContext(ctx).Create(dbEntry).Error; err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	entry.ID = dbEntry.ID
+	return nil
+}
+
+// Find returns the entries matching the filter, newest first, and how many match in total
+func (r *GormAuditRepository) Find(ctx context.Context, filter domain.Filter) ([]*domain.Entry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&AuditLogDB{})
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", string(filter.Source))
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var dbEntries []AuditLogDB
+	if err := query.Order("occurred_at DESC, id DESC").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Find(&dbEntries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find audit entries: %w", err)
+	}
+
+	entries := make([]*domain.Entry, len(dbEntries))
+	for i := range dbEntries {
+		entry, err := r.toDomainModel(&dbEntries[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		entries[i] = entry
+	}
+	return entries, total, nil
+}
+
+// DeleteBefore removes entries older than the cutoff and returns how many were removed
+func (r *GormAuditRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("occurred_at < ?", cutoff).Delete(&AuditLogDB{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete old audit entries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// toDomainModel converts a database model to a domain model
+func (r *GormAuditRepository) toDomainModel(dbEntry *AuditLogDB) (*domain.Entry, error) {
+	changes := domain.Changes{}
+	if len(dbEntry.Changes) > 0 {
+		if err := json.Unmarshal(dbEntry.Changes, &changes); err != nil {
+			return nil, fmt.Errorf("failed to decode changes of audit entry %d: %w", dbEntry.ID, err)
+		}
+	}
+
+	return &domain.Entry{
+		ID:         dbEntry.ID,
+		OccurredAt: dbEntry.OccurredAt,
+		ActorID:    dbEntry.ActorID,
+		ActorEmail: dbEntry.ActorEmail,
+		Action:     dbEntry.Action,
+		TargetType: dbEntry.TargetType,
+		TargetID:   dbEntry.TargetID,
+		Changes:    changes,
+		Source:     domain.Source(dbEntry.Source),
+		IPAddress:  dbEntry.IPAddress,
+		UserAgent:  dbEntry.UserAgent,
+		RequestID:  dbEntry.RequestID,
+	}, nil
+}
@@ -0,0 +1,341 @@
+// Package interfaces records REST requests, sign-ins and sign-outs in the audit log
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	authInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// maxBodySize bounds the request and response bodies read to work out what a
+// request changed
+const maxBodySize = 64 << 10
+
+// collectionTargets maps the collections of REST routes to audit target types
+var collectionTargets = map[string]string{
+	"projects":         domain.TargetProject,
+	"users":            domain.TargetUser,
+	"tags":             domain.TargetTag,
+	"flaky-tests":      domain.TargetFlakyTest,
+	"test-runs":        domain.TargetTestRun,
+	"jira-connections": domain.TargetJiraConnection,
+	"webhooks":         domain.TargetWebhook,
+	"rules":            domain.TargetNotificationRule,
+	"sessions":         domain.TargetSession,
+	// SCIM resource types
+	"Users":  domain.TargetUser,
+	"Groups": domain.TargetGroup,
+}
+
+// connectionTargets maps the integration a connections collection belongs to
+// to the audit target type of its connections
+var connectionTargets = map[string]string{
+	"jira":     domain.TargetJiraConnection,
+	"trackers": domain.TargetTrackerConnection,
+	"vcs":      domain.TargetVCSConnection,
+}
+
+// RequestInfo returns who made a request and where it came from
+func RequestInfo(c *gin.Context) domain.RequestInfo {
+	info := domain.RequestInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: c.GetString("request_id"),
+	}
+	if user, ok := authInterfaces.GetAuthUser(c); ok {
+		info.ActorID = user.UserID
+		info.ActorEmail = user.Email
+	}
+	return info
+}
+
+// Middleware records successful POST, PUT, PATCH and DELETE requests in the
+// audit log. Use it after authentication. The action is named after the route
+// handler, and the target is the last resource named in the route. Changes
+// are read from the target before and after the request when the service can
+// snapshot it, and are otherwise the fields of the request body.
+func Middleware(service *application.AuditService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutation(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		ctx := domain.WithRequestInfo(c.Request.Context(), RequestInfo(c))
+		c.Request = c.Request.WithContext(ctx)
+		log := logger.WithRequest(c.GetString("request_id"), c.Request.Method, c.Request.URL.Path)
+
+		target, parent := resolveTarget(c.FullPath(), c.Params)
+		before, err := service.Snapshot(ctx, target.Type, target.ID)
+		if err != nil {
+			log.WithError(err).Debug("Failed to snapshot audit target")
+		}
+		body := readBody(c)
+
+		// Targets created by the request are identified by the response
+		var response *responseRecorder
+		if target.ID == "" {
+			response = &responseRecorder{ResponseWriter: c.Writer}
+			c.Writer = response
+		}
+
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		if response != nil {
+			target.ID = createdID(response.body.Bytes(), target.Type)
+			if target.ID == "" && parent.ID != "" {
+				target = parent
+				before, _ = service.Snapshot(ctx, target.Type, target.ID)
+			}
+		}
+
+		var after domain.Snapshot
+		if service.CanSnapshot(target.Type) {
+			if after, err = service.Snapshot(ctx, target.Type, target.ID); err != nil {
+				log.WithError(err).Debug("Failed to snapshot audit target")
+			}
+		} else if c.Request.Method != http.MethodDelete {
+			after, _ = domain.NewSnapshot(body)
+		}
+
+		entry := &domain.Entry{
+			Action:     handlerAction(c.HandlerName(), c.Request.Method, c.FullPath()),
+			TargetType: target.Type,
+			TargetID:   target.ID,
+			Changes:    domain.Diff(before, after),
+			Source:     domain.SourceREST,
+		}
+		if err := service.Record(ctx, entry); err != nil {
+			log.WithError(err).Error("Failed to record audit entry")
+		}
+	}
+}
+
+// AuthEventRecorder records sign-ins and sign-outs in the audit log
+type AuthEventRecorder struct {
+	service *application.AuditService
+	logger  *logging.Logger
+}
+
+// NewAuthEventRecorder creates a recorder of sign-ins and sign-outs
+func NewAuthEventRecorder(service *application.AuditService, logger *logging.Logger) *AuthEventRecorder {
+	return &AuthEventRecorder{service: service, logger: logger}
+}
+
+// RecordAuthEvent records a sign-in, failed sign-in or sign-out of the user,
+// who may be nil if unknown
+func (r *AuthEventRecorder) RecordAuthEvent(c *gin.Context, action string, user *authDomain.User) {
+	info := RequestInfo(c)
+	entry := &domain.Entry{
+		Action:     action,
+		TargetType: domain.TargetUser,
+		Source:     domain.SourceAuth,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+		RequestID:  info.RequestID,
+	}
+	if user != nil {
+		entry.ActorID = user.UserID
+		entry.ActorEmail = user.Email
+		entry.TargetID = user.UserID
+	}
+
+	if err := r.service.Record(c.Request.Context(), entry); err != nil {
+		r.logger.WithRequest(info.RequestID, c.Request.Method, c.Request.URL.Path).
+			WithError(err).Error("Failed to record audit entry")
+	}
+}
+
+// target identifies the resource a request acts on
+type target struct {
+	Type string
+	ID   string
+}
+
+// resolveTarget finds the resource a route acts on: the last collection in
+// the route followed by an ID, or a collection ending the route, which the
+// request creates a resource in. The parent is the resource the route is
+// nested under, if any.
+func resolveTarget(fullPath string, params gin.Params) (target, target) {
+	segments := strings.Split(strings.Trim(fullPath, "/"), "/")
+
+	var current, parent target
+	for i := range segments {
+		targetType := collectionTarget(segments, i)
+		if targetType == "" {
+			continue
+		}
+		if i+1 < len(segments) && strings.HasPrefix(segments[i+1], ":") {
+			parent = current
+			current = target{Type: targetType, ID: params.ByName(segments[i+1][1:])}
+		} else if i == len(segments)-1 {
+			parent = current
+			current = target{Type: targetType}
+		}
+	}
+
+	// Project access is granted to users and groups, as in
+	// /projects/:projectId/users/:userId/access
+	if n := len(segments); n >= 5 && segments[n-1] == "access" && strings.HasPrefix(segments[n-2], ":") {
+		projectID := params.ByName("projectId")
+		if projectID != "" {
+			return target{
+				Type: domain.TargetProjectAccess,
+				ID:   projectID + "/" + segments[n-3] + "/" + params.ByName(segments[n-2][1:]),
+			}, target{Type: domain.TargetProject, ID: projectID}
+		}
+	}
+	return current, parent
+}
+
+// collectionTarget returns the target type of the collection at a segment of a route, if any
+func collectionTarget(segments []string, i int) string {
+	if segments[i] == "connections" && i > 0 {
+		return connectionTargets[segments[i-1]]
+	}
+	return collectionTargets[segments[i]]
+}
+
+// createdID reads the ID of a created resource from a JSON response, also
+// looking one object deep for responses wrapping the resource
+func createdID(body []byte, targetType string) string {
+	var response map[string]interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ""
+	}
+
+	keys := []string{"id"}
+	if targetType == domain.TargetProject {
+		// Projects are identified by their project ID rather than database ID
+		keys = []string{"projectId", "project_id", "id"}
+	}
+	if id := idField(response, keys); id != "" {
+		return id
+	}
+	for _, value := range response {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if id := idField(nested, keys); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// idField returns the first of the keys set on an object
+func idField(object map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		switch id := object[key].(type) {
+		case string:
+			if id != "" {
+				return id
+			}
+		case float64:
+			return strconv.FormatFloat(id, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// readBody returns the JSON request body, leaving it in place for the handler.
+// Bodies that are not JSON or too large to record are skipped.
+func readBody(c *gin.Context) interface{} {
+	if c.Request.Body == nil || !strings.Contains(c.ContentType(), "json") {
+		return nil
+	}
+	if c.Request.ContentLength > maxBodySize {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize+1))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), c.Request.Body))
+	if err != nil || len(data) > maxBodySize {
+		return nil
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil
+	}
+	return body
+}
+
+// handlerAction names an action after its route handler, such as
+// "update_project" for ProjectHandler.updateProject, falling back to the
+// method and route
+func handlerAction(handlerName, method, fullPath string) string {
+	name := strings.TrimSuffix(handlerName, "-fm")
+	parts := strings.Split(name, ".")
+	// Handlers returned by functions are named like Logout.func1
+	for len(parts) > 1 && strings.HasPrefix(parts[len(parts)-1], "func") {
+		parts = parts[:len(parts)-1]
+	}
+
+	if last := parts[len(parts)-1]; last != "" && !strings.ContainsAny(last, "()*/") {
+		return SnakeCase(last)
+	}
+	return strings.ToLower(method) + " " + fullPath
+}
+
+// SnakeCase converts a Go or GraphQL name, such as grantProjectAccess, to an
+// action name like grant_project_access
+func SnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a word at an upper case letter after a lower case one, or
+			// at the last letter of an acronym followed by a lower case one
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isMutation reports whether requests with the method change something
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// responseRecorder keeps a copy of the start of the response body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	if remaining := maxBodySize - w.body.Len(); remaining > 0 {
+		if len(data) < remaining {
+			remaining = len(data)
+		}
+		w.body.Write(data[:remaining])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,11 @@ import (
 type AuthenticationService struct {
 	userRepo    domain.UserRepository
 	sessionRepo domain.SessionRepository
+	policy      SessionPolicy
+	refresher   TokenRefresher
+
+	// refreshLocks serializes token refreshes of each session
+	refreshLocks sessionLocks
 }
 
 // NewAuthenticationService creates a new authentication service
@@ -22,6 +28,7 @@ func NewAuthenticationService(userRepo domain.UserRepository, sessionRepo domain
 	return &AuthenticationService{
 		userRepo:    userRepo,
 		sessionRepo: sessionRepo,
+		policy:      DefaultSessionPolicy(),
 	}
 }
 
@@ -97,6 +104,15 @@ func (s *AuthenticationService) ValidateSession(ctx context.Context, sessionID s
 		return nil, fmt.Errorf("session is invalid or expired")
 	}
 
+	if session.IsIdle(s.policy.IdleTimeout) {
+		_ = s.sessionRepo.Invalidate(ctx, sessionID)
+		return nil, fmt.Errorf("session has been idle too long")
+	}
+
+	if err := s.refreshTokens(ctx, session); err != nil {
+		return nil, err
+	}
+
 	// Update activity
 	if err := s.sessionRepo.UpdateActivity(ctx, sessionID); err != nil {
 		// Non-critical error, continue
@@ -116,6 +132,58 @@ func (s *AuthenticationService) ValidateSession(ctx context.Context, sessionID s
 	return session, nil
 }
 
+// AuthenticateBearer resolves the user a validated bearer token was issued
+// to. Token holders are not stored and get no session: a stored user keeps
+// their status, scopes and any role assigned by an admin, while other holders,
+// such as service accounts, are known only by the token's claims. Groups
+// always come from the token.
+func (s *AuthenticationService) AuthenticateBearer(ctx context.Context, userInfo UserInfo) (*domain.User, error) {
+	if userInfo.Sub == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userInfo.Sub)
+	if errors.Is(err, domain.ErrUserNotFound) && userInfo.Email != "" {
+		// Provisioned users are known by their email, as at sign-in
+		user, err = s.userRepo.FindByEmail(ctx, userInfo.Email)
+	}
+	switch {
+	case err == nil:
+		if !user.IsActive() {
+			return nil, fmt.Errorf("user account is not active")
+		}
+		if user.RoleAssignedBy == "" {
+			user.Role = s.determineUserRole(userInfo)
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		deleted, err := s.userRepo.IsDeleted(ctx, userInfo.Sub, userInfo.Email)
+		if err != nil {
+			return nil, err
+		}
+		if deleted {
+			return nil, fmt.Errorf("user account was deleted")
+		}
+		user = &domain.User{
+			UserID:        userInfo.Sub,
+			Email:         userInfo.Email,
+			Name:          userInfo.Name,
+			FirstName:     userInfo.FirstName,
+			LastName:      userInfo.LastName,
+			Role:          s.determineUserRole(userInfo),
+			Status:        domain.StatusActive,
+			EmailVerified: userInfo.EmailVerified,
+		}
+	default:
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	user.Groups = make([]domain.UserGroup, len(userInfo.Groups))
+	for i, group := range userInfo.Groups {
+		user.Groups[i] = domain.UserGroup{UserID: user.UserID, GroupName: group}
+	}
+	return user, nil
+}
+
 // Logout invalidates a user session
 func (s *AuthenticationService) Logout(ctx context.Context, sessionID string) error {
 	return s.sessionRepo.Invalidate(ctx, sessionID)
@@ -139,13 +207,28 @@ func (s *AuthenticationService) findOrCreateUser(ctx context.Context, userInfo U
 		user.LastName = userInfo.LastName
 		user.ProfileURL = userInfo.Picture
 		user.EmailVerified = userInfo.EmailVerified
-		user.Role = s.determineUserRole(userInfo)
+		// A role assigned by an admin outlives the user's groups
+		if user.RoleAssignedBy == "" {
+			user.Role = s.determineUserRole(userInfo)
+		}
 
 		if err := s.userRepo.Update(ctx, user); err != nil {
 			return nil, false, err
 		}
 		return user, false, nil
 	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, false, err
+	}
+
+	// A deleted user cannot sign in again, under the same subject or email
+	deleted, err := s.userRepo.IsDeleted(ctx, userInfo.Sub, userInfo.Email)
+	if err != nil {
+		return nil, false, err
+	}
+	if deleted {
+		return nil, false, fmt.Errorf("user account was deleted")
+	}
 
 	// Create new user
 	role := s.determineUserRole(userInfo)
@@ -171,11 +254,6 @@ func (s *AuthenticationService) findOrCreateUser(ctx context.Context, userInfo U
 }
 
 func (s *AuthenticationService) createSession(ctx context.Context, user *domain.User, tokenInfo TokenInfo, ipAddress, userAgent string) (*domain.Session, error) {
-	expiresAt := time.Now().Add(time.Duration(tokenInfo.ExpiresIn) * time.Second)
-	if tokenInfo.ExpiresIn == 0 {
-		expiresAt = time.Now().Add(24 * time.Hour) // Default 24 hours
-	}
-
 	sessionID, err := generateSessionID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
@@ -188,12 +266,14 @@ func (s *AuthenticationService) createSession(ctx context.Context, user *domain.
 		AccessToken:  tokenInfo.AccessToken,
 		RefreshToken: tokenInfo.RefreshToken,
 		IDToken:      tokenInfo.IDToken,
-		ExpiresAt:    expiresAt,
-		IsActive:     true,
-		IPAddress:    ipAddress,
-		UserAgent:    userAgent,
-		LastActivity: time.Now(),
-		CreatedAt:    time.Now(),
+		// The session outlives the access token, which is refreshed as needed
+		TokenExpiresAt: tokenExpiry(tokenInfo.ExpiresIn),
+		ExpiresAt:      time.Now().Add(s.policy.AbsoluteLifetime),
+		IsActive:       true,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		LastActivity:   time.Now(),
+		CreatedAt:      time.Now(),
 	}
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
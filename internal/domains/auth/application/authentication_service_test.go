@@ -94,6 +94,24 @@ func (m *MockUserRepository) GetUserScopes(ctx context.Context, userID string) (
 	return args.Get(0).([]domain.UserScope), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, userID, deletedBy, reassignTo string) error {
+	args := m.Called(ctx, userID, deletedBy, reassignTo)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) IsDeleted(ctx context.Context, userID, email string) (bool, error) {
+	args := m.Called(ctx, userID, email)
+	return args.Bool(0), args.Error(1)
+}
+
 type MockSessionRepository struct {
 	mock.Mock
 }
@@ -134,11 +152,29 @@ func (m *MockSessionRepository) InvalidateAllForUser(ctx context.Context, userID
 	return args.Error(0)
 }
 
-func (m *MockSessionRepository) CleanupExpired(ctx context.Context) error {
-	args := m.Called(ctx)
+func (m *MockSessionRepository) FindActiveByUser(ctx context.Context, userID string) ([]*domain.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) UpdateTokens(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) InvalidateForUser(ctx context.Context, userID string, id uint) error {
+	args := m.Called(ctx, userID, id)
 	return args.Error(0)
 }
 
+func (m *MockSessionRepository) CleanupExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 var _ = Describe("AuthenticationService", func() {
 	var (
 		authService     *application.AuthenticationService
@@ -178,7 +214,9 @@ var _ = Describe("AuthenticationService", func() {
 
 				// Setup mocks - user doesn't exist
 				mockUserRepo.On("FindByIDOrEmail", ctx, "oauth-user-123", "newuser@example.com").
-					Return(nil, fmt.Errorf("user not found"))
+					Return(nil, domain.ErrUserNotFound)
+				mockUserRepo.On("IsDeleted", ctx, "oauth-user-123", "newuser@example.com").
+					Return(false, nil)
 				mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).
 					Return(nil)
 				mockUserRepo.On("UpdateLastLogin", ctx, "oauth-user-123", mock.AnythingOfType("time.Time")).
@@ -265,7 +303,9 @@ var _ = Describe("AuthenticationService", func() {
 				}
 
 				mockUserRepo.On("FindByIDOrEmail", ctx, "user-123", "user@example.com").
-					Return(nil, fmt.Errorf("not found"))
+					Return(nil, domain.ErrUserNotFound)
+				mockUserRepo.On("IsDeleted", ctx, "user-123", "user@example.com").
+					Return(false, nil)
 				mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).
 					Return(fmt.Errorf("database error"))
 
@@ -287,7 +327,9 @@ var _ = Describe("AuthenticationService", func() {
 				}
 
 				mockUserRepo.On("FindByIDOrEmail", ctx, "user-123", "user@example.com").
-					Return(nil, fmt.Errorf("not found"))
+					Return(nil, domain.ErrUserNotFound)
+				mockUserRepo.On("IsDeleted", ctx, "user-123", "user@example.com").
+					Return(false, nil)
 				mockUserRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).
 					Return(nil)
 				mockUserRepo.On("UpdateLastLogin", ctx, "user-123", mock.AnythingOfType("time.Time")).
@@ -302,6 +344,52 @@ var _ = Describe("AuthenticationService", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("session creation failed"))
 			})
+
+			It("should not recreate a deleted user", func() {
+				userInfo := application.UserInfo{
+					Sub:   "user-456",
+					Email: "user@example.com",
+				}
+
+				tokenInfo := application.TokenInfo{
+					AccessToken: "token",
+					ExpiresIn:   3600,
+				}
+
+				// The user was deleted under another subject with the same email
+				mockUserRepo.On("FindByIDOrEmail", ctx, "user-456", "user@example.com").
+					Return(nil, domain.ErrUserNotFound)
+				mockUserRepo.On("IsDeleted", ctx, "user-456", "user@example.com").
+					Return(true, nil)
+
+				result, err := authService.AuthenticateWithOAuth(ctx, userInfo, tokenInfo, "", "")
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("user account was deleted"))
+				Expect(result).To(BeNil())
+				mockUserRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+				mockSessionRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+			})
+
+			It("should not create a user when the lookup fails", func() {
+				userInfo := application.UserInfo{
+					Sub:   "user-123",
+					Email: "user@example.com",
+				}
+
+				tokenInfo := application.TokenInfo{
+					AccessToken: "token",
+					ExpiresIn:   3600,
+				}
+
+				mockUserRepo.On("FindByIDOrEmail", ctx, "user-123", "user@example.com").
+					Return(nil, fmt.Errorf("database error"))
+
+				_, err := authService.AuthenticateWithOAuth(ctx, userInfo, tokenInfo, "", "")
+
+				Expect(err).To(HaveOccurred())
+				mockUserRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+			})
 		})
 	})
 
@@ -349,6 +437,89 @@ var _ = Describe("AuthenticationService", func() {
 		})
 	})
 
+	Describe("AuthenticateBearer", func() {
+		It("should use the stored user without creating a session", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").
+				Return(&domain.User{UserID: "svc-ci", Role: domain.RoleUser, Status: domain.StatusActive}, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{
+				Sub:    "svc-ci",
+				Groups: []string{"admin", "team-a"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Role).To(Equal(domain.RoleAdmin))
+			Expect(user.Groups).To(HaveLen(2))
+			mockSessionRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+		})
+
+		It("should keep a role assigned by an admin", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").
+				Return(&domain.User{UserID: "svc-ci", Role: domain.RoleUser, Status: domain.StatusActive, RoleAssignedBy: "admin-1"}, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{Sub: "svc-ci", Groups: []string{"admin"}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Role).To(Equal(domain.RoleUser))
+		})
+
+		It("should reject suspended users", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").
+				Return(&domain.User{UserID: "svc-ci", Status: domain.StatusSuspended}, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{Sub: "svc-ci"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(user).To(BeNil())
+		})
+
+		It("should reject deleted users", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("IsDeleted", ctx, "svc-ci", "").Return(true, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{Sub: "svc-ci"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(user).To(BeNil())
+		})
+
+		It("should find provisioned users by their email", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("FindByEmail", ctx, "ci@example.com").
+				Return(&domain.User{UserID: "provisioned-1", Email: "ci@example.com", Status: domain.StatusSuspended}, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{Sub: "svc-ci", Email: "ci@example.com"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(user).To(BeNil())
+		})
+
+		It("should build an unsaved user from the token claims", func() {
+			mockUserRepo.On("FindByID", ctx, "svc-ci").Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("FindByEmail", ctx, "ci@example.com").Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("IsDeleted", ctx, "svc-ci", "ci@example.com").Return(false, nil)
+
+			user, err := authService.AuthenticateBearer(ctx, application.UserInfo{
+				Sub:    "svc-ci",
+				Email:  "ci@example.com",
+				Groups: []string{"team-a"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.UserID).To(Equal("svc-ci"))
+			Expect(user.Role).To(Equal(domain.RoleUser))
+			Expect(user.IsActive()).To(BeTrue())
+			Expect(user.Groups).To(ConsistOf(domain.UserGroup{UserID: "svc-ci", GroupName: "team-a"}))
+			mockUserRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+		})
+
+		It("should reject tokens without a subject", func() {
+			_, err := authService.AuthenticateBearer(ctx, application.UserInfo{})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Logout", func() {
 		It("should invalidate session successfully", func() {
 			mockSessionRepo.On("Invalidate", ctx, "session-to-logout").
@@ -393,7 +564,9 @@ var _ = Describe("AuthenticationService", func() {
 			tokenInfo := application.TokenInfo{ExpiresIn: 3600}
 
 			mockUserRepo.On("FindByIDOrEmail", ctx, "admin-user", "admin@example.com").
-				Return(nil, fmt.Errorf("not found"))
+				Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("IsDeleted", ctx, "admin-user", "admin@example.com").
+				Return(false, nil)
 			mockUserRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
 				return u.Role == domain.RoleAdmin
 			})).Return(nil)
@@ -408,5 +581,37 @@ var _ = Describe("AuthenticationService", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.User.Role).To(Equal(domain.RoleAdmin))
 		})
+
+		It("should keep a role assigned by an admin", func() {
+			existingUser := &domain.User{
+				UserID:         "demoted-user",
+				Email:          "demoted@example.com",
+				Role:           domain.RoleUser,
+				Status:         domain.StatusActive,
+				RoleAssignedBy: "admin-1",
+			}
+			userInfo := application.UserInfo{
+				Sub:    "demoted-user",
+				Email:  "demoted@example.com",
+				Groups: []string{"admin"},
+			}
+
+			mockUserRepo.On("FindByIDOrEmail", ctx, "demoted-user", "demoted@example.com").
+				Return(existingUser, nil)
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Role == domain.RoleUser
+			})).Return(nil)
+			mockUserRepo.On("UpdateLastLogin", ctx, "demoted-user", mock.AnythingOfType("time.Time")).
+				Return(nil)
+			mockUserRepo.On("SetUserGroups", ctx, "demoted-user", []string{"admin"}).
+				Return(nil)
+			mockSessionRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+			result, err := authService.AuthenticateWithOAuth(ctx, userInfo, application.TokenInfo{ExpiresIn: 3600}, "", "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.User.Role).To(Equal(domain.RoleUser))
+			mockUserRepo.AssertExpectations(GinkgoT())
+		})
 	})
 })
\ No newline at end of file
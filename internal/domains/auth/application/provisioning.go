@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+// ProvisioningActor is recorded as the actor of changes the identity
+// provider makes through SCIM
+const ProvisioningActor = "scim"
+
+var (
+	// ErrInvalidUser is returned for provisioned users missing required fields
+	ErrInvalidUser = errors.New("invalid user")
+
+	// ErrInvalidGroup is returned for provisioned groups missing required
+	// fields or with members that are not users
+	ErrInvalidGroup = errors.New("invalid group")
+)
+
+// ProvisioningService lets the identity provider create and update users and
+// groups ahead of sign-in. Deprovisioned users are suspended rather than
+// deleted, so the resources they created keep their owner, and their sessions
+// end. Provisioned users sign in as usual, matched by their email.
+type ProvisioningService struct {
+	userRepo  domain.UserRepository
+	groupRepo domain.GroupRepository
+	userAdmin *UserAdminService
+}
+
+// NewProvisioningService creates a new provisioning service
+func NewProvisioningService(userRepo domain.UserRepository, groupRepo domain.GroupRepository, userAdmin *UserAdminService) *ProvisioningService {
+	return &ProvisioningService{
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+		userAdmin: userAdmin,
+	}
+}
+
+// GetUser gets a user by ID
+func (s *ProvisioningService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	return s.userRepo.FindByID(ctx, userID)
+}
+
+// ListUsers lists the users matching the filter with the total number of matches
+func (s *ProvisioningService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	return s.userRepo.List(ctx, filter)
+}
+
+// CreateUser provisions a user with the user role. Users provisioned as
+// inactive are suspended.
+func (s *ProvisioningService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := s.checkUser(ctx, user, ""); err != nil {
+		return nil, err
+	}
+
+	user.UserID = uuid.New().String()
+	user.Role = domain.RoleUser
+	if !user.IsActive() {
+		user.Status = domain.StatusSuspended
+	}
+	user.UpdatedBy = ProvisioningActor
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return s.userRepo.FindByID(ctx, user.UserID)
+}
+
+// ReplaceUser updates a provisioned user's profile and status. Users becoming
+// inactive are suspended and their sessions end; the role is left as is.
+func (s *ProvisioningService) ReplaceUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	existing, err := s.userRepo.FindByID(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUser(ctx, user, existing.UserID); err != nil {
+		return nil, err
+	}
+
+	existing.UserName = user.UserName
+	existing.ExternalID = user.ExternalID
+	existing.Email = user.Email
+	existing.Name = user.Name
+	existing.FirstName = user.FirstName
+	existing.LastName = user.LastName
+	existing.UpdatedBy = ProvisioningActor
+	if err := s.userRepo.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	switch {
+	case user.IsActive() && !existing.IsActive():
+		return s.userAdmin.ActivateUser(ctx, ProvisioningActor, user.UserID)
+	case !user.IsActive() && existing.IsActive():
+		return s.userAdmin.SuspendUser(ctx, ProvisioningActor, user.UserID)
+	}
+	return s.userRepo.FindByID(ctx, user.UserID)
+}
+
+// DeprovisionUser suspends a user and ends their sessions
+func (s *ProvisioningService) DeprovisionUser(ctx context.Context, userID string) error {
+	_, err := s.userAdmin.SuspendUser(ctx, ProvisioningActor, userID)
+	return err
+}
+
+// checkUser checks a user has the required fields and that no other user has
+// their user name or email
+func (s *ProvisioningService) checkUser(ctx context.Context, user *domain.User, userID string) error {
+	if user.UserName == "" {
+		return fmt.Errorf("%w: user name is required", ErrInvalidUser)
+	}
+	if user.Email == "" {
+		return fmt.Errorf("%w: email is required", ErrInvalidUser)
+	}
+
+	users, _, err := s.userRepo.List(ctx, domain.UserFilter{UserName: user.UserName, Limit: 2})
+	if err != nil {
+		return err
+	}
+	for _, other := range users {
+		if other.UserID != userID {
+			return fmt.Errorf("%w: user name %s is taken", domain.ErrUserExists, user.UserName)
+		}
+	}
+
+	other, err := s.userRepo.FindByEmail(ctx, user.Email)
+	switch {
+	case err == nil && other.UserID != userID:
+		return fmt.Errorf("%w: email %s is taken", domain.ErrUserExists, user.Email)
+	case err != nil && !errors.Is(err, domain.ErrUserNotFound):
+		return err
+	}
+	return nil
+}
+
+// GetGroup gets a group by ID
+func (s *ProvisioningService) GetGroup(ctx context.Context, groupID string) (*domain.Group, error) {
+	return s.groupRepo.FindByID(ctx, groupID)
+}
+
+// ListGroups lists the groups matching the filter with the total number of matches
+func (s *ProvisioningService) ListGroups(ctx context.Context, filter domain.GroupFilter) ([]*domain.Group, int64, error) {
+	return s.groupRepo.List(ctx, filter)
+}
+
+// CreateGroup provisions a group with its members. From then on, its members
+// are managed only through provisioning, not taken from sign-in tokens.
+func (s *ProvisioningService) CreateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	if err := s.checkGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	group.GroupID = uuid.New().String()
+	if err := s.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+	return s.groupRepo.FindByID(ctx, group.GroupID)
+}
+
+// ReplaceGroup renames a group and sets its members
+func (s *ProvisioningService) ReplaceGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	if err := s.checkGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+	return s.groupRepo.FindByID(ctx, group.GroupID)
+}
+
+// DeleteGroup removes a group and its memberships
+func (s *ProvisioningService) DeleteGroup(ctx context.Context, groupID string) error {
+	return s.groupRepo.Delete(ctx, groupID)
+}
+
+// checkGroup checks a group has a name and that its members are users,
+// dropping duplicate members
+func (s *ProvisioningService) checkGroup(ctx context.Context, group *domain.Group) error {
+	if group.DisplayName == "" {
+		return fmt.Errorf("%w: display name is required", ErrInvalidGroup)
+	}
+
+	members := make([]string, 0, len(group.Members))
+	seen := make(map[string]bool, len(group.Members))
+	for _, userID := range group.Members {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return fmt.Errorf("%w: member %s is not a user", ErrInvalidGroup, userID)
+			}
+			return err
+		}
+		members = append(members, userID)
+	}
+	group.Members = members
+	return nil
+}
@@ -0,0 +1,198 @@
+package application_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+// MockGroupRepository is a mock implementation of GroupRepository
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) Create(ctx context.Context, group *domain.Group) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) Update(ctx context.Context, group *domain.Group) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) FindByID(ctx context.Context, groupID string) (*domain.Group, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) List(ctx context.Context, filter domain.GroupFilter) ([]*domain.Group, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Group), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGroupRepository) Delete(ctx context.Context, groupID string) error {
+	args := m.Called(ctx, groupID)
+	return args.Error(0)
+}
+
+var _ = Describe("ProvisioningService", func() {
+	var (
+		service         *application.ProvisioningService
+		mockUserRepo    *MockUserRepository
+		mockSessionRepo *MockSessionRepository
+		mockGroupRepo   *MockGroupRepository
+		ctx             context.Context
+		user            *domain.User
+	)
+
+	BeforeEach(func() {
+		mockUserRepo = new(MockUserRepository)
+		mockSessionRepo = new(MockSessionRepository)
+		mockGroupRepo = new(MockGroupRepository)
+		ctx = context.Background()
+		service = application.NewProvisioningService(mockUserRepo, mockGroupRepo,
+			application.NewUserAdminService(mockUserRepo, mockSessionRepo))
+
+		user = &domain.User{
+			UserID:   "user-1",
+			UserName: "jdoe",
+			Email:    "jdoe@example.com",
+			Name:     "Jane Doe",
+			Role:     domain.RoleAdmin,
+			Status:   domain.StatusActive,
+		}
+	})
+
+	Describe("CreateUser", func() {
+		It("should create users with the user role", func() {
+			mockUserRepo.On("List", ctx, domain.UserFilter{UserName: "jdoe", Limit: 2}).Return([]*domain.User{}, int64(0), nil)
+			mockUserRepo.On("FindByEmail", ctx, "jdoe@example.com").Return(nil, domain.ErrUserNotFound)
+			mockUserRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.UserID != "" && u.Role == domain.RoleUser && u.Status == domain.StatusSuspended &&
+					u.UpdatedBy == application.ProvisioningActor
+			})).Return(nil)
+			mockUserRepo.On("FindByID", ctx, mock.Anything).Return(user, nil)
+
+			_, err := service.CreateUser(ctx, &domain.User{UserName: "jdoe", Email: "jdoe@example.com", Status: domain.StatusInactive})
+
+			Expect(err).NotTo(HaveOccurred())
+			mockUserRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should require a user name and email", func() {
+			_, err := service.CreateUser(ctx, &domain.User{Email: "jdoe@example.com"})
+			Expect(err).To(MatchError(application.ErrInvalidUser))
+
+			_, err = service.CreateUser(ctx, &domain.User{UserName: "jdoe"})
+			Expect(err).To(MatchError(application.ErrInvalidUser))
+		})
+
+		It("should reject taken user names and emails", func() {
+			mockUserRepo.On("List", ctx, domain.UserFilter{UserName: "jdoe", Limit: 2}).Return([]*domain.User{user}, int64(1), nil)
+
+			_, err := service.CreateUser(ctx, &domain.User{UserName: "jdoe", Email: "other@example.com"})
+			Expect(err).To(MatchError(domain.ErrUserExists))
+
+			mockUserRepo.On("List", ctx, domain.UserFilter{UserName: "jane", Limit: 2}).Return([]*domain.User{}, int64(0), nil)
+			mockUserRepo.On("FindByEmail", ctx, "jdoe@example.com").Return(user, nil)
+
+			_, err = service.CreateUser(ctx, &domain.User{UserName: "jane", Email: "jdoe@example.com"})
+			Expect(err).To(MatchError(domain.ErrUserExists))
+		})
+	})
+
+	Describe("ReplaceUser", func() {
+		BeforeEach(func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("List", ctx, domain.UserFilter{UserName: "jdoe", Limit: 2}).Return([]*domain.User{user}, int64(1), nil)
+			mockUserRepo.On("FindByEmail", ctx, "jdoe@example.com").Return(user, nil)
+		})
+
+		It("should update the profile and keep the role", func() {
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Name == "Jane Smith" && u.Role == domain.RoleAdmin && u.IsActive()
+			})).Return(nil)
+
+			replaced, err := service.ReplaceUser(ctx, &domain.User{
+				UserID: "user-1", UserName: "jdoe", Email: "jdoe@example.com", Name: "Jane Smith", Status: domain.StatusActive,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replaced.Name).To(Equal("Jane Smith"))
+			mockUserRepo.AssertExpectations(GinkgoT())
+			mockSessionRepo.AssertNotCalled(GinkgoT(), "InvalidateAllForUser", mock.Anything, mock.Anything)
+		})
+
+		It("should suspend users becoming inactive and end their sessions", func() {
+			mockUserRepo.On("Update", ctx, mock.Anything).Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(nil)
+
+			replaced, err := service.ReplaceUser(ctx, &domain.User{
+				UserID: "user-1", UserName: "jdoe", Email: "jdoe@example.com", Status: domain.StatusSuspended,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replaced.Status).To(Equal(domain.StatusSuspended))
+			Expect(replaced.UpdatedBy).To(Equal(application.ProvisioningActor))
+			mockSessionRepo.AssertExpectations(GinkgoT())
+		})
+	})
+
+	Describe("DeprovisionUser", func() {
+		It("should suspend the user and end their sessions", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Status == domain.StatusSuspended
+			})).Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(nil)
+
+			Expect(service.DeprovisionUser(ctx, "user-1")).To(Succeed())
+
+			mockUserRepo.AssertNotCalled(GinkgoT(), "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockSessionRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should report missing users", func() {
+			mockUserRepo.On("FindByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+			Expect(service.DeprovisionUser(ctx, "missing")).To(MatchError(domain.ErrUserNotFound))
+		})
+	})
+
+	Describe("CreateGroup", func() {
+		It("should create groups with their members once", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockGroupRepo.On("Create", ctx, mock.MatchedBy(func(g *domain.Group) bool {
+				return g.GroupID != "" && len(g.Members) == 1
+			})).Return(nil)
+			mockGroupRepo.On("FindByID", ctx, mock.Anything).Return(&domain.Group{GroupID: "g1"}, nil)
+
+			_, err := service.CreateGroup(ctx, &domain.Group{DisplayName: "shop-users", Members: []string{"user-1", "user-1"}})
+
+			Expect(err).NotTo(HaveOccurred())
+			mockGroupRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should reject groups without a name or with unknown members", func() {
+			_, err := service.CreateGroup(ctx, &domain.Group{})
+			Expect(err).To(MatchError(application.ErrInvalidGroup))
+
+			mockUserRepo.On("FindByID", ctx, "ghost").Return(nil, domain.ErrUserNotFound)
+			_, err = service.CreateGroup(ctx, &domain.Group{DisplayName: "shop-users", Members: []string{"ghost"}})
+			Expect(err).To(MatchError(application.ErrInvalidGroup))
+			mockGroupRepo.AssertNotCalled(GinkgoT(), "Create", mock.Anything, mock.Anything)
+		})
+	})
+})
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+// SessionPolicy bounds how long sessions last and when their OAuth access
+// tokens are refreshed
+type SessionPolicy struct {
+	// IdleTimeout ends sessions unused for this long; zero disables it
+	IdleTimeout time.Duration
+	// AbsoluteLifetime ends sessions this long after sign-in
+	AbsoluteLifetime time.Duration
+	// RefreshBefore is how long before the access token expires it is refreshed
+	RefreshBefore time.Duration
+}
+
+// DefaultSessionPolicy returns a policy of 24 hour sessions without an idle timeout
+func DefaultSessionPolicy() SessionPolicy {
+	return SessionPolicy{
+		AbsoluteLifetime: 24 * time.Hour,
+		RefreshBefore:    time.Minute,
+	}
+}
+
+// TokenRefresher exchanges a refresh token for new OAuth tokens
+type TokenRefresher interface {
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenInfo, error)
+}
+
+// SetSessionPolicy sets how long sessions last. Sessions last 24 hours when
+// no absolute lifetime is given.
+func (s *AuthenticationService) SetSessionPolicy(policy SessionPolicy) {
+	if policy.AbsoluteLifetime <= 0 {
+		policy.AbsoluteLifetime = DefaultSessionPolicy().AbsoluteLifetime
+	}
+	s.policy = policy
+}
+
+// SetTokenRefresher sets how access tokens are refreshed. Without one, access
+// tokens are not refreshed.
+func (s *AuthenticationService) SetTokenRefresher(refresher TokenRefresher) {
+	s.refresher = refresher
+}
+
+// ListSessions lists a user's active sessions, most recently used first
+func (s *AuthenticationService) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	sessions, err := s.sessionRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions past their idle timeout are rejected on their next request
+	active := sessions[:0]
+	for _, session := range sessions {
+		if !session.IsIdle(s.policy.IdleTimeout) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+// RevokeSession ends one of a user's sessions
+func (s *AuthenticationService) RevokeSession(ctx context.Context, userID string, id uint) error {
+	return s.sessionRepo.InvalidateForUser(ctx, userID, id)
+}
+
+// RevokeOtherSessions ends all of a user's sessions except the current one,
+// returning how many were ended
+func (s *AuthenticationService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int, error) {
+	sessions, err := s.sessionRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.SessionID == currentSessionID {
+			continue
+		}
+		if err := s.sessionRepo.InvalidateForUser(ctx, userID, session.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// CleanupExpiredSessions removes expired and ended sessions, returning how
+// many were removed
+func (s *AuthenticationService) CleanupExpiredSessions(ctx context.Context) (int64, error) {
+	return s.sessionRepo.CleanupExpired(ctx)
+}
+
+// refreshTokens refreshes a session's access token when it is about to
+// expire. A failed refresh ends the session once its access token has
+// expired, as the provider no longer vouches for the user; until then the
+// refresh is retried on later requests.
+//
+// Refreshes of a session are serialized and the session is reloaded first,
+// so tokens another request just refreshed are reused rather than refreshed
+// again with a refresh token the provider has since rotated.
+func (s *AuthenticationService) refreshTokens(ctx context.Context, session *domain.Session) error {
+	if s.refresher == nil || !session.NeedsTokenRefresh(s.policy.RefreshBefore) {
+		return nil
+	}
+
+	unlock := s.refreshLocks.lock(session.SessionID)
+	defer unlock()
+
+	if latest, err := s.sessionRepo.FindActiveByID(ctx, session.SessionID); err == nil && latest.TokenExpiresAt.After(session.TokenExpiresAt) {
+		session.AccessToken = latest.AccessToken
+		session.RefreshToken = latest.RefreshToken
+		session.IDToken = latest.IDToken
+		session.TokenExpiresAt = latest.TokenExpiresAt
+		return nil
+	}
+
+	tokens, err := s.refresher.RefreshToken(ctx, session.RefreshToken)
+	if err != nil {
+		if session.IsTokenExpired() {
+			_ = s.sessionRepo.Invalidate(ctx, session.SessionID)
+			return fmt.Errorf("failed to refresh access token: %w", err)
+		}
+		return nil
+	}
+
+	session.AccessToken = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		session.RefreshToken = tokens.RefreshToken
+	}
+	if tokens.IDToken != "" {
+		session.IDToken = tokens.IDToken
+	}
+	session.TokenExpiresAt = tokenExpiry(tokens.ExpiresIn)
+
+	if err := s.sessionRepo.UpdateTokens(ctx, session); err != nil {
+		return fmt.Errorf("failed to store refreshed tokens: %w", err)
+	}
+	return nil
+}
+
+// tokenExpiry converts a token lifetime in seconds to an expiry time, or zero
+// when the provider did not say
+func tokenExpiry(expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(expiresIn) * time.Second)
+}
+
+// sessionLocks holds a lock per session, kept only while it is in use
+type sessionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+type sessionLock struct {
+	sync.Mutex
+	holders int
+}
+
+// lock locks a session, returning the function that unlocks it
+func (l *sessionLocks) lock(sessionID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sessionLock)
+	}
+	lock, ok := l.locks[sessionID]
+	if !ok {
+		lock = &sessionLock{}
+		l.locks[sessionID] = lock
+	}
+	lock.holders++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		l.mu.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(l.locks, sessionID)
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,275 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+type fakeTokenRefresher struct {
+	tokens *application.TokenInfo
+	err    error
+	calls  []string
+}
+
+func (f *fakeTokenRefresher) RefreshToken(ctx context.Context, refreshToken string) (*application.TokenInfo, error) {
+	f.calls = append(f.calls, refreshToken)
+	return f.tokens, f.err
+}
+
+// rotatingTokenRefresher accepts each refresh token once, like providers
+// that rotate refresh tokens
+type rotatingTokenRefresher struct {
+	mu           sync.Mutex
+	refreshToken string
+	calls        int
+}
+
+func (r *rotatingTokenRefresher) RefreshToken(ctx context.Context, refreshToken string) (*application.TokenInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if refreshToken != r.refreshToken {
+		return nil, fmt.Errorf("invalid_grant")
+	}
+	// Give concurrent requests time to pile up
+	time.Sleep(10 * time.Millisecond)
+	r.refreshToken = fmt.Sprintf("refresh-%d", r.calls+1)
+	return &application.TokenInfo{AccessToken: fmt.Sprintf("access-%d", r.calls+1), RefreshToken: r.refreshToken, ExpiresIn: 3600}, nil
+}
+
+// memorySessionRepository stores sessions in memory, handing out copies
+type memorySessionRepository struct {
+	domain.SessionRepository
+	mu          sync.Mutex
+	sessions    map[string]domain.Session
+	invalidated []string
+}
+
+func (r *memorySessionRepository) FindActiveByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[sessionID]
+	if !ok || !session.IsActive {
+		return nil, domain.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (r *memorySessionRepository) UpdateTokens(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.SessionID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) UpdateActivity(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (r *memorySessionRepository) Invalidate(ctx context.Context, sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessions[sessionID]
+	session.IsActive = false
+	r.sessions[sessionID] = session
+	r.invalidated = append(r.invalidated, sessionID)
+	return nil
+}
+
+var _ = Describe("Session management", func() {
+	var (
+		authService     *application.AuthenticationService
+		mockUserRepo    *MockUserRepository
+		mockSessionRepo *MockSessionRepository
+		refresher       *fakeTokenRefresher
+		ctx             context.Context
+		user            *domain.User
+	)
+
+	newSession := func(sessionID string) *domain.Session {
+		return &domain.Session{
+			ID:             1,
+			SessionID:      sessionID,
+			UserID:         "user-1",
+			RefreshToken:   "refresh-1",
+			TokenExpiresAt: time.Now().Add(time.Hour),
+			ExpiresAt:      time.Now().Add(12 * time.Hour),
+			IsActive:       true,
+			LastActivity:   time.Now(),
+		}
+	}
+
+	BeforeEach(func() {
+		mockUserRepo = new(MockUserRepository)
+		mockSessionRepo = new(MockSessionRepository)
+		refresher = &fakeTokenRefresher{}
+		ctx = context.Background()
+
+		authService = application.NewAuthenticationService(mockUserRepo, mockSessionRepo)
+		authService.SetSessionPolicy(application.SessionPolicy{
+			IdleTimeout:      time.Hour,
+			AbsoluteLifetime: 8 * time.Hour,
+			RefreshBefore:    time.Minute,
+		})
+		authService.SetTokenRefresher(refresher)
+
+		user = &domain.User{UserID: "user-1", Role: domain.RoleUser, Status: domain.StatusActive}
+	})
+
+	Describe("AuthenticateWithOAuth", func() {
+		It("should bound sessions by their absolute lifetime rather than the access token", func() {
+			mockUserRepo.On("FindByIDOrEmail", ctx, "user-1", "").Return(user, nil)
+			mockUserRepo.On("Update", ctx, user).Return(nil)
+			mockUserRepo.On("SetUserGroups", ctx, "user-1", []string(nil)).Return(nil)
+			mockUserRepo.On("UpdateLastLogin", ctx, "user-1", mock.AnythingOfType("time.Time")).Return(nil)
+			mockSessionRepo.On("Create", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
+
+			result, err := authService.AuthenticateWithOAuth(ctx, application.UserInfo{Sub: "user-1"},
+				application.TokenInfo{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 300}, "", "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Session.ExpiresAt).To(BeTemporally("~", time.Now().Add(8*time.Hour), time.Second))
+			Expect(result.Session.TokenExpiresAt).To(BeTemporally("~", time.Now().Add(5*time.Minute), time.Second))
+		})
+	})
+
+	Describe("ValidateSession", func() {
+		It("should end sessions past the idle timeout", func() {
+			session := newSession("idle")
+			session.LastActivity = time.Now().Add(-2 * time.Hour)
+			mockSessionRepo.On("FindActiveByID", ctx, "idle").Return(session, nil)
+			mockSessionRepo.On("Invalidate", ctx, "idle").Return(nil)
+
+			_, err := authService.ValidateSession(ctx, "idle")
+
+			Expect(err).To(MatchError(ContainSubstring("idle")))
+			mockSessionRepo.AssertCalled(GinkgoT(), "Invalidate", ctx, "idle")
+		})
+
+		It("should refresh access tokens about to expire", func() {
+			session := newSession("expiring")
+			session.TokenExpiresAt = time.Now().Add(30 * time.Second)
+			refresher.tokens = &application.TokenInfo{AccessToken: "access-2", ExpiresIn: 3600}
+			mockSessionRepo.On("FindActiveByID", ctx, "expiring").Return(session, nil)
+			mockSessionRepo.On("UpdateTokens", ctx, session).Return(nil)
+			mockSessionRepo.On("UpdateActivity", ctx, "expiring").Return(nil)
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+
+			validated, err := authService.ValidateSession(ctx, "expiring")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(refresher.calls).To(Equal([]string{"refresh-1"}))
+			Expect(validated.AccessToken).To(Equal("access-2"))
+			Expect(validated.RefreshToken).To(Equal("refresh-1"))
+			Expect(validated.TokenExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+			mockSessionRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should not refresh access tokens that remain valid", func() {
+			mockSessionRepo.On("FindActiveByID", ctx, "fresh").Return(newSession("fresh"), nil)
+			mockSessionRepo.On("UpdateActivity", ctx, "fresh").Return(nil)
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+
+			_, err := authService.ValidateSession(ctx, "fresh")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(refresher.calls).To(BeEmpty())
+		})
+
+		It("should keep sessions whose refresh fails before the access token expires", func() {
+			session := newSession("retry")
+			session.TokenExpiresAt = time.Now().Add(30 * time.Second)
+			refresher.err = fmt.Errorf("provider unavailable")
+			mockSessionRepo.On("FindActiveByID", ctx, "retry").Return(session, nil)
+			mockSessionRepo.On("UpdateActivity", ctx, "retry").Return(nil)
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+
+			_, err := authService.ValidateSession(ctx, "retry")
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should end sessions whose refresh fails after the access token expired", func() {
+			session := newSession("revoked")
+			session.TokenExpiresAt = time.Now().Add(-time.Minute)
+			refresher.err = fmt.Errorf("invalid_grant")
+			mockSessionRepo.On("FindActiveByID", ctx, "revoked").Return(session, nil)
+			mockSessionRepo.On("Invalidate", ctx, "revoked").Return(nil)
+
+			_, err := authService.ValidateSession(ctx, "revoked")
+
+			Expect(err).To(MatchError(ContainSubstring("invalid_grant")))
+			mockSessionRepo.AssertCalled(GinkgoT(), "Invalidate", ctx, "revoked")
+		})
+	})
+
+	Describe("concurrent requests", func() {
+		It("should refresh an expired access token once and keep the session", func() {
+			session := newSession("busy")
+			session.TokenExpiresAt = time.Now().Add(-time.Minute)
+			sessions := &memorySessionRepository{sessions: map[string]domain.Session{"busy": *session}}
+			rotating := &rotatingTokenRefresher{refreshToken: "refresh-1"}
+			mockUserRepo.On("FindByID", mock.Anything, "user-1").Return(user, nil)
+
+			authService = application.NewAuthenticationService(mockUserRepo, sessions)
+			authService.SetTokenRefresher(rotating)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 8)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, errs[i] = authService.ValidateSession(ctx, "busy")
+				}(i)
+			}
+			wg.Wait()
+
+			for _, err := range errs {
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(rotating.calls).To(Equal(1))
+			Expect(sessions.invalidated).To(BeEmpty())
+			Expect(sessions.sessions["busy"].RefreshToken).To(Equal("refresh-2"))
+		})
+	})
+
+	Describe("ListSessions", func() {
+		It("should leave out sessions past the idle timeout", func() {
+			idle := newSession("idle")
+			idle.LastActivity = time.Now().Add(-2 * time.Hour)
+			mockSessionRepo.On("FindActiveByUser", ctx, "user-1").Return([]*domain.Session{newSession("active"), idle}, nil)
+
+			sessions, err := authService.ListSessions(ctx, "user-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].SessionID).To(Equal("active"))
+		})
+	})
+
+	Describe("RevokeOtherSessions", func() {
+		It("should end every session but the current one", func() {
+			current := newSession("current")
+			other := newSession("other")
+			other.ID = 2
+			mockSessionRepo.On("FindActiveByUser", ctx, "user-1").Return([]*domain.Session{current, other}, nil)
+			mockSessionRepo.On("InvalidateForUser", ctx, "user-1", uint(2)).Return(nil)
+
+			revoked, err := authService.RevokeOtherSessions(ctx, "user-1", "current")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(Equal(1))
+			mockSessionRepo.AssertNotCalled(GinkgoT(), "InvalidateForUser", ctx, "user-1", uint(1))
+		})
+	})
+})
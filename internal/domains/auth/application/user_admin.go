@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+const (
+	// defaultUserPageSize is how many users a listing returns by default
+	defaultUserPageSize = 20
+
+	// maxUserPageSize caps how many users a listing returns
+	maxUserPageSize = 100
+)
+
+var (
+	// ErrSelfAdministration is returned when admins change their own role, suspend or delete themselves
+	ErrSelfAdministration = errors.New("admins cannot change their own role, suspend or delete themselves")
+
+	// ErrInvalidReassignment is returned when a deleted user's resources cannot be reassigned to the given user
+	ErrInvalidReassignment = errors.New("invalid user to reassign resources to")
+)
+
+// UserAdminService lets admins manage user accounts. Changes are recorded
+// against the acting admin and apply to the user's next request, as sessions
+// reload the user on every request.
+type UserAdminService struct {
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
+}
+
+// NewUserAdminService creates a new user administration service
+func NewUserAdminService(userRepo domain.UserRepository, sessionRepo domain.SessionRepository) *UserAdminService {
+	return &UserAdminService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ListUsers lists the users matching the filter with the total number of matches
+func (s *UserAdminService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	if filter.Role != "" && !filter.Role.IsValid() {
+		return nil, 0, fmt.Errorf("%w: %s", domain.ErrInvalidRole, filter.Role)
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, filter.Status)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserPageSize
+	}
+	if filter.Limit > maxUserPageSize {
+		filter.Limit = maxUserPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return s.userRepo.List(ctx, filter)
+}
+
+// GetUser gets a user by ID
+func (s *UserAdminService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	return s.userRepo.FindByID(ctx, userID)
+}
+
+// UpdateUserRole assigns a role to a user. The role is kept at sign-in
+// rather than derived from the user's groups.
+func (s *UserAdminService) UpdateUserRole(ctx context.Context, actorID, userID string, role domain.UserRole) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidRole, role)
+	}
+	if actorID == userID {
+		return nil, ErrSelfAdministration
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	user.RoleAssignedBy = actorID
+	user.UpdatedBy = actorID
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+	return user, nil
+}
+
+// SuspendUser suspends a user and ends all of their sessions
+func (s *UserAdminService) SuspendUser(ctx context.Context, actorID, userID string) (*domain.User, error) {
+	if actorID == userID {
+		return nil, ErrSelfAdministration
+	}
+
+	user, err := s.setStatus(ctx, actorID, userID, domain.StatusSuspended)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.InvalidateAllForUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to invalidate user sessions: %w", err)
+	}
+	return user, nil
+}
+
+// ActivateUser reactivates a suspended or inactive user
+func (s *UserAdminService) ActivateUser(ctx context.Context, actorID, userID string) (*domain.User, error) {
+	return s.setStatus(ctx, actorID, userID, domain.StatusActive)
+}
+
+// DeleteUser soft-deletes a user, ends their sessions and reassigns the
+// resources they created to reassignTo, or to the acting admin if empty
+func (s *UserAdminService) DeleteUser(ctx context.Context, actorID, userID, reassignTo string) error {
+	if actorID == userID {
+		return ErrSelfAdministration
+	}
+	if reassignTo == "" {
+		reassignTo = actorID
+	}
+	if reassignTo == userID {
+		return fmt.Errorf("%w: cannot reassign to the deleted user", ErrInvalidReassignment)
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	if reassignTo != actorID {
+		if _, err := s.userRepo.FindByID(ctx, reassignTo); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return fmt.Errorf("%w: user %s not found", ErrInvalidReassignment, reassignTo)
+			}
+			return err
+		}
+	}
+
+	if err := s.userRepo.Delete(ctx, userID, actorID, reassignTo); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.InvalidateAllForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to invalidate user sessions: %w", err)
+	}
+	return nil
+}
+
+// setStatus changes the status of a user on behalf of an admin
+func (s *UserAdminService) setStatus(ctx context.Context, actorID, userID string, status domain.UserStatus) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Status = status
+	user.UpdatedBy = actorID
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user status: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,197 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+)
+
+var _ = Describe("UserAdminService", func() {
+	var (
+		service         *application.UserAdminService
+		mockUserRepo    *MockUserRepository
+		mockSessionRepo *MockSessionRepository
+		ctx             context.Context
+		user            *domain.User
+	)
+
+	BeforeEach(func() {
+		mockUserRepo = new(MockUserRepository)
+		mockSessionRepo = new(MockSessionRepository)
+		ctx = context.Background()
+		service = application.NewUserAdminService(mockUserRepo, mockSessionRepo)
+
+		user = &domain.User{
+			UserID: "user-1",
+			Email:  "user@example.com",
+			Role:   domain.RoleUser,
+			Status: domain.StatusActive,
+		}
+	})
+
+	Describe("ListUsers", func() {
+		It("should apply the default page size", func() {
+			mockUserRepo.On("List", ctx, domain.UserFilter{Search: "ali", Role: domain.RoleAdmin, Limit: 20}).
+				Return([]*domain.User{user}, int64(1), nil)
+
+			users, total, err := service.ListUsers(ctx, domain.UserFilter{Search: "ali", Role: domain.RoleAdmin})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(ConsistOf(user))
+			Expect(total).To(Equal(int64(1)))
+		})
+
+		It("should cap the page size", func() {
+			mockUserRepo.On("List", ctx, domain.UserFilter{Limit: 100, Offset: 200}).
+				Return([]*domain.User{}, int64(0), nil)
+
+			_, _, err := service.ListUsers(ctx, domain.UserFilter{Limit: 5000, Offset: 200})
+
+			Expect(err).NotTo(HaveOccurred())
+			mockUserRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should reject unknown roles and statuses", func() {
+			_, _, err := service.ListUsers(ctx, domain.UserFilter{Role: "owner"})
+			Expect(err).To(MatchError(domain.ErrInvalidRole))
+
+			_, _, err = service.ListUsers(ctx, domain.UserFilter{Status: "banned"})
+			Expect(err).To(MatchError(domain.ErrInvalidStatus))
+		})
+	})
+
+	Describe("UpdateUserRole", func() {
+		It("should assign the role on behalf of the admin", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Role == domain.RoleAdmin && u.RoleAssignedBy == "admin-1" && u.UpdatedBy == "admin-1"
+			})).Return(nil)
+
+			updated, err := service.UpdateUserRole(ctx, "admin-1", "user-1", domain.RoleAdmin)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Role).To(Equal(domain.RoleAdmin))
+			mockUserRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should reject unknown roles", func() {
+			_, err := service.UpdateUserRole(ctx, "admin-1", "user-1", "owner")
+
+			Expect(err).To(MatchError(domain.ErrInvalidRole))
+		})
+
+		It("should not let admins change their own role", func() {
+			_, err := service.UpdateUserRole(ctx, "admin-1", "admin-1", domain.RoleUser)
+
+			Expect(err).To(MatchError(application.ErrSelfAdministration))
+		})
+
+		It("should report missing users", func() {
+			mockUserRepo.On("FindByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+			_, err := service.UpdateUserRole(ctx, "admin-1", "missing", domain.RoleAdmin)
+
+			Expect(err).To(MatchError(domain.ErrUserNotFound))
+		})
+	})
+
+	Describe("SuspendUser", func() {
+		It("should suspend the user and end their sessions", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Status == domain.StatusSuspended && u.UpdatedBy == "admin-1"
+			})).Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(nil)
+
+			suspended, err := service.SuspendUser(ctx, "admin-1", "user-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suspended.IsActive()).To(BeFalse())
+			mockUserRepo.AssertExpectations(GinkgoT())
+			mockSessionRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should not let admins suspend themselves", func() {
+			_, err := service.SuspendUser(ctx, "admin-1", "admin-1")
+
+			Expect(err).To(MatchError(application.ErrSelfAdministration))
+		})
+
+		It("should report sessions that could not be ended", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Update", ctx, mock.Anything).Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(fmt.Errorf("database error"))
+
+			_, err := service.SuspendUser(ctx, "admin-1", "user-1")
+
+			Expect(err).To(MatchError(ContainSubstring("database error")))
+		})
+	})
+
+	Describe("ActivateUser", func() {
+		It("should reactivate a suspended user", func() {
+			user.Status = domain.StatusSuspended
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Status == domain.StatusActive && u.UpdatedBy == "admin-1"
+			})).Return(nil)
+
+			activated, err := service.ActivateUser(ctx, "admin-1", "user-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(activated.IsActive()).To(BeTrue())
+		})
+	})
+
+	Describe("DeleteUser", func() {
+		It("should reassign resources to the acting admin by default", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("Delete", ctx, "user-1", "admin-1", "admin-1").Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(nil)
+
+			Expect(service.DeleteUser(ctx, "admin-1", "user-1", "")).To(Succeed())
+
+			mockUserRepo.AssertExpectations(GinkgoT())
+			mockSessionRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should reassign resources to another user", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("FindByID", ctx, "user-2").Return(&domain.User{UserID: "user-2"}, nil)
+			mockUserRepo.On("Delete", ctx, "user-1", "admin-1", "user-2").Return(nil)
+			mockSessionRepo.On("InvalidateAllForUser", ctx, "user-1").Return(nil)
+
+			Expect(service.DeleteUser(ctx, "admin-1", "user-1", "user-2")).To(Succeed())
+
+			mockUserRepo.AssertExpectations(GinkgoT())
+		})
+
+		It("should reject reassignment to a missing user", func() {
+			mockUserRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+			mockUserRepo.On("FindByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+			err := service.DeleteUser(ctx, "admin-1", "user-1", "missing")
+
+			Expect(err).To(MatchError(application.ErrInvalidReassignment))
+			mockUserRepo.AssertNotCalled(GinkgoT(), "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		It("should reject reassignment to the deleted user", func() {
+			err := service.DeleteUser(ctx, "admin-1", "user-1", "user-1")
+
+			Expect(err).To(MatchError(application.ErrInvalidReassignment))
+		})
+
+		It("should not let admins delete themselves", func() {
+			err := service.DeleteUser(ctx, "admin-1", "admin-1", "")
+
+			Expect(err).To(MatchError(application.ErrSelfAdministration))
+		})
+	})
+})
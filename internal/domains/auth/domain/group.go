@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrGroupNotFound is returned when a group does not exist
+	ErrGroupNotFound = errors.New("group not found")
+
+	// ErrGroupExists is returned when a group name is already taken
+	ErrGroupExists = errors.New("group already exists")
+)
+
+// Group is an identity provider group provisioned through SCIM. Its members
+// are the users with a UserGroup of the same name, so provisioned groups grant
+// the same team roles and project access as groups from sign-in tokens.
+type Group struct {
+	// GroupID stays the same when the group is renamed
+	GroupID     string
+	DisplayName string
+	ExternalID  string
+	// Members are the IDs of the users in the group
+	Members   []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GroupFilter selects groups for listing; empty fields match every group
+type GroupFilter struct {
+	// DisplayName matches group names, ignoring case
+	DisplayName string
+	ExternalID  string
+	// Member matches groups the user is a member of
+	Member string
+	// A negative limit lists every matching group
+	Limit  int
+	Offset int
+}
+
+// HasMember checks if a user is a member of the group
+func (g *Group) HasMember(userID string) bool {
+	for _, member := range g.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -14,8 +14,18 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByIDOrEmail(ctx context.Context, userID, email string) (*User, error)
 	UpdateLastLogin(ctx context.Context, userID string, loginTime time.Time) error
+	List(ctx context.Context, filter UserFilter) ([]*User, int64, error)
+
+	// Delete soft-deletes a user, removing their groups, scopes and project
+	// access, and reassigns the resources they created to reassignTo
+	Delete(ctx context.Context, userID, deletedBy, reassignTo string) error
+
+	// IsDeleted checks if a user with the ID, or with the email if given, was deleted
+	IsDeleted(ctx context.Context, userID, email string) (bool, error)
 
 	// Group operations
+	// SetUserGroups sets the user's memberships of groups that are not
+	// provisioned, which are managed through the GroupRepository
 	SetUserGroups(ctx context.Context, userID string, groups []string) error
 	GetUserGroups(ctx context.Context, userID string) ([]UserGroup, error)
 
@@ -25,13 +35,36 @@ type UserRepository interface {
 	GetUserScopes(ctx context.Context, userID string) ([]UserScope, error)
 }
 
+// GroupRepository defines the interface for persisting groups provisioned
+// by the identity provider
+type GroupRepository interface {
+	// Create stores a group with its members, returning ErrGroupExists if its
+	// name is taken
+	Create(ctx context.Context, group *Group) error
+	// Update renames a group and sets its external ID and members. Renaming
+	// moves its members and project permissions to the new name.
+	Update(ctx context.Context, group *Group) error
+	FindByID(ctx context.Context, groupID string) (*Group, error)
+	List(ctx context.Context, filter GroupFilter) ([]*Group, int64, error)
+	// Delete removes a group and its memberships
+	Delete(ctx context.Context, groupID string) error
+}
+
 // SessionRepository defines the interface for session persistence
 type SessionRepository interface {
 	Create(ctx context.Context, session *Session) error
 	FindByID(ctx context.Context, sessionID string) (*Session, error)
 	FindActiveByID(ctx context.Context, sessionID string) (*Session, error)
+	// FindActiveByUser lists a user's active sessions, most recently used first
+	FindActiveByUser(ctx context.Context, userID string) ([]*Session, error)
 	UpdateActivity(ctx context.Context, sessionID string) error
+	// UpdateTokens stores a session's refreshed OAuth tokens
+	UpdateTokens(ctx context.Context, session *Session) error
 	Invalidate(ctx context.Context, sessionID string) error
+	// InvalidateForUser ends one of a user's sessions by its ID, returning
+	// ErrSessionNotFound if the user has no such active session
+	InvalidateForUser(ctx context.Context, userID string, id uint) error
 	InvalidateAllForUser(ctx context.Context, userID string) error
-	CleanupExpired(ctx context.Context) error
+	// CleanupExpired removes expired and ended sessions
+	CleanupExpired(ctx context.Context) (int64, error)
 }
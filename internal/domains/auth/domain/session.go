@@ -1,23 +1,33 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrSessionNotFound is returned when a session does not exist, has ended or
+// belongs to another user
+var ErrSessionNotFound = errors.New("session not found")
+
 // Session represents an authenticated user session
 type Session struct {
+	// ID identifies the session to its user without revealing SessionID,
+	// which authenticates requests
+	ID           uint
 	SessionID    string
 	UserID       string
 	User         *User
 	AccessToken  string
 	RefreshToken string
 	IDToken      string
-	ExpiresAt    time.Time
-	IsActive     bool
-	IPAddress    string
-	UserAgent    string
-	LastActivity time.Time
-	CreatedAt    time.Time
+	// TokenExpiresAt is when the OAuth access token expires, or zero if unknown
+	TokenExpiresAt time.Time
+	ExpiresAt      time.Time
+	IsActive       bool
+	IPAddress      string
+	UserAgent      string
+	LastActivity   time.Time
+	CreatedAt      time.Time
 }
 
 // IsExpired checks if the session has expired
@@ -30,6 +40,24 @@ func (s *Session) IsValid() bool {
 	return s.IsActive && !s.IsExpired()
 }
 
+// IsIdle checks if the session has been unused for longer than the timeout.
+// A zero timeout never idles sessions out.
+func (s *Session) IsIdle(timeout time.Duration) bool {
+	return timeout > 0 && time.Since(s.LastActivity) > timeout
+}
+
+// NeedsTokenRefresh checks if the access token expires within the given
+// window and can be refreshed
+func (s *Session) NeedsTokenRefresh(within time.Duration) bool {
+	return s.RefreshToken != "" && !s.TokenExpiresAt.IsZero() &&
+		time.Now().Add(within).After(s.TokenExpiresAt)
+}
+
+// IsTokenExpired checks if the access token has expired
+func (s *Session) IsTokenExpired() bool {
+	return !s.TokenExpiresAt.IsZero() && time.Now().After(s.TokenExpiresAt)
+}
+
 // UpdateActivity updates the last activity timestamp
 func (s *Session) UpdateActivity() {
 	s.LastActivity = time.Now()
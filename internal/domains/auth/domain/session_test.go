@@ -154,6 +154,37 @@ var _ = Describe("Session", Label("unit", "domain", "auth"), func() {
 		})
 	})
 
+	Describe("IsIdle", func() {
+		It("should idle out sessions unused for longer than the timeout", func() {
+			session := &domain.Session{LastActivity: time.Now().Add(-2 * time.Hour)}
+			Expect(session.IsIdle(time.Hour)).To(BeTrue())
+			Expect(session.IsIdle(3 * time.Hour)).To(BeFalse())
+		})
+
+		It("should never idle out sessions without a timeout", func() {
+			session := &domain.Session{LastActivity: time.Now().Add(-48 * time.Hour)}
+			Expect(session.IsIdle(0)).To(BeFalse())
+		})
+	})
+
+	Describe("NeedsTokenRefresh", func() {
+		It("should refresh access tokens about to expire", func() {
+			session := &domain.Session{RefreshToken: "refresh", TokenExpiresAt: time.Now().Add(30 * time.Second)}
+			Expect(session.NeedsTokenRefresh(time.Minute)).To(BeTrue())
+			Expect(session.IsTokenExpired()).To(BeFalse())
+		})
+
+		It("should not refresh access tokens that remain valid", func() {
+			session := &domain.Session{RefreshToken: "refresh", TokenExpiresAt: time.Now().Add(time.Hour)}
+			Expect(session.NeedsTokenRefresh(time.Minute)).To(BeFalse())
+		})
+
+		It("should not refresh without a refresh token or a known expiry", func() {
+			Expect((&domain.Session{TokenExpiresAt: time.Now().Add(-time.Minute)}).NeedsTokenRefresh(time.Minute)).To(BeFalse())
+			Expect((&domain.Session{RefreshToken: "refresh"}).NeedsTokenRefresh(time.Minute)).To(BeFalse())
+		})
+	})
+
 	Describe("Session Extension", func() {
 		It("should be able to extend session expiration", func() {
 			session := &domain.Session{
@@ -1,9 +1,24 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
+var (
+	// ErrUserNotFound is returned when a user does not exist or was deleted
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrInvalidRole is returned for a role other than admin or user
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrInvalidStatus is returned for a status other than active, inactive or suspended
+	ErrInvalidStatus = errors.New("invalid status")
+
+	// ErrUserExists is returned when a user name or email is already taken
+	ErrUserExists = errors.New("user already exists")
+)
+
 // User represents a user in the auth domain
 type User struct {
 	UserID        string
@@ -19,6 +34,18 @@ type User struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 
+	// UserName and ExternalID identify users provisioned by the identity
+	// provider through SCIM. Users without a user name are known by their email.
+	UserName   string
+	ExternalID string
+
+	// UpdatedBy is the admin who last changed the user's role or status
+	UpdatedBy string
+
+	// RoleAssignedBy is the admin who assigned the user's role. While set, the
+	// role is kept at sign-in instead of being derived from the user's groups.
+	RoleAssignedBy string
+
 	// Relationships
 	Groups []UserGroup
 	Scopes []UserScope
@@ -32,6 +59,11 @@ const (
 	RoleUser  UserRole = "user"
 )
 
+// IsValid checks if the role is a known role
+func (r UserRole) IsValid() bool {
+	return r == RoleAdmin || r == RoleUser
+}
+
 // UserStatus represents the status of a user account
 type UserStatus string
 
@@ -41,6 +73,25 @@ const (
 	StatusSuspended UserStatus = "suspended"
 )
 
+// IsValid checks if the status is a known status
+func (s UserStatus) IsValid() bool {
+	return s == StatusActive || s == StatusInactive || s == StatusSuspended
+}
+
+// UserFilter selects users for listing. Search matches the user ID, email
+// and name; empty fields match every user.
+type UserFilter struct {
+	Search string
+	Role   UserRole
+	Status UserStatus
+	// UserName matches user names, or the email of users without one, ignoring case
+	UserName   string
+	ExternalID string
+	// A negative limit lists every matching user
+	Limit  int
+	Offset int
+}
+
 // UserGroup represents a user's group membership
 type UserGroup struct {
 	UserID    string
@@ -0,0 +1,253 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormGroupRepository implements GroupRepository using GORM. Members are
+// stored as the user groups of the group's name.
+type GormGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGroupRepository creates a new GORM-based group repository
+func NewGormGroupRepository(db *gorm.DB) *GormGroupRepository {
+	return &GormGroupRepository{db: db}
+}
+
+// Create creates a new group with its members
+func (r *GormGroupRepository) Create(ctx context.Context, group *domain.Group) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkGroupNameFree(tx, group.DisplayName, ""); err != nil {
+			return err
+		}
+
+		dbGroup := &database.Group{
+			GroupID:     group.GroupID,
+			DisplayName: group.DisplayName,
+			ExternalID:  group.ExternalID,
+		}
+		if err := tx.Create(dbGroup).Error; err != nil {
+			return fmt.Errorf("failed to create group: %w", err)
+		}
+		group.CreatedAt = dbGroup.CreatedAt
+		group.UpdatedAt = dbGroup.UpdatedAt
+
+		return setGroupMembers(tx, group.DisplayName, group.Members)
+	})
+}
+
+// Update renames a group, moving its members and project permissions to the
+// new name, and sets its external ID and members
+func (r *GormGroupRepository) Update(ctx context.Context, group *domain.Group) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing database.Group
+		if err := tx.Where("group_id = ?", group.GroupID).First(&existing).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrGroupNotFound
+			}
+			return fmt.Errorf("failed to find group: %w", err)
+		}
+
+		if group.DisplayName != existing.DisplayName {
+			if err := checkGroupNameFree(tx, group.DisplayName, group.GroupID); err != nil {
+				return err
+			}
+			if err := tx.Model(&database.UserGroup{}).Where("group_name = ?", existing.DisplayName).
+				Update("group_name", group.DisplayName).Error; err != nil {
+				return fmt.Errorf("failed to rename group memberships: %w", err)
+			}
+			// Project permissions name groups without the leading slash some
+			// identity providers add
+			if err := tx.Table("project_group_permissions").
+				Where("group_name = ?", strings.TrimPrefix(existing.DisplayName, "/")).
+				Update("group_name", strings.TrimPrefix(group.DisplayName, "/")).Error; err != nil {
+				return fmt.Errorf("failed to rename group project permissions: %w", err)
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"display_name": group.DisplayName,
+			"external_id":  group.ExternalID,
+			"updated_at":   now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update group: %w", err)
+		}
+		group.CreatedAt = existing.CreatedAt
+		group.UpdatedAt = now
+
+		return setGroupMembers(tx, group.DisplayName, group.Members)
+	})
+}
+
+// FindByID finds a group by ID
+func (r *GormGroupRepository) FindByID(ctx context.Context, groupID string) (*domain.Group, error) {
+	var dbGroup database.Group
+	if err := r.db.WithContext(ctx).Where("group_id = ?", groupID).First(&dbGroup).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+
+	groups, err := r.withMembers(ctx, []database.Group{dbGroup})
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+// List lists the groups matching the filter, ordered by name, with the total number of matches
+func (r *GormGroupRepository) List(ctx context.Context, filter domain.GroupFilter) ([]*domain.Group, int64, error) {
+	query := r.db.WithContext(ctx).Model(&database.Group{})
+	if filter.DisplayName != "" {
+		query = query.Where("LOWER(display_name) = ?", strings.ToLower(filter.DisplayName))
+	}
+	if filter.ExternalID != "" {
+		query = query.Where("external_id = ?", filter.ExternalID)
+	}
+	if filter.Member != "" {
+		members := r.db.Model(&database.UserGroup{}).Select("group_name").Where("user_id = ?", filter.Member)
+		query = query.Where("display_name IN (?)", members)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count groups: %w", err)
+	}
+
+	var dbGroups []database.Group
+	if err := query.Order("display_name").Limit(filter.Limit).Offset(filter.Offset).
+		Find(&dbGroups).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	groups, err := r.withMembers(ctx, dbGroups)
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// Delete removes a group and its memberships
+func (r *GormGroupRepository) Delete(ctx context.Context, groupID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var dbGroup database.Group
+		if err := tx.Where("group_id = ?", groupID).First(&dbGroup).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrGroupNotFound
+			}
+			return fmt.Errorf("failed to find group: %w", err)
+		}
+
+		// Deleted groups free their name for a new group
+		if err := tx.Unscoped().Delete(&dbGroup).Error; err != nil {
+			return fmt.Errorf("failed to delete group: %w", err)
+		}
+		if err := tx.Where("group_name = ?", dbGroup.DisplayName).Delete(&database.UserGroup{}).Error; err != nil {
+			return fmt.Errorf("failed to remove group members: %w", err)
+		}
+		return nil
+	})
+}
+
+// withMembers converts groups to domain groups with their members
+func (r *GormGroupRepository) withMembers(ctx context.Context, dbGroups []database.Group) ([]*domain.Group, error) {
+	groups := make([]*domain.Group, len(dbGroups))
+	if len(dbGroups) == 0 {
+		return groups, nil
+	}
+
+	names := make([]string, len(dbGroups))
+	for i, dbGroup := range dbGroups {
+		names[i] = dbGroup.DisplayName
+	}
+	var memberships []database.UserGroup
+	if err := r.db.WithContext(ctx).Where("group_name IN ?", names).Order("user_id").
+		Find(&memberships).Error; err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	members := make(map[string][]string, len(dbGroups))
+	for _, membership := range memberships {
+		members[membership.GroupName] = append(members[membership.GroupName], membership.UserID)
+	}
+
+	for i, dbGroup := range dbGroups {
+		groups[i] = &domain.Group{
+			GroupID:     dbGroup.GroupID,
+			DisplayName: dbGroup.DisplayName,
+			ExternalID:  dbGroup.ExternalID,
+			Members:     members[dbGroup.DisplayName],
+			CreatedAt:   dbGroup.CreatedAt,
+			UpdatedAt:   dbGroup.UpdatedAt,
+		}
+	}
+	return groups, nil
+}
+
+// checkGroupNameFree returns ErrGroupExists if another group has the name,
+// ignoring case
+func checkGroupNameFree(tx *gorm.DB, name, groupID string) error {
+	var count int64
+	if err := tx.Model(&database.Group{}).
+		Where("LOWER(display_name) = ? AND group_id <> ?", strings.ToLower(name), groupID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrGroupExists, name)
+	}
+	return nil
+}
+
+// setGroupMembers makes the users the only members of the group
+func setGroupMembers(tx *gorm.DB, groupName string, userIDs []string) error {
+	var current []string
+	if err := tx.Model(&database.UserGroup{}).Where("group_name = ?", groupName).
+		Pluck("user_id", &current).Error; err != nil {
+		return fmt.Errorf("failed to get group members: %w", err)
+	}
+
+	var removed []string
+	for _, userID := range current {
+		if !containsString(userIDs, userID) {
+			removed = append(removed, userID)
+		}
+	}
+	if len(removed) > 0 {
+		if err := tx.Where("group_name = ? AND user_id IN ?", groupName, removed).
+			Delete(&database.UserGroup{}).Error; err != nil {
+			return fmt.Errorf("failed to remove group members: %w", err)
+		}
+	}
+
+	for _, userID := range userIDs {
+		if containsString(current, userID) {
+			continue
+		}
+		current = append(current, userID)
+		if err := tx.Create(&database.UserGroup{UserID: userID, GroupName: groupName}).Error; err != nil {
+			return fmt.Errorf("failed to add group member: %w", err)
+		}
+	}
+	return nil
+}
+
+// containsString checks if a value is in a list
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
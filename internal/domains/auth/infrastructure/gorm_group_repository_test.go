@@ -0,0 +1,165 @@
+package infrastructure_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+)
+
+var _ = Describe("GormGroupRepository", Label("integration", "infrastructure", "auth"), func() {
+	var (
+		db       *gorm.DB
+		repo     *infrastructure.GormGroupRepository
+		userRepo *infrastructure.GormUserRepository
+		ctx      context.Context
+	)
+
+	groupNames := func(userID string) []string {
+		groups, err := userRepo.GetUserGroups(ctx, userID)
+		Expect(err).NotTo(HaveOccurred())
+		names := make([]string, len(groups))
+		for i, group := range groups {
+			names[i] = group.GroupName
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.User{}, &database.UserGroup{}, &database.Group{},
+			&projectsInfra.ProjectGroupPermissionDB{},
+		)).To(Succeed())
+
+		repo = infrastructure.NewGormGroupRepository(db)
+		userRepo = infrastructure.NewGormUserRepository(db)
+		ctx = context.Background()
+
+		for _, userID := range []string{"alice", "bob"} {
+			Expect(userRepo.Create(ctx, &domain.User{
+				UserID: userID,
+				Email:  userID + "@example.com",
+				Role:   domain.RoleUser,
+				Status: domain.StatusActive,
+			})).To(Succeed())
+		}
+		Expect(repo.Create(ctx, &domain.Group{
+			GroupID:     "g1",
+			DisplayName: "shop-users",
+			ExternalID:  "okta-1",
+			Members:     []string{"alice", "bob"},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	It("should create groups with their members as user groups", func() {
+		group, err := repo.FindByID(ctx, "g1")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.DisplayName).To(Equal("shop-users"))
+		Expect(group.ExternalID).To(Equal("okta-1"))
+		Expect(group.Members).To(Equal([]string{"alice", "bob"}))
+		Expect(groupNames("alice")).To(ConsistOf("shop-users"))
+	})
+
+	It("should not create groups with a taken name", func() {
+		err := repo.Create(ctx, &domain.Group{GroupID: "g2", DisplayName: "Shop-Users"})
+
+		Expect(err).To(MatchError(domain.ErrGroupExists))
+	})
+
+	It("should report groups that do not exist", func() {
+		_, err := repo.FindByID(ctx, "nope")
+		Expect(err).To(MatchError(domain.ErrGroupNotFound))
+
+		err = repo.Update(ctx, &domain.Group{GroupID: "nope", DisplayName: "x"})
+		Expect(err).To(MatchError(domain.ErrGroupNotFound))
+
+		Expect(repo.Delete(ctx, "nope")).To(MatchError(domain.ErrGroupNotFound))
+	})
+
+	It("should rename groups with their members and project permissions", func() {
+		Expect(db.Create(&projectsInfra.ProjectGroupPermissionDB{
+			ProjectID: "shop", GroupName: "shop-users", Permission: "read", GrantedBy: "admin", GrantedAt: time.Now(),
+		}).Error).To(Succeed())
+
+		Expect(repo.Update(ctx, &domain.Group{
+			GroupID:     "g1",
+			DisplayName: "store-users",
+			Members:     []string{"bob"},
+		})).To(Succeed())
+
+		group, err := repo.FindByID(ctx, "g1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.DisplayName).To(Equal("store-users"))
+		Expect(group.ExternalID).To(BeEmpty())
+		Expect(group.Members).To(Equal([]string{"bob"}))
+		Expect(groupNames("alice")).To(BeEmpty())
+		Expect(groupNames("bob")).To(ConsistOf("store-users"))
+
+		var permission projectsInfra.ProjectGroupPermissionDB
+		Expect(db.First(&permission).Error).To(Succeed())
+		Expect(permission.GroupName).To(Equal("store-users"))
+	})
+
+	It("should not rename groups to a taken name", func() {
+		Expect(repo.Create(ctx, &domain.Group{GroupID: "g2", DisplayName: "billing"})).To(Succeed())
+
+		err := repo.Update(ctx, &domain.Group{GroupID: "g2", DisplayName: "shop-users"})
+
+		Expect(err).To(MatchError(domain.ErrGroupExists))
+	})
+
+	It("should list groups by name, external ID and member", func() {
+		Expect(repo.Create(ctx, &domain.Group{GroupID: "g2", DisplayName: "billing", Members: []string{"bob"}})).To(Succeed())
+
+		groups, total, err := repo.List(ctx, domain.GroupFilter{Limit: -1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(total).To(Equal(int64(2)))
+		Expect(groups[0].DisplayName).To(Equal("billing"))
+
+		groups, _, err = repo.List(ctx, domain.GroupFilter{DisplayName: "SHOP-USERS", Limit: -1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].GroupID).To(Equal("g1"))
+
+		groups, _, err = repo.List(ctx, domain.GroupFilter{ExternalID: "okta-1", Limit: -1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+
+		_, total, err = repo.List(ctx, domain.GroupFilter{Member: "bob", Limit: -1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(total).To(Equal(int64(2)))
+		groups, _, err = repo.List(ctx, domain.GroupFilter{Member: "alice", Limit: -1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].GroupID).To(Equal("g1"))
+	})
+
+	It("should delete groups with their memberships and free their name", func() {
+		Expect(repo.Delete(ctx, "g1")).To(Succeed())
+
+		_, err := repo.FindByID(ctx, "g1")
+		Expect(err).To(MatchError(domain.ErrGroupNotFound))
+		Expect(groupNames("alice")).To(BeEmpty())
+		Expect(repo.Create(ctx, &domain.Group{GroupID: "g2", DisplayName: "shop-users"})).To(Succeed())
+	})
+})
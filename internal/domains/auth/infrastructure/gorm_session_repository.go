@@ -23,22 +23,25 @@ func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
 // Create creates a new session
 func (r *GormSessionRepository) Create(ctx context.Context, session *domain.Session) error {
 	dbSession := &database.UserSession{
-		UserID:       session.UserID,
-		SessionID:    session.SessionID,
-		AccessToken:  session.AccessToken,
-		RefreshToken: session.RefreshToken,
-		IDToken:      session.IDToken,
-		ExpiresAt:    session.ExpiresAt,
-		IsActive:     session.IsActive,
-		IPAddress:    session.IPAddress,
-		UserAgent:    session.UserAgent,
-		LastActivity: session.LastActivity,
+		UserID:         session.UserID,
+		SessionID:      session.SessionID,
+		AccessToken:    session.AccessToken,
+		RefreshToken:   session.RefreshToken,
+		IDToken:        session.IDToken,
+		TokenExpiresAt: timePtr(session.TokenExpiresAt),
+		ExpiresAt:      session.ExpiresAt,
+		IsActive:       session.IsActive,
+		IPAddress:      session.IPAddress,
+		UserAgent:      session.UserAgent,
+		LastActivity:   session.LastActivity,
 	}
 
 	if err := r.db.WithContext(ctx).Create(dbSession).Error; err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
+	session.ID = dbSession.ID
+	session.CreatedAt = dbSession.CreatedAt
 	return nil
 }
 
@@ -69,6 +72,23 @@ func (r *GormSessionRepository) FindActiveByID(ctx context.Context, sessionID st
 	return r.toDomainSession(&dbSession), nil
 }
 
+// FindActiveByUser lists a user's active sessions, most recently used first
+func (r *GormSessionRepository) FindActiveByUser(ctx context.Context, userID string) ([]*domain.Session, error) {
+	var dbSessions []database.UserSession
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ? AND expires_at > ?", userID, true, time.Now()).
+		Order("last_activity DESC").
+		Find(&dbSessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*domain.Session, len(dbSessions))
+	for i := range dbSessions {
+		sessions[i] = r.toDomainSession(&dbSessions[i])
+	}
+	return sessions, nil
+}
+
 // UpdateActivity updates the session's last activity time
 func (r *GormSessionRepository) UpdateActivity(ctx context.Context, sessionID string) error {
 	result := r.db.WithContext(ctx).Model(&database.UserSession{}).
@@ -86,6 +106,28 @@ func (r *GormSessionRepository) UpdateActivity(ctx context.Context, sessionID st
 	return nil
 }
 
+// UpdateTokens stores a session's refreshed OAuth tokens
+func (r *GormSessionRepository) UpdateTokens(ctx context.Context, session *domain.Session) error {
+	result := r.db.WithContext(ctx).Model(&database.UserSession{}).
+		Where("session_id = ?", session.SessionID).
+		Updates(map[string]interface{}{
+			"access_token":     session.AccessToken,
+			"refresh_token":    session.RefreshToken,
+			"id_token":         session.IDToken,
+			"token_expires_at": timePtr(session.TokenExpiresAt),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update session tokens: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
 // Invalidate marks a session as inactive
 func (r *GormSessionRepository) Invalidate(ctx context.Context, sessionID string) error {
 	result := r.db.WithContext(ctx).Model(&database.UserSession{}).
@@ -103,6 +145,23 @@ func (r *GormSessionRepository) Invalidate(ctx context.Context, sessionID string
 	return nil
 }
 
+// InvalidateForUser invalidates one of a user's active sessions by its ID
+func (r *GormSessionRepository) InvalidateForUser(ctx context.Context, userID string, id uint) error {
+	result := r.db.WithContext(ctx).Model(&database.UserSession{}).
+		Where("id = ? AND user_id = ? AND is_active = ?", id, userID, true).
+		Update("is_active", false)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to invalidate session: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
 // InvalidateAllForUser invalidates all sessions for a user
 func (r *GormSessionRepository) InvalidateAllForUser(ctx context.Context, userID string) error {
 	return r.db.WithContext(ctx).Model(&database.UserSession{}).
@@ -110,16 +169,22 @@ func (r *GormSessionRepository) InvalidateAllForUser(ctx context.Context, userID
 		Update("is_active", false).Error
 }
 
-// CleanupExpired removes expired sessions
-func (r *GormSessionRepository) CleanupExpired(ctx context.Context) error {
-	return r.db.WithContext(ctx).
-		Where("expires_at < ?", time.Now()).
-		Delete(&database.UserSession{}).Error
+// CleanupExpired permanently removes expired and ended sessions, so their
+// tokens are not kept
+func (r *GormSessionRepository) CleanupExpired(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("expires_at < ? OR is_active = ?", time.Now(), false).
+		Delete(&database.UserSession{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clean up sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
 }
 
 // Helper method to convert database session to domain session
 func (r *GormSessionRepository) toDomainSession(dbSession *database.UserSession) *domain.Session {
-	return &domain.Session{
+	session := &domain.Session{
+		ID:           dbSession.ID,
 		SessionID:    dbSession.SessionID,
 		UserID:       dbSession.UserID,
 		AccessToken:  dbSession.AccessToken,
@@ -132,4 +197,16 @@ func (r *GormSessionRepository) toDomainSession(dbSession *database.UserSession)
 		LastActivity: dbSession.LastActivity,
 		CreatedAt:    dbSession.CreatedAt,
 	}
+	if dbSession.TokenExpiresAt != nil {
+		session.TokenExpiresAt = *dbSession.TokenExpiresAt
+	}
+	return session
+}
+
+// timePtr stores a zero time as NULL
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
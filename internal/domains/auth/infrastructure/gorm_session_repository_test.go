@@ -0,0 +1,122 @@
+package infrastructure_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+)
+
+var _ = Describe("GormSessionRepository", Label("integration", "infrastructure", "auth"), func() {
+	var (
+		db   *gorm.DB
+		repo *infrastructure.GormSessionRepository
+		ctx  context.Context
+	)
+
+	createSession := func(sessionID, userID string, lastActivity time.Time, expiresAt time.Time) *domain.Session {
+		session := &domain.Session{
+			SessionID:    sessionID,
+			UserID:       userID,
+			AccessToken:  "access-" + sessionID,
+			RefreshToken: "refresh-" + sessionID,
+			ExpiresAt:    expiresAt,
+			IsActive:     true,
+			LastActivity: lastActivity,
+		}
+		Expect(repo.Create(ctx, session)).To(Succeed())
+		return session
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&database.UserSession{})).To(Succeed())
+
+		repo = infrastructure.NewGormSessionRepository(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	Describe("FindActiveByUser", func() {
+		It("should list active sessions, most recently used first", func() {
+			now := time.Now()
+			older := createSession("older", "alice", now.Add(-time.Hour), now.Add(time.Hour))
+			newer := createSession("newer", "alice", now, now.Add(time.Hour))
+			createSession("expired", "alice", now, now.Add(-time.Minute))
+			createSession("ended", "alice", now, now.Add(time.Hour))
+			Expect(repo.Invalidate(ctx, "ended")).To(Succeed())
+			createSession("other", "bob", now, now.Add(time.Hour))
+
+			sessions, err := repo.FindActiveByUser(ctx, "alice")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions[0].ID).To(Equal(newer.ID))
+			Expect(sessions[1].ID).To(Equal(older.ID))
+		})
+	})
+
+	Describe("InvalidateForUser", func() {
+		It("should only end sessions belonging to the user", func() {
+			session := createSession("mine", "alice", time.Now(), time.Now().Add(time.Hour))
+
+			Expect(repo.InvalidateForUser(ctx, "bob", session.ID)).To(MatchError(domain.ErrSessionNotFound))
+			Expect(repo.InvalidateForUser(ctx, "alice", session.ID)).To(Succeed())
+			Expect(repo.InvalidateForUser(ctx, "alice", session.ID)).To(MatchError(domain.ErrSessionNotFound))
+
+			_, err := repo.FindActiveByID(ctx, "mine")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateTokens", func() {
+		It("should store refreshed tokens and their expiry", func() {
+			session := createSession("refreshed", "alice", time.Now(), time.Now().Add(time.Hour))
+			session.AccessToken = "new-access"
+			session.RefreshToken = "new-refresh"
+			session.TokenExpiresAt = time.Now().Add(time.Hour)
+
+			Expect(repo.UpdateTokens(ctx, session)).To(Succeed())
+
+			stored, err := repo.FindByID(ctx, "refreshed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.AccessToken).To(Equal("new-access"))
+			Expect(stored.RefreshToken).To(Equal("new-refresh"))
+			Expect(stored.TokenExpiresAt).To(BeTemporally("~", session.TokenExpiresAt, time.Second))
+		})
+	})
+
+	Describe("CleanupExpired", func() {
+		It("should permanently remove expired and ended sessions", func() {
+			now := time.Now()
+			createSession("active", "alice", now, now.Add(time.Hour))
+			createSession("expired", "alice", now, now.Add(-time.Minute))
+			createSession("ended", "alice", now, now.Add(time.Hour))
+			Expect(repo.Invalidate(ctx, "ended")).To(Succeed())
+
+			removed, err := repo.CleanupExpired(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(int64(2)))
+			var remaining int64
+			Expect(db.Unscoped().Model(&database.UserSession{}).Count(&remaining).Error).To(Succeed())
+			Expect(remaining).To(Equal(int64(1)))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
@@ -33,6 +34,8 @@ func (r *GormUserRepository) Create(ctx context.Context, user *domain.User) erro
 		ProfileURL:    user.ProfileURL,
 		EmailVerified: user.EmailVerified,
 		LastLoginAt:   user.LastLoginAt,
+		UserName:      user.UserName,
+		ExternalID:    user.ExternalID,
 	}
 
 	if err := r.db.WithContext(ctx).Create(dbUser).Error; err != nil {
@@ -45,15 +48,19 @@ func (r *GormUserRepository) Create(ctx context.Context, user *domain.User) erro
 // Update updates an existing user
 func (r *GormUserRepository) Update(ctx context.Context, user *domain.User) error {
 	updates := map[string]interface{}{
-		"email":          user.Email,
-		"name":           user.Name,
-		"first_name":     user.FirstName,
-		"last_name":      user.LastName,
-		"role":           string(user.Role),
-		"status":         string(user.Status),
-		"profile_url":    user.ProfileURL,
-		"email_verified": user.EmailVerified,
-		"updated_at":     time.Now(),
+		"email":            user.Email,
+		"name":             user.Name,
+		"first_name":       user.FirstName,
+		"last_name":        user.LastName,
+		"role":             string(user.Role),
+		"status":           string(user.Status),
+		"profile_url":      user.ProfileURL,
+		"email_verified":   user.EmailVerified,
+		"updated_by":       user.UpdatedBy,
+		"role_assigned_by": user.RoleAssignedBy,
+		"user_name":        user.UserName,
+		"external_id":      user.ExternalID,
+		"updated_at":       time.Now(),
 	}
 
 	result := r.db.WithContext(ctx).Model(&database.User{}).Where("user_id = ?", user.UserID).Updates(updates)
@@ -62,7 +69,7 @@ func (r *GormUserRepository) Update(ctx context.Context, user *domain.User) erro
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
@@ -73,7 +80,7 @@ func (r *GormUserRepository) FindByID(ctx context.Context, userID string) (*doma
 	var dbUser database.User
 	if err := r.db.WithContext(ctx).Preload("UserGroups").Preload("UserScopes").Where("user_id = ?", userID).First(&dbUser).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -86,7 +93,7 @@ func (r *GormUserRepository) FindByEmail(ctx context.Context, email string) (*do
 	var dbUser database.User
 	if err := r.db.WithContext(ctx).Preload("UserGroups").Preload("UserScopes").Where("email = ?", email).First(&dbUser).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -99,7 +106,7 @@ func (r *GormUserRepository) FindByIDOrEmail(ctx context.Context, userID, email
 	var dbUser database.User
 	if err := r.db.WithContext(ctx).Preload("UserGroups").Preload("UserScopes").Where("user_id = ? OR email = ?", userID, email).First(&dbUser).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -112,30 +119,135 @@ func (r *GormUserRepository) UpdateLastLogin(ctx context.Context, userID string,
 	return r.db.WithContext(ctx).Model(&database.User{}).Where("user_id = ?", userID).Update("last_login_at", loginTime).Error
 }
 
-// SetUserGroups sets the user's group memberships
-func (r *GormUserRepository) SetUserGroups(ctx context.Context, userID string, groups []string) error {
-	// Start transaction
-	tx := r.db.WithContext(ctx).Begin()
+// List lists the users matching the filter, ordered by email, with the total number of matches
+func (r *GormUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&database.User{})
+	if filter.Search != "" {
+		pattern := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where("LOWER(user_id) LIKE ? OR LOWER(email) LIKE ? OR LOWER(name) LIKE ?", pattern, pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", string(filter.Role))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.UserName != "" {
+		userName := strings.ToLower(filter.UserName)
+		query = query.Where("LOWER(user_name) = ? OR (COALESCE(user_name, '') = '' AND LOWER(email) = ?)", userName, userName)
+	}
+	if filter.ExternalID != "" {
+		query = query.Where("external_id = ?", filter.ExternalID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
 
-	// Delete existing groups
-	if err := tx.Where("user_id = ?", userID).Delete(&database.UserGroup{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete existing groups: %w", err)
+	var dbUsers []database.User
+	if err := query.Preload("UserGroups").Preload("UserScopes").
+		Order("email").Limit(filter.Limit).Offset(filter.Offset).
+		Find(&dbUsers).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	// Add new groups
-	for _, group := range groups {
-		userGroup := &database.UserGroup{
-			UserID:    userID,
-			GroupName: group,
+	users := make([]*domain.User, len(dbUsers))
+	for i := range dbUsers {
+		users[i] = r.toDomainUser(&dbUsers[i])
+	}
+	return users, total, nil
+}
+
+// ownedResources are the resources a user creates, by the column naming their creator
+var ownedResources = []struct {
+	model  interface{}
+	column string
+}{
+	{&database.JiraIssueLink{}, "created_by"},
+	{&database.JiraFieldMapping{}, "updated_by"},
+	{&database.WebhookSubscription{}, "created_by"},
+	{&database.NotificationRule{}, "created_by"},
+	{&database.VCSConnection{}, "created_by"},
+}
+
+// Delete soft-deletes a user and reassigns the resources they created
+func (r *GormUserRepository) Delete(ctx context.Context, userID, deletedBy, reassignTo string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&database.User{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"deleted_by": deletedBy, "deleted_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
 		}
-		if err := tx.Create(userGroup).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to create user group: %w", err)
+
+		for _, model := range []interface{}{&database.UserGroup{}, &database.UserScope{}, &database.ProjectAccess{}, &database.ProjectPermission{}} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return fmt.Errorf("failed to remove user access: %w", err)
+			}
 		}
+
+		for _, owned := range ownedResources {
+			if err := tx.Model(owned.model).Where(owned.column+" = ?", userID).
+				UpdateColumn(owned.column, reassignTo).Error; err != nil {
+				return fmt.Errorf("failed to reassign user resources: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// IsDeleted checks if a user with the ID, or with the email if given, was deleted
+func (r *GormUserRepository) IsDeleted(ctx context.Context, userID, email string) (bool, error) {
+	query := r.db.WithContext(ctx).Unscoped().Model(&database.User{}).Where("deleted_at IS NOT NULL")
+	if email != "" {
+		query = query.Where("user_id = ? OR email = ?", userID, email)
+	} else {
+		query = query.Where("user_id = ?", userID)
 	}
 
-	return tx.Commit().Error
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to find user: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SetUserGroups sets the user's memberships of groups that are not
+// provisioned; members of provisioned groups are managed through SCIM
+func (r *GormUserRepository) SetUserGroups(ctx context.Context, userID string, groups []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var provisioned []string
+		if err := tx.Model(&database.Group{}).Pluck("display_name", &provisioned).Error; err != nil {
+			return fmt.Errorf("failed to find provisioned groups: %w", err)
+		}
+
+		// Delete existing groups
+		query := tx.Where("user_id = ?", userID)
+		if len(provisioned) > 0 {
+			query = query.Where("group_name NOT IN ?", provisioned)
+		}
+		if err := query.Delete(&database.UserGroup{}).Error; err != nil {
+			return fmt.Errorf("failed to delete existing groups: %w", err)
+		}
+
+		// Add new groups
+		for _, group := range groups {
+			if containsString(provisioned, group) {
+				continue
+			}
+			userGroup := &database.UserGroup{
+				UserID:    userID,
+				GroupName: group,
+			}
+			if err := tx.Create(userGroup).Error; err != nil {
+				return fmt.Errorf("failed to create user group: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 // GetUserGroups gets the user's group memberships
@@ -198,20 +310,24 @@ func (r *GormUserRepository) GetUserScopes(ctx context.Context, userID string) (
 // Helper method to convert database user to domain user
 func (r *GormUserRepository) toDomainUser(dbUser *database.User) *domain.User {
 	user := &domain.User{
-		UserID:        dbUser.UserID,
-		Email:         dbUser.Email,
-		Name:          dbUser.Name,
-		FirstName:     dbUser.FirstName,
-		LastName:      dbUser.LastName,
-		Role:          domain.UserRole(dbUser.Role),
-		Status:        domain.UserStatus(dbUser.Status),
-		ProfileURL:    dbUser.ProfileURL,
-		EmailVerified: dbUser.EmailVerified,
-		LastLoginAt:   dbUser.LastLoginAt,
-		CreatedAt:     dbUser.CreatedAt,
-		UpdatedAt:     dbUser.UpdatedAt,
-		Groups:        make([]domain.UserGroup, len(dbUser.UserGroups)),
-		Scopes:        make([]domain.UserScope, len(dbUser.UserScopes)),
+		UserID:         dbUser.UserID,
+		Email:          dbUser.Email,
+		Name:           dbUser.Name,
+		FirstName:      dbUser.FirstName,
+		LastName:       dbUser.LastName,
+		Role:           domain.UserRole(dbUser.Role),
+		Status:         domain.UserStatus(dbUser.Status),
+		ProfileURL:     dbUser.ProfileURL,
+		EmailVerified:  dbUser.EmailVerified,
+		LastLoginAt:    dbUser.LastLoginAt,
+		CreatedAt:      dbUser.CreatedAt,
+		UpdatedAt:      dbUser.UpdatedAt,
+		UpdatedBy:      dbUser.UpdatedBy,
+		RoleAssignedBy: dbUser.RoleAssignedBy,
+		UserName:       dbUser.UserName,
+		ExternalID:     dbUser.ExternalID,
+		Groups:         make([]domain.UserGroup, len(dbUser.UserGroups)),
+		Scopes:         make([]domain.UserScope, len(dbUser.UserScopes)),
 	}
 
 	for i, group := range dbUser.UserGroups {
@@ -0,0 +1,223 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+)
+
+func TestAuthInfrastructure(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Auth Infrastructure Suite")
+}
+
+var _ = Describe("GormUserRepository", Label("integration", "infrastructure", "auth"), func() {
+	var (
+		db   *gorm.DB
+		repo *infrastructure.GormUserRepository
+		ctx  context.Context
+	)
+
+	createUser := func(userID, email, name string, role domain.UserRole, status domain.UserStatus) {
+		Expect(repo.Create(ctx, &domain.User{
+			UserID: userID,
+			Email:  email,
+			Name:   name,
+			Role:   role,
+			Status: status,
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(
+			&database.User{}, &database.UserGroup{}, &database.UserScope{}, &database.Group{},
+			&database.ProjectAccess{}, &database.ProjectPermission{},
+			&database.JiraIssueLink{}, &database.JiraFieldMapping{},
+			&database.WebhookSubscription{}, &database.NotificationRule{}, &database.VCSConnection{},
+		)).To(Succeed())
+
+		repo = infrastructure.NewGormUserRepository(db)
+		ctx = context.Background()
+
+		createUser("alice", "alice@example.com", "Alice Admin", domain.RoleAdmin, domain.StatusActive)
+		createUser("bob", "bob@example.com", "Bob Builder", domain.RoleUser, domain.StatusActive)
+		createUser("carol", "carol@example.com", "Carol", domain.RoleUser, domain.StatusSuspended)
+	})
+
+	AfterEach(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	Describe("List", func() {
+		It("should page through users ordered by email", func() {
+			users, total, err := repo.List(ctx, domain.UserFilter{Limit: 2, Offset: 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(int64(3)))
+			Expect(users).To(HaveLen(2))
+			Expect(users[0].UserID).To(Equal("bob"))
+			Expect(users[1].UserID).To(Equal("carol"))
+		})
+
+		It("should search user IDs, emails and names case-insensitively", func() {
+			users, total, err := repo.List(ctx, domain.UserFilter{Search: "BUILDER", Limit: 10})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(int64(1)))
+			Expect(users[0].UserID).To(Equal("bob"))
+		})
+
+		It("should filter by role and status", func() {
+			users, total, err := repo.List(ctx, domain.UserFilter{Role: domain.RoleUser, Status: domain.StatusSuspended, Limit: 10})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(int64(1)))
+			Expect(users[0].UserID).To(Equal("carol"))
+		})
+
+		It("should find users by user name, or by email when they have none", func() {
+			Expect(repo.Create(ctx, &domain.User{
+				UserID:     "dave",
+				Email:      "dave@example.com",
+				Name:       "Dave",
+				Role:       domain.RoleUser,
+				Status:     domain.StatusActive,
+				UserName:   "DSmith",
+				ExternalID: "00u1",
+			})).To(Succeed())
+
+			users, total, err := repo.List(ctx, domain.UserFilter{UserName: "dsmith", Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(int64(1)))
+			Expect(users[0].UserID).To(Equal("dave"))
+			Expect(users[0].UserName).To(Equal("DSmith"))
+			Expect(users[0].ExternalID).To(Equal("00u1"))
+
+			users, _, err = repo.List(ctx, domain.UserFilter{UserName: "Bob@Example.com", Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(HaveLen(1))
+			Expect(users[0].UserID).To(Equal("bob"))
+
+			_, total, err = repo.List(ctx, domain.UserFilter{UserName: "dave@example.com", Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(BeZero())
+
+			users, _, err = repo.List(ctx, domain.UserFilter{ExternalID: "00u1", Limit: -1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(HaveLen(1))
+			Expect(users[0].UserID).To(Equal("dave"))
+		})
+	})
+
+	Describe("SetUserGroups", func() {
+		It("should keep memberships of provisioned groups", func() {
+			groupRepo := infrastructure.NewGormGroupRepository(db)
+			Expect(groupRepo.Create(ctx, &domain.Group{GroupID: "g1", DisplayName: "shop-admins", Members: []string{"bob"}})).To(Succeed())
+
+			Expect(repo.SetUserGroups(ctx, "bob", []string{"shop-users", "shop-admins"})).To(Succeed())
+			Expect(repo.SetUserGroups(ctx, "bob", []string{"billing-users"})).To(Succeed())
+
+			groups, err := repo.GetUserGroups(ctx, "bob")
+			Expect(err).NotTo(HaveOccurred())
+			names := make([]string, len(groups))
+			for i, group := range groups {
+				names[i] = group.GroupName
+			}
+			Expect(names).To(ConsistOf("billing-users", "shop-admins"))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should record the acting admin", func() {
+			user, err := repo.FindByID(ctx, "bob")
+			Expect(err).NotTo(HaveOccurred())
+
+			user.Role = domain.RoleAdmin
+			user.RoleAssignedBy = "alice"
+			user.UpdatedBy = "alice"
+			Expect(repo.Update(ctx, user)).To(Succeed())
+
+			updated, err := repo.FindByID(ctx, "bob")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Role).To(Equal(domain.RoleAdmin))
+			Expect(updated.RoleAssignedBy).To(Equal("alice"))
+			Expect(updated.UpdatedBy).To(Equal("alice"))
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			Expect(repo.SetUserGroups(ctx, "bob", []string{"shop-users"})).To(Succeed())
+			Expect(repo.GrantScope(ctx, domain.UserScope{UserID: "bob", Scope: "project:read:shop"})).To(Succeed())
+			Expect(db.Create(&database.WebhookSubscription{Name: "Deploys", URL: "https://example.com", Events: "test_run.completed", EncryptedSecret: "x", CreatedBy: "bob"}).Error).To(Succeed())
+			Expect(db.Create(&database.NotificationRule{ProjectID: "shop", Name: "Failures", ChannelType: "slack", EncryptedWebhookURL: "x", Events: "test_run.failed", CreatedBy: "carol"}).Error).To(Succeed())
+		})
+
+		It("should soft-delete the user, remove their access and reassign their resources", func() {
+			Expect(repo.Delete(ctx, "bob", "alice", "carol")).To(Succeed())
+
+			_, err := repo.FindByID(ctx, "bob")
+			Expect(err).To(MatchError(domain.ErrUserNotFound))
+
+			var deleted database.User
+			Expect(db.Unscoped().Where("user_id = ?", "bob").First(&deleted).Error).To(Succeed())
+			Expect(deleted.DeletedAt.Valid).To(BeTrue())
+			Expect(deleted.DeletedBy).To(Equal("alice"))
+
+			groups, err := repo.GetUserGroups(ctx, "bob")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(BeEmpty())
+			scopes, err := repo.GetUserScopes(ctx, "bob")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scopes).To(BeEmpty())
+
+			var webhook database.WebhookSubscription
+			Expect(db.First(&webhook).Error).To(Succeed())
+			Expect(webhook.CreatedBy).To(Equal("carol"))
+			var rule database.NotificationRule
+			Expect(db.First(&rule).Error).To(Succeed())
+			Expect(rule.CreatedBy).To(Equal("carol"))
+
+			_, total, err := repo.List(ctx, domain.UserFilter{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(int64(2)))
+		})
+
+		It("should report users that do not exist or were already deleted", func() {
+			Expect(repo.Delete(ctx, "bob", "alice", "alice")).To(Succeed())
+
+			Expect(repo.Delete(ctx, "bob", "alice", "alice")).To(MatchError(domain.ErrUserNotFound))
+			Expect(repo.Delete(ctx, "nobody", "alice", "alice")).To(MatchError(domain.ErrUserNotFound))
+		})
+
+		It("should tell deleted users from users that never existed", func() {
+			Expect(repo.Delete(ctx, "bob", "alice", "alice")).To(Succeed())
+
+			Expect(repo.IsDeleted(ctx, "bob", "")).To(BeTrue())
+			Expect(repo.IsDeleted(ctx, "carol", "")).To(BeFalse())
+			Expect(repo.IsDeleted(ctx, "nobody", "")).To(BeFalse())
+		})
+
+		It("should find deleted users by their email", func() {
+			Expect(repo.Delete(ctx, "bob", "alice", "alice")).To(Succeed())
+
+			Expect(repo.IsDeleted(ctx, "bob-2", "bob@example.com")).To(BeTrue())
+			Expect(repo.IsDeleted(ctx, "carol-2", "carol@example.com")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+// tokenLeeway allows for clock skew between Fern and the identity provider
+const tokenLeeway = 30 * time.Second
+
+// asymmetricMethods are the signing methods checked against the JWKS
+var asymmetricMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// hmacMethods are the signing methods checked against the shared JWT secret
+var hmacMethods = []string{"HS256", "HS384", "HS512"}
+
+// TokenValidator validates bearer JWTs signed by the identity provider, with
+// keys from its JWKS, or with the shared JWT secret. Tokens must not have
+// expired and must match the configured issuer and audience.
+type TokenValidator struct {
+	config   *config.AuthConfig
+	keys     *JWKSCache
+	issuer   string
+	audience string
+	methods  []string
+}
+
+// NewTokenValidator creates a validator for the JWKS and JWT secret in the
+// auth configuration, preferring auth.jwksUrl and auth.issuer over their OAuth
+// counterparts. The audience defaults to the OAuth client ID. It returns nil
+// when neither a JWKS nor a secret is configured, or there is no audience to
+// check tokens against.
+func NewTokenValidator(cfg *config.AuthConfig) *TokenValidator {
+	v := &TokenValidator{
+		config:   cfg,
+		issuer:   firstNonEmpty(cfg.Issuer, cfg.OAuth.IssuerURL),
+		audience: firstNonEmpty(cfg.Audience, cfg.OAuth.ClientID),
+	}
+	if v.audience == "" {
+		return nil
+	}
+	if jwksURL := firstNonEmpty(cfg.JWKSUrl, cfg.OAuth.JWKSUrl); jwksURL != "" {
+		v.keys = NewJWKSCache(jwksURL)
+		v.methods = append(v.methods, asymmetricMethods...)
+	}
+	if cfg.JWTSecret != "" {
+		v.methods = append(v.methods, hmacMethods...)
+	}
+	if len(v.methods) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Validate checks a bearer token and maps its claims to user information
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*application.UserInfo, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(v.methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenLeeway),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return []byte(v.config.JWTSecret), nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if !v.hasAudience(claims) {
+		return nil, fmt.Errorf("invalid token: not issued for audience %q", v.audience)
+	}
+
+	userInfo := userInfoFromClaims(&v.config.OAuth, claims)
+	if userInfo.Sub == "" {
+		return nil, fmt.Errorf("invalid token: no user ID claim")
+	}
+	return userInfo, nil
+}
+
+// hasAudience checks if a token was issued for Fern, either as one of its
+// audiences or to Fern as the authorized party
+func (v *TokenValidator) hasAudience(claims jwt.MapClaims) bool {
+	audiences, _ := claims.GetAudience()
+	for _, audience := range audiences {
+		if audience == v.audience {
+			return true
+		}
+	}
+	azp, _ := claims["azp"].(string)
+	return azp == v.audience
+}
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
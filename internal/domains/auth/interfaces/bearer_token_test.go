@@ -0,0 +1,339 @@
+package interfaces
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeJWKS is a local stand-in for an identity provider's JWKS endpoint
+type fakeJWKS struct {
+	mu       sync.Mutex
+	keys     []map[string]string
+	requests atomic.Int32
+	fail     atomic.Bool
+}
+
+func (f *fakeJWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+	if f.fail.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": f.keys})
+}
+
+func (f *fakeJWKS) publish(keys ...map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = keys
+}
+
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+		"n": encodeBigInt(key.N),
+		"e": encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "use": "sig", "crv": "P-256",
+		"x": encodeBigInt(key.X),
+		"y": encodeBigInt(key.Y),
+	}
+}
+
+func signToken(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	Expect(err).NotTo(HaveOccurred())
+	return signed
+}
+
+var _ = Describe("TokenValidator", Label("auth"), func() {
+	var (
+		jwks      *fakeJWKS
+		server    *httptest.Server
+		rsaKey    *rsa.PrivateKey
+		authCfg   *config.AuthConfig
+		validator *TokenValidator
+		claims    jwt.MapClaims
+	)
+
+	BeforeEach(func() {
+		var err error
+		rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		jwks = &fakeJWKS{}
+		jwks.publish(rsaJWK("key-1", rsaKey))
+		server = httptest.NewServer(jwks)
+		DeferCleanup(server.Close)
+
+		authCfg = &config.AuthConfig{
+			JWKSUrl:  server.URL,
+			Issuer:   "https://idp.example.com",
+			Audience: "fern-platform",
+			OAuth: config.OAuthConfig{
+				UserIDField: "sub",
+				EmailField:  "email",
+				NameField:   "name",
+				GroupsField: "groups",
+				RolesField:  "roles",
+				AdminGroups: []string{"fern-admins"},
+			},
+		}
+		validator = NewTokenValidator(authCfg)
+
+		claims = jwt.MapClaims{
+			"sub":    "ci-pipeline",
+			"email":  "ci@example.com",
+			"name":   "CI Pipeline",
+			"groups": []string{"team-a"},
+			"iss":    "https://idp.example.com",
+			"aud":    "fern-platform",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+	})
+
+	It("returns nil without a JWKS or JWT secret", func() {
+		Expect(NewTokenValidator(&config.AuthConfig{})).To(BeNil())
+	})
+
+	It("accepts a token signed with a key from the JWKS", func() {
+		userInfo, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userInfo.Sub).To(Equal("ci-pipeline"))
+		Expect(userInfo.Email).To(Equal("ci@example.com"))
+		Expect(userInfo.Groups).To(ConsistOf("team-a"))
+	})
+
+	It("accepts EC keys", func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		jwks.publish(ecJWK("ec-1", ecKey))
+
+		userInfo, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodES256, "ec-1", ecKey, claims))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userInfo.Sub).To(Equal("ci-pipeline"))
+	})
+
+	It("accepts a token without a key ID when the JWKS has a single key", func() {
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "", rsaKey, claims))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("caches the JWKS", func() {
+		token := signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims)
+		for i := 0; i < 3; i++ {
+			_, err := validator.Validate(context.Background(), token)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(jwks.requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("fetches rotated keys", func() {
+		now := time.Now()
+		validator.keys.now = func() time.Time { return now }
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).NotTo(HaveOccurred())
+
+		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		jwks.publish(rsaJWK("key-1", rsaKey), rsaJWK("key-2", newKey))
+		newToken := signToken(jwt.SigningMethodRS256, "key-2", newKey, claims)
+
+		By("not fetching the JWKS again right away")
+		_, err = validator.Validate(context.Background(), newToken)
+		Expect(err).To(MatchError(ContainSubstring("unknown signing key")))
+
+		By("fetching the JWKS again for an unknown key after the minimum interval")
+		now = now.Add(jwksMinRefreshInterval)
+		_, err = validator.Validate(context.Background(), newToken)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwks.requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("drops keys removed from the JWKS after the refresh interval", func() {
+		now := time.Now()
+		validator.keys.now = func() time.Time { return now }
+		token := signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims)
+		_, err := validator.Validate(context.Background(), token)
+		Expect(err).NotTo(HaveOccurred())
+
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		jwks.publish(rsaJWK("key-2", otherKey))
+		now = now.Add(jwksRefreshInterval)
+
+		_, err = validator.Validate(context.Background(), token)
+		Expect(err).To(MatchError(ContainSubstring("unknown signing key")))
+	})
+
+	It("keeps using cached keys when the JWKS is unavailable", func() {
+		now := time.Now()
+		validator.keys.now = func() time.Time { return now }
+		token := signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims)
+		_, err := validator.Validate(context.Background(), token)
+		Expect(err).NotTo(HaveOccurred())
+
+		jwks.fail.Store(true)
+		now = now.Add(jwksRefreshInterval)
+
+		_, err = validator.Validate(context.Background(), token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwks.requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("rejects a token when the JWKS cannot be fetched", func() {
+		jwks.fail.Store(true)
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(ContainSubstring("failed to fetch JWKS")))
+	})
+
+	It("rejects a token signed with another key", func() {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", otherKey, claims))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an expired token", func() {
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(jwt.ErrTokenExpired))
+	})
+
+	It("rejects a token without an expiry", func() {
+		delete(claims, "exp")
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects a token from another issuer", func() {
+		claims["iss"] = "https://other.example.com"
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(jwt.ErrTokenInvalidIssuer))
+	})
+
+	It("rejects a token for another audience", func() {
+		claims["aud"] = "other-service"
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(ContainSubstring("audience")))
+	})
+
+	It("requires an audience, defaulting to the OAuth client ID", func() {
+		authCfg.Audience = ""
+		Expect(NewTokenValidator(authCfg)).To(BeNil())
+
+		authCfg.OAuth.ClientID = "fern-web"
+		validator = NewTokenValidator(authCfg)
+		Expect(validator).NotTo(BeNil())
+
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(ContainSubstring("audience")))
+
+		claims["azp"] = "fern-web"
+		_, err = validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("serves cached keys while the JWKS is being fetched", func() {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			jwks.ServeHTTP(w, r)
+		}))
+		DeferCleanup(slow.Close)
+		DeferCleanup(func() { close(release) })
+
+		cache := NewJWKSCache(slow.URL)
+		cache.keys = map[string]crypto.PublicKey{"key-1": &rsaKey.PublicKey}
+		cache.fetchedAt = time.Now()
+
+		go func() {
+			defer GinkgoRecover()
+			_, _ = cache.Key(context.Background(), "key-2")
+		}()
+		Eventually(func() bool {
+			cache.mu.Lock()
+			defer cache.mu.Unlock()
+			return !cache.attemptedAt.IsZero()
+		}).Should(BeTrue())
+
+		key, err := cache.Key(context.Background(), "key-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal(&rsaKey.PublicKey))
+	})
+
+	It("accepts a token issued to Fern as the authorized party", func() {
+		claims["aud"] = "account"
+		claims["azp"] = "fern-platform"
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects HMAC tokens without a JWT secret", func() {
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("accepts HMAC tokens signed with the JWT secret", func() {
+		authCfg.JWTSecret = "secret"
+		validator = NewTokenValidator(authCfg)
+
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects a token without a user ID", func() {
+		delete(claims, "sub")
+		_, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+		Expect(err).To(MatchError(ContainSubstring("no user ID")))
+	})
+
+	It("maps claims with the configured fields", func() {
+		authCfg.OAuth.UserIDField = "client_id"
+		authCfg.OAuth.GroupsField = "realm_access.roles"
+		validator = NewTokenValidator(authCfg)
+		claims["client_id"] = "svc-reporting"
+		claims["realm_access"] = map[string]interface{}{"roles": []string{"fern-admins"}}
+
+		userInfo, err := validator.Validate(context.Background(), signToken(jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userInfo.Sub).To(Equal("svc-reporting"))
+		Expect(userInfo.Groups).To(ContainElements("fern-admins", "admin"))
+	})
+})
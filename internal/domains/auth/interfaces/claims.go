@@ -0,0 +1,153 @@
+package interfaces
+
+import (
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+// userInfoFromClaims maps the claims of a userinfo response or token to user
+// information using the configured claim fields, and applies the configured
+// admin users and admin groups. Claim fields can name nested claims with dots,
+// as in realm_access.roles.
+func userInfoFromClaims(cfg *config.OAuthConfig, claims map[string]interface{}) *application.UserInfo {
+	userInfo := &application.UserInfo{
+		Sub:       claimString(claims, claimField(cfg.UserIDField, "sub")),
+		Email:     claimString(claims, claimField(cfg.EmailField, "email")),
+		Name:      claimString(claims, claimField(cfg.NameField, "name")),
+		Picture:   claimString(claims, "picture"),
+		FirstName: claimString(claims, "given_name"),
+		LastName:  claimString(claims, "family_name"),
+		Groups:    claimStrings(claims, claimField(cfg.GroupsField, "groups")),
+		Roles:     claimStrings(claims, claimField(cfg.RolesField, "roles")),
+	}
+	if emailVerified, ok := claims["email_verified"].(bool); ok {
+		userInfo.EmailVerified = emailVerified
+	}
+
+	applyAdminOverrides(cfg, userInfo)
+	return userInfo
+}
+
+// applyAdminOverrides grants admin to configured admin users and to members of
+// configured admin groups, by adding them to the admin group
+func applyAdminOverrides(cfg *config.OAuthConfig, userInfo *application.UserInfo) {
+	if isConfiguredAdmin(cfg, userInfo) {
+		grantAdmin(userInfo)
+	}
+}
+
+// applyRoleMappings grants admin to bearer token holders whose user, groups
+// or roles claim are mapped to the admin role, or whose roles claim names an
+// admin group. Users who sign in through the browser are not mapped.
+func applyRoleMappings(cfg *config.OAuthConfig, userInfo *application.UserInfo) {
+	if isMappedAdmin(cfg, userInfo) {
+		grantAdmin(userInfo)
+	}
+}
+
+// isConfiguredAdmin checks if the admin users or admin groups include a user
+func isConfiguredAdmin(cfg *config.OAuthConfig, userInfo *application.UserInfo) bool {
+	for _, adminUser := range cfg.AdminUsers {
+		if adminUser == userInfo.Email || adminUser == userInfo.Sub {
+			return true
+		}
+	}
+	for _, group := range userInfo.Groups {
+		for _, adminGroup := range cfg.AdminGroups {
+			if group == adminGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMappedAdmin checks if the role mappings or the roles claim make a user an admin
+func isMappedAdmin(cfg *config.OAuthConfig, userInfo *application.UserInfo) bool {
+	for _, id := range []string{userInfo.Email, userInfo.Sub} {
+		if role, ok := cfg.UserRoleMapping[id]; ok && id != "" && strings.EqualFold(role, "admin") {
+			return true
+		}
+	}
+
+	for _, role := range userInfo.Roles {
+		for _, adminGroup := range cfg.AdminGroups {
+			if role == adminGroup {
+				return true
+			}
+		}
+	}
+	groups := append(append([]string{}, userInfo.Groups...), userInfo.Roles...)
+	for _, group := range groups {
+		if role, ok := cfg.GroupRoleMapping[group]; ok && strings.EqualFold(role, "admin") {
+			return true
+		}
+	}
+	return false
+}
+
+// grantAdmin adds a user to the admin group, unless they are a member already
+func grantAdmin(userInfo *application.UserInfo) {
+	if !hasAdminGroup(userInfo.Groups) {
+		userInfo.Groups = append(userInfo.Groups, "admin")
+	}
+}
+
+// hasAdminGroup checks if the groups include the admin group
+func hasAdminGroup(groups []string) bool {
+	for _, group := range groups {
+		if group == "admin" || group == "/admin" {
+			return true
+		}
+	}
+	return false
+}
+
+// claimField returns the configured claim field, or its default
+func claimField(field, fallback string) string {
+	if field == "" {
+		return fallback
+	}
+	return field
+}
+
+// claimValue looks up a claim, following dots into nested claims
+func claimValue(claims map[string]interface{}, field string) interface{} {
+	if value, ok := claims[field]; ok {
+		return value
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(field, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// claimString looks up a string claim
+func claimString(claims map[string]interface{}, field string) string {
+	value, _ := claimValue(claims, field).(string)
+	return value
+}
+
+// claimStrings looks up a claim holding a list of strings, ignoring other values
+func claimStrings(claims map[string]interface{}, field string) []string {
+	list, ok := claimValue(claims, field).([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -20,6 +20,7 @@ import (
 type AuthService interface {
 	ValidateSession(ctx context.Context, sid string) (*domain.Session, error)
 	AuthenticateWithOAuth(ctx context.Context, userInfo application.UserInfo, t application.TokenInfo, ip, ua string) (*application.AuthenticateResult, error)
+	AuthenticateBearer(ctx context.Context, userInfo application.UserInfo) (*domain.User, error)
 	Logout(ctx context.Context, sid string) error
 }
 
@@ -33,13 +34,31 @@ type OAuthAdapterIface interface {
 	BuildProviderLogoutURL(idToken string) string
 }
 
+// Auth events passed to an AuthEventRecorder
+const (
+	AuthEventLogin       = "login"
+	AuthEventLoginFailed = "login_failed"
+	AuthEventLogout      = "logout"
+)
+
+// AuthEventRecorder records sign-ins and sign-outs, such as in the audit log.
+// The user is nil when unknown.
+type AuthEventRecorder interface {
+	RecordAuthEvent(c *gin.Context, event string, user *domain.User)
+}
+
 // AuthMiddlewareAdapter provides Gin middleware using auth domain services
 type AuthMiddlewareAdapter struct {
-	authService  AuthService
-	authzService *application.AuthorizationService
-	oauthAdapter OAuthAdapterIface
-	config       *config.AuthConfig
-	logger       *logging.Logger
+	authService   AuthService
+	authzService  *application.AuthorizationService
+	oauthAdapter  OAuthAdapterIface
+	config        *config.AuthConfig
+	logger        *logging.Logger
+	eventRecorder AuthEventRecorder
+
+	// tokenValidator validates bearer JWTs; without one, bearer tokens are
+	// checked with the provider's userinfo endpoint
+	tokenValidator *TokenValidator
 }
 
 // NewAuthMiddlewareAdapter creates a new auth middleware adapter
@@ -51,11 +70,24 @@ func NewAuthMiddlewareAdapter(
 	logger *logging.Logger,
 ) *AuthMiddlewareAdapter {
 	return &AuthMiddlewareAdapter{
-		authService:  authService,
-		authzService: authzService,
-		oauthAdapter: oauthAdapter,
-		config:       config,
-		logger:       logger,
+		authService:    authService,
+		authzService:   authzService,
+		oauthAdapter:   oauthAdapter,
+		config:         config,
+		logger:         logger,
+		tokenValidator: NewTokenValidator(config),
+	}
+}
+
+// SetEventRecorder records sign-ins and sign-outs through the OAuth flow
+func (m *AuthMiddlewareAdapter) SetEventRecorder(recorder AuthEventRecorder) {
+	m.eventRecorder = recorder
+}
+
+// recordEvent records a sign-in or sign-out if a recorder is set
+func (m *AuthMiddlewareAdapter) recordEvent(c *gin.Context, event string, user *domain.User) {
+	if m.eventRecorder != nil {
+		m.eventRecorder.RecordAuthEvent(c, event, user)
 	}
 }
 
@@ -67,50 +99,31 @@ func (m *AuthMiddlewareAdapter) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		var sessionID string
-
-		// 1. Try to get token from Authorization header
-		authHeader := c.GetHeader("Authorization")
-
-		authHeaderLower := strings.ToLower(authHeader)
-		if strings.HasPrefix(authHeaderLower, "bearer ") {
-			accessToken := authHeader[7:]
-			// Extract user info and craete session
-			// Get user info
-			userInfo, err := m.oauthAdapter.GetUserInfo(accessToken)
-			if err != nil {
-				m.logger.WithError(err).Error("Failed to get user info due to: " + err.Error())
-				c.JSON(400, gin.H{"error": "Failed to get user information"})
-				return
-			}
-
-			tokenInfo := application.TokenInfo{AccessToken: accessToken}
+		// The session may already have been validated by IdentifyUser
+		if _, ok := m.getUserFromContext(c); ok {
+			c.Next()
+			return
+		}
 
-			//Authenticate user
-			result, err := m.authService.AuthenticateWithOAuth(
-				c.Request.Context(),
-				*userInfo,
-				tokenInfo,
-				c.ClientIP(),
-				c.GetHeader("User-Agent"),
-			)
+		// 1. Machine clients authenticate with a bearer token, without a session
+		if token, ok := bearerToken(c); ok {
+			user, err := m.authenticateBearer(c.Request.Context(), token)
 			if err != nil {
-				m.logger.WithError(err).Error("Failed to authenticate user")
-				c.JSON(500, gin.H{"error": "Authentication failed"})
+				m.logger.WithRequest(c.GetString("request_id"), c.Request.Method, c.Request.URL.Path).
+					WithError(err).Debug("Bearer token authentication failed")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid bearer token"})
 				return
 			}
-			sessionID = result.Session.SessionID
+			m.setUserContext(c, user, nil)
+			c.Next()
+			return
 		}
 
-		// 2. If not in header, try cookie
-		if sessionID == "" {
-			m.logger.Debug("Cookie header received")
-			cookie, err := c.Cookie("session_id")
-			if err != nil || cookie == "" {
-				m.handleUnauthenticated(c)
-				return
-			}
-			sessionID = cookie
+		// 2. Otherwise use the session cookie
+		sessionID, err := c.Cookie("session_id")
+		if err != nil || sessionID == "" {
+			m.handleUnauthenticated(c)
+			return
 		}
 
 		session, err := m.authService.ValidateSession(c.Request.Context(), sessionID)
@@ -127,6 +140,71 @@ func (m *AuthMiddlewareAdapter) RequireAuth() gin.HandlerFunc {
 	}
 }
 
+// authenticateBearer resolves the user of a bearer token, validating JWTs
+// locally when a JWKS or JWT secret is configured and asking the provider's
+// userinfo endpoint otherwise. Role mappings apply to token holders only.
+func (m *AuthMiddlewareAdapter) authenticateBearer(ctx context.Context, token string) (*domain.User, error) {
+	var userInfo *application.UserInfo
+	var err error
+	if m.tokenValidator != nil {
+		userInfo, err = m.tokenValidator.Validate(ctx, token)
+	} else {
+		userInfo, err = m.oauthAdapter.GetUserInfo(token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	applyRoleMappings(&m.config.OAuth, userInfo)
+	return m.authService.AuthenticateBearer(ctx, *userInfo)
+}
+
+// bearerToken returns the token of a request's bearer Authorization header
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) <= 7 || !strings.EqualFold(header[:7], "bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(header[7:]), true
+}
+
+// IdentifyUser returns the ID of the user signed in with the request's session
+// cookie or bearer token, without requiring one. A valid session or token is
+// kept in the context, so RequireAuth does not validate it again.
+func (m *AuthMiddlewareAdapter) IdentifyUser(c *gin.Context) (string, bool) {
+	if user, ok := m.getUserFromContext(c); ok {
+		return user.UserID, true
+	}
+	if !m.config.Enabled || !m.config.OAuth.Enabled {
+		return "", false
+	}
+	// Bearer tokens take precedence over session cookies in RequireAuth. They
+	// are only identified when they can be validated locally, so unauthenticated
+	// requests do not reach the provider's userinfo endpoint.
+	if token, ok := bearerToken(c); ok {
+		if m.tokenValidator == nil {
+			return "", false
+		}
+		user, err := m.authenticateBearer(c.Request.Context(), token)
+		if err != nil {
+			return "", false
+		}
+		m.setUserContext(c, user, nil)
+		return user.UserID, true
+	}
+
+	sessionID, err := c.Cookie("session_id")
+	if err != nil || sessionID == "" {
+		return "", false
+	}
+	session, err := m.authService.ValidateSession(c.Request.Context(), sessionID)
+	if err != nil {
+		return "", false
+	}
+
+	m.setUserContext(c, session.User, session)
+	return session.User.UserID, true
+}
+
 // RequireAdmin middleware ensures user has admin role
 func (m *AuthMiddlewareAdapter) RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -290,6 +368,7 @@ func (m *AuthMiddlewareAdapter) HandleOAuthCallback() gin.HandlerFunc {
 		tokenInfo, err := m.oauthAdapter.ExchangeCodeForToken(code, codeVerifier)
 		if err != nil {
 			m.logger.WithError(err).Error("Failed to exchange code for token")
+			m.recordEvent(c, AuthEventLoginFailed, nil)
 			c.JSON(400, gin.H{"error": "Token exchange failed"})
 			return
 		}
@@ -312,9 +391,11 @@ func (m *AuthMiddlewareAdapter) HandleOAuthCallback() gin.HandlerFunc {
 		)
 		if err != nil {
 			m.logger.WithError(err).Error("Failed to authenticate user")
+			m.recordEvent(c, AuthEventLoginFailed, &domain.User{UserID: userInfo.Sub, Email: userInfo.Email})
 			c.JSON(500, gin.H{"error": "Authentication failed"})
 			return
 		}
+		m.recordEvent(c, AuthEventLogin, result.User)
 
 		// Set session cookie
 		m.setSessionCookie(c, result.Session.SessionID)
@@ -347,6 +428,9 @@ func (m *AuthMiddlewareAdapter) Logout() gin.HandlerFunc {
 
 			// Invalidate session
 			m.authService.Logout(c.Request.Context(), sessionID)
+			if session != nil {
+				m.recordEvent(c, AuthEventLogout, session.User)
+			}
 
 			// Clear session cookie
 			isSecure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
@@ -404,7 +488,10 @@ func (m *AuthMiddlewareAdapter) setUserContext(c *gin.Context, user *domain.User
 	c.Set("user", user)
 	c.Set("user_id", user.UserID)
 	c.Set("user_role", string(user.Role))
-	c.Set("session", session)
+	// Bearer token holders have no session
+	if session != nil {
+		c.Set("session", session)
+	}
 }
 
 func (m *AuthMiddlewareAdapter) getUserFromContext(c *gin.Context) (*domain.User, bool) {
@@ -418,9 +505,13 @@ func (m *AuthMiddlewareAdapter) getUserFromContext(c *gin.Context) (*domain.User
 }
 
 func (m *AuthMiddlewareAdapter) setSessionCookie(c *gin.Context, sessionID string) {
-	// Set secure cookie for 24 hours
+	// Keep the cookie for the session's lifetime, 24 hours unless configured
+	maxAge := 86400
+	if lifetime := m.config.Session.AbsoluteLifetime; lifetime > 0 {
+		maxAge = int(lifetime.Seconds())
+	}
 	isSecure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
-	c.SetCookie("session_id", sessionID, 86400, "/", "", isSecure, true)
+	c.SetCookie("session_id", sessionID, maxAge, "/", "", isSecure, true)
 }
 
 // GetAuthUser extracts the authenticated user from Gin context
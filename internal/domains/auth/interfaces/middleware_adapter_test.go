@@ -9,11 +9,13 @@ import (
 	_ "net/http"
 	"net/http/httptest"
 	_ "strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
 	"github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
@@ -24,6 +26,7 @@ import (
 type fakeAuthService struct {
 	validateFn func(ctx context.Context, sid string) (*domain.Session, error)
 	authFn     func(ctx context.Context, u application.UserInfo, t application.TokenInfo, ip, ua string) (*application.AuthenticateResult, error)
+	bearerFn   func(ctx context.Context, u application.UserInfo) (*domain.User, error)
 	logoutFn   func(ctx context.Context, sid string) error
 }
 
@@ -33,6 +36,9 @@ func (f *fakeAuthService) ValidateSession(ctx context.Context, sid string) (*dom
 func (f *fakeAuthService) AuthenticateWithOAuth(ctx context.Context, u application.UserInfo, t application.TokenInfo, ip, ua string) (*application.AuthenticateResult, error) {
 	return f.authFn(ctx, u, t, ip, ua)
 }
+func (f *fakeAuthService) AuthenticateBearer(ctx context.Context, u application.UserInfo) (*domain.User, error) {
+	return f.bearerFn(ctx, u)
+}
 func (f *fakeAuthService) Logout(ctx context.Context, sid string) error {
 	return f.logoutFn(ctx, sid)
 }
@@ -103,11 +109,8 @@ var _ = Describe("AuthMiddlewareAdapter", Label("auth"), func() {
 	Describe("RequireAuth", func() {
 		It("authenticates with bearer token", func() {
 			oauth.user = &application.UserInfo{Sub: "u1"}
-			authSvc.authFn = func(ctx context.Context, u application.UserInfo, t application.TokenInfo, ip, ua string) (*application.AuthenticateResult, error) {
-				return &application.AuthenticateResult{Session: &domain.Session{SessionID: "sid1"}, User: &domain.User{UserID: "u1"}}, nil
-			}
-			authSvc.validateFn = func(ctx context.Context, sid string) (*domain.Session, error) {
-				return &domain.Session{SessionID: sid, User: &domain.User{UserID: "u1"}}, nil
+			authSvc.bearerFn = func(ctx context.Context, u application.UserInfo) (*domain.User, error) {
+				return &domain.User{UserID: u.Sub}, nil
 			}
 
 			c.Request.Header.Set("Authorization", "Bearer abc")
@@ -116,6 +119,21 @@ var _ = Describe("AuthMiddlewareAdapter", Label("auth"), func() {
 
 			Expect(c.IsAborted()).To(BeFalse())
 			Expect(c.Keys["user_id"]).To(Equal("u1"))
+			Expect(c.Keys).NotTo(HaveKey("session"))
+		})
+
+		It("rejects bearer tokens of users that cannot sign in", func() {
+			oauth.user = &application.UserInfo{Sub: "u1"}
+			authSvc.bearerFn = func(ctx context.Context, u application.UserInfo) (*domain.User, error) {
+				return nil, errors.New("user account is suspended")
+			}
+
+			c.Request.Header.Set("Authorization", "Bearer abc")
+			mw := adapter.RequireAuth()
+			mw(c)
+
+			Expect(c.IsAborted()).To(BeTrue())
+			Expect(recorder.Code).To(Equal(401))
 		})
 
 		It("fails with invalid token", func() {
@@ -124,7 +142,7 @@ var _ = Describe("AuthMiddlewareAdapter", Label("auth"), func() {
 			mw := adapter.RequireAuth()
 			mw(c)
 
-			Expect(recorder.Code).To(Equal(400))
+			Expect(recorder.Code).To(Equal(401))
 		})
 
 		It("redirects to login if no session", func() {
@@ -193,6 +211,30 @@ var _ = Describe("AuthMiddlewareAdapter", Label("auth"), func() {
 			Expect(recorder.Code).To(Equal(400))
 		})
 
+		It("does not apply role mappings to users who sign in", func() {
+			req := httptest.NewRequest("GET", "/callback?state=state123&code=abc", nil)
+			req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "state123"})
+			c.Request = req
+
+			cfg.OAuth.UserRoleMapping = map[string]string{"u1": "admin"}
+			cfg.OAuth.GroupRoleMapping = map[string]string{"team-a": "admin"}
+			oauth.token = &application.TokenInfo{AccessToken: "tok"}
+			oauth.user = &application.UserInfo{Sub: "u1", Groups: []string{"team-a"}}
+			var signedIn application.UserInfo
+			authSvc.authFn = func(ctx context.Context, u application.UserInfo, t application.TokenInfo, ip, ua string) (*application.AuthenticateResult, error) {
+				signedIn = u
+				return &application.AuthenticateResult{
+					Session: &domain.Session{SessionID: "s1"},
+					User:    &domain.User{UserID: "u1"},
+				}, nil
+			}
+
+			adapter.HandleOAuthCallback()(c)
+
+			Expect(recorder.Code).To(Equal(302))
+			Expect(signedIn.Groups).To(Equal([]string{"team-a"}))
+		})
+
 		It("fails with missing code", func() {
 			c.Request = httptest.NewRequest("GET", "/callback?state=state123", nil)
 			c.SetCookie("oauth_state", "state123", 600, "/", "", false, true)
@@ -304,6 +346,97 @@ var _ = Describe("AuthMiddlewareAdapter", Label("auth"), func() {
 		})
 	})
 
+	Describe("bearer JWTs", func() {
+		var bearerUser application.UserInfo
+
+		signed := func(claims jwt.MapClaims) string {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			return token
+		}
+
+		serve := func(token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", "/api/admin", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/admin", adapter.RequireAdmin(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		BeforeEach(func() {
+			cfg.JWTSecret = "secret"
+			cfg.Issuer = "https://idp.example.com"
+			cfg.Audience = "fern-platform"
+			adapter = interfaces.NewAuthMiddlewareAdapter(authSvc, nil, oauth, cfg, logger)
+			authSvc.bearerFn = func(ctx context.Context, u application.UserInfo) (*domain.User, error) {
+				bearerUser = u
+				role := domain.RoleUser
+				for _, group := range u.Groups {
+					if group == "admin" {
+						role = domain.RoleAdmin
+					}
+				}
+				return &domain.User{UserID: u.Sub, Role: role}, nil
+			}
+		})
+
+		claims := func(groups ...string) jwt.MapClaims {
+			return jwt.MapClaims{
+				"sub":    "svc-ci",
+				"groups": groups,
+				"iss":    "https://idp.example.com",
+				"aud":    "fern-platform",
+				"exp":    time.Now().Add(time.Hour).Unix(),
+			}
+		}
+
+		It("validates the token locally and applies the role checks", func() {
+			Expect(serve(signed(claims("admin"))).Code).To(Equal(http.StatusNoContent))
+			Expect(bearerUser.Sub).To(Equal("svc-ci"))
+
+			w := serve(signed(claims("team-a")))
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("grants admin to token holders mapped to the admin role", func() {
+			cfg.OAuth.UserRoleMapping = map[string]string{"svc-ci": "admin"}
+			Expect(serve(signed(claims("team-a"))).Code).To(Equal(http.StatusNoContent))
+
+			cfg.OAuth.UserRoleMapping = nil
+			cfg.OAuth.GroupRoleMapping = map[string]string{"team-a": "Admin"}
+			Expect(serve(signed(claims("team-a"))).Code).To(Equal(http.StatusNoContent))
+
+			cfg.OAuth.GroupRoleMapping = nil
+			cfg.OAuth.AdminGroups = []string{"fern-admins"}
+			withRoles := claims("team-a")
+			withRoles["roles"] = []string{"fern-admins"}
+			Expect(serve(signed(withRoles)).Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("rejects tokens that fail validation", func() {
+			expired := claims("admin")
+			expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+			Expect(serve(signed(expired)).Code).To(Equal(http.StatusUnauthorized))
+			Expect(serve("not-a-jwt").Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("identifies only users with valid tokens", func() {
+			c.Request.Header.Set("Authorization", "Bearer "+signed(claims("team-a")))
+			userID, ok := adapter.IdentifyUser(c)
+			Expect(ok).To(BeTrue())
+			Expect(userID).To(Equal("svc-ci"))
+
+			c, _ = gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Request.Header.Set("Authorization", "Bearer not-a-jwt")
+			_, ok = adapter.IdentifyUser(c)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
 	Describe("RequireManager", func() {
 		It("denies non-manager user", func() {
 			u := &domain.User{UserID: "u1", Role: domain.RoleUser}
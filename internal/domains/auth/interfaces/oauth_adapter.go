@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -73,7 +74,25 @@ func (a *OAuthAdapter) ExchangeCodeForToken(code string, codeVerifier string) (*
 		data.Set("code_verifier", codeVerifier) // Required for PKCE
 	}
 
-	req, err := http.NewRequest("POST", a.config.OAuth.TokenURL, strings.NewReader(data.Encode()))
+	return a.requestToken(context.Background(), data, "token exchange")
+}
+
+// RefreshToken exchanges a refresh token for a new access token. Providers
+// that do not rotate refresh tokens return none, so the caller keeps the one
+// it has.
+func (a *OAuthAdapter) RefreshToken(ctx context.Context, refreshToken string) (*application.TokenInfo, error) {
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+	data.Set("client_id", a.config.OAuth.ClientID)
+	data.Set("client_secret", a.config.OAuth.ClientSecret)
+
+	return a.requestToken(ctx, data, "token refresh")
+}
+
+// requestToken posts a token request to the provider's token endpoint
+func (a *OAuthAdapter) requestToken(ctx context.Context, data url.Values, action string) (*application.TokenInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", a.config.OAuth.TokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +108,7 @@ func (a *OAuthAdapter) ExchangeCodeForToken(code string, codeVerifier string) (*
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("token exchange failed: %s", string(body))
+		return nil, fmt.Errorf("%s failed: %s", action, string(body))
 	}
 
 	var tokenResp struct {
@@ -139,54 +158,7 @@ func (a *OAuthAdapter) GetUserInfo(accessToken string) (*application.UserInfo, e
 		return nil, err
 	}
 
-	// Extract user info using configurable field mappings
-	userInfo := &application.UserInfo{}
-
-	if sub, ok := rawInfo[a.config.OAuth.UserIDField].(string); ok {
-		userInfo.Sub = sub
-	}
-	if email, ok := rawInfo[a.config.OAuth.EmailField].(string); ok {
-		userInfo.Email = email
-	}
-	if name, ok := rawInfo[a.config.OAuth.NameField].(string); ok {
-		userInfo.Name = name
-	}
-	if picture, ok := rawInfo["picture"].(string); ok {
-		userInfo.Picture = picture
-	}
-
-	// Extract additional fields
-	if firstName, ok := rawInfo["given_name"].(string); ok {
-		userInfo.FirstName = firstName
-	}
-	if lastName, ok := rawInfo["family_name"].(string); ok {
-		userInfo.LastName = lastName
-	}
-	if emailVerified, ok := rawInfo["email_verified"].(bool); ok {
-		userInfo.EmailVerified = emailVerified
-	}
-
-	// Extract groups and roles
-	if groups, ok := rawInfo[a.config.OAuth.GroupsField].([]interface{}); ok {
-		for _, group := range groups {
-			if groupStr, ok := group.(string); ok {
-				userInfo.Groups = append(userInfo.Groups, groupStr)
-			}
-		}
-	}
-
-	if roles, ok := rawInfo[a.config.OAuth.RolesField].([]interface{}); ok {
-		for _, role := range roles {
-			if roleStr, ok := role.(string); ok {
-				userInfo.Roles = append(userInfo.Roles, roleStr)
-			}
-		}
-	}
-
-	// Apply admin user/group overrides from config
-	a.applyAdminOverrides(userInfo)
-
-	return userInfo, nil
+	return userInfoFromClaims(&a.config.OAuth, rawInfo), nil
 }
 
 // BuildProviderLogoutURL builds the OAuth provider logout URL
@@ -236,44 +208,3 @@ func (a *OAuthAdapter) BuildProviderLogoutURL(idToken string) string {
 	// Final fallback to local login page
 	return "/auth/login"
 }
-
-// applyAdminOverrides applies admin user/group configurations
-func (a *OAuthAdapter) applyAdminOverrides(userInfo *application.UserInfo) {
-	// Check if user should be admin based on config
-	for _, adminUser := range a.config.OAuth.AdminUsers {
-		if adminUser == userInfo.Email || adminUser == userInfo.Sub {
-			// Ensure admin group is present
-			hasAdminGroup := false
-			for _, group := range userInfo.Groups {
-				if group == "admin" || group == "/admin" {
-					hasAdminGroup = true
-					break
-				}
-			}
-			if !hasAdminGroup {
-				userInfo.Groups = append(userInfo.Groups, "admin")
-			}
-			return
-		}
-	}
-
-	// Check if any of user's groups are configured as admin groups
-	for _, group := range userInfo.Groups {
-		for _, adminGroup := range a.config.OAuth.AdminGroups {
-			if group == adminGroup {
-				// Add admin group if not present
-				hasAdminGroup := false
-				for _, g := range userInfo.Groups {
-					if g == "admin" || g == "/admin" {
-						hasAdminGroup = true
-						break
-					}
-				}
-				if !hasAdminGroup {
-					userInfo.Groups = append(userInfo.Groups, "admin")
-				}
-				return
-			}
-		}
-	}
-}
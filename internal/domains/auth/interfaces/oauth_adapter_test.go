@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -241,6 +242,47 @@ var _ = Describe("OAuthAdapter", Label("auth"), func() {
 		})
 	})
 
+	Describe("RefreshToken", func() {
+		var server *httptest.Server
+
+		AfterEach(func() {
+			if server != nil {
+				server.Close()
+			}
+		})
+
+		It("exchanges a refresh token for new tokens", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				Expect(r.Form.Get("grant_type")).To(Equal("refresh_token"))
+				Expect(r.Form.Get("refresh_token")).To(Equal("refresh-123"))
+				Expect(r.Form.Get("client_id")).To(Equal(authConfig.OAuth.ClientID))
+				w.Write([]byte(`{"access_token":"access-456","token_type":"Bearer","expires_in":900}`))
+			}))
+			authConfig.OAuth.TokenURL = server.URL
+			adapter = NewOAuthAdapter(authConfig, logger)
+
+			token, err := adapter.RefreshToken(context.Background(), "refresh-123")
+			Expect(err).To(BeNil())
+			Expect(token.AccessToken).To(Equal("access-456"))
+			Expect(token.RefreshToken).To(BeEmpty())
+			Expect(token.ExpiresIn).To(Equal(900))
+		})
+
+		It("returns error when the provider rejects the refresh token", func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			}))
+			authConfig.OAuth.TokenURL = server.URL
+			adapter = NewOAuthAdapter(authConfig, logger)
+
+			token, err := adapter.RefreshToken(context.Background(), "revoked")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("token refresh failed"))
+			Expect(token).To(BeNil())
+		})
+	})
+
 	Describe("GetUserInfo", func() {
 		var server *httptest.Server
 
@@ -590,23 +632,20 @@ var _ = Describe("OAuthAdapter", Label("auth"), func() {
 
 		It("adds admin group for AdminUsers email match", func() {
 			authConfig.OAuth.AdminUsers = []string{"test@example.com"}
-			adapter = NewOAuthAdapter(authConfig, logger)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(ContainElement("admin"))
 		})
 
 		It("adds admin group for AdminUsers sub match", func() {
 			authConfig.OAuth.AdminUsers = []string{"user123"}
-			adapter = NewOAuthAdapter(authConfig, logger)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(ContainElement("admin"))
 		})
 
 		It("does not add admin if not in AdminUsers", func() {
 			authConfig.OAuth.AdminUsers = []string{"other@example.com"}
-			adapter = NewOAuthAdapter(authConfig, logger)
 			originalGroups := len(userInfo.Groups)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(HaveLen(originalGroups))
 			Expect(userInfo.Groups).NotTo(ContainElement("admin"))
 		})
@@ -614,8 +653,7 @@ var _ = Describe("OAuthAdapter", Label("auth"), func() {
 		It("adds admin group for AdminGroups match", func() {
 			userInfo.Groups = append(userInfo.Groups, "managers")
 			authConfig.OAuth.AdminGroups = []string{"managers"}
-			adapter = NewOAuthAdapter(authConfig, logger)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(ContainElement("admin"))
 		})
 
@@ -623,26 +661,32 @@ var _ = Describe("OAuthAdapter", Label("auth"), func() {
 			userInfo.Groups = append(userInfo.Groups, "blocked-group")
 			authConfig.OAuth.AdminUsers = []string{"test@example.com"}
 			authConfig.OAuth.AdminGroups = []string{"blocked-group"}
-			adapter = NewOAuthAdapter(authConfig, logger)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(ContainElement("admin"))
 		})
 
 		It("handles empty admin configurations", func() {
 			authConfig.OAuth.AdminUsers = []string{}
 			authConfig.OAuth.AdminGroups = []string{}
-			adapter = NewOAuthAdapter(authConfig, logger)
 			originalGroups := len(userInfo.Groups)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(HaveLen(originalGroups))
 			Expect(userInfo.Groups).NotTo(ContainElement("admin"))
 		})
 
+		It("does not grant admin through role mappings or the roles claim", func() {
+			userInfo.Roles = []string{"fern-admins"}
+			authConfig.OAuth.AdminGroups = []string{"fern-admins"}
+			authConfig.OAuth.UserRoleMapping = map[string]string{"test@example.com": "admin"}
+			authConfig.OAuth.GroupRoleMapping = map[string]string{"users": "admin"}
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
+			Expect(userInfo.Groups).NotTo(ContainElement("admin"))
+		})
+
 		It("handles nil groups", func() {
 			userInfo.Groups = nil
 			authConfig.OAuth.AdminUsers = []string{"test@example.com"}
-			adapter = NewOAuthAdapter(authConfig, logger)
-			adapter.applyAdminOverrides(userInfo)
+			applyAdminOverrides(&authConfig.OAuth, userInfo)
 			Expect(userInfo.Groups).To(HaveLen(1))
 			Expect(userInfo.Groups).To(ContainElement("admin"))
 		})
@@ -1,8 +1,14 @@
 package domains
 
 import (
+	"context"
+	"fmt"
+
 	"gorm.io/gorm"
 
+	// Audit domain
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+
 	// Auth domain
 	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
 	authInfra "github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
@@ -16,11 +22,13 @@ import (
 
 	// Testing domain
 	testingApp "github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
 	testingInfra "github.com/guidewire-oss/fern-platform/internal/domains/testing/infrastructure"
 	testingInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/testing/interfaces"
 
 	// Projects domain
 	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
 	projectsInfra "github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
 
 	// Tags domain
@@ -33,6 +41,8 @@ import (
 
 	"github.com/guidewire-oss/fern-platform/pkg/config"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/metrics"
+	"github.com/guidewire-oss/fern-platform/pkg/similarity"
 )
 
 // DomainFactory creates and wires all domain components
@@ -40,19 +50,25 @@ type DomainFactory struct {
 	db         *gorm.DB
 	logger     *logging.Logger
 	authConfig *config.AuthConfig
+	keyRing    *integrations.KeyRing
 
 	// Auth domain
-	authService    *authApp.AuthenticationService
-	authzService   *authApp.AuthorizationService
-	authMiddleware *authInterfaces.AuthMiddlewareAdapter
+	authService         *authApp.AuthenticationService
+	authzService        *authApp.AuthorizationService
+	userAdminService    *authApp.UserAdminService
+	provisioningService *authApp.ProvisioningService
+	authMiddleware      *authInterfaces.AuthMiddlewareAdapter
 
 	// Analytics domain
 	flakyDetectionService *analyticsApp.FlakyDetectionService
 	flakyDetectionAdapter *analyticsInterfaces.FlakyDetectionAdapter
 
 	// Testing domain
-	testRunService *testingApp.TestRunService
-	testingAdapter *testingInterfaces.TestServiceAdapter
+	testRunService        *testingApp.TestRunService
+	testingAdapter        *testingInterfaces.TestServiceAdapter
+	flakyLifecycleService *testingApp.FlakyLifecycleService
+	similarFailureService *testingApp.SimilarFailureService
+	explanationService    *testingApp.FailureExplanationService
 
 	// Projects domain
 	projectService *projectsApp.ProjectService
@@ -61,15 +77,54 @@ type DomainFactory struct {
 	tagService *tagsApp.TagService
 
 	// Integrations domain
-	jiraConnectionService *integrations.JiraConnectionService
+	jiraConnectionService    *integrations.JiraConnectionService
+	trackerConnectionService *integrations.TrackerConnectionService
+	reencryptionService      *integrations.CredentialReencryptionService
+	webhookService           *integrations.WebhookService
+	notificationService      *integrations.NotificationService
+	vcsReportService         *integrations.VCSReportService
+	digestService            *integrations.DigestService
+	eventPublisher           *integrationsInfra.IntegrationEventPublisher
+
+	// Audit domain
+	auditService *auditApp.AuditService
+}
+
+// developmentKeyID identifies the built-in key used when no credential
+// encryption keys are configured
+const developmentKeyID = "development"
+
+// developmentKey encrypted every credential before keys were configurable
+var developmentKey = []byte("your-32-byte-encryption-key-here")
+
+// NewCredentialKeyRing loads the keys integration credentials are encrypted
+// with. Without configured keys it falls back to the insecure development key,
+// which is also the legacy key then so credentials stored before key rings
+// stay readable.
+func NewCredentialKeyRing(encryptionConfig config.CredentialEncryptionConfig, logger *logging.Logger) (*integrations.KeyRing, error) {
+	if len(encryptionConfig.Keys) == 0 {
+		logger.WithService("domains").Warn("No credential encryption keys configured, using the insecure development key")
+		return integrations.NewKeyRing(developmentKeyID, developmentKeyID, map[string][]byte{developmentKeyID: developmentKey})
+	}
+
+	sources := make([]integrations.KeySource, len(encryptionConfig.Keys))
+	for i, key := range encryptionConfig.Keys {
+		sources[i] = integrations.KeySource{ID: key.ID, File: key.File, Env: key.Env}
+	}
+	keyRing, err := integrations.LoadKeyRing(encryptionConfig.PrimaryKeyID, encryptionConfig.LegacyKeyID, sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential encryption keys: %w", err)
+	}
+	return keyRing, nil
 }
 
 // NewDomainFactory creates a new domain factory
-func NewDomainFactory(db *gorm.DB, logger *logging.Logger, authConfig *config.AuthConfig) *DomainFactory {
+func NewDomainFactory(db *gorm.DB, logger *logging.Logger, authConfig *config.AuthConfig, keyRing *integrations.KeyRing) *DomainFactory {
 	factory := &DomainFactory{
 		db:         db,
 		logger:     logger,
 		authConfig: authConfig,
+		keyRing:    keyRing,
 	}
 
 	// Initialize Auth domain (must be first as others may depend on it)
@@ -112,6 +167,32 @@ func (f *DomainFactory) initTestingDomain() {
 		f.testRunService,
 		f.logger,
 	)
+
+	// Create flaky lifecycle service; the resolution threshold follows the
+	// project's effective flaky detection config
+	flakyTestRepo := testingInfra.NewGormFlakyTestRepository(f.db)
+	f.flakyLifecycleService = testingApp.NewFlakyLifecycleService(
+		flakyTestRepo,
+		func(ctx context.Context, projectID string) int {
+			// EffectiveConfig falls back to the defaults on error
+			config, _ := f.flakyDetectionService.EffectiveConfig(ctx, projectID)
+			return config.ConsecutivePassesForResolution
+		},
+	)
+
+	// Resolve spec and flaky test owners from project ownership rules on ingestion
+	f.testRunService.SetOwnership(
+		testingInfra.NewProjectOwnershipProvider(projectsInfra.NewGormProjectRepository(f.db)),
+		flakyTestRepo,
+	)
+
+	// Index failures on ingestion for similar failure search
+	f.similarFailureService = testingApp.NewSimilarFailureService(
+		specRunRepo,
+		similarity.NewIndex(similarity.DefaultMaxDocuments),
+		testingApp.DefaultSimilarityWindow,
+	)
+	f.testRunService.SetFailureIndexer(f.similarFailureService)
 }
 
 // initProjectsDomain initializes the projects domain components
@@ -130,6 +211,34 @@ func (f *DomainFactory) GetTestingService() *testingApp.TestRunService {
 	return f.testRunService
 }
 
+// GetFlakyLifecycleService returns the flaky test lifecycle service
+func (f *DomainFactory) GetFlakyLifecycleService() *testingApp.FlakyLifecycleService {
+	return f.flakyLifecycleService
+}
+
+// GetSimilarFailureService returns the similar failure search service
+func (f *DomainFactory) GetSimilarFailureService() *testingApp.SimilarFailureService {
+	return f.similarFailureService
+}
+
+// EnableFailureExplanations creates the failure explanation service using the given explainer
+func (f *DomainFactory) EnableFailureExplanations(explainer testingApp.FailureExplainer) *testingApp.FailureExplanationService {
+	f.explanationService = testingApp.NewFailureExplanationService(
+		testingInfra.NewGormTestRunRepository(f.db),
+		testingInfra.NewGormSuiteRunRepository(f.db),
+		testingInfra.NewGormSpecRunRepository(f.db),
+		testingInfra.NewGormFlakyTestRepository(f.db),
+		explainer,
+	)
+	return f.explanationService
+}
+
+// GetFailureExplanationService returns the failure explanation service, or nil
+// when explanations are not enabled
+func (f *DomainFactory) GetFailureExplanationService() *testingApp.FailureExplanationService {
+	return f.explanationService
+}
+
 // GetTestingAdapter returns the testing adapter for HTTP/GraphQL
 func (f *DomainFactory) GetTestingAdapter() *testingInterfaces.TestServiceAdapter {
 	return f.testingAdapter
@@ -164,9 +273,19 @@ func (f *DomainFactory) initAuthDomain() {
 	// Create application services
 	f.authService = authApp.NewAuthenticationService(userRepo, sessionRepo)
 	f.authzService = authApp.NewAuthorizationService(userRepo)
+	f.userAdminService = authApp.NewUserAdminService(userRepo, sessionRepo)
+	f.provisioningService = authApp.NewProvisioningService(userRepo, authInfra.NewGormGroupRepository(f.db), f.userAdminService)
 
-	// Create OAuth adapter
+	// Create OAuth adapter, which also refreshes sessions' access tokens
 	oauthAdapter := authInterfaces.NewOAuthAdapter(f.authConfig, f.logger)
+	f.authService.SetSessionPolicy(authApp.SessionPolicy{
+		IdleTimeout:      f.authConfig.Session.IdleTimeout,
+		AbsoluteLifetime: f.authConfig.Session.AbsoluteLifetime,
+		RefreshBefore:    f.authConfig.Session.RefreshBefore,
+	})
+	if f.authConfig.OAuth.Enabled {
+		f.authService.SetTokenRefresher(oauthAdapter)
+	}
 
 	// Create middleware adapter
 	f.authMiddleware = authInterfaces.NewAuthMiddlewareAdapter(
@@ -188,6 +307,16 @@ func (f *DomainFactory) GetAuthorizationService() *authApp.AuthorizationService
 	return f.authzService
 }
 
+// GetUserAdminService returns the user administration service
+func (f *DomainFactory) GetUserAdminService() *authApp.UserAdminService {
+	return f.userAdminService
+}
+
+// GetProvisioningService returns the SCIM user and group provisioning service
+func (f *DomainFactory) GetProvisioningService() *authApp.ProvisioningService {
+	return f.provisioningService
+}
+
 // GetAuthMiddleware returns the auth middleware adapter
 func (f *DomainFactory) GetAuthMiddleware() *authInterfaces.AuthMiddlewareAdapter {
 	return f.authMiddleware
@@ -198,9 +327,20 @@ func (f *DomainFactory) initAnalyticsDomain() {
 	// Create repository
 	flakyRepo := analyticsInfra.NewGormFlakyDetectionRepository(f.db)
 
-	// Create service with default config
+	// Create service with default config, overridable per project through project settings
 	config := analyticsDomain.DefaultFlakyTestDetectionConfig()
-	f.flakyDetectionService = analyticsApp.NewFlakyDetectionService(flakyRepo, config)
+	configResolver := analyticsInfra.NewProjectSettingsConfigResolver(projectsInfra.NewGormProjectRepository(f.db))
+	// Status changes go through the flaky lifecycle, which records their history
+	f.flakyDetectionService = analyticsApp.NewFlakyDetectionService(flakyRepo, config, configResolver,
+		func(ctx context.Context, projectID, testName string, status analyticsDomain.FlakyTestStatus, userID, reason string) error {
+			actor := testingDomain.SystemActor()
+			if userID != "" {
+				actor = testingDomain.UserActor(userID)
+			}
+			_, err := f.flakyLifecycleService.ChangeTestStatus(ctx, projectID, testName, testingDomain.FlakyStatus(status), actor, reason)
+			return err
+		},
+	)
 
 	// Create adapter
 	f.flakyDetectionAdapter = analyticsInterfaces.NewFlakyDetectionAdapter(f.flakyDetectionService, f.logger)
@@ -224,15 +364,131 @@ func (f *DomainFactory) initIntegrationsDomain() {
 	// Create JIRA client
 	jiraClient := integrations.NewDefaultJiraClient()
 
-	// Get encryption key from config (or generate one)
-	// For now, use a placeholder - in production this should come from secure config
-	encryptionKey := []byte("your-32-byte-encryption-key-here") // TODO: Load from secure config
-
 	// Create service
 	f.jiraConnectionService = integrations.NewJiraConnectionService(
 		jiraConnRepo,
 		jiraClient,
-		encryptionKey,
+		f.keyRing,
+	)
+	f.jiraConnectionService.EnableFieldMapping(integrationsInfra.NewGormJiraFieldMappingRepository(f.db))
+
+	// Connections to any registered issue tracker share the credential encryption
+	f.trackerConnectionService = integrations.NewTrackerConnectionService(
+		integrationsInfra.NewGormTrackerConnectionRepository(f.db),
+		integrations.DefaultConnectorRegistry(),
+		f.keyRing,
+	)
+
+	f.reencryptionService = integrations.NewCredentialReencryptionService(
+		integrationsInfra.NewGormEncryptedCredentialRepository(f.db),
+		f.keyRing,
+	)
+
+	f.webhookService = integrations.NewWebhookService(
+		integrationsInfra.NewGormWebhookSubscriptionRepository(f.db),
+		integrationsInfra.NewGormWebhookDeliveryRepository(f.db),
+		f.keyRing,
+	)
+
+	f.notificationService = integrations.NewNotificationService(
+		integrationsInfra.NewGormNotificationRuleRepository(f.db),
+		integrationsInfra.NewGormNotificationDeliveryRepository(f.db),
+		f.keyRing,
+	)
+
+	f.vcsReportService = integrations.NewVCSReportService(
+		integrationsInfra.NewGormVCSConnectionRepository(f.db),
+		integrationsInfra.NewGormVCSReportRepository(f.db),
+		f.keyRing,
+	)
+}
+
+// EnableEventPublishing publishes test run and flaky test events to webhook
+// subscribers and notification rules, and reports test runs of commits to
+// GitHub and GitLab. Payloads, messages and reports link back to pages under
+// fernURL. Call it before EnableJiraIssueCreation so flaky tests resolved by
+// the JIRA sync are published too.
+func (f *DomainFactory) EnableEventPublishing(fernURL string) {
+	flakyTestRepo := testingInfra.NewGormFlakyTestRepository(f.db)
+	f.vcsReportService.EnableReporting(integrationsInfra.NewTestResultVCSReportSource(
+		f.db,
+		testingInfra.NewGormTestRunRepository(f.db),
+		flakyTestRepo,
+		fernURL,
+	))
+
+	f.eventPublisher = integrationsInfra.NewIntegrationEventPublisher(
+		f.webhookService,
+		f.notificationService,
+		f.vcsReportService,
+		flakyTestRepo,
+		fernURL,
+		f.logger,
+	)
+	f.testRunService.SetEventPublisher(f.eventPublisher)
+	f.flakyLifecycleService.SetEventPublisher(f.eventPublisher)
+	f.flakyDetectionService.SetEventPublisher(f.eventPublisher)
+}
+
+// EnableMetrics records the test data ingested per project and how long
+// flaky test analyses take in the metrics registry
+func (f *DomainFactory) EnableMetrics(registry *metrics.Registry) {
+	f.testRunService.SetIngestionRecorder(registry)
+	f.flakyDetectionService.SetAnalysisRecorder(registry)
+	f.flakyLifecycleService.SetAnalysisRecorder(registry)
+}
+
+// EnableJiraOAuth allows JIRA connections to be authorized through the
+// configured Atlassian OAuth 2.0 (3LO) app
+func (f *DomainFactory) EnableJiraOAuth(oauthConfig config.JiraOAuthConfig) error {
+	provider, err := integrations.NewJiraOAuthProvider(integrations.JiraOAuthSettings{
+		ClientID:     oauthConfig.ClientID,
+		ClientSecret: oauthConfig.ClientSecret,
+		RedirectURL:  oauthConfig.RedirectURL,
+		Scopes:       oauthConfig.Scopes,
+		AuthorizeURL: oauthConfig.AuthURL,
+		TokenURL:     oauthConfig.TokenURL,
+		ResourcesURL: oauthConfig.ResourcesURL,
+		APIURL:       oauthConfig.APIURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure JIRA OAuth: %w", err)
+	}
+	f.jiraConnectionService.EnableOAuth(provider)
+	return nil
+}
+
+// EnableJiraIssueCreation allows filing JIRA issues from test results and
+// syncing them back to flaky tests. Filed issues link back to pages under fernURL.
+func (f *DomainFactory) EnableJiraIssueCreation(fernURL string) {
+	var flakyTestRepo testingDomain.FlakyTestRepository = testingInfra.NewGormFlakyTestRepository(f.db)
+	if f.eventPublisher != nil {
+		flakyTestRepo = testingApp.NewPublishingFlakyTestRepository(flakyTestRepo, f.eventPublisher)
+	}
+	f.jiraConnectionService.EnableIssueCreation(
+		integrationsInfra.NewGormJiraIssueLinkRepository(f.db),
+		integrationsInfra.NewTestResultIssueSourceProvider(
+			testingInfra.NewGormSpecRunRepository(f.db),
+			flakyTestRepo,
+			fernURL,
+		),
+	)
+
+	// Re-flakes are commented on unless the project asks for closed issues to be reopened
+	f.jiraConnectionService.EnableIssueSync(
+		integrationsInfra.NewTestResultFlakyTestTracker(flakyTestRepo, fernURL),
+		func(ctx context.Context, projectID string) bool {
+			project, err := f.projectService.GetProject(ctx, projectsDomain.ProjectID(projectID))
+			if err != nil {
+				return false
+			}
+			settings, err := project.JiraSyncSettings()
+			if err != nil {
+				return false
+			}
+			return settings.EffectiveReflakeAction() == projectsDomain.ReflakeActionReopen
+		},
+		integrations.DefaultIssueSyncOptions(),
 	)
 }
 
@@ -240,3 +496,67 @@ func (f *DomainFactory) initIntegrationsDomain() {
 func (f *DomainFactory) GetJiraConnectionService() *integrations.JiraConnectionService {
 	return f.jiraConnectionService
 }
+
+// GetTrackerConnectionService returns the issue tracker connection service
+func (f *DomainFactory) GetTrackerConnectionService() *integrations.TrackerConnectionService {
+	return f.trackerConnectionService
+}
+
+// GetCredentialReencryptionService returns the credential re-encryption service
+func (f *DomainFactory) GetCredentialReencryptionService() *integrations.CredentialReencryptionService {
+	return f.reencryptionService
+}
+
+// GetWebhookService returns the webhook service
+func (f *DomainFactory) GetWebhookService() *integrations.WebhookService {
+	return f.webhookService
+}
+
+// GetNotificationService returns the chat notification service
+func (f *DomainFactory) GetNotificationService() *integrations.NotificationService {
+	return f.notificationService
+}
+
+// GetVCSReportService returns the GitHub and GitLab report service
+func (f *DomainFactory) GetVCSReportService() *integrations.VCSReportService {
+	return f.vcsReportService
+}
+
+// EnableEmailDigests sends users scheduled email digests of the projects they
+// favorite or manage through the configured SMTP server. Digests link back
+// to pages under fernURL.
+func (f *DomainFactory) EnableEmailDigests(emailConfig config.EmailConfig, fernURL string) (*integrations.DigestService, error) {
+	sender, err := integrations.NewSMTPSender(integrations.SMTPSettings{
+		Host:     emailConfig.SMTP.Host,
+		Port:     emailConfig.SMTP.Port,
+		Username: emailConfig.SMTP.Username,
+		Password: emailConfig.SMTP.Password,
+		From:     emailConfig.SMTP.From,
+		TLS:      integrations.SMTPTLSMode(emailConfig.SMTP.TLS),
+		Timeout:  emailConfig.SMTP.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SMTP: %w", err)
+	}
+	schedule, err := integrations.NewDigestSchedule(emailConfig.Digest.Weekday, emailConfig.Digest.Hour, emailConfig.Digest.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure digest schedule: %w", err)
+	}
+
+	f.digestService = integrations.NewDigestService(
+		integrationsInfra.NewTestResultDigestSource(f.db, fernURL),
+		sender,
+		integrationsInfra.NewGormDigestDeliveryRepository(f.db),
+		integrationsInfra.NewGormDigestPreferenceRepository(f.db),
+		f.keyRing,
+		schedule,
+		fernURL,
+	)
+	return f.digestService, nil
+}
+
+// GetDigestService returns the email digest service, or nil when digests are
+// not enabled
+func (f *DomainFactory) GetDigestService() *integrations.DigestService {
+	return f.digestService
+}
@@ -0,0 +1,263 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConnectorType identifies an issue tracker a connector talks to
+type ConnectorType string
+
+const (
+	// ConnectorTypeJira connects to a JIRA instance over its REST API
+	ConnectorTypeJira ConnectorType = "jira"
+	// ConnectorTypeGitHub connects to the issues of a GitHub repository
+	ConnectorTypeGitHub ConnectorType = "github"
+	// ConnectorTypeLinear connects to a Linear team over its GraphQL API
+	ConnectorTypeLinear ConnectorType = "linear"
+)
+
+// ErrUnknownConnectorType is returned when no connector is registered for a type
+var ErrUnknownConnectorType = errors.New("unknown connector type")
+
+// ErrInvalidConnectorConfig is returned when a connection's config does not match its connector's schema
+var ErrInvalidConnectorConfig = errors.New("invalid connector config")
+
+// IssueState groups the workflow statuses of every tracker into a common set
+type IssueState string
+
+const (
+	// IssueStateOpen is an issue nobody has started on
+	IssueStateOpen IssueState = "open"
+	// IssueStateInProgress is an issue being worked on
+	IssueStateInProgress IssueState = "in_progress"
+	// IssueStateDone is a closed, resolved or canceled issue
+	IssueStateDone IssueState = "done"
+)
+
+// Connector is the set of operations Fern performs against an issue tracker.
+// A connector is bound to one tracker project, repository or team and its credential.
+type Connector interface {
+	// TestConnection checks that the tracker is reachable and the credential can access it
+	TestConnection(ctx context.Context) error
+	// CreateIssue files a new issue
+	CreateIssue(ctx context.Context, issue TrackerIssueRequest) (*TrackerIssue, error)
+	// GetIssueStatus returns the workflow state of an issue
+	GetIssueStatus(ctx context.Context, issueKey string) (*TrackerIssueStatus, error)
+	// AddComment adds a comment to an issue
+	AddComment(ctx context.Context, issueKey, body string) error
+}
+
+// TrackerIssueRequest describes an issue to file in any tracker
+type TrackerIssueRequest struct {
+	Title       string
+	Description string
+	Labels      []string
+}
+
+// TrackerIssue is an issue filed through a connector
+type TrackerIssue struct {
+	ID string
+	// Key is what the tracker shows users, such as FERN-12, ENG-7 or 42 for GitHub
+	Key string
+	URL string
+}
+
+// TrackerIssueStatus is the workflow state of an issue
+type TrackerIssueStatus struct {
+	Key string
+	// Status is the tracker's own name for the state, such as "In Review"
+	Status     string
+	State      IssueState
+	ResolvedAt *time.Time
+}
+
+// IsDone returns true when the issue is closed, resolved or canceled
+func (s TrackerIssueStatus) IsDone() bool {
+	return s.State == IssueStateDone
+}
+
+// ConnectorConfig holds the non-secret settings of a connection, keyed by config field
+type ConnectorConfig map[string]string
+
+// ConfigFieldType is the kind of value a config field holds
+type ConfigFieldType string
+
+const (
+	// ConfigFieldTypeString is free text
+	ConfigFieldTypeString ConfigFieldType = "string"
+	// ConfigFieldTypeURL is an http or https URL
+	ConfigFieldTypeURL ConfigFieldType = "url"
+	// ConfigFieldTypeSelect is one of the field's options
+	ConfigFieldTypeSelect ConfigFieldType = "select"
+)
+
+// ConfigField describes one setting of a connector type
+type ConfigField struct {
+	Key         string
+	Label       string
+	Description string
+	Type        ConfigFieldType
+	Required    bool
+	// Default is used when the setting is left empty
+	Default string
+	// Options lists the accepted values of select fields
+	Options []string
+}
+
+// ConnectorFactory creates a connector from a validated config and a decrypted credential
+type ConnectorFactory func(config ConnectorConfig, credential string) (Connector, error)
+
+// ConnectorDefinition registers a connector type: its config schema and how to build it
+type ConnectorDefinition struct {
+	Type        ConnectorType
+	DisplayName string
+	Description string
+	// ConfigFields is the schema of the settings stored with a connection
+	ConfigFields []ConfigField
+	// CredentialLabel describes the secret the connector authenticates with
+	CredentialLabel string
+	New             ConnectorFactory
+}
+
+// ApplyDefaults returns a copy of config with defaults filled in for empty fields
+func (d ConnectorDefinition) ApplyDefaults(config ConnectorConfig) ConnectorConfig {
+	applied := make(ConnectorConfig, len(config))
+	for key, value := range config {
+		applied[key] = strings.TrimSpace(value)
+	}
+	for _, field := range d.ConfigFields {
+		if applied[field.Key] == "" && field.Default != "" {
+			applied[field.Key] = field.Default
+		}
+	}
+	return applied
+}
+
+// ValidateConfig checks config against the schema after applying defaults
+func (d ConnectorDefinition) ValidateConfig(config ConnectorConfig) error {
+	config = d.ApplyDefaults(config)
+
+	known := make(map[string]bool, len(d.ConfigFields))
+	for _, field := range d.ConfigFields {
+		known[field.Key] = true
+		value := config[field.Key]
+		if value == "" {
+			if field.Required {
+				return fmt.Errorf("%w: %s is required", ErrInvalidConnectorConfig, field.Key)
+			}
+			continue
+		}
+
+		switch field.Type {
+		case ConfigFieldTypeURL:
+			if !isValidJiraURL(value) {
+				return fmt.Errorf("%w: %s must start with http:// or https://", ErrInvalidConnectorConfig, field.Key)
+			}
+		case ConfigFieldTypeSelect:
+			valid := false
+			for _, option := range field.Options {
+				if value == option {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("%w: %s must be one of %s", ErrInvalidConnectorConfig, field.Key, strings.Join(field.Options, ", "))
+			}
+		}
+	}
+
+	unknown := make([]string, 0)
+	for key := range config {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown settings %s", ErrInvalidConnectorConfig, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// ConnectorRegistry holds the connector types available to connections
+type ConnectorRegistry struct {
+	definitions map[ConnectorType]ConnectorDefinition
+	order       []ConnectorType
+}
+
+// NewConnectorRegistry creates an empty connector registry
+func NewConnectorRegistry() *ConnectorRegistry {
+	return &ConnectorRegistry{
+		definitions: make(map[ConnectorType]ConnectorDefinition),
+	}
+}
+
+// DefaultConnectorRegistry creates a registry with the JIRA, GitHub Issues and Linear connectors
+func DefaultConnectorRegistry() *ConnectorRegistry {
+	registry := NewConnectorRegistry()
+	for _, definition := range []ConnectorDefinition{
+		JiraConnectorDefinition(NewDefaultJiraClient()),
+		GitHubConnectorDefinition(),
+		LinearConnectorDefinition(),
+	} {
+		// The built-in definitions are complete and unique
+		_ = registry.Register(definition)
+	}
+	return registry
+}
+
+// Register adds a connector type
+func (r *ConnectorRegistry) Register(definition ConnectorDefinition) error {
+	if definition.Type == "" {
+		return errors.New("connector type is required")
+	}
+	if definition.New == nil {
+		return fmt.Errorf("connector %s has no factory", definition.Type)
+	}
+	if _, exists := r.definitions[definition.Type]; exists {
+		return fmt.Errorf("connector %s is already registered", definition.Type)
+	}
+
+	r.definitions[definition.Type] = definition
+	r.order = append(r.order, definition.Type)
+	return nil
+}
+
+// Definition returns the definition of a connector type
+func (r *ConnectorRegistry) Definition(connectorType ConnectorType) (ConnectorDefinition, error) {
+	definition, exists := r.definitions[connectorType]
+	if !exists {
+		return ConnectorDefinition{}, fmt.Errorf("%w: %s", ErrUnknownConnectorType, connectorType)
+	}
+	return definition, nil
+}
+
+// Definitions returns every registered connector type in registration order
+func (r *ConnectorRegistry) Definitions() []ConnectorDefinition {
+	definitions := make([]ConnectorDefinition, len(r.order))
+	for i, connectorType := range r.order {
+		definitions[i] = r.definitions[connectorType]
+	}
+	return definitions
+}
+
+// NewConnector validates config and creates a connector of the given type
+func (r *ConnectorRegistry) NewConnector(connectorType ConnectorType, config ConnectorConfig, credential string) (Connector, error) {
+	definition, err := r.Definition(connectorType)
+	if err != nil {
+		return nil, err
+	}
+	if err := definition.ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	if credential == "" {
+		return nil, errors.New("credential is required")
+	}
+	return definition.New(definition.ApplyDefaults(config), credential)
+}
@@ -0,0 +1,202 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGitHubAPIURL is the REST API of github.com
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubConnectorDefinition registers GitHub Issues as a connector type
+func GitHubConnectorDefinition() ConnectorDefinition {
+	return ConnectorDefinition{
+		Type:        ConnectorTypeGitHub,
+		DisplayName: "GitHub Issues",
+		Description: "Files issues in a GitHub or GitHub Enterprise repository",
+		ConfigFields: []ConfigField{
+			{
+				Key:         "apiUrl",
+				Label:       "API URL",
+				Description: "Change for GitHub Enterprise, such as https://github.example.com/api/v3",
+				Type:        ConfigFieldTypeURL,
+				Default:     DefaultGitHubAPIURL,
+			},
+			{Key: "owner", Label: "Owner", Description: "User or organization that owns the repository", Type: ConfigFieldTypeString, Required: true},
+			{Key: "repository", Label: "Repository", Type: ConfigFieldTypeString, Required: true},
+		},
+		CredentialLabel: "Personal access token with issues read and write access",
+		New: func(config ConnectorConfig, credential string) (Connector, error) {
+			return &gitHubConnector{
+				httpClient: &http.Client{Timeout: 30 * time.Second},
+				apiURL:     strings.TrimRight(config["apiUrl"], "/"),
+				owner:      config["owner"],
+				repository: config["repository"],
+				token:      credential,
+			}, nil
+		},
+	}
+}
+
+// gitHubConnector files and tracks issues through the GitHub REST API
+type gitHubConnector struct {
+	httpClient *http.Client
+	apiURL     string
+	owner      string
+	repository string
+	token      string
+}
+
+// gitHubIssue is the part of a GitHub issue the connector reads
+type gitHubIssue struct {
+	ID       int64      `json:"id"`
+	Number   int        `json:"number"`
+	HTMLURL  string     `json:"html_url"`
+	State    string     `json:"state"`
+	ClosedAt *time.Time `json:"closed_at"`
+	// Assignees mark an open issue as being worked on
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+}
+
+// TestConnection checks that the token can see the repository
+func (c *gitHubConnector) TestConnection(ctx context.Context) error {
+	var repository struct {
+		HasIssues bool `json:"has_issues"`
+	}
+	if err := c.send(ctx, "GET", c.repositoryPath(""), "get repository", nil, &repository); err != nil {
+		return err
+	}
+	if !repository.HasIssues {
+		return fmt.Errorf("issues are disabled for %s/%s", c.owner, c.repository)
+	}
+	return nil
+}
+
+// CreateIssue opens an issue in the repository
+func (c *gitHubConnector) CreateIssue(ctx context.Context, issue TrackerIssueRequest) (*TrackerIssue, error) {
+	request := map[string]interface{}{
+		"title": issue.Title,
+		"body":  issue.Description,
+	}
+	if len(issue.Labels) > 0 {
+		request["labels"] = issue.Labels
+	}
+
+	var created gitHubIssue
+	if err := c.send(ctx, "POST", c.repositoryPath("/issues"), "create issue", request, &created); err != nil {
+		return nil, err
+	}
+	return &TrackerIssue{
+		ID:  strconv.FormatInt(created.ID, 10),
+		Key: strconv.Itoa(created.Number),
+		URL: created.HTMLURL,
+	}, nil
+}
+
+// GetIssueStatus reads the state of an issue by number
+func (c *gitHubConnector) GetIssueStatus(ctx context.Context, issueKey string) (*TrackerIssueStatus, error) {
+	number, err := gitHubIssueNumber(issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue gitHubIssue
+	if err := c.send(ctx, "GET", c.repositoryPath(fmt.Sprintf("/issues/%d", number)), "get issue", nil, &issue); err != nil {
+		return nil, err
+	}
+
+	status := &TrackerIssueStatus{
+		Key:    strconv.Itoa(issue.Number),
+		Status: issue.State,
+		State:  IssueStateOpen,
+	}
+	switch {
+	case issue.State == "closed":
+		status.State = IssueStateDone
+		status.ResolvedAt = issue.ClosedAt
+	case len(issue.Assignees) > 0:
+		status.State = IssueStateInProgress
+	}
+	return status, nil
+}
+
+// AddComment comments on an issue by number
+func (c *gitHubConnector) AddComment(ctx context.Context, issueKey, body string) error {
+	number, err := gitHubIssueNumber(issueKey)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "POST", c.repositoryPath(fmt.Sprintf("/issues/%d/comments", number)), "add comment", map[string]string{"body": body}, nil)
+}
+
+// repositoryPath returns the API URL of a resource of the repository
+func (c *gitHubConnector) repositoryPath(path string) string {
+	return fmt.Sprintf("%s/repos/%s/%s%s", c.apiURL, neturl.PathEscape(c.owner), neturl.PathEscape(c.repository), path)
+}
+
+// send makes an authenticated request, encoding body when it is not nil and
+// decoding the JSON response into out when it is not nil
+func (c *gitHubConnector) send(ctx context.Context, method, endpoint, action string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "Fern-Platform/1.0")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorBody struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err == nil && errorBody.Message != "" {
+			return fmt.Errorf("failed to %s: status %d, message: %s", action, resp.StatusCode, errorBody.Message)
+		}
+		return fmt.Errorf("failed to %s: status %d", action, resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", action, err)
+		}
+	}
+	return nil
+}
+
+// gitHubIssueNumber parses an issue key such as 42 or #42
+func gitHubIssueNumber(issueKey string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(issueKey, "#"))
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid GitHub issue number %q", issueKey)
+	}
+	return number, nil
+}
@@ -0,0 +1,158 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// jiraIssueKeyPattern matches JIRA issue keys such as FERN-12
+var jiraIssueKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*-[0-9]+$`)
+
+// JiraConnectorDefinition registers JIRA as a connector type backed by client
+func JiraConnectorDefinition(client JiraClient) ConnectorDefinition {
+	return ConnectorDefinition{
+		Type:        ConnectorTypeJira,
+		DisplayName: "JIRA",
+		Description: "Files issues in a JIRA Cloud or Server project",
+		ConfigFields: []ConfigField{
+			{Key: "url", Label: "JIRA URL", Type: ConfigFieldTypeURL, Required: true},
+			{Key: "projectKey", Label: "Project key", Type: ConfigFieldTypeString, Required: true},
+			{Key: "username", Label: "Username", Description: "Account email, required for API tokens", Type: ConfigFieldTypeString},
+			{
+				Key:     "authenticationType",
+				Label:   "Authentication type",
+				Type:    ConfigFieldTypeSelect,
+				Default: string(AuthTypeAPIToken),
+				Options: []string{string(AuthTypeAPIToken), string(AuthTypePersonalAccessToken), string(AuthTypeOAuth)},
+			},
+			{Key: "issueType", Label: "Issue type", Type: ConfigFieldTypeString, Default: DefaultIssueType},
+		},
+		CredentialLabel: "API token or personal access token",
+		New: func(config ConnectorConfig, credential string) (Connector, error) {
+			authType := AuthenticationType(config["authenticationType"])
+			if authType == AuthTypeAPIToken && config["username"] == "" {
+				return nil, fmt.Errorf("%w: username is required for API tokens", ErrInvalidConnectorConfig)
+			}
+			url := strings.TrimRight(config["url"], "/")
+			return &jiraConnector{
+				client:     client,
+				url:        url,
+				apiURL:     url,
+				projectKey: config["projectKey"],
+				issueType:  config["issueType"],
+				username:   config["username"],
+				authType:   authType,
+				credential: credential,
+			}, nil
+		},
+	}
+}
+
+// jiraConnector adapts a JiraClient to the Connector interface
+type jiraConnector struct {
+	client     JiraClient
+	url        string
+	apiURL     string
+	projectKey string
+	issueType  string
+	username   string
+	authType   AuthenticationType
+	credential string
+}
+
+// TestConnection checks the credential and that the project exists
+func (c *jiraConnector) TestConnection(ctx context.Context) error {
+	if err := c.client.TestConnection(ctx, c.apiURL, c.username, c.credential, c.authType); err != nil {
+		return err
+	}
+	if _, err := c.client.GetProject(ctx, c.apiURL, c.projectKey, c.username, c.credential, c.authType); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateIssue files an issue in the configured project
+func (c *jiraConnector) CreateIssue(ctx context.Context, issue TrackerIssueRequest) (*TrackerIssue, error) {
+	created, err := c.client.CreateIssue(ctx, c.apiURL, c.username, c.credential, c.authType, JiraIssueRequest{
+		ProjectKey:  c.projectKey,
+		IssueType:   c.issueType,
+		Summary:     issue.Title,
+		Description: issue.Description,
+		Labels:      issue.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TrackerIssue{
+		ID:  created.ID,
+		Key: created.Key,
+		URL: fmt.Sprintf("%s/browse/%s", c.url, created.Key),
+	}, nil
+}
+
+// GetIssueStatus looks the issue up by key
+func (c *jiraConnector) GetIssueStatus(ctx context.Context, issueKey string) (*TrackerIssueStatus, error) {
+	if !jiraIssueKeyPattern.MatchString(issueKey) {
+		return nil, fmt.Errorf("invalid JIRA issue key %q", issueKey)
+	}
+
+	statuses, err := c.client.SearchIssues(ctx, c.apiURL, c.username, c.credential, c.authType, fmt.Sprintf("key = %s", issueKey), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("issue %s not found", issueKey)
+	}
+
+	status := statuses[0]
+	return &TrackerIssueStatus{
+		Key:        status.Key,
+		Status:     status.Status,
+		State:      jiraIssueState(status.StatusCategory),
+		ResolvedAt: status.ResolvedAt,
+	}, nil
+}
+
+// AddComment adds a comment to the issue
+func (c *jiraConnector) AddComment(ctx context.Context, issueKey, body string) error {
+	return c.client.AddComment(ctx, c.apiURL, c.username, c.credential, c.authType, issueKey, body)
+}
+
+// jiraIssueState maps a JIRA status category onto an issue state
+func jiraIssueState(statusCategory string) IssueState {
+	switch statusCategory {
+	case JiraStatusCategoryDone:
+		return IssueStateDone
+	case JiraStatusCategoryInProgress:
+		return IssueStateInProgress
+	default:
+		return IssueStateOpen
+	}
+}
+
+// Connector returns a connector for an existing JIRA connection so it can be
+// used wherever any issue tracker is accepted
+func (s *JiraConnectionService) Connector(ctx context.Context, connectionID string) (Connector, error) {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	apiURL, credential, err := s.credentialFor(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jiraConnector{
+		client:     s.jiraClient,
+		url:        conn.jiraURL,
+		apiURL:     apiURL,
+		projectKey: conn.projectKey,
+		issueType:  DefaultIssueType,
+		username:   conn.username,
+		authType:   conn.authenticationType,
+		credential: credential,
+	}, nil
+}
@@ -0,0 +1,276 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultLinearAPIURL is the GraphQL endpoint of Linear
+const DefaultLinearAPIURL = "https://api.linear.app/graphql"
+
+// LinearConnectorDefinition registers Linear as a connector type
+func LinearConnectorDefinition() ConnectorDefinition {
+	return ConnectorDefinition{
+		Type:        ConnectorTypeLinear,
+		DisplayName: "Linear",
+		Description: "Files issues in a Linear team",
+		ConfigFields: []ConfigField{
+			{Key: "apiUrl", Label: "GraphQL API URL", Type: ConfigFieldTypeURL, Default: DefaultLinearAPIURL},
+			{Key: "teamId", Label: "Team ID", Description: "ID of the team issues are filed in", Type: ConfigFieldTypeString, Required: true},
+		},
+		CredentialLabel: "Personal API key",
+		New: func(config ConnectorConfig, credential string) (Connector, error) {
+			return &linearConnector{
+				httpClient: &http.Client{Timeout: 30 * time.Second},
+				apiURL:     config["apiUrl"],
+				teamID:     config["teamId"],
+				apiKey:     credential,
+			}, nil
+		},
+	}
+}
+
+// linearConnector files and tracks issues through the Linear GraphQL API
+type linearConnector struct {
+	httpClient *http.Client
+	apiURL     string
+	teamID     string
+	apiKey     string
+}
+
+// linearIssue is the part of a Linear issue the connector reads
+type linearIssue struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	URL        string `json:"url"`
+	State      struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+	CompletedAt *time.Time `json:"completedAt"`
+	CanceledAt  *time.Time `json:"canceledAt"`
+}
+
+// TestConnection checks the API key and that the team exists
+func (c *linearConnector) TestConnection(ctx context.Context) error {
+	var data struct {
+		Viewer struct {
+			ID string `json:"id"`
+		} `json:"viewer"`
+		Team *struct {
+			ID string `json:"id"`
+		} `json:"team"`
+	}
+	query := `query TestConnection($teamId: String!) { viewer { id } team(id: $teamId) { id } }`
+	if err := c.query(ctx, query, map[string]interface{}{"teamId": c.teamID}, "test connection", &data); err != nil {
+		return err
+	}
+	if data.Team == nil {
+		return fmt.Errorf("team %s not found", c.teamID)
+	}
+	return nil
+}
+
+// CreateIssue files an issue in the team. Labels are matched by name against
+// the team's labels; names the team does not have are skipped.
+func (c *linearConnector) CreateIssue(ctx context.Context, issue TrackerIssueRequest) (*TrackerIssue, error) {
+	input := map[string]interface{}{
+		"teamId":      c.teamID,
+		"title":       issue.Title,
+		"description": issue.Description,
+	}
+	if len(issue.Labels) > 0 {
+		labelIDs, err := c.labelIDs(ctx, issue.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if len(labelIDs) > 0 {
+			input["labelIds"] = labelIDs
+		}
+	}
+
+	var data struct {
+		IssueCreate struct {
+			Success bool        `json:"success"`
+			Issue   linearIssue `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	query := `mutation CreateIssue($input: IssueCreateInput!) { issueCreate(input: $input) { success issue { id identifier url } } }`
+	if err := c.query(ctx, query, map[string]interface{}{"input": input}, "create issue", &data); err != nil {
+		return nil, err
+	}
+	if !data.IssueCreate.Success {
+		return nil, errors.New("failed to create issue: Linear did not create it")
+	}
+
+	created := data.IssueCreate.Issue
+	return &TrackerIssue{
+		ID:  created.ID,
+		Key: created.Identifier,
+		URL: created.URL,
+	}, nil
+}
+
+// GetIssueStatus reads the workflow state of an issue by identifier
+func (c *linearConnector) GetIssueStatus(ctx context.Context, issueKey string) (*TrackerIssueStatus, error) {
+	issue, err := c.issue(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TrackerIssueStatus{
+		Key:    issue.Identifier,
+		Status: issue.State.Name,
+		State:  linearIssueState(issue.State.Type),
+	}
+	if status.State == IssueStateDone {
+		status.ResolvedAt = issue.CompletedAt
+		if status.ResolvedAt == nil {
+			status.ResolvedAt = issue.CanceledAt
+		}
+	}
+	return status, nil
+}
+
+// AddComment comments on an issue by identifier
+func (c *linearConnector) AddComment(ctx context.Context, issueKey, body string) error {
+	// Comments are created against the issue's ID rather than its identifier
+	issue, err := c.issue(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+
+	var data struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+	query := `mutation AddComment($input: CommentCreateInput!) { commentCreate(input: $input) { success } }`
+	input := map[string]interface{}{"issueId": issue.ID, "body": body}
+	if err := c.query(ctx, query, map[string]interface{}{"input": input}, "add comment", &data); err != nil {
+		return err
+	}
+	if !data.CommentCreate.Success {
+		return errors.New("failed to add comment: Linear did not create it")
+	}
+	return nil
+}
+
+// issue loads an issue by ID or identifier
+func (c *linearConnector) issue(ctx context.Context, issueKey string) (*linearIssue, error) {
+	var data struct {
+		Issue *linearIssue `json:"issue"`
+	}
+	query := `query Issue($id: String!) { issue(id: $id) { id identifier url state { name type } completedAt canceledAt } }`
+	if err := c.query(ctx, query, map[string]interface{}{"id": issueKey}, "get issue", &data); err != nil {
+		return nil, err
+	}
+	if data.Issue == nil {
+		return nil, fmt.Errorf("issue %s not found", issueKey)
+	}
+	return data.Issue, nil
+}
+
+// labelIDs resolves label names to the IDs of the team's labels
+func (c *linearConnector) labelIDs(ctx context.Context, names []string) ([]string, error) {
+	var data struct {
+		Team *struct {
+			Labels struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"labels"`
+		} `json:"team"`
+	}
+	query := `query TeamLabels($teamId: String!) { team(id: $teamId) { labels(first: 250) { nodes { id name } } } }`
+	if err := c.query(ctx, query, map[string]interface{}{"teamId": c.teamID}, "get labels", &data); err != nil {
+		return nil, err
+	}
+	if data.Team == nil {
+		return nil, fmt.Errorf("team %s not found", c.teamID)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[strings.ToLower(name)] = true
+	}
+	ids := make([]string, 0, len(names))
+	for _, label := range data.Team.Labels.Nodes {
+		if wanted[strings.ToLower(label.Name)] {
+			ids = append(ids, label.ID)
+		}
+	}
+	return ids, nil
+}
+
+// query sends a GraphQL request and decodes its data into out
+func (c *linearConnector) query(ctx context.Context, query string, variables map[string]interface{}, action string, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	// Personal API keys are sent as is; OAuth tokens carry their own Bearer prefix
+	req.Header.Set("Authorization", c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fern-Platform/1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Linear: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&response)
+	if len(response.Errors) > 0 {
+		messages := make([]string, len(response.Errors))
+		for i, graphqlErr := range response.Errors {
+			messages[i] = graphqlErr.Message
+		}
+		return fmt.Errorf("failed to %s: %s", action, strings.Join(messages, "; "))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %s: status %d", action, resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to parse %s response: %w", action, decodeErr)
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", action, err)
+	}
+	return nil
+}
+
+// linearIssueState maps a Linear workflow state type onto an issue state
+func linearIssueState(stateType string) IssueState {
+	switch stateType {
+	case "completed", "canceled":
+		return IssueStateDone
+	case "started":
+		return IssueStateInProgress
+	default:
+		// triage, backlog and unstarted
+		return IssueStateOpen
+	}
+}
@@ -0,0 +1,405 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubConnector records the config and credential it was built with
+type stubConnector struct {
+	config     integrations.ConnectorConfig
+	credential string
+	testErr    error
+	comments   map[string][]string
+}
+
+func (c *stubConnector) TestConnection(ctx context.Context) error {
+	return c.testErr
+}
+
+func (c *stubConnector) CreateIssue(ctx context.Context, issue integrations.TrackerIssueRequest) (*integrations.TrackerIssue, error) {
+	return &integrations.TrackerIssue{ID: "1", Key: "STUB-1", URL: "https://tracker.example.com/STUB-1"}, nil
+}
+
+func (c *stubConnector) GetIssueStatus(ctx context.Context, issueKey string) (*integrations.TrackerIssueStatus, error) {
+	return &integrations.TrackerIssueStatus{Key: issueKey, Status: "Done", State: integrations.IssueStateDone}, nil
+}
+
+func (c *stubConnector) AddComment(ctx context.Context, issueKey, body string) error {
+	if c.comments == nil {
+		c.comments = make(map[string][]string)
+	}
+	c.comments[issueKey] = append(c.comments[issueKey], body)
+	return nil
+}
+
+func stubDefinition(built **stubConnector) integrations.ConnectorDefinition {
+	return integrations.ConnectorDefinition{
+		Type:        "stub",
+		DisplayName: "Stub",
+		ConfigFields: []integrations.ConfigField{
+			{Key: "url", Type: integrations.ConfigFieldTypeURL, Required: true},
+			{Key: "team", Type: integrations.ConfigFieldTypeString, Default: "core"},
+			{Key: "mode", Type: integrations.ConfigFieldTypeSelect, Options: []string{"fast", "safe"}},
+		},
+		New: func(config integrations.ConnectorConfig, credential string) (integrations.Connector, error) {
+			connector := &stubConnector{config: config, credential: credential}
+			if built != nil {
+				*built = connector
+			}
+			return connector, nil
+		},
+	}
+}
+
+func TestConnectorRegistry(t *testing.T) {
+	t.Run("registers the built-in connectors in order", func(t *testing.T) {
+		registry := integrations.DefaultConnectorRegistry()
+
+		var types []integrations.ConnectorType
+		for _, definition := range registry.Definitions() {
+			types = append(types, definition.Type)
+			assert.NotEmpty(t, definition.DisplayName)
+			assert.NotEmpty(t, definition.ConfigFields)
+		}
+		assert.Equal(t, []integrations.ConnectorType{
+			integrations.ConnectorTypeJira,
+			integrations.ConnectorTypeGitHub,
+			integrations.ConnectorTypeLinear,
+		}, types)
+	})
+
+	t.Run("rejects duplicate and incomplete definitions", func(t *testing.T) {
+		registry := integrations.NewConnectorRegistry()
+		require.NoError(t, registry.Register(stubDefinition(nil)))
+
+		assert.Error(t, registry.Register(stubDefinition(nil)))
+		assert.Error(t, registry.Register(integrations.ConnectorDefinition{Type: "empty"}))
+		assert.Error(t, registry.Register(integrations.ConnectorDefinition{}))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		registry := integrations.NewConnectorRegistry()
+		_, err := registry.NewConnector("missing", nil, "secret")
+		assert.True(t, errors.Is(err, integrations.ErrUnknownConnectorType))
+	})
+
+	t.Run("builds connectors with defaults applied", func(t *testing.T) {
+		registry := integrations.NewConnectorRegistry()
+		var built *stubConnector
+		require.NoError(t, registry.Register(stubDefinition(&built)))
+
+		_, err := registry.NewConnector("stub", integrations.ConnectorConfig{"url": " https://tracker.example.com "}, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, integrations.ConnectorConfig{"url": "https://tracker.example.com", "team": "core"}, built.config)
+		assert.Equal(t, "secret", built.credential)
+
+		_, err = registry.NewConnector("stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestConnectorDefinition_ValidateConfig(t *testing.T) {
+	definition := stubDefinition(nil)
+
+	tests := []struct {
+		name    string
+		config  integrations.ConnectorConfig
+		wantErr string
+	}{
+		{name: "valid", config: integrations.ConnectorConfig{"url": "https://tracker.example.com", "mode": "safe"}},
+		{name: "missing required", config: integrations.ConnectorConfig{"team": "core"}, wantErr: "url is required"},
+		{name: "invalid URL", config: integrations.ConnectorConfig{"url": "tracker.example.com"}, wantErr: "url must start with"},
+		{name: "invalid option", config: integrations.ConnectorConfig{"url": "https://tracker.example.com", "mode": "slow"}, wantErr: "mode must be one of fast, safe"},
+		{name: "unknown setting", config: integrations.ConnectorConfig{"url": "https://tracker.example.com", "token": "x"}, wantErr: "unknown settings token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := definition.ValidateConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, integrations.ErrInvalidConnectorConfig))
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGitHubConnector(t *testing.T) {
+	var requests []string
+	var createdIssue map[string]interface{}
+	var comment map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer gh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/widgets":
+			_, _ = w.Write([]byte(`{"full_name":"acme/widgets","has_issues":true}`))
+		case "POST /repos/acme/widgets/issues":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&createdIssue))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":9001,"number":42,"html_url":"https://github.com/acme/widgets/issues/42","state":"open"}`))
+		case "GET /repos/acme/widgets/issues/42":
+			_, _ = w.Write([]byte(`{"id":9001,"number":42,"state":"closed","closed_at":"2026-03-01T10:00:00Z"}`))
+		case "GET /repos/acme/widgets/issues/43":
+			_, _ = w.Write([]byte(`{"id":9002,"number":43,"state":"open","assignees":[{"login":"octocat"}]}`))
+		case "POST /repos/acme/widgets/issues/42/comments":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	registry := integrations.DefaultConnectorRegistry()
+	config := integrations.ConnectorConfig{"apiUrl": server.URL, "owner": "acme", "repository": "widgets"}
+	connector, err := registry.NewConnector(integrations.ConnectorTypeGitHub, config, "gh-token")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, connector.TestConnection(ctx))
+
+	issue, err := connector.CreateIssue(ctx, integrations.TrackerIssueRequest{
+		Title:       "Flaky test: checkout",
+		Description: "Fails 20% of the time",
+		Labels:      []string{"fern", "flaky-test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "42", issue.Key)
+	assert.Equal(t, "9001", issue.ID)
+	assert.Equal(t, "https://github.com/acme/widgets/issues/42", issue.URL)
+	assert.Equal(t, "Flaky test: checkout", createdIssue["title"])
+	assert.Equal(t, "Fails 20% of the time", createdIssue["body"])
+	assert.Equal(t, []interface{}{"fern", "flaky-test"}, createdIssue["labels"])
+
+	status, err := connector.GetIssueStatus(ctx, "#42")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.IssueStateDone, status.State)
+	assert.True(t, status.IsDone())
+	require.NotNil(t, status.ResolvedAt)
+	assert.Equal(t, 2026, status.ResolvedAt.Year())
+
+	status, err = connector.GetIssueStatus(ctx, "43")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.IssueStateInProgress, status.State)
+
+	require.NoError(t, connector.AddComment(ctx, "42", "Flaked again"))
+	assert.Equal(t, "Flaked again", comment["body"])
+
+	_, err = connector.GetIssueStatus(ctx, "not-a-number")
+	assert.Error(t, err)
+
+	_, err = connector.GetIssueStatus(ctx, "7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Not Found")
+
+	badToken, err := registry.NewConnector(integrations.ConnectorTypeGitHub, config, "wrong")
+	require.NoError(t, err)
+	err = badToken.TestConnection(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Bad credentials")
+}
+
+// linearServer is a GraphQL stand-in for the parts of the Linear API the connector uses
+type linearServer struct {
+	*httptest.Server
+	operations []string
+	inputs     []map[string]interface{}
+}
+
+func newLinearServer(t *testing.T) *linearServer {
+	s := &linearServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		operation := strings.Fields(request.Query)[1]
+		if i := strings.Index(operation, "("); i >= 0 {
+			operation = operation[:i]
+		}
+		s.operations = append(s.operations, operation)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "lin_api_key" {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Authentication required"}]}`))
+			return
+		}
+
+		switch operation {
+		case "TestConnection":
+			if request.Variables["teamId"] == "team-1" {
+				_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"},"team":{"id":"team-1"}}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"},"team":null}}`))
+			}
+		case "TeamLabels":
+			_, _ = w.Write([]byte(`{"data":{"team":{"labels":{"nodes":[{"id":"label-1","name":"Fern"},{"id":"label-2","name":"Bug"}]}}}}`))
+		case "CreateIssue":
+			s.inputs = append(s.inputs, request.Variables["input"].(map[string]interface{}))
+			_, _ = w.Write([]byte(`{"data":{"issueCreate":{"success":true,"issue":{"id":"issue-uuid","identifier":"ENG-7","url":"https://linear.app/acme/issue/ENG-7"}}}}`))
+		case "Issue":
+			switch request.Variables["id"] {
+			case "ENG-7":
+				_, _ = w.Write([]byte(`{"data":{"issue":{"id":"issue-uuid","identifier":"ENG-7","state":{"name":"Done","type":"completed"},"completedAt":"2026-04-02T08:00:00.000Z"}}}`))
+			case "ENG-8":
+				_, _ = w.Write([]byte(`{"data":{"issue":{"id":"issue-uuid-8","identifier":"ENG-8","state":{"name":"In Review","type":"started"}}}}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":{"issue":null},"errors":[{"message":"Entity not found: Issue"}]}`))
+			}
+		case "AddComment":
+			s.inputs = append(s.inputs, request.Variables["input"].(map[string]interface{}))
+			_, _ = w.Write([]byte(`{"data":{"commentCreate":{"success":true}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Unknown operation"}]}`))
+		}
+	}))
+	return s
+}
+
+func TestLinearConnector(t *testing.T) {
+	server := newLinearServer(t)
+	defer server.Close()
+
+	registry := integrations.DefaultConnectorRegistry()
+	config := integrations.ConnectorConfig{"apiUrl": server.URL, "teamId": "team-1"}
+	connector, err := registry.NewConnector(integrations.ConnectorTypeLinear, config, "lin_api_key")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, connector.TestConnection(ctx))
+
+	issue, err := connector.CreateIssue(ctx, integrations.TrackerIssueRequest{
+		Title:       "Failing test: login",
+		Description: "Expected 200, got 500",
+		Labels:      []string{"fern", "flaky-test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-7", issue.Key)
+	assert.Equal(t, "issue-uuid", issue.ID)
+	assert.Equal(t, "https://linear.app/acme/issue/ENG-7", issue.URL)
+	require.Len(t, server.inputs, 1)
+	assert.Equal(t, "team-1", server.inputs[0]["teamId"])
+	assert.Equal(t, "Failing test: login", server.inputs[0]["title"])
+	// Only labels the team has are attached
+	assert.Equal(t, []interface{}{"label-1"}, server.inputs[0]["labelIds"])
+
+	status, err := connector.GetIssueStatus(ctx, "ENG-7")
+	require.NoError(t, err)
+	assert.Equal(t, "Done", status.Status)
+	assert.Equal(t, integrations.IssueStateDone, status.State)
+	require.NotNil(t, status.ResolvedAt)
+
+	status, err = connector.GetIssueStatus(ctx, "ENG-8")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.IssueStateInProgress, status.State)
+	assert.Nil(t, status.ResolvedAt)
+
+	require.NoError(t, connector.AddComment(ctx, "ENG-7", "Flaked again"))
+	require.Len(t, server.inputs, 2)
+	// Comments are created against the issue ID, not its identifier
+	assert.Equal(t, "issue-uuid", server.inputs[1]["issueId"])
+	assert.Equal(t, "Flaked again", server.inputs[1]["body"])
+
+	_, err = connector.GetIssueStatus(ctx, "ENG-404")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Entity not found")
+
+	otherTeam, err := registry.NewConnector(integrations.ConnectorTypeLinear, integrations.ConnectorConfig{"apiUrl": server.URL, "teamId": "team-2"}, "lin_api_key")
+	require.NoError(t, err)
+	err = otherTeam.TestConnection(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "team team-2 not found")
+
+	badKey, err := registry.NewConnector(integrations.ConnectorTypeLinear, config, "wrong")
+	require.NoError(t, err)
+	err = badKey.TestConnection(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Authentication required")
+}
+
+func TestJiraConnector(t *testing.T) {
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/api/2/myself":
+			_, _ = w.Write([]byte(`{"accountId":"1"}`))
+		case "GET /rest/api/2/project/FERN":
+			_, _ = w.Write([]byte(`{"id":"10000","key":"FERN","name":"Fern"}`))
+		case "POST /rest/api/2/issue":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"10001","key":"FERN-12","self":"x"}`))
+		case "POST /rest/api/2/search":
+			var request map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			searches = append(searches, request["jql"].(string))
+			_, _ = w.Write([]byte(`{"issues":[{"key":"FERN-12","fields":{"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}}}]}`))
+		case "POST /rest/api/2/issue/FERN-12/comment":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry := integrations.DefaultConnectorRegistry()
+	ctx := context.Background()
+
+	_, err := registry.NewConnector(integrations.ConnectorTypeJira, integrations.ConnectorConfig{"url": server.URL, "projectKey": "FERN"}, "token")
+	assert.True(t, errors.Is(err, integrations.ErrInvalidConnectorConfig), "API tokens need a username")
+
+	connector, err := registry.NewConnector(integrations.ConnectorTypeJira, integrations.ConnectorConfig{
+		"url":        server.URL,
+		"projectKey": "FERN",
+		"username":   "bot@example.com",
+	}, "token")
+	require.NoError(t, err)
+
+	require.NoError(t, connector.TestConnection(ctx))
+
+	issue, err := connector.CreateIssue(ctx, integrations.TrackerIssueRequest{Title: "Failing test"})
+	require.NoError(t, err)
+	assert.Equal(t, "FERN-12", issue.Key)
+	assert.Equal(t, server.URL+"/browse/FERN-12", issue.URL)
+
+	status, err := connector.GetIssueStatus(ctx, "FERN-12")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.IssueStateInProgress, status.State)
+	assert.Equal(t, []string{"key = FERN-12"}, searches)
+
+	_, err = connector.GetIssueStatus(ctx, "FERN-12 OR project = SECRET")
+	assert.Error(t, err)
+	assert.Len(t, searches, 1, "invalid keys are not sent to JIRA")
+
+	require.NoError(t, connector.AddComment(ctx, "FERN-12", "Flaked again"))
+
+	wrongProject, err := registry.NewConnector(integrations.ConnectorTypeJira, integrations.ConnectorConfig{
+		"url":        server.URL,
+		"projectKey": "NOPE",
+		"username":   "bot@example.com",
+	}, "token")
+	require.NoError(t, err)
+	assert.Error(t, wrongProject.TestConnection(ctx))
+}
@@ -0,0 +1,207 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Kinds of stored encrypted credentials
+const (
+	CredentialKindJiraCredential        = "jira_connection.credential"
+	CredentialKindJiraOAuthRefreshToken = "jira_connection.oauth_refresh_token"
+	CredentialKindTrackerCredential     = "tracker_connection.credential"
+	CredentialKindWebhookSecret         = "webhook_subscription.secret"
+	CredentialKindNotificationWebhook   = "notification_rule.webhook_url"
+	CredentialKindVCSToken              = "vcs_connection.token"
+)
+
+// maxReencryptionErrors caps the errors kept in the progress of a re-encryption run
+const maxReencryptionErrors = 20
+
+// ErrReencryptionRunning is returned when a re-encryption run is started while another is running
+var ErrReencryptionRunning = errors.New("credential re-encryption is already running")
+
+// EncryptedCredential is one stored encrypted credential
+type EncryptedCredential struct {
+	Kind       string
+	OwnerID    string // ID of the connection or webhook the credential belongs to
+	Ciphertext string
+}
+
+// ReencryptionStatus is the state of a re-encryption run
+type ReencryptionStatus string
+
+const (
+	ReencryptionIdle      ReencryptionStatus = "idle"
+	ReencryptionRunning   ReencryptionStatus = "running"
+	ReencryptionCompleted ReencryptionStatus = "completed"
+	ReencryptionFailed    ReencryptionStatus = "failed"
+)
+
+// ReencryptionProgress reports how far a re-encryption run got
+type ReencryptionProgress struct {
+	Status       ReencryptionStatus
+	PrimaryKeyID string
+	Total        int
+	Processed    int
+	Reencrypted  int
+	Skipped      int // Already encrypted with the primary key, or changed during the run
+	Failed       int
+	Errors       []string
+	StartedAt    *time.Time
+	FinishedAt   *time.Time
+}
+
+// CredentialReencryptionService moves every stored credential onto the
+// primary key of the key ring, so retired keys can be removed from it
+type CredentialReencryptionService struct {
+	credentials EncryptedCredentialRepository
+	keys        *KeyRing
+
+	mu       sync.Mutex
+	progress ReencryptionProgress
+}
+
+// NewCredentialReencryptionService creates a new credential re-encryption service
+func NewCredentialReencryptionService(credentials EncryptedCredentialRepository, keys *KeyRing) *CredentialReencryptionService {
+	return &CredentialReencryptionService{
+		credentials: credentials,
+		keys:        keys,
+		progress:    ReencryptionProgress{Status: ReencryptionIdle, PrimaryKeyID: keys.PrimaryKeyID()},
+	}
+}
+
+// KeyRing returns the key ring credentials are re-encrypted with
+func (s *CredentialReencryptionService) KeyRing() *KeyRing {
+	return s.keys
+}
+
+// Progress returns the progress of the current or last run
+func (s *CredentialReencryptionService) Progress() ReencryptionProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshot()
+}
+
+// Start re-encrypts all credentials in the background and returns the
+// progress of the started run
+func (s *CredentialReencryptionService) Start(ctx context.Context) (ReencryptionProgress, error) {
+	if err := s.begin(); err != nil {
+		return ReencryptionProgress{}, err
+	}
+	progress := s.Progress()
+
+	// The run outlives the request that started it
+	go s.run(context.WithoutCancel(ctx), nil)
+	return progress, nil
+}
+
+// Run re-encrypts all credentials and returns once done. onProgress, when not
+// nil, is called after each credential.
+func (s *CredentialReencryptionService) Run(ctx context.Context, onProgress func(ReencryptionProgress)) (ReencryptionProgress, error) {
+	if err := s.begin(); err != nil {
+		return ReencryptionProgress{}, err
+	}
+	return s.run(ctx, onProgress)
+}
+
+// begin resets the progress for a new run
+func (s *CredentialReencryptionService) begin() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.progress.Status == ReencryptionRunning {
+		return ErrReencryptionRunning
+	}
+	now := time.Now()
+	s.progress = ReencryptionProgress{
+		Status:       ReencryptionRunning,
+		PrimaryKeyID: s.keys.PrimaryKeyID(),
+		StartedAt:    &now,
+	}
+	return nil
+}
+
+// run re-encrypts every credential not encrypted with the primary key. A
+// credential that fails is counted and reported without stopping the run.
+func (s *CredentialReencryptionService) run(ctx context.Context, onProgress func(ReencryptionProgress)) (ReencryptionProgress, error) {
+	credentials, err := s.credentials.FindAll(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list encrypted credentials: %w", err)
+		return s.finish(err), err
+	}
+
+	s.update(func(p *ReencryptionProgress) { p.Total = len(credentials) })
+	for _, credential := range credentials {
+		if err := ctx.Err(); err != nil {
+			return s.finish(err), err
+		}
+
+		outcome, err := s.reencrypt(ctx, credential)
+		progress := s.update(func(p *ReencryptionProgress) {
+			p.Processed++
+			switch {
+			case err != nil:
+				p.Failed++
+				if len(p.Errors) < maxReencryptionErrors {
+					p.Errors = append(p.Errors, fmt.Sprintf("%s %s: %v", credential.Kind, credential.OwnerID, err))
+				}
+			case outcome:
+				p.Reencrypted++
+			default:
+				p.Skipped++
+			}
+		})
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	return s.finish(nil), nil
+}
+
+// reencrypt moves one credential onto the primary key and reports whether it was rewritten
+func (s *CredentialReencryptionService) reencrypt(ctx context.Context, credential EncryptedCredential) (bool, error) {
+	if !s.keys.NeedsReencryption(credential.Ciphertext) {
+		return false, nil
+	}
+	ciphertext, err := s.keys.Reencrypt(credential.Ciphertext)
+	if err != nil {
+		return false, err
+	}
+	// A credential updated since it was listed was written with the primary key
+	replaced, err := s.credentials.Replace(ctx, credential, ciphertext)
+	if err != nil {
+		return false, fmt.Errorf("failed to save re-encrypted credential: %w", err)
+	}
+	return replaced, nil
+}
+
+// update changes the progress and returns a copy of it
+func (s *CredentialReencryptionService) update(change func(*ReencryptionProgress)) ReencryptionProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(&s.progress)
+	return s.snapshot()
+}
+
+// finish records the end of a run
+func (s *CredentialReencryptionService) finish(err error) ReencryptionProgress {
+	return s.update(func(p *ReencryptionProgress) {
+		now := time.Now()
+		p.FinishedAt = &now
+		p.Status = ReencryptionCompleted
+		if err != nil {
+			p.Status = ReencryptionFailed
+			p.Errors = append(p.Errors, err.Error())
+		}
+	})
+}
+
+// snapshot copies the progress; the caller must hold mu
+func (s *CredentialReencryptionService) snapshot() ReencryptionProgress {
+	progress := s.progress
+	progress.Errors = append([]string(nil), s.progress.Errors...)
+	return progress
+}
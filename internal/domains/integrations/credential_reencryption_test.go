@@ -0,0 +1,191 @@
+package integrations_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCredentialRepo is an in-memory EncryptedCredentialRepository
+type memoryCredentialRepo struct {
+	mu          sync.Mutex
+	credentials []integrations.EncryptedCredential
+	findErr     error
+	// beforeReplace runs before each replacement, outside the lock
+	beforeReplace func(credential integrations.EncryptedCredential)
+}
+
+func (r *memoryCredentialRepo) FindAll(ctx context.Context) ([]integrations.EncryptedCredential, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	return append([]integrations.EncryptedCredential(nil), r.credentials...), nil
+}
+
+func (r *memoryCredentialRepo) Replace(ctx context.Context, credential integrations.EncryptedCredential, ciphertext string) (bool, error) {
+	if r.beforeReplace != nil {
+		r.beforeReplace(credential)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, stored := range r.credentials {
+		if stored.Kind == credential.Kind && stored.OwnerID == credential.OwnerID {
+			if stored.Ciphertext != credential.Ciphertext {
+				return false, nil
+			}
+			r.credentials[i].Ciphertext = ciphertext
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryCredentialRepo) ciphertext(kind, ownerID string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.credentials {
+		if stored.Kind == kind && stored.OwnerID == ownerID {
+			return stored.Ciphertext
+		}
+	}
+	return ""
+}
+
+func encryptWith(t *testing.T, keyID string, key []byte, credential string) string {
+	t.Helper()
+	ring, err := integrations.NewKeyRing(keyID, "", map[string][]byte{keyID: key})
+	require.NoError(t, err)
+	encrypted, err := ring.Encrypt(credential)
+	require.NoError(t, err)
+	return encrypted
+}
+
+func TestCredentialReencryptionService_Run(t *testing.T) {
+	legacy, err := integrations.EncryptCredential("legacy-token", oldKey)
+	require.NoError(t, err)
+	repo := &memoryCredentialRepo{credentials: []integrations.EncryptedCredential{
+		{Kind: integrations.CredentialKindJiraCredential, OwnerID: "1", Ciphertext: encryptWith(t, "old", oldKey, "jira-token")},
+		{Kind: integrations.CredentialKindJiraOAuthRefreshToken, OwnerID: "1", Ciphertext: legacy},
+		{Kind: integrations.CredentialKindTrackerCredential, OwnerID: "2", Ciphertext: encryptWith(t, "new", newKey, "tracker-token")},
+		{Kind: integrations.CredentialKindTrackerCredential, OwnerID: "3", Ciphertext: encryptWith(t, "retired", testEncryptionKey, "lost-token")},
+	}}
+	ring, err := integrations.NewKeyRing("new", "old", map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+	service := integrations.NewCredentialReencryptionService(repo, ring)
+	assert.Equal(t, integrations.ReencryptionIdle, service.Progress().Status)
+
+	var reported []int
+	progress, err := service.Run(context.Background(), func(progress integrations.ReencryptionProgress) {
+		reported = append(reported, progress.Processed)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, reported)
+	assert.Equal(t, integrations.ReencryptionCompleted, progress.Status)
+	assert.Equal(t, "new", progress.PrimaryKeyID)
+	assert.Equal(t, 4, progress.Total)
+	assert.Equal(t, 4, progress.Processed)
+	assert.Equal(t, 2, progress.Reencrypted)
+	assert.Equal(t, 1, progress.Skipped)
+	assert.Equal(t, 1, progress.Failed)
+	require.Len(t, progress.Errors, 1)
+	assert.Contains(t, progress.Errors[0], "tracker_connection.credential 3")
+	assert.NotNil(t, progress.StartedAt)
+	assert.NotNil(t, progress.FinishedAt)
+	assert.Equal(t, progress, service.Progress())
+
+	// Re-encrypted credentials are readable with only the primary key
+	primaryOnly, err := integrations.NewKeyRing("new", "", map[string][]byte{"new": newKey})
+	require.NoError(t, err)
+	for _, expected := range []struct{ kind, ownerID, credential string }{
+		{integrations.CredentialKindJiraCredential, "1", "jira-token"},
+		{integrations.CredentialKindJiraOAuthRefreshToken, "1", "legacy-token"},
+		{integrations.CredentialKindTrackerCredential, "2", "tracker-token"},
+	} {
+		decrypted, err := primaryOnly.Decrypt(repo.ciphertext(expected.kind, expected.ownerID))
+		require.NoError(t, err)
+		assert.Equal(t, expected.credential, decrypted)
+	}
+
+	// A second run has nothing left to do
+	progress, err = service.Run(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, progress.Reencrypted)
+	assert.Equal(t, 3, progress.Skipped)
+	assert.Equal(t, 1, progress.Failed)
+}
+
+func TestCredentialReencryptionService_ConcurrentUpdate(t *testing.T) {
+	ring, err := integrations.NewKeyRing("new", "", map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+	updated, err := ring.Encrypt("updated-token")
+	require.NoError(t, err)
+
+	repo := &memoryCredentialRepo{credentials: []integrations.EncryptedCredential{
+		{Kind: integrations.CredentialKindJiraCredential, OwnerID: "1", Ciphertext: encryptWith(t, "old", oldKey, "jira-token")},
+	}}
+	// The credential is replaced by its owner while it is being re-encrypted
+	repo.beforeReplace = func(credential integrations.EncryptedCredential) {
+		repo.mu.Lock()
+		repo.credentials[0].Ciphertext = updated
+		repo.mu.Unlock()
+	}
+
+	progress, err := integrations.NewCredentialReencryptionService(repo, ring).Run(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, progress.Reencrypted)
+	assert.Equal(t, 1, progress.Skipped)
+	assert.Equal(t, updated, repo.ciphertext(integrations.CredentialKindJiraCredential, "1"))
+}
+
+func TestCredentialReencryptionService_Start(t *testing.T) {
+	ring, err := integrations.NewKeyRing("new", "", map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	repo := &memoryCredentialRepo{
+		credentials: []integrations.EncryptedCredential{
+			{Kind: integrations.CredentialKindJiraCredential, OwnerID: "1", Ciphertext: encryptWith(t, "old", oldKey, "jira-token")},
+		},
+		beforeReplace: func(integrations.EncryptedCredential) { <-release },
+	}
+	service := integrations.NewCredentialReencryptionService(repo, ring)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress, err := service.Start(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.ReencryptionRunning, progress.Status)
+	// The run outlives the request that started it
+	cancel()
+
+	_, err = service.Start(context.Background())
+	assert.ErrorIs(t, err, integrations.ErrReencryptionRunning)
+	_, err = service.Run(context.Background(), nil)
+	assert.ErrorIs(t, err, integrations.ErrReencryptionRunning)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return service.Progress().Status == integrations.ReencryptionCompleted
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, service.Progress().Reencrypted)
+}
+
+func TestCredentialReencryptionService_ListFailure(t *testing.T) {
+	ring, err := integrations.NewKeyRing("new", "", map[string][]byte{"new": newKey})
+	require.NoError(t, err)
+	repo := &memoryCredentialRepo{findErr: errors.New("database unavailable")}
+
+	progress, err := integrations.NewCredentialReencryptionService(repo, ring).Run(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Equal(t, integrations.ReencryptionFailed, progress.Status)
+	require.Len(t, progress.Errors, 1)
+	assert.Contains(t, progress.Errors[0], "database unavailable")
+}
@@ -0,0 +1,278 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDigestSchedule is returned when a digest schedule is invalid
+var ErrInvalidDigestSchedule = errors.New("invalid digest schedule")
+
+// DigestSchedule says when digests are sent: every week on a weekday, or
+// every day, at an hour in a time zone. Each digest covers the period since
+// the previous scheduled time.
+type DigestSchedule struct {
+	weekly   bool
+	weekday  time.Weekday
+	hour     int
+	location *time.Location
+}
+
+// NewDigestSchedule creates a schedule. An empty weekday schedules daily
+// digests; an empty time zone means UTC.
+func NewDigestSchedule(weekday string, hour int, timeZone string) (DigestSchedule, error) {
+	schedule := DigestSchedule{hour: hour, location: time.UTC}
+	if hour < 0 || hour > 23 {
+		return schedule, fmt.Errorf("%w: hour must be between 0 and 23", ErrInvalidDigestSchedule)
+	}
+	if timeZone != "" {
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return schedule, fmt.Errorf("%w: unknown time zone %q", ErrInvalidDigestSchedule, timeZone)
+		}
+		schedule.location = location
+	}
+	if weekday != "" {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(day.String(), weekday) {
+				schedule.weekly, schedule.weekday, found = true, day, true
+				break
+			}
+		}
+		if !found {
+			return schedule, fmt.Errorf("%w: unknown weekday %q", ErrInvalidDigestSchedule, weekday)
+		}
+	}
+	return schedule, nil
+}
+
+// Start returns the start of the period a digest scheduled at end covers:
+// the scheduled time a week or a day before
+func (s DigestSchedule) Start(end time.Time) time.Time {
+	if s.weekly {
+		return end.AddDate(0, 0, -7)
+	}
+	return end.AddDate(0, 0, -1)
+}
+
+// Location returns the time zone of the schedule
+func (s DigestSchedule) Location() *time.Location {
+	if s.location == nil {
+		return time.UTC
+	}
+	return s.location
+}
+
+// Latest returns the latest scheduled time at or before now
+func (s DigestSchedule) Latest(now time.Time) time.Time {
+	local := now.In(s.Location())
+	at := time.Date(local.Year(), local.Month(), local.Day(), s.hour, 0, 0, 0, s.Location())
+	if at.After(local) {
+		at = at.AddDate(0, 0, -1)
+	}
+	if s.weekly {
+		at = at.AddDate(0, 0, -((int(at.Weekday()) - int(s.weekday) + 7) % 7))
+	}
+	return at
+}
+
+// Next returns the first scheduled time after now
+func (s DigestSchedule) Next(now time.Time) time.Time {
+	if s.weekly {
+		return s.Latest(now).AddDate(0, 0, 7)
+	}
+	return s.Latest(now).AddDate(0, 0, 1)
+}
+
+// Name describes how often digests are sent, for subjects and headings
+func (s DigestSchedule) Name() string {
+	if s.weekly {
+		return "weekly"
+	}
+	return "daily"
+}
+
+// DigestRecipient is a user who may receive a digest, with the projects they
+// favorite or manage
+type DigestRecipient struct {
+	UserID     string
+	Email      string
+	Name       string
+	ProjectIDs []string
+	OptedOut   bool
+}
+
+// DigestRun is the outcome of one finished test run
+type DigestRun struct {
+	StartTime   time.Time
+	PassedTests int
+	FailedTests int
+}
+
+// DigestFailingSpec is a spec that failed in the period
+type DigestFailingSpec struct {
+	SuiteName string
+	SpecName  string
+	Failures  int
+}
+
+// DigestFlakyTest is a flaky test detected or resolved in the period
+type DigestFlakyTest struct {
+	SuiteName string
+	TestName  string
+	FlakeRate float64 // Share of flaky executions, from 0 to 1
+	Severity  string
+	URL       string
+}
+
+// DigestSuite is a suite with its average duration in the period
+type DigestSuite struct {
+	Name            string
+	AverageDuration time.Duration
+	Runs            int
+}
+
+// DigestProject is the test health of one project over a digest period
+type DigestProject struct {
+	ID                 string
+	Name               string
+	URL                string
+	Runs               []DigestRun // Finished runs in the period
+	PreviousRuns       []DigestRun // Finished runs in the period before, for the trend
+	TopFailingSpecs    []DigestFailingSpec
+	NewFlakyTests      []DigestFlakyTest
+	ResolvedFlakyTests []DigestFlakyTest
+	SlowestSuites      []DigestSuite
+}
+
+// PassRate returns the share of passed tests over the period, and false when
+// no tests passed or failed
+func (p *DigestProject) PassRate() (float64, bool) {
+	return passRate(p.Runs)
+}
+
+// PreviousPassRate returns the share of passed tests over the period before
+func (p *DigestProject) PreviousPassRate() (float64, bool) {
+	return passRate(p.PreviousRuns)
+}
+
+// DigestDay is the pass rate of one day in the period
+type DigestDay struct {
+	Date     time.Time
+	Runs     int
+	PassRate float64
+	HasTests bool
+}
+
+// Trend returns the pass rate of each day from from to to, in the time zone
+// of from
+func (p *DigestProject) Trend(from, to time.Time) []DigestDay {
+	var trend []DigestDay
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		var runs []DigestRun
+		for _, run := range p.Runs {
+			if !run.StartTime.Before(day) && run.StartTime.Before(next) {
+				runs = append(runs, run)
+			}
+		}
+		rate, ok := passRate(runs)
+		trend = append(trend, DigestDay{Date: day, Runs: len(runs), PassRate: rate, HasTests: ok})
+	}
+	return trend
+}
+
+// Active reports whether anything happened in the project during the period
+func (p *DigestProject) Active() bool {
+	return len(p.Runs) > 0 || len(p.NewFlakyTests) > 0 || len(p.ResolvedFlakyTests) > 0
+}
+
+// passRate returns the share of passed tests of runs
+func passRate(runs []DigestRun) (float64, bool) {
+	passed, executed := 0, 0
+	for _, run := range runs {
+		passed += run.PassedTests
+		executed += run.PassedTests + run.FailedTests
+	}
+	if executed == 0 {
+		return 0, false
+	}
+	return float64(passed) / float64(executed), true
+}
+
+// Digest is the email digest of one user for one period
+type Digest struct {
+	Recipient      DigestRecipient
+	Frequency      string // weekly or daily
+	From           time.Time
+	To             time.Time
+	Projects       []*DigestProject // Sorted by name
+	UnsubscribeURL string           // Opts the user out without signing in; empty when no Fern URL is configured
+}
+
+// sortProjects orders the projects of a digest by name
+func (d *Digest) sortProjects() {
+	sort.SliceStable(d.Projects, func(i, j int) bool {
+		return strings.ToLower(d.Projects[i].Name) < strings.ToLower(d.Projects[j].Name)
+	})
+}
+
+// DigestSource loads recipients and project test health for digests
+type DigestSource interface {
+	// Recipients returns the active users with an email address, with the
+	// projects they favorite or manage
+	Recipients(ctx context.Context) ([]DigestRecipient, error)
+
+	// Recipient returns one user as a recipient
+	Recipient(ctx context.Context, userID string) (*DigestRecipient, error)
+
+	// ProjectHealth loads the test health of a project from from to to, and
+	// the runs of the equally long period before
+	ProjectHealth(ctx context.Context, projectID string, from, to time.Time) (*DigestProject, error)
+}
+
+// DigestDeliveryStatus is the state of a user's digest for one period
+type DigestDeliveryStatus string
+
+const (
+	DigestPending DigestDeliveryStatus = "pending"
+	DigestSent    DigestDeliveryStatus = "sent"
+	DigestFailed  DigestDeliveryStatus = "failed"
+	// DigestSkipped digests were not sent because nothing happened in the user's projects
+	DigestSkipped DigestDeliveryStatus = "skipped"
+)
+
+// DigestDelivery records the digest of a user for one period, so each user
+// gets at most one digest per period however many instances send them
+type DigestDelivery struct {
+	ID            string
+	UserID        string
+	Email         string
+	PeriodEnd     time.Time // Scheduled time the digest was sent for
+	Status        DigestDeliveryStatus
+	Projects      int
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// recordAttempt records the outcome of a send attempt and schedules the next
+// one, or fails the digest once maxAttempts is reached
+func (d *DigestDelivery) recordAttempt(at time.Time, attemptErr error, maxAttempts int, backoff func(attempt int) time.Duration) {
+	attempts := deliveryAttempts{&d.Attempts, &d.NextAttemptAt, &d.LastAttemptAt, &d.LastError}
+	switch attempts.record(at, attemptErr, maxAttempts, backoff) {
+	case attemptSucceeded:
+		d.Status = DigestSent
+	case attemptExhausted:
+		d.Status = DigestFailed
+	default:
+		d.Status = DigestPending
+	}
+}
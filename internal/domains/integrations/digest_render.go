@@ -0,0 +1,320 @@
+package integrations
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// DigestListLimit caps the entries listed in each section of a digest
+const DigestListLimit = 5
+
+// sparkBars draws pass rates from 0% to 100% in text digests
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// RenderedDigest is a digest rendered as an email
+type RenderedDigest struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// digestView is what the digest templates see
+type digestView struct {
+	Greeting       string
+	Frequency      string
+	Range          string
+	Summary        string
+	Projects       []digestProjectView
+	UnsubscribeURL string
+}
+
+type digestProjectView struct {
+	Name               string
+	URL                string
+	Runs               int
+	PassRate           string // Empty when no tests ran
+	PassRateColor      string
+	Change             string // Change of pass rate from the period before, empty when unknown
+	Sparkline          string
+	Days               []digestDayView
+	TopFailingSpecs    []DigestFailingSpec
+	NewFlakyTests      []digestFlakyView
+	ResolvedFlakyTests []digestFlakyView
+	SlowestSuites      []digestSuiteView
+}
+
+type digestDayView struct {
+	Label    string
+	PassRate string
+	Height   int // Bar height in pixels
+	Color    string
+}
+
+type digestFlakyView struct {
+	Name      string
+	URL       string
+	FlakeRate string
+	Severity  string
+}
+
+type digestSuiteView struct {
+	Name    string
+	Average string
+	Runs    int
+}
+
+// RenderDigest renders a digest as an email with plain text and HTML bodies
+func RenderDigest(d Digest) (*RenderedDigest, error) {
+	view := newDigestView(d)
+
+	var text, html strings.Builder
+	if err := digestTextTemplate.Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render text digest: %w", err)
+	}
+	if err := digestHTMLTemplate.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render HTML digest: %w", err)
+	}
+
+	return &RenderedDigest{
+		Subject: fmt.Sprintf("Fern %s digest, %s: %s", d.Frequency, view.Range, view.Summary),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// newDigestView prepares a digest for the templates
+func newDigestView(d Digest) digestView {
+	view := digestView{
+		Greeting:       "Hi,",
+		Frequency:      d.Frequency,
+		Range:          digestRange(d.From, d.To),
+		UnsubscribeURL: d.UnsubscribeURL,
+	}
+	if d.Recipient.Name != "" {
+		view.Greeting = fmt.Sprintf("Hi %s,", d.Recipient.Name)
+	}
+
+	var allRuns []DigestRun
+	for _, project := range d.Projects {
+		allRuns = append(allRuns, project.Runs...)
+		view.Projects = append(view.Projects, newDigestProjectView(project, d))
+	}
+	view.Summary = fmt.Sprintf("%d test runs", len(allRuns))
+	if rate, ok := passRate(allRuns); ok {
+		view.Summary = fmt.Sprintf("%s of tests passed in %d runs", formatPassRate(rate), len(allRuns))
+	}
+	return view
+}
+
+func newDigestProjectView(project *DigestProject, d Digest) digestProjectView {
+	view := digestProjectView{
+		Name:            project.Name,
+		URL:             project.URL,
+		Runs:            len(project.Runs),
+		PassRateColor:   "#6b7280",
+		TopFailingSpecs: limitList(project.TopFailingSpecs),
+	}
+	if view.Name == "" {
+		view.Name = project.ID
+	}
+
+	if rate, ok := project.PassRate(); ok {
+		view.PassRate = formatPassRate(rate)
+		view.PassRateColor = passRateColor(rate)
+		if previous, ok := project.PreviousPassRate(); ok {
+			view.Change = formatPassRateChange(rate-previous, d.Frequency)
+		}
+	}
+
+	var spark strings.Builder
+	for _, day := range project.Trend(d.From, d.To) {
+		dayView := digestDayView{Label: day.Date.Format("Mon"), Height: 2, Color: "#e5e7eb"}
+		if day.HasTests {
+			spark.WriteRune(sparkBars[int(math.Round(day.PassRate*float64(len(sparkBars)-1)))])
+			dayView.PassRate = formatPassRate(day.PassRate)
+			dayView.Height = 4 + int(math.Round(day.PassRate*36))
+			dayView.Color = passRateColor(day.PassRate)
+		} else {
+			spark.WriteRune('·')
+		}
+		view.Days = append(view.Days, dayView)
+	}
+	view.Sparkline = spark.String()
+
+	for _, test := range limitList(project.NewFlakyTests) {
+		view.NewFlakyTests = append(view.NewFlakyTests, newDigestFlakyView(test))
+	}
+	for _, test := range limitList(project.ResolvedFlakyTests) {
+		view.ResolvedFlakyTests = append(view.ResolvedFlakyTests, newDigestFlakyView(test))
+	}
+	for _, suite := range limitList(project.SlowestSuites) {
+		view.SlowestSuites = append(view.SlowestSuites, digestSuiteView{
+			Name:    suite.Name,
+			Average: formatNotificationDuration(suite.AverageDuration),
+			Runs:    suite.Runs,
+		})
+	}
+	return view
+}
+
+func newDigestFlakyView(test DigestFlakyTest) digestFlakyView {
+	return digestFlakyView{
+		Name:      testLabel(test.SuiteName, test.TestName),
+		URL:       test.URL,
+		FlakeRate: fmt.Sprintf("%.0f%%", test.FlakeRate*100),
+		Severity:  test.Severity,
+	}
+}
+
+// limitList returns the first DigestListLimit entries of a list
+func limitList[T any](items []T) []T {
+	if len(items) > DigestListLimit {
+		return items[:DigestListLimit]
+	}
+	return items
+}
+
+// digestRange describes the days a digest covers; to is exclusive
+func digestRange(from, to time.Time) string {
+	last := to.Add(-time.Second)
+	if from.Year() == last.Year() && from.YearDay() == last.YearDay() {
+		return from.Format("Jan 2, 2006")
+	}
+	if from.Year() != last.Year() {
+		return from.Format("Jan 2, 2006") + " – " + last.Format("Jan 2, 2006")
+	}
+	return from.Format("Jan 2") + " – " + last.Format("Jan 2, 2006")
+}
+
+// formatPassRate formats a pass rate as a percentage with one decimal
+func formatPassRate(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
+
+// formatPassRateChange describes a change of pass rate in percentage points
+func formatPassRateChange(change float64, frequency string) string {
+	previous := "previous week"
+	if frequency == "daily" {
+		previous = "previous day"
+	}
+	points := change * 100
+	switch {
+	case math.Abs(points) < 0.05:
+		return "unchanged from the " + previous
+	case points > 0:
+		return fmt.Sprintf("▲ %.1f pts from the %s", points, previous)
+	default:
+		return fmt.Sprintf("▼ %.1f pts from the %s", -points, previous)
+	}
+}
+
+// passRateColor picks a green, amber or red color for a pass rate
+func passRateColor(rate float64) string {
+	switch {
+	case rate >= 0.95:
+		return "#16a34a"
+	case rate >= 0.8:
+		return "#d97706"
+	default:
+		return "#dc2626"
+	}
+}
+
+var digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").Parse(`Fern {{.Frequency}} digest: {{.Range}}
+
+{{.Greeting}}
+
+Here is the test health of the projects you favorite or manage.
+{{range .Projects}}
+== {{.Name}} ==
+{{if .URL}}{{.URL}}
+{{end -}}
+{{if .PassRate}}Pass rate: {{.PassRate}} over {{.Runs}} runs{{if .Change}} ({{.Change}}){{end}}
+Trend: {{.Sparkline}}
+{{else}}No test runs.
+{{end -}}
+{{if .TopFailingSpecs}}
+Top failing specs:
+{{range .TopFailingSpecs}}  - {{if .SuiteName}}{{.SuiteName}} › {{end}}{{.SpecName}}: {{.Failures}} failures
+{{end}}{{end -}}
+{{if .NewFlakyTests}}
+New flaky tests:
+{{range .NewFlakyTests}}  - {{.Name}} ({{.FlakeRate}} flaky{{if .Severity}}, {{.Severity}}{{end}})
+{{end}}{{end -}}
+{{if .ResolvedFlakyTests}}
+Resolved flaky tests:
+{{range .ResolvedFlakyTests}}  - {{.Name}}
+{{end}}{{end -}}
+{{if .SlowestSuites}}
+Slowest suites:
+{{range .SlowestSuites}}  - {{.Name}}: {{.Average}} on average over {{.Runs}} runs
+{{end}}{{end -}}
+{{end}}
+--
+You receive this digest because you favorite or manage these projects in Fern.
+{{if .UnsubscribeURL}}To stop receiving it, unsubscribe at {{.UnsubscribeURL}}{{else}}To stop receiving it, unsubscribe from email digests through the Fern API (PUT /api/v1/user/digest).{{end}}
+`))
+
+var digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Fern {{.Frequency}} digest</title></head>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:-apple-system,'Segoe UI',Helvetica,Arial,sans-serif;color:#111827;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="max-width:640px;margin:0 auto;">
+<tr><td style="padding-bottom:16px;">
+<h1 style="margin:0;font-size:20px;">Fern {{.Frequency}} digest</h1>
+<p style="margin:4px 0 0;color:#6b7280;">{{.Range}}</p>
+</td></tr>
+<tr><td style="padding-bottom:16px;">
+<p style="margin:0 0 8px;">{{.Greeting}}</p>
+<p style="margin:0;">Here is the test health of the projects you favorite or manage.</p>
+</td></tr>
+{{range .Projects}}
+<tr><td style="background:#ffffff;border-radius:8px;padding:20px;margin-bottom:16px;">
+<h2 style="margin:0 0 4px;font-size:18px;">{{if .URL}}<a href="{{.URL}}" style="color:#111827;text-decoration:none;">{{.Name}}</a>{{else}}{{.Name}}{{end}}</h2>
+{{if .PassRate}}
+<p style="margin:0 0 12px;"><span style="font-size:24px;font-weight:bold;color:{{.PassRateColor}};">{{.PassRate}}</span> pass rate over {{.Runs}} runs{{if .Change}}<br><span style="color:#6b7280;">{{.Change}}</span>{{end}}</p>
+<table role="presentation" cellpadding="0" cellspacing="4" style="margin-bottom:12px;"><tr style="vertical-align:bottom;">
+{{range .Days}}<td style="text-align:center;font-size:11px;color:#6b7280;"><div title="{{.PassRate}}" style="width:28px;height:{{.Height}}px;background:{{.Color}};border-radius:2px;"></div>{{.Label}}</td>{{end}}
+</tr></table>
+{{else}}
+<p style="margin:0 0 12px;color:#6b7280;">No test runs.</p>
+{{end}}
+{{if .TopFailingSpecs}}
+<h3 style="margin:12px 0 4px;font-size:14px;">Top failing specs</h3>
+<table role="presentation" width="100%" cellpadding="4" cellspacing="0" style="font-size:13px;">
+{{range .TopFailingSpecs}}<tr><td style="border-top:1px solid #e5e7eb;">{{if .SuiteName}}<span style="color:#6b7280;">{{.SuiteName}} ›</span> {{end}}{{.SpecName}}</td><td style="border-top:1px solid #e5e7eb;text-align:right;white-space:nowrap;">{{.Failures}} failures</td></tr>
+{{end}}</table>
+{{end}}
+{{if .NewFlakyTests}}
+<h3 style="margin:12px 0 4px;font-size:14px;">New flaky tests</h3>
+<table role="presentation" width="100%" cellpadding="4" cellspacing="0" style="font-size:13px;">
+{{range .NewFlakyTests}}<tr><td style="border-top:1px solid #e5e7eb;">{{if .URL}}<a href="{{.URL}}" style="color:#2563eb;">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td><td style="border-top:1px solid #e5e7eb;text-align:right;white-space:nowrap;">{{.FlakeRate}} flaky{{if .Severity}} · {{.Severity}}{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .ResolvedFlakyTests}}
+<h3 style="margin:12px 0 4px;font-size:14px;">Resolved flaky tests</h3>
+<table role="presentation" width="100%" cellpadding="4" cellspacing="0" style="font-size:13px;">
+{{range .ResolvedFlakyTests}}<tr><td style="border-top:1px solid #e5e7eb;">{{if .URL}}<a href="{{.URL}}" style="color:#2563eb;">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .SlowestSuites}}
+<h3 style="margin:12px 0 4px;font-size:14px;">Slowest suites</h3>
+<table role="presentation" width="100%" cellpadding="4" cellspacing="0" style="font-size:13px;">
+{{range .SlowestSuites}}<tr><td style="border-top:1px solid #e5e7eb;">{{.Name}}</td><td style="border-top:1px solid #e5e7eb;text-align:right;white-space:nowrap;">{{.Average}} avg · {{.Runs}} runs</td></tr>
+{{end}}</table>
+{{end}}
+</td></tr>
+<tr><td style="height:16px;"></td></tr>
+{{end}}
+<tr><td style="font-size:12px;color:#6b7280;">
+You receive this digest because you favorite or manage these projects in Fern.
+{{if .UnsubscribeURL}}<a href="{{.UnsubscribeURL}}" style="color:#6b7280;">Unsubscribe from email digests</a>{{else}}To stop receiving it, unsubscribe from email digests through the Fern API (PUT /api/v1/user/digest).{{end}}
+</td></tr>
+</table>
+</body>
+</html>
+`))
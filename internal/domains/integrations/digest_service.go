@@ -0,0 +1,365 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DigestMaxAttempts is how many times a digest is sent before it is marked failed
+	DigestMaxAttempts = 3
+
+	// DigestRetryBaseDelay is the wait after the first failed attempt; it doubles after each attempt
+	DigestRetryBaseDelay = 10 * time.Minute
+
+	// DigestRetryMaxDelay caps the delay between attempts
+	DigestRetryMaxDelay = time.Hour
+
+	// DigestSendWindow is how long after its scheduled time a digest is still
+	// sent. Digests missed for longer, such as while digests were disabled,
+	// are skipped rather than sent late.
+	DigestSendWindow = 24 * time.Hour
+
+	// DigestRetention is how long digest deliveries are kept
+	DigestRetention = 90 * 24 * time.Hour
+
+	// digestClaimLease is how long an instance has to send a digest it claimed
+	// before another instance may take it over
+	digestClaimLease = 10 * time.Minute
+
+	// digestUnsubscribePrefix scopes unsubscribe tokens, so that no other
+	// encrypted value can be used as one
+	digestUnsubscribePrefix = "digest-unsubscribe:"
+)
+
+// ErrInvalidUnsubscribeToken is returned when an unsubscribe link is invalid
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// DigestResult summarises one run of the digest job
+type DigestResult struct {
+	Attempted int
+	Sent      int
+	Skipped   int // Digests not sent because nothing happened in the user's projects
+	Retrying  int
+	Failed    int
+	Errors    []error
+}
+
+// DigestService sends users scheduled email digests of the test health of
+// the projects they favorite or manage
+type DigestService struct {
+	source      DigestSource
+	sender      EmailSender
+	deliveries  DigestDeliveryRepository
+	preferences DigestPreferenceRepository
+	keys        *KeyRing
+	schedule    DigestSchedule
+	fernURL     string
+	now         func() time.Time
+}
+
+// NewDigestService creates a digest service. Unsubscribe tokens are
+// encrypted with keys, and digests link back to pages under fernURL.
+func NewDigestService(
+	source DigestSource,
+	sender EmailSender,
+	deliveries DigestDeliveryRepository,
+	preferences DigestPreferenceRepository,
+	keys *KeyRing,
+	schedule DigestSchedule,
+	fernURL string,
+) *DigestService {
+	return &DigestService{
+		source:      source,
+		sender:      sender,
+		deliveries:  deliveries,
+		preferences: preferences,
+		keys:        keys,
+		schedule:    schedule,
+		fernURL:     strings.TrimRight(fernURL, "/"),
+		now:         time.Now,
+	}
+}
+
+// SetClock replaces the clock, for tests
+func (s *DigestService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// Schedule returns when digests are sent
+func (s *DigestService) Schedule() DigestSchedule {
+	return s.schedule
+}
+
+// SendDue sends the digests of the latest scheduled time that have not been
+// sent yet, and retries failed ones. Failures for individual users are
+// collected in the result and do not stop the run.
+func (s *DigestService) SendDue(ctx context.Context) (*DigestResult, error) {
+	now := s.now()
+	periodEnd := s.schedule.Latest(now)
+	result := &DigestResult{}
+	if now.Sub(periodEnd) > DigestSendWindow {
+		return result, nil
+	}
+
+	existing, err := s.deliveries.FindByPeriod(ctx, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest deliveries: %w", err)
+	}
+	byUser := make(map[string]*DigestDelivery, len(existing))
+	for _, delivery := range existing {
+		byUser[delivery.UserID] = delivery
+	}
+
+	recipients, err := s.source.Recipients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest recipients: %w", err)
+	}
+
+	health := newDigestHealthCache(s.source, s.schedule.Start(periodEnd), periodEnd)
+	for _, recipient := range recipients {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if recipient.OptedOut || recipient.Email == "" || len(recipient.ProjectIDs) == 0 {
+			continue
+		}
+
+		delivery, claimed, err := s.claim(ctx, byUser[recipient.UserID], recipient, periodEnd)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("user %s: %w", recipient.UserID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.deliver(ctx, delivery, recipient, health, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("user %s: %w", recipient.UserID, err))
+		}
+	}
+	return result, nil
+}
+
+// claim reserves the digest of a user for a period, or takes over a pending
+// one that is due, so that only one instance sends it
+func (s *DigestService) claim(ctx context.Context, delivery *DigestDelivery, recipient DigestRecipient, periodEnd time.Time) (*DigestDelivery, bool, error) {
+	now := s.now()
+	if delivery == nil {
+		delivery = &DigestDelivery{
+			UserID:        recipient.UserID,
+			Email:         recipient.Email,
+			PeriodEnd:     periodEnd,
+			Status:        DigestPending,
+			NextAttemptAt: now.Add(digestClaimLease),
+			CreatedAt:     now,
+		}
+		reserved, err := s.deliveries.Reserve(ctx, delivery)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve digest: %w", err)
+		}
+		return delivery, reserved, nil
+	}
+
+	if delivery.Status != DigestPending || delivery.NextAttemptAt.After(now) {
+		return nil, false, nil
+	}
+	claimed, err := s.deliveries.Claim(ctx, delivery, now.Add(digestClaimLease))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+	return delivery, claimed, nil
+}
+
+// deliver makes one attempt at sending a claimed digest and records its outcome
+func (s *DigestService) deliver(ctx context.Context, delivery *DigestDelivery, recipient DigestRecipient, health *digestHealthCache, result *DigestResult) error {
+	result.Attempted++
+	attemptedAt := s.now()
+
+	digest, attemptErr := s.build(ctx, recipient, delivery.PeriodEnd, health, false)
+	if attemptErr == nil && len(digest.Projects) == 0 {
+		delivery.Status = DigestSkipped
+		delivery.LastAttemptAt = &attemptedAt
+		result.Skipped++
+		if err := s.deliveries.Update(ctx, delivery); err != nil {
+			return fmt.Errorf("failed to save digest: %w", err)
+		}
+		return nil
+	}
+	if attemptErr == nil {
+		delivery.Projects = len(digest.Projects)
+		attemptErr = s.send(ctx, digest)
+	}
+
+	delivery.Email = recipient.Email
+	delivery.recordAttempt(attemptedAt, attemptErr, DigestMaxAttempts, DigestRetryDelay)
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to save digest attempt: %w", err)
+	}
+
+	switch delivery.Status {
+	case DigestPending:
+		result.Retrying++
+	case DigestSent:
+		result.Sent++
+	case DigestFailed:
+		result.Failed++
+	}
+	return nil
+}
+
+// build assembles the digest of a user for the period ending at periodEnd.
+// Projects where nothing happened are left out unless includeIdle is set.
+func (s *DigestService) build(ctx context.Context, recipient DigestRecipient, periodEnd time.Time, health *digestHealthCache, includeIdle bool) (*Digest, error) {
+	digest := &Digest{
+		Recipient: recipient,
+		Frequency: s.schedule.Name(),
+		From:      s.schedule.Start(periodEnd),
+		To:        periodEnd,
+	}
+	for _, projectID := range recipient.ProjectIDs {
+		project, err := health.get(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", projectID, err)
+		}
+		if includeIdle || project.Active() {
+			digest.Projects = append(digest.Projects, project)
+		}
+	}
+	digest.sortProjects()
+
+	if link, err := s.unsubscribeURL(recipient.UserID); err == nil {
+		digest.UnsubscribeURL = link
+	}
+	return digest, nil
+}
+
+// send renders a digest and emails it to its recipient
+func (s *DigestService) send(ctx context.Context, digest *Digest) error {
+	rendered, err := RenderDigest(*digest)
+	if err != nil {
+		return err
+	}
+
+	message := EmailMessage{
+		To:      []string{digest.Recipient.Email},
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	}
+	if digest.UnsubscribeURL != "" {
+		// One-click unsubscribe (RFC 8058)
+		message.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + digest.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+	return s.sender.Send(ctx, message)
+}
+
+// Preview renders the digest a user would get for the latest scheduled time,
+// including projects where nothing happened
+func (s *DigestService) Preview(ctx context.Context, userID string) (*RenderedDigest, error) {
+	recipient, err := s.source.Recipient(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	periodEnd := s.schedule.Latest(s.now())
+	health := newDigestHealthCache(s.source, s.schedule.Start(periodEnd), periodEnd)
+	digest, err := s.build(ctx, *recipient, periodEnd, health, true)
+	if err != nil {
+		return nil, err
+	}
+	return RenderDigest(*digest)
+}
+
+// Subscribed reports whether a user receives digests
+func (s *DigestService) Subscribed(ctx context.Context, userID string) (bool, error) {
+	optedOut, err := s.preferences.IsOptedOut(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return !optedOut, nil
+}
+
+// SetSubscribed opts a user in to or out of digests
+func (s *DigestService) SetSubscribed(ctx context.Context, userID string, subscribed bool) error {
+	return s.preferences.SetOptedOut(ctx, userID, !subscribed)
+}
+
+// Unsubscribe opts out the user an unsubscribe link was created for
+func (s *DigestService) Unsubscribe(ctx context.Context, token string) error {
+	value, err := s.keys.Decrypt(token)
+	if err != nil || !strings.HasPrefix(value, digestUnsubscribePrefix) {
+		return ErrInvalidUnsubscribeToken
+	}
+	return s.preferences.SetOptedOut(ctx, strings.TrimPrefix(value, digestUnsubscribePrefix), true)
+}
+
+// unsubscribeURL creates the link that opts a user out of digests without signing in
+func (s *DigestService) unsubscribeURL(userID string) (string, error) {
+	if s.fernURL == "" {
+		return "", errors.New("no Fern URL configured")
+	}
+	token, err := s.keys.Encrypt(digestUnsubscribePrefix + userID)
+	if err != nil {
+		return "", err
+	}
+	return s.fernURL + "/api/v1/digests/unsubscribe?token=" + url.QueryEscape(token), nil
+}
+
+// ListDeliveries retrieves the digests sent to a user, newest first
+func (s *DigestService) ListDeliveries(ctx context.Context, userID string, limit int) ([]*DigestDelivery, error) {
+	return s.deliveries.FindByUserID(ctx, userID, limit)
+}
+
+// PruneDeliveries removes digest deliveries older than the retention period
+func (s *DigestService) PruneDeliveries(ctx context.Context) (int64, error) {
+	return s.deliveries.DeleteBefore(ctx, s.now().Add(-DigestRetention))
+}
+
+// DigestRetryDelay returns how long to wait after a failed attempt before the next one
+func DigestRetryDelay(attempt int) time.Duration {
+	return retryDelay(attempt, DigestRetryBaseDelay, DigestRetryMaxDelay)
+}
+
+// digestHealthCache loads the health of each project once per run, since
+// many users follow the same projects
+type digestHealthCache struct {
+	source   DigestSource
+	from, to time.Time
+	projects map[string]*DigestProject
+	errors   map[string]error
+}
+
+func newDigestHealthCache(source DigestSource, from, to time.Time) *digestHealthCache {
+	return &digestHealthCache{
+		source:   source,
+		from:     from,
+		to:       to,
+		projects: make(map[string]*DigestProject),
+		errors:   make(map[string]error),
+	}
+}
+
+func (c *digestHealthCache) get(ctx context.Context, projectID string) (*DigestProject, error) {
+	if project, ok := c.projects[projectID]; ok {
+		return project, nil
+	}
+	if err, ok := c.errors[projectID]; ok {
+		return nil, err
+	}
+
+	project, err := c.source.ProjectHealth(ctx, projectID, c.from, c.to)
+	if err != nil {
+		c.errors[projectID] = err
+		return nil, err
+	}
+	c.projects[projectID] = project
+	return project, nil
+}
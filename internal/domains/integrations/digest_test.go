@@ -0,0 +1,807 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturedEmail is a message accepted by the capturing SMTP server
+type capturedEmail struct {
+	Auth string // Decoded AUTH PLAIN credentials
+	From string
+	To   []string
+	Data string
+}
+
+// smtpCapture is a local SMTP server that accepts every message and keeps it
+type smtpCapture struct {
+	listener net.Listener
+	mu       sync.Mutex
+	emails   []capturedEmail
+	failData int // Number of upcoming messages to reject with a temporary error
+}
+
+func newSMTPCapture(t *testing.T) *smtpCapture {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	capture := &smtpCapture{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go capture.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return capture
+}
+
+func (c *smtpCapture) port() int {
+	return c.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (c *smtpCapture) failNext(count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failData = count
+}
+
+func (c *smtpCapture) messages() []capturedEmail {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]capturedEmail(nil), c.emails...)
+}
+
+func (c *smtpCapture) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 capture ESMTP")
+
+	var email capturedEmail
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250-capture")
+			_ = text.PrintfLine("250-AUTH PLAIN")
+			_ = text.PrintfLine("250 8BITMIME")
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(encoded)
+			email.Auth = strings.ReplaceAll(string(decoded), "\x00", " ")
+			_ = text.PrintfLine("235 Authenticated")
+		case "MAIL":
+			email = capturedEmail{Auth: email.Auth, From: smtpPath(arg)}
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			email.To = append(email.To, smtpPath(arg))
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			c.mu.Lock()
+			fail := c.failData > 0
+			if fail {
+				c.failData--
+			}
+			c.mu.Unlock()
+			if fail {
+				_ = text.PrintfLine("451 Try again later")
+				continue
+			}
+			_ = text.PrintfLine("354 Go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			email.Data = string(data)
+			c.mu.Lock()
+			c.emails = append(c.emails, email)
+			c.mu.Unlock()
+			_ = text.PrintfLine("250 Queued")
+		case "RSET", "NOOP":
+			_ = text.PrintfLine("250 OK")
+		case "QUIT":
+			_ = text.PrintfLine("221 Bye")
+			return
+		default:
+			_ = text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+// smtpPath extracts the address of a MAIL FROM or RCPT TO argument
+func smtpPath(arg string) string {
+	start, end := strings.Index(arg, "<"), strings.Index(arg, ">")
+	if start < 0 || end < start {
+		return arg
+	}
+	return arg[start+1 : end]
+}
+
+// parsedEmail is a captured message with its decoded subject and bodies
+type parsedEmail struct {
+	Header  mail.Header
+	Subject string
+	Text    string
+	HTML    string
+}
+
+func parseEmail(t *testing.T, data string) parsedEmail {
+	t.Helper()
+	message, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	require.NoError(t, err)
+	parsed := parsedEmail{Header: message.Header, Subject: subject}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		switch {
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain"):
+			parsed.Text = string(body)
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/html"):
+			parsed.HTML = string(body)
+		}
+	}
+	return parsed
+}
+
+func newCaptureSender(t *testing.T, capture *smtpCapture) *integrations.SMTPSender {
+	t.Helper()
+	sender, err := integrations.NewSMTPSender(integrations.SMTPSettings{
+		Host:     "127.0.0.1",
+		Port:     capture.port(),
+		Username: "fern",
+		Password: "secret",
+		From:     "Fern <fern@example.com>",
+		TLS:      integrations.SMTPTLSNone,
+		Timeout:  5 * time.Second,
+	})
+	require.NoError(t, err)
+	return sender
+}
+
+func TestNewSMTPSender_Validation(t *testing.T) {
+	valid := integrations.SMTPSettings{Host: "smtp.example.com", Port: 587, From: "fern@example.com"}
+
+	tests := []struct {
+		name   string
+		modify func(*integrations.SMTPSettings)
+	}{
+		{"missing host", func(s *integrations.SMTPSettings) { s.Host = "" }},
+		{"invalid port", func(s *integrations.SMTPSettings) { s.Port = 0 }},
+		{"invalid sender", func(s *integrations.SMTPSettings) { s.From = "not an address" }},
+		{"unknown TLS mode", func(s *integrations.SMTPSettings) { s.TLS = "ssl" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := valid
+			tt.modify(&settings)
+			_, err := integrations.NewSMTPSender(settings)
+			assert.ErrorIs(t, err, integrations.ErrInvalidSMTPSettings)
+		})
+	}
+
+	_, err := integrations.NewSMTPSender(valid)
+	assert.NoError(t, err)
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	capture := newSMTPCapture(t)
+	sender := newCaptureSender(t, capture)
+
+	err := sender.Send(context.Background(), integrations.EmailMessage{
+		To:      []string{"Alice Example <alice@example.com>"},
+		Subject: "Fern weekly digest – 98% passed",
+		Text:    "Pass rate: 98%\nTrend: ▁▃█",
+		HTML:    `<p style="color:#16a34a">Pass rate: 98% – a line long enough to need soft line breaks in quoted-printable bodies</p>`,
+		Headers: map[string]string{"List-Unsubscribe": "<https://fern.example/unsubscribe>"},
+	})
+	require.NoError(t, err)
+
+	emails := capture.messages()
+	require.Len(t, emails, 1)
+	assert.Equal(t, " fern secret", emails[0].Auth)
+	assert.Equal(t, "fern@example.com", emails[0].From)
+	assert.Equal(t, []string{"alice@example.com"}, emails[0].To)
+
+	parsed := parseEmail(t, emails[0].Data)
+	assert.Equal(t, "Fern weekly digest – 98% passed", parsed.Subject)
+	assert.Equal(t, `"Fern" <fern@example.com>`, parsed.Header.Get("From"))
+	assert.Equal(t, "Alice Example <alice@example.com>", parsed.Header.Get("To"))
+	assert.Equal(t, "<https://fern.example/unsubscribe>", parsed.Header.Get("List-Unsubscribe"))
+	assert.True(t, strings.HasSuffix(parsed.Header.Get("Message-Id"), "@example.com>"))
+	assert.Equal(t, "Pass rate: 98%\nTrend: ▁▃█", parsed.Text)
+	assert.Contains(t, parsed.HTML, "a line long enough to need soft line breaks in quoted-printable bodies</p>")
+}
+
+func TestSMTPSender_Send_Errors(t *testing.T) {
+	capture := newSMTPCapture(t)
+
+	t.Run("server without STARTTLS", func(t *testing.T) {
+		sender, err := integrations.NewSMTPSender(integrations.SMTPSettings{
+			Host: "127.0.0.1", Port: capture.port(), From: "fern@example.com", TLS: integrations.SMTPTLSStartTLS,
+		})
+		require.NoError(t, err)
+
+		err = sender.Send(context.Background(), integrations.EmailMessage{To: []string{"alice@example.com"}, Text: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STARTTLS")
+	})
+
+	t.Run("rejected message", func(t *testing.T) {
+		capture.failNext(1)
+		err := newCaptureSender(t, capture).Send(context.Background(), integrations.EmailMessage{To: []string{"alice@example.com"}, Text: "hi"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "451")
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		err := newCaptureSender(t, capture).Send(context.Background(), integrations.EmailMessage{To: []string{"not an address"}, Text: "hi"})
+		assert.Error(t, err)
+	})
+
+	assert.Empty(t, capture.messages())
+}
+
+func TestDigestSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	weekly, err := integrations.NewDigestSchedule("Monday", 8, "America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, "weekly", weekly.Name())
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"later in the week", time.Date(2026, 10, 14, 15, 0, 0, 0, newYork), time.Date(2026, 10, 12, 8, 0, 0, 0, newYork)},
+		{"at the scheduled time", time.Date(2026, 10, 12, 8, 0, 0, 0, newYork), time.Date(2026, 10, 12, 8, 0, 0, 0, newYork)},
+		{"just before the scheduled time", time.Date(2026, 10, 12, 7, 59, 0, 0, newYork), time.Date(2026, 10, 5, 8, 0, 0, 0, newYork)},
+		{"in another time zone", time.Date(2026, 10, 12, 11, 30, 0, 0, time.UTC), time.Date(2026, 10, 5, 8, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(weekly.Latest(tt.now)), "got %s", weekly.Latest(tt.now))
+		})
+	}
+
+	// The week daylight saving time ends is an hour longer
+	end := time.Date(2026, 11, 2, 8, 0, 0, 0, newYork)
+	assert.True(t, time.Date(2026, 10, 26, 8, 0, 0, 0, newYork).Equal(weekly.Start(end)))
+	assert.Equal(t, 169*time.Hour, end.Sub(weekly.Start(end)))
+	assert.True(t, end.Equal(weekly.Next(time.Date(2026, 10, 30, 0, 0, 0, 0, newYork))))
+
+	daily, err := integrations.NewDigestSchedule("", 6, "")
+	require.NoError(t, err)
+	assert.Equal(t, "daily", daily.Name())
+	assert.Equal(t, time.Date(2026, 10, 13, 6, 0, 0, 0, time.UTC), daily.Latest(time.Date(2026, 10, 14, 5, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC), daily.Next(time.Date(2026, 10, 14, 5, 0, 0, 0, time.UTC)))
+
+	for _, invalid := range []struct {
+		weekday  string
+		hour     int
+		timeZone string
+	}{
+		{"someday", 8, "UTC"},
+		{"monday", 24, "UTC"},
+		{"monday", 8, "Mars/Olympus_Mons"},
+	} {
+		_, err := integrations.NewDigestSchedule(invalid.weekday, invalid.hour, invalid.timeZone)
+		assert.ErrorIs(t, err, integrations.ErrInvalidDigestSchedule, "%+v", invalid)
+	}
+}
+
+// digestPeriodEnd is the scheduled time of the digests in the tests, a Monday
+var digestPeriodEnd = time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+
+func weeklyDigestSchedule(t *testing.T) integrations.DigestSchedule {
+	t.Helper()
+	schedule, err := integrations.NewDigestSchedule("monday", 8, "UTC")
+	require.NoError(t, err)
+	return schedule
+}
+
+// shopProject is a project with some of everything in the week before digestPeriodEnd
+func shopProject() *integrations.DigestProject {
+	day := func(days int) time.Time { return digestPeriodEnd.AddDate(0, 0, -7+days).Add(2 * time.Hour) }
+	return &integrations.DigestProject{
+		ID:   "shop",
+		Name: "Shop",
+		URL:  "https://fern.example/projects/shop",
+		Runs: []integrations.DigestRun{
+			{StartTime: day(0), PassedTests: 95, FailedTests: 5},
+			{StartTime: day(0).Add(time.Hour), PassedTests: 100},
+			{StartTime: day(3), PassedTests: 80, FailedTests: 20},
+		},
+		PreviousRuns: []integrations.DigestRun{
+			{StartTime: day(-3), PassedTests: 90, FailedTests: 10},
+		},
+		TopFailingSpecs: []integrations.DigestFailingSpec{
+			{SuiteName: "Checkout", SpecName: "applies <discount> codes", Failures: 3},
+		},
+		NewFlakyTests: []integrations.DigestFlakyTest{
+			{SuiteName: "Search", TestName: "finds products", FlakeRate: 0.4, Severity: "high", URL: "https://fern.example/flaky-tests/5"},
+		},
+		ResolvedFlakyTests: []integrations.DigestFlakyTest{
+			{SuiteName: "Cart", TestName: "keeps items"},
+		},
+		SlowestSuites: []integrations.DigestSuite{
+			{Name: "Checkout", AverageDuration: 95 * time.Second, Runs: 3},
+		},
+	}
+}
+
+func TestRenderDigest(t *testing.T) {
+	rendered, err := integrations.RenderDigest(integrations.Digest{
+		Recipient:      integrations.DigestRecipient{Name: "Alice"},
+		Frequency:      "weekly",
+		From:           digestPeriodEnd.AddDate(0, 0, -7),
+		To:             digestPeriodEnd,
+		Projects:       []*integrations.DigestProject{shopProject()},
+		UnsubscribeURL: "https://fern.example/api/v1/digests/unsubscribe?token=abc",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Fern weekly digest, Oct 5 – Oct 12, 2026: 91.7% of tests passed in 3 runs", rendered.Subject)
+
+	for _, expected := range []string{
+		"Hi Alice,",
+		"== Shop ==",
+		"Pass rate: 91.7% over 3 runs (▲ 1.7 pts from the previous week)",
+		"Checkout › applies <discount> codes: 3 failures",
+		"Search › finds products (40% flaky, high)",
+		"Cart › keeps items",
+		"Checkout: 1m35s on average over 3 runs",
+		"https://fern.example/api/v1/digests/unsubscribe?token=abc",
+	} {
+		assert.Contains(t, rendered.Text, expected)
+	}
+
+	assert.Contains(t, rendered.HTML, "applies &lt;discount&gt; codes")
+	assert.NotContains(t, rendered.HTML, "<discount>")
+	assert.Contains(t, rendered.HTML, `href="https://fern.example/flaky-tests/5"`)
+	assert.Contains(t, rendered.HTML, `href="https://fern.example/api/v1/digests/unsubscribe?token=abc"`)
+}
+
+func TestDigestProject_Trend(t *testing.T) {
+	trend := shopProject().Trend(digestPeriodEnd.AddDate(0, 0, -7), digestPeriodEnd)
+	require.Len(t, trend, 7)
+
+	assert.Equal(t, 2, trend[0].Runs)
+	assert.InDelta(t, 0.975, trend[0].PassRate, 0.0001)
+	assert.False(t, trend[1].HasTests)
+	assert.Equal(t, 1, trend[3].Runs)
+	assert.InDelta(t, 0.8, trend[3].PassRate, 0.0001)
+}
+
+// fakeDigestSource serves fixed recipients and projects; opt-outs are read
+// from the store, as the real source reads them from user preferences
+type fakeDigestSource struct {
+	mu         sync.Mutex
+	store      *memoryDigestStore
+	recipients []integrations.DigestRecipient
+	projects   map[string]*integrations.DigestProject
+	loads      map[string]int
+	from, to   time.Time
+}
+
+func (s *fakeDigestSource) withOptOut(recipient integrations.DigestRecipient) integrations.DigestRecipient {
+	optedOut, _ := s.store.IsOptedOut(context.Background(), recipient.UserID)
+	recipient.OptedOut = optedOut
+	return recipient
+}
+
+func (s *fakeDigestSource) Recipients(ctx context.Context) ([]integrations.DigestRecipient, error) {
+	recipients := make([]integrations.DigestRecipient, len(s.recipients))
+	for i, recipient := range s.recipients {
+		recipients[i] = s.withOptOut(recipient)
+	}
+	return recipients, nil
+}
+
+func (s *fakeDigestSource) Recipient(ctx context.Context, userID string) (*integrations.DigestRecipient, error) {
+	for _, recipient := range s.recipients {
+		if recipient.UserID == userID {
+			found := s.withOptOut(recipient)
+			return &found, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+func (s *fakeDigestSource) ProjectHealth(ctx context.Context, projectID string, from, to time.Time) (*integrations.DigestProject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads[projectID]++
+	s.from, s.to = from, to
+	project, ok := s.projects[projectID]
+	if !ok {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+	found := *project
+	return &found, nil
+}
+
+// memoryDigestStore keeps digest deliveries and opt-outs in memory
+type memoryDigestStore struct {
+	mu         sync.Mutex
+	nextID     int
+	deliveries map[string]*integrations.DigestDelivery
+	optedOut   map[string]bool
+}
+
+func newMemoryDigestStore() *memoryDigestStore {
+	return &memoryDigestStore{
+		deliveries: make(map[string]*integrations.DigestDelivery),
+		optedOut:   make(map[string]bool),
+	}
+}
+
+// sortedDeliveries returns copies of the deliveries accepted by keep, oldest first
+func (s *memoryDigestStore) sortedDeliveries(keep func(*integrations.DigestDelivery) bool) []*integrations.DigestDelivery {
+	var deliveries []*integrations.DigestDelivery
+	for _, delivery := range s.deliveries {
+		if keep(delivery) {
+			found := *delivery
+			deliveries = append(deliveries, &found)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, _ := strconv.Atoi(deliveries[i].ID)
+		b, _ := strconv.Atoi(deliveries[j].ID)
+		return a < b
+	})
+	return deliveries
+}
+
+func (s *memoryDigestStore) Reserve(ctx context.Context, delivery *integrations.DigestDelivery) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.deliveries {
+		if existing.UserID == delivery.UserID && existing.PeriodEnd.Equal(delivery.PeriodEnd) {
+			return false, nil
+		}
+	}
+	s.nextID++
+	delivery.ID = strconv.Itoa(s.nextID)
+	stored := *delivery
+	s.deliveries[delivery.ID] = &stored
+	return true, nil
+}
+
+func (s *memoryDigestStore) FindByPeriod(ctx context.Context, periodEnd time.Time) ([]*integrations.DigestDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedDeliveries(func(d *integrations.DigestDelivery) bool { return d.PeriodEnd.Equal(periodEnd) }), nil
+}
+
+func (s *memoryDigestStore) FindByUserID(ctx context.Context, userID string, limit int) ([]*integrations.DigestDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deliveries := s.sortedDeliveries(func(d *integrations.DigestDelivery) bool { return d.UserID == userID })
+	sort.SliceStable(deliveries, func(i, j int) bool { return deliveries[i].PeriodEnd.After(deliveries[j].PeriodEnd) })
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (s *memoryDigestStore) Update(ctx context.Context, delivery *integrations.DigestDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *delivery
+	s.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (s *memoryDigestStore) Claim(ctx context.Context, delivery *integrations.DigestDelivery, leaseUntil time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.deliveries[delivery.ID]
+	if !ok || stored.Status != integrations.DigestPending || stored.NextAttemptAt.After(delivery.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = leaseUntil
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (s *memoryDigestStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed int64
+	for id, delivery := range s.deliveries {
+		if delivery.PeriodEnd.Before(before) {
+			delete(s.deliveries, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (s *memoryDigestStore) IsOptedOut(ctx context.Context, userID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.optedOut[userID], nil
+}
+
+func (s *memoryDigestStore) SetOptedOut(ctx context.Context, userID string, optedOut bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.optedOut[userID] = optedOut
+	return nil
+}
+
+type digestFixture struct {
+	service *integrations.DigestService
+	source  *fakeDigestSource
+	store   *memoryDigestStore
+	capture *smtpCapture
+	clock   *testClock
+}
+
+// newDigestFixture sends digests through a capturing SMTP server, half an
+// hour after digestPeriodEnd
+func newDigestFixture(t *testing.T) *digestFixture {
+	t.Helper()
+	store := newMemoryDigestStore()
+	source := &fakeDigestSource{
+		store: store,
+		recipients: []integrations.DigestRecipient{
+			{UserID: "alice", Email: "alice@example.com", Name: "Alice", ProjectIDs: []string{"shop", "search"}},
+			{UserID: "bob", Email: "bob@example.com", ProjectIDs: []string{"shop"}},
+			{UserID: "carol", Email: "carol@example.com"},
+			{UserID: "dave", Email: "dave@example.com", ProjectIDs: []string{"legacy"}},
+			{UserID: "erin", ProjectIDs: []string{"shop"}},
+		},
+		projects: map[string]*integrations.DigestProject{
+			"shop": shopProject(),
+			"search": {ID: "search", Name: "Search", Runs: []integrations.DigestRun{
+				{StartTime: digestPeriodEnd.Add(-time.Hour), PassedTests: 10},
+			}},
+			"legacy": {ID: "legacy", Name: "Legacy"},
+		},
+		loads: make(map[string]int),
+	}
+	capture := newSMTPCapture(t)
+	clock := &testClock{now: digestPeriodEnd.Add(30 * time.Minute)}
+
+	service := integrations.NewDigestService(source, newCaptureSender(t, capture), store, store, testKeyRing, weeklyDigestSchedule(t), "https://fern.example/")
+	service.SetClock(clock.Now)
+	return &digestFixture{service: service, source: source, store: store, capture: capture, clock: clock}
+}
+
+func (f *digestFixture) emailsTo(address string) []capturedEmail {
+	var emails []capturedEmail
+	for _, email := range f.capture.messages() {
+		for _, to := range email.To {
+			if to == address {
+				emails = append(emails, email)
+			}
+		}
+	}
+	return emails
+}
+
+func TestDigestService_SendDue(t *testing.T) {
+	f := newDigestFixture(t)
+	ctx := context.Background()
+
+	result, err := f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 3, result.Attempted) // alice, bob and dave; carol follows no projects and erin has no email
+	assert.Equal(t, 2, result.Sent)
+	assert.Equal(t, 1, result.Skipped) // Nothing happened in dave's project
+
+	assert.Equal(t, 1, f.source.loads["shop"], "project health is loaded once per run")
+	assert.True(t, digestPeriodEnd.AddDate(0, 0, -7).Equal(f.source.from))
+	assert.True(t, digestPeriodEnd.Equal(f.source.to))
+
+	emails := f.emailsTo("alice@example.com")
+	require.Len(t, emails, 1)
+	parsed := parseEmail(t, emails[0].Data)
+	assert.Contains(t, parsed.Subject, "Fern weekly digest, Oct 5 – Oct 12, 2026")
+	assert.Contains(t, parsed.Text, "== Search ==")
+	assert.Contains(t, parsed.Text, "== Shop ==")
+	assert.Less(t, strings.Index(parsed.Text, "== Search =="), strings.Index(parsed.Text, "== Shop =="))
+	assert.Equal(t, "List-Unsubscribe=One-Click", parsed.Header.Get("List-Unsubscribe-Post"))
+	assert.Len(t, f.emailsTo("bob@example.com"), 1)
+	assert.Empty(t, f.emailsTo("dave@example.com"))
+
+	deliveries, err := f.service.ListDeliveries(ctx, "alice", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, integrations.DigestSent, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Projects)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+
+	skipped, err := f.service.ListDeliveries(ctx, "dave", 10)
+	require.NoError(t, err)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, integrations.DigestSkipped, skipped[0].Status)
+
+	// Later checks in the same period send nothing more
+	f.clock.Set(digestPeriodEnd.Add(6 * time.Hour))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+	assert.Len(t, f.capture.messages(), 2)
+
+	// The next week has its own digest
+	f.clock.Set(digestPeriodEnd.AddDate(0, 0, 7).Add(time.Minute))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Sent)
+	assert.Len(t, f.emailsTo("alice@example.com"), 2)
+}
+
+func TestDigestService_SendDue_SkipsMissedSchedules(t *testing.T) {
+	f := newDigestFixture(t)
+	f.clock.Set(digestPeriodEnd.Add(integrations.DigestSendWindow + time.Minute))
+
+	result, err := f.service.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+	assert.Empty(t, f.capture.messages())
+}
+
+func TestDigestService_SendDue_Retries(t *testing.T) {
+	f := newDigestFixture(t)
+	ctx := context.Background()
+	f.source.recipients = f.source.recipients[:1]
+	f.capture.failNext(integrations.DigestMaxAttempts)
+
+	result, err := f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+
+	// Not due again until the retry delay passed
+	f.clock.Set(f.clock.Now().Add(integrations.DigestRetryDelay(1) - time.Minute))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	f.clock.Set(f.clock.Now().Add(time.Minute))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+
+	f.clock.Set(f.clock.Now().Add(integrations.DigestRetryDelay(2)))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+
+	deliveries, err := f.service.ListDeliveries(ctx, "alice", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, integrations.DigestFailed, deliveries[0].Status)
+	assert.Equal(t, integrations.DigestMaxAttempts, deliveries[0].Attempts)
+	assert.Contains(t, deliveries[0].LastError, "451")
+
+	// Failed digests are not retried
+	f.clock.Set(f.clock.Now().Add(time.Hour))
+	result, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+	assert.Empty(t, f.capture.messages())
+}
+
+func TestDigestService_Unsubscribe(t *testing.T) {
+	f := newDigestFixture(t)
+	ctx := context.Background()
+
+	_, err := f.service.SendDue(ctx)
+	require.NoError(t, err)
+	emails := f.emailsTo("alice@example.com")
+	require.Len(t, emails, 1)
+
+	// The one-click link in the headers is also in the body
+	header := parseEmail(t, emails[0].Data).Header.Get("List-Unsubscribe")
+	link, err := url.Parse(strings.Trim(header, "<>"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://fern.example/api/v1/digests/unsubscribe", link.Scheme+"://"+link.Host+link.Path)
+	assert.Contains(t, parseEmail(t, emails[0].Data).Text, link.String())
+
+	require.NoError(t, f.service.Unsubscribe(ctx, link.Query().Get("token")))
+	subscribed, err := f.service.Subscribed(ctx, "alice")
+	require.NoError(t, err)
+	assert.False(t, subscribed)
+
+	f.clock.Set(digestPeriodEnd.AddDate(0, 0, 7).Add(time.Minute))
+	_, err = f.service.SendDue(ctx)
+	require.NoError(t, err)
+	assert.Len(t, f.emailsTo("alice@example.com"), 1, "unsubscribed users get no more digests")
+	assert.Len(t, f.emailsTo("bob@example.com"), 2)
+
+	require.NoError(t, f.service.SetSubscribed(ctx, "alice", true))
+	subscribed, err = f.service.Subscribed(ctx, "alice")
+	require.NoError(t, err)
+	assert.True(t, subscribed)
+}
+
+func TestDigestService_Unsubscribe_InvalidToken(t *testing.T) {
+	f := newDigestFixture(t)
+
+	otherSecret, err := testKeyRing.Encrypt("alice")
+	require.NoError(t, err)
+
+	for _, token := range []string{"", "not-a-token", otherSecret} {
+		assert.ErrorIs(t, f.service.Unsubscribe(context.Background(), token), integrations.ErrInvalidUnsubscribeToken)
+	}
+	assert.Empty(t, f.store.optedOut)
+}
+
+func TestDigestService_Preview(t *testing.T) {
+	f := newDigestFixture(t)
+
+	rendered, err := f.service.Preview(context.Background(), "dave")
+	require.NoError(t, err)
+	assert.Contains(t, rendered.Text, "== Legacy ==")
+	assert.Contains(t, rendered.Text, "No test runs.")
+	assert.Empty(t, f.capture.messages(), "previews are not sent")
+}
+
+func TestDigestService_PruneDeliveries(t *testing.T) {
+	f := newDigestFixture(t)
+	ctx := context.Background()
+
+	_, err := f.service.SendDue(ctx)
+	require.NoError(t, err)
+
+	removed, err := f.service.PruneDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	f.clock.Set(digestPeriodEnd.Add(integrations.DigestRetention + time.Hour))
+	removed, err = f.service.PruneDeliveries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), removed)
+}
+
+func TestDigestRetryDelay(t *testing.T) {
+	assert.Equal(t, integrations.DigestRetryBaseDelay, integrations.DigestRetryDelay(1))
+	assert.Equal(t, 2*integrations.DigestRetryBaseDelay, integrations.DigestRetryDelay(2))
+	assert.Equal(t, integrations.DigestRetryMaxDelay, integrations.DigestRetryDelay(10))
+}
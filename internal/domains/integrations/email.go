@@ -0,0 +1,254 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPTLSMode selects how the connection to an SMTP server is secured
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSNone sends email in plain text, for servers on a trusted network
+	SMTPTLSNone SMTPTLSMode = "none"
+	// SMTPTLSStartTLS upgrades the connection with STARTTLS, which the server must offer
+	SMTPTLSStartTLS SMTPTLSMode = "starttls"
+	// SMTPTLSImplicit connects over TLS from the start, usually on port 465
+	SMTPTLSImplicit SMTPTLSMode = "tls"
+)
+
+// defaultSMTPTimeout bounds sending one email when no timeout is configured
+const defaultSMTPTimeout = 30 * time.Second
+
+// ErrInvalidSMTPSettings is returned when the SMTP settings are incomplete or invalid
+var ErrInvalidSMTPSettings = errors.New("invalid SMTP settings")
+
+// EmailMessage is an email with alternative plain text and HTML bodies
+type EmailMessage struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string // Additional headers, such as List-Unsubscribe
+}
+
+// EmailSender sends email
+type EmailSender interface {
+	Send(ctx context.Context, message EmailMessage) error
+}
+
+// SMTPSettings configure the SMTP server email is sent through
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string // Empty for servers without authentication
+	Password string
+	From     string // Sender address, optionally with a name
+	TLS      SMTPTLSMode
+	Timeout  time.Duration
+}
+
+// SMTPSender sends email through an SMTP server
+type SMTPSender struct {
+	settings  SMTPSettings
+	from      *mail.Address
+	tlsConfig *tls.Config
+}
+
+// NewSMTPSender creates a sender for the SMTP server in settings
+func NewSMTPSender(settings SMTPSettings) (*SMTPSender, error) {
+	if settings.Host == "" {
+		return nil, fmt.Errorf("%w: host is required", ErrInvalidSMTPSettings)
+	}
+	if settings.Port <= 0 || settings.Port > 65535 {
+		return nil, fmt.Errorf("%w: port must be between 1 and 65535", ErrInvalidSMTPSettings)
+	}
+	from, err := mail.ParseAddress(settings.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sender address: %v", ErrInvalidSMTPSettings, err)
+	}
+	switch settings.TLS {
+	case "":
+		settings.TLS = SMTPTLSStartTLS
+	case SMTPTLSNone, SMTPTLSStartTLS, SMTPTLSImplicit:
+	default:
+		return nil, fmt.Errorf("%w: unknown TLS mode %q", ErrInvalidSMTPSettings, settings.TLS)
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultSMTPTimeout
+	}
+
+	return &SMTPSender{
+		settings:  settings,
+		from:      from,
+		tlsConfig: &tls.Config{ServerName: settings.Host, MinVersion: tls.VersionTLS12},
+	}, nil
+}
+
+// Send delivers a message to its recipients in one SMTP transaction
+func (s *SMTPSender) Send(ctx context.Context, message EmailMessage) error {
+	if len(message.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+	recipients := make([]string, len(message.To))
+	for i, to := range message.To {
+		address, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		recipients[i] = address.Address
+	}
+	body, err := s.buildMessage(message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.settings.Timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(s.settings.Host, strconv.Itoa(s.settings.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if s.settings.TLS == SMTPTLSImplicit {
+		conn = tls.Client(conn, s.tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.settings.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if s.settings.TLS == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(s.tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.settings.Username != "" {
+		auth := smtp.PlainAuth("", s.settings.Username, s.settings.Password, s.settings.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP server rejected recipient %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage encodes a message as a multipart/alternative MIME message
+// with quoted-printable UTF-8 bodies
+func (s *SMTPSender) buildMessage(message EmailMessage, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	headers := map[string]string{
+		"From":         s.from.String(),
+		"To":           strings.Join(message.To, ", "),
+		"Subject":      mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date":         date.Format(time.RFC1123Z),
+		"Message-ID":   fmt.Sprintf("<%s@%s>", randomMessageID(), s.messageIDDomain()),
+		"MIME-Version": "1.0",
+		"Content-Type": fmt.Sprintf("multipart/alternative; boundary=%q", parts.Boundary()),
+	}
+	for name, value := range message.Headers {
+		headers[textproto.CanonicalMIMEHeaderKey(name)] = value
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var head bytes.Buffer
+	for _, name := range names {
+		value := strings.NewReplacer("\r", "", "\n", "").Replace(headers[name])
+		fmt.Fprintf(&head, "%s: %s\r\n", name, value)
+	}
+	head.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode message: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	return append(head.Bytes(), buf.Bytes()...), nil
+}
+
+// messageIDDomain returns the domain of the sender address, which message IDs are scoped to
+func (s *SMTPSender) messageIDDomain() string {
+	if _, domain, ok := strings.Cut(s.from.Address, "@"); ok && domain != "" {
+		return domain
+	}
+	return s.settings.Host
+}
+
+// randomMessageID returns the unique local part of a message ID
+func randomMessageID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -1,12 +1,17 @@
 package integrations
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -97,6 +102,306 @@ func (c *DefaultJiraClient) GetProject(ctx context.Context, url, projectKey, use
 	return &project, nil
 }
 
+// CreateIssue creates a JIRA issue
+func (c *DefaultJiraClient) CreateIssue(ctx context.Context, url, username, credential string, authType AuthenticationType, issue JiraIssueRequest) (*JiraIssue, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue", url)
+
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": issue.ProjectKey},
+		"issuetype":   map[string]string{"name": issue.IssueType},
+		"summary":     issue.Summary,
+		"description": issue.Description,
+	}
+	if len(issue.Labels) > 0 {
+		fields["labels"] = issue.Labels
+	}
+	for id, value := range issue.Fields {
+		// Core fields are always taken from the request itself
+		if _, ok := fields[id]; !ok {
+			fields[id] = value
+		}
+	}
+	body, err := json.Marshal(map[string]interface{}{"fields": fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issue: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set authentication header
+	c.setAuthHeader(req, username, credential, authType)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to JIRA: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errorBody struct {
+			ErrorMessages []string          `json:"errorMessages"`
+			Errors        map[string]string `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err == nil {
+			messages := errorBody.ErrorMessages
+			fieldNames := make([]string, 0, len(errorBody.Errors))
+			for field := range errorBody.Errors {
+				fieldNames = append(fieldNames, field)
+			}
+			sort.Strings(fieldNames)
+			for _, field := range fieldNames {
+				messages = append(messages, fmt.Sprintf("%s: %s", field, errorBody.Errors[field]))
+			}
+			if len(messages) > 0 {
+				return nil, fmt.Errorf("failed to create issue: status %d, message: %s", resp.StatusCode, strings.Join(messages, "; "))
+			}
+		}
+		return nil, fmt.Errorf("failed to create issue: status %d", resp.StatusCode)
+	}
+
+	var created JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to parse issue response: %w", err)
+	}
+	if created.Key == "" {
+		return nil, fmt.Errorf("failed to parse issue response: missing issue key")
+	}
+
+	return &created, nil
+}
+
+// GetFields retrieves the fields defined in a JIRA instance
+func (c *DefaultJiraClient) GetFields(ctx context.Context, url, username, credential string, authType AuthenticationType) ([]JiraField, error) {
+	var response []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Custom bool   `json:"custom"`
+		Schema struct {
+			Type  string `json:"type"`
+			Items string `json:"items"`
+		} `json:"schema"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/rest/api/2/field", url), username, credential, authType, "fields", &response); err != nil {
+		return nil, err
+	}
+
+	fields := make([]JiraField, len(response))
+	for i, field := range response {
+		fields[i] = JiraField{
+			ID:          field.ID,
+			Name:        field.Name,
+			Custom:      field.Custom,
+			SchemaType:  field.Schema.Type,
+			SchemaItems: field.Schema.Items,
+		}
+	}
+	return fields, nil
+}
+
+// GetIssueTypes retrieves the issue types defined in a JIRA instance
+func (c *DefaultJiraClient) GetIssueTypes(ctx context.Context, url, username, credential string, authType AuthenticationType) ([]JiraIssueType, error) {
+	var response []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		IconURL     string `json:"iconUrl"`
+		Subtask     bool   `json:"subtask"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/rest/api/2/issuetype", url), username, credential, authType, "issue types", &response); err != nil {
+		return nil, err
+	}
+
+	issueTypes := make([]JiraIssueType, len(response))
+	for i, issueType := range response {
+		issueTypes[i] = JiraIssueType{
+			ID:          issueType.ID,
+			Name:        issueType.Name,
+			Description: issueType.Description,
+			IconURL:     issueType.IconURL,
+			Subtask:     issueType.Subtask,
+		}
+	}
+	return issueTypes, nil
+}
+
+// jiraTimeLayout is the timestamp format used by the JIRA REST API
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// SearchIssues retrieves the workflow status of the issues matching a JQL query
+func (c *DefaultJiraClient) SearchIssues(ctx context.Context, url, username, credential string, authType AuthenticationType, jql string, maxResults int) ([]JiraIssueStatus, error) {
+	request := map[string]interface{}{
+		"jql":        jql,
+		"maxResults": maxResults,
+		"fields":     []string{"status", "resolutiondate"},
+	}
+	var response struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Status struct {
+					Name           string `json:"name"`
+					StatusCategory struct {
+						Key string `json:"key"`
+					} `json:"statusCategory"`
+				} `json:"status"`
+				ResolutionDate string `json:"resolutiondate"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := c.sendJSON(ctx, "POST", fmt.Sprintf("%s/rest/api/2/search", url), username, credential, authType, "search issues", request, &response); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]JiraIssueStatus, len(response.Issues))
+	for i, issue := range response.Issues {
+		statuses[i] = JiraIssueStatus{
+			Key:            issue.Key,
+			Status:         issue.Fields.Status.Name,
+			StatusCategory: issue.Fields.Status.StatusCategory.Key,
+		}
+		if issue.Fields.ResolutionDate != "" {
+			if resolvedAt, err := time.Parse(jiraTimeLayout, issue.Fields.ResolutionDate); err == nil {
+				statuses[i].ResolvedAt = &resolvedAt
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// AddComment adds a comment to an issue
+func (c *DefaultJiraClient) AddComment(ctx context.Context, url, username, credential string, authType AuthenticationType, issueKey, body string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/comment", url, neturl.PathEscape(issueKey))
+	return c.sendJSON(ctx, "POST", endpoint, username, credential, authType, "add comment", map[string]string{"body": body}, nil)
+}
+
+// ReopenIssue moves a closed issue back to an open status using the first
+// available transition that leaves the done category, preferring one named
+// after reopening
+func (c *DefaultJiraClient) ReopenIssue(ctx context.Context, url, username, credential string, authType AuthenticationType, issueKey string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/transitions", url, neturl.PathEscape(issueKey))
+
+	var response struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name           string `json:"name"`
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.getJSON(ctx, endpoint, username, credential, authType, "transitions", &response); err != nil {
+		return err
+	}
+
+	transitionID := ""
+	for _, transition := range response.Transitions {
+		if transition.To.StatusCategory.Key == JiraStatusCategoryDone {
+			continue
+		}
+		name := strings.ToLower(transition.Name + " " + transition.To.Name)
+		if strings.Contains(name, "reopen") {
+			transitionID = transition.ID
+			break
+		}
+		if transitionID == "" {
+			transitionID = transition.ID
+		}
+	}
+	if transitionID == "" {
+		return fmt.Errorf("no transition reopens issue %s", issueKey)
+	}
+
+	request := map[string]interface{}{"transition": map[string]string{"id": transitionID}}
+	return c.sendJSON(ctx, "POST", endpoint, username, credential, authType, "reopen issue", request, nil)
+}
+
+// sendJSON sends an authenticated request with a JSON body and decodes the
+// JSON response into out when it is not nil
+func (c *DefaultJiraClient) sendJSON(ctx context.Context, method, endpoint, username, credential string, authType AuthenticationType, action string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set authentication header
+	c.setAuthHeader(req, username, credential, authType)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to JIRA: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := rateLimitError(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to %s: status %d", action, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", action, err)
+	}
+	return nil
+}
+
+// rateLimitError returns a JiraRateLimitError when the response is a 429
+func rateLimitError(resp *http.Response) error {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	rateLimited := &JiraRateLimitError{}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		rateLimited.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return rateLimited
+}
+
+// getJSON sends an authenticated GET request and decodes the JSON response
+func (c *DefaultJiraClient) getJSON(ctx context.Context, endpoint, username, credential string, authType AuthenticationType, resource string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set authentication header
+	c.setAuthHeader(req, username, credential, authType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to JIRA: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := rateLimitError(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: status %d", resource, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", resource, err)
+	}
+	return nil
+}
+
 // setAuthHeader sets the appropriate authentication header
 func (c *DefaultJiraClient) setAuthHeader(req *http.Request, username, credential string, authType AuthenticationType) {
 	switch authType {
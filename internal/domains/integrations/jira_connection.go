@@ -27,16 +27,24 @@ type JiraConnection struct {
 	username           string
 	encryptedCredential string
 	status             ConnectionStatus
+	statusReason       string
 	isActive           bool
+	oauth              JiraOAuthState
 	lastTestedAt       *time.Time
 	createdAt          time.Time
 	updatedAt          time.Time
 }
 
-// JiraClient interface for testing connections
+// JiraClient interface for communicating with a JIRA instance
 type JiraClient interface {
 	TestConnection(ctx context.Context, url, username, credential string, authType AuthenticationType) error
 	GetProject(ctx context.Context, url, projectKey, username, credential string, authType AuthenticationType) (*JiraProject, error)
+	CreateIssue(ctx context.Context, url, username, credential string, authType AuthenticationType, issue JiraIssueRequest) (*JiraIssue, error)
+	GetFields(ctx context.Context, url, username, credential string, authType AuthenticationType) ([]JiraField, error)
+	GetIssueTypes(ctx context.Context, url, username, credential string, authType AuthenticationType) ([]JiraIssueType, error)
+	SearchIssues(ctx context.Context, url, username, credential string, authType AuthenticationType, jql string, maxResults int) ([]JiraIssueStatus, error)
+	AddComment(ctx context.Context, url, username, credential string, authType AuthenticationType, issueKey, body string) error
+	ReopenIssue(ctx context.Context, url, username, credential string, authType AuthenticationType, issueKey string) error
 }
 
 // NewJiraConnection creates a new JIRA connection
@@ -53,11 +61,14 @@ func NewJiraConnection(projectID, name, jiraURL string, authType AuthenticationT
 	if projectKey == "" {
 		return nil, errors.New("project key is required")
 	}
-	if username == "" {
-		return nil, errors.New("username is required")
-	}
-	if credential == "" {
-		return nil, errors.New("credential is required")
+	// OAuth connections are authorized afterwards, so they start without a credential
+	if authType != AuthTypeOAuth {
+		if username == "" {
+			return nil, errors.New("username is required")
+		}
+		if credential == "" {
+			return nil, errors.New("credential is required")
+		}
 	}
 
 	now := time.Now()
@@ -117,11 +128,26 @@ func (j *JiraConnection) Status() ConnectionStatus {
 	return j.status
 }
 
+// StatusReason returns why the connection failed, if it did
+func (j *JiraConnection) StatusReason() string {
+	return j.statusReason
+}
+
 // IsActive returns whether the connection is active
 func (j *JiraConnection) IsActive() bool {
 	return j.isActive
 }
 
+// OAuthState returns the OAuth token state of the connection (for repository use only)
+func (j *JiraConnection) OAuthState() JiraOAuthState {
+	return j.oauth
+}
+
+// IsOAuthAuthorized returns whether the connection was authorized through the OAuth flow
+func (j *JiraConnection) IsOAuthAuthorized() bool {
+	return j.authenticationType == AuthTypeOAuth && j.oauth.CloudID != ""
+}
+
 // GetEncryptedCredentialDirect returns the encrypted credential directly (for repository use only)
 func (j *JiraConnection) GetEncryptedCredentialDirect() string {
 	return j.encryptedCredential
@@ -163,17 +189,21 @@ func (j *JiraConnection) UpdateConnectionInfo(name, jiraURL, projectKey string)
 
 // UpdateCredentials updates the authentication credentials
 func (j *JiraConnection) UpdateCredentials(authType AuthenticationType, username, credential string) error {
-	if username == "" {
-		return errors.New("username is required")
-	}
-	if credential == "" {
-		return errors.New("credential is required")
+	if authType != AuthTypeOAuth {
+		if username == "" {
+			return errors.New("username is required")
+		}
+		if credential == "" {
+			return errors.New("credential is required")
+		}
 	}
 
 	j.authenticationType = authType
 	j.username = username
 	j.encryptedCredential = credential // Will be encrypted when saved
+	j.oauth = JiraOAuthState{}         // New credentials replace any OAuth authorization
 	j.status = ConnectionStatusPending // Reset status when credentials change
+	j.statusReason = ""
 	j.updatedAt = time.Now()
 	return nil
 }
@@ -183,21 +213,59 @@ func (j *JiraConnection) TestConnection(ctx context.Context, client JiraClient)
 	log.Printf("[JiraConnection] Testing connection for ID: %s, URL: %s", j.id, j.jiraURL)
 	
 	err := client.TestConnection(ctx, j.jiraURL, j.username, j.encryptedCredential, j.authenticationType)
+	return j.recordTest(err)
+}
+
+// recordTest records the result of a connection test
+func (j *JiraConnection) recordTest(err error) error {
 	now := time.Now()
 	j.lastTestedAt = &now
 	j.updatedAt = now
 
 	if err != nil {
 		j.status = ConnectionStatusFailed
+		j.statusReason = err.Error()
 		log.Printf("[JiraConnection] Test failed for %s: %v", j.jiraURL, err)
 		return fmt.Errorf("connection test failed: %w", err)
 	}
 
 	j.status = ConnectionStatusConnected
+	j.statusReason = ""
 	log.Printf("[JiraConnection] Test successful for %s, status updated to Connected", j.jiraURL)
 	return nil
 }
 
+// AuthorizeOAuth stores the encrypted tokens of a completed OAuth authorization
+// for the given Atlassian site and marks the connection connected
+func (j *JiraConnection) AuthorizeOAuth(encryptedAccessToken, encryptedRefreshToken string, expiresAt time.Time, cloudID string) {
+	j.encryptedCredential = encryptedAccessToken
+	j.oauth = JiraOAuthState{
+		CloudID:               cloudID,
+		EncryptedRefreshToken: encryptedRefreshToken,
+		TokenExpiresAt:        &expiresAt,
+	}
+	now := time.Now()
+	j.status = ConnectionStatusConnected
+	j.statusReason = ""
+	j.lastTestedAt = &now
+	j.updatedAt = now
+}
+
+// RefreshOAuth stores refreshed encrypted tokens
+func (j *JiraConnection) RefreshOAuth(encryptedAccessToken, encryptedRefreshToken string, expiresAt time.Time) {
+	j.encryptedCredential = encryptedAccessToken
+	j.oauth.EncryptedRefreshToken = encryptedRefreshToken
+	j.oauth.TokenExpiresAt = &expiresAt
+	j.updatedAt = time.Now()
+}
+
+// MarkFailed sets the connection to failed with the reason shown to users
+func (j *JiraConnection) MarkFailed(reason string) {
+	j.status = ConnectionStatusFailed
+	j.statusReason = reason
+	j.updatedAt = time.Now()
+}
+
 // Activate activates the connection
 func (j *JiraConnection) Activate() {
 	j.isActive = true
@@ -212,12 +280,17 @@ func (j *JiraConnection) Deactivate() {
 
 // GetEncryptedCredential returns the credential encrypted with the provided key
 func (j *JiraConnection) GetEncryptedCredential(key []byte) (string, error) {
+	return EncryptCredential(j.encryptedCredential, key)
+}
+
+// EncryptCredential encrypts a credential with the provided key
+func EncryptCredential(credential string, key []byte) (string, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to create cipher: %w", err)
 	}
 
-	plaintext := []byte(j.encryptedCredential)
+	plaintext := []byte(credential)
 	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
 	iv := ciphertext[:aes.BlockSize]
 
@@ -267,7 +340,10 @@ func (j *JiraConnection) Snapshot() JiraConnectionSnapshot {
 		ProjectKey:         j.projectKey,
 		Username:           j.username,
 		Status:             j.status,
+		StatusReason:       j.statusReason,
 		IsActive:           j.isActive,
+		CloudID:            j.oauth.CloudID,
+		TokenExpiresAt:     j.oauth.TokenExpiresAt,
 		LastTestedAt:       j.lastTestedAt,
 		CreatedAt:          j.createdAt,
 		UpdatedAt:          j.updatedAt,
@@ -284,19 +360,33 @@ type JiraConnectionSnapshot struct {
 	ProjectKey         string
 	Username           string
 	Status             ConnectionStatus
+	StatusReason       string
 	IsActive           bool
+	CloudID            string
+	TokenExpiresAt     *time.Time
 	LastTestedAt       *time.Time
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
 
+// JiraOAuthState is the OAuth token state of a connection authorized through
+// the Atlassian 3LO flow. The access token is stored as the credential.
+type JiraOAuthState struct {
+	// CloudID identifies the authorized Atlassian site
+	CloudID               string
+	EncryptedRefreshToken string
+	TokenExpiresAt        *time.Time
+}
+
 // ReconstructJiraConnection reconstructs a JiraConnection from persisted data
 func ReconstructJiraConnection(
 	id, projectID, name, jiraURL string,
 	authType AuthenticationType,
 	projectKey, username, encryptedCredential string,
 	status ConnectionStatus,
+	statusReason string,
 	isActive bool,
+	oauth JiraOAuthState,
 	lastTestedAt *time.Time,
 	createdAt, updatedAt time.Time,
 ) *JiraConnection {
@@ -310,7 +400,9 @@ func ReconstructJiraConnection(
 		username:            username,
 		encryptedCredential: encryptedCredential,
 		status:              status,
+		statusReason:        statusReason,
 		isActive:            isActive,
+		oauth:               oauth,
 		lastTestedAt:        lastTestedAt,
 		createdAt:           createdAt,
 		updatedAt:           updatedAt,
@@ -410,4 +410,62 @@ func (m *mockJiraClient) GetProject(ctx context.Context, url, projectKey, userna
 		Key:  projectKey,
 		Name: "Test Project",
 	}, nil
-}
\ No newline at end of file
+}
+func (m *mockJiraClient) CreateIssue(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issue integrations.JiraIssueRequest) (*integrations.JiraIssue, error) {
+	if !m.shouldSucceed {
+		return nil, assert.AnError
+	}
+	return &integrations.JiraIssue{
+		ID:   "10001",
+		Key:  issue.ProjectKey + "-1",
+		Self: url + "/rest/api/2/issue/10001",
+	}, nil
+}
+
+func (m *mockJiraClient) GetFields(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType) ([]integrations.JiraField, error) {
+	if !m.shouldSucceed {
+		return nil, assert.AnError
+	}
+	return []integrations.JiraField{
+		{ID: "summary", Name: "Summary", SchemaType: "string"},
+		{ID: "description", Name: "Description", SchemaType: "string"},
+		{ID: "labels", Name: "Labels", SchemaType: "array", SchemaItems: "string"},
+		{ID: "status", Name: "Status", SchemaType: "status"},
+		{ID: "customfield_10001", Name: "Story Points", Custom: true, SchemaType: "number"},
+		{ID: "customfield_10010", Name: "Test Owner", Custom: true, SchemaType: "string"},
+		{ID: "customfield_10011", Name: "Fern Link", Custom: true, SchemaType: "string"},
+		{ID: "customfield_10012", Name: "Components Hit", Custom: true, SchemaType: "array", SchemaItems: "string"},
+	}, nil
+}
+
+func (m *mockJiraClient) GetIssueTypes(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType) ([]integrations.JiraIssueType, error) {
+	if !m.shouldSucceed {
+		return nil, assert.AnError
+	}
+	return []integrations.JiraIssueType{
+		{ID: "10001", Name: "Bug"},
+		{ID: "10002", Name: "Task"},
+		{ID: "10003", Name: "Sub-task", Subtask: true},
+	}, nil
+}
+
+func (m *mockJiraClient) SearchIssues(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, jql string, maxResults int) ([]integrations.JiraIssueStatus, error) {
+	if !m.shouldSucceed {
+		return nil, assert.AnError
+	}
+	return nil, nil
+}
+
+func (m *mockJiraClient) AddComment(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issueKey, body string) error {
+	if !m.shouldSucceed {
+		return assert.AnError
+	}
+	return nil
+}
+
+func (m *mockJiraClient) ReopenIssue(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issueKey string) error {
+	if !m.shouldSucceed {
+		return assert.AnError
+	}
+	return nil
+}
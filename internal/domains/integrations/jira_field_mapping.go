@@ -0,0 +1,233 @@
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrFieldMappingNotConfigured is returned when field mapping storage has not been enabled on the service
+var ErrFieldMappingNotConfigured = errors.New("JIRA field mapping is not configured")
+
+// FernField identifies a test result value that can be mapped to a JIRA field
+type FernField string
+
+const (
+	// FernFieldTestName is the suite and name of the test
+	FernFieldTestName FernField = "test_name"
+	// FernFieldProject is the Fern project the test belongs to
+	FernFieldProject FernField = "project"
+	// FernFieldError is the error message of the representative failure
+	FernFieldError FernField = "error"
+	// FernFieldSeverity is the severity of a flaky test
+	FernFieldSeverity FernField = "severity"
+	// FernFieldOwner is the owners of the test
+	FernFieldOwner FernField = "owner"
+	// FernFieldRunURL is the link back to the failure or flaky test in Fern
+	FernFieldRunURL FernField = "run_url"
+)
+
+// FernFieldDefinition describes a Fern field available for mapping
+type FernFieldDefinition struct {
+	Field       FernField
+	Name        string
+	Description string
+	Required    bool
+}
+
+// fernFields lists the mappable Fern fields in display order
+var fernFields = []FernFieldDefinition{
+	{Field: FernFieldTestName, Name: "Test Name", Description: "Suite and name of the failing test", Required: true},
+	{Field: FernFieldProject, Name: "Project", Description: "Fern project the test belongs to"},
+	{Field: FernFieldError, Name: "Error", Description: "Error message of the failure", Required: true},
+	{Field: FernFieldSeverity, Name: "Severity", Description: "Severity of the flaky test"},
+	{Field: FernFieldOwner, Name: "Owner", Description: "Owners of the test"},
+	{Field: FernFieldRunURL, Name: "Run URL", Description: "Link to the failure or flaky test in Fern"},
+}
+
+// FernFields returns the Fern fields that can be mapped to JIRA fields
+func FernFields() []FernFieldDefinition {
+	return append([]FernFieldDefinition(nil), fernFields...)
+}
+
+// reservedJiraFields are set from the connection and mapping itself and cannot be mapped
+var reservedJiraFields = map[string]bool{
+	"project":   true,
+	"issuetype": true,
+	"labels":    true,
+}
+
+// JiraFieldMapping maps Fern fields onto the JIRA fields of issues filed through a connection
+type JiraFieldMapping struct {
+	ConnectionID string
+	ProjectID    string
+	// IssueType is the name of the JIRA issue type issues are filed as
+	IssueType string
+	// Fields maps Fern fields to JIRA field IDs
+	Fields    map[FernField]string
+	UpdatedBy string
+	UpdatedAt time.Time
+	// IsDefault is true when no mapping has been saved for the connection
+	IsDefault bool
+}
+
+// DefaultFieldMapping returns the mapping used when none has been saved.
+// It matches the summary and description built by the issue template.
+func DefaultFieldMapping(connectionID, projectID string) *JiraFieldMapping {
+	return &JiraFieldMapping{
+		ConnectionID: connectionID,
+		ProjectID:    projectID,
+		IssueType:    DefaultIssueType,
+		Fields: map[FernField]string{
+			FernFieldTestName: "summary",
+			FernFieldError:    "description",
+		},
+		IsDefault: true,
+	}
+}
+
+// FieldMappingValidationError lists the problems found in a field mapping
+type FieldMappingValidationError struct {
+	Problems []string
+}
+
+func (e *FieldMappingValidationError) Error() string {
+	return "invalid field mapping: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateFieldMapping checks a mapping against the fields and issue types of a JIRA instance
+func ValidateFieldMapping(mapping *JiraFieldMapping, fields []JiraField, issueTypes []JiraIssueType) error {
+	var problems []string
+
+	issueTypeFound := false
+	for _, issueType := range issueTypes {
+		if issueType.Name == mapping.IssueType {
+			issueTypeFound = true
+			if issueType.Subtask {
+				problems = append(problems, fmt.Sprintf("issue type %q is a sub-task type", mapping.IssueType))
+			}
+			break
+		}
+	}
+	if mapping.IssueType == "" {
+		problems = append(problems, "issue type is required")
+	} else if !issueTypeFound {
+		problems = append(problems, fmt.Sprintf("issue type %q does not exist in JIRA", mapping.IssueType))
+	}
+
+	known := make(map[FernField]bool, len(fernFields))
+	for _, definition := range fernFields {
+		known[definition.Field] = true
+		if definition.Required && mapping.Fields[definition.Field] == "" {
+			problems = append(problems, fmt.Sprintf("required field %q is not mapped", definition.Field))
+		}
+	}
+
+	jiraFields := make(map[string]JiraField, len(fields))
+	for _, field := range fields {
+		jiraFields[field.ID] = field
+	}
+
+	// Report in a stable order
+	fernKeys := make([]string, 0, len(mapping.Fields))
+	for fernField := range mapping.Fields {
+		fernKeys = append(fernKeys, string(fernField))
+	}
+	sort.Strings(fernKeys)
+
+	mappedTo := make(map[string]FernField, len(mapping.Fields))
+	for _, key := range fernKeys {
+		fernField := FernField(key)
+		jiraFieldID := mapping.Fields[fernField]
+		if !known[fernField] {
+			problems = append(problems, fmt.Sprintf("unknown Fern field %q", fernField))
+			continue
+		}
+		if jiraFieldID == "" {
+			continue
+		}
+		if reservedJiraFields[jiraFieldID] {
+			problems = append(problems, fmt.Sprintf("JIRA field %q cannot be mapped", jiraFieldID))
+			continue
+		}
+		field, ok := jiraFields[jiraFieldID]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("JIRA field %q does not exist", jiraFieldID))
+			continue
+		}
+		if !acceptsText(field) {
+			problems = append(problems, fmt.Sprintf("JIRA field %q of type %q cannot hold %s", field.Name, field.SchemaType, fernField))
+			continue
+		}
+		if other, ok := mappedTo[jiraFieldID]; ok {
+			problems = append(problems, fmt.Sprintf("JIRA field %q is mapped from both %s and %s", field.Name, other, fernField))
+			continue
+		}
+		mappedTo[jiraFieldID] = fernField
+	}
+
+	if len(problems) > 0 {
+		return &FieldMappingValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// acceptsText reports whether a JIRA field can be set from a text value
+func acceptsText(field JiraField) bool {
+	return field.SchemaType == "string" || (field.SchemaType == "array" && field.SchemaItems == "string")
+}
+
+// fieldValues returns the JIRA field values for an issue source. Summary and
+// description are always built by the issue template, so they are skipped.
+func (m *JiraFieldMapping) fieldValues(source *IssueSource, fields []JiraField) map[string]interface{} {
+	byID := make(map[string]JiraField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+
+	values := make(map[string]interface{})
+	for fernField, jiraFieldID := range m.Fields {
+		if jiraFieldID == "" || jiraFieldID == "summary" || jiraFieldID == "description" {
+			continue
+		}
+		value := source.fernFieldValue(fernField)
+		if value == "" {
+			continue
+		}
+		if field, ok := byID[jiraFieldID]; ok && field.SchemaType == "array" {
+			// Array fields such as labels cannot contain spaces
+			values[jiraFieldID] = []string{strings.Join(strings.Fields(value), "_")}
+			continue
+		}
+		values[jiraFieldID] = value
+	}
+	return values
+}
+
+// fernFieldValue returns the value of a Fern field for an issue source
+func (s *IssueSource) fernFieldValue(field FernField) string {
+	switch field {
+	case FernFieldTestName:
+		if s.SuiteName != "" {
+			return s.SuiteName + " > " + s.TestName
+		}
+		return s.TestName
+	case FernFieldProject:
+		return s.ProjectID
+	case FernFieldError:
+		if len(s.Failures) > 0 {
+			return s.Failures[0].ErrorMessage
+		}
+	case FernFieldSeverity:
+		if s.Flaky != nil {
+			return s.Flaky.Severity
+		}
+	case FernFieldOwner:
+		return strings.Join(s.Owners, ", ")
+	case FernFieldRunURL:
+		return s.URL
+	}
+	return ""
+}
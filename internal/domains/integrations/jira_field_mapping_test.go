@@ -0,0 +1,245 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryFieldMappingRepo is an in-memory JiraFieldMappingRepository
+type memoryFieldMappingRepo struct {
+	mappings map[string]*integrations.JiraFieldMapping
+}
+
+func (r *memoryFieldMappingRepo) Save(ctx context.Context, mapping *integrations.JiraFieldMapping) error {
+	if r.mappings == nil {
+		r.mappings = make(map[string]*integrations.JiraFieldMapping)
+	}
+	r.mappings[mapping.ConnectionID] = mapping
+	return nil
+}
+
+func (r *memoryFieldMappingRepo) FindByConnectionID(ctx context.Context, connectionID string) (*integrations.JiraFieldMapping, error) {
+	return r.mappings[connectionID], nil
+}
+
+// countingJiraClient counts metadata requests
+type countingJiraClient struct {
+	recordingJiraClient
+	fieldCalls     int
+	issueTypeCalls int
+}
+
+func (c *countingJiraClient) GetFields(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType) ([]integrations.JiraField, error) {
+	c.fieldCalls++
+	return c.recordingJiraClient.GetFields(ctx, url, username, credential, authType)
+}
+
+func (c *countingJiraClient) GetIssueTypes(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType) ([]integrations.JiraIssueType, error) {
+	c.issueTypeCalls++
+	return c.recordingJiraClient.GetIssueTypes(ctx, url, username, credential, authType)
+}
+
+func newFieldMappingService(t *testing.T) (*integrations.JiraConnectionService, *countingJiraClient, *integrations.JiraConnection) {
+	t.Helper()
+
+	client := &countingJiraClient{recordingJiraClient: recordingJiraClient{mockJiraClient: mockJiraClient{shouldSucceed: true}}}
+	service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, client, testKeyRing)
+
+	conn, err := service.CreateConnection(context.Background(), "proj-123", "Production JIRA", "https://example.atlassian.net",
+		integrations.AuthTypeAPIToken, "FERN", "user@example.com", "test-token")
+	require.NoError(t, err)
+	conn.Activate()
+
+	service.EnableFieldMapping(&memoryFieldMappingRepo{})
+	service.EnableIssueCreation(&memoryIssueLinkRepo{}, &staticIssueSources{
+		failures: map[uint]*integrations.IssueSource{1: failureSource(1, "Checkout", "pays with card")},
+	})
+	return service, client, conn
+}
+
+func TestJiraConnectionService_Metadata_IsCachedPerConnection(t *testing.T) {
+	ctx := context.Background()
+	service, client, conn := newFieldMappingService(t)
+
+	fields, err := service.GetJiraFields(ctx, conn.ID(), false)
+	require.NoError(t, err)
+	assert.Len(t, fields, 8)
+
+	issueTypes, err := service.GetJiraIssueTypes(ctx, conn.ID(), false)
+	require.NoError(t, err)
+	assert.Len(t, issueTypes, 3)
+	assert.Equal(t, 1, client.fieldCalls)
+	assert.Equal(t, 1, client.issueTypeCalls)
+
+	_, err = service.GetJiraFields(ctx, conn.ID(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.fieldCalls, "refresh bypasses the cache")
+	assert.Equal(t, 2, client.issueTypeCalls, "fields and issue types are refreshed together")
+
+	_, err = service.UpdateCredentials(ctx, conn.ID(), integrations.AuthTypeAPIToken, "user@example.com", "test-token")
+	require.NoError(t, err)
+	_, err = service.GetJiraIssueTypes(ctx, conn.ID(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.issueTypeCalls, "changing credentials invalidates the cache")
+}
+
+func TestJiraConnectionService_FieldMapping(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the default mapping until one is saved", func(t *testing.T) {
+		service, _, conn := newFieldMappingService(t)
+
+		mapping, err := service.GetFieldMapping(ctx, conn.ID())
+		require.NoError(t, err)
+		assert.True(t, mapping.IsDefault)
+		assert.Equal(t, integrations.DefaultIssueType, mapping.IssueType)
+		assert.Equal(t, "summary", mapping.Fields[integrations.FernFieldTestName])
+		assert.Equal(t, "description", mapping.Fields[integrations.FernFieldError])
+	})
+
+	t.Run("saves a valid mapping", func(t *testing.T) {
+		service, _, conn := newFieldMappingService(t)
+
+		saved, err := service.UpdateFieldMapping(ctx, conn.ID(), "Task", map[integrations.FernField]string{
+			integrations.FernFieldTestName: "summary",
+			integrations.FernFieldError:    "description",
+			integrations.FernFieldOwner:    "customfield_10010",
+			integrations.FernFieldProject:  "",
+		}, "admin-1")
+		require.NoError(t, err)
+		assert.False(t, saved.IsDefault)
+		assert.NotContains(t, saved.Fields, integrations.FernFieldProject, "empty mappings are dropped")
+
+		mapping, err := service.GetFieldMapping(ctx, conn.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Task", mapping.IssueType)
+		assert.Equal(t, "customfield_10010", mapping.Fields[integrations.FernFieldOwner])
+		assert.Equal(t, "admin-1", mapping.UpdatedBy)
+	})
+
+	t.Run("rejects invalid mappings", func(t *testing.T) {
+		service, _, conn := newFieldMappingService(t)
+
+		_, err := service.UpdateFieldMapping(ctx, conn.ID(), "Sub-task", map[integrations.FernField]string{
+			integrations.FernFieldError:    "description",
+			integrations.FernFieldSeverity: "customfield_10001",
+			integrations.FernFieldOwner:    "customfield_99999",
+			integrations.FernFieldRunURL:   "project",
+			"build_number":                 "customfield_10011",
+		}, "admin-1")
+
+		var validationErr *integrations.FieldMappingValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.ElementsMatch(t, []string{
+			`issue type "Sub-task" is a sub-task type`,
+			`required field "test_name" is not mapped`,
+			`unknown Fern field "build_number"`,
+			`JIRA field "customfield_99999" does not exist`,
+			`JIRA field "project" cannot be mapped`,
+			`JIRA field "Story Points" of type "number" cannot hold severity`,
+		}, validationErr.Problems)
+
+		mapping, err := service.GetFieldMapping(ctx, conn.ID())
+		require.NoError(t, err)
+		assert.True(t, mapping.IsDefault, "invalid mappings are not saved")
+	})
+
+	t.Run("rejects mapping two Fern fields to one JIRA field", func(t *testing.T) {
+		service, _, conn := newFieldMappingService(t)
+
+		_, err := service.UpdateFieldMapping(ctx, conn.ID(), "Bug", map[integrations.FernField]string{
+			integrations.FernFieldTestName: "summary",
+			integrations.FernFieldError:    "summary",
+		}, "admin-1")
+		assert.ErrorContains(t, err, `JIRA field "Summary" is mapped from both error and test_name`)
+	})
+
+	t.Run("is unavailable until enabled", func(t *testing.T) {
+		service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, &mockJiraClient{shouldSucceed: true}, testKeyRing)
+
+		_, err := service.GetFieldMapping(ctx, "conn-1")
+		assert.ErrorIs(t, err, integrations.ErrFieldMappingNotConfigured)
+	})
+}
+
+func TestJiraConnectionService_CreateIssue_UsesFieldMapping(t *testing.T) {
+	ctx := context.Background()
+	service, client, conn := newFieldMappingService(t)
+
+	_, err := service.UpdateFieldMapping(ctx, conn.ID(), "Task", map[integrations.FernField]string{
+		integrations.FernFieldTestName: "customfield_10012",
+		integrations.FernFieldError:    "description",
+		integrations.FernFieldOwner:    "customfield_10010",
+		integrations.FernFieldRunURL:   "customfield_10011",
+		integrations.FernFieldSeverity: "summary",
+	}, "admin-1")
+	require.NoError(t, err)
+
+	source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+	require.NoError(t, err)
+	source.Owners = []string{"@payments", "@checkout"}
+
+	_, err = service.CreateIssue(ctx, source, "user-1")
+	require.NoError(t, err)
+
+	require.Len(t, client.requests, 1)
+	request := client.requests[0]
+	assert.Equal(t, "Task", request.IssueType)
+	assert.Equal(t, "Test failure: Checkout > pays with card", request.Summary, "the template still builds the summary")
+	assert.Equal(t, map[string]interface{}{
+		"customfield_10012": []string{"Checkout_>_pays_with_card"},
+		"customfield_10010": "@payments, @checkout",
+		"customfield_10011": "https://fern.example.com/test-runs/run-1",
+	}, request.Fields)
+}
+
+func TestDefaultJiraClient_GetFieldsAndIssueTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/field":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "summary", "name": "Summary", "custom": false, "schema": map[string]string{"type": "string", "system": "summary"}},
+				{"id": "labels", "name": "Labels", "custom": false, "schema": map[string]string{"type": "array", "items": "string"}},
+				{"id": "customfield_10001", "name": "Story Points", "custom": true, "schema": map[string]string{"type": "number"}},
+			})
+		case "/rest/api/2/issuetype":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "10003", "name": "Bug", "description": "A problem or error.", "iconUrl": "https://example.com/bug.png", "subtask": false},
+				{"id": "10004", "name": "Sub-task", "subtask": true},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := integrations.NewDefaultJiraClient()
+	ctx := context.Background()
+
+	fields, err := client.GetFields(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken)
+	require.NoError(t, err)
+	assert.Equal(t, []integrations.JiraField{
+		{ID: "summary", Name: "Summary", SchemaType: "string"},
+		{ID: "labels", Name: "Labels", SchemaType: "array", SchemaItems: "string"},
+		{ID: "customfield_10001", Name: "Story Points", Custom: true, SchemaType: "number"},
+	}, fields)
+
+	issueTypes, err := client.GetIssueTypes(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken)
+	require.NoError(t, err)
+	assert.Equal(t, []integrations.JiraIssueType{
+		{ID: "10003", Name: "Bug", Description: "A problem or error.", IconURL: "https://example.com/bug.png"},
+		{ID: "10004", Name: "Sub-task", Subtask: true},
+	}, issueTypes)
+
+	_, err = client.GetFields(ctx, server.URL+"/missing", "user@example.com", "token", integrations.AuthTypeAPIToken)
+	assert.ErrorContains(t, err, "failed to get fields: status 404")
+}
@@ -0,0 +1,33 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CheckReachability tests every active JIRA connection and reports those that
+// cannot be reached. It succeeds when no connection is active. Connection
+// statuses are left unchanged; use TestConnection to record a test.
+func (s *JiraConnectionService) CheckReachability(ctx context.Context) error {
+	connections, err := s.repo.FindAllActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find active connections: %w", err)
+	}
+
+	var unreachable []string
+	for _, conn := range connections {
+		apiURL, credential, err := s.credentialFor(ctx, conn)
+		if err == nil {
+			err = s.jiraClient.TestConnection(ctx, apiURL, conn.username, credential, conn.authenticationType)
+		}
+		if err != nil {
+			unreachable = append(unreachable, fmt.Sprintf("%s (%s): %v", conn.name, conn.jiraURL, err))
+		}
+	}
+	if len(unreachable) > 0 {
+		return fmt.Errorf("%d of %d JIRA connections unreachable: %s",
+			len(unreachable), len(connections), strings.Join(unreachable, "; "))
+	}
+	return nil
+}
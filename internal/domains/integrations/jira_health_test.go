@@ -0,0 +1,42 @@
+package integrations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraConnectionService_CheckReachability(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes without active connections", func(t *testing.T) {
+		service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, &mockJiraClient{shouldSucceed: false}, testKeyRing)
+
+		assert.NoError(t, service.CheckReachability(ctx))
+	})
+
+	t.Run("tests every active connection", func(t *testing.T) {
+		client := &mockJiraClient{shouldSucceed: true}
+		service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, client, testKeyRing)
+		conn, err := service.CreateConnection(ctx, "proj-123", "Production JIRA", "https://example.atlassian.net",
+			integrations.AuthTypeAPIToken, "FERN", "user@example.com", "test-token")
+		require.NoError(t, err)
+		conn.Activate()
+		_, err = service.CreateConnection(ctx, "proj-456", "Inactive JIRA", "https://inactive.atlassian.net",
+			integrations.AuthTypeAPIToken, "OLD", "user@example.com", "test-token")
+		require.NoError(t, err)
+
+		assert.NoError(t, service.CheckReachability(ctx))
+
+		client.shouldSucceed = false
+		client.errorMsg = "connection refused"
+		err = service.CheckReachability(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 1 JIRA connections unreachable")
+		assert.Contains(t, err.Error(), "Production JIRA (https://example.atlassian.net): connection refused")
+		assert.NotContains(t, err.Error(), "Inactive JIRA")
+	})
+}
@@ -0,0 +1,162 @@
+package integrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNoActiveJiraConnection is returned when a project has no active JIRA connection to file issues with
+	ErrNoActiveJiraConnection = errors.New("project has no active JIRA connection")
+	// ErrIssueCreationNotConfigured is returned when issue creation has not been enabled on the service
+	ErrIssueCreationNotConfigured = errors.New("JIRA issue creation is not configured")
+)
+
+// IssueSourceType identifies what an issue is filed from
+type IssueSourceType string
+
+const (
+	// IssueSourceSpecRun files an issue for a single failed spec run
+	IssueSourceSpecRun IssueSourceType = "spec_run"
+	// IssueSourceFailureCluster files one issue for a group of related failed spec runs
+	IssueSourceFailureCluster IssueSourceType = "failure_cluster"
+	// IssueSourceFlakyTest files an issue for a flaky test
+	IssueSourceFlakyTest IssueSourceType = "flaky_test"
+)
+
+// IssueTargetType identifies what a filed issue is stored against
+type IssueTargetType string
+
+const (
+	// IssueTargetFailure links an issue to a failing test, identified by suite and test name
+	IssueTargetFailure IssueTargetType = "failure"
+	// IssueTargetFlakyTest links an issue to a flaky test record
+	IssueTargetFlakyTest IssueTargetType = "flaky_test"
+)
+
+// IssueTarget is a failure or flaky test an issue is stored against, so
+// that it is not filed twice
+type IssueTarget struct {
+	Type      IssueTargetType
+	ID        string
+	TestName  string
+	SuiteName string
+}
+
+// FailureTarget returns the target for failures of a test. Every failure of
+// the same test maps to the same target.
+func FailureTarget(suiteName, testName string) IssueTarget {
+	sum := sha256.Sum256([]byte(suiteName + "\x00" + testName))
+	return IssueTarget{
+		Type:      IssueTargetFailure,
+		ID:        hex.EncodeToString(sum[:]),
+		TestName:  testName,
+		SuiteName: suiteName,
+	}
+}
+
+// FlakyTestTarget returns the target for a flaky test record
+func FlakyTestTarget(flakyTestID uint, suiteName, testName string) IssueTarget {
+	return IssueTarget{
+		Type:      IssueTargetFlakyTest,
+		ID:        fmt.Sprintf("%d", flakyTestID),
+		TestName:  testName,
+		SuiteName: suiteName,
+	}
+}
+
+// IssueFailure is a failed execution included in an issue
+type IssueFailure struct {
+	SpecRunID    uint
+	TestName     string
+	SuiteName    string
+	TestRunID    string
+	Branch       string
+	ErrorMessage string
+	StackTrace   string
+	FailedAt     time.Time
+}
+
+// IssueHistoryEntry is a previous execution of the test an issue is filed for
+type IssueHistoryEntry struct {
+	TestRunID    string
+	Status       string
+	ErrorMessage string
+	ExecutedAt   time.Time
+}
+
+// IssueFlakyStats summarizes a flaky test for an issue
+type IssueFlakyStats struct {
+	FlakeRate       float64 // Share of flaky executions, from 0 to 1
+	TotalExecutions int
+	FlakyExecutions int
+	Severity        string
+	Status          string
+	FirstSeenAt     time.Time
+	LastSeenAt      time.Time
+}
+
+// IssueSource is the test result content an issue is built from
+type IssueSource struct {
+	Type      IssueSourceType
+	ProjectID string
+	TestName  string
+	SuiteName string
+	Owners    []string
+	Failures  []IssueFailure
+	History   []IssueHistoryEntry
+	Flaky     *IssueFlakyStats
+	// URL links back to the failure or flaky test in Fern
+	URL     string
+	Targets []IssueTarget
+}
+
+// IssueSourceRequest identifies the test results to file an issue for
+type IssueSourceRequest struct {
+	Type        IssueSourceType
+	SpecRunIDs  []uint
+	FlakyTestID uint
+}
+
+// IssueSourceProvider loads issue content from test results
+type IssueSourceProvider interface {
+	// FailureSource loads one or more failed spec runs, treating the first as representative
+	FailureSource(ctx context.Context, specRunIDs []uint) (*IssueSource, error)
+	// FlakyTestSource loads a flaky test with its most recent failure
+	FlakyTestSource(ctx context.Context, flakyTestID uint) (*IssueSource, error)
+}
+
+// JiraIssueLink records a JIRA issue filed for a failure or flaky test
+type JiraIssueLink struct {
+	ID           uint
+	ProjectID    string
+	ConnectionID string
+	IssueKey     string
+	IssueURL     string
+	SourceType   IssueSourceType
+	Target       IssueTarget
+	CreatedBy    string
+	CreatedAt    time.Time
+
+	// Sync state, maintained by the issue sync job
+	IssueStatus         string
+	IssueStatusCategory string
+	IssueResolvedAt     *time.Time
+	SyncedAt            *time.Time
+	// ReflakeNotifiedAt is when the issue was last commented on or reopened
+	// because the flaky test flaked again
+	ReflakeNotifiedAt *time.Time
+}
+
+// IssueCreation is the result of filing an issue
+type IssueCreation struct {
+	IssueKey string
+	IssueURL string
+	// Created is false when the failure or flaky test had already been filed
+	Created bool
+	Links   []*JiraIssueLink
+}
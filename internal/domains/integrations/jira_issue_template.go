@@ -0,0 +1,171 @@
+package integrations
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// maxSummaryLength is the JIRA limit for issue summaries
+	maxSummaryLength = 255
+	// maxDescribedFailures bounds the failures written out in full in a description
+	maxDescribedFailures = 10
+	// stackTraceExcerptLines is the number of stack trace lines included per failure
+	stackTraceExcerptLines = 15
+	// maxBlockLength bounds error messages and stack trace excerpts
+	maxBlockLength = 2000
+)
+
+// DefaultIssueSummaryTemplate is the default template for issue summaries
+const DefaultIssueSummaryTemplate = `{{if eq .Type "flaky_test"}}Flaky test: {{else if eq .Type "failure_cluster"}}{{len .Failures}} related failures: {{else}}Test failure: {{end}}{{if .SuiteName}}{{.SuiteName}} > {{end}}{{.TestName}}`
+
+// DefaultIssueDescriptionTemplate is the default template for issue
+// descriptions, written in JIRA wiki markup
+const DefaultIssueDescriptionTemplate = `{{with .Flaky -}}
+h3. Flaky test
+||Flake rate|{{percent .FlakeRate}}|
+||Flaky executions|{{.FlakyExecutions}} of {{.TotalExecutions}}|
+||Severity|{{.Severity}}|
+||First seen|{{date .FirstSeenAt}}|
+||Last seen|{{date .LastSeenAt}}|
+
+{{end -}}
+{{if .Failures -}}
+h3. {{if gt (len .Failures) 1}}Failures ({{len .Failures}}){{else}}Failure{{end}}
+{{range .DescribedFailures}}
+*{{if .SuiteName}}{{.SuiteName}} > {{end}}{{.TestName}}*{{if .TestRunID}} in run {{.TestRunID}}{{end}}{{if .Branch}} on {{.Branch}}{{end}}{{if not .FailedAt.IsZero}} at {{date .FailedAt}}{{end}}
+{{if .ErrorMessage}}{{noformat .ErrorMessage}}{{else}}_No error message was reported._
+{{end}}{{with excerpt .StackTrace}}Stack trace excerpt:
+{{noformat .}}{{end}}{{end}}{{if .OmittedFailures}}
+_{{.OmittedFailures}} more failures are not shown._
+{{end}}
+{{end -}}
+{{if .History -}}
+h3. Recent history
+||Run||Status||Executed||Error||
+{{range .History}}|{{cell .TestRunID}}|{{.Status}}|{{date .ExecutedAt}}|{{cell (firstLine .ErrorMessage)}}|
+{{end}}
+{{end -}}
+{{if .URL}}[View in Fern|{{.URL}}]
+{{end}}`
+
+// IssueTemplate renders issue summaries and descriptions from issue sources
+type IssueTemplate struct {
+	summary     *template.Template
+	description *template.Template
+}
+
+// issueTemplateData is the data available to issue templates
+type issueTemplateData struct {
+	IssueSource
+	DescribedFailures []IssueFailure
+	OmittedFailures   int
+}
+
+// NewIssueTemplate parses summary and description templates
+func NewIssueTemplate(summaryTemplate, descriptionTemplate string) (*IssueTemplate, error) {
+	summary, err := template.New("summary").Funcs(issueTemplateFuncs).Parse(summaryTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary template: %w", err)
+	}
+	description, err := template.New("description").Funcs(issueTemplateFuncs).Parse(descriptionTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse description template: %w", err)
+	}
+	return &IssueTemplate{summary: summary, description: description}, nil
+}
+
+// DefaultIssueTemplate returns the built-in issue template
+func DefaultIssueTemplate() *IssueTemplate {
+	t, err := NewIssueTemplate(DefaultIssueSummaryTemplate, DefaultIssueDescriptionTemplate)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render builds the issue summary and description for a source
+func (t *IssueTemplate) Render(source *IssueSource) (string, string, error) {
+	data := issueTemplateData{IssueSource: *source, DescribedFailures: source.Failures}
+	if len(data.DescribedFailures) > maxDescribedFailures {
+		data.DescribedFailures = data.DescribedFailures[:maxDescribedFailures]
+		data.OmittedFailures = len(source.Failures) - maxDescribedFailures
+	}
+
+	var summary strings.Builder
+	if err := t.summary.Execute(&summary, data); err != nil {
+		return "", "", fmt.Errorf("failed to render summary: %w", err)
+	}
+	var description strings.Builder
+	if err := t.description.Execute(&description, data); err != nil {
+		return "", "", fmt.Errorf("failed to render description: %w", err)
+	}
+
+	// Summaries are a single line in JIRA
+	summaryText := strings.Join(strings.Fields(summary.String()), " ")
+	if len(summaryText) > maxSummaryLength {
+		summaryText = truncate(summaryText, maxSummaryLength-3) + "..."
+	}
+	return summaryText, strings.TrimSpace(description.String()), nil
+}
+
+var issueTemplateFuncs = template.FuncMap{
+	"excerpt":   stackTraceExcerpt,
+	"noformat":  noformat,
+	"cell":      tableCell,
+	"firstLine": firstLine,
+	"percent": func(rate float64) string {
+		return fmt.Sprintf("%.1f%%", rate*100)
+	},
+	"date": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+}
+
+// stackTraceExcerpt returns the first lines of a stack trace
+func stackTraceExcerpt(stackTrace string) string {
+	lines := strings.Split(strings.TrimSpace(stackTrace), "\n")
+	if len(lines) > stackTraceExcerptLines {
+		lines = append(lines[:stackTraceExcerptLines], "...")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// noformat wraps text in a preformatted block, removing anything that would end it early
+func noformat(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "{noformat}", "")
+	if len(text) > maxBlockLength {
+		text = truncate(text, maxBlockLength) + "\n..."
+	}
+	return "{noformat}\n" + text + "\n{noformat}\n"
+}
+
+// tableCell makes text safe to place in a wiki markup table cell
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "/")
+	if text == "" {
+		return " "
+	}
+	return text
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if len(line) > 120 {
+		line = truncate(line, 117) + "..."
+	}
+	return line
+}
+
+// truncate shortens text to at most n bytes without splitting a UTF-8 sequence
+func truncate(text string, n int) string {
+	if len(text) <= n {
+		return text
+	}
+	for n > 0 && text[n]&0xC0 == 0x80 {
+		n--
+	}
+	return text[:n]
+}
@@ -0,0 +1,497 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEncryptionKey = []byte("test-encryption-key-32-bytes-lon")
+
+// testKeyRing encrypts credentials with testEncryptionKey
+var testKeyRing = func() *integrations.KeyRing {
+	ring, err := integrations.NewKeyRing("test", "", map[string][]byte{"test": testEncryptionKey})
+	if err != nil {
+		panic(err)
+	}
+	return ring
+}()
+
+// memoryConnectionRepo is an in-memory JiraConnectionRepository
+type memoryConnectionRepo struct {
+	connections []*integrations.JiraConnection
+}
+
+func (r *memoryConnectionRepo) Create(ctx context.Context, connection *integrations.JiraConnection) error {
+	r.connections = append(r.connections, connection)
+	return nil
+}
+
+func (r *memoryConnectionRepo) Update(ctx context.Context, connection *integrations.JiraConnection) error {
+	return nil
+}
+
+func (r *memoryConnectionRepo) Delete(ctx context.Context, connectionID string) error {
+	return nil
+}
+
+func (r *memoryConnectionRepo) FindByID(ctx context.Context, connectionID string) (*integrations.JiraConnection, error) {
+	for _, conn := range r.connections {
+		if conn.ID() == connectionID {
+			return conn, nil
+		}
+	}
+	return nil, fmt.Errorf("JIRA connection not found")
+}
+
+func (r *memoryConnectionRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.JiraConnection, error) {
+	var found []*integrations.JiraConnection
+	for _, conn := range r.connections {
+		if conn.ProjectID() == projectID {
+			found = append(found, conn)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryConnectionRepo) FindActiveByProjectID(ctx context.Context, projectID string) ([]*integrations.JiraConnection, error) {
+	var found []*integrations.JiraConnection
+	for _, conn := range r.connections {
+		if conn.ProjectID() == projectID && conn.IsActive() {
+			found = append(found, conn)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryConnectionRepo) FindAllActive(ctx context.Context) ([]*integrations.JiraConnection, error) {
+	var found []*integrations.JiraConnection
+	for _, conn := range r.connections {
+		if conn.IsActive() {
+			found = append(found, conn)
+		}
+	}
+	return found, nil
+}
+
+// memoryIssueLinkRepo is an in-memory JiraIssueLinkRepository
+type memoryIssueLinkRepo struct {
+	links []*integrations.JiraIssueLink
+}
+
+func (r *memoryIssueLinkRepo) Create(ctx context.Context, links []*integrations.JiraIssueLink) error {
+	for _, link := range links {
+		link.ID = uint(len(r.links) + 1)
+		link.CreatedAt = time.Now()
+		r.links = append(r.links, link)
+	}
+	return nil
+}
+
+func (r *memoryIssueLinkRepo) FindByTargets(ctx context.Context, projectID string, targets []integrations.IssueTarget) ([]*integrations.JiraIssueLink, error) {
+	var found []*integrations.JiraIssueLink
+	for _, link := range r.links {
+		for _, target := range targets {
+			if link.ProjectID == projectID && link.Target.Type == target.Type && link.Target.ID == target.ID {
+				found = append(found, link)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryIssueLinkRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.JiraIssueLink, error) {
+	var found []*integrations.JiraIssueLink
+	for _, link := range r.links {
+		if link.ProjectID == projectID {
+			found = append(found, link)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryIssueLinkRepo) FindByConnectionID(ctx context.Context, connectionID string) ([]*integrations.JiraIssueLink, error) {
+	var found []*integrations.JiraIssueLink
+	for _, link := range r.links {
+		if link.ConnectionID == connectionID {
+			found = append(found, link)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryIssueLinkRepo) UpdateSync(ctx context.Context, link *integrations.JiraIssueLink) error {
+	return nil
+}
+
+// recordingJiraClient records the issues it is asked to create
+type recordingJiraClient struct {
+	mockJiraClient
+	requests []integrations.JiraIssueRequest
+}
+
+func (c *recordingJiraClient) CreateIssue(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issue integrations.JiraIssueRequest) (*integrations.JiraIssue, error) {
+	if credential != "test-token" {
+		return nil, fmt.Errorf("unexpected credential %q", credential)
+	}
+	c.requests = append(c.requests, issue)
+	key := fmt.Sprintf("%s-%d", issue.ProjectKey, len(c.requests))
+	return &integrations.JiraIssue{ID: "1", Key: key, Self: url + "/rest/api/2/issue/1"}, nil
+}
+
+// staticIssueSources serves fixed issue sources
+type staticIssueSources struct {
+	failures map[uint]*integrations.IssueSource
+	flaky    map[uint]*integrations.IssueSource
+}
+
+func (s *staticIssueSources) FailureSource(ctx context.Context, specRunIDs []uint) (*integrations.IssueSource, error) {
+	source, ok := s.failures[specRunIDs[0]]
+	if !ok {
+		return nil, fmt.Errorf("spec run %d not found or did not fail", specRunIDs[0])
+	}
+	copied := *source
+	for _, id := range specRunIDs[1:] {
+		other, ok := s.failures[id]
+		if !ok {
+			return nil, fmt.Errorf("spec run %d not found or did not fail", id)
+		}
+		copied.Failures = append(copied.Failures, other.Failures...)
+		copied.Targets = append(copied.Targets, other.Targets...)
+	}
+	return &copied, nil
+}
+
+func (s *staticIssueSources) FlakyTestSource(ctx context.Context, flakyTestID uint) (*integrations.IssueSource, error) {
+	source, ok := s.flaky[flakyTestID]
+	if !ok {
+		return nil, fmt.Errorf("flaky test not found")
+	}
+	return source, nil
+}
+
+func failureSource(specRunID uint, suiteName, testName string) *integrations.IssueSource {
+	return &integrations.IssueSource{
+		ProjectID: "proj-123",
+		TestName:  testName,
+		SuiteName: suiteName,
+		Failures: []integrations.IssueFailure{{
+			SpecRunID:    specRunID,
+			TestName:     testName,
+			SuiteName:    suiteName,
+			TestRunID:    "run-1",
+			Branch:       "main",
+			ErrorMessage: "expected 200, got 500",
+			StackTrace:   "at checkout_test.go:42",
+		}},
+		URL:     "https://fern.example.com/test-runs/run-1",
+		Targets: []integrations.IssueTarget{integrations.FailureTarget(suiteName, testName)},
+	}
+}
+
+func newIssueService(t *testing.T, active bool) (*integrations.JiraConnectionService, *recordingJiraClient, *memoryIssueLinkRepo) {
+	t.Helper()
+
+	connRepo := &memoryConnectionRepo{}
+	client := &recordingJiraClient{mockJiraClient: mockJiraClient{shouldSucceed: true}}
+	service := integrations.NewJiraConnectionService(connRepo, client, testKeyRing)
+
+	conn, err := service.CreateConnection(context.Background(), "proj-123", "Production JIRA", "https://example.atlassian.net",
+		integrations.AuthTypeAPIToken, "FERN", "user@example.com", "test-token")
+	require.NoError(t, err)
+	if active {
+		conn.Activate()
+	}
+
+	linkRepo := &memoryIssueLinkRepo{}
+	service.EnableIssueCreation(linkRepo, &staticIssueSources{
+		failures: map[uint]*integrations.IssueSource{
+			1: failureSource(1, "Checkout", "pays with card"),
+			2: failureSource(2, "Checkout", "pays with voucher"),
+			3: failureSource(3, "Checkout", "pays with card"),
+		},
+		flaky: map[uint]*integrations.IssueSource{
+			7: {
+				Type:      integrations.IssueSourceFlakyTest,
+				ProjectID: "proj-123",
+				TestName:  "pays with card",
+				SuiteName: "Checkout",
+				Flaky:     &integrations.IssueFlakyStats{FlakeRate: 0.25, TotalExecutions: 20, FlakyExecutions: 5, Severity: "medium"},
+				Targets:   []integrations.IssueTarget{integrations.FlakyTestTarget(7, "Checkout", "pays with card")},
+			},
+		},
+	})
+	return service, client, linkRepo
+}
+
+func TestJiraConnectionService_CreateIssue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("files a spec run failure and records the issue key", func(t *testing.T) {
+		service, client, linkRepo := newIssueService(t, true)
+
+		source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+		require.NoError(t, err)
+		assert.Equal(t, integrations.IssueSourceSpecRun, source.Type)
+
+		creation, err := service.CreateIssue(ctx, source, "user-1")
+		require.NoError(t, err)
+		assert.True(t, creation.Created)
+		assert.Equal(t, "FERN-1", creation.IssueKey)
+		assert.Equal(t, "https://example.atlassian.net/browse/FERN-1", creation.IssueURL)
+
+		require.Len(t, client.requests, 1)
+		request := client.requests[0]
+		assert.Equal(t, "FERN", request.ProjectKey)
+		assert.Equal(t, integrations.DefaultIssueType, request.IssueType)
+		assert.Equal(t, "Test failure: Checkout > pays with card", request.Summary)
+		assert.Contains(t, request.Description, "expected 200, got 500")
+		assert.Contains(t, request.Description, "https://fern.example.com/test-runs/run-1")
+		assert.Equal(t, []string{"fern"}, request.Labels)
+
+		require.Len(t, linkRepo.links, 1)
+		assert.Equal(t, "FERN-1", linkRepo.links[0].IssueKey)
+		assert.Equal(t, integrations.IssueTargetFailure, linkRepo.links[0].Target.Type)
+		assert.Equal(t, "user-1", linkRepo.links[0].CreatedBy)
+	})
+
+	t.Run("does not file the same failure twice", func(t *testing.T) {
+		service, client, _ := newIssueService(t, true)
+
+		source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+		require.NoError(t, err)
+		_, err = service.CreateIssue(ctx, source, "user-1")
+		require.NoError(t, err)
+
+		// A later failure of the same test maps to the same target
+		again, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{3}})
+		require.NoError(t, err)
+		creation, err := service.CreateIssue(ctx, again, "user-2")
+		require.NoError(t, err)
+
+		assert.False(t, creation.Created)
+		assert.Equal(t, "FERN-1", creation.IssueKey)
+		assert.Len(t, client.requests, 1)
+	})
+
+	t.Run("links the rest of a cluster to an existing issue", func(t *testing.T) {
+		service, client, linkRepo := newIssueService(t, true)
+
+		source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+		require.NoError(t, err)
+		_, err = service.CreateIssue(ctx, source, "user-1")
+		require.NoError(t, err)
+
+		cluster, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceFailureCluster, SpecRunIDs: []uint{2, 1}})
+		require.NoError(t, err)
+		creation, err := service.CreateIssue(ctx, cluster, "user-1")
+		require.NoError(t, err)
+
+		assert.False(t, creation.Created)
+		assert.Equal(t, "FERN-1", creation.IssueKey)
+		assert.Len(t, creation.Links, 2)
+		assert.Len(t, client.requests, 1)
+		require.Len(t, linkRepo.links, 2)
+		assert.Equal(t, "pays with voucher", linkRepo.links[1].Target.TestName)
+		assert.Equal(t, integrations.IssueSourceFailureCluster, linkRepo.links[1].SourceType)
+	})
+
+	t.Run("files a flaky test with a flaky label", func(t *testing.T) {
+		service, client, _ := newIssueService(t, true)
+
+		source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceFlakyTest, FlakyTestID: 7})
+		require.NoError(t, err)
+		creation, err := service.CreateIssue(ctx, source, "user-1")
+		require.NoError(t, err)
+		assert.True(t, creation.Created)
+
+		require.Len(t, client.requests, 1)
+		assert.Equal(t, "Flaky test: Checkout > pays with card", client.requests[0].Summary)
+		assert.Contains(t, client.requests[0].Description, "25.0%")
+		assert.Equal(t, []string{"fern", "flaky-test"}, client.requests[0].Labels)
+
+		link, err := service.GetIssueLink(ctx, "proj-123", integrations.FlakyTestTarget(7, "Checkout", "pays with card"))
+		require.NoError(t, err)
+		require.NotNil(t, link)
+		assert.Equal(t, creation.IssueKey, link.IssueKey)
+	})
+
+	t.Run("requires an active connection", func(t *testing.T) {
+		service, client, _ := newIssueService(t, false)
+
+		source, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+		require.NoError(t, err)
+		_, err = service.CreateIssue(ctx, source, "user-1")
+		assert.ErrorIs(t, err, integrations.ErrNoActiveJiraConnection)
+		assert.Empty(t, client.requests)
+	})
+
+	t.Run("requires issue creation to be enabled", func(t *testing.T) {
+		service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, &mockJiraClient{}, testKeyRing)
+
+		_, err := service.LoadIssueSource(ctx, integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1}})
+		assert.ErrorIs(t, err, integrations.ErrIssueCreationNotConfigured)
+	})
+}
+
+func TestJiraConnectionService_LoadIssueSource_Validation(t *testing.T) {
+	service, _, _ := newIssueService(t, true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		req         integrations.IssueSourceRequest
+		errContains string
+	}{
+		{
+			name:        "spec run needs exactly one ID",
+			req:         integrations.IssueSourceRequest{Type: integrations.IssueSourceSpecRun, SpecRunIDs: []uint{1, 2}},
+			errContains: "exactly one spec run",
+		},
+		{
+			name:        "cluster needs spec runs",
+			req:         integrations.IssueSourceRequest{Type: integrations.IssueSourceFailureCluster},
+			errContains: "at least one spec run",
+		},
+		{
+			name:        "flaky test needs an ID",
+			req:         integrations.IssueSourceRequest{Type: integrations.IssueSourceFlakyTest},
+			errContains: "flaky test ID is required",
+		},
+		{
+			name:        "unknown source type",
+			req:         integrations.IssueSourceRequest{Type: "suite_run"},
+			errContains: "unsupported issue source type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.LoadIssueSource(ctx, tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestIssueTemplate_Render(t *testing.T) {
+	stackTrace := make([]string, 30)
+	for i := range stackTrace {
+		stackTrace[i] = fmt.Sprintf("at frame%d (checkout_test.go:%d)", i, i)
+	}
+
+	source := &integrations.IssueSource{
+		Type:      integrations.IssueSourceFailureCluster,
+		ProjectID: "proj-123",
+		TestName:  "pays with card",
+		SuiteName: "Checkout",
+		Failures: []integrations.IssueFailure{
+			{
+				TestName:     "pays with card",
+				SuiteName:    "Checkout",
+				TestRunID:    "run-9",
+				Branch:       "main",
+				ErrorMessage: "timeout {noformat} waiting for payment",
+				StackTrace:   strings.Join(stackTrace, "\n"),
+			},
+			{TestName: "pays with voucher", SuiteName: "Checkout", TestRunID: "run-9"},
+		},
+		History: []integrations.IssueHistoryEntry{
+			{TestRunID: "run-8", Status: "passed after retry", ErrorMessage: "timeout | retrying\nsecond line", ExecutedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)},
+			{TestRunID: "run-7", Status: "passed", ExecutedAt: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+		},
+		URL: "https://fern.example.com/test-runs/run-9",
+	}
+
+	summary, description, err := integrations.DefaultIssueTemplate().Render(source)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2 related failures: Checkout > pays with card", summary)
+	assert.Contains(t, description, "h3. Failures (2)")
+	assert.Contains(t, description, "*Checkout > pays with card* in run run-9 on main")
+	assert.Contains(t, description, "timeout  waiting for payment")
+	assert.Equal(t, 4, strings.Count(description, "{noformat}"), "error and stack trace blocks only")
+	assert.Contains(t, description, "at frame14 (checkout_test.go:14)")
+	assert.NotContains(t, description, "at frame15 ")
+	assert.Contains(t, description, "_No error message was reported._")
+	assert.Contains(t, description, "|run-8|passed after retry|2025-03-01 10:00 UTC|timeout / retrying|")
+	assert.Contains(t, description, "[View in Fern|https://fern.example.com/test-runs/run-9]")
+
+	t.Run("summaries are single line and bounded", func(t *testing.T) {
+		long := *source
+		long.Type = integrations.IssueSourceSpecRun
+		long.TestName = strings.Repeat("very long test name ", 20) + "\nwith newline"
+
+		summary, _, err := integrations.DefaultIssueTemplate().Render(&long)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(summary), 255)
+		assert.NotContains(t, summary, "\n")
+		assert.True(t, strings.HasSuffix(summary, "..."))
+	})
+
+	t.Run("custom templates", func(t *testing.T) {
+		tmpl, err := integrations.NewIssueTemplate("[{{.ProjectID}}] {{.TestName}}", "{{range .Failures}}{{excerpt .StackTrace}}{{end}}")
+		require.NoError(t, err)
+
+		summary, _, err := tmpl.Render(source)
+		require.NoError(t, err)
+		assert.Equal(t, "[proj-123] pays with card", summary)
+
+		_, err = integrations.NewIssueTemplate("{{.Missing", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultJiraClient_CreateIssue(t *testing.T) {
+	var received map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer pat-token", r.Header.Get("Authorization"))
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received["fields"]["summary"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errorMessages": []string{},
+				"errors":        map[string]string{"summary": "You must specify a summary of the issue."},
+			})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "10042", "key": "FERN-42", "self": "http://jira/rest/api/2/issue/10042"})
+	}))
+	defer server.Close()
+
+	client := integrations.NewDefaultJiraClient()
+	issue, err := client.CreateIssue(context.Background(), server.URL, "", "pat-token", integrations.AuthTypePersonalAccessToken, integrations.JiraIssueRequest{
+		ProjectKey:  "FERN",
+		IssueType:   "Bug",
+		Summary:     "Test failure: pays with card",
+		Description: "details",
+		Labels:      []string{"fern"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FERN-42", issue.Key)
+	assert.Equal(t, "10042", issue.ID)
+
+	fields := received["fields"]
+	assert.Equal(t, map[string]interface{}{"key": "FERN"}, fields["project"])
+	assert.Equal(t, map[string]interface{}{"name": "Bug"}, fields["issuetype"])
+	assert.Equal(t, []interface{}{"fern"}, fields["labels"])
+
+	_, err = client.CreateIssue(context.Background(), server.URL, "", "pat-token", integrations.AuthTypePersonalAccessToken, integrations.JiraIssueRequest{ProjectKey: "FERN", IssueType: "Bug"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "summary: You must specify a summary of the issue.")
+}
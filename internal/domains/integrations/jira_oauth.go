@@ -0,0 +1,522 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultJiraOAuthAuthorizeURL is the Atlassian authorization endpoint
+	DefaultJiraOAuthAuthorizeURL = "https://auth.atlassian.com/authorize"
+
+	// DefaultJiraOAuthTokenURL is the Atlassian token endpoint
+	DefaultJiraOAuthTokenURL = "https://auth.atlassian.com/oauth/token"
+
+	// DefaultJiraOAuthResourcesURL lists the Atlassian sites a token can access
+	DefaultJiraOAuthResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+
+	// DefaultJiraOAuthAPIURL is the base URL of the Atlassian API gateway that
+	// OAuth requests are sent through
+	DefaultJiraOAuthAPIURL = "https://api.atlassian.com"
+
+	// JiraOAuthStateTTL is how long an authorization request can take to complete
+	JiraOAuthStateTTL = 10 * time.Minute
+
+	// JiraOAuthRefreshLeeway is how long before expiry access tokens are refreshed
+	JiraOAuthRefreshLeeway = time.Minute
+)
+
+// DefaultJiraOAuthScopes are requested when no scopes are configured.
+// offline_access is what makes Atlassian return a refresh token.
+var DefaultJiraOAuthScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"}
+
+var (
+	// ErrJiraOAuthNotConfigured is returned when no OAuth app is configured
+	ErrJiraOAuthNotConfigured = errors.New("JIRA OAuth is not configured")
+
+	// ErrInvalidOAuthState is returned when a callback state is forged, expired or for another user
+	ErrInvalidOAuthState = errors.New("invalid or expired OAuth state")
+
+	// ErrJiraOAuthAuthorizationRequired is returned when an OAuth connection has not been authorized yet
+	ErrJiraOAuthAuthorizationRequired = errors.New("JIRA connection has not been authorized")
+
+	// ErrJiraOAuthRefreshFailed is returned when an access token could not be refreshed
+	ErrJiraOAuthRefreshFailed = errors.New("JIRA OAuth token refresh failed")
+)
+
+// JiraOAuthSettings configures the Atlassian OAuth 2.0 (3LO) app used to
+// authorize connections
+type JiraOAuthSettings struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// Endpoints, defaulting to Atlassian's
+	AuthorizeURL string
+	TokenURL     string
+	ResourcesURL string
+	APIURL       string
+}
+
+// withDefaults fills in Atlassian's endpoints and the default scopes
+func (s JiraOAuthSettings) withDefaults() JiraOAuthSettings {
+	if s.AuthorizeURL == "" {
+		s.AuthorizeURL = DefaultJiraOAuthAuthorizeURL
+	}
+	if s.TokenURL == "" {
+		s.TokenURL = DefaultJiraOAuthTokenURL
+	}
+	if s.ResourcesURL == "" {
+		s.ResourcesURL = DefaultJiraOAuthResourcesURL
+	}
+	if s.APIURL == "" {
+		s.APIURL = DefaultJiraOAuthAPIURL
+	}
+	s.APIURL = strings.TrimRight(s.APIURL, "/")
+	if len(s.Scopes) == 0 {
+		s.Scopes = DefaultJiraOAuthScopes
+	}
+	return s
+}
+
+// JiraOAuthToken is a token pair issued by the authorization server
+type JiraOAuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// JiraOAuthResource is an Atlassian site an access token can be used with
+type JiraOAuthResource struct {
+	ID   string `json:"id"`
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// JiraOAuthProvider talks to the Atlassian authorization server
+type JiraOAuthProvider struct {
+	settings   JiraOAuthSettings
+	httpClient *http.Client
+}
+
+// NewJiraOAuthProvider creates an OAuth provider for the configured app
+func NewJiraOAuthProvider(settings JiraOAuthSettings) (*JiraOAuthProvider, error) {
+	if settings.ClientID == "" || settings.ClientSecret == "" {
+		return nil, errors.New("OAuth client ID and secret are required")
+	}
+	if !isValidJiraURL(settings.RedirectURL) {
+		return nil, errors.New("OAuth redirect URL must start with http:// or https://")
+	}
+
+	return &JiraOAuthProvider{
+		settings:   settings.withDefaults(),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// AuthorizationURL returns the URL users are sent to to grant access
+func (p *JiraOAuthProvider) AuthorizationURL(state string) string {
+	query := neturl.Values{}
+	query.Set("audience", "api.atlassian.com")
+	query.Set("client_id", p.settings.ClientID)
+	query.Set("scope", strings.Join(p.settings.Scopes, " "))
+	query.Set("redirect_uri", p.settings.RedirectURL)
+	query.Set("state", state)
+	query.Set("response_type", "code")
+	query.Set("prompt", "consent")
+	return p.settings.AuthorizeURL + "?" + query.Encode()
+}
+
+// Exchange trades an authorization code for tokens
+func (p *JiraOAuthProvider) Exchange(ctx context.Context, code string) (*JiraOAuthToken, error) {
+	return p.requestToken(ctx, map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     p.settings.ClientID,
+		"client_secret": p.settings.ClientSecret,
+		"code":          code,
+		"redirect_uri":  p.settings.RedirectURL,
+	})
+}
+
+// Refresh trades a refresh token for new tokens. Atlassian rotates refresh
+// tokens, so the returned refresh token replaces the old one.
+func (p *JiraOAuthProvider) Refresh(ctx context.Context, refreshToken string) (*JiraOAuthToken, error) {
+	token, err := p.requestToken(ctx, map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     p.settings.ClientID,
+		"client_secret": p.settings.ClientSecret,
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// AccessibleResources lists the sites the access token was granted for
+func (p *JiraOAuthProvider) AccessibleResources(ctx context.Context, accessToken string) ([]JiraOAuthResource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.settings.ResourcesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accessible resources: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list accessible resources: status %d", resp.StatusCode)
+	}
+
+	var resources []JiraOAuthResource
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return nil, fmt.Errorf("failed to parse accessible resources: %w", err)
+	}
+	return resources, nil
+}
+
+// APIURL returns the base URL of the JIRA REST API of an authorized site
+func (p *JiraOAuthProvider) APIURL(cloudID string) string {
+	return fmt.Sprintf("%s/ex/jira/%s", p.settings.APIURL, cloudID)
+}
+
+// requestToken posts a token request and parses the token response
+func (p *JiraOAuthProvider) requestToken(ctx context.Context, params map[string]string) (*JiraOAuthToken, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.settings.TokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach authorization server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = json.Unmarshal(data, &result)
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != "" {
+			if result.ErrorDescription != "" {
+				return nil, fmt.Errorf("authorization server rejected the request: %s (%s)", result.Error, result.ErrorDescription)
+			}
+			return nil, fmt.Errorf("authorization server rejected the request: %s", result.Error)
+		}
+		return nil, fmt.Errorf("authorization server returned status %d", resp.StatusCode)
+	}
+	if result.AccessToken == "" {
+		return nil, errors.New("authorization server returned no access token")
+	}
+
+	return &JiraOAuthToken{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(result.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// oauthState is the payload of the state parameter of an authorization request
+type oauthState struct {
+	ConnectionID string `json:"c"`
+	UserID       string `json:"u"`
+	ReturnTo     string `json:"r,omitempty"`
+	ExpiresAt    int64  `json:"e"`
+	Nonce        string `json:"n"`
+}
+
+// JiraOAuthCompletion is the result of a completed authorization
+type JiraOAuthCompletion struct {
+	Connection *JiraConnection
+	ReturnTo   string
+}
+
+// EnableOAuth configures the OAuth app used to authorize OAuth connections
+func (s *JiraConnectionService) EnableOAuth(provider *JiraOAuthProvider) {
+	s.oauth = provider
+}
+
+// StartOAuthAuthorization returns the URL the user is sent to to authorize
+// an OAuth connection. The returned state is bound to the connection and the
+// user and expires after JiraOAuthStateTTL. returnTo is an optional local path
+// the user is sent back to afterwards.
+func (s *JiraConnectionService) StartOAuthAuthorization(ctx context.Context, connectionID, userID, returnTo string) (string, error) {
+	if s.oauth == nil {
+		return "", ErrJiraOAuthNotConfigured
+	}
+
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find connection: %w", err)
+	}
+	if conn.authenticationType != AuthTypeOAuth {
+		return "", fmt.Errorf("connection does not use OAuth authentication")
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	state, err := s.signOAuthState(oauthState{
+		ConnectionID: connectionID,
+		UserID:       userID,
+		ReturnTo:     returnTo,
+		ExpiresAt:    time.Now().Add(JiraOAuthStateTTL).Unix(),
+		Nonce:        base64.RawURLEncoding.EncodeToString(nonce),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.oauth.AuthorizationURL(state), nil
+}
+
+// CompleteOAuthAuthorization exchanges the authorization code of a callback,
+// finds the Atlassian site matching the connection's JIRA URL and stores the
+// encrypted tokens. Failures after the state is verified leave the connection
+// failed with the reason.
+func (s *JiraConnectionService) CompleteOAuthAuthorization(ctx context.Context, state, userID, code string) (*JiraOAuthCompletion, error) {
+	if s.oauth == nil {
+		return nil, ErrJiraOAuthNotConfigured
+	}
+
+	payload, conn, err := s.verifyOAuthState(ctx, state, userID)
+	if err != nil {
+		return nil, err
+	}
+	completion := &JiraOAuthCompletion{Connection: conn, ReturnTo: payload.ReturnTo}
+
+	token, err := s.oauth.Exchange(ctx, code)
+	if err != nil {
+		return completion, s.failOAuth(ctx, conn, fmt.Errorf("failed to exchange authorization code: %w", err))
+	}
+
+	resources, err := s.oauth.AccessibleResources(ctx, token.AccessToken)
+	if err != nil {
+		return completion, s.failOAuth(ctx, conn, err)
+	}
+	var cloudID string
+	for _, resource := range resources {
+		if strings.EqualFold(strings.TrimRight(resource.URL, "/"), conn.jiraURL) {
+			cloudID = resource.ID
+			break
+		}
+	}
+	if cloudID == "" {
+		return completion, s.failOAuth(ctx, conn, fmt.Errorf("the authorized account has no access to %s", conn.jiraURL))
+	}
+
+	encryptedAccess, encryptedRefresh, err := s.encryptOAuthToken(token)
+	if err != nil {
+		return completion, err
+	}
+
+	conn.AuthorizeOAuth(encryptedAccess, encryptedRefresh, token.ExpiresAt, cloudID)
+	if err := s.repo.Update(ctx, conn); err != nil {
+		return completion, fmt.Errorf("failed to save connection: %w", err)
+	}
+	s.invalidateMetadata(conn.id)
+
+	log.Printf("[JiraConnectionService] Authorized OAuth connection %s for site %s", conn.id, cloudID)
+	return completion, nil
+}
+
+// RejectOAuthAuthorization records an authorization the user or the
+// authorization server declined
+func (s *JiraConnectionService) RejectOAuthAuthorization(ctx context.Context, state, userID, reason string) (*JiraOAuthCompletion, error) {
+	payload, conn, err := s.verifyOAuthState(ctx, state, userID)
+	if err != nil {
+		return nil, err
+	}
+	completion := &JiraOAuthCompletion{Connection: conn, ReturnTo: payload.ReturnTo}
+	return completion, s.failOAuth(ctx, conn, fmt.Errorf("authorization was declined: %s", reason))
+}
+
+// credentialFor returns the base URL of the JIRA REST API and the decrypted
+// credential of a connection. OAuth access tokens that are about to expire
+// are refreshed first.
+func (s *JiraConnectionService) credentialFor(ctx context.Context, conn *JiraConnection) (string, string, error) {
+	switch {
+	case conn.IsOAuthAuthorized():
+		if s.oauth == nil {
+			return "", "", ErrJiraOAuthNotConfigured
+		}
+		expiresAt := conn.oauth.TokenExpiresAt
+		if expiresAt == nil || time.Until(*expiresAt) < JiraOAuthRefreshLeeway {
+			if err := s.refreshOAuthToken(ctx, conn); err != nil {
+				return "", "", err
+			}
+		}
+	case conn.authenticationType == AuthTypeOAuth && conn.encryptedCredential == "":
+		// Connections with a static OAuth token are used as they are
+		return "", "", ErrJiraOAuthAuthorizationRequired
+	}
+
+	credential, err := s.keys.Decrypt(conn.encryptedCredential)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+	return s.apiURL(conn), credential, nil
+}
+
+// apiURL returns the base URL requests for a connection are sent to. OAuth
+// requests go through the Atlassian API gateway rather than the site itself.
+func (s *JiraConnectionService) apiURL(conn *JiraConnection) string {
+	if conn.IsOAuthAuthorized() && s.oauth != nil {
+		return s.oauth.APIURL(conn.oauth.CloudID)
+	}
+	return conn.jiraURL
+}
+
+// refreshOAuthToken refreshes the access token of a connection. Refreshes are
+// serialized and the connection is reloaded first, so a token another caller
+// just refreshed is reused rather than refreshed again with a rotated token.
+func (s *JiraConnectionService) refreshOAuthToken(ctx context.Context, conn *JiraConnection) error {
+	s.oauthMu.Lock()
+	defer s.oauthMu.Unlock()
+
+	if latest, err := s.repo.FindByID(ctx, conn.id); err == nil && latest.IsOAuthAuthorized() {
+		conn.encryptedCredential = latest.encryptedCredential
+		conn.oauth = latest.oauth
+		if expiresAt := conn.oauth.TokenExpiresAt; expiresAt != nil && time.Until(*expiresAt) >= JiraOAuthRefreshLeeway {
+			return nil
+		}
+	}
+
+	var token *JiraOAuthToken
+	err := errors.New("no refresh token was issued, request the offline_access scope")
+	if conn.oauth.EncryptedRefreshToken != "" {
+		var refreshToken string
+		refreshToken, err = s.keys.Decrypt(conn.oauth.EncryptedRefreshToken)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt refresh token: %w", err)
+		}
+		token, err = s.oauth.Refresh(ctx, refreshToken)
+	}
+	if err != nil {
+		conn.MarkFailed(fmt.Sprintf("OAuth token refresh failed, authorize the connection again: %v", err))
+		if updateErr := s.repo.Update(ctx, conn); updateErr != nil {
+			log.Printf("[JiraConnectionService] Failed to save connection %s after refresh failure: %v", conn.id, updateErr)
+		}
+		return fmt.Errorf("%w: %v", ErrJiraOAuthRefreshFailed, err)
+	}
+
+	encryptedAccess, encryptedRefresh, err := s.encryptOAuthToken(token)
+	if err != nil {
+		return err
+	}
+	conn.RefreshOAuth(encryptedAccess, encryptedRefresh, token.ExpiresAt)
+	if err := s.repo.Update(ctx, conn); err != nil {
+		return fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	return nil
+}
+
+// encryptOAuthToken encrypts the access and refresh tokens of a token pair
+func (s *JiraConnectionService) encryptOAuthToken(token *JiraOAuthToken) (string, string, error) {
+	encryptedAccess, err := s.keys.Encrypt(token.AccessToken)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+	if token.RefreshToken == "" {
+		return encryptedAccess, "", nil
+	}
+	encryptedRefresh, err := s.keys.Encrypt(token.RefreshToken)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt refresh token: %w", err)
+	}
+	return encryptedAccess, encryptedRefresh, nil
+}
+
+// failOAuth marks the connection failed with err as the reason and returns err
+func (s *JiraConnectionService) failOAuth(ctx context.Context, conn *JiraConnection, err error) error {
+	conn.MarkFailed(err.Error())
+	if updateErr := s.repo.Update(ctx, conn); updateErr != nil {
+		log.Printf("[JiraConnectionService] Failed to save connection %s after authorization failure: %v", conn.id, updateErr)
+	}
+	return err
+}
+
+// signOAuthState encodes and signs the state parameter
+func (s *JiraConnectionService) signOAuthState(state oauthState) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode state: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.oauthStateMAC(encoded)), nil
+}
+
+// verifyOAuthState checks the signature, expiry and user of a state
+// parameter and loads its connection
+func (s *JiraConnectionService) verifyOAuthState(ctx context.Context, state, userID string) (*oauthState, *JiraConnection, error) {
+	encoded, signature, ok := strings.Cut(state, ".")
+	if !ok {
+		return nil, nil, ErrInvalidOAuthState
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.oauthStateMAC(encoded)) {
+		return nil, nil, ErrInvalidOAuthState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, ErrInvalidOAuthState
+	}
+	var decoded oauthState
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, nil, ErrInvalidOAuthState
+	}
+	if time.Now().Unix() > decoded.ExpiresAt || decoded.UserID != userID {
+		return nil, nil, ErrInvalidOAuthState
+	}
+
+	conn, err := s.repo.FindByID(ctx, decoded.ConnectionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+	return &decoded, conn, nil
+}
+
+// oauthStateMAC signs a state payload with the primary credential encryption key
+func (s *JiraConnectionService) oauthStateMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, s.keys.primaryKey())
+	mac.Write([]byte("jira-oauth-state:" + payload))
+	return mac.Sum(nil)
+}
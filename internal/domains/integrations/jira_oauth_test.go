@@ -0,0 +1,284 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAuthServer stands in for the Atlassian authorization server and API
+// gateway. It issues rotating tokens and only accepts the latest access token.
+type mockAuthServer struct {
+	*httptest.Server
+	siteURL string
+
+	mu            sync.Mutex
+	issued        int
+	accessToken   string
+	refreshToken  string
+	expiresIn     int
+	rejectRefresh bool
+	exchanges     int
+	refreshes     int
+}
+
+func newMockAuthServer(t *testing.T, siteURL string) *mockAuthServer {
+	server := &mockAuthServer{siteURL: siteURL, expiresIn: 3600}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /oauth/token":
+			var params map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			if params["client_id"] != "client-id" || params["client_secret"] != "client-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+				return
+			}
+
+			switch {
+			case params["grant_type"] == "authorization_code" && params["code"] == "good-code" && params["redirect_uri"] == "https://fern.example.com/callback":
+				server.exchanges++
+			case params["grant_type"] == "refresh_token" && params["refresh_token"] == server.refreshToken && !server.rejectRefresh:
+				server.refreshes++
+			default:
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Unknown or invalid refresh token."}`))
+				return
+			}
+
+			server.issued++
+			server.accessToken = fmt.Sprintf("access-%d", server.issued)
+			server.refreshToken = fmt.Sprintf("refresh-%d", server.issued)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  server.accessToken,
+				"refresh_token": server.refreshToken,
+				"expires_in":    server.expiresIn,
+				"token_type":    "Bearer",
+			})
+		case "GET /oauth/token/accessible-resources":
+			if r.Header.Get("Authorization") != "Bearer "+server.accessToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]string{
+				{"id": "cloud-1", "url": server.siteURL, "name": "acme"},
+			})
+		case "GET /ex/jira/cloud-1/rest/api/2/myself":
+			if r.Header.Get("Authorization") != "Bearer "+server.accessToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"token expired"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"accountId":"abc"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newOAuthService creates a service with an OAuth connection to the mock site
+func newOAuthService(t *testing.T, server *mockAuthServer) (*integrations.JiraConnectionService, *integrations.JiraConnection) {
+	service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, integrations.NewDefaultJiraClient(), testKeyRing)
+
+	provider, err := integrations.NewJiraOAuthProvider(integrations.JiraOAuthSettings{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://fern.example.com/callback",
+		AuthorizeURL: server.URL + "/authorize",
+		TokenURL:     server.URL + "/oauth/token",
+		ResourcesURL: server.URL + "/oauth/token/accessible-resources",
+		APIURL:       server.URL,
+	})
+	require.NoError(t, err)
+	service.EnableOAuth(provider)
+
+	conn, err := service.CreateConnection(context.Background(), "project-1", "JIRA", "https://acme.atlassian.net", integrations.AuthTypeOAuth, "FERN", "", "")
+	require.NoError(t, err)
+	return service, conn
+}
+
+// authorize runs the authorization flow and returns the callback state
+func authorize(t *testing.T, service *integrations.JiraConnectionService, connectionID string) string {
+	authorizationURL, err := service.StartOAuthAuthorization(context.Background(), connectionID, "user-1", "/projects/project-1/settings")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	return parsed.Query().Get("state")
+}
+
+func TestJiraOAuth_AuthorizationFlow(t *testing.T) {
+	ctx := context.Background()
+	server := newMockAuthServer(t, "https://acme.atlassian.net/")
+	service, conn := newOAuthService(t, server)
+
+	authorizationURL, err := service.StartOAuthAuthorization(ctx, conn.ID(), "user-1", "/projects/project-1/settings")
+	require.NoError(t, err)
+	parsed, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/authorize", fmt.Sprintf("%s://%s%s", parsed.Scheme, parsed.Host, parsed.Path))
+	assert.Equal(t, "client-id", parsed.Query().Get("client_id"))
+	assert.Equal(t, "https://fern.example.com/callback", parsed.Query().Get("redirect_uri"))
+	assert.Equal(t, "code", parsed.Query().Get("response_type"))
+	assert.Contains(t, parsed.Query().Get("scope"), "offline_access")
+
+	completion, err := service.CompleteOAuthAuthorization(ctx, parsed.Query().Get("state"), "user-1", "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, "/projects/project-1/settings", completion.ReturnTo)
+	assert.Equal(t, integrations.ConnectionStatusConnected, conn.Status())
+	assert.True(t, conn.IsOAuthAuthorized())
+	assert.Equal(t, "cloud-1", conn.Snapshot().CloudID)
+	require.NotNil(t, conn.Snapshot().TokenExpiresAt)
+
+	// Both tokens are stored encrypted
+	assert.NotEqual(t, "access-1", conn.GetEncryptedCredentialDirect())
+	access, err := testKeyRing.Decrypt(conn.GetEncryptedCredentialDirect())
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", access)
+	refresh, err := testKeyRing.Decrypt(conn.OAuthState().EncryptedRefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-1", refresh)
+
+	// Requests go through the API gateway with the access token
+	require.NoError(t, service.TestConnection(ctx, conn.ID()))
+	assert.Equal(t, 0, server.refreshes)
+}
+
+func TestJiraOAuth_State(t *testing.T) {
+	ctx := context.Background()
+	server := newMockAuthServer(t, "https://acme.atlassian.net")
+	service, conn := newOAuthService(t, server)
+	state := authorize(t, service, conn.ID())
+
+	_, err := service.CompleteOAuthAuthorization(ctx, state, "user-2", "good-code")
+	assert.True(t, errors.Is(err, integrations.ErrInvalidOAuthState), "state is bound to the user")
+
+	_, err = service.CompleteOAuthAuthorization(ctx, state+"x", "user-1", "good-code")
+	assert.True(t, errors.Is(err, integrations.ErrInvalidOAuthState), "state is signed")
+
+	_, err = service.CompleteOAuthAuthorization(ctx, "garbage", "user-1", "good-code")
+	assert.True(t, errors.Is(err, integrations.ErrInvalidOAuthState))
+
+	assert.Equal(t, 0, server.exchanges)
+	assert.Equal(t, integrations.ConnectionStatusPending, conn.Status())
+}
+
+func TestJiraOAuth_RefreshesBeforeExpiry(t *testing.T) {
+	ctx := context.Background()
+	server := newMockAuthServer(t, "https://acme.atlassian.net")
+	service, conn := newOAuthService(t, server)
+
+	// The first token expires within the refresh leeway
+	server.expiresIn = 30
+	_, err := service.CompleteOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "good-code")
+	require.NoError(t, err)
+	server.expiresIn = 3600
+
+	require.NoError(t, service.TestConnection(ctx, conn.ID()))
+	assert.Equal(t, 1, server.refreshes)
+
+	access, err := testKeyRing.Decrypt(conn.GetEncryptedCredentialDirect())
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", access)
+	refresh, err := testKeyRing.Decrypt(conn.OAuthState().EncryptedRefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-2", refresh, "the rotated refresh token is kept")
+
+	// The refreshed token is reused until it is about to expire
+	require.NoError(t, service.TestConnection(ctx, conn.ID()))
+	assert.Equal(t, 1, server.refreshes)
+}
+
+func TestJiraOAuth_RefreshFailureFailsConnection(t *testing.T) {
+	ctx := context.Background()
+	server := newMockAuthServer(t, "https://acme.atlassian.net")
+	service, conn := newOAuthService(t, server)
+
+	server.expiresIn = 30
+	_, err := service.CompleteOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "good-code")
+	require.NoError(t, err)
+	server.rejectRefresh = true
+
+	err = service.TestConnection(ctx, conn.ID())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, integrations.ErrJiraOAuthRefreshFailed))
+	assert.Equal(t, integrations.ConnectionStatusFailed, conn.Status())
+	assert.Contains(t, conn.StatusReason(), "invalid_grant")
+	assert.Contains(t, conn.StatusReason(), "authorize the connection again")
+
+	// Authorizing again recovers the connection
+	server.rejectRefresh = false
+	server.expiresIn = 3600
+	_, err = service.CompleteOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.ConnectionStatusConnected, conn.Status())
+	assert.Empty(t, conn.StatusReason())
+}
+
+func TestJiraOAuth_AuthorizationFailures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("site not granted", func(t *testing.T) {
+		server := newMockAuthServer(t, "https://other.atlassian.net")
+		service, conn := newOAuthService(t, server)
+
+		completion, err := service.CompleteOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "good-code")
+		require.Error(t, err)
+		require.NotNil(t, completion)
+		assert.Equal(t, integrations.ConnectionStatusFailed, conn.Status())
+		assert.Contains(t, conn.StatusReason(), "no access to https://acme.atlassian.net")
+		assert.False(t, conn.IsOAuthAuthorized())
+	})
+
+	t.Run("bad code", func(t *testing.T) {
+		server := newMockAuthServer(t, "https://acme.atlassian.net")
+		service, conn := newOAuthService(t, server)
+
+		_, err := service.CompleteOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "stale-code")
+		require.Error(t, err)
+		assert.Equal(t, integrations.ConnectionStatusFailed, conn.Status())
+		assert.Contains(t, conn.StatusReason(), "failed to exchange authorization code")
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		server := newMockAuthServer(t, "https://acme.atlassian.net")
+		service, conn := newOAuthService(t, server)
+
+		_, err := service.RejectOAuthAuthorization(ctx, authorize(t, service, conn.ID()), "user-1", "access_denied")
+		require.Error(t, err)
+		assert.Equal(t, integrations.ConnectionStatusFailed, conn.Status())
+		assert.Equal(t, "authorization was declined: access_denied", conn.StatusReason())
+	})
+
+	t.Run("not authorized yet", func(t *testing.T) {
+		server := newMockAuthServer(t, "https://acme.atlassian.net")
+		service, conn := newOAuthService(t, server)
+
+		err := service.TestConnection(ctx, conn.ID())
+		assert.True(t, errors.Is(err, integrations.ErrJiraOAuthAuthorizationRequired))
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, &mockJiraClient{shouldSucceed: true}, testKeyRing)
+		conn, err := service.CreateConnection(ctx, "project-1", "JIRA", "https://acme.atlassian.net", integrations.AuthTypeOAuth, "FERN", "", "")
+		require.NoError(t, err)
+
+		_, err = service.StartOAuthAuthorization(ctx, conn.ID(), "user-1", "")
+		assert.True(t, errors.Is(err, integrations.ErrJiraOAuthNotConfigured))
+	})
+}
@@ -0,0 +1,380 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrIssueSyncNotConfigured is returned when issue sync has not been enabled on the service
+var ErrIssueSyncNotConfigured = errors.New("JIRA issue sync is not configured")
+
+// Flaky test statuses reported by a FlakyTestTracker
+const (
+	FlakyTestStatusActive   = "active"
+	FlakyTestStatusResolved = "resolved"
+	FlakyTestStatusIgnored  = "ignored"
+)
+
+// FlakyTestState is the state of a flaky test used when syncing its JIRA issue
+type FlakyTestState struct {
+	Status string
+	// ReactivatedAt is when the test last went from resolved back to active
+	ReactivatedAt    *time.Time
+	FlakeRate        float64 // Share of flaky executions, from 0 to 1
+	TotalExecutions  int
+	FlakyExecutions  int
+	LastSeenAt       time.Time
+	LastErrorMessage string
+	// URL links to the flaky test in Fern
+	URL string
+}
+
+// FlakyTestTracker reads and resolves the flaky tests that JIRA issues are linked to
+type FlakyTestTracker interface {
+	// FlakyTestState loads a flaky test, returning nil when it no longer exists
+	FlakyTestState(ctx context.Context, flakyTestID uint) (*FlakyTestState, error)
+	// ResolveFlakyTest marks an active flaky test resolved
+	ResolveFlakyTest(ctx context.Context, flakyTestID uint, reason string) error
+}
+
+// ReopenOnReflakeFunc reports whether a project reopens closed issues when
+// their flaky test flakes again, rather than only commenting on them
+type ReopenOnReflakeFunc func(ctx context.Context, projectID string) bool
+
+// IssueSyncOptions controls how often the sync job talks to JIRA
+type IssueSyncOptions struct {
+	// RequestInterval is the minimum time between requests to JIRA
+	RequestInterval time.Duration
+	// BaseBackoff is how long a failing connection is skipped after its first failure.
+	// It doubles with every consecutive failure up to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// BatchSize is the number of issues looked up per JQL search
+	BatchSize int
+}
+
+// DefaultIssueSyncOptions returns the options used when none are configured
+func DefaultIssueSyncOptions() IssueSyncOptions {
+	return IssueSyncOptions{
+		RequestInterval: 200 * time.Millisecond,
+		BaseBackoff:     5 * time.Minute,
+		MaxBackoff:      6 * time.Hour,
+		BatchSize:       50,
+	}
+}
+
+// IssueSyncResult summarizes a sync run
+type IssueSyncResult struct {
+	Connections        int
+	SkippedConnections int
+	FailedConnections  int
+	IssuesChecked      int
+	FlakyTestsResolved int
+	IssuesCommented    int
+	IssuesReopened     int
+	Errors             int
+}
+
+// syncBackoff tracks consecutive sync failures of a connection
+type syncBackoff struct {
+	failures    int
+	nextAttempt time.Time
+}
+
+// EnableIssueSync configures how linked flaky tests are read and resolved,
+// and how projects want re-flakes reported
+func (s *JiraConnectionService) EnableIssueSync(flakyTracker FlakyTestTracker, reopenOnReflake ReopenOnReflakeFunc, options IssueSyncOptions) {
+	defaults := DefaultIssueSyncOptions()
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaults.BatchSize
+	}
+	if options.BaseBackoff <= 0 {
+		options.BaseBackoff = defaults.BaseBackoff
+	}
+	if options.MaxBackoff < options.BaseBackoff {
+		options.MaxBackoff = options.BaseBackoff
+	}
+
+	s.flakyTracker = flakyTracker
+	s.reopenOnReflake = reopenOnReflake
+	s.syncOptions = options
+}
+
+// SyncIssues polls the issues linked through every active connection. Closing
+// an issue resolves its flaky test, and a resolved flaky test that flakes again
+// gets a comment on its issue, or has it reopened when the project asks for it.
+// Connections that fail are retried with exponential backoff.
+func (s *JiraConnectionService) SyncIssues(ctx context.Context) (*IssueSyncResult, error) {
+	if s.issueLinks == nil || s.flakyTracker == nil {
+		return nil, ErrIssueSyncNotConfigured
+	}
+
+	// One sync at a time so the request interval holds across runs
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	connections, err := s.repo.FindAllActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active connections: %w", err)
+	}
+
+	result := &IssueSyncResult{}
+	for _, conn := range connections {
+		if backoff, ok := s.syncBackoffs[conn.id]; ok && time.Now().Before(backoff.nextAttempt) {
+			result.SkippedConnections++
+			continue
+		}
+
+		if err := s.syncConnection(ctx, conn, result); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			delay := s.recordSyncFailure(conn.id, err)
+			log.Printf("[JiraConnectionService] Failed to sync issues of connection %s, retrying in %s: %v", conn.id, delay, err)
+			result.FailedConnections++
+			continue
+		}
+
+		delete(s.syncBackoffs, conn.id)
+		result.Connections++
+	}
+
+	return result, nil
+}
+
+// recordSyncFailure schedules the next sync attempt of a failing connection and returns the delay
+func (s *JiraConnectionService) recordSyncFailure(connectionID string, err error) time.Duration {
+	backoff, ok := s.syncBackoffs[connectionID]
+	if !ok {
+		backoff = &syncBackoff{}
+		s.syncBackoffs[connectionID] = backoff
+	}
+	backoff.failures++
+
+	delay := s.syncOptions.BaseBackoff
+	for i := 1; i < backoff.failures && delay < s.syncOptions.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.syncOptions.MaxBackoff {
+		delay = s.syncOptions.MaxBackoff
+	}
+
+	var rateLimited *JiraRateLimitError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > delay {
+		delay = rateLimited.RetryAfter
+	}
+
+	backoff.nextAttempt = time.Now().Add(delay)
+	return delay
+}
+
+// syncConnection refreshes the status of every issue linked through a connection
+func (s *JiraConnectionService) syncConnection(ctx context.Context, conn *JiraConnection, result *IssueSyncResult) error {
+	links, err := s.issueLinks.FindByConnectionID(ctx, conn.id)
+	if err != nil {
+		return fmt.Errorf("failed to find issue links: %w", err)
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	apiURL, credential, err := s.credentialFor(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	linksByKey := make(map[string][]*JiraIssueLink)
+	for _, link := range links {
+		linksByKey[link.IssueKey] = append(linksByKey[link.IssueKey], link)
+	}
+	keys := make([]string, 0, len(linksByKey))
+	for key := range linksByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for start := 0; start < len(keys); start += s.syncOptions.BatchSize {
+		end := start + s.syncOptions.BatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+
+		if err := s.waitForRequestSlot(ctx); err != nil {
+			return err
+		}
+		jql := fmt.Sprintf("key in (%s) ORDER BY key", strings.Join(batch, ","))
+		statuses, err := s.jiraClient.SearchIssues(ctx, apiURL, conn.username, credential, conn.authenticationType, jql, len(batch))
+		if err != nil {
+			return fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		for _, status := range statuses {
+			for _, link := range linksByKey[status.Key] {
+				result.IssuesChecked++
+				if err := s.syncLink(ctx, conn, apiURL, credential, link, status, result); err != nil {
+					var rateLimited *JiraRateLimitError
+					if errors.As(err, &rateLimited) {
+						return err
+					}
+					log.Printf("[JiraConnectionService] Failed to sync issue %s: %v", link.IssueKey, err)
+					result.Errors++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncLink applies an issue's status to its link and, for flaky tests, to the test itself
+func (s *JiraConnectionService) syncLink(ctx context.Context, conn *JiraConnection, apiURL, credential string, link *JiraIssueLink, status JiraIssueStatus, result *IssueSyncResult) error {
+	now := time.Now()
+	wasDone := link.IssueStatusCategory == JiraStatusCategoryDone
+
+	link.IssueStatus = status.Status
+	link.IssueStatusCategory = status.StatusCategory
+	switch {
+	case !status.IsDone():
+		link.IssueResolvedAt = nil
+	case status.ResolvedAt != nil:
+		link.IssueResolvedAt = status.ResolvedAt
+	case !wasDone || link.IssueResolvedAt == nil:
+		// JIRA did not report when the issue was closed, so use when the sync first saw it
+		link.IssueResolvedAt = &now
+	}
+	link.SyncedAt = &now
+
+	var syncErr error
+	if link.Target.Type == IssueTargetFlakyTest {
+		syncErr = s.syncFlakyTest(ctx, conn, apiURL, credential, link, status, result)
+	}
+
+	if err := s.issueLinks.UpdateSync(ctx, link); err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return syncErr
+}
+
+// syncFlakyTest resolves a flaky test whose issue was closed, and reports a
+// re-flake on the issue when the test flaked again since it was filed, last
+// reported or closed
+func (s *JiraConnectionService) syncFlakyTest(ctx context.Context, conn *JiraConnection, apiURL, credential string, link *JiraIssueLink, status JiraIssueStatus, result *IssueSyncResult) error {
+	flakyTestID, err := strconv.ParseUint(link.Target.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid flaky test ID %q: %w", link.Target.ID, err)
+	}
+
+	state, err := s.flakyTracker.FlakyTestState(ctx, uint(flakyTestID))
+	if err != nil {
+		return fmt.Errorf("failed to load flaky test: %w", err)
+	}
+	if state == nil || state.Status != FlakyTestStatusActive {
+		return nil
+	}
+
+	since := link.CreatedAt
+	if link.ReflakeNotifiedAt != nil && link.ReflakeNotifiedAt.After(since) {
+		since = *link.ReflakeNotifiedAt
+	}
+	if status.IsDone() && link.IssueResolvedAt != nil && link.IssueResolvedAt.After(since) {
+		since = *link.IssueResolvedAt
+	}
+
+	if state.ReactivatedAt != nil && state.ReactivatedAt.After(since) {
+		return s.reportReflake(ctx, conn, apiURL, credential, link, status, state, result)
+	}
+
+	if status.IsDone() {
+		reason := fmt.Sprintf("JIRA issue %s was closed", link.IssueKey)
+		if err := s.flakyTracker.ResolveFlakyTest(ctx, uint(flakyTestID), reason); err != nil {
+			return fmt.Errorf("failed to resolve flaky test: %w", err)
+		}
+		log.Printf("[JiraConnectionService] Resolved flaky test %d because %s", flakyTestID, reason)
+		result.FlakyTestsResolved++
+	}
+	return nil
+}
+
+// reportReflake comments on the issue of a flaky test that flaked again,
+// reopening it first when it is closed and the project asks for it
+func (s *JiraConnectionService) reportReflake(ctx context.Context, conn *JiraConnection, apiURL, credential string, link *JiraIssueLink, status JiraIssueStatus, state *FlakyTestState, result *IssueSyncResult) error {
+	reopen := status.IsDone() && s.reopenOnReflake != nil && s.reopenOnReflake(ctx, link.ProjectID)
+
+	if reopen {
+		if err := s.waitForRequestSlot(ctx); err != nil {
+			return err
+		}
+		if err := s.jiraClient.ReopenIssue(ctx, apiURL, conn.username, credential, conn.authenticationType, link.IssueKey); err != nil {
+			return fmt.Errorf("failed to reopen issue: %w", err)
+		}
+		log.Printf("[JiraConnectionService] Reopened issue %s because its flaky test flaked again", link.IssueKey)
+		result.IssuesReopened++
+	}
+
+	if err := s.waitForRequestSlot(ctx); err != nil {
+		return err
+	}
+	body := reflakeComment(link, state, reopen)
+	if err := s.jiraClient.AddComment(ctx, apiURL, conn.username, credential, conn.authenticationType, link.IssueKey, body); err != nil {
+		return fmt.Errorf("failed to comment on issue: %w", err)
+	}
+	result.IssuesCommented++
+
+	now := time.Now()
+	link.ReflakeNotifiedAt = &now
+	return nil
+}
+
+// reflakeComment builds the comment added to an issue when its flaky test flakes again
+func reflakeComment(link *JiraIssueLink, state *FlakyTestState, reopened bool) string {
+	var b strings.Builder
+
+	testName := link.Target.TestName
+	if link.Target.SuiteName != "" {
+		testName = link.Target.SuiteName + " > " + testName
+	}
+	if reopened {
+		fmt.Fprintf(&b, "Reopened by Fern: *%s* flaked again after this issue was closed.\n\n", testName)
+	} else {
+		fmt.Fprintf(&b, "Fern detected that *%s* flaked again.\n\n", testName)
+	}
+
+	fmt.Fprintf(&b, "* Flake rate: %.1f%%", state.FlakeRate*100)
+	if state.TotalExecutions > 0 {
+		fmt.Fprintf(&b, " (%d of %d runs)", state.FlakyExecutions, state.TotalExecutions)
+	}
+	b.WriteString("\n")
+	if !state.LastSeenAt.IsZero() {
+		fmt.Fprintf(&b, "* Last flaked: %s\n", state.LastSeenAt.UTC().Format(time.RFC3339))
+	}
+	if state.LastErrorMessage != "" {
+		fmt.Fprintf(&b, "* Last error: {noformat}%s{noformat}\n", state.LastErrorMessage)
+	}
+	if state.URL != "" {
+		fmt.Fprintf(&b, "\n[View in Fern|%s]", state.URL)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// waitForRequestSlot blocks until the request interval has passed since the previous request
+func (s *JiraConnectionService) waitForRequestSlot(ctx context.Context) error {
+	if wait := time.Until(s.lastSyncRequest.Add(s.syncOptions.RequestInterval)); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	s.lastSyncRequest = time.Now()
+	return nil
+}
@@ -0,0 +1,386 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncingJiraClient serves issue statuses and records sync requests
+type syncingJiraClient struct {
+	recordingJiraClient
+	statuses  map[string]integrations.JiraIssueStatus
+	searchErr error
+	searches  []string
+	comments  map[string][]string
+	reopened  []string
+}
+
+func (c *syncingJiraClient) SearchIssues(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, jql string, maxResults int) ([]integrations.JiraIssueStatus, error) {
+	c.searches = append(c.searches, jql)
+	if c.searchErr != nil {
+		return nil, c.searchErr
+	}
+
+	keys := jql[strings.Index(jql, "(")+1 : strings.Index(jql, ")")]
+	var found []integrations.JiraIssueStatus
+	for _, key := range strings.Split(keys, ",") {
+		if status, ok := c.statuses[key]; ok {
+			found = append(found, status)
+		}
+	}
+	return found, nil
+}
+
+func (c *syncingJiraClient) AddComment(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issueKey, body string) error {
+	if c.comments == nil {
+		c.comments = make(map[string][]string)
+	}
+	c.comments[issueKey] = append(c.comments[issueKey], body)
+	return nil
+}
+
+func (c *syncingJiraClient) ReopenIssue(ctx context.Context, url, username, credential string, authType integrations.AuthenticationType, issueKey string) error {
+	c.reopened = append(c.reopened, issueKey)
+	status := c.statuses[issueKey]
+	status.Status = "Reopened"
+	status.StatusCategory = integrations.JiraStatusCategoryNew
+	status.ResolvedAt = nil
+	c.statuses[issueKey] = status
+	return nil
+}
+
+// memoryFlakyTracker is an in-memory FlakyTestTracker
+type memoryFlakyTracker struct {
+	states   map[uint]*integrations.FlakyTestState
+	resolved map[uint]string
+}
+
+func (t *memoryFlakyTracker) FlakyTestState(ctx context.Context, flakyTestID uint) (*integrations.FlakyTestState, error) {
+	return t.states[flakyTestID], nil
+}
+
+func (t *memoryFlakyTracker) ResolveFlakyTest(ctx context.Context, flakyTestID uint, reason string) error {
+	if t.resolved == nil {
+		t.resolved = make(map[uint]string)
+	}
+	t.resolved[flakyTestID] = reason
+	t.states[flakyTestID].Status = integrations.FlakyTestStatusResolved
+	return nil
+}
+
+func timeAgo(d time.Duration) *time.Time {
+	t := time.Now().Add(-d)
+	return &t
+}
+
+func newSyncService(t *testing.T, reopen bool) (*integrations.JiraConnectionService, *syncingJiraClient, *memoryIssueLinkRepo, *memoryFlakyTracker) {
+	t.Helper()
+
+	client := &syncingJiraClient{
+		recordingJiraClient: recordingJiraClient{mockJiraClient: mockJiraClient{shouldSucceed: true}},
+		statuses:            make(map[string]integrations.JiraIssueStatus),
+	}
+	service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, client, testKeyRing)
+
+	conn, err := service.CreateConnection(context.Background(), "proj-123", "Production JIRA", "https://example.atlassian.net",
+		integrations.AuthTypeAPIToken, "FERN", "user@example.com", "test-token")
+	require.NoError(t, err)
+	conn.Activate()
+
+	linkRepo := &memoryIssueLinkRepo{}
+	service.EnableIssueCreation(linkRepo, &staticIssueSources{
+		flaky: map[uint]*integrations.IssueSource{
+			7: {
+				Type:      integrations.IssueSourceFlakyTest,
+				ProjectID: "proj-123",
+				TestName:  "pays with card",
+				SuiteName: "Checkout",
+				Targets:   []integrations.IssueTarget{integrations.FlakyTestTarget(7, "Checkout", "pays with card")},
+			},
+		},
+	})
+
+	source, err := service.LoadIssueSource(context.Background(), integrations.IssueSourceRequest{Type: integrations.IssueSourceFlakyTest, FlakyTestID: 7})
+	require.NoError(t, err)
+	_, err = service.CreateIssue(context.Background(), source, "user-1")
+	require.NoError(t, err)
+	require.Len(t, linkRepo.links, 1)
+	linkRepo.links[0].CreatedAt = time.Now().Add(-3 * time.Hour)
+
+	tracker := &memoryFlakyTracker{states: map[uint]*integrations.FlakyTestState{
+		7: {
+			Status:           integrations.FlakyTestStatusActive,
+			FlakeRate:        0.25,
+			TotalExecutions:  20,
+			FlakyExecutions:  5,
+			LastErrorMessage: "timeout waiting for payment",
+			URL:              "https://fern.example.com/flaky-tests/7",
+		},
+	}}
+	service.EnableIssueSync(tracker, func(ctx context.Context, projectID string) bool { return reopen }, integrations.IssueSyncOptions{
+		BaseBackoff: time.Hour,
+		MaxBackoff:  4 * time.Hour,
+	})
+	return service, client, linkRepo, tracker
+}
+
+func TestJiraConnectionService_SyncIssues_ClosingIssueResolvesFlakyTest(t *testing.T) {
+	ctx := context.Background()
+	service, client, linkRepo, tracker := newSyncService(t, false)
+
+	client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "In Progress", StatusCategory: integrations.JiraStatusCategoryInProgress}
+	result, err := service.SyncIssues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Connections)
+	assert.Equal(t, 1, result.IssuesChecked)
+	assert.Equal(t, 0, result.FlakyTestsResolved, "open issues leave the flaky test alone")
+	assert.Equal(t, []string{"key in (FERN-1) ORDER BY key"}, client.searches)
+	assert.Equal(t, "In Progress", linkRepo.links[0].IssueStatus)
+	assert.NotNil(t, linkRepo.links[0].SyncedAt)
+
+	client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "Closed", StatusCategory: integrations.JiraStatusCategoryDone, ResolvedAt: timeAgo(time.Hour)}
+	result, err = service.SyncIssues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FlakyTestsResolved)
+	assert.Equal(t, "JIRA issue FERN-1 was closed", tracker.resolved[7])
+	assert.Equal(t, integrations.FlakyTestStatusResolved, tracker.states[7].Status)
+	assert.Empty(t, client.comments)
+
+	result, err = service.SyncIssues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.FlakyTestsResolved, "already resolved")
+}
+
+func TestJiraConnectionService_SyncIssues_Reflake(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("comments on the issue by default", func(t *testing.T) {
+		service, client, linkRepo, tracker := newSyncService(t, false)
+		client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "Closed", StatusCategory: integrations.JiraStatusCategoryDone, ResolvedAt: timeAgo(2 * time.Hour)}
+		tracker.states[7].ReactivatedAt = timeAgo(time.Hour)
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.IssuesCommented)
+		assert.Equal(t, 0, result.IssuesReopened)
+		assert.Equal(t, 0, result.FlakyTestsResolved, "a re-flake after closing does not resolve the test")
+		assert.Empty(t, client.reopened)
+		require.Len(t, client.comments["FERN-1"], 1)
+		comment := client.comments["FERN-1"][0]
+		assert.Contains(t, comment, "*Checkout > pays with card* flaked again")
+		assert.Contains(t, comment, "Flake rate: 25.0% (5 of 20 runs)")
+		assert.Contains(t, comment, "timeout waiting for payment")
+		assert.Contains(t, comment, "https://fern.example.com/flaky-tests/7")
+		assert.NotNil(t, linkRepo.links[0].ReflakeNotifiedAt)
+
+		_, err = service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Len(t, client.comments["FERN-1"], 1, "a re-flake is reported once")
+	})
+
+	t.Run("reopens closed issues when configured", func(t *testing.T) {
+		service, client, _, tracker := newSyncService(t, true)
+		client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "Closed", StatusCategory: integrations.JiraStatusCategoryDone, ResolvedAt: timeAgo(2 * time.Hour)}
+		tracker.states[7].ReactivatedAt = timeAgo(time.Hour)
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.IssuesReopened)
+		assert.Equal(t, []string{"FERN-1"}, client.reopened)
+		require.Len(t, client.comments["FERN-1"], 1)
+		assert.Contains(t, client.comments["FERN-1"][0], "Reopened by Fern")
+
+		result, err = service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.IssuesReopened)
+		assert.Equal(t, 0, result.FlakyTestsResolved, "the reopened issue keeps the test active")
+	})
+
+	t.Run("comments on open issues even when reopening is configured", func(t *testing.T) {
+		service, client, _, tracker := newSyncService(t, true)
+		client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "Open", StatusCategory: integrations.JiraStatusCategoryNew}
+		tracker.states[7].ReactivatedAt = timeAgo(time.Hour)
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.IssuesReopened)
+		assert.Equal(t, 1, result.IssuesCommented)
+	})
+
+	t.Run("ignores reactivations from before the issue was closed", func(t *testing.T) {
+		service, client, _, tracker := newSyncService(t, false)
+		client.statuses["FERN-1"] = integrations.JiraIssueStatus{Key: "FERN-1", Status: "Done", StatusCategory: integrations.JiraStatusCategoryDone, ResolvedAt: timeAgo(time.Hour)}
+		tracker.states[7].ReactivatedAt = timeAgo(2 * time.Hour)
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.IssuesCommented)
+		assert.Equal(t, 1, result.FlakyTestsResolved)
+	})
+}
+
+func TestJiraConnectionService_SyncIssues_BatchesSearches(t *testing.T) {
+	ctx := context.Background()
+	service, client, linkRepo, tracker := newSyncService(t, false)
+	service.EnableIssueSync(tracker, nil, integrations.IssueSyncOptions{BatchSize: 2})
+
+	for _, key := range []string{"FERN-2", "FERN-3"} {
+		require.NoError(t, linkRepo.Create(ctx, []*integrations.JiraIssueLink{{
+			ProjectID:    "proj-123",
+			ConnectionID: linkRepo.links[0].ConnectionID,
+			IssueKey:     key,
+			SourceType:   integrations.IssueSourceSpecRun,
+			Target:       integrations.FailureTarget("Checkout", key),
+		}}))
+		client.statuses[key] = integrations.JiraIssueStatus{Key: key, Status: "Open", StatusCategory: integrations.JiraStatusCategoryNew}
+	}
+
+	result, err := service.SyncIssues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"key in (FERN-1,FERN-2) ORDER BY key",
+		"key in (FERN-3) ORDER BY key",
+	}, client.searches)
+	assert.Equal(t, 2, result.IssuesChecked, "FERN-1 is missing from JIRA and is skipped")
+	assert.Equal(t, "Open", linkRepo.links[2].IssueStatus)
+}
+
+func TestJiraConnectionService_SyncIssues_BacksOffFailingConnections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips a connection after it fails", func(t *testing.T) {
+		service, client, _, _ := newSyncService(t, false)
+		client.searchErr = errors.New("connection refused")
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.FailedConnections)
+
+		client.searchErr = nil
+		result, err = service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.SkippedConnections)
+		assert.Len(t, client.searches, 1, "no requests are sent while backing off")
+	})
+
+	t.Run("honors Retry-After beyond the backoff", func(t *testing.T) {
+		service, client, _, _ := newSyncService(t, false)
+		service.EnableIssueSync(&memoryFlakyTracker{}, nil, integrations.IssueSyncOptions{BaseBackoff: time.Millisecond})
+		client.searchErr = &integrations.JiraRateLimitError{RetryAfter: time.Hour}
+
+		_, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.SkippedConnections)
+	})
+
+	t.Run("retries once the backoff has passed", func(t *testing.T) {
+		service, client, _, _ := newSyncService(t, false)
+		service.EnableIssueSync(&memoryFlakyTracker{}, nil, integrations.IssueSyncOptions{BaseBackoff: time.Millisecond})
+		client.searchErr = errors.New("connection refused")
+
+		_, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+
+		client.searchErr = nil
+		result, err := service.SyncIssues(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Connections)
+	})
+}
+
+func TestJiraConnectionService_SyncIssues_NotConfigured(t *testing.T) {
+	service := integrations.NewJiraConnectionService(&memoryConnectionRepo{}, &mockJiraClient{shouldSucceed: true}, testKeyRing)
+
+	_, err := service.SyncIssues(context.Background())
+	assert.ErrorIs(t, err, integrations.ErrIssueSyncNotConfigured)
+}
+
+func TestDefaultJiraClient_IssueSync(t *testing.T) {
+	var comment, transition map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			var request map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			if request["jql"] == "key in (LIMIT-1)" {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issues": []map[string]interface{}{
+					{"key": "FERN-1", "fields": map[string]interface{}{
+						"status":         map[string]interface{}{"name": "Closed", "statusCategory": map[string]string{"key": "done"}},
+						"resolutiondate": "2024-03-01T10:15:00.000+0000",
+					}},
+					{"key": "FERN-2", "fields": map[string]interface{}{
+						"status": map[string]interface{}{"name": "In Progress", "statusCategory": map[string]string{"key": "indeterminate"}},
+					}},
+				},
+			})
+		case r.URL.Path == "/rest/api/2/issue/FERN-1/comment" && r.Method == "POST":
+			_ = json.NewDecoder(r.Body).Decode(&comment)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "100"}`))
+		case r.URL.Path == "/rest/api/2/issue/FERN-1/transitions" && r.Method == "GET":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"transitions": []map[string]interface{}{
+					{"id": "31", "name": "Close", "to": map[string]interface{}{"name": "Closed", "statusCategory": map[string]string{"key": "done"}}},
+					{"id": "11", "name": "Start Progress", "to": map[string]interface{}{"name": "In Progress", "statusCategory": map[string]string{"key": "indeterminate"}}},
+					{"id": "41", "name": "Reopen Issue", "to": map[string]interface{}{"name": "Reopened", "statusCategory": map[string]string{"key": "new"}}},
+				},
+			})
+		case r.URL.Path == "/rest/api/2/issue/FERN-1/transitions" && r.Method == "POST":
+			_ = json.NewDecoder(r.Body).Decode(&transition)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/FERN-2/transitions":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"transitions": []map[string]interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := integrations.NewDefaultJiraClient()
+	ctx := context.Background()
+
+	statuses, err := client.SearchIssues(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken, "key in (FERN-1,FERN-2)", 50)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].IsDone())
+	assert.Equal(t, "Closed", statuses[0].Status)
+	require.NotNil(t, statuses[0].ResolvedAt)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC), statuses[0].ResolvedAt.UTC())
+	assert.False(t, statuses[1].IsDone())
+	assert.Nil(t, statuses[1].ResolvedAt)
+
+	_, err = client.SearchIssues(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken, "key in (LIMIT-1)", 50)
+	var rateLimited *integrations.JiraRateLimitError
+	require.True(t, errors.As(err, &rateLimited))
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+
+	require.NoError(t, client.AddComment(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken, "FERN-1", "flaked again"))
+	assert.Equal(t, "flaked again", comment["body"])
+
+	require.NoError(t, client.ReopenIssue(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken, "FERN-1"))
+	assert.Equal(t, map[string]interface{}{"id": "41"}, transition["transition"], "prefers the reopen transition")
+
+	err = client.ReopenIssue(ctx, server.URL, "user@example.com", "token", integrations.AuthTypeAPIToken, "FERN-2")
+	assert.ErrorContains(t, err, "no transition reopens issue FERN-2")
+}
@@ -0,0 +1,195 @@
+package integrations
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// keyRingCiphertextVersion prefixes ciphertexts that record their key ID, as
+// "v1:<key ID>:<ciphertext>". Ciphertexts without it predate key IDs.
+const keyRingCiphertextVersion = "v1"
+
+// keyIDPattern restricts key IDs so they can be embedded in ciphertexts
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// ErrUnknownEncryptionKey is returned when a ciphertext names a key that is not in the key ring
+var ErrUnknownEncryptionKey = errors.New("unknown encryption key")
+
+// KeyRing encrypts credentials with its primary key and decrypts them with
+// whichever key encrypted them, so keys can be rotated without making stored
+// credentials undecryptable
+type KeyRing struct {
+	keys      map[string][]byte
+	primaryID string
+	legacyID  string
+}
+
+// NewKeyRing creates a key ring. New credentials are encrypted with the
+// primary key. Credentials stored before key IDs were recorded are decrypted
+// with the legacy key; legacyID may be empty when there are none.
+func NewKeyRing(primaryID, legacyID string, keys map[string][]byte) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string][]byte, len(keys)), primaryID: primaryID, legacyID: legacyID}
+	for id, key := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid encryption key ID %q", id)
+		}
+		if !isValidAESKeyLength(len(key)) {
+			return nil, fmt.Errorf("encryption key %s must be 16, 24 or 32 bytes, got %d", id, len(key))
+		}
+		ring.keys[id] = append([]byte(nil), key...)
+	}
+
+	if _, ok := ring.keys[primaryID]; !ok {
+		return nil, fmt.Errorf("primary encryption key %q is not in the key ring", primaryID)
+	}
+	if _, ok := ring.keys[legacyID]; legacyID != "" && !ok {
+		return nil, fmt.Errorf("legacy encryption key %q is not in the key ring", legacyID)
+	}
+	return ring, nil
+}
+
+// PrimaryKeyID returns the ID of the key new credentials are encrypted with
+func (r *KeyRing) PrimaryKeyID() string {
+	return r.primaryID
+}
+
+// primaryKey returns the primary key
+func (r *KeyRing) primaryKey() []byte {
+	return r.keys[r.primaryID]
+}
+
+// KeyIDs returns the IDs of all keys in the ring, sorted
+func (r *KeyRing) KeyIDs() []string {
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Encrypt encrypts a credential with the primary key
+func (r *KeyRing) Encrypt(credential string) (string, error) {
+	encrypted, err := EncryptCredential(credential, r.primaryKey())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s:%s", keyRingCiphertextVersion, r.primaryID, encrypted), nil
+}
+
+// Decrypt decrypts a credential with the key that encrypted it
+func (r *KeyRing) Decrypt(encrypted string) (string, error) {
+	keyID, ciphertext := r.split(encrypted)
+	if keyID == "" {
+		return "", errors.New("credential was encrypted without a key ID and no legacy key is configured")
+	}
+	key, ok := r.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownEncryptionKey, keyID)
+	}
+	return DecryptCredential(ciphertext, key)
+}
+
+// KeyID returns the ID of the key that encrypted a credential, or "" when it
+// predates key IDs and no legacy key is configured
+func (r *KeyRing) KeyID(encrypted string) string {
+	keyID, _ := r.split(encrypted)
+	return keyID
+}
+
+// NeedsReencryption returns whether a credential is not encrypted with the primary key
+func (r *KeyRing) NeedsReencryption(encrypted string) bool {
+	return encrypted != "" && r.KeyID(encrypted) != r.primaryID
+}
+
+// Reencrypt decrypts a credential and encrypts it again with the primary key
+func (r *KeyRing) Reencrypt(encrypted string) (string, error) {
+	credential, err := r.Decrypt(encrypted)
+	if err != nil {
+		return "", err
+	}
+	return r.Encrypt(credential)
+}
+
+// split separates the key ID from the ciphertext. Ciphertexts without a key
+// ID are attributed to the legacy key; base64 never contains a colon.
+func (r *KeyRing) split(encrypted string) (string, string) {
+	if rest, ok := strings.CutPrefix(encrypted, keyRingCiphertextVersion+":"); ok {
+		if keyID, ciphertext, ok := strings.Cut(rest, ":"); ok {
+			return keyID, ciphertext
+		}
+	}
+	return r.legacyID, encrypted
+}
+
+// KeySource says where an encryption key is loaded from: a file or an
+// environment variable holding either the raw key or "base64:" followed by
+// its base64 encoding
+type KeySource struct {
+	ID   string
+	File string
+	Env  string
+}
+
+// LoadKeyRing loads the keys of a key ring from their sources
+func LoadKeyRing(primaryID, legacyID string, sources []KeySource) (*KeyRing, error) {
+	keys := make(map[string][]byte, len(sources))
+	for _, source := range sources {
+		if _, ok := keys[source.ID]; ok {
+			return nil, fmt.Errorf("encryption key %s is configured more than once", source.ID)
+		}
+		key, err := LoadKey(source)
+		if err != nil {
+			return nil, err
+		}
+		keys[source.ID] = key
+	}
+	return NewKeyRing(primaryID, legacyID, keys)
+}
+
+// LoadKey loads a key from its file or environment variable
+func LoadKey(source KeySource) ([]byte, error) {
+	var value string
+	switch {
+	case source.File != "" && source.Env != "":
+		return nil, fmt.Errorf("encryption key %s must be loaded from a file or an environment variable, not both", source.ID)
+	case source.File != "":
+		data, err := os.ReadFile(source.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key %s: %w", source.ID, err)
+		}
+		value = string(data)
+	case source.Env != "":
+		var ok bool
+		value, ok = os.LookupEnv(source.Env)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s for encryption key %s is not set", source.Env, source.ID)
+		}
+	default:
+		return nil, fmt.Errorf("encryption key %s has no file or environment variable", source.ID)
+	}
+
+	// Files commonly end with a newline
+	key := []byte(strings.TrimSpace(value))
+	if encoded, ok := strings.CutPrefix(string(key), "base64:"); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encryption key %s: %w", source.ID, err)
+		}
+		key = decoded
+	}
+	if !isValidAESKeyLength(len(key)) {
+		return nil, fmt.Errorf("encryption key %s must be 16, 24 or 32 bytes, got %d", source.ID, len(key))
+	}
+	return key, nil
+}
+
+// isValidAESKeyLength returns whether n is the length of an AES-128, AES-192 or AES-256 key
+func isValidAESKeyLength(n int) bool {
+	return n == 16 || n == 24 || n == 32
+}
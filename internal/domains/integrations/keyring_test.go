@@ -0,0 +1,144 @@
+package integrations_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey = []byte("old-encryption-key-32-bytes-long")
+	newKey = []byte("new-encryption-key-32-bytes-long")
+)
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldRing, err := integrations.NewKeyRing("old", "", map[string][]byte{"old": oldKey})
+	require.NoError(t, err)
+	encrypted, err := oldRing.Encrypt("secret-token")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "v1:old:"))
+	assert.Equal(t, "old", oldRing.KeyID(encrypted))
+	assert.False(t, oldRing.NeedsReencryption(encrypted))
+
+	// After rotating, old credentials stay readable and new ones use the new key
+	ring, err := integrations.NewKeyRing("new", "", map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+	assert.Equal(t, "new", ring.PrimaryKeyID())
+	assert.Equal(t, []string{"new", "old"}, ring.KeyIDs())
+
+	decrypted, err := ring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", decrypted)
+	assert.True(t, ring.NeedsReencryption(encrypted))
+
+	reencrypted, err := ring.Reencrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "new", ring.KeyID(reencrypted))
+	assert.False(t, ring.NeedsReencryption(reencrypted))
+	decrypted, err = ring.Decrypt(reencrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", decrypted)
+
+	// Once the old key is retired, its credentials can no longer be read
+	newRing, err := integrations.NewKeyRing("new", "", map[string][]byte{"new": newKey})
+	require.NoError(t, err)
+	_, err = newRing.Decrypt(encrypted)
+	assert.ErrorIs(t, err, integrations.ErrUnknownEncryptionKey)
+	_, err = newRing.Decrypt(reencrypted)
+	assert.NoError(t, err)
+}
+
+func TestKeyRing_LegacyCiphertexts(t *testing.T) {
+	legacy, err := integrations.EncryptCredential("legacy-token", oldKey)
+	require.NoError(t, err)
+
+	ring, err := integrations.NewKeyRing("new", "old", map[string][]byte{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+	assert.Equal(t, "old", ring.KeyID(legacy))
+	assert.True(t, ring.NeedsReencryption(legacy))
+	decrypted, err := ring.Decrypt(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", decrypted)
+
+	withoutLegacy, err := integrations.NewKeyRing("new", "", map[string][]byte{"new": newKey})
+	require.NoError(t, err)
+	_, err = withoutLegacy.Decrypt(legacy)
+	assert.Error(t, err)
+}
+
+func TestNewKeyRing_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		primaryID string
+		legacyID  string
+		keys      map[string][]byte
+	}{
+		{"missing primary key", "missing", "", map[string][]byte{"new": newKey}},
+		{"missing legacy key", "new", "missing", map[string][]byte{"new": newKey}},
+		{"short key", "new", "", map[string][]byte{"new": []byte("too-short")}},
+		{"invalid key ID", "new:1", "", map[string][]byte{"new:1": newKey}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := integrations.NewKeyRing(tt.primaryID, tt.legacyID, tt.keys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "old.key")
+	require.NoError(t, os.WriteFile(keyFile, append(oldKey, '\n'), 0o600))
+	t.Setenv("FERN_TEST_NEW_KEY", "base64:"+base64.StdEncoding.EncodeToString(newKey))
+
+	ring, err := integrations.LoadKeyRing("new", "", []integrations.KeySource{
+		{ID: "old", File: keyFile},
+		{ID: "new", Env: "FERN_TEST_NEW_KEY"},
+	})
+	require.NoError(t, err)
+
+	// Both keys were loaded as written
+	oldRing, err := integrations.NewKeyRing("old", "", map[string][]byte{"old": oldKey})
+	require.NoError(t, err)
+	encrypted, err := oldRing.Encrypt("secret-token")
+	require.NoError(t, err)
+	decrypted, err := ring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", decrypted)
+
+	key, err := integrations.LoadKey(integrations.KeySource{ID: "new", Env: "FERN_TEST_NEW_KEY"})
+	require.NoError(t, err)
+	assert.Equal(t, newKey, key)
+
+	t.Run("Failures", func(t *testing.T) {
+		t.Setenv("FERN_TEST_BAD_KEY", "base64:not base64")
+		t.Setenv("FERN_TEST_SHORT_KEY", "too-short")
+
+		sources := map[string]integrations.KeySource{
+			"no source":          {ID: "k"},
+			"file and env":       {ID: "k", File: keyFile, Env: "FERN_TEST_NEW_KEY"},
+			"missing file":       {ID: "k", File: filepath.Join(dir, "missing.key")},
+			"unset variable":     {ID: "k", Env: "FERN_TEST_UNSET_KEY"},
+			"invalid base64":     {ID: "k", Env: "FERN_TEST_BAD_KEY"},
+			"invalid key length": {ID: "k", Env: "FERN_TEST_SHORT_KEY"},
+		}
+		for name, source := range sources {
+			_, err := integrations.LoadKey(source)
+			assert.Error(t, err, name)
+		}
+
+		_, err := integrations.LoadKeyRing("new", "", []integrations.KeySource{
+			{ID: "new", File: keyFile},
+			{ID: "new", Env: "FERN_TEST_NEW_KEY"},
+		})
+		assert.Error(t, err, "duplicate key ID")
+	})
+}
@@ -0,0 +1,484 @@
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// NotificationChannelType identifies the chat service a rule posts to
+type NotificationChannelType string
+
+const (
+	NotificationChannelSlack NotificationChannelType = "slack"
+	NotificationChannelTeams NotificationChannelType = "teams"
+)
+
+// NotificationChannelTypes lists the chat services rules can post to
+func NotificationChannelTypes() []NotificationChannelType {
+	return []NotificationChannelType{NotificationChannelSlack, NotificationChannelTeams}
+}
+
+// DefaultNotificationDedupWindow is how long repeats of a notification are suppressed by default
+const DefaultNotificationDedupWindow = time.Hour
+
+// ErrInvalidNotificationRule is returned when a rule's settings are invalid
+var ErrInvalidNotificationRule = errors.New("invalid notification rule")
+
+// NotificationFilter narrows the events a rule posts. Every non-empty list
+// must match; a list filtering on something the event does not carry, such
+// as the branch of a flaky test, is ignored for that event.
+type NotificationFilter struct {
+	Branches     []string // Branch patterns, e.g. "main" or "release/*"
+	Environments []string
+	Statuses     []string // Test run status, or flaky test status for flaky test events
+	Tags         []string // Matches when the event has any of the tags
+	Severities   []string // Flaky test severity
+}
+
+// QuietHours is a daily period in which notifications are held back until
+// it ends. Start and End are "HH:MM" in TimeZone; a period may span midnight.
+type QuietHours struct {
+	Start    string
+	End      string
+	TimeZone string
+}
+
+// validate checks the period and its time zone
+func (q QuietHours) validate() error {
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	if errStart != nil || errEnd != nil {
+		return fmt.Errorf("%w: quiet hours must be given as HH:MM", ErrInvalidNotificationRule)
+	}
+	if start == end {
+		return fmt.Errorf("%w: quiet hours must not start and end at the same time", ErrInvalidNotificationRule)
+	}
+	if _, err := q.location(); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidNotificationRule, q.TimeZone)
+	}
+	return nil
+}
+
+// location returns the time zone of the period, UTC when none is set
+func (q QuietHours) location() (*time.Location, error) {
+	if q.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(q.TimeZone)
+}
+
+// EndAfter returns when the quiet period containing t ends, and false when t
+// is outside quiet hours
+func (q QuietHours) EndAfter(t time.Time) (time.Time, bool) {
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	location, errLocation := q.location()
+	if errStart != nil || errEnd != nil || errLocation != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	endOn := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, end/60, end%60, 0, 0, location)
+	}
+
+	switch {
+	case start < end && minute >= start && minute < end:
+		return endOn(0), true
+	case start > end && minute >= start:
+		// Spans midnight and ends tomorrow
+		return endOn(1), true
+	case start > end && minute < end:
+		return endOn(0), true
+	}
+	return time.Time{}, false
+}
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// NotificationRuleSettings are the user-provided settings of a notification rule
+type NotificationRuleSettings struct {
+	Name          string
+	ChannelType   NotificationChannelType
+	Events        []WebhookEventType
+	Filter        NotificationFilter
+	QuietHours    *QuietHours
+	DedupWindow   time.Duration // Zero posts every matching event
+	TitleTemplate string        // Overrides the default title of the event type when set
+	TextTemplate  string        // Overrides the default text of the event type when set
+}
+
+// validate checks the settings of a rule
+func (s NotificationRuleSettings) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidNotificationRule)
+	}
+	if s.ChannelType != NotificationChannelSlack && s.ChannelType != NotificationChannelTeams {
+		return fmt.Errorf("%w: unknown channel type %q", ErrInvalidNotificationRule, s.ChannelType)
+	}
+	if len(s.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidNotificationRule)
+	}
+	for _, event := range s.Events {
+		if !isWebhookEventType(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidNotificationRule, event)
+		}
+	}
+	for _, pattern := range s.Filter.Branches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: invalid branch pattern %q", ErrInvalidNotificationRule, pattern)
+		}
+	}
+	if s.QuietHours != nil {
+		if err := s.QuietHours.validate(); err != nil {
+			return err
+		}
+	}
+	if s.DedupWindow < 0 {
+		return fmt.Errorf("%w: deduplication window must not be negative", ErrInvalidNotificationRule)
+	}
+	for name, text := range map[string]string{"title": s.TitleTemplate, "text": s.TextTemplate} {
+		if _, err := parseNotificationTemplate(name, text); err != nil {
+			return fmt.Errorf("%w: invalid %s template: %v", ErrInvalidNotificationRule, name, err)
+		}
+	}
+	return nil
+}
+
+// normalize sorts and deduplicates the events of the settings
+func (s NotificationRuleSettings) normalize() NotificationRuleSettings {
+	s.Events = normalizeWebhookEvents(s.Events)
+	return s
+}
+
+// NotificationRule posts the events of a project that match its filter to a
+// Slack or Microsoft Teams channel through an incoming webhook
+type NotificationRule struct {
+	id                  string
+	projectID           string
+	settings            NotificationRuleSettings
+	encryptedWebhookURL string
+	isActive            bool
+	createdBy           string
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+// NewNotificationRule creates an active rule. The incoming webhook URL must
+// already be encrypted.
+func NewNotificationRule(projectID string, settings NotificationRuleSettings, encryptedWebhookURL, createdBy string) (*NotificationRule, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("%w: project ID is required", ErrInvalidNotificationRule)
+	}
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+	if encryptedWebhookURL == "" {
+		return nil, fmt.Errorf("%w: webhook URL is required", ErrInvalidNotificationRule)
+	}
+
+	now := time.Now()
+	return &NotificationRule{
+		projectID:           projectID,
+		settings:            settings.normalize(),
+		encryptedWebhookURL: encryptedWebhookURL,
+		isActive:            true,
+		createdBy:           createdBy,
+		createdAt:           now,
+		updatedAt:           now,
+	}, nil
+}
+
+// validateNotificationWebhookURL checks an incoming webhook URL before it is encrypted
+func validateNotificationWebhookURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: webhook URL must be an absolute http or https URL", ErrInvalidNotificationRule)
+	}
+	return nil
+}
+
+// ID returns the rule ID
+func (r *NotificationRule) ID() string {
+	return r.id
+}
+
+// ProjectID returns the project whose events the rule posts
+func (r *NotificationRule) ProjectID() string {
+	return r.projectID
+}
+
+// Settings returns the user-provided settings of the rule
+func (r *NotificationRule) Settings() NotificationRuleSettings {
+	return r.settings
+}
+
+// ChannelType returns the chat service the rule posts to
+func (r *NotificationRule) ChannelType() NotificationChannelType {
+	return r.settings.ChannelType
+}
+
+// EncryptedWebhookURL returns the encrypted incoming webhook URL
+func (r *NotificationRule) EncryptedWebhookURL() string {
+	return r.encryptedWebhookURL
+}
+
+// IsActive returns whether the rule posts notifications
+func (r *NotificationRule) IsActive() bool {
+	return r.isActive
+}
+
+// AssignID sets the ID given by the repository when the rule is created
+func (r *NotificationRule) AssignID(id string) {
+	r.id = id
+}
+
+// Update replaces the user-provided settings of the rule. An empty encrypted
+// webhook URL keeps the current one.
+func (r *NotificationRule) Update(settings NotificationRuleSettings, encryptedWebhookURL string) error {
+	if err := settings.validate(); err != nil {
+		return err
+	}
+
+	r.settings = settings.normalize()
+	if encryptedWebhookURL != "" {
+		r.encryptedWebhookURL = encryptedWebhookURL
+	}
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Activate resumes posting notifications
+func (r *NotificationRule) Activate() {
+	r.isActive = true
+	r.updatedAt = time.Now()
+}
+
+// Deactivate stops posting notifications
+func (r *NotificationRule) Deactivate() {
+	r.isActive = false
+	r.updatedAt = time.Now()
+}
+
+// Matches returns whether the rule posts an event
+func (r *NotificationRule) Matches(event WebhookEvent) bool {
+	if !r.isActive || event.ProjectID != r.projectID {
+		return false
+	}
+
+	subscribed := false
+	for _, eventType := range r.settings.Events {
+		if eventType == event.Type {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+
+	filter := r.settings.Filter
+	if branch, ok := eventString(event.Data, "branch"); ok && len(filter.Branches) > 0 && !matchesBranch(filter.Branches, branch) {
+		return false
+	}
+	if environment, ok := eventString(event.Data, "environment"); ok && len(filter.Environments) > 0 && !containsFold(filter.Environments, environment) {
+		return false
+	}
+	if status, ok := eventString(event.Data, "status"); ok && len(filter.Statuses) > 0 && !containsFold(filter.Statuses, status) {
+		return false
+	}
+	if severity, ok := eventString(event.Data, "severity"); ok && len(filter.Severities) > 0 && !containsFold(filter.Severities, severity) {
+		return false
+	}
+	if tags, ok := eventStrings(event.Data, "tags"); ok && len(filter.Tags) > 0 {
+		matched := false
+		for _, tag := range tags {
+			if containsFold(filter.Tags, tag) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesBranch returns whether a branch matches any of the patterns
+func matchesBranch(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold returns whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventString returns a value of the event data as a string, and false when the event does not carry it
+func eventString(data map[string]interface{}, key string) (string, bool) {
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	return fmt.Sprint(value), true
+}
+
+// eventStrings returns a list value of the event data, and false when the event does not carry it
+func eventStrings(data map[string]interface{}, key string) ([]string, bool) {
+	switch values := data[key].(type) {
+	case []string:
+		return values, true
+	case []interface{}:
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = fmt.Sprint(value)
+		}
+		return strs, true
+	}
+	return nil, false
+}
+
+// notificationDedupKey identifies repeats of an event: test run events of the
+// same branch and environment, or flaky test events of the same test
+func notificationDedupKey(event WebhookEvent) string {
+	parts := []string{string(event.Type)}
+	switch event.Type {
+	case WebhookEventFlakyDetected, WebhookEventFlakyResolved:
+		suite, _ := eventString(event.Data, "suiteName")
+		test, _ := eventString(event.Data, "testName")
+		parts = append(parts, suite, test)
+	default:
+		branch, _ := eventString(event.Data, "branch")
+		environment, _ := eventString(event.Data, "environment")
+		parts = append(parts, branch, environment)
+	}
+	return strings.Join(parts, "|")
+}
+
+// parseNotificationTemplate parses a title or text template; an empty template parses to nil
+func parseNotificationTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New(name).Option("missingkey=zero").Funcs(notificationTemplateFuncs).Parse(text)
+}
+
+// Snapshot returns a read-only snapshot of the rule
+func (r *NotificationRule) Snapshot() NotificationRuleSnapshot {
+	settings := r.settings
+	settings.Events = append([]WebhookEventType(nil), settings.Events...)
+	if settings.QuietHours != nil {
+		quietHours := *settings.QuietHours
+		settings.QuietHours = &quietHours
+	}
+
+	return NotificationRuleSnapshot{
+		ID:        r.id,
+		ProjectID: r.projectID,
+		Settings:  settings,
+		IsActive:  r.isActive,
+		CreatedBy: r.createdBy,
+		CreatedAt: r.createdAt,
+		UpdatedAt: r.updatedAt,
+	}
+}
+
+// NotificationRuleSnapshot is a read-only view of a notification rule
+type NotificationRuleSnapshot struct {
+	ID        string
+	ProjectID string
+	Settings  NotificationRuleSettings
+	IsActive  bool
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReconstructNotificationRule reconstructs a NotificationRule from persisted data
+func ReconstructNotificationRule(snapshot NotificationRuleSnapshot, encryptedWebhookURL string) *NotificationRule {
+	return &NotificationRule{
+		id:                  snapshot.ID,
+		projectID:           snapshot.ProjectID,
+		settings:            snapshot.Settings,
+		encryptedWebhookURL: encryptedWebhookURL,
+		isActive:            snapshot.IsActive,
+		createdBy:           snapshot.CreatedBy,
+		createdAt:           snapshot.CreatedAt,
+		updatedAt:           snapshot.UpdatedAt,
+	}
+}
+
+// NotificationDeliveryStatus is the state of a notification
+type NotificationDeliveryStatus string
+
+const (
+	NotificationPending    NotificationDeliveryStatus = "pending"
+	NotificationSent       NotificationDeliveryStatus = "sent"
+	NotificationFailed     NotificationDeliveryStatus = "failed"
+	NotificationSuppressed NotificationDeliveryStatus = "suppressed" // A repeat within the deduplication window
+)
+
+// NotificationDelivery is one notification queued for, posted to, or
+// suppressed for a rule's channel. Deliveries are kept as a log and to
+// find repeats.
+type NotificationDelivery struct {
+	ID             string
+	RuleID         string
+	ProjectID      string
+	EventType      WebhookEventType
+	DedupKey       string
+	Title          string
+	Payload        string // Request body for the channel
+	Status         NotificationDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	LastError      string
+	HeldUntil      *time.Time // End of the quiet hours the notification was raised in
+	SuppressedBy   string     // ID of the notification this one repeats
+	CreatedAt      time.Time
+}
+
+// recordAttempt records the outcome of a delivery attempt and schedules the
+// next one, or fails the notification once maxAttempts is reached
+func (d *NotificationDelivery) recordAttempt(at time.Time, statusCode int, attemptErr error, maxAttempts int, backoff func(attempt int) time.Duration) {
+	d.ResponseStatus = statusCode
+
+	attempts := deliveryAttempts{&d.Attempts, &d.NextAttemptAt, &d.LastAttemptAt, &d.LastError}
+	switch attempts.record(at, attemptErr, maxAttempts, backoff) {
+	case attemptSucceeded:
+		d.Status = NotificationSent
+	case attemptExhausted:
+		d.Status = NotificationFailed
+	default:
+		d.Status = NotificationPending
+	}
+}
@@ -0,0 +1,378 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// NotificationTone colors a message by how good or bad the news is
+type NotificationTone string
+
+const (
+	NotificationToneNeutral NotificationTone = "neutral"
+	NotificationToneGood    NotificationTone = "good"
+	NotificationToneWarning NotificationTone = "warning"
+	NotificationToneDanger  NotificationTone = "danger"
+)
+
+// NotificationField is a labelled detail shown below a message's text
+type NotificationField struct {
+	Name  string
+	Value string
+}
+
+// NotificationMessage is a chat message about an event, before it is
+// formatted for a chat service
+type NotificationMessage struct {
+	Title     string
+	Text      string
+	URL       string // Link to the event's page in Fern, if any
+	Tone      NotificationTone
+	Fields    []NotificationField
+	ProjectID string
+}
+
+// notificationTemplateFuncs are available in title and text templates
+var notificationTemplateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join": func(values interface{}, sep string) string {
+		strs, _ := eventStrings(map[string]interface{}{"values": values}, "values")
+		return strings.Join(strs, sep)
+	},
+	"shortCommit": shortCommit,
+	"percent": func(value interface{}) string {
+		return fmt.Sprintf("%.1f%%", toFloat(value))
+	},
+	"duration": func(milliseconds interface{}) string {
+		return formatNotificationDuration(time.Duration(toFloat(milliseconds)) * time.Millisecond)
+	},
+}
+
+// defaultNotificationTemplates are the title and text templates of each event type
+var defaultNotificationTemplates = map[WebhookEventType][2]string{
+	WebhookEventTestRunCreated: {
+		`Test run {{.runId}} started{{if .branch}} on {{.branch}}{{end}}`,
+		`{{if .name}}{{.name}}{{else}}A test run{{end}} started in project {{.projectId}}.`,
+	},
+	WebhookEventTestRunCompleted: {
+		`Test run {{.runId}} {{.status}}{{if .branch}} on {{.branch}}{{end}}`,
+		`{{.passedTests}} of {{.totalTests}} tests passed{{if .failedTests}}, {{.failedTests}} failed{{end}}{{if .skippedTests}}, {{.skippedTests}} skipped{{end}}.`,
+	},
+	WebhookEventTestRunFailed: {
+		`Test run {{.runId}} failed{{if .branch}} on {{.branch}}{{end}}`,
+		`{{.failedTests}} of {{.totalTests}} tests failed.`,
+	},
+	WebhookEventFlakyDetected: {
+		`Flaky test detected: {{.testName}}`,
+		`{{if .suiteName}}{{.suiteName}}: {{end}}{{.testName}} is flaky{{with .flakeRate}} with a flake rate of {{percent .}}{{end}}.`,
+	},
+	WebhookEventFlakyResolved: {
+		`Flaky test resolved: {{.testName}}`,
+		`{{if .suiteName}}{{.suiteName}}: {{end}}{{.testName}} is no longer flaky.{{with .reason}} {{.}}{{end}}`,
+	},
+}
+
+// RenderNotificationMessage renders the message about an event. Empty
+// templates fall back to the defaults of the event type. Templates see the
+// event data along with "event", "projectId" and "occurredAt".
+func RenderNotificationMessage(event WebhookEvent, titleTemplate, textTemplate string) (NotificationMessage, error) {
+	defaults := defaultNotificationTemplates[event.Type]
+	if titleTemplate == "" {
+		titleTemplate = defaults[0]
+	}
+	if textTemplate == "" {
+		textTemplate = defaults[1]
+	}
+
+	data := make(map[string]interface{}, len(event.Data)+3)
+	for key, value := range event.Data {
+		data[key] = value
+	}
+	data["event"] = string(event.Type)
+	data["projectId"] = event.ProjectID
+	data["occurredAt"] = event.OccurredAt
+
+	title, err := executeNotificationTemplate("title", titleTemplate, data)
+	if err != nil {
+		return NotificationMessage{}, err
+	}
+	text, err := executeNotificationTemplate("text", textTemplate, data)
+	if err != nil {
+		return NotificationMessage{}, err
+	}
+
+	url, _ := eventString(event.Data, "url")
+	return NotificationMessage{
+		Title:     title,
+		Text:      text,
+		URL:       url,
+		Tone:      notificationTone(event),
+		Fields:    notificationFields(event),
+		ProjectID: event.ProjectID,
+	}, nil
+}
+
+// executeNotificationTemplate renders one template
+func executeNotificationTemplate(name, text string, data map[string]interface{}) (string, error) {
+	tmpl, err := parseNotificationTemplate(name, text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	// Missing values render as "<no value>" in maps
+	return strings.TrimSpace(strings.ReplaceAll(rendered.String(), "<no value>", "")), nil
+}
+
+// notificationTone picks the color of an event's message
+func notificationTone(event WebhookEvent) NotificationTone {
+	switch event.Type {
+	case WebhookEventTestRunFailed:
+		return NotificationToneDanger
+	case WebhookEventTestRunCompleted:
+		status, _ := eventString(event.Data, "status")
+		if status == "failed" || toFloat(event.Data["failedTests"]) > 0 {
+			return NotificationToneDanger
+		}
+		return NotificationToneGood
+	case WebhookEventFlakyDetected:
+		if severity, _ := eventString(event.Data, "severity"); severity == "critical" {
+			return NotificationToneDanger
+		}
+		return NotificationToneWarning
+	case WebhookEventFlakyResolved:
+		return NotificationToneGood
+	}
+	return NotificationToneNeutral
+}
+
+// notificationFields picks the details shown below an event's text
+func notificationFields(event WebhookEvent) []NotificationField {
+	var fields []NotificationField
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, NotificationField{Name: name, Value: value})
+		}
+	}
+	value := func(key string) string {
+		s, _ := eventString(event.Data, key)
+		return s
+	}
+
+	switch event.Type {
+	case WebhookEventFlakyDetected, WebhookEventFlakyResolved:
+		add("Suite", value("suiteName"))
+		add("Severity", value("severity"))
+		if _, ok := event.Data["flakeRate"]; ok {
+			add("Flake rate", fmt.Sprintf("%.1f%%", toFloat(event.Data["flakeRate"])))
+		}
+		add("Status", value("status"))
+		if actor, ok := event.Data["actor"].(map[string]interface{}); ok {
+			id, _ := eventString(actor, "id")
+			add("Changed by", id)
+		}
+	default:
+		add("Branch", value("branch"))
+		add("Environment", value("environment"))
+		add("Commit", shortCommit(value("commit")))
+		if _, ok := event.Data["totalTests"]; ok && event.Type != WebhookEventTestRunCreated {
+			add("Tests", fmt.Sprintf("%s passed, %s failed, %s skipped", value("passedTests"), value("failedTests"), value("skippedTests")))
+		}
+		if toFloat(event.Data["durationMs"]) > 0 {
+			add("Duration", formatNotificationDuration(time.Duration(toFloat(event.Data["durationMs"]))*time.Millisecond))
+		}
+		if tags, ok := eventStrings(event.Data, "tags"); ok {
+			add("Tags", strings.Join(tags, ", "))
+		}
+	}
+	return fields
+}
+
+// shortCommit abbreviates a commit SHA
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// formatNotificationDuration formats a duration to the second
+func formatNotificationDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// toFloat converts a numeric event value
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	}
+	return 0
+}
+
+// NotificationFormatter formats a message as the request body of a chat
+// service's incoming webhook
+type NotificationFormatter interface {
+	Format(message NotificationMessage) ([]byte, error)
+}
+
+// NotificationFormatterFor returns the formatter of a channel type
+func NotificationFormatterFor(channelType NotificationChannelType) (NotificationFormatter, error) {
+	switch channelType {
+	case NotificationChannelSlack:
+		return SlackFormatter{}, nil
+	case NotificationChannelTeams:
+		return TeamsFormatter{}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown channel type %q", ErrInvalidNotificationRule, channelType)
+}
+
+// SlackFormatter formats messages for Slack incoming webhooks as Block Kit
+// blocks in an attachment colored by the message's tone
+type SlackFormatter struct{}
+
+// slackColors maps message tones to attachment colors
+var slackColors = map[NotificationTone]string{
+	NotificationToneNeutral: "#6a737d",
+	NotificationToneGood:    "#2eb67d",
+	NotificationToneWarning: "#ecb22e",
+	NotificationToneDanger:  "#e01e5a",
+}
+
+// slackMaxFields is how many fields Slack shows in one section block
+const slackMaxFields = 10
+
+// Format implements NotificationFormatter
+func (SlackFormatter) Format(message NotificationMessage) ([]byte, error) {
+	heading := "*" + slackEscape(message.Title) + "*"
+	if message.URL != "" {
+		heading = fmt.Sprintf("*<%s|%s>*", message.URL, slackEscape(message.Title))
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": heading + "\n" + slackEscape(message.Text)},
+		},
+	}
+	if len(message.Fields) > 0 {
+		fields := make([]map[string]interface{}, 0, len(message.Fields))
+		for i, field := range message.Fields {
+			if i == slackMaxFields {
+				break
+			}
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", slackEscape(field.Name), slackEscape(field.Value)),
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []map[string]interface{}{
+			{"type": "mrkdwn", "text": "Fern Platform · " + slackEscape(message.ProjectID)},
+		},
+	})
+
+	color, ok := slackColors[message.Tone]
+	if !ok {
+		color = slackColors[NotificationToneNeutral]
+	}
+
+	return json.Marshal(map[string]interface{}{
+		// Shown in push notifications and clients that cannot render blocks
+		"text": slackEscape(message.Title),
+		"attachments": []map[string]interface{}{
+			{"color": color, "blocks": blocks},
+		},
+	})
+}
+
+// slackEscape escapes the characters Slack treats as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// TeamsFormatter formats messages for Microsoft Teams incoming webhooks as
+// Adaptive Cards
+type TeamsFormatter struct{}
+
+// teamsColors maps message tones to Adaptive Card text colors
+var teamsColors = map[NotificationTone]string{
+	NotificationToneNeutral: "Default",
+	NotificationToneGood:    "Good",
+	NotificationToneWarning: "Warning",
+	NotificationToneDanger:  "Attention",
+}
+
+// Format implements NotificationFormatter
+func (TeamsFormatter) Format(message NotificationMessage) ([]byte, error) {
+	color, ok := teamsColors[message.Tone]
+	if !ok {
+		color = teamsColors[NotificationToneNeutral]
+	}
+
+	body := []map[string]interface{}{
+		{
+			"type":   "TextBlock",
+			"text":   message.Title,
+			"size":   "Medium",
+			"weight": "Bolder",
+			"color":  color,
+			"wrap":   true,
+		},
+		{"type": "TextBlock", "text": message.Text, "wrap": true},
+	}
+	if len(message.Fields) > 0 {
+		facts := make([]map[string]interface{}, len(message.Fields))
+		for i, field := range message.Fields {
+			facts[i] = map[string]interface{}{"title": field.Name, "value": field.Value}
+		}
+		body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+	}
+	body = append(body, map[string]interface{}{
+		"type":     "TextBlock",
+		"text":     "Fern Platform · " + message.ProjectID,
+		"isSubtle": true,
+		"size":     "Small",
+		"wrap":     true,
+	})
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.URL != "" {
+		card["actions"] = []map[string]interface{}{
+			{"type": "Action.OpenUrl", "title": "View in Fern", "url": message.URL},
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	})
+}
@@ -0,0 +1,412 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// NotificationMaxAttempts is how often a notification is attempted before it fails
+	NotificationMaxAttempts = 5
+
+	// NotificationRetryBaseDelay is the delay before the first retry; it doubles with every further attempt
+	NotificationRetryBaseDelay = time.Minute
+
+	// NotificationRetryMaxDelay caps the delay between attempts
+	NotificationRetryMaxDelay = 30 * time.Minute
+
+	// NotificationDeliveryTimeout bounds a single delivery attempt
+	NotificationDeliveryTimeout = 10 * time.Second
+
+	// NotificationDeliveryBatchSize is how many due notifications one DeliverDue call attempts
+	NotificationDeliveryBatchSize = 100
+
+	// NotificationDeliveryRetention is how long finished notifications are kept in the log
+	NotificationDeliveryRetention = 30 * 24 * time.Hour
+
+	// notificationResponseBodyLimit caps how much of a chat service's error response is kept
+	notificationResponseBodyLimit = 512
+
+	// notificationClaimLease is how long a claimed notification is hidden from other workers
+	notificationClaimLease = 2 * NotificationDeliveryTimeout
+)
+
+var (
+	// ErrNotificationRuleNotFound is returned when a rule does not exist
+	ErrNotificationRuleNotFound = errors.New("notification rule not found")
+
+	// ErrNotificationDeliveryFailed is returned when a chat service rejects a test notification
+	ErrNotificationDeliveryFailed = errors.New("notification delivery failed")
+)
+
+// NotificationDeliveryResult summarises a DeliverDue run
+type NotificationDeliveryResult struct {
+	Attempted int
+	Sent      int
+	Retrying  int
+	Failed    int
+	Errors    []error
+}
+
+// NotificationService manages notification rules and posts the events they
+// match to Slack and Microsoft Teams channels through a durable queue
+type NotificationService struct {
+	rules      NotificationRuleRepository
+	deliveries NotificationDeliveryRepository
+	keys       *KeyRing
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewNotificationService creates a new notification service. Incoming webhook
+// URLs are encrypted with the key ring, since they grant posting to a channel.
+func NewNotificationService(rules NotificationRuleRepository, deliveries NotificationDeliveryRepository, keys *KeyRing) *NotificationService {
+	return &NotificationService{
+		rules:      rules,
+		deliveries: deliveries,
+		keys:       keys,
+		httpClient: &http.Client{
+			Timeout: NotificationDeliveryTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// SetClock replaces the clock quiet hours and deduplication are evaluated with
+func (s *NotificationService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// CreateRule creates a rule posting to a channel's incoming webhook URL
+func (s *NotificationService) CreateRule(ctx context.Context, projectID string, settings NotificationRuleSettings, webhookURL, createdBy string) (*NotificationRule, error) {
+	encryptedURL, err := s.encryptWebhookURL(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := NewNotificationRule(projectID, settings, encryptedURL, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rules.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to save notification rule: %w", err)
+	}
+	return rule, nil
+}
+
+// GetRule retrieves a rule
+func (s *NotificationService) GetRule(ctx context.Context, ruleID string) (*NotificationRule, error) {
+	rule, err := s.rules.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotificationRuleNotFound, err)
+	}
+	return rule, nil
+}
+
+// ListRules retrieves the rules of a project
+func (s *NotificationService) ListRules(ctx context.Context, projectID string) ([]*NotificationRule, error) {
+	return s.rules.FindByProjectID(ctx, projectID)
+}
+
+// UpdateRule replaces the settings of a rule. An empty webhook URL keeps the current one.
+func (s *NotificationService) UpdateRule(ctx context.Context, ruleID string, settings NotificationRuleSettings, webhookURL string) (*NotificationRule, error) {
+	var encryptedURL string
+	if webhookURL != "" {
+		var err error
+		if encryptedURL, err = s.encryptWebhookURL(webhookURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.changeRule(ctx, ruleID, func(rule *NotificationRule) error {
+		return rule.Update(settings, encryptedURL)
+	})
+}
+
+// ActivateRule resumes posting a rule's notifications, including those queued while it was inactive
+func (s *NotificationService) ActivateRule(ctx context.Context, ruleID string) (*NotificationRule, error) {
+	return s.changeRule(ctx, ruleID, func(rule *NotificationRule) error {
+		rule.Activate()
+		return nil
+	})
+}
+
+// DeactivateRule stops posting a rule's notifications
+func (s *NotificationService) DeactivateRule(ctx context.Context, ruleID string) (*NotificationRule, error) {
+	return s.changeRule(ctx, ruleID, func(rule *NotificationRule) error {
+		rule.Deactivate()
+		return nil
+	})
+}
+
+// DeleteRule removes a rule
+func (s *NotificationService) DeleteRule(ctx context.Context, ruleID string) error {
+	if _, err := s.GetRule(ctx, ruleID); err != nil {
+		return err
+	}
+	return s.rules.Delete(ctx, ruleID)
+}
+
+// changeRule loads a rule, applies a change and saves it
+func (s *NotificationService) changeRule(ctx context.Context, ruleID string, change func(*NotificationRule) error) (*NotificationRule, error) {
+	rule, err := s.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(rule); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update notification rule: %w", err)
+	}
+	return rule, nil
+}
+
+// encryptWebhookURL validates and encrypts an incoming webhook URL
+func (s *NotificationService) encryptWebhookURL(webhookURL string) (string, error) {
+	if err := validateNotificationWebhookURL(webhookURL); err != nil {
+		return "", err
+	}
+	encrypted, err := s.keys.Encrypt(webhookURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt webhook URL: %w", err)
+	}
+	return encrypted, nil
+}
+
+// SendTestNotification posts a sample message through a rule right away, so
+// its channel can be checked without waiting for an event
+func (s *NotificationService) SendTestNotification(ctx context.Context, ruleID string) error {
+	rule, err := s.GetRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := s.format(rule, NotificationMessage{
+		Title:     "Test notification from Fern Platform",
+		Text:      fmt.Sprintf("Notification rule %q is set up to post to this channel.", rule.Settings().Name),
+		Tone:      NotificationToneNeutral,
+		ProjectID: rule.ProjectID(),
+	})
+	if err != nil {
+		return err
+	}
+	webhookURL, err := s.keys.Decrypt(rule.EncryptedWebhookURL())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt webhook URL: %w", err)
+	}
+	if _, err := s.send(ctx, webhookURL, payload); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotificationDeliveryFailed, err)
+	}
+	return nil
+}
+
+// Notify queues a notification for every active rule of the event's project
+// that matches it. Notifications raised during a rule's quiet hours are held
+// until they end; repeats within the rule's deduplication window are logged
+// as suppressed. It returns how many notifications were queued.
+func (s *NotificationService) Notify(ctx context.Context, event WebhookEvent) (int, error) {
+	rules, err := s.rules.FindActiveForEvent(ctx, event.ProjectID, event.Type)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find notification rules: %w", err)
+	}
+
+	now := s.now()
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+
+	queued := 0
+	var errs []error
+	for _, rule := range rules {
+		if !rule.Matches(event) {
+			continue
+		}
+		sent, err := s.queue(ctx, rule, event, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID(), err))
+			continue
+		}
+		if sent {
+			queued++
+		}
+	}
+	return queued, errors.Join(errs...)
+}
+
+// queue queues, or logs as suppressed, the notification of one rule about an
+// event and reports whether it was queued
+func (s *NotificationService) queue(ctx context.Context, rule *NotificationRule, event WebhookEvent, now time.Time) (bool, error) {
+	settings := rule.Settings()
+	message, err := RenderNotificationMessage(event, settings.TitleTemplate, settings.TextTemplate)
+	if err != nil {
+		return false, err
+	}
+	payload, err := s.format(rule, message)
+	if err != nil {
+		return false, err
+	}
+
+	delivery := &NotificationDelivery{
+		RuleID:        rule.ID(),
+		ProjectID:     event.ProjectID,
+		EventType:     event.Type,
+		DedupKey:      notificationDedupKey(event),
+		Title:         message.Title,
+		Payload:       string(payload),
+		Status:        NotificationPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	if settings.DedupWindow > 0 {
+		previous, err := s.deliveries.FindLatestByDedupKey(ctx, rule.ID(), delivery.DedupKey, now.Add(-settings.DedupWindow))
+		if err != nil {
+			return false, fmt.Errorf("failed to find earlier notifications: %w", err)
+		}
+		if previous != nil {
+			delivery.Status = NotificationSuppressed
+			delivery.SuppressedBy = previous.ID
+		}
+	}
+	if delivery.Status == NotificationPending && settings.QuietHours != nil {
+		if end, quiet := settings.QuietHours.EndAfter(now); quiet {
+			delivery.NextAttemptAt = end
+			delivery.HeldUntil = &end
+		}
+	}
+
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		return false, fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return delivery.Status == NotificationPending, nil
+}
+
+// format formats a message for a rule's channel
+func (s *NotificationService) format(rule *NotificationRule, message NotificationMessage) ([]byte, error) {
+	formatter, err := NotificationFormatterFor(rule.ChannelType())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := formatter.Format(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format notification: %w", err)
+	}
+	return payload, nil
+}
+
+// ListDeliveries retrieves the notification log of a rule, newest first
+func (s *NotificationService) ListDeliveries(ctx context.Context, ruleID string, limit int) ([]*NotificationDelivery, error) {
+	return s.deliveries.FindByRuleID(ctx, ruleID, limit)
+}
+
+// DeliverDue posts the notifications whose next attempt is due. Failed
+// attempts are retried with exponential backoff. Failures on individual
+// notifications are collected in the result and do not stop the run.
+func (s *NotificationService) DeliverDue(ctx context.Context) (*NotificationDeliveryResult, error) {
+	due, err := s.deliveries.FindDue(ctx, s.now(), NotificationDeliveryBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due notifications: %w", err)
+	}
+
+	result := &NotificationDeliveryResult{}
+	for _, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Other instances deliver from the same queue
+		claimed, err := s.deliveries.Claim(ctx, delivery, s.now().Add(notificationClaimLease))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("notification %s: failed to claim: %w", delivery.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.deliver(ctx, delivery, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("notification %s: %w", delivery.ID, err))
+		}
+	}
+	return result, nil
+}
+
+// deliver makes one attempt at posting a notification and records its outcome
+func (s *NotificationService) deliver(ctx context.Context, delivery *NotificationDelivery, result *NotificationDeliveryResult) error {
+	rule, err := s.rules.FindByID(ctx, delivery.RuleID)
+	if err != nil {
+		return fmt.Errorf("failed to load notification rule: %w", err)
+	}
+	webhookURL, err := s.keys.Decrypt(rule.EncryptedWebhookURL())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt webhook URL: %w", err)
+	}
+
+	result.Attempted++
+	attemptedAt := s.now()
+	statusCode, attemptErr := s.send(ctx, webhookURL, []byte(delivery.Payload))
+	delivery.recordAttempt(attemptedAt, statusCode, attemptErr, NotificationMaxAttempts, NotificationRetryDelay)
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to save notification attempt: %w", err)
+	}
+
+	switch delivery.Status {
+	case NotificationPending:
+		result.Retrying++
+	case NotificationSent:
+		result.Sent++
+	case NotificationFailed:
+		result.Failed++
+	}
+	return nil
+}
+
+// send posts a formatted message to an incoming webhook. Any response other
+// than 2xx is an error.
+func (s *NotificationService) send(ctx context.Context, webhookURL string, payload []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, NotificationDeliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fern-Platform-Notifications/1.0")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		// The URL is a credential, so it is kept out of the error
+		var urlErr interface{ Unwrap() error }
+		if errors.As(err, &urlErr) {
+			err = urlErr.Unwrap()
+		}
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, notificationResponseBodyLimit))
+		return resp.StatusCode, fmt.Errorf("channel returned status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return resp.StatusCode, nil
+}
+
+// PruneDeliveries removes finished notifications older than the retention period
+func (s *NotificationService) PruneDeliveries(ctx context.Context) (int64, error) {
+	return s.deliveries.DeleteFinishedBefore(ctx, s.now().Add(-NotificationDeliveryRetention))
+}
+
+// NotificationRetryDelay returns how long to wait after a failed attempt before the next one
+func NotificationRetryDelay(attempt int) time.Duration {
+	return retryDelay(attempt, NotificationRetryBaseDelay, NotificationRetryMaxDelay)
+}
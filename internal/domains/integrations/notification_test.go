@@ -0,0 +1,786 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryNotificationStore keeps notification rules and deliveries in memory
+type memoryNotificationStore struct {
+	mu         sync.Mutex
+	nextID     int
+	rules      map[string]*integrations.NotificationRule
+	deliveries map[string]*integrations.NotificationDelivery
+}
+
+func newMemoryNotificationStore() *memoryNotificationStore {
+	return &memoryNotificationStore{
+		rules:      make(map[string]*integrations.NotificationRule),
+		deliveries: make(map[string]*integrations.NotificationDelivery),
+	}
+}
+
+func (s *memoryNotificationStore) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// copyRule returns a detached copy, as a database round trip would
+func copyRule(rule *integrations.NotificationRule) *integrations.NotificationRule {
+	return integrations.ReconstructNotificationRule(rule.Snapshot(), rule.EncryptedWebhookURL())
+}
+
+// sortedDeliveries returns copies of the deliveries accepted by keep, oldest first
+func (s *memoryNotificationStore) sortedDeliveries(keep func(*integrations.NotificationDelivery) bool) []*integrations.NotificationDelivery {
+	var deliveries []*integrations.NotificationDelivery
+	for _, delivery := range s.deliveries {
+		if keep(delivery) {
+			found := *delivery
+			deliveries = append(deliveries, &found)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, _ := strconv.Atoi(deliveries[i].ID)
+		b, _ := strconv.Atoi(deliveries[j].ID)
+		return a < b
+	})
+	return deliveries
+}
+
+// memoryNotificationRuleRepo is the NotificationRuleRepository view of the store
+type memoryNotificationRuleRepo struct {
+	*memoryNotificationStore
+}
+
+func (r memoryNotificationRuleRepo) Create(ctx context.Context, rule *integrations.NotificationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule.AssignID(r.newID())
+	r.rules[rule.ID()] = copyRule(rule)
+	return nil
+}
+
+func (r memoryNotificationRuleRepo) Update(ctx context.Context, rule *integrations.NotificationRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.ID()] = copyRule(rule)
+	return nil
+}
+
+func (r memoryNotificationRuleRepo) Delete(ctx context.Context, ruleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rules, ruleID)
+	return nil
+}
+
+func (r memoryNotificationRuleRepo) FindByID(ctx context.Context, ruleID string) (*integrations.NotificationRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rule, ok := r.rules[ruleID]
+	if !ok {
+		return nil, errors.New("notification rule not found")
+	}
+	return copyRule(rule), nil
+}
+
+func (r memoryNotificationRuleRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.NotificationRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rules []*integrations.NotificationRule
+	for _, rule := range r.rules {
+		if rule.ProjectID() == projectID {
+			rules = append(rules, copyRule(rule))
+		}
+	}
+	return rules, nil
+}
+
+func (r memoryNotificationRuleRepo) FindActiveForEvent(ctx context.Context, projectID string, eventType integrations.WebhookEventType) ([]*integrations.NotificationRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rules []*integrations.NotificationRule
+	for _, rule := range r.rules {
+		if !rule.IsActive() || rule.ProjectID() != projectID {
+			continue
+		}
+		for _, event := range rule.Settings().Events {
+			if event == eventType {
+				rules = append(rules, copyRule(rule))
+				break
+			}
+		}
+	}
+	return rules, nil
+}
+
+// memoryNotificationDeliveryRepo is the NotificationDeliveryRepository view of the store
+type memoryNotificationDeliveryRepo struct {
+	*memoryNotificationStore
+}
+
+func (r memoryNotificationDeliveryRepo) Create(ctx context.Context, delivery *integrations.NotificationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery.ID = r.newID()
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (r memoryNotificationDeliveryRepo) Update(ctx context.Context, delivery *integrations.NotificationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (r memoryNotificationDeliveryRepo) FindByRuleID(ctx context.Context, ruleID string, limit int) ([]*integrations.NotificationDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := r.sortedDeliveries(func(d *integrations.NotificationDelivery) bool { return d.RuleID == ruleID })
+	// Newest first
+	for i, j := 0, len(deliveries)-1; i < j; i, j = i+1, j-1 {
+		deliveries[i], deliveries[j] = deliveries[j], deliveries[i]
+	}
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r memoryNotificationDeliveryRepo) FindLatestByDedupKey(ctx context.Context, ruleID, dedupKey string, since time.Time) (*integrations.NotificationDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := r.sortedDeliveries(func(d *integrations.NotificationDelivery) bool {
+		return d.RuleID == ruleID && d.DedupKey == dedupKey && !d.CreatedAt.Before(since) &&
+			d.Status != integrations.NotificationSuppressed
+	})
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	return deliveries[len(deliveries)-1], nil
+}
+
+func (r memoryNotificationDeliveryRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.NotificationDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := r.sortedDeliveries(func(d *integrations.NotificationDelivery) bool {
+		rule, ok := r.rules[d.RuleID]
+		return ok && rule.IsActive() && d.Status == integrations.NotificationPending && !d.NextAttemptAt.After(now)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r memoryNotificationDeliveryRepo) Claim(ctx context.Context, delivery *integrations.NotificationDelivery, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.deliveries[delivery.ID]
+	if !ok || stored.Status != integrations.NotificationPending || stored.NextAttemptAt.After(delivery.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (r memoryNotificationDeliveryRepo) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var removed int64
+	for id, delivery := range r.deliveries {
+		if delivery.Status != integrations.NotificationPending && delivery.CreatedAt.Before(before) {
+			delete(r.deliveries, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// makeDue moves the next attempt of every pending notification to before now
+func (s *memoryNotificationStore) makeDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, delivery := range s.deliveries {
+		if delivery.Status == integrations.NotificationPending {
+			delivery.NextAttemptAt = now.Add(-time.Second)
+		}
+	}
+}
+
+// testClock is a settable clock for the notification service
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func newTestNotificationService(now time.Time) (*integrations.NotificationService, *memoryNotificationStore, *testClock) {
+	store := newMemoryNotificationStore()
+	clock := &testClock{now: now}
+	service := integrations.NewNotificationService(memoryNotificationRuleRepo{store}, memoryNotificationDeliveryRepo{store}, testKeyRing)
+	service.SetClock(clock.Now)
+	return service, store, clock
+}
+
+func failedMainRun() integrations.WebhookEvent {
+	return integrations.WebhookEvent{
+		Type:      integrations.WebhookEventTestRunFailed,
+		ProjectID: "project-1",
+		Data: map[string]interface{}{
+			"runId":        "build-42",
+			"status":       "failed",
+			"branch":       "main",
+			"environment":  "ci",
+			"commit":       "0123456789abcdef",
+			"totalTests":   120,
+			"passedTests":  117,
+			"failedTests":  3,
+			"skippedTests": 0,
+			"durationMs":   int64(93000),
+			"tags":         []string{"smoke"},
+			"url":          "https://fern.example.com/test-runs/build-42",
+		},
+	}
+}
+
+func slackSettings(events ...integrations.WebhookEventType) integrations.NotificationRuleSettings {
+	return integrations.NotificationRuleSettings{
+		Name:        "Broken builds",
+		ChannelType: integrations.NotificationChannelSlack,
+		Events:      events,
+	}
+}
+
+func TestSlackFormatter_Format(t *testing.T) {
+	message, err := integrations.RenderNotificationMessage(failedMainRun(), "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Test run build-42 failed on main", message.Title)
+	assert.Equal(t, "3 of 120 tests failed.", message.Text)
+
+	body, err := integrations.SlackFormatter{}.Format(message)
+	require.NoError(t, err)
+
+	var payload struct {
+		Text        string `json:"text"`
+		Attachments []struct {
+			Color  string `json:"color"`
+			Blocks []struct {
+				Type string `json:"type"`
+				Text struct {
+					Text string `json:"text"`
+				} `json:"text"`
+				Fields []struct {
+					Text string `json:"text"`
+				} `json:"fields"`
+			} `json:"blocks"`
+		} `json:"attachments"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "Test run build-42 failed on main", payload.Text)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "#e01e5a", payload.Attachments[0].Color)
+
+	blocks := payload.Attachments[0].Blocks
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "*<https://fern.example.com/test-runs/build-42|Test run build-42 failed on main>*\n3 of 120 tests failed.", blocks[0].Text.Text)
+	assert.Equal(t, "section", blocks[1].Type)
+	assert.Contains(t, blocks[1].Fields, struct {
+		Text string `json:"text"`
+	}{Text: "*Commit*\n0123456"})
+	assert.Equal(t, "context", blocks[2].Type)
+}
+
+func TestSlackFormatter_EscapesMarkup(t *testing.T) {
+	body, err := integrations.SlackFormatter{}.Format(integrations.NotificationMessage{
+		Title: "a <b> & c",
+		Text:  "<!channel>",
+	})
+	require.NoError(t, err)
+
+	var payload struct {
+		Text        string `json:"text"`
+		Attachments []struct {
+			Color  string `json:"color"`
+			Blocks []struct {
+				Text struct {
+					Text string `json:"text"`
+				} `json:"text"`
+			} `json:"blocks"`
+		} `json:"attachments"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "a &lt;b&gt; &amp; c", payload.Text)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "#6a737d", payload.Attachments[0].Color)
+	assert.Equal(t, "*a &lt;b&gt; &amp; c*\n&lt;!channel&gt;", payload.Attachments[0].Blocks[0].Text.Text)
+}
+
+func TestTeamsFormatter_Format(t *testing.T) {
+	message, err := integrations.RenderNotificationMessage(failedMainRun(), "", "")
+	require.NoError(t, err)
+
+	body, err := integrations.TeamsFormatter{}.Format(message)
+	require.NoError(t, err)
+
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type    string `json:"type"`
+				Version string `json:"version"`
+				Body    []struct {
+					Type  string `json:"type"`
+					Text  string `json:"text"`
+					Color string `json:"color"`
+					Facts []struct {
+						Title string `json:"title"`
+						Value string `json:"value"`
+					} `json:"facts"`
+				} `json:"body"`
+				Actions []struct {
+					Type string `json:"type"`
+					URL  string `json:"url"`
+				} `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "message", payload.Type)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", payload.Attachments[0].ContentType)
+
+	card := payload.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	assert.Equal(t, "1.4", card.Version)
+	require.Len(t, card.Body, 4)
+	assert.Equal(t, "Test run build-42 failed on main", card.Body[0].Text)
+	assert.Equal(t, "Attention", card.Body[0].Color)
+	assert.Equal(t, "FactSet", card.Body[2].Type)
+	assert.Equal(t, "Branch", card.Body[2].Facts[0].Title)
+	assert.Equal(t, "main", card.Body[2].Facts[0].Value)
+	require.Len(t, card.Actions, 1)
+	assert.Equal(t, "Action.OpenUrl", card.Actions[0].Type)
+	assert.Equal(t, "https://fern.example.com/test-runs/build-42", card.Actions[0].URL)
+}
+
+func TestRenderNotificationMessage_CustomTemplates(t *testing.T) {
+	message, err := integrations.RenderNotificationMessage(failedMainRun(),
+		`{{upper .branch}} is red`,
+		`{{.failedTests}} failures at {{shortCommit .commit}} in {{duration .durationMs}} ({{join .tags ", "}}){{.missing}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "MAIN is red", message.Title)
+	assert.Equal(t, "3 failures at 0123456 in 1m33s (smoke)", message.Text)
+}
+
+func TestNotificationRule_Matches(t *testing.T) {
+	flakyEvent := integrations.WebhookEvent{
+		Type:      integrations.WebhookEventFlakyDetected,
+		ProjectID: "project-1",
+		Data:      map[string]interface{}{"testName": "TestLogin", "status": "active", "severity": "critical"},
+	}
+
+	tests := []struct {
+		name   string
+		events []integrations.WebhookEventType
+		filter integrations.NotificationFilter
+		event  integrations.WebhookEvent
+		want   bool
+	}{
+		{name: "no filter", filter: integrations.NotificationFilter{}, event: failedMainRun(), want: true},
+		{name: "branch", filter: integrations.NotificationFilter{Branches: []string{"main"}}, event: failedMainRun(), want: true},
+		{name: "other branch", filter: integrations.NotificationFilter{Branches: []string{"develop"}}, event: failedMainRun(), want: false},
+		{name: "branch pattern", filter: integrations.NotificationFilter{Branches: []string{"release/*", "ma*"}}, event: failedMainRun(), want: true},
+		{name: "environment ignores case", filter: integrations.NotificationFilter{Environments: []string{"CI"}}, event: failedMainRun(), want: true},
+		{name: "other environment", filter: integrations.NotificationFilter{Environments: []string{"staging"}}, event: failedMainRun(), want: false},
+		{name: "status", filter: integrations.NotificationFilter{Statuses: []string{"failed"}}, event: failedMainRun(), want: true},
+		{name: "other status", filter: integrations.NotificationFilter{Statuses: []string{"passed"}}, event: failedMainRun(), want: false},
+		{name: "any tag", filter: integrations.NotificationFilter{Tags: []string{"nightly", "smoke"}}, event: failedMainRun(), want: true},
+		{name: "no tag", filter: integrations.NotificationFilter{Tags: []string{"nightly"}}, event: failedMainRun(), want: false},
+		{name: "all filters", filter: integrations.NotificationFilter{Branches: []string{"main"}, Environments: []string{"ci"}, Statuses: []string{"failed"}, Tags: []string{"smoke"}}, event: failedMainRun(), want: true},
+		{
+			name:   "severity",
+			events: []integrations.WebhookEventType{integrations.WebhookEventFlakyDetected},
+			filter: integrations.NotificationFilter{Severities: []string{"critical", "high"}},
+			event:  flakyEvent,
+			want:   true,
+		},
+		{
+			name:   "other severity",
+			events: []integrations.WebhookEventType{integrations.WebhookEventFlakyDetected},
+			filter: integrations.NotificationFilter{Severities: []string{"low"}},
+			event:  flakyEvent,
+			want:   false,
+		},
+		{
+			// Flaky tests have no branch, so the branch filter does not apply
+			name:   "filter on an attribute the event lacks",
+			events: []integrations.WebhookEventType{integrations.WebhookEventFlakyDetected},
+			filter: integrations.NotificationFilter{Branches: []string{"main"}, Severities: []string{"critical"}},
+			event:  flakyEvent,
+			want:   true,
+		},
+		{
+			name:   "event not posted by the rule",
+			events: []integrations.WebhookEventType{integrations.WebhookEventFlakyDetected},
+			event:  failedMainRun(),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := tt.events
+			if events == nil {
+				events = []integrations.WebhookEventType{integrations.WebhookEventTestRunFailed}
+			}
+			settings := slackSettings(events...)
+			settings.Filter = tt.filter
+			rule, err := integrations.NewNotificationRule("project-1", settings, "encrypted", "user-1")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, rule.Matches(tt.event))
+		})
+	}
+
+	t.Run("other project", func(t *testing.T) {
+		rule, err := integrations.NewNotificationRule("project-2", slackSettings(integrations.WebhookEventTestRunFailed), "encrypted", "user-1")
+		require.NoError(t, err)
+		assert.False(t, rule.Matches(failedMainRun()))
+	})
+
+	t.Run("inactive rule", func(t *testing.T) {
+		rule, err := integrations.NewNotificationRule("project-1", slackSettings(integrations.WebhookEventTestRunFailed), "encrypted", "user-1")
+		require.NoError(t, err)
+		rule.Deactivate()
+		assert.False(t, rule.Matches(failedMainRun()))
+	})
+}
+
+func TestQuietHours_EndAfter(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	overnight := integrations.QuietHours{Start: "22:00", End: "07:30", TimeZone: "Europe/Berlin"}
+	lunch := integrations.QuietHours{Start: "12:00", End: "13:00"}
+
+	tests := []struct {
+		name       string
+		quietHours integrations.QuietHours
+		at         time.Time
+		wantEnd    time.Time
+		wantQuiet  bool
+	}{
+		{name: "before midnight", quietHours: overnight, at: time.Date(2026, 10, 18, 23, 15, 0, 0, berlin), wantEnd: time.Date(2026, 10, 19, 7, 30, 0, 0, berlin), wantQuiet: true},
+		{name: "after midnight", quietHours: overnight, at: time.Date(2026, 10, 19, 6, 0, 0, 0, berlin), wantEnd: time.Date(2026, 10, 19, 7, 30, 0, 0, berlin), wantQuiet: true},
+		{name: "at the end", quietHours: overnight, at: time.Date(2026, 10, 19, 7, 30, 0, 0, berlin), wantQuiet: false},
+		{name: "during the day", quietHours: overnight, at: time.Date(2026, 10, 19, 12, 0, 0, 0, berlin), wantQuiet: false},
+		{name: "converted to the time zone", quietHours: overnight, at: time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC), wantEnd: time.Date(2026, 10, 19, 7, 30, 0, 0, berlin), wantQuiet: true},
+		{name: "same day period in UTC", quietHours: lunch, at: time.Date(2026, 10, 18, 12, 59, 0, 0, time.UTC), wantEnd: time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC), wantQuiet: true},
+		{name: "before a same day period", quietHours: lunch, at: time.Date(2026, 10, 18, 11, 59, 0, 0, time.UTC), wantQuiet: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, quiet := tt.quietHours.EndAfter(tt.at)
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if tt.wantQuiet {
+				assert.True(t, tt.wantEnd.Equal(end), "expected %s, got %s", tt.wantEnd, end)
+			}
+		})
+	}
+}
+
+func TestNotificationService_RejectsInvalidRules(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestNotificationService(time.Now())
+
+	tests := []struct {
+		name       string
+		modify     func(*integrations.NotificationRuleSettings)
+		webhookURL string
+	}{
+		{name: "unknown channel", modify: func(s *integrations.NotificationRuleSettings) { s.ChannelType = "discord" }},
+		{name: "no events", modify: func(s *integrations.NotificationRuleSettings) { s.Events = nil }},
+		{name: "unknown event", modify: func(s *integrations.NotificationRuleSettings) {
+			s.Events = []integrations.WebhookEventType{"build.broken"}
+		}},
+		{name: "invalid branch pattern", modify: func(s *integrations.NotificationRuleSettings) { s.Filter.Branches = []string{"release/["} }},
+		{name: "invalid quiet hours", modify: func(s *integrations.NotificationRuleSettings) {
+			s.QuietHours = &integrations.QuietHours{Start: "22", End: "07:00"}
+		}},
+		{name: "unknown time zone", modify: func(s *integrations.NotificationRuleSettings) {
+			s.QuietHours = &integrations.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Mars/Olympus"}
+		}},
+		{name: "invalid template", modify: func(s *integrations.NotificationRuleSettings) { s.TextTemplate = "{{.runId" }},
+		{name: "relative webhook URL", webhookURL: "/services/T000/B000/XXXX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := slackSettings(integrations.WebhookEventTestRunFailed)
+			if tt.modify != nil {
+				tt.modify(&settings)
+			}
+			webhookURL := tt.webhookURL
+			if webhookURL == "" {
+				webhookURL = "https://hooks.slack.com/services/T000/B000/XXXX"
+			}
+
+			_, err := service.CreateRule(ctx, "project-1", settings, webhookURL, "user-1")
+			assert.ErrorIs(t, err, integrations.ErrInvalidNotificationRule)
+		})
+	}
+}
+
+func TestNotificationService_PostsMatchingEvents(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestNotificationService(time.Now())
+	slack := newWebhookEndpoint(t, http.StatusOK)
+	teams := newWebhookEndpoint(t, http.StatusAccepted)
+
+	mainOnly := slackSettings(integrations.WebhookEventTestRunFailed)
+	mainOnly.Filter.Branches = []string{"main"}
+	slackRule, err := service.CreateRule(ctx, "project-1", mainOnly, slack.URL, "user-1")
+	require.NoError(t, err)
+	assert.NotContains(t, slackRule.EncryptedWebhookURL(), slack.URL)
+
+	teamsSettings := slackSettings(integrations.WebhookEventTestRunFailed)
+	teamsSettings.ChannelType = integrations.NotificationChannelTeams
+	teamsSettings.Filter.Branches = []string{"release/*"}
+	_, err = service.CreateRule(ctx, "project-1", teamsSettings, teams.URL, "user-1")
+	require.NoError(t, err)
+
+	queued, err := service.Notify(ctx, failedMainRun())
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempted)
+	assert.Equal(t, 1, result.Sent)
+	assert.Empty(t, result.Errors)
+
+	requests := slack.received()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "application/json", requests[0].header.Get("Content-Type"))
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(requests[0].body, &payload))
+	assert.Equal(t, "Test run build-42 failed on main", payload["text"])
+	assert.Empty(t, teams.received())
+
+	deliveries, err := service.ListDeliveries(ctx, slackRule.ID(), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, integrations.NotificationSent, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].ResponseStatus)
+}
+
+func TestNotificationService_SuppressesRepeats(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	service, _, clock := newTestNotificationService(now)
+	slack := newWebhookEndpoint(t, http.StatusOK)
+
+	settings := slackSettings(integrations.WebhookEventTestRunFailed)
+	settings.DedupWindow = time.Hour
+	rule, err := service.CreateRule(ctx, "project-1", settings, slack.URL, "user-1")
+	require.NoError(t, err)
+
+	// One broken build failing again and again posts once
+	for i := 0; i < 50; i++ {
+		clock.Set(now.Add(time.Duration(i) * time.Minute))
+		_, err := service.Notify(ctx, failedMainRun())
+		require.NoError(t, err)
+	}
+
+	// A failure on another branch is not a repeat
+	otherBranch := failedMainRun()
+	otherBranch.Data["branch"] = "develop"
+	queued, err := service.Notify(ctx, otherBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Sent)
+	assert.Len(t, slack.received(), 2)
+
+	deliveries, err := service.ListDeliveries(ctx, rule.ID(), 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 51)
+	first := deliveries[len(deliveries)-1]
+	suppressed := 0
+	for _, delivery := range deliveries {
+		if delivery.Status == integrations.NotificationSuppressed {
+			suppressed++
+			assert.Equal(t, first.ID, delivery.SuppressedBy)
+		}
+	}
+	assert.Equal(t, 49, suppressed)
+
+	// Once the window has passed the failure is posted again
+	clock.Set(now.Add(2 * time.Hour))
+	queued, err = service.Notify(ctx, failedMainRun())
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+}
+
+func TestNotificationService_HoldsNotificationsDuringQuietHours(t *testing.T) {
+	ctx := context.Background()
+	night := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	service, _, clock := newTestNotificationService(night)
+	slack := newWebhookEndpoint(t, http.StatusOK)
+
+	settings := slackSettings(integrations.WebhookEventTestRunFailed)
+	settings.QuietHours = &integrations.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"}
+	rule, err := service.CreateRule(ctx, "project-1", settings, slack.URL, "user-1")
+	require.NoError(t, err)
+
+	queued, err := service.Notify(ctx, failedMainRun())
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	deliveries, err := service.ListDeliveries(ctx, rule.ID(), 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	morning := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	require.NotNil(t, deliveries[0].HeldUntil)
+	assert.True(t, morning.Equal(*deliveries[0].HeldUntil))
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	clock.Set(morning)
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sent)
+	assert.Len(t, slack.received(), 1)
+}
+
+func TestNotificationService_RetriesFailedPosts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	service, store, clock := newTestNotificationService(now)
+	teams := newWebhookEndpoint(t, http.StatusInternalServerError)
+
+	settings := slackSettings(integrations.WebhookEventTestRunFailed)
+	settings.ChannelType = integrations.NotificationChannelTeams
+	rule, err := service.CreateRule(ctx, "project-1", settings, teams.URL, "user-1")
+	require.NoError(t, err)
+
+	_, err = service.Notify(ctx, failedMainRun())
+	require.NoError(t, err)
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+
+	deliveries, err := service.ListDeliveries(ctx, rule.ID(), 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, integrations.NotificationPending, deliveries[0].Status)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseStatus)
+	assert.Contains(t, deliveries[0].LastError, "status 500")
+	assert.True(t, deliveries[0].NextAttemptAt.Equal(now.Add(integrations.NotificationRetryDelay(1))))
+
+	// Not due until the backoff has passed
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	teams.setStatus(http.StatusOK)
+	store.makeDue(now)
+	clock.Set(now)
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sent)
+
+	// A notification that never gets through fails after the last attempt
+	teams.setStatus(http.StatusBadGateway)
+	_, err = service.Notify(ctx, failedMainRun())
+	require.NoError(t, err)
+	for attempt := 0; attempt < integrations.NotificationMaxAttempts; attempt++ {
+		store.makeDue(now)
+		_, err := service.DeliverDue(ctx)
+		require.NoError(t, err)
+	}
+	deliveries, err = service.ListDeliveries(ctx, rule.ID(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.NotificationFailed, deliveries[0].Status)
+	assert.Equal(t, integrations.NotificationMaxAttempts, deliveries[0].Attempts)
+}
+
+func TestNotificationService_SendTestNotification(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestNotificationService(time.Now())
+	slack := newWebhookEndpoint(t, http.StatusOK)
+
+	rule, err := service.CreateRule(ctx, "project-1", slackSettings(integrations.WebhookEventTestRunFailed), slack.URL, "user-1")
+	require.NoError(t, err)
+
+	require.NoError(t, service.SendTestNotification(ctx, rule.ID()))
+	requests := slack.received()
+	require.Len(t, requests, 1)
+	assert.Contains(t, string(requests[0].body), "Test notification from Fern Platform")
+
+	slack.setStatus(http.StatusNotFound)
+	err = service.SendTestNotification(ctx, rule.ID())
+	assert.ErrorIs(t, err, integrations.ErrNotificationDeliveryFailed)
+
+	err = service.SendTestNotification(ctx, "missing")
+	assert.ErrorIs(t, err, integrations.ErrNotificationRuleNotFound)
+}
+
+func TestNotificationService_UpdateRuleKeepsWebhookURL(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestNotificationService(time.Now())
+	slack := newWebhookEndpoint(t, http.StatusOK)
+
+	rule, err := service.CreateRule(ctx, "project-1", slackSettings(integrations.WebhookEventTestRunFailed), slack.URL, "user-1")
+	require.NoError(t, err)
+
+	settings := slackSettings(integrations.WebhookEventTestRunFailed, integrations.WebhookEventFlakyDetected)
+	settings.Name = "Renamed"
+	updated, err := service.UpdateRule(ctx, rule.ID(), settings, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Settings().Name)
+	assert.Equal(t, rule.EncryptedWebhookURL(), updated.EncryptedWebhookURL())
+
+	require.NoError(t, service.SendTestNotification(ctx, rule.ID()))
+	assert.Len(t, slack.received(), 1)
+}
+
+func TestNotificationRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, integrations.NotificationRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, integrations.NotificationRetryDelay(2))
+	assert.Equal(t, 16*time.Minute, integrations.NotificationRetryDelay(5))
+	assert.Equal(t, integrations.NotificationRetryMaxDelay, integrations.NotificationRetryDelay(10))
+}
@@ -2,6 +2,7 @@ package integrations
 
 import (
 	"context"
+	"time"
 )
 
 // JiraConnectionRepository defines the interface for JIRA connection persistence
@@ -23,4 +24,242 @@ type JiraConnectionRepository interface {
 	
 	// FindActiveByProjectID retrieves all active connections for a project
 	FindActiveByProjectID(ctx context.Context, projectID string) ([]*JiraConnection, error)
-}
\ No newline at end of file
+	
+	// FindAllActive retrieves the active connections of every project
+	FindAllActive(ctx context.Context) ([]*JiraConnection, error)
+}
+
+// JiraIssueLinkRepository defines the interface for persisting filed JIRA issues
+type JiraIssueLinkRepository interface {
+	// Create saves issue links
+	Create(ctx context.Context, links []*JiraIssueLink) error
+
+	// FindByTargets retrieves the links of any of the given targets in a project
+	FindByTargets(ctx context.Context, projectID string, targets []IssueTarget) ([]*JiraIssueLink, error)
+
+	// FindByProjectID retrieves all issue links for a project, newest first
+	FindByProjectID(ctx context.Context, projectID string) ([]*JiraIssueLink, error)
+
+	// FindByConnectionID retrieves all issue links filed through a connection
+	FindByConnectionID(ctx context.Context, connectionID string) ([]*JiraIssueLink, error)
+
+	// UpdateSync saves the sync state of an issue link
+	UpdateSync(ctx context.Context, link *JiraIssueLink) error
+}
+
+// JiraFieldMappingRepository defines the interface for persisting JIRA field mappings
+type JiraFieldMappingRepository interface {
+	// Save creates or replaces the mapping of a connection
+	Save(ctx context.Context, mapping *JiraFieldMapping) error
+
+	// FindByConnectionID retrieves the mapping of a connection, or nil when none was saved
+	FindByConnectionID(ctx context.Context, connectionID string) (*JiraFieldMapping, error)
+}
+
+// TrackerConnectionRepository defines the interface for issue tracker connection persistence
+type TrackerConnectionRepository interface {
+	// Create saves a new connection and assigns its ID
+	Create(ctx context.Context, connection *TrackerConnection) error
+
+	// Update updates an existing connection
+	Update(ctx context.Context, connection *TrackerConnection) error
+
+	// Delete removes a connection
+	Delete(ctx context.Context, connectionID string) error
+
+	// FindByID retrieves a connection by ID
+	FindByID(ctx context.Context, connectionID string) (*TrackerConnection, error)
+
+	// FindByProjectID retrieves all connections for a project, newest first
+	FindByProjectID(ctx context.Context, projectID string) ([]*TrackerConnection, error)
+}
+
+// EncryptedCredentialRepository gives key rotation access to every stored encrypted credential
+type EncryptedCredentialRepository interface {
+	// FindAll retrieves every non-empty encrypted credential, including those of deleted connections
+	FindAll(ctx context.Context) ([]EncryptedCredential, error)
+
+	// Replace swaps the ciphertext of a credential unless it changed since it
+	// was retrieved, and reports whether it was replaced
+	Replace(ctx context.Context, credential EncryptedCredential, ciphertext string) (bool, error)
+}
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription persistence
+type WebhookSubscriptionRepository interface {
+	// Create saves a new subscription and assigns its ID
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+
+	// Update updates an existing subscription
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+
+	// UpdateDeliveryState saves only the delivery outcome of a subscription:
+	// whether it is active, why it was disabled, its failure streak and when
+	// it was last delivered to
+	UpdateDeliveryState(ctx context.Context, subscription *WebhookSubscription) error
+
+	// Delete removes a subscription
+	Delete(ctx context.Context, subscriptionID string) error
+
+	// FindByID retrieves a subscription by ID
+	FindByID(ctx context.Context, subscriptionID string) (*WebhookSubscription, error)
+
+	// FindByProjectID retrieves the subscriptions of a project, or the global
+	// subscriptions when projectID is empty, newest first
+	FindByProjectID(ctx context.Context, projectID string) ([]*WebhookSubscription, error)
+
+	// FindActiveForEvent retrieves the active subscriptions of a project and
+	// the active global subscriptions that subscribe to an event type
+	FindActiveForEvent(ctx context.Context, projectID string, eventType WebhookEventType) ([]*WebhookSubscription, error)
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery queue and log
+type WebhookDeliveryRepository interface {
+	// Create queues deliveries and assigns their IDs
+	Create(ctx context.Context, deliveries []*WebhookDelivery) error
+
+	// Update saves the outcome of a delivery attempt
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+
+	// FindByID retrieves a delivery by ID
+	FindByID(ctx context.Context, deliveryID string) (*WebhookDelivery, error)
+
+	// FindBySubscriptionID retrieves the deliveries of a subscription, newest first
+	FindBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*WebhookDelivery, error)
+
+	// FindDue retrieves pending deliveries of active subscriptions whose next
+	// attempt is due, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+
+	// Claim moves the next attempt of a due delivery to leaseUntil, unless
+	// another worker claimed it first, and reports whether it was claimed
+	Claim(ctx context.Context, delivery *WebhookDelivery, leaseUntil time.Time) (bool, error)
+
+	// DeleteFinishedBefore removes succeeded and failed deliveries created before a time
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NotificationRuleRepository defines the interface for notification rule persistence
+type NotificationRuleRepository interface {
+	// Create saves a new rule and assigns its ID
+	Create(ctx context.Context, rule *NotificationRule) error
+
+	// Update updates an existing rule
+	Update(ctx context.Context, rule *NotificationRule) error
+
+	// Delete removes a rule
+	Delete(ctx context.Context, ruleID string) error
+
+	// FindByID retrieves a rule by ID
+	FindByID(ctx context.Context, ruleID string) (*NotificationRule, error)
+
+	// FindByProjectID retrieves the rules of a project
+	FindByProjectID(ctx context.Context, projectID string) ([]*NotificationRule, error)
+
+	// FindActiveForEvent retrieves the active rules of a project that post an event type
+	FindActiveForEvent(ctx context.Context, projectID string, eventType WebhookEventType) ([]*NotificationRule, error)
+}
+
+// NotificationDeliveryRepository defines the interface for the notification queue and log
+type NotificationDeliveryRepository interface {
+	// Create queues or logs a notification and assigns its ID
+	Create(ctx context.Context, delivery *NotificationDelivery) error
+
+	// Update saves the outcome of a delivery attempt
+	Update(ctx context.Context, delivery *NotificationDelivery) error
+
+	// FindByRuleID retrieves the notifications of a rule, newest first
+	FindByRuleID(ctx context.Context, ruleID string, limit int) ([]*NotificationDelivery, error)
+
+	// FindLatestByDedupKey retrieves the newest notification of a rule with a
+	// deduplication key created since a time that was not itself suppressed,
+	// or nil when there is none
+	FindLatestByDedupKey(ctx context.Context, ruleID, dedupKey string, since time.Time) (*NotificationDelivery, error)
+
+	// FindDue retrieves pending notifications of active rules whose next
+	// attempt is due, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*NotificationDelivery, error)
+
+	// Claim moves the next attempt of a due notification to leaseUntil, unless
+	// another worker claimed it first, and reports whether it was claimed
+	Claim(ctx context.Context, delivery *NotificationDelivery, leaseUntil time.Time) (bool, error)
+
+	// DeleteFinishedBefore removes sent, failed and suppressed notifications created before a time
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// VCSConnectionRepository defines the interface for VCS connection persistence
+type VCSConnectionRepository interface {
+	// Create saves a new connection and assigns its ID
+	Create(ctx context.Context, connection *VCSConnection) error
+
+	// Update updates an existing connection
+	Update(ctx context.Context, connection *VCSConnection) error
+
+	// Delete removes a connection
+	Delete(ctx context.Context, connectionID string) error
+
+	// FindByID retrieves a connection by ID
+	FindByID(ctx context.Context, connectionID string) (*VCSConnection, error)
+
+	// FindByProjectID retrieves the connections of a project
+	FindByProjectID(ctx context.Context, projectID string) ([]*VCSConnection, error)
+
+	// FindActiveByProjectID retrieves the active connections of a project
+	FindActiveByProjectID(ctx context.Context, projectID string) ([]*VCSConnection, error)
+}
+
+// VCSReportRepository defines the interface for the VCS report queue and log
+type VCSReportRepository interface {
+	// Create queues a report and assigns its ID
+	Create(ctx context.Context, report *VCSReport) error
+
+	// Update saves the outcome of a report attempt
+	Update(ctx context.Context, report *VCSReport) error
+
+	// FindByConnectionID retrieves the reports of a connection, newest first
+	FindByConnectionID(ctx context.Context, connectionID string, limit int) ([]*VCSReport, error)
+
+	// HasPending reports whether a report of a test run queued at the same
+	// stage of the run is still pending for a connection
+	HasPending(ctx context.Context, connectionID string, testRunID uint, final bool) (bool, error)
+
+	// FindDue retrieves pending reports of active connections whose next
+	// attempt is due, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*VCSReport, error)
+
+	// Claim moves the next attempt of a due report to leaseUntil, unless
+	// another worker claimed it first, and reports whether it was claimed
+	Claim(ctx context.Context, report *VCSReport, leaseUntil time.Time) (bool, error)
+
+	// DeleteFinishedBefore removes sent and failed reports created before a time
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DigestDeliveryRepository stores the email digests sent to users
+type DigestDeliveryRepository interface {
+	// Reserve creates a delivery unless the user already has one for its
+	// period, and reports whether it was created
+	Reserve(ctx context.Context, delivery *DigestDelivery) (bool, error)
+
+	// FindByPeriod retrieves the deliveries of one scheduled time
+	FindByPeriod(ctx context.Context, periodEnd time.Time) ([]*DigestDelivery, error)
+
+	// FindByUserID retrieves the deliveries of a user, newest first
+	FindByUserID(ctx context.Context, userID string, limit int) ([]*DigestDelivery, error)
+
+	// Update saves a delivery
+	Update(ctx context.Context, delivery *DigestDelivery) error
+
+	// Claim moves the next attempt of a pending delivery to leaseUntil, unless
+	// another instance changed it since it was loaded, and reports whether it did
+	Claim(ctx context.Context, delivery *DigestDelivery, leaseUntil time.Time) (bool, error)
+
+	// DeleteBefore removes deliveries of periods ending before a time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DigestPreferenceRepository stores whether users opted out of email digests
+type DigestPreferenceRepository interface {
+	IsOptedOut(ctx context.Context, userID string) (bool, error)
+	SetOptedOut(ctx context.Context, userID string, optedOut bool) error
+}
@@ -0,0 +1,56 @@
+package integrations
+
+import "time"
+
+// retryDelay returns how long to wait after a failed attempt before the next
+// one: base after the first attempt, doubling with every further attempt up
+// to maxDelay
+func retryDelay(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// attemptOutcome is what an attempt leaves a notification, webhook delivery,
+// VCS report or digest to do
+type attemptOutcome int
+
+const (
+	attemptSucceeded attemptOutcome = iota // Nothing more to send
+	attemptRetry                           // Attempt again at NextAttemptAt
+	attemptExhausted                       // No attempts left
+)
+
+// deliveryAttempts points to the attempt bookkeeping of a notification,
+// webhook delivery, VCS report or digest
+type deliveryAttempts struct {
+	attempts      *int
+	nextAttemptAt *time.Time
+	lastAttemptAt **time.Time
+	lastError     *string
+}
+
+// record counts an attempt and its error, and schedules the next attempt
+// after backoff unless the attempt succeeded or was the last of maxAttempts
+func (a deliveryAttempts) record(at time.Time, attemptErr error, maxAttempts int, backoff func(attempt int) time.Duration) attemptOutcome {
+	*a.attempts++
+	*a.lastAttemptAt = &at
+	*a.lastError = ""
+
+	switch {
+	case attemptErr == nil:
+		return attemptSucceeded
+	case *a.attempts >= maxAttempts:
+		*a.lastError = attemptErr.Error()
+		return attemptExhausted
+	default:
+		*a.lastError = attemptErr.Error()
+		*a.nextAttemptAt = at.Add(backoff(*a.attempts))
+		return attemptRetry
+	}
+}
@@ -4,24 +4,88 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultIssueType is the JIRA issue type used for filed failures and flaky tests
+	DefaultIssueType = "Bug"
+
+	// MaxIssueClusterSize bounds the number of spec runs filed together as one issue
+	MaxIssueClusterSize = 50
+
+	// JiraMetadataCacheTTL is how long the fields and issue types of a connection are cached
+	JiraMetadataCacheTTL = 15 * time.Minute
 )
 
 // JiraConnectionService handles JIRA connection operations
 type JiraConnectionService struct {
 	repo           JiraConnectionRepository
 	jiraClient     JiraClient
-	encryptionKey  []byte
+	keys           *KeyRing
+
+	// Issue creation
+	issueLinks    JiraIssueLinkRepository
+	issueSources  IssueSourceProvider
+	issueTemplate *IssueTemplate
+	issueMu       sync.Mutex
+
+	// Field mapping
+	fieldMappings JiraFieldMappingRepository
+	metadata      map[string]*jiraMetadata
+	metadataMu    sync.Mutex
+
+	// Issue sync
+	flakyTracker    FlakyTestTracker
+	reopenOnReflake ReopenOnReflakeFunc
+	syncOptions     IssueSyncOptions
+	syncBackoffs    map[string]*syncBackoff
+	lastSyncRequest time.Time
+	syncMu          sync.Mutex
+
+	// OAuth authorization
+	oauth   *JiraOAuthProvider
+	oauthMu sync.Mutex
+}
+
+// jiraMetadata is the cached field and issue type metadata of a connection
+type jiraMetadata struct {
+	fields     []JiraField
+	issueTypes []JiraIssueType
+	fetchedAt  time.Time
 }
 
 // NewJiraConnectionService creates a new JIRA connection service
-func NewJiraConnectionService(repo JiraConnectionRepository, jiraClient JiraClient, encryptionKey []byte) *JiraConnectionService {
+func NewJiraConnectionService(repo JiraConnectionRepository, jiraClient JiraClient, keys *KeyRing) *JiraConnectionService {
 	return &JiraConnectionService{
 		repo:          repo,
 		jiraClient:    jiraClient,
-		encryptionKey: encryptionKey,
+		keys:          keys,
+		issueTemplate: DefaultIssueTemplate(),
+		metadata:      make(map[string]*jiraMetadata),
+		syncOptions:   DefaultIssueSyncOptions(),
+		syncBackoffs:  make(map[string]*syncBackoff),
 	}
 }
 
+// EnableIssueCreation configures where issue content is loaded from and
+// where filed issues are recorded
+func (s *JiraConnectionService) EnableIssueCreation(issueLinks JiraIssueLinkRepository, issueSources IssueSourceProvider) {
+	s.issueLinks = issueLinks
+	s.issueSources = issueSources
+}
+
+// EnableFieldMapping configures where field mappings are stored
+func (s *JiraConnectionService) EnableFieldMapping(fieldMappings JiraFieldMappingRepository) {
+	s.fieldMappings = fieldMappings
+}
+
+// SetIssueTemplate overrides the template used to build issue summaries and descriptions
+func (s *JiraConnectionService) SetIssueTemplate(issueTemplate *IssueTemplate) {
+	s.issueTemplate = issueTemplate
+}
+
 // CreateConnection creates a new JIRA connection
 func (s *JiraConnectionService) CreateConnection(ctx context.Context, projectID, name, jiraURL string, authType AuthenticationType, projectKey, username, credential string) (*JiraConnection, error) {
 	// Check if a connection already exists for this project
@@ -43,12 +107,14 @@ func (s *JiraConnectionService) CreateConnection(ctx context.Context, projectID,
 		return nil, fmt.Errorf("failed to create connection: %w", err)
 	}
 
-	// Encrypt the credential before saving
-	encrypted, err := conn.GetEncryptedCredential(s.encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+	// Encrypt the credential before saving; OAuth connections have none until authorized
+	if conn.encryptedCredential != "" {
+		encrypted, err := s.keys.Encrypt(conn.encryptedCredential)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+		}
+		conn.encryptedCredential = encrypted
 	}
-	conn.encryptedCredential = encrypted
 
 	// Save to repository
 	if err := s.repo.Create(ctx, conn); err != nil {
@@ -75,6 +141,7 @@ func (s *JiraConnectionService) UpdateConnection(ctx context.Context, connection
 	if err := s.repo.Update(ctx, conn); err != nil {
 		return nil, fmt.Errorf("failed to save connection: %w", err)
 	}
+	s.invalidateMetadata(connectionID)
 
 	return conn, nil
 }
@@ -93,16 +160,19 @@ func (s *JiraConnectionService) UpdateCredentials(ctx context.Context, connectio
 	}
 
 	// Encrypt the new credential
-	encrypted, err := conn.GetEncryptedCredential(s.encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+	if conn.encryptedCredential != "" {
+		encrypted, err := s.keys.Encrypt(conn.encryptedCredential)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+		}
+		conn.encryptedCredential = encrypted
 	}
-	conn.encryptedCredential = encrypted
 
 	// Save changes
 	if err := s.repo.Update(ctx, conn); err != nil {
 		return nil, fmt.Errorf("failed to save connection: %w", err)
 	}
+	s.invalidateMetadata(connectionID)
 
 	return conn, nil
 }
@@ -119,27 +189,16 @@ func (s *JiraConnectionService) TestConnection(ctx context.Context, connectionID
 	log.Printf("[JiraConnectionService] Testing connection ID: %s, URL: %s, Project: %s, Username: %s", 
 		connectionID, conn.jiraURL, conn.projectKey, conn.username)
 
-	// Decrypt the credential
-	decrypted, err := DecryptCredential(conn.encryptedCredential, s.encryptionKey)
+	// Decrypt the credential, refreshing OAuth tokens as needed
+	apiURL, credential, err := s.credentialFor(ctx, conn)
 	if err != nil {
-		log.Printf("[JiraConnectionService] Failed to decrypt credential: %v", err)
-		return fmt.Errorf("failed to decrypt credential: %w", err)
+		log.Printf("[JiraConnectionService] Failed to load credential: %v", err)
+		return err
 	}
 
-	// Save the original encrypted credential
-	originalEncrypted := conn.GetEncryptedCredentialDirect()
-	
-	// Temporarily set the decrypted credential for testing
-	conn.encryptedCredential = decrypted
-	
 	// Test the connection
-	log.Printf("[JiraConnectionService] Calling TestConnection on JIRA client for URL: %s", conn.jiraURL)
-	err = conn.TestConnection(ctx, s.jiraClient)
-	
-	// CRITICAL: Restore the original encrypted credential before saving
-	conn.encryptedCredential = originalEncrypted
-	
-	// Now save with the original encrypted credential
+	log.Printf("[JiraConnectionService] Calling TestConnection on JIRA client for URL: %s", apiURL)
+	err = conn.recordTest(s.jiraClient.TestConnection(ctx, apiURL, conn.username, credential, conn.authenticationType))
 	if err != nil {
 		log.Printf("[JiraConnectionService] Test failed for %s: %v", conn.jiraURL, err)
 		updateErr := s.repo.Update(ctx, conn)
@@ -177,6 +236,7 @@ func (s *JiraConnectionService) DeactivateConnection(ctx context.Context, connec
 
 // DeleteConnection deletes a JIRA connection
 func (s *JiraConnectionService) DeleteConnection(ctx context.Context, connectionID string) error {
+	s.invalidateMetadata(connectionID)
 	return s.repo.Delete(ctx, connectionID)
 }
 
@@ -193,4 +253,344 @@ func (s *JiraConnectionService) GetProjectConnections(ctx context.Context, proje
 // GetActiveProjectConnections retrieves all active connections for a project
 func (s *JiraConnectionService) GetActiveProjectConnections(ctx context.Context, projectID string) ([]*JiraConnection, error) {
 	return s.repo.FindActiveByProjectID(ctx, projectID)
-}
\ No newline at end of file
+}
+
+// LoadIssueSource loads the content of an issue, so callers can check
+// project access before filing it
+func (s *JiraConnectionService) LoadIssueSource(ctx context.Context, req IssueSourceRequest) (*IssueSource, error) {
+	if s.issueSources == nil {
+		return nil, ErrIssueCreationNotConfigured
+	}
+
+	switch req.Type {
+	case IssueSourceSpecRun:
+		if len(req.SpecRunIDs) != 1 {
+			return nil, fmt.Errorf("exactly one spec run is required")
+		}
+	case IssueSourceFailureCluster:
+		if len(req.SpecRunIDs) == 0 {
+			return nil, fmt.Errorf("at least one spec run is required")
+		}
+		if len(req.SpecRunIDs) > MaxIssueClusterSize {
+			return nil, fmt.Errorf("at most %d spec runs can be filed together", MaxIssueClusterSize)
+		}
+	case IssueSourceFlakyTest:
+		if req.FlakyTestID == 0 {
+			return nil, fmt.Errorf("flaky test ID is required")
+		}
+		return s.issueSources.FlakyTestSource(ctx, req.FlakyTestID)
+	default:
+		return nil, fmt.Errorf("unsupported issue source type %q", req.Type)
+	}
+
+	source, err := s.issueSources.FailureSource(ctx, req.SpecRunIDs)
+	if err != nil {
+		return nil, err
+	}
+	source.Type = req.Type
+	return source, nil
+}
+
+// CreateIssue files a JIRA issue for the source using the project's active
+// connection. When any of the source's failures or flaky test already has an
+// issue, no new issue is filed and the remaining targets are linked to it.
+func (s *JiraConnectionService) CreateIssue(ctx context.Context, source *IssueSource, createdBy string) (*IssueCreation, error) {
+	if s.issueLinks == nil {
+		return nil, ErrIssueCreationNotConfigured
+	}
+	if len(source.Targets) == 0 {
+		return nil, fmt.Errorf("issue source has no failures or flaky test to link")
+	}
+
+	// Serialize filing so concurrent requests for the same failure create one issue
+	s.issueMu.Lock()
+	defer s.issueMu.Unlock()
+
+	existing, err := s.issueLinks.FindByTargets(ctx, source.ProjectID, source.Targets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing issues: %w", err)
+	}
+	if len(existing) > 0 {
+		filed := existing[0]
+		links, err := s.linkIssue(ctx, source, filed.ConnectionID, filed.IssueKey, filed.IssueURL, createdBy, existing)
+		if err != nil {
+			return nil, err
+		}
+		return &IssueCreation{
+			IssueKey: filed.IssueKey,
+			IssueURL: filed.IssueURL,
+			Created:  false,
+			Links:    append(existing, links...),
+		}, nil
+	}
+
+	connections, err := s.repo.FindActiveByProjectID(ctx, source.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active connections: %w", err)
+	}
+	if len(connections) == 0 {
+		return nil, ErrNoActiveJiraConnection
+	}
+	conn := connections[0]
+
+	apiURL, credential, err := s.credentialFor(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, description, err := s.issueTemplate.Render(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build issue: %w", err)
+	}
+
+	labels := []string{"fern"}
+	if source.Type == IssueSourceFlakyTest {
+		labels = append(labels, "flaky-test")
+	}
+
+	request := JiraIssueRequest{
+		ProjectKey:  conn.projectKey,
+		IssueType:   DefaultIssueType,
+		Summary:     summary,
+		Description: description,
+		Labels:      labels,
+	}
+	if err := s.applyFieldMapping(ctx, conn, apiURL, credential, source, &request); err != nil {
+		return nil, err
+	}
+
+	issue, err := s.jiraClient.CreateIssue(ctx, apiURL, conn.username, credential, conn.authenticationType, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JIRA issue: %w", err)
+	}
+
+	issueURL := fmt.Sprintf("%s/browse/%s", conn.jiraURL, issue.Key)
+	log.Printf("[JiraConnectionService] Created issue %s for %s in project %s", issue.Key, source.Type, source.ProjectID)
+
+	links, err := s.linkIssue(ctx, source, conn.id, issue.Key, issueURL, createdBy, nil)
+	if err != nil {
+		return nil, fmt.Errorf("issue %s was created but could not be recorded: %w", issue.Key, err)
+	}
+
+	return &IssueCreation{
+		IssueKey: issue.Key,
+		IssueURL: issueURL,
+		Created:  true,
+		Links:    links,
+	}, nil
+}
+
+// GetIssueLinks retrieves the issues filed for a project
+func (s *JiraConnectionService) GetIssueLinks(ctx context.Context, projectID string) ([]*JiraIssueLink, error) {
+	if s.issueLinks == nil {
+		return nil, ErrIssueCreationNotConfigured
+	}
+	return s.issueLinks.FindByProjectID(ctx, projectID)
+}
+
+// linkIssue records the issue against every source target that is not already linked
+func (s *JiraConnectionService) linkIssue(ctx context.Context, source *IssueSource, connectionID, issueKey, issueURL, createdBy string, existing []*JiraIssueLink) ([]*JiraIssueLink, error) {
+	linked := make(map[IssueTarget]bool, len(existing))
+	for _, link := range existing {
+		linked[IssueTarget{Type: link.Target.Type, ID: link.Target.ID}] = true
+	}
+
+	var links []*JiraIssueLink
+	for _, target := range source.Targets {
+		key := IssueTarget{Type: target.Type, ID: target.ID}
+		if linked[key] {
+			continue
+		}
+		linked[key] = true
+		links = append(links, &JiraIssueLink{
+			ProjectID:    source.ProjectID,
+			ConnectionID: connectionID,
+			IssueKey:     issueKey,
+			IssueURL:     issueURL,
+			SourceType:   source.Type,
+			Target:       target,
+			CreatedBy:    createdBy,
+		})
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	if err := s.issueLinks.Create(ctx, links); err != nil {
+		return nil, fmt.Errorf("failed to save issue links: %w", err)
+	}
+	return links, nil
+}
+
+// GetIssueLink retrieves the issue filed for a failure or flaky test, or nil when none was filed
+func (s *JiraConnectionService) GetIssueLink(ctx context.Context, projectID string, target IssueTarget) (*JiraIssueLink, error) {
+	if s.issueLinks == nil {
+		return nil, ErrIssueCreationNotConfigured
+	}
+
+	links, err := s.issueLinks.FindByTargets(ctx, projectID, []IssueTarget{target})
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return links[0], nil
+}
+
+// GetJiraFields retrieves the fields of the connection's JIRA instance.
+// Results are cached per connection unless refresh is set.
+func (s *JiraConnectionService) GetJiraFields(ctx context.Context, connectionID string, refresh bool) ([]JiraField, error) {
+	metadata, err := s.connectionMetadata(ctx, connectionID, refresh)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.fields, nil
+}
+
+// GetJiraIssueTypes retrieves the issue types of the connection's JIRA instance.
+// Results are cached per connection unless refresh is set.
+func (s *JiraConnectionService) GetJiraIssueTypes(ctx context.Context, connectionID string, refresh bool) ([]JiraIssueType, error) {
+	metadata, err := s.connectionMetadata(ctx, connectionID, refresh)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.issueTypes, nil
+}
+
+// GetFieldMapping retrieves the field mapping of a connection, or the
+// default mapping when none has been saved
+func (s *JiraConnectionService) GetFieldMapping(ctx context.Context, connectionID string) (*JiraFieldMapping, error) {
+	if s.fieldMappings == nil {
+		return nil, ErrFieldMappingNotConfigured
+	}
+
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	mapping, err := s.fieldMappings.FindByConnectionID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field mapping: %w", err)
+	}
+	if mapping == nil {
+		return DefaultFieldMapping(conn.id, conn.projectID), nil
+	}
+	return mapping, nil
+}
+
+// UpdateFieldMapping validates a field mapping against the connection's JIRA
+// instance and saves it
+func (s *JiraConnectionService) UpdateFieldMapping(ctx context.Context, connectionID, issueType string, fields map[FernField]string, updatedBy string) (*JiraFieldMapping, error) {
+	if s.fieldMappings == nil {
+		return nil, ErrFieldMappingNotConfigured
+	}
+
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	mapping := &JiraFieldMapping{
+		ConnectionID: conn.id,
+		ProjectID:    conn.projectID,
+		IssueType:    issueType,
+		Fields:       make(map[FernField]string, len(fields)),
+		UpdatedBy:    updatedBy,
+		UpdatedAt:    time.Now(),
+	}
+	for fernField, jiraFieldID := range fields {
+		if jiraFieldID != "" {
+			mapping.Fields[fernField] = jiraFieldID
+		}
+	}
+
+	metadata, err := s.loadMetadata(ctx, conn, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateFieldMapping(mapping, metadata.fields, metadata.issueTypes); err != nil {
+		return nil, err
+	}
+
+	if err := s.fieldMappings.Save(ctx, mapping); err != nil {
+		return nil, fmt.Errorf("failed to save field mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// applyFieldMapping sets the issue type and mapped field values of an issue
+// from the connection's saved field mapping
+func (s *JiraConnectionService) applyFieldMapping(ctx context.Context, conn *JiraConnection, apiURL, credential string, source *IssueSource, request *JiraIssueRequest) error {
+	if s.fieldMappings == nil {
+		return nil
+	}
+
+	mapping, err := s.fieldMappings.FindByConnectionID(ctx, conn.id)
+	if err != nil {
+		return fmt.Errorf("failed to get field mapping: %w", err)
+	}
+	if mapping == nil {
+		return nil
+	}
+
+	metadata, err := s.fetchMetadata(ctx, conn, apiURL, credential, false)
+	if err != nil {
+		return err
+	}
+	request.IssueType = mapping.IssueType
+	request.Fields = mapping.fieldValues(source, metadata.fields)
+	return nil
+}
+
+// connectionMetadata loads the cached metadata of a connection by ID
+func (s *JiraConnectionService) connectionMetadata(ctx context.Context, connectionID string, refresh bool) (*jiraMetadata, error) {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+	return s.loadMetadata(ctx, conn, refresh)
+}
+
+// loadMetadata loads the connection's credential and its metadata
+func (s *JiraConnectionService) loadMetadata(ctx context.Context, conn *JiraConnection, refresh bool) (*jiraMetadata, error) {
+	apiURL, credential, err := s.credentialFor(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return s.fetchMetadata(ctx, conn, apiURL, credential, refresh)
+}
+
+// fetchMetadata returns the cached metadata of a connection, fetching it from
+// JIRA when it is missing, expired or a refresh is requested
+func (s *JiraConnectionService) fetchMetadata(ctx context.Context, conn *JiraConnection, apiURL, credential string, refresh bool) (*jiraMetadata, error) {
+	s.metadataMu.Lock()
+	cached, ok := s.metadata[conn.id]
+	s.metadataMu.Unlock()
+	if ok && !refresh && time.Since(cached.fetchedAt) < JiraMetadataCacheTTL {
+		return cached, nil
+	}
+
+	fields, err := s.jiraClient.GetFields(ctx, apiURL, conn.username, credential, conn.authenticationType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JIRA fields: %w", err)
+	}
+	issueTypes, err := s.jiraClient.GetIssueTypes(ctx, apiURL, conn.username, credential, conn.authenticationType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JIRA issue types: %w", err)
+	}
+
+	metadata := &jiraMetadata{fields: fields, issueTypes: issueTypes, fetchedAt: time.Now()}
+	s.metadataMu.Lock()
+	s.metadata[conn.id] = metadata
+	s.metadataMu.Unlock()
+	return metadata, nil
+}
+
+// invalidateMetadata drops the cached metadata of a connection
+func (s *JiraConnectionService) invalidateMetadata(connectionID string) {
+	s.metadataMu.Lock()
+	delete(s.metadata, connectionID)
+	s.metadataMu.Unlock()
+}
@@ -0,0 +1,224 @@
+package integrations
+
+import (
+	"errors"
+	"time"
+)
+
+// TrackerConnection connects a project to an issue tracker through one of the registered connectors
+type TrackerConnection struct {
+	id                  string
+	projectID           string
+	name                string
+	connectorType       ConnectorType
+	config              ConnectorConfig
+	encryptedCredential string
+	status              ConnectionStatus
+	lastError           string
+	isActive            bool
+	lastTestedAt        *time.Time
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+// NewTrackerConnection creates a pending, inactive connection. The config must
+// already be validated against the connector's schema and the credential encrypted.
+func NewTrackerConnection(projectID, name string, connectorType ConnectorType, config ConnectorConfig, encryptedCredential string) (*TrackerConnection, error) {
+	if projectID == "" {
+		return nil, errors.New("project ID is required")
+	}
+	if name == "" {
+		return nil, errors.New("connection name is required")
+	}
+	if connectorType == "" {
+		return nil, errors.New("connector type is required")
+	}
+	if encryptedCredential == "" {
+		return nil, errors.New("credential is required")
+	}
+
+	now := time.Now()
+	return &TrackerConnection{
+		projectID:           projectID,
+		name:                name,
+		connectorType:       connectorType,
+		config:              copyConnectorConfig(config),
+		encryptedCredential: encryptedCredential,
+		status:              ConnectionStatusPending,
+		createdAt:           now,
+		updatedAt:           now,
+	}, nil
+}
+
+// ID returns the connection ID
+func (t *TrackerConnection) ID() string {
+	return t.id
+}
+
+// ProjectID returns the project ID
+func (t *TrackerConnection) ProjectID() string {
+	return t.projectID
+}
+
+// Name returns the connection name
+func (t *TrackerConnection) Name() string {
+	return t.name
+}
+
+// ConnectorType returns the type of tracker the connection talks to
+func (t *TrackerConnection) ConnectorType() ConnectorType {
+	return t.connectorType
+}
+
+// Config returns a copy of the connector settings
+func (t *TrackerConnection) Config() ConnectorConfig {
+	return copyConnectorConfig(t.config)
+}
+
+// EncryptedCredential returns the encrypted credential (for repository and service use)
+func (t *TrackerConnection) EncryptedCredential() string {
+	return t.encryptedCredential
+}
+
+// Status returns the connection status
+func (t *TrackerConnection) Status() ConnectionStatus {
+	return t.status
+}
+
+// LastError returns why the last connection test failed, if it did
+func (t *TrackerConnection) LastError() string {
+	return t.lastError
+}
+
+// IsActive returns whether the connection is active
+func (t *TrackerConnection) IsActive() bool {
+	return t.isActive
+}
+
+// AssignID sets the ID given by the repository when the connection is created
+func (t *TrackerConnection) AssignID(id string) {
+	t.id = id
+}
+
+// UpdateInfo renames the connection and replaces its validated config
+func (t *TrackerConnection) UpdateInfo(name string, config ConnectorConfig) error {
+	if name == "" {
+		return errors.New("connection name is required")
+	}
+
+	t.name = name
+	t.config = copyConnectorConfig(config)
+	t.status = ConnectionStatusPending // The settings have not been tested yet
+	t.lastError = ""
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// UpdateCredential replaces the encrypted credential
+func (t *TrackerConnection) UpdateCredential(encryptedCredential string) error {
+	if encryptedCredential == "" {
+		return errors.New("credential is required")
+	}
+
+	t.encryptedCredential = encryptedCredential
+	t.status = ConnectionStatusPending // Reset status when credentials change
+	t.lastError = ""
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// RecordTest records the outcome of a connection test
+func (t *TrackerConnection) RecordTest(testErr error) {
+	now := time.Now()
+	t.lastTestedAt = &now
+	t.updatedAt = now
+
+	if testErr != nil {
+		t.status = ConnectionStatusFailed
+		t.lastError = testErr.Error()
+		return
+	}
+	t.status = ConnectionStatusConnected
+	t.lastError = ""
+}
+
+// Activate activates the connection
+func (t *TrackerConnection) Activate() {
+	t.isActive = true
+	t.updatedAt = time.Now()
+}
+
+// Deactivate deactivates the connection
+func (t *TrackerConnection) Deactivate() {
+	t.isActive = false
+	t.updatedAt = time.Now()
+}
+
+// Snapshot returns a read-only snapshot of the connection
+func (t *TrackerConnection) Snapshot() TrackerConnectionSnapshot {
+	return TrackerConnectionSnapshot{
+		ID:            t.id,
+		ProjectID:     t.projectID,
+		Name:          t.name,
+		ConnectorType: t.connectorType,
+		Config:        copyConnectorConfig(t.config),
+		Status:        t.status,
+		LastError:     t.lastError,
+		IsActive:      t.isActive,
+		LastTestedAt:  t.lastTestedAt,
+		CreatedAt:     t.createdAt,
+		UpdatedAt:     t.updatedAt,
+	}
+}
+
+// TrackerConnectionSnapshot is a read-only view of an issue tracker connection
+type TrackerConnectionSnapshot struct {
+	ID            string
+	ProjectID     string
+	Name          string
+	ConnectorType ConnectorType
+	Config        ConnectorConfig
+	Status        ConnectionStatus
+	LastError     string
+	IsActive      bool
+	LastTestedAt  *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ReconstructTrackerConnection reconstructs a TrackerConnection from persisted data
+func ReconstructTrackerConnection(
+	id, projectID, name string,
+	connectorType ConnectorType,
+	config ConnectorConfig,
+	encryptedCredential string,
+	status ConnectionStatus,
+	lastError string,
+	isActive bool,
+	lastTestedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *TrackerConnection {
+	return &TrackerConnection{
+		id:                  id,
+		projectID:           projectID,
+		name:                name,
+		connectorType:       connectorType,
+		config:              copyConnectorConfig(config),
+		encryptedCredential: encryptedCredential,
+		status:              status,
+		lastError:           lastError,
+		isActive:            isActive,
+		lastTestedAt:        lastTestedAt,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+	}
+}
+
+// copyConnectorConfig copies config so callers cannot change a connection's settings in place
+func copyConnectorConfig(config ConnectorConfig) ConnectorConfig {
+	copied := make(ConnectorConfig, len(config))
+	for key, value := range config {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,244 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrTrackerConnectionInactive is returned when issues are filed or read through an inactive connection
+var ErrTrackerConnectionInactive = errors.New("issue tracker connection is not active")
+
+// TrackerConnectionService manages issue tracker connections of any registered
+// connector type and performs issue operations through them
+type TrackerConnectionService struct {
+	repo     TrackerConnectionRepository
+	registry *ConnectorRegistry
+	keys     *KeyRing
+}
+
+// NewTrackerConnectionService creates a new issue tracker connection service
+func NewTrackerConnectionService(repo TrackerConnectionRepository, registry *ConnectorRegistry, keys *KeyRing) *TrackerConnectionService {
+	return &TrackerConnectionService{
+		repo:     repo,
+		registry: registry,
+		keys:     keys,
+	}
+}
+
+// ConnectorTypes returns the connector types connections can be created for
+func (s *TrackerConnectionService) ConnectorTypes() []ConnectorDefinition {
+	return s.registry.Definitions()
+}
+
+// CreateConnection creates a connection after checking its config against the connector's schema
+func (s *TrackerConnectionService) CreateConnection(ctx context.Context, projectID, name string, connectorType ConnectorType, config ConnectorConfig, credential string) (*TrackerConnection, error) {
+	definition, err := s.registry.Definition(connectorType)
+	if err != nil {
+		return nil, err
+	}
+	if err := definition.ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	if credential == "" {
+		return nil, errors.New("credential is required")
+	}
+
+	encrypted, err := s.keys.Encrypt(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+	}
+
+	conn, err := NewTrackerConnection(projectID, name, connectorType, definition.ApplyDefaults(config), encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save connection: %w", err)
+	}
+	return conn, nil
+}
+
+// UpdateConnection renames a connection and replaces its config
+func (s *TrackerConnectionService) UpdateConnection(ctx context.Context, connectionID, name string, config ConnectorConfig) (*TrackerConnection, error) {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	definition, err := s.registry.Definition(conn.ConnectorType())
+	if err != nil {
+		return nil, err
+	}
+	if err := definition.ValidateConfig(config); err != nil {
+		return nil, err
+	}
+
+	if err := conn.UpdateInfo(name, definition.ApplyDefaults(config)); err != nil {
+		return nil, fmt.Errorf("failed to update connection info: %w", err)
+	}
+
+	if err := s.repo.Update(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save connection: %w", err)
+	}
+	return conn, nil
+}
+
+// UpdateCredential replaces the credential of a connection
+func (s *TrackerConnectionService) UpdateCredential(ctx context.Context, connectionID, credential string) (*TrackerConnection, error) {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+	if credential == "" {
+		return nil, errors.New("failed to update credentials: credential is required")
+	}
+
+	encrypted, err := s.keys.Encrypt(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+	}
+	if err := conn.UpdateCredential(encrypted); err != nil {
+		return nil, fmt.Errorf("failed to update credentials: %w", err)
+	}
+
+	if err := s.repo.Update(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save connection: %w", err)
+	}
+	return conn, nil
+}
+
+// TestConnection tests a connection and records the outcome on it
+func (s *TrackerConnectionService) TestConnection(ctx context.Context, connectionID string) error {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	connector, err := s.connector(conn)
+	if err == nil {
+		err = connector.TestConnection(ctx)
+	}
+	conn.RecordTest(err)
+
+	if updateErr := s.repo.Update(ctx, conn); updateErr != nil {
+		if err != nil {
+			log.Printf("[TrackerConnectionService] Failed to update connection %s after test failure: %v", connectionID, updateErr)
+		} else {
+			return fmt.Errorf("failed to save connection: %w", updateErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("connection test failed: %w", err)
+	}
+	return nil
+}
+
+// ActivateConnection activates a connection
+func (s *TrackerConnectionService) ActivateConnection(ctx context.Context, connectionID string) error {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	conn.Activate()
+	return s.repo.Update(ctx, conn)
+}
+
+// DeactivateConnection deactivates a connection
+func (s *TrackerConnectionService) DeactivateConnection(ctx context.Context, connectionID string) error {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to find connection: %w", err)
+	}
+
+	conn.Deactivate()
+	return s.repo.Update(ctx, conn)
+}
+
+// DeleteConnection deletes a connection
+func (s *TrackerConnectionService) DeleteConnection(ctx context.Context, connectionID string) error {
+	return s.repo.Delete(ctx, connectionID)
+}
+
+// GetConnection retrieves a connection by ID
+func (s *TrackerConnectionService) GetConnection(ctx context.Context, connectionID string) (*TrackerConnection, error) {
+	return s.repo.FindByID(ctx, connectionID)
+}
+
+// GetProjectConnections retrieves all connections for a project
+func (s *TrackerConnectionService) GetProjectConnections(ctx context.Context, projectID string) ([]*TrackerConnection, error) {
+	return s.repo.FindByProjectID(ctx, projectID)
+}
+
+// CreateIssue files an issue through an active connection
+func (s *TrackerConnectionService) CreateIssue(ctx context.Context, connectionID string, issue TrackerIssueRequest) (*TrackerIssue, error) {
+	if issue.Title == "" {
+		return nil, errors.New("issue title is required")
+	}
+
+	connector, err := s.activeConnector(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := connector.CreateIssue(ctx, issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	return created, nil
+}
+
+// GetIssueStatus reads the workflow state of an issue through an active connection
+func (s *TrackerConnectionService) GetIssueStatus(ctx context.Context, connectionID, issueKey string) (*TrackerIssueStatus, error) {
+	connector, err := s.activeConnector(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := connector.GetIssueStatus(ctx, issueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue status: %w", err)
+	}
+	return status, nil
+}
+
+// AddComment comments on an issue through an active connection
+func (s *TrackerConnectionService) AddComment(ctx context.Context, connectionID, issueKey, body string) error {
+	if body == "" {
+		return errors.New("comment body is required")
+	}
+
+	connector, err := s.activeConnector(ctx, connectionID)
+	if err != nil {
+		return err
+	}
+
+	if err := connector.AddComment(ctx, issueKey, body); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	return nil
+}
+
+// activeConnector loads a connection and builds its connector, failing when the connection is inactive
+func (s *TrackerConnectionService) activeConnector(ctx context.Context, connectionID string) (Connector, error) {
+	conn, err := s.repo.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+	if !conn.IsActive() {
+		return nil, ErrTrackerConnectionInactive
+	}
+	return s.connector(conn)
+}
+
+// connector decrypts the credential of a connection and builds its connector
+func (s *TrackerConnectionService) connector(conn *TrackerConnection) (Connector, error) {
+	credential, err := s.keys.Decrypt(conn.EncryptedCredential())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+	return s.registry.NewConnector(conn.ConnectorType(), conn.Config(), credential)
+}
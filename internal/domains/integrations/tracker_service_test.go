@@ -0,0 +1,180 @@
+package integrations_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTrackerRepo is an in-memory TrackerConnectionRepository
+type memoryTrackerRepo struct {
+	connections map[string]*integrations.TrackerConnection
+	nextID      int
+}
+
+func (r *memoryTrackerRepo) Create(ctx context.Context, connection *integrations.TrackerConnection) error {
+	r.nextID++
+	connection.AssignID(fmt.Sprintf("%d", r.nextID))
+	r.connections[connection.ID()] = connection
+	return nil
+}
+
+func (r *memoryTrackerRepo) Update(ctx context.Context, connection *integrations.TrackerConnection) error {
+	r.connections[connection.ID()] = connection
+	return nil
+}
+
+func (r *memoryTrackerRepo) Delete(ctx context.Context, connectionID string) error {
+	delete(r.connections, connectionID)
+	return nil
+}
+
+func (r *memoryTrackerRepo) FindByID(ctx context.Context, connectionID string) (*integrations.TrackerConnection, error) {
+	conn, ok := r.connections[connectionID]
+	if !ok {
+		return nil, errors.New("tracker connection not found")
+	}
+	return conn, nil
+}
+
+func (r *memoryTrackerRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.TrackerConnection, error) {
+	var result []*integrations.TrackerConnection
+	for _, conn := range r.connections {
+		if conn.ProjectID() == projectID {
+			result = append(result, conn)
+		}
+	}
+	return result, nil
+}
+
+func newTrackerService(t *testing.T) (*integrations.TrackerConnectionService, *memoryTrackerRepo, **stubConnector) {
+	registry := integrations.NewConnectorRegistry()
+	built := new(*stubConnector)
+	require.NoError(t, registry.Register(stubDefinition(built)))
+
+	repo := &memoryTrackerRepo{connections: make(map[string]*integrations.TrackerConnection)}
+	return integrations.NewTrackerConnectionService(repo, registry, testKeyRing), repo, built
+}
+
+func TestTrackerConnectionService_CreateConnection(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("encrypts the credential and stores defaults", func(t *testing.T) {
+		service, repo, _ := newTrackerService(t)
+
+		conn, err := service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "secret")
+		require.NoError(t, err)
+
+		stored := repo.connections[conn.ID()]
+		require.NotNil(t, stored)
+		assert.Equal(t, integrations.ConnectionStatusPending, stored.Status())
+		assert.False(t, stored.IsActive())
+		assert.Equal(t, "core", stored.Config()["team"])
+		assert.NotEqual(t, "secret", stored.EncryptedCredential())
+
+		decrypted, err := testKeyRing.Decrypt(stored.EncryptedCredential())
+		require.NoError(t, err)
+		assert.Equal(t, "secret", decrypted)
+	})
+
+	t.Run("validates the config and type", func(t *testing.T) {
+		service, repo, _ := newTrackerService(t)
+
+		_, err := service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{}, "secret")
+		assert.True(t, errors.Is(err, integrations.ErrInvalidConnectorConfig))
+
+		_, err = service.CreateConnection(ctx, "project-1", "Tracker", "missing", integrations.ConnectorConfig{}, "secret")
+		assert.True(t, errors.Is(err, integrations.ErrUnknownConnectorType))
+
+		_, err = service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "")
+		assert.Error(t, err)
+
+		assert.Empty(t, repo.connections)
+	})
+}
+
+func TestTrackerConnectionService_TestConnection(t *testing.T) {
+	ctx := context.Background()
+	service, repo, built := newTrackerService(t)
+
+	conn, err := service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, service.TestConnection(ctx, conn.ID()))
+	assert.Equal(t, "secret", (*built).credential, "connectors receive the decrypted credential")
+	assert.Equal(t, integrations.ConnectionStatusConnected, repo.connections[conn.ID()].Status())
+	assert.NotNil(t, repo.connections[conn.ID()].Snapshot().LastTestedAt)
+
+	// Build a failing connector on the next test
+	registry := integrations.NewConnectorRegistry()
+	definition := stubDefinition(nil)
+	definition.New = func(config integrations.ConnectorConfig, credential string) (integrations.Connector, error) {
+		return &stubConnector{testErr: errors.New("401 Unauthorized")}, nil
+	}
+	require.NoError(t, registry.Register(definition))
+	failing := integrations.NewTrackerConnectionService(repo, registry, testKeyRing)
+
+	err = failing.TestConnection(ctx, conn.ID())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+	assert.Equal(t, integrations.ConnectionStatusFailed, repo.connections[conn.ID()].Status())
+	assert.Equal(t, "401 Unauthorized", repo.connections[conn.ID()].LastError())
+
+	// Changing the credential resets the status until the next test
+	updated, err := service.UpdateCredential(ctx, conn.ID(), "new-secret")
+	require.NoError(t, err)
+	assert.Equal(t, integrations.ConnectionStatusPending, updated.Status())
+	assert.Empty(t, updated.LastError())
+}
+
+func TestTrackerConnectionService_IssueOperations(t *testing.T) {
+	ctx := context.Background()
+	service, _, built := newTrackerService(t)
+
+	conn, err := service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "secret")
+	require.NoError(t, err)
+
+	_, err = service.CreateIssue(ctx, conn.ID(), integrations.TrackerIssueRequest{Title: "Failing test"})
+	assert.True(t, errors.Is(err, integrations.ErrTrackerConnectionInactive))
+
+	require.NoError(t, service.ActivateConnection(ctx, conn.ID()))
+
+	issue, err := service.CreateIssue(ctx, conn.ID(), integrations.TrackerIssueRequest{Title: "Failing test"})
+	require.NoError(t, err)
+	assert.Equal(t, "STUB-1", issue.Key)
+
+	_, err = service.CreateIssue(ctx, conn.ID(), integrations.TrackerIssueRequest{})
+	assert.Error(t, err, "a title is required")
+
+	status, err := service.GetIssueStatus(ctx, conn.ID(), "STUB-1")
+	require.NoError(t, err)
+	assert.True(t, status.IsDone())
+
+	require.NoError(t, service.AddComment(ctx, conn.ID(), "STUB-1", "Flaked again"))
+	assert.Equal(t, []string{"Flaked again"}, (*built).comments["STUB-1"])
+
+	require.NoError(t, service.DeactivateConnection(ctx, conn.ID()))
+	err = service.AddComment(ctx, conn.ID(), "STUB-1", "Flaked again")
+	assert.True(t, errors.Is(err, integrations.ErrTrackerConnectionInactive))
+}
+
+func TestTrackerConnectionService_UpdateConnection(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTrackerService(t)
+
+	conn, err := service.CreateConnection(ctx, "project-1", "Tracker", "stub", integrations.ConnectorConfig{"url": "https://tracker.example.com"}, "secret")
+	require.NoError(t, err)
+
+	updated, err := service.UpdateConnection(ctx, conn.ID(), "Renamed", integrations.ConnectorConfig{"url": "https://other.example.com", "mode": "fast"})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Name())
+	assert.Equal(t, integrations.ConnectorConfig{"url": "https://other.example.com", "mode": "fast", "team": "core"}, updated.Config())
+
+	_, err = service.UpdateConnection(ctx, conn.ID(), "Renamed", integrations.ConnectorConfig{"url": "https://other.example.com", "mode": "slow"})
+	assert.True(t, errors.Is(err, integrations.ErrInvalidConnectorConfig))
+}
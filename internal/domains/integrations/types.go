@@ -1,5 +1,10 @@
 package integrations
 
+import (
+	"fmt"
+	"time"
+)
+
 // AuthenticationType represents the type of authentication used for JIRA
 type AuthenticationType string
 
@@ -37,6 +42,8 @@ type JiraField struct {
 	Name       string
 	Custom     bool
 	SchemaType string
+	// SchemaItems is the element type of array fields
+	SchemaItems string
 }
 
 // JiraIssueType represents a JIRA issue type
@@ -46,4 +53,56 @@ type JiraIssueType struct {
 	Description string
 	IconURL     string
 	Subtask     bool
-}
\ No newline at end of file
+}
+
+// JiraIssueRequest describes an issue to create in JIRA
+type JiraIssueRequest struct {
+	ProjectKey  string
+	IssueType   string
+	Summary     string
+	Description string
+	Labels      []string
+	// Fields holds additional field values keyed by JIRA field ID
+	Fields map[string]interface{}
+}
+
+// JiraIssue represents a created JIRA issue
+type JiraIssue struct {
+	ID   string
+	Key  string
+	Self string
+}
+
+// Status categories JIRA groups workflow statuses into
+const (
+	JiraStatusCategoryNew        = "new"
+	JiraStatusCategoryInProgress = "indeterminate"
+	JiraStatusCategoryDone       = "done"
+)
+
+// JiraIssueStatus is the workflow state of an issue returned by a search
+type JiraIssueStatus struct {
+	Key    string
+	Status string
+	// StatusCategory is one of the JiraStatusCategory values
+	StatusCategory string
+	ResolvedAt     *time.Time
+}
+
+// IsDone returns true when the issue is in a done status such as Closed or Resolved
+func (s JiraIssueStatus) IsDone() bool {
+	return s.StatusCategory == JiraStatusCategoryDone
+}
+
+// JiraRateLimitError is returned when JIRA rejects a request for exceeding its rate limit
+type JiraRateLimitError struct {
+	// RetryAfter is how long JIRA asked clients to wait, or zero when it did not say
+	RetryAfter time.Duration
+}
+
+func (e *JiraRateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("JIRA rate limit exceeded, retry after %s", e.RetryAfter)
+	}
+	return "JIRA rate limit exceeded"
+}
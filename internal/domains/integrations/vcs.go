@@ -0,0 +1,467 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// VCSProviderType identifies the version control host a connection reports to
+type VCSProviderType string
+
+const (
+	VCSProviderGitHub VCSProviderType = "github"
+	VCSProviderGitLab VCSProviderType = "gitlab"
+)
+
+// VCSProviderTypes lists the version control hosts connections can report to
+func VCSProviderTypes() []VCSProviderType {
+	return []VCSProviderType{VCSProviderGitHub, VCSProviderGitLab}
+}
+
+// VCSStatusMode selects how a test run's result is attached to its commit
+type VCSStatusMode string
+
+const (
+	// VCSStatusModeCommitStatus sets a commit status (GitHub and GitLab)
+	VCSStatusModeCommitStatus VCSStatusMode = "commit_status"
+	// VCSStatusModeCheckRun creates a check run (GitHub only, requires a GitHub App token)
+	VCSStatusModeCheckRun VCSStatusMode = "check_run"
+)
+
+// DefaultVCSStatusContext is the name commit statuses and check runs are reported under by default
+const DefaultVCSStatusContext = "fern-platform/tests"
+
+// DefaultGitLabAPIURL is the REST API of gitlab.com
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+var (
+	// ErrInvalidVCSConnection is returned when a connection's settings are invalid
+	ErrInvalidVCSConnection = errors.New("invalid VCS connection")
+
+	// ErrVCSConnectionNotFound is returned when a connection does not exist
+	ErrVCSConnectionNotFound = errors.New("VCS connection not found")
+
+	// ErrVCSConnectionFailed is returned when the version control host rejects a connection test
+	ErrVCSConnectionFailed = errors.New("VCS connection test failed")
+)
+
+// VCSCommitState is the state of a commit status or check run
+type VCSCommitState string
+
+const (
+	VCSCommitPending VCSCommitState = "pending"
+	VCSCommitSuccess VCSCommitState = "success"
+	VCSCommitFailure VCSCommitState = "failure"
+)
+
+// VCSCommitStatus is the result of a test run as attached to its commit
+type VCSCommitStatus struct {
+	State       VCSCommitState
+	Context     string // Name the status is reported under
+	Description string // One-line summary, shortened to what the host accepts
+	TargetURL   string // Link to the run in Fern
+	ExternalID  string // Identifies the run, so retries update the same check run
+	Summary     string // Markdown summary shown on check runs
+}
+
+// VCSPullRequest is an open pull request (GitHub) or merge request (GitLab) containing a commit
+type VCSPullRequest struct {
+	Number     int // Pull request number, or merge request IID
+	URL        string
+	BaseBranch string // Branch the request merges into
+}
+
+// VCSProvider reports test results to a repository on a version control host
+type VCSProvider interface {
+	// TestConnection checks that the token can see the repository
+	TestConnection(ctx context.Context) error
+
+	// SetCommitStatus attaches a status, or a check run, to a commit
+	SetCommitStatus(ctx context.Context, commitSHA string, status VCSCommitStatus) error
+
+	// FindPullRequests returns the open pull or merge requests containing a commit
+	FindPullRequests(ctx context.Context, commitSHA string) ([]VCSPullRequest, error)
+
+	// UpsertComment updates the comment of a pull or merge request that
+	// contains marker, or creates one when there is none, and returns its URL
+	UpsertComment(ctx context.Context, pullRequest VCSPullRequest, marker, body string) (string, error)
+}
+
+// NewVCSProvider creates the provider of a connection's host
+func NewVCSProvider(settings VCSConnectionSettings, token string) (VCSProvider, error) {
+	switch settings.Provider {
+	case VCSProviderGitHub:
+		return newGitHubVCSProvider(settings, token), nil
+	case VCSProviderGitLab:
+		return newGitLabVCSProvider(settings, token), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidVCSConnection, settings.Provider)
+	}
+}
+
+// VCSConnectionSettings are the user-provided settings of a VCS connection
+type VCSConnectionSettings struct {
+	Name     string
+	Provider VCSProviderType
+	// APIURL is the REST API of the host; empty selects github.com or gitlab.com
+	APIURL string
+	// Repository is owner/name on GitHub, and the project path or numeric ID on GitLab
+	Repository    string
+	StatusContext string
+	StatusMode    VCSStatusMode
+	// CommentOnPullRequests upserts a summary comment on the open pull or merge requests of the commit
+	CommentOnPullRequests bool
+}
+
+// gitHubRepositoryPattern matches owner/name
+var gitHubRepositoryPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// validate checks the settings of a connection
+func (s VCSConnectionSettings) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidVCSConnection)
+	}
+	switch s.Provider {
+	case VCSProviderGitHub:
+		if !gitHubRepositoryPattern.MatchString(s.Repository) {
+			return fmt.Errorf("%w: repository must be given as owner/name", ErrInvalidVCSConnection)
+		}
+	case VCSProviderGitLab:
+		if strings.Trim(s.Repository, "/") == "" {
+			return fmt.Errorf("%w: repository is required", ErrInvalidVCSConnection)
+		}
+		if s.StatusMode == VCSStatusModeCheckRun {
+			return fmt.Errorf("%w: check runs are only available on GitHub", ErrInvalidVCSConnection)
+		}
+	default:
+		return fmt.Errorf("%w: unknown provider %q", ErrInvalidVCSConnection, s.Provider)
+	}
+	if s.StatusMode != "" && s.StatusMode != VCSStatusModeCommitStatus && s.StatusMode != VCSStatusModeCheckRun {
+		return fmt.Errorf("%w: unknown status mode %q", ErrInvalidVCSConnection, s.StatusMode)
+	}
+	if s.APIURL != "" {
+		parsed, err := url.Parse(s.APIURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: API URL must be an absolute http or https URL", ErrInvalidVCSConnection)
+		}
+	}
+	return nil
+}
+
+// normalize fills in the defaults of the settings
+func (s VCSConnectionSettings) normalize() VCSConnectionSettings {
+	s.APIURL = strings.TrimRight(s.APIURL, "/")
+	if s.APIURL == "" {
+		s.APIURL = DefaultGitHubAPIURL
+		if s.Provider == VCSProviderGitLab {
+			s.APIURL = DefaultGitLabAPIURL
+		}
+	}
+	s.Repository = strings.Trim(s.Repository, "/")
+	if s.StatusContext == "" {
+		s.StatusContext = DefaultVCSStatusContext
+	}
+	if s.StatusMode == "" {
+		s.StatusMode = VCSStatusModeCommitStatus
+	}
+	return s
+}
+
+// VCSConnection reports the test runs of a project to the commits and pull
+// requests of a GitHub or GitLab repository
+type VCSConnection struct {
+	id             string
+	projectID      string
+	settings       VCSConnectionSettings
+	encryptedToken string
+	status         ConnectionStatus
+	lastError      string
+	isActive       bool
+	lastTestedAt   *time.Time
+	createdBy      string
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewVCSConnection creates an active, untested connection. The token must
+// already be encrypted.
+func NewVCSConnection(projectID string, settings VCSConnectionSettings, encryptedToken, createdBy string) (*VCSConnection, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("%w: project ID is required", ErrInvalidVCSConnection)
+	}
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+	if encryptedToken == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidVCSConnection)
+	}
+
+	now := time.Now()
+	return &VCSConnection{
+		projectID:      projectID,
+		settings:       settings.normalize(),
+		encryptedToken: encryptedToken,
+		status:         ConnectionStatusPending,
+		isActive:       true,
+		createdBy:      createdBy,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// ID returns the connection ID
+func (c *VCSConnection) ID() string {
+	return c.id
+}
+
+// ProjectID returns the project whose test runs the connection reports
+func (c *VCSConnection) ProjectID() string {
+	return c.projectID
+}
+
+// Settings returns the settings of the connection, with defaults filled in
+func (c *VCSConnection) Settings() VCSConnectionSettings {
+	return c.settings
+}
+
+// EncryptedToken returns the encrypted access token
+func (c *VCSConnection) EncryptedToken() string {
+	return c.encryptedToken
+}
+
+// IsActive returns whether the connection reports test runs
+func (c *VCSConnection) IsActive() bool {
+	return c.isActive
+}
+
+// AssignID sets the ID given by the repository when the connection is created
+func (c *VCSConnection) AssignID(id string) {
+	c.id = id
+}
+
+// Update replaces the settings of the connection. An empty encrypted token keeps the current one.
+func (c *VCSConnection) Update(settings VCSConnectionSettings, encryptedToken string) error {
+	if err := settings.validate(); err != nil {
+		return err
+	}
+
+	c.settings = settings.normalize()
+	if encryptedToken != "" {
+		c.encryptedToken = encryptedToken
+	}
+	c.status = ConnectionStatusPending // The settings have not been tested yet
+	c.lastError = ""
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// RecordTest records the outcome of a connection test
+func (c *VCSConnection) RecordTest(testErr error) {
+	now := time.Now()
+	c.lastTestedAt = &now
+	c.updatedAt = now
+
+	if testErr != nil {
+		c.status = ConnectionStatusFailed
+		c.lastError = testErr.Error()
+		return
+	}
+	c.status = ConnectionStatusConnected
+	c.lastError = ""
+}
+
+// Activate resumes reporting test runs
+func (c *VCSConnection) Activate() {
+	c.isActive = true
+	c.updatedAt = time.Now()
+}
+
+// Deactivate stops reporting test runs
+func (c *VCSConnection) Deactivate() {
+	c.isActive = false
+	c.updatedAt = time.Now()
+}
+
+// MatchesRepository reports whether a repository a test run names, such as
+// https://github.com/acme/shop.git or git@github.com:acme/shop, is the
+// connection's repository. Runs that name no repository match every
+// connection of their project, as do GitLab projects given by numeric ID.
+func (c *VCSConnection) MatchesRepository(repository string) bool {
+	own := strings.ToLower(c.settings.Repository)
+	if repository == "" || strings.Trim(own, "0123456789") == "" {
+		return true
+	}
+
+	named := strings.ToLower(strings.TrimSpace(repository))
+	if i := strings.Index(named, "://"); i >= 0 {
+		named = named[i+3:]
+		if j := strings.Index(named, "/"); j >= 0 {
+			named = named[j+1:]
+		}
+	} else if i := strings.Index(named, ":"); i >= 0 {
+		named = named[i+1:] // scp-like git@host:owner/name
+	}
+	named = strings.Trim(strings.TrimSuffix(named, ".git"), "/")
+	return named == own
+}
+
+// Snapshot returns a read-only snapshot of the connection
+func (c *VCSConnection) Snapshot() VCSConnectionSnapshot {
+	return VCSConnectionSnapshot{
+		ID:           c.id,
+		ProjectID:    c.projectID,
+		Settings:     c.settings,
+		Status:       c.status,
+		LastError:    c.lastError,
+		IsActive:     c.isActive,
+		LastTestedAt: c.lastTestedAt,
+		CreatedBy:    c.createdBy,
+		CreatedAt:    c.createdAt,
+		UpdatedAt:    c.updatedAt,
+	}
+}
+
+// VCSConnectionSnapshot is a read-only view of a VCS connection
+type VCSConnectionSnapshot struct {
+	ID           string
+	ProjectID    string
+	Settings     VCSConnectionSettings
+	Status       ConnectionStatus
+	LastError    string
+	IsActive     bool
+	LastTestedAt *time.Time
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ReconstructVCSConnection reconstructs a VCSConnection from persisted data
+func ReconstructVCSConnection(snapshot VCSConnectionSnapshot, encryptedToken string) *VCSConnection {
+	return &VCSConnection{
+		id:             snapshot.ID,
+		projectID:      snapshot.ProjectID,
+		settings:       snapshot.Settings,
+		encryptedToken: encryptedToken,
+		status:         snapshot.Status,
+		lastError:      snapshot.LastError,
+		isActive:       snapshot.IsActive,
+		lastTestedAt:   snapshot.LastTestedAt,
+		createdBy:      snapshot.CreatedBy,
+		createdAt:      snapshot.CreatedAt,
+		updatedAt:      snapshot.UpdatedAt,
+	}
+}
+
+// VCSReportStatus is the state of a queued report
+type VCSReportStatus string
+
+const (
+	VCSReportPending VCSReportStatus = "pending"
+	VCSReportSent    VCSReportStatus = "sent"
+	VCSReportFailed  VCSReportStatus = "failed"
+)
+
+// VCSReport is one report of a test run to a connection, queued when the run
+// starts or finishes. A report always describes the run as it is when the
+// report is sent, so a late report of a started run reports its result.
+type VCSReport struct {
+	ID            string
+	ConnectionID  string
+	ProjectID     string
+	TestRunID     uint
+	RunID         string
+	CommitSHA     string
+	Final         bool // Queued when the run finished rather than when it started
+	Status        VCSReportStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	LastError     string
+	State         VCSCommitState // Commit state last reported
+	CommentURLs   []string       // Pull or merge request comments last upserted
+	CreatedAt     time.Time
+}
+
+// recordAttempt records the outcome of a report attempt and schedules the
+// next one, or fails the report once maxAttempts is reached
+func (r *VCSReport) recordAttempt(at time.Time, attemptErr error, maxAttempts int, backoff func(attempt int) time.Duration) {
+	attempts := deliveryAttempts{&r.Attempts, &r.NextAttemptAt, &r.LastAttemptAt, &r.LastError}
+	switch attempts.record(at, attemptErr, maxAttempts, backoff) {
+	case attemptSucceeded:
+		r.Status = VCSReportSent
+	case attemptExhausted:
+		r.Status = VCSReportFailed
+	default:
+		r.Status = VCSReportPending
+	}
+}
+
+// VCSRunRef identifies a test run to report
+type VCSRunRef struct {
+	ProjectID  string
+	TestRunID  uint
+	RunID      string
+	CommitSHA  string
+	Repository string // Repository the run names, if any
+	Finished   bool
+}
+
+// VCSTestRun describes a test run for a report
+type VCSTestRun struct {
+	ID           uint
+	RunID        string
+	ProjectID    string
+	Name         string
+	Status       string
+	Branch       string
+	CommitSHA    string
+	Finished     bool
+	TotalTests   int
+	PassedTests  int
+	FailedTests  int
+	SkippedTests int
+	Duration     time.Duration
+	URL          string // Link to the run in Fern, empty when no Fern URL is configured
+	Failures     []VCSFailedTest
+	FlakyTests   []VCSFlakyTest // Known flaky tests the run executed, or tests that passed on retry
+}
+
+// VCSFailedTest is a test that failed in a run
+type VCSFailedTest struct {
+	SuiteName    string
+	TestName     string
+	ErrorMessage string
+}
+
+// key identifies the test across runs
+func (t VCSFailedTest) key() string {
+	return t.SuiteName + "\x00" + t.TestName
+}
+
+// VCSFlakyTest is a flaky test a run executed
+type VCSFlakyTest struct {
+	SuiteName string
+	TestName  string
+	Result    string  // Status of the test in the run
+	FlakeRate float64 // Share of flaky executions, from 0 to 1; zero for tests that are not tracked as flaky yet
+	Severity  string
+	URL       string
+}
+
+// VCSReportSource loads test runs from the testing domain for reports
+type VCSReportSource interface {
+	// TestRun loads a test run with its failures and the flaky tests it executed
+	TestRun(ctx context.Context, testRunID uint) (*VCSTestRun, error)
+
+	// BaseRun loads the newest finished run of a project on a branch that
+	// started before the given run, or returns nil when there is none
+	BaseRun(ctx context.Context, projectID, branch string, before uint) (*VCSTestRun, error)
+
+	// DefaultBranch returns the default branch of a project, or an empty string when it has none
+	DefaultBranch(ctx context.Context, projectID string) (string, error)
+}
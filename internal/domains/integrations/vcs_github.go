@@ -0,0 +1,177 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// vcsCommentPageSize is how many comments are read per request when looking for a report comment
+	vcsCommentPageSize = 100
+
+	// vcsCommentMaxPages bounds how many pages of comments are searched for a report comment
+	vcsCommentMaxPages = 10
+)
+
+// gitHubVCSProvider reports to a GitHub repository through commit statuses or
+// check runs and issue comments on pull requests
+type gitHubVCSProvider struct {
+	api  *gitHubConnector
+	mode VCSStatusMode
+}
+
+// newGitHubVCSProvider creates a provider for a GitHub or GitHub Enterprise repository
+func newGitHubVCSProvider(settings VCSConnectionSettings, token string) *gitHubVCSProvider {
+	owner, repository, _ := strings.Cut(settings.Repository, "/")
+	return &gitHubVCSProvider{
+		api: &gitHubConnector{
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+			apiURL:     settings.APIURL,
+			owner:      owner,
+			repository: repository,
+			token:      token,
+		},
+		mode: settings.StatusMode,
+	}
+}
+
+// gitHubComment is the part of an issue comment the provider reads
+type gitHubComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+// TestConnection checks that the token can see the repository
+func (p *gitHubVCSProvider) TestConnection(ctx context.Context) error {
+	var repository struct {
+		FullName string `json:"full_name"`
+	}
+	return p.api.send(ctx, "GET", p.api.repositoryPath(""), "get repository", nil, &repository)
+}
+
+// SetCommitStatus sets a commit status, or creates or updates a check run
+func (p *gitHubVCSProvider) SetCommitStatus(ctx context.Context, commitSHA string, status VCSCommitStatus) error {
+	if p.mode == VCSStatusModeCheckRun {
+		return p.setCheckRun(ctx, commitSHA, status)
+	}
+
+	request := map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": shorten(status.Description, 140),
+	}
+	if status.TargetURL != "" {
+		request["target_url"] = status.TargetURL
+	}
+	return p.api.send(ctx, "POST", p.api.repositoryPath("/statuses/"+neturl.PathEscape(commitSHA)), "set commit status", request, nil)
+}
+
+// setCheckRun updates the check run created for the same test run on the
+// commit, or creates one when there is none
+func (p *gitHubVCSProvider) setCheckRun(ctx context.Context, commitSHA string, status VCSCommitStatus) error {
+	request := map[string]interface{}{
+		"name":        status.Context,
+		"external_id": status.ExternalID,
+		"output": map[string]string{
+			"title":   shorten(status.Description, 140),
+			"summary": shorten(status.Summary, 65000),
+		},
+	}
+	if status.TargetURL != "" {
+		request["details_url"] = status.TargetURL
+	}
+	if status.State == VCSCommitPending {
+		request["status"] = "in_progress"
+	} else {
+		request["status"] = "completed"
+		request["conclusion"] = string(status.State)
+	}
+
+	var existing struct {
+		CheckRuns []struct {
+			ID         int64  `json:"id"`
+			ExternalID string `json:"external_id"`
+		} `json:"check_runs"`
+	}
+	query := neturl.Values{"check_name": {status.Context}, "filter": {"latest"}}
+	path := p.api.repositoryPath("/commits/" + neturl.PathEscape(commitSHA) + "/check-runs?" + query.Encode())
+	if err := p.api.send(ctx, "GET", path, "list check runs", nil, &existing); err != nil {
+		return err
+	}
+	for _, run := range existing.CheckRuns {
+		if run.ExternalID == status.ExternalID {
+			return p.api.send(ctx, "PATCH", p.api.repositoryPath(fmt.Sprintf("/check-runs/%d", run.ID)), "update check run", request, nil)
+		}
+	}
+
+	request["head_sha"] = commitSHA
+	return p.api.send(ctx, "POST", p.api.repositoryPath("/check-runs"), "create check run", request, nil)
+}
+
+// FindPullRequests returns the open pull requests containing a commit
+func (p *gitHubVCSProvider) FindPullRequests(ctx context.Context, commitSHA string) ([]VCSPullRequest, error) {
+	var pulls []struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		State   string `json:"state"`
+		Base    struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+	if err := p.api.send(ctx, "GET", p.api.repositoryPath("/commits/"+neturl.PathEscape(commitSHA)+"/pulls"), "list pull requests", nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	var open []VCSPullRequest
+	for _, pull := range pulls {
+		if pull.State == "open" {
+			open = append(open, VCSPullRequest{Number: pull.Number, URL: pull.HTMLURL, BaseBranch: pull.Base.Ref})
+		}
+	}
+	return open, nil
+}
+
+// UpsertComment updates the pull request comment containing marker, or creates one
+func (p *gitHubVCSProvider) UpsertComment(ctx context.Context, pullRequest VCSPullRequest, marker, body string) (string, error) {
+	for page := 1; page <= vcsCommentMaxPages; page++ {
+		var comments []gitHubComment
+		path := p.api.repositoryPath(fmt.Sprintf("/issues/%d/comments?per_page=%d&page=%d", pullRequest.Number, vcsCommentPageSize, page))
+		if err := p.api.send(ctx, "GET", path, "list comments", nil, &comments); err != nil {
+			return "", err
+		}
+
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, marker) {
+				var updated gitHubComment
+				path := p.api.repositoryPath(fmt.Sprintf("/issues/comments/%d", comment.ID))
+				if err := p.api.send(ctx, "PATCH", path, "update comment", map[string]string{"body": body}, &updated); err != nil {
+					return "", err
+				}
+				return updated.HTMLURL, nil
+			}
+		}
+		if len(comments) < vcsCommentPageSize {
+			break
+		}
+	}
+
+	var created gitHubComment
+	path := p.api.repositoryPath(fmt.Sprintf("/issues/%d/comments", pullRequest.Number))
+	if err := p.api.send(ctx, "POST", path, "create comment", map[string]string{"body": body}, &created); err != nil {
+		return "", err
+	}
+	return created.HTMLURL, nil
+}
+
+// shorten cuts text to at most n bytes, ending it with "..." when it was cut
+func shorten(text string, n int) string {
+	if len(text) <= n {
+		return text
+	}
+	return truncate(text, n-3) + "..."
+}
@@ -0,0 +1,193 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+// gitLabVCSProvider reports to a GitLab project through commit statuses and
+// notes on merge requests
+type gitLabVCSProvider struct {
+	httpClient *http.Client
+	apiURL     string
+	project    string // Project path, such as group/subgroup/name, or numeric ID
+	token      string
+}
+
+// newGitLabVCSProvider creates a provider for a project on gitlab.com or a self-managed GitLab
+func newGitLabVCSProvider(settings VCSConnectionSettings, token string) *gitLabVCSProvider {
+	return &gitLabVCSProvider{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiURL:     settings.APIURL,
+		project:    settings.Repository,
+		token:      token,
+	}
+}
+
+// gitLabNote is the part of a merge request note the provider reads
+type gitLabNote struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// TestConnection checks that the token can see the project
+func (p *gitLabVCSProvider) TestConnection(ctx context.Context) error {
+	var project struct {
+		ID int64 `json:"id"`
+	}
+	return p.send(ctx, "GET", p.projectPath(""), "get project", nil, &project)
+}
+
+// SetCommitStatus sets the pipeline-independent status of a commit
+func (p *gitLabVCSProvider) SetCommitStatus(ctx context.Context, commitSHA string, status VCSCommitStatus) error {
+	state := "running"
+	switch status.State {
+	case VCSCommitSuccess:
+		state = "success"
+	case VCSCommitFailure:
+		state = "failed"
+	}
+
+	request := map[string]string{
+		"state":       state,
+		"name":        status.Context,
+		"description": shorten(status.Description, 255),
+	}
+	if status.TargetURL != "" {
+		request["target_url"] = status.TargetURL
+	}
+	err := p.send(ctx, "POST", p.projectPath("/statuses/"+neturl.PathEscape(commitSHA)), "set commit status", request, nil)
+	if err != nil && strings.Contains(err.Error(), "Cannot transition status") {
+		// GitLab rejects setting a status to the state it is already in,
+		// which happens when a report is retried
+		return nil
+	}
+	return err
+}
+
+// FindPullRequests returns the open merge requests containing a commit
+func (p *gitLabVCSProvider) FindPullRequests(ctx context.Context, commitSHA string) ([]VCSPullRequest, error) {
+	var mergeRequests []struct {
+		IID          int    `json:"iid"`
+		WebURL       string `json:"web_url"`
+		State        string `json:"state"`
+		TargetBranch string `json:"target_branch"`
+	}
+	path := p.projectPath("/repository/commits/" + neturl.PathEscape(commitSHA) + "/merge_requests")
+	if err := p.send(ctx, "GET", path, "list merge requests", nil, &mergeRequests); err != nil {
+		return nil, err
+	}
+
+	var open []VCSPullRequest
+	for _, mergeRequest := range mergeRequests {
+		if mergeRequest.State == "opened" {
+			open = append(open, VCSPullRequest{Number: mergeRequest.IID, URL: mergeRequest.WebURL, BaseBranch: mergeRequest.TargetBranch})
+		}
+	}
+	return open, nil
+}
+
+// UpsertComment updates the merge request note containing marker, or creates one
+func (p *gitLabVCSProvider) UpsertComment(ctx context.Context, pullRequest VCSPullRequest, marker, body string) (string, error) {
+	notesPath := p.projectPath(fmt.Sprintf("/merge_requests/%d/notes", pullRequest.Number))
+	for page := 1; page <= vcsCommentMaxPages; page++ {
+		var notes []gitLabNote
+		path := fmt.Sprintf("%s?sort=asc&per_page=%d&page=%d", notesPath, vcsCommentPageSize, page)
+		if err := p.send(ctx, "GET", path, "list notes", nil, &notes); err != nil {
+			return "", err
+		}
+
+		for _, note := range notes {
+			if strings.Contains(note.Body, marker) {
+				path := fmt.Sprintf("%s/%d", notesPath, note.ID)
+				if err := p.send(ctx, "PUT", path, "update note", map[string]string{"body": body}, nil); err != nil {
+					return "", err
+				}
+				return p.noteURL(pullRequest, note.ID), nil
+			}
+		}
+		if len(notes) < vcsCommentPageSize {
+			break
+		}
+	}
+
+	var created gitLabNote
+	if err := p.send(ctx, "POST", notesPath, "create note", map[string]string{"body": body}, &created); err != nil {
+		return "", err
+	}
+	return p.noteURL(pullRequest, created.ID), nil
+}
+
+// noteURL links to a note on the merge request page, since the API does not return one
+func (p *gitLabVCSProvider) noteURL(pullRequest VCSPullRequest, noteID int64) string {
+	if pullRequest.URL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s#note_%d", pullRequest.URL, noteID)
+}
+
+// projectPath returns the API URL of a resource of the project
+func (p *gitLabVCSProvider) projectPath(path string) string {
+	return fmt.Sprintf("%s/projects/%s%s", p.apiURL, neturl.PathEscape(p.project), path)
+}
+
+// send makes an authenticated request, encoding body when it is not nil and
+// decoding the JSON response into out when it is not nil
+func (p *gitLabVCSProvider) send(ctx context.Context, method, endpoint, action string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", p.token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Fern-Platform/1.0")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to GitLab: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// GitLab reports errors as a message string or object, or an error string
+		var errorBody struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err == nil {
+			if errorBody.Message != nil {
+				return fmt.Errorf("failed to %s: status %d, message: %v", action, resp.StatusCode, errorBody.Message)
+			}
+			if errorBody.Error != "" {
+				return fmt.Errorf("failed to %s: status %d, message: %s", action, resp.StatusCode, errorBody.Error)
+			}
+		}
+		return fmt.Errorf("failed to %s: status %d", action, resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", action, err)
+		}
+	}
+	return nil
+}
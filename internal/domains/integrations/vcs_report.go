@@ -0,0 +1,238 @@
+package integrations
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// vcsCommentListLimit caps the tests listed in each section of a report comment
+const vcsCommentListLimit = 25
+
+// VCSRunComparison is a test run compared with the latest finished run on
+// the branch it merges into
+type VCSRunComparison struct {
+	Run        *VCSTestRun
+	Base       *VCSTestRun // Nil when the base branch has no earlier finished run
+	BaseBranch string
+	// NewFailures failed in the run but not in the base run; without a base
+	// run every failure is listed here
+	NewFailures []VCSFailedTest
+	// KnownFailures failed in the base run too
+	KnownFailures []VCSFailedTest
+}
+
+// CompareVCSTestRun splits the failures of a run into new ones and ones that
+// already failed on the base branch
+func CompareVCSTestRun(run, base *VCSTestRun, baseBranch string) VCSRunComparison {
+	comparison := VCSRunComparison{Run: run, Base: base, BaseBranch: baseBranch}
+
+	failedOnBase := map[string]bool{}
+	if base != nil {
+		for _, failure := range base.Failures {
+			failedOnBase[failure.key()] = true
+		}
+	}
+	for _, failure := range run.Failures {
+		if failedOnBase[failure.key()] {
+			comparison.KnownFailures = append(comparison.KnownFailures, failure)
+		} else {
+			comparison.NewFailures = append(comparison.NewFailures, failure)
+		}
+	}
+	return comparison
+}
+
+// failed reports whether the run failed or has failed tests
+func (c VCSRunComparison) failed() bool {
+	return c.Run.Status == "failed" || c.Run.FailedTests > 0 || len(c.Run.Failures) > 0
+}
+
+// CommitState returns the state the run is reported with
+func (c VCSRunComparison) CommitState() VCSCommitState {
+	switch {
+	case !c.Run.Finished:
+		return VCSCommitPending
+	case c.failed():
+		return VCSCommitFailure
+	default:
+		return VCSCommitSuccess
+	}
+}
+
+// Description summarises the run in one line for a commit status
+func (c VCSRunComparison) Description() string {
+	run := c.Run
+	switch c.CommitState() {
+	case VCSCommitPending:
+		return "Tests are running"
+	case VCSCommitSuccess:
+		if run.SkippedTests > 0 {
+			return fmt.Sprintf("%d tests passed, %d skipped", run.PassedTests, run.SkippedTests)
+		}
+		return fmt.Sprintf("All %d tests passed", run.TotalTests)
+	}
+
+	if run.FailedTests == 0 {
+		return "Test run failed"
+	}
+	description := fmt.Sprintf("%d of %d tests failed", run.FailedTests, run.TotalTests)
+	if c.Base != nil {
+		description += fmt.Sprintf(", %d new vs %s", len(c.NewFailures), c.BaseBranch)
+	}
+	return description
+}
+
+// RenderVCSComment renders the Markdown summary of a finished run posted on
+// pull and merge requests. The marker, an HTML comment, lets later reports
+// find and update the comment instead of adding another.
+func RenderVCSComment(c VCSRunComparison, marker string) string {
+	run := c.Run
+	var b strings.Builder
+
+	if marker != "" {
+		b.WriteString(marker)
+		b.WriteString("\n")
+	}
+	if c.CommitState() == VCSCommitFailure {
+		fmt.Fprintf(&b, "### ❌ Fern test report: %s\n\n", c.Description())
+	} else {
+		fmt.Fprintf(&b, "### ✅ Fern test report: %s\n\n", c.Description())
+	}
+
+	details := []string{"Run " + markdownLink(inlineCode(runLabel(run)), run.URL)}
+	if run.CommitSHA != "" {
+		details = append(details, "commit "+inlineCode(shortCommit(run.CommitSHA)))
+	}
+	if run.Branch != "" {
+		details = append(details, "branch "+inlineCode(run.Branch))
+	}
+	if run.Duration > 0 {
+		details = append(details, formatNotificationDuration(run.Duration))
+	}
+	b.WriteString(strings.Join(details, " · "))
+	b.WriteString("\n\n")
+
+	b.WriteString("| Passed | Failed | Skipped | Total |\n|---:|---:|---:|---:|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d |\n", run.PassedTests, run.FailedTests, run.SkippedTests, run.TotalTests)
+
+	if c.Base != nil {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "#### New failures vs %s (%d)\n\n", inlineCode(c.BaseBranch), len(c.NewFailures))
+		if len(c.NewFailures) == 0 {
+			b.WriteString("No new failures.\n")
+		} else {
+			writeFailureList(&b, c.NewFailures)
+		}
+
+		if len(c.KnownFailures) > 0 {
+			fmt.Fprintf(&b, "\n<details><summary>Also failing on %s (%d)</summary>\n\n", html.EscapeString(c.BaseBranch), len(c.KnownFailures))
+			writeFailureList(&b, c.KnownFailures)
+			b.WriteString("\n</details>\n")
+		}
+	} else if len(c.NewFailures) > 0 {
+		fmt.Fprintf(&b, "\n#### Failures (%d)\n\n", len(c.NewFailures))
+		writeFailureList(&b, c.NewFailures)
+	}
+
+	if len(run.FlakyTests) > 0 {
+		flaky := append([]VCSFlakyTest(nil), run.FlakyTests...)
+		sort.SliceStable(flaky, func(i, j int) bool { return flaky[i].FlakeRate > flaky[j].FlakeRate })
+
+		fmt.Fprintf(&b, "\n#### Flaky tests hit (%d)\n\n", len(flaky))
+		b.WriteString("| Test | Result | Flake rate | Severity |\n|---|---|---:|---|\n")
+		for i, test := range flaky {
+			if i == vcsCommentListLimit {
+				fmt.Fprintf(&b, "\n…and %d more\n", len(flaky)-vcsCommentListLimit)
+				break
+			}
+			rate, severity := "new", "-"
+			if test.FlakeRate > 0 {
+				rate = fmt.Sprintf("%.0f%%", test.FlakeRate*100)
+			}
+			if test.Severity != "" {
+				severity = test.Severity
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+				markdownCell(markdownLink(inlineCode(testLabel(test.SuiteName, test.TestName)), test.URL)),
+				markdownCell(test.Result), rate, markdownCell(severity))
+		}
+	}
+
+	if c.Base != nil {
+		fmt.Fprintf(&b, "\n<sub>Compared with run %s on %s.</sub>\n", markdownLink(inlineCode(runLabel(c.Base)), c.Base.URL), inlineCode(c.BaseBranch))
+	} else if c.BaseBranch != "" {
+		fmt.Fprintf(&b, "\n<sub>No finished run on %s to compare with.</sub>\n", inlineCode(c.BaseBranch))
+	}
+	return b.String()
+}
+
+// VCSStatusSummary renders the Markdown summary shown on check runs, which is
+// the comment without its marker
+func VCSStatusSummary(c VCSRunComparison) string {
+	if c.CommitState() == VCSCommitPending {
+		return fmt.Sprintf("Run %s is in progress.", markdownLink(inlineCode(runLabel(c.Run)), c.Run.URL))
+	}
+	return RenderVCSComment(c, "")
+}
+
+// writeFailureList lists failed tests with the first line of their error
+func writeFailureList(b *strings.Builder, failures []VCSFailedTest) {
+	for i, failure := range failures {
+		if i == vcsCommentListLimit {
+			fmt.Fprintf(b, "- …and %d more\n", len(failures)-vcsCommentListLimit)
+			return
+		}
+		fmt.Fprintf(b, "- %s", inlineCode(testLabel(failure.SuiteName, failure.TestName)))
+		if message := firstLine(failure.ErrorMessage); message != "" {
+			fmt.Fprintf(b, ": %s", inlineCode(message))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// runLabel names a run by its name, falling back to its run ID
+func runLabel(run *VCSTestRun) string {
+	if run.Name != "" {
+		return run.Name
+	}
+	return run.RunID
+}
+
+// testLabel names a test within its suite
+func testLabel(suiteName, testName string) string {
+	if suiteName == "" {
+		return testName
+	}
+	return suiteName + " › " + testName
+}
+
+// inlineCode wraps text in a Markdown code span, which shows it literally
+func inlineCode(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\n", " ")
+	if text == "" {
+		return ""
+	}
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// markdownLink links text to a URL, or returns the text when there is no URL
+func markdownLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return "[" + text + "](" + url + ")"
+}
+
+// markdownCell makes text safe to place in a Markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\n", " "), "|", `\|`)
+}
@@ -0,0 +1,412 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// VCSReportMaxAttempts is how often a report is attempted before it fails
+	VCSReportMaxAttempts = 5
+
+	// VCSReportRetryBaseDelay is the delay before the first retry; it doubles with every further attempt
+	VCSReportRetryBaseDelay = time.Minute
+
+	// VCSReportRetryMaxDelay caps the delay between attempts
+	VCSReportRetryMaxDelay = 30 * time.Minute
+
+	// VCSReportTimeout bounds a single report attempt, which makes several requests
+	VCSReportTimeout = time.Minute
+
+	// VCSReportBatchSize is how many due reports one ReportDue call attempts
+	VCSReportBatchSize = 50
+
+	// VCSReportRetention is how long finished reports are kept in the log
+	VCSReportRetention = 30 * 24 * time.Hour
+
+	// vcsReportClaimLease is how long a claimed report is hidden from other workers
+	vcsReportClaimLease = 2 * VCSReportTimeout
+)
+
+// ErrVCSReportingDisabled is returned when reports are sent before a report source is set
+var ErrVCSReportingDisabled = errors.New("VCS reporting is not enabled")
+
+// VCSReportResult summarises a ReportDue run
+type VCSReportResult struct {
+	Attempted int
+	Sent      int
+	Retrying  int
+	Failed    int
+	Errors    []error
+}
+
+// VCSReportService manages VCS connections and reports the test runs of their
+// projects to GitHub and GitLab as commit statuses or check runs and as
+// summary comments on pull and merge requests, through a durable queue
+type VCSReportService struct {
+	connections VCSConnectionRepository
+	reports     VCSReportRepository
+	keys        *KeyRing
+	source      VCSReportSource
+	now         func() time.Time
+}
+
+// NewVCSReportService creates a new VCS report service. Access tokens are
+// encrypted with the key ring, like the credentials of issue tracker connections.
+func NewVCSReportService(connections VCSConnectionRepository, reports VCSReportRepository, keys *KeyRing) *VCSReportService {
+	return &VCSReportService{
+		connections: connections,
+		reports:     reports,
+		keys:        keys,
+		now:         time.Now,
+	}
+}
+
+// EnableReporting allows queued reports to be sent, loading their test runs from source
+func (s *VCSReportService) EnableReporting(source VCSReportSource) {
+	s.source = source
+}
+
+// SetClock replaces the clock reports are scheduled with
+func (s *VCSReportService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// CreateConnection creates a connection reporting with an access token
+func (s *VCSReportService) CreateConnection(ctx context.Context, projectID string, settings VCSConnectionSettings, token, createdBy string) (*VCSConnection, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidVCSConnection)
+	}
+	encryptedToken, err := s.keys.Encrypt(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt token: %w", err)
+	}
+
+	conn, err := NewVCSConnection(projectID, settings, encryptedToken, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.connections.Create(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save VCS connection: %w", err)
+	}
+	return conn, nil
+}
+
+// GetConnection retrieves a connection
+func (s *VCSReportService) GetConnection(ctx context.Context, connectionID string) (*VCSConnection, error) {
+	conn, err := s.connections.FindByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVCSConnectionNotFound, err)
+	}
+	return conn, nil
+}
+
+// ListConnections retrieves the connections of a project
+func (s *VCSReportService) ListConnections(ctx context.Context, projectID string) ([]*VCSConnection, error) {
+	return s.connections.FindByProjectID(ctx, projectID)
+}
+
+// UpdateConnection replaces the settings of a connection. An empty token keeps the current one.
+func (s *VCSReportService) UpdateConnection(ctx context.Context, connectionID string, settings VCSConnectionSettings, token string) (*VCSConnection, error) {
+	var encryptedToken string
+	if token != "" {
+		var err error
+		if encryptedToken, err = s.keys.Encrypt(token); err != nil {
+			return nil, fmt.Errorf("failed to encrypt token: %w", err)
+		}
+	}
+
+	return s.changeConnection(ctx, connectionID, func(conn *VCSConnection) error {
+		return conn.Update(settings, encryptedToken)
+	})
+}
+
+// TestConnection checks that the token can see the repository and records the outcome on the connection
+func (s *VCSReportService) TestConnection(ctx context.Context, connectionID string) (*VCSConnection, error) {
+	conn, err := s.GetConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, testErr := s.provider(conn)
+	if testErr == nil {
+		testErr = provider.TestConnection(ctx)
+	}
+	conn.RecordTest(testErr)
+
+	if err := s.connections.Update(ctx, conn); err != nil {
+		if testErr == nil {
+			return nil, fmt.Errorf("failed to save VCS connection: %w", err)
+		}
+		log.Printf("[VCSReportService] Failed to update connection %s after test failure: %v", connectionID, err)
+	}
+	if testErr != nil {
+		return conn, fmt.Errorf("%w: %v", ErrVCSConnectionFailed, testErr)
+	}
+	return conn, nil
+}
+
+// ActivateConnection resumes reporting, including the reports queued while the connection was inactive
+func (s *VCSReportService) ActivateConnection(ctx context.Context, connectionID string) (*VCSConnection, error) {
+	return s.changeConnection(ctx, connectionID, func(conn *VCSConnection) error {
+		conn.Activate()
+		return nil
+	})
+}
+
+// DeactivateConnection stops reporting
+func (s *VCSReportService) DeactivateConnection(ctx context.Context, connectionID string) (*VCSConnection, error) {
+	return s.changeConnection(ctx, connectionID, func(conn *VCSConnection) error {
+		conn.Deactivate()
+		return nil
+	})
+}
+
+// DeleteConnection removes a connection
+func (s *VCSReportService) DeleteConnection(ctx context.Context, connectionID string) error {
+	if _, err := s.GetConnection(ctx, connectionID); err != nil {
+		return err
+	}
+	return s.connections.Delete(ctx, connectionID)
+}
+
+// changeConnection loads a connection, applies a change and saves it
+func (s *VCSReportService) changeConnection(ctx context.Context, connectionID string, change func(*VCSConnection) error) (*VCSConnection, error) {
+	conn, err := s.GetConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(conn); err != nil {
+		return nil, err
+	}
+	if err := s.connections.Update(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to update VCS connection: %w", err)
+	}
+	return conn, nil
+}
+
+// provider decrypts the token of a connection and creates the provider of its host
+func (s *VCSReportService) provider(conn *VCSConnection) (VCSProvider, error) {
+	token, err := s.keys.Decrypt(conn.EncryptedToken())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token: %w", err)
+	}
+	return NewVCSProvider(conn.Settings(), token)
+}
+
+// QueueTestRun queues a report of a test run for every active connection of
+// its project whose repository it matches. Runs without a commit are not
+// reported. A report already pending for the same stage of the run is not
+// queued twice. It returns how many reports were queued.
+func (s *VCSReportService) QueueTestRun(ctx context.Context, ref VCSRunRef) (int, error) {
+	if ref.CommitSHA == "" {
+		return 0, nil
+	}
+
+	connections, err := s.connections.FindActiveByProjectID(ctx, ref.ProjectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find VCS connections: %w", err)
+	}
+
+	now := s.now()
+	queued := 0
+	var errs []error
+	for _, conn := range connections {
+		if !conn.MatchesRepository(ref.Repository) {
+			continue
+		}
+
+		pending, err := s.reports.HasPending(ctx, conn.ID(), ref.TestRunID, ref.Finished)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("connection %s: failed to find pending reports: %w", conn.ID(), err))
+			continue
+		}
+		if pending {
+			continue
+		}
+
+		report := &VCSReport{
+			ConnectionID:  conn.ID(),
+			ProjectID:     ref.ProjectID,
+			TestRunID:     ref.TestRunID,
+			RunID:         ref.RunID,
+			CommitSHA:     ref.CommitSHA,
+			Final:         ref.Finished,
+			Status:        VCSReportPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := s.reports.Create(ctx, report); err != nil {
+			errs = append(errs, fmt.Errorf("connection %s: failed to queue report: %w", conn.ID(), err))
+			continue
+		}
+		queued++
+	}
+	return queued, errors.Join(errs...)
+}
+
+// ListReports retrieves the report log of a connection, newest first
+func (s *VCSReportService) ListReports(ctx context.Context, connectionID string, limit int) ([]*VCSReport, error) {
+	return s.reports.FindByConnectionID(ctx, connectionID, limit)
+}
+
+// ReportDue sends the reports whose next attempt is due. Failed attempts are
+// retried with exponential backoff. Failures on individual reports are
+// collected in the result and do not stop the run.
+func (s *VCSReportService) ReportDue(ctx context.Context) (*VCSReportResult, error) {
+	if s.source == nil {
+		return nil, ErrVCSReportingDisabled
+	}
+
+	due, err := s.reports.FindDue(ctx, s.now(), VCSReportBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due VCS reports: %w", err)
+	}
+
+	result := &VCSReportResult{}
+	for _, report := range due {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Other instances report from the same queue
+		claimed, err := s.reports.Claim(ctx, report, s.now().Add(vcsReportClaimLease))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("report %s: failed to claim: %w", report.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.report(ctx, report, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("report %s: %w", report.ID, err))
+		}
+	}
+	return result, nil
+}
+
+// report makes one attempt at sending a report and records its outcome
+func (s *VCSReportService) report(ctx context.Context, report *VCSReport, result *VCSReportResult) error {
+	conn, err := s.connections.FindByID(ctx, report.ConnectionID)
+	if err != nil {
+		return fmt.Errorf("failed to load VCS connection: %w", err)
+	}
+
+	result.Attempted++
+	attemptedAt := s.now()
+	provider, attemptErr := s.provider(conn)
+	if attemptErr == nil {
+		attemptErr = s.send(ctx, conn, provider, report)
+	}
+	report.recordAttempt(attemptedAt, attemptErr, VCSReportMaxAttempts, VCSReportRetryDelay)
+	if err := s.reports.Update(ctx, report); err != nil {
+		return fmt.Errorf("failed to save report attempt: %w", err)
+	}
+
+	switch report.Status {
+	case VCSReportPending:
+		result.Retrying++
+	case VCSReportSent:
+		result.Sent++
+	case VCSReportFailed:
+		result.Failed++
+	}
+	return nil
+}
+
+// send reports a test run as it is now: a pending status while it runs, and
+// once it finished its result compared with the base branch, upserting the
+// summary comment on the open pull or merge requests of its commit
+func (s *VCSReportService) send(ctx context.Context, conn *VCSConnection, provider VCSProvider, report *VCSReport) error {
+	ctx, cancel := context.WithTimeout(ctx, VCSReportTimeout)
+	defer cancel()
+
+	run, err := s.source.TestRun(ctx, report.TestRunID)
+	if err != nil {
+		return fmt.Errorf("failed to load test run: %w", err)
+	}
+	settings := conn.Settings()
+
+	comparison := VCSRunComparison{Run: run}
+	var pullRequests []VCSPullRequest
+	if run.Finished {
+		if pullRequests, err = provider.FindPullRequests(ctx, report.CommitSHA); err != nil {
+			return err
+		}
+		if comparison, err = s.compare(ctx, run, pullRequests); err != nil {
+			return err
+		}
+	}
+
+	status := VCSCommitStatus{
+		State:       comparison.CommitState(),
+		Context:     settings.StatusContext,
+		Description: comparison.Description(),
+		TargetURL:   run.URL,
+		ExternalID:  run.RunID,
+		Summary:     VCSStatusSummary(comparison),
+	}
+	if err := provider.SetCommitStatus(ctx, report.CommitSHA, status); err != nil {
+		return err
+	}
+	report.State = status.State
+
+	if !run.Finished || !settings.CommentOnPullRequests {
+		return nil
+	}
+	marker := VCSCommentMarker(conn.ID())
+	body := RenderVCSComment(comparison, marker)
+	var commentURLs []string
+	for _, pullRequest := range pullRequests {
+		commentURL, err := provider.UpsertComment(ctx, pullRequest, marker, body)
+		if err != nil {
+			return fmt.Errorf("pull request %d: %w", pullRequest.Number, err)
+		}
+		commentURLs = append(commentURLs, commentURL)
+	}
+	report.CommentURLs = commentURLs
+	return nil
+}
+
+// compare compares a finished run with the latest earlier run on the branch
+// its first pull request merges into, or on the project's default branch
+// when its commit is in no open pull request
+func (s *VCSReportService) compare(ctx context.Context, run *VCSTestRun, pullRequests []VCSPullRequest) (VCSRunComparison, error) {
+	var baseBranch string
+	if len(pullRequests) > 0 {
+		baseBranch = pullRequests[0].BaseBranch
+	} else {
+		var err error
+		if baseBranch, err = s.source.DefaultBranch(ctx, run.ProjectID); err != nil {
+			return VCSRunComparison{}, fmt.Errorf("failed to get default branch: %w", err)
+		}
+	}
+	if baseBranch == "" {
+		return CompareVCSTestRun(run, nil, ""), nil
+	}
+
+	base, err := s.source.BaseRun(ctx, run.ProjectID, baseBranch, run.ID)
+	if err != nil {
+		return VCSRunComparison{}, fmt.Errorf("failed to load base run: %w", err)
+	}
+	return CompareVCSTestRun(run, base, baseBranch), nil
+}
+
+// PruneReports removes finished reports older than the retention period
+func (s *VCSReportService) PruneReports(ctx context.Context) (int64, error) {
+	return s.reports.DeleteFinishedBefore(ctx, s.now().Add(-VCSReportRetention))
+}
+
+// VCSCommentMarker returns the hidden marker identifying the comments of a connection
+func VCSCommentMarker(connectionID string) string {
+	return fmt.Sprintf("<!-- fern-platform:vcs-report:%s -->", connectionID)
+}
+
+// VCSReportRetryDelay returns how long to wait after a failed attempt before the next one
+func VCSReportRetryDelay(attempt int) time.Duration {
+	return retryDelay(attempt, VCSReportRetryBaseDelay, VCSReportRetryMaxDelay)
+}
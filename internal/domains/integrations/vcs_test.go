@@ -0,0 +1,765 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryVCSStore keeps VCS connections and reports in memory
+type memoryVCSStore struct {
+	mu          sync.Mutex
+	nextID      int
+	connections map[string]*integrations.VCSConnection
+	reports     map[string]*integrations.VCSReport
+}
+
+func newMemoryVCSStore() *memoryVCSStore {
+	return &memoryVCSStore{
+		connections: make(map[string]*integrations.VCSConnection),
+		reports:     make(map[string]*integrations.VCSReport),
+	}
+}
+
+func (s *memoryVCSStore) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// copyVCSConnection returns a detached copy, as a database round trip would
+func copyVCSConnection(conn *integrations.VCSConnection) *integrations.VCSConnection {
+	return integrations.ReconstructVCSConnection(conn.Snapshot(), conn.EncryptedToken())
+}
+
+// sortedReports returns copies of the reports accepted by keep, oldest first
+func (s *memoryVCSStore) sortedReports(keep func(*integrations.VCSReport) bool) []*integrations.VCSReport {
+	var reports []*integrations.VCSReport
+	for _, report := range s.reports {
+		if keep(report) {
+			found := *report
+			reports = append(reports, &found)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		a, _ := strconv.Atoi(reports[i].ID)
+		b, _ := strconv.Atoi(reports[j].ID)
+		return a < b
+	})
+	return reports
+}
+
+// memoryVCSConnectionRepo is the VCSConnectionRepository view of the store
+type memoryVCSConnectionRepo struct {
+	*memoryVCSStore
+}
+
+func (r memoryVCSConnectionRepo) Create(ctx context.Context, conn *integrations.VCSConnection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn.AssignID(r.newID())
+	r.connections[conn.ID()] = copyVCSConnection(conn)
+	return nil
+}
+
+func (r memoryVCSConnectionRepo) Update(ctx context.Context, conn *integrations.VCSConnection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.connections[conn.ID()] = copyVCSConnection(conn)
+	return nil
+}
+
+func (r memoryVCSConnectionRepo) Delete(ctx context.Context, connectionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.connections, connectionID)
+	return nil
+}
+
+func (r memoryVCSConnectionRepo) FindByID(ctx context.Context, connectionID string) (*integrations.VCSConnection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn, ok := r.connections[connectionID]
+	if !ok {
+		return nil, errors.New("VCS connection not found")
+	}
+	return copyVCSConnection(conn), nil
+}
+
+func (r memoryVCSConnectionRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.VCSConnection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var connections []*integrations.VCSConnection
+	for _, conn := range r.connections {
+		if conn.ProjectID() == projectID {
+			connections = append(connections, copyVCSConnection(conn))
+		}
+	}
+	return connections, nil
+}
+
+func (r memoryVCSConnectionRepo) FindActiveByProjectID(ctx context.Context, projectID string) ([]*integrations.VCSConnection, error) {
+	connections, err := r.FindByProjectID(ctx, projectID)
+	var active []*integrations.VCSConnection
+	for _, conn := range connections {
+		if conn.IsActive() {
+			active = append(active, conn)
+		}
+	}
+	return active, err
+}
+
+// memoryVCSReportRepo is the VCSReportRepository view of the store
+type memoryVCSReportRepo struct {
+	*memoryVCSStore
+}
+
+func (r memoryVCSReportRepo) Create(ctx context.Context, report *integrations.VCSReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report.ID = r.newID()
+	stored := *report
+	r.reports[report.ID] = &stored
+	return nil
+}
+
+func (r memoryVCSReportRepo) Update(ctx context.Context, report *integrations.VCSReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *report
+	r.reports[report.ID] = &stored
+	return nil
+}
+
+func (r memoryVCSReportRepo) FindByConnectionID(ctx context.Context, connectionID string, limit int) ([]*integrations.VCSReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reports := r.sortedReports(func(report *integrations.VCSReport) bool { return report.ConnectionID == connectionID })
+	// Newest first
+	for i, j := 0, len(reports)-1; i < j; i, j = i+1, j-1 {
+		reports[i], reports[j] = reports[j], reports[i]
+	}
+	if limit > 0 && len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
+
+func (r memoryVCSReportRepo) HasPending(ctx context.Context, connectionID string, testRunID uint, final bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := r.sortedReports(func(report *integrations.VCSReport) bool {
+		return report.ConnectionID == connectionID && report.TestRunID == testRunID &&
+			report.Final == final && report.Status == integrations.VCSReportPending
+	})
+	return len(pending) > 0, nil
+}
+
+func (r memoryVCSReportRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.VCSReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reports := r.sortedReports(func(report *integrations.VCSReport) bool {
+		conn, ok := r.connections[report.ConnectionID]
+		return ok && conn.IsActive() && report.Status == integrations.VCSReportPending && !report.NextAttemptAt.After(now)
+	})
+	if len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
+
+func (r memoryVCSReportRepo) Claim(ctx context.Context, report *integrations.VCSReport, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.reports[report.ID]
+	if !ok || stored.Status != integrations.VCSReportPending || stored.NextAttemptAt.After(report.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (r memoryVCSReportRepo) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var removed int64
+	for id, report := range r.reports {
+		if report.Status != integrations.VCSReportPending && report.CreatedAt.Before(before) {
+			delete(r.reports, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// makeDue moves the next attempt of every pending report to before now
+func (s *memoryVCSStore) makeDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, report := range s.reports {
+		if report.Status == integrations.VCSReportPending {
+			report.NextAttemptAt = now.Add(-time.Second)
+		}
+	}
+}
+
+// fakeVCSReportSource serves test runs from memory
+type fakeVCSReportSource struct {
+	runs          map[uint]*integrations.VCSTestRun
+	defaultBranch string
+}
+
+func (s *fakeVCSReportSource) TestRun(ctx context.Context, testRunID uint) (*integrations.VCSTestRun, error) {
+	run, ok := s.runs[testRunID]
+	if !ok {
+		return nil, fmt.Errorf("test run %d not found", testRunID)
+	}
+	return run, nil
+}
+
+func (s *fakeVCSReportSource) BaseRun(ctx context.Context, projectID, branch string, before uint) (*integrations.VCSTestRun, error) {
+	var base *integrations.VCSTestRun
+	for id, run := range s.runs {
+		if run.ProjectID == projectID && run.Branch == branch && run.Finished && id < before && (base == nil || id > base.ID) {
+			base = run
+		}
+	}
+	return base, nil
+}
+
+func (s *fakeVCSReportSource) DefaultBranch(ctx context.Context, projectID string) (string, error) {
+	return s.defaultBranch, nil
+}
+
+// gitHubStandIn records the statuses, check runs and comments posted to a
+// GitHub repository. A commit is in pull request 7, which merges into main.
+type gitHubStandIn struct {
+	*httptest.Server
+	mu        sync.Mutex
+	failing   bool
+	tokens    []string
+	statuses  []map[string]string
+	checkRuns map[int64]map[string]interface{}
+	comments  map[int64]string
+	created   int
+	updated   int
+}
+
+func newGitHubStandIn(t *testing.T) *gitHubStandIn {
+	g := &gitHubStandIn{checkRuns: map[int64]map[string]interface{}{}, comments: map[int64]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/shop", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"full_name": "acme/shop"})
+	})
+	mux.HandleFunc("POST /repos/acme/shop/statuses/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		var status map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&status)
+		status["sha"] = r.PathValue("sha")
+		g.statuses = append(g.statuses, status)
+		writeJSON(w, map[string]int{"id": len(g.statuses)})
+	})
+	mux.HandleFunc("GET /repos/acme/shop/commits/{sha}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		var runs []map[string]interface{}
+		for id, run := range g.checkRuns {
+			runs = append(runs, map[string]interface{}{"id": id, "external_id": run["external_id"]})
+		}
+		writeJSON(w, map[string]interface{}{"check_runs": runs})
+	})
+	mux.HandleFunc("POST /repos/acme/shop/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		var run map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&run)
+		id := int64(len(g.checkRuns) + 1)
+		g.checkRuns[id] = run
+		writeJSON(w, map[string]int64{"id": id})
+	})
+	mux.HandleFunc("PATCH /repos/acme/shop/check-runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		var run map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&run)
+		g.checkRuns[id] = run
+		writeJSON(w, map[string]int64{"id": id})
+	})
+	mux.HandleFunc("GET /repos/acme/shop/commits/{sha}/pulls", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"number": 7, "html_url": "https://github.example/acme/shop/pull/7", "state": "open", "base": map[string]string{"ref": "main"}},
+			{"number": 3, "html_url": "https://github.example/acme/shop/pull/3", "state": "closed", "base": map[string]string{"ref": "main"}},
+		})
+	})
+	mux.HandleFunc("GET /repos/acme/shop/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		comments := []gitHubTestComment{{ID: 1, Body: "Looks good to me"}}
+		for id, body := range g.comments {
+			comments = append(comments, gitHubTestComment{ID: id, Body: body})
+		}
+		writeJSON(w, comments)
+	})
+	mux.HandleFunc("POST /repos/acme/shop/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment gitHubTestComment
+		_ = json.NewDecoder(r.Body).Decode(&comment)
+		g.created++
+		id := int64(100 + g.created)
+		g.comments[id] = comment.Body
+		writeJSON(w, gitHubTestComment{ID: id, HTMLURL: fmt.Sprintf("https://github.example/acme/shop/pull/7#issuecomment-%d", id)})
+	})
+	mux.HandleFunc("PATCH /repos/acme/shop/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		var comment gitHubTestComment
+		_ = json.NewDecoder(r.Body).Decode(&comment)
+		g.updated++
+		g.comments[id] = comment.Body
+		writeJSON(w, gitHubTestComment{ID: id, HTMLURL: fmt.Sprintf("https://github.example/acme/shop/pull/7#issuecomment-%d", id)})
+	})
+
+	g.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.tokens = append(g.tokens, r.Header.Get("Authorization"))
+		if g.failing {
+			w.WriteHeader(http.StatusBadGateway)
+			writeJSON(w, map[string]string{"message": "Server Error"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(g.Close)
+	return g
+}
+
+// gitHubTestComment is an issue comment as the GitHub stand-in serves it
+type gitHubTestComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+func (g *gitHubStandIn) setFailing(failing bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failing = failing
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTestVCSReportService(now time.Time, source integrations.VCSReportSource) (*integrations.VCSReportService, *memoryVCSStore, *testClock) {
+	store := newMemoryVCSStore()
+	clock := &testClock{now: now}
+	service := integrations.NewVCSReportService(memoryVCSConnectionRepo{store}, memoryVCSReportRepo{store}, testKeyRing)
+	service.SetClock(clock.Now)
+	if source != nil {
+		service.EnableReporting(source)
+	}
+	return service, store, clock
+}
+
+func gitHubSettings(apiURL string) integrations.VCSConnectionSettings {
+	return integrations.VCSConnectionSettings{
+		Name:                  "GitHub",
+		Provider:              integrations.VCSProviderGitHub,
+		APIURL:                apiURL,
+		Repository:            "acme/shop",
+		CommentOnPullRequests: true,
+	}
+}
+
+// pullRequestRuns returns a finished run on main and a run of a feature
+// branch commit, which fails one test that also fails on main and one that
+// does not, and retries a known flaky test
+func pullRequestRuns() *fakeVCSReportSource {
+	return &fakeVCSReportSource{
+		defaultBranch: "main",
+		runs: map[uint]*integrations.VCSTestRun{
+			10: {
+				ID: 10, RunID: "main-10", ProjectID: "project-1", Status: "failed", Branch: "main", Finished: true,
+				CommitSHA: "1111111111", TotalTests: 3, PassedTests: 2, FailedTests: 1,
+				URL:      "https://fern.example/test-runs/main-10",
+				Failures: []integrations.VCSFailedTest{{SuiteName: "Checkout", TestName: "applies coupons", ErrorMessage: "expected 90"}},
+			},
+			11: {
+				ID: 11, RunID: "pr-11", ProjectID: "project-1", Name: "Shop CI", Status: "failed", Branch: "feature/cart", Finished: true,
+				CommitSHA: "abcdef1234567890", TotalTests: 3, PassedTests: 1, FailedTests: 2, Duration: 95 * time.Second,
+				URL: "https://fern.example/test-runs/pr-11",
+				Failures: []integrations.VCSFailedTest{
+					{SuiteName: "Checkout", TestName: "applies coupons", ErrorMessage: "expected 90"},
+					{SuiteName: "Cart", TestName: "removes items", ErrorMessage: "item still present\nat cart.go:12"},
+				},
+				FlakyTests: []integrations.VCSFlakyTest{
+					{SuiteName: "Search", TestName: "finds products", Result: "passed after retry", FlakeRate: 0.4, Severity: "high", URL: "https://fern.example/flaky-tests/5"},
+				},
+			},
+		},
+	}
+}
+
+func TestVCSConnection_RejectsInvalidSettings(t *testing.T) {
+	valid := gitHubSettings("")
+
+	tests := []struct {
+		name   string
+		change func(*integrations.VCSConnectionSettings)
+	}{
+		{"missing name", func(s *integrations.VCSConnectionSettings) { s.Name = "" }},
+		{"unknown provider", func(s *integrations.VCSConnectionSettings) { s.Provider = "bitbucket" }},
+		{"GitHub repository without owner", func(s *integrations.VCSConnectionSettings) { s.Repository = "shop" }},
+		{"relative API URL", func(s *integrations.VCSConnectionSettings) { s.APIURL = "api.github.com" }},
+		{"unknown status mode", func(s *integrations.VCSConnectionSettings) { s.StatusMode = "label" }},
+		{"GitLab check runs", func(s *integrations.VCSConnectionSettings) {
+			s.Provider = integrations.VCSProviderGitLab
+			s.StatusMode = integrations.VCSStatusModeCheckRun
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := valid
+			tt.change(&settings)
+			_, err := integrations.NewVCSConnection("project-1", settings, "token", "user-1")
+			assert.ErrorIs(t, err, integrations.ErrInvalidVCSConnection)
+		})
+	}
+
+	conn, err := integrations.NewVCSConnection("project-1", valid, "token", "user-1")
+	require.NoError(t, err)
+	settings := conn.Settings()
+	assert.Equal(t, "https://api.github.com", settings.APIURL)
+	assert.Equal(t, integrations.DefaultVCSStatusContext, settings.StatusContext)
+	assert.Equal(t, integrations.VCSStatusModeCommitStatus, settings.StatusMode)
+}
+
+func TestVCSConnection_MatchesRepository(t *testing.T) {
+	conn, err := integrations.NewVCSConnection("project-1", gitHubSettings(""), "token", "user-1")
+	require.NoError(t, err)
+
+	for _, repository := range []string{
+		"",
+		"acme/shop",
+		"Acme/Shop",
+		"https://github.com/acme/shop",
+		"https://github.com/acme/shop.git",
+		"git@github.com:acme/shop.git",
+	} {
+		assert.True(t, conn.MatchesRepository(repository), repository)
+	}
+	for _, repository := range []string{"acme/shop-admin", "https://github.com/other/shop"} {
+		assert.False(t, conn.MatchesRepository(repository), repository)
+	}
+}
+
+func TestRenderVCSComment(t *testing.T) {
+	source := pullRequestRuns()
+	comparison := integrations.CompareVCSTestRun(source.runs[11], source.runs[10], "main")
+
+	assert.Equal(t, integrations.VCSCommitFailure, comparison.CommitState())
+	assert.Equal(t, "2 of 3 tests failed, 1 new vs main", comparison.Description())
+	require.Len(t, comparison.NewFailures, 1)
+	assert.Equal(t, "removes items", comparison.NewFailures[0].TestName)
+
+	comment := integrations.RenderVCSComment(comparison, "<!-- marker -->")
+	assert.True(t, strings.HasPrefix(comment, "<!-- marker -->\n### ❌ Fern test report: 2 of 3 tests failed, 1 new vs main"))
+	assert.Contains(t, comment, "Run [`Shop CI`](https://fern.example/test-runs/pr-11) · commit `abcdef1`")
+	assert.Contains(t, comment, "#### New failures vs `main` (1)\n\n- `Cart › removes items`: `item still present`\n")
+	assert.Contains(t, comment, "<details><summary>Also failing on main (1)</summary>\n\n- `Checkout › applies coupons`: `expected 90`\n")
+	assert.Contains(t, comment, "#### Flaky tests hit (1)")
+	assert.Contains(t, comment, "| [`Search › finds products`](https://fern.example/flaky-tests/5) | passed after retry | 40% | high |")
+	assert.Contains(t, comment, "Compared with run [`main-10`](https://fern.example/test-runs/main-10) on `main`.")
+
+	passed := &integrations.VCSTestRun{RunID: "ok", Finished: true, Status: "passed", TotalTests: 4, PassedTests: 4}
+	comparison = integrations.CompareVCSTestRun(passed, nil, "main")
+	assert.Equal(t, integrations.VCSCommitSuccess, comparison.CommitState())
+	assert.Equal(t, "All 4 tests passed", comparison.Description())
+	assert.Contains(t, integrations.RenderVCSComment(comparison, ""), "No finished run on `main` to compare with.")
+}
+
+func TestVCSReportService_StoresTokenEncrypted(t *testing.T) {
+	service, _, _ := newTestVCSReportService(time.Now(), nil)
+	ctx := context.Background()
+
+	conn, err := service.CreateConnection(ctx, "project-1", gitHubSettings(""), "ghp_secret", "user-1")
+	require.NoError(t, err)
+	assert.NotContains(t, conn.EncryptedToken(), "ghp_secret")
+	token, err := testKeyRing.Decrypt(conn.EncryptedToken())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_secret", token)
+
+	// An update without a token keeps the current one
+	settings := gitHubSettings("")
+	settings.Name = "Renamed"
+	updated, err := service.UpdateConnection(ctx, conn.ID(), settings, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Settings().Name)
+	assert.Equal(t, conn.EncryptedToken(), updated.EncryptedToken())
+
+	_, err = service.CreateConnection(ctx, "project-1", gitHubSettings(""), "", "user-1")
+	assert.ErrorIs(t, err, integrations.ErrInvalidVCSConnection)
+	_, err = service.GetConnection(ctx, "missing")
+	assert.ErrorIs(t, err, integrations.ErrVCSConnectionNotFound)
+}
+
+func TestVCSReportService_TestConnection(t *testing.T) {
+	github := newGitHubStandIn(t)
+	service, _, _ := newTestVCSReportService(time.Now(), nil)
+	ctx := context.Background()
+
+	conn, err := service.CreateConnection(ctx, "project-1", gitHubSettings(github.URL), "ghp_secret", "user-1")
+	require.NoError(t, err)
+
+	tested, err := service.TestConnection(ctx, conn.ID())
+	require.NoError(t, err)
+	assert.Equal(t, integrations.ConnectionStatusConnected, tested.Snapshot().Status)
+	assert.Equal(t, "Bearer ghp_secret", github.tokens[0])
+
+	github.setFailing(true)
+	tested, err = service.TestConnection(ctx, conn.ID())
+	assert.ErrorIs(t, err, integrations.ErrVCSConnectionFailed)
+	assert.Equal(t, integrations.ConnectionStatusFailed, tested.Snapshot().Status)
+}
+
+func TestVCSReportService_ReportsPullRequestRuns(t *testing.T) {
+	github := newGitHubStandIn(t)
+	source := pullRequestRuns()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	service, _, _ := newTestVCSReportService(now, source)
+	ctx := context.Background()
+
+	conn, err := service.CreateConnection(ctx, "project-1", gitHubSettings(github.URL), "ghp_secret", "user-1")
+	require.NoError(t, err)
+	_, err = service.CreateConnection(ctx, "project-1", integrations.VCSConnectionSettings{
+		Name: "Other", Provider: integrations.VCSProviderGitHub, APIURL: github.URL, Repository: "acme/admin",
+	}, "ghp_other", "user-1")
+	require.NoError(t, err)
+
+	// The run starts: only the connection of its repository gets a pending status
+	run := source.runs[11]
+	run.Finished = false
+	ref := integrations.VCSRunRef{
+		ProjectID: "project-1", TestRunID: 11, RunID: "pr-11", CommitSHA: run.CommitSHA,
+		Repository: "https://github.com/acme/shop.git",
+	}
+	queued, err := service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	queued, err = service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	assert.Zero(t, queued, "a pending report is not queued twice")
+
+	result, err := service.ReportDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sent)
+	require.Len(t, github.statuses, 1)
+	assert.Equal(t, map[string]string{
+		"sha": "abcdef1234567890", "state": "pending", "context": integrations.DefaultVCSStatusContext,
+		"description": "Tests are running", "target_url": "https://fern.example/test-runs/pr-11",
+	}, github.statuses[0])
+	assert.Empty(t, github.comments, "running runs are not commented on")
+
+	// The run completes: a failure status and a comment on the open pull request
+	run.Finished = true
+	ref.Finished = true
+	_, err = service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	result, err = service.ReportDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sent)
+	require.Len(t, github.statuses, 2)
+	assert.Equal(t, "failure", github.statuses[1]["state"])
+	assert.Equal(t, "2 of 3 tests failed, 1 new vs main", github.statuses[1]["description"])
+	require.Len(t, github.comments, 1)
+	assert.Equal(t, 1, github.created)
+	comment := github.comments[101]
+	assert.True(t, strings.HasPrefix(comment, integrations.VCSCommentMarker(conn.ID())))
+	assert.Contains(t, comment, "Cart › removes items")
+
+	reports, err := service.ListReports(ctx, conn.ID(), 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, integrations.VCSReportSent, reports[0].Status)
+	assert.Equal(t, integrations.VCSCommitFailure, reports[0].State)
+	assert.Equal(t, []string{"https://github.example/acme/shop/pull/7#issuecomment-101"}, reports[0].CommentURLs)
+
+	// A rerun of the commit updates the comment instead of adding another
+	run.Failures = run.Failures[1:]
+	run.FailedTests = 1
+	run.PassedTests = 2
+	_, err = service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	_, err = service.ReportDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, github.created)
+	assert.Equal(t, 1, github.updated)
+	assert.Contains(t, github.comments[101], "1 of 3 tests failed, 1 new vs main")
+}
+
+func TestVCSReportService_UpdatesCheckRun(t *testing.T) {
+	github := newGitHubStandIn(t)
+	source := pullRequestRuns()
+	service, _, _ := newTestVCSReportService(time.Now(), source)
+	ctx := context.Background()
+
+	settings := gitHubSettings(github.URL)
+	settings.StatusMode = integrations.VCSStatusModeCheckRun
+	settings.CommentOnPullRequests = false
+	_, err := service.CreateConnection(ctx, "project-1", settings, "ghp_secret", "user-1")
+	require.NoError(t, err)
+
+	run := source.runs[11]
+	ref := integrations.VCSRunRef{ProjectID: "project-1", TestRunID: 11, RunID: "pr-11", CommitSHA: run.CommitSHA}
+	run.Finished = false
+	_, err = service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	_, err = service.ReportDue(ctx)
+	require.NoError(t, err)
+
+	run.Finished = true
+	ref.Finished = true
+	_, err = service.QueueTestRun(ctx, ref)
+	require.NoError(t, err)
+	_, err = service.ReportDue(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, github.checkRuns, 1, "the check run of the test run is updated")
+	checkRun := github.checkRuns[1]
+	assert.Equal(t, "pr-11", checkRun["external_id"])
+	assert.Equal(t, "completed", checkRun["status"])
+	assert.Equal(t, "failure", checkRun["conclusion"])
+	output := checkRun["output"].(map[string]interface{})
+	assert.Contains(t, output["summary"], "#### New failures vs `main` (1)")
+	assert.Empty(t, github.statuses)
+	assert.Empty(t, github.comments)
+}
+
+func TestVCSReportService_RetriesFailedReports(t *testing.T) {
+	github := newGitHubStandIn(t)
+	source := pullRequestRuns()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	service, store, clock := newTestVCSReportService(now, source)
+	ctx := context.Background()
+
+	conn, err := service.CreateConnection(ctx, "project-1", gitHubSettings(github.URL), "ghp_secret", "user-1")
+	require.NoError(t, err)
+	_, err = service.QueueTestRun(ctx, integrations.VCSRunRef{
+		ProjectID: "project-1", TestRunID: 11, RunID: "pr-11", CommitSHA: "abcdef1234567890", Finished: true,
+	})
+	require.NoError(t, err)
+
+	github.setFailing(true)
+	result, err := service.ReportDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+
+	reports, err := service.ListReports(ctx, conn.ID(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reports[0].Attempts)
+	assert.Equal(t, now.Add(integrations.VCSReportRetryDelay(1)), reports[0].NextAttemptAt)
+	assert.Contains(t, reports[0].LastError, "status 502")
+
+	// Not due yet
+	result, err = service.ReportDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	for attempt := 2; attempt <= integrations.VCSReportMaxAttempts; attempt++ {
+		store.makeDue(clock.Now())
+		_, err = service.ReportDue(ctx)
+		require.NoError(t, err)
+	}
+	reports, err = service.ListReports(ctx, conn.ID(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.VCSReportFailed, reports[0].Status)
+	assert.Equal(t, integrations.VCSReportMaxAttempts, reports[0].Attempts)
+
+	// Finished reports are pruned after the retention period
+	clock.Set(now.Add(integrations.VCSReportRetention + time.Hour))
+	removed, err := service.PruneReports(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+}
+
+func TestVCSReportService_ReportsToGitLab(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		tokens   []string
+		statuses []map[string]string
+		notes    = map[int64]string{}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v4/projects/{project}/statuses/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		var status map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&status)
+		status["project"] = r.PathValue("project")
+		statuses = append(statuses, status)
+		writeJSON(w, map[string]int{"id": len(statuses)})
+	})
+	mux.HandleFunc("GET /api/v4/projects/{project}/repository/commits/{sha}/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"iid": 12, "web_url": "https://gitlab.example/acme/web/shop/-/merge_requests/12", "state": "opened", "target_branch": "main"},
+		})
+	})
+	mux.HandleFunc("GET /api/v4/projects/{project}/merge_requests/12/notes", func(w http.ResponseWriter, r *http.Request) {
+		var list []map[string]interface{}
+		for id, body := range notes {
+			list = append(list, map[string]interface{}{"id": id, "body": body})
+		}
+		writeJSON(w, list)
+	})
+	mux.HandleFunc("POST /api/v4/projects/{project}/merge_requests/12/notes", func(w http.ResponseWriter, r *http.Request) {
+		var note map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&note)
+		id := int64(500 + len(notes))
+		notes[id] = note["body"]
+		writeJSON(w, map[string]interface{}{"id": id, "body": note["body"]})
+	})
+	gitlab := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		tokens = append(tokens, r.Header.Get("PRIVATE-TOKEN"))
+		mux.ServeHTTP(w, r)
+	}))
+	defer gitlab.Close()
+
+	source := pullRequestRuns()
+	service, _, _ := newTestVCSReportService(time.Now(), source)
+	ctx := context.Background()
+
+	conn, err := service.CreateConnection(ctx, "project-1", integrations.VCSConnectionSettings{
+		Name:                  "GitLab",
+		Provider:              integrations.VCSProviderGitLab,
+		APIURL:                gitlab.URL + "/api/v4",
+		Repository:            "acme/web/shop",
+		CommentOnPullRequests: true,
+	}, "glpat-secret", "user-1")
+	require.NoError(t, err)
+	_, err = service.QueueTestRun(ctx, integrations.VCSRunRef{
+		ProjectID: "project-1", TestRunID: 11, RunID: "pr-11", CommitSHA: "abcdef1234567890",
+		Repository: "git@gitlab.example:acme/web/shop.git", Finished: true,
+	})
+	require.NoError(t, err)
+
+	result, err := service.ReportDue(ctx)
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	assert.Equal(t, 1, result.Sent)
+
+	assert.Equal(t, "glpat-secret", tokens[0])
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "acme/web/shop", statuses[0]["project"])
+	assert.Equal(t, "failed", statuses[0]["state"])
+	assert.Equal(t, integrations.DefaultVCSStatusContext, statuses[0]["name"])
+	require.Len(t, notes, 1)
+	assert.Contains(t, notes[500], integrations.VCSCommentMarker(conn.ID()))
+
+	reports, err := service.ListReports(ctx, conn.ID(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://gitlab.example/acme/web/shop/-/merge_requests/12#note_500"}, reports[0].CommentURLs)
+}
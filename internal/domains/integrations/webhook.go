@@ -0,0 +1,334 @@
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// WebhookEventType identifies what happened in a webhook event
+type WebhookEventType string
+
+const (
+	WebhookEventTestRunCreated   WebhookEventType = "test_run.created"
+	WebhookEventTestRunCompleted WebhookEventType = "test_run.completed"
+	WebhookEventTestRunFailed    WebhookEventType = "test_run.failed"
+	WebhookEventFlakyDetected    WebhookEventType = "flaky_test.detected"
+	WebhookEventFlakyResolved    WebhookEventType = "flaky_test.resolved"
+)
+
+// WebhookEventTypes lists every event type subscriptions can subscribe to
+func WebhookEventTypes() []WebhookEventType {
+	return []WebhookEventType{
+		WebhookEventTestRunCreated,
+		WebhookEventTestRunCompleted,
+		WebhookEventTestRunFailed,
+		WebhookEventFlakyDetected,
+		WebhookEventFlakyResolved,
+	}
+}
+
+// ErrInvalidWebhook is returned when a subscription's settings are invalid
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// WebhookEvent is something that happened in a project that subscribers are notified of
+type WebhookEvent struct {
+	ID         string
+	Type       WebhookEventType
+	ProjectID  string
+	OccurredAt time.Time
+	Data       map[string]interface{}
+}
+
+// WebhookSubscription delivers events of the subscribed types to an endpoint.
+// Subscriptions without a project receive the events of every project.
+type WebhookSubscription struct {
+	id                  string
+	projectID           string
+	name                string
+	url                 string
+	events              []WebhookEventType
+	encryptedSecret     string
+	isActive            bool
+	disabledReason      string
+	consecutiveFailures int
+	lastDeliveryAt      *time.Time
+	createdBy           string
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+// NewWebhookSubscription creates an active subscription. An empty project ID
+// subscribes to the events of every project. The signing secret must already
+// be encrypted.
+func NewWebhookSubscription(projectID, name, endpointURL string, events []WebhookEventType, encryptedSecret, createdBy string) (*WebhookSubscription, error) {
+	if err := validateWebhook(name, endpointURL, events); err != nil {
+		return nil, err
+	}
+	if encryptedSecret == "" {
+		return nil, errors.New("signing secret is required")
+	}
+
+	now := time.Now()
+	return &WebhookSubscription{
+		projectID:       projectID,
+		name:            name,
+		url:             endpointURL,
+		events:          normalizeWebhookEvents(events),
+		encryptedSecret: encryptedSecret,
+		isActive:        true,
+		createdBy:       createdBy,
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+// validateWebhook checks the user-provided settings of a subscription
+func validateWebhook(name, endpointURL string, events []WebhookEventType) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: URL must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	for _, event := range events {
+		if !isWebhookEventType(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+	}
+	return nil
+}
+
+// isWebhookEventType returns whether subscriptions can subscribe to the event type
+func isWebhookEventType(eventType WebhookEventType) bool {
+	for _, known := range WebhookEventTypes() {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeWebhookEvents sorts and deduplicates event types
+func normalizeWebhookEvents(events []WebhookEventType) []WebhookEventType {
+	seen := make(map[WebhookEventType]bool, len(events))
+	normalized := make([]WebhookEventType, 0, len(events))
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i] < normalized[j] })
+	return normalized
+}
+
+// ID returns the subscription ID
+func (w *WebhookSubscription) ID() string {
+	return w.id
+}
+
+// ProjectID returns the project the subscription belongs to, or "" for global subscriptions
+func (w *WebhookSubscription) ProjectID() string {
+	return w.projectID
+}
+
+// IsGlobal returns whether the subscription receives the events of every project
+func (w *WebhookSubscription) IsGlobal() bool {
+	return w.projectID == ""
+}
+
+// URL returns the endpoint events are delivered to
+func (w *WebhookSubscription) URL() string {
+	return w.url
+}
+
+// EncryptedSecret returns the encrypted signing secret (for repository and service use)
+func (w *WebhookSubscription) EncryptedSecret() string {
+	return w.encryptedSecret
+}
+
+// IsActive returns whether events are delivered to the subscription
+func (w *WebhookSubscription) IsActive() bool {
+	return w.isActive
+}
+
+// Subscribes returns whether the subscription receives events of the type
+func (w *WebhookSubscription) Subscribes(eventType WebhookEventType) bool {
+	for _, event := range w.events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignID sets the ID given by the repository when the subscription is created
+func (w *WebhookSubscription) AssignID(id string) {
+	w.id = id
+}
+
+// Update replaces the user-provided settings of the subscription
+func (w *WebhookSubscription) Update(name, endpointURL string, events []WebhookEventType) error {
+	if err := validateWebhook(name, endpointURL, events); err != nil {
+		return err
+	}
+
+	w.name = name
+	w.url = endpointURL
+	w.events = normalizeWebhookEvents(events)
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// RotateSecret replaces the encrypted signing secret
+func (w *WebhookSubscription) RotateSecret(encryptedSecret string) error {
+	if encryptedSecret == "" {
+		return errors.New("signing secret is required")
+	}
+	w.encryptedSecret = encryptedSecret
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// Enable resumes deliveries and forgets earlier failures
+func (w *WebhookSubscription) Enable() {
+	w.isActive = true
+	w.disabledReason = ""
+	w.consecutiveFailures = 0
+	w.updatedAt = time.Now()
+}
+
+// Disable stops deliveries, recording why
+func (w *WebhookSubscription) Disable(reason string) {
+	w.isActive = false
+	w.disabledReason = reason
+	w.updatedAt = time.Now()
+}
+
+// recordDelivery records whether a delivery ultimately succeeded. The
+// subscription is disabled once maxFailures deliveries in a row have failed;
+// it reports whether that happened.
+func (w *WebhookSubscription) recordDelivery(succeeded bool, maxFailures int) bool {
+	now := time.Now()
+	w.lastDeliveryAt = &now
+	w.updatedAt = now
+
+	if succeeded {
+		w.consecutiveFailures = 0
+		return false
+	}
+	w.consecutiveFailures++
+	if w.isActive && w.consecutiveFailures >= maxFailures {
+		w.Disable(fmt.Sprintf("disabled after %d failed deliveries in a row", w.consecutiveFailures))
+		return true
+	}
+	return false
+}
+
+// Snapshot returns a read-only snapshot of the subscription
+func (w *WebhookSubscription) Snapshot() WebhookSubscriptionSnapshot {
+	return WebhookSubscriptionSnapshot{
+		ID:                  w.id,
+		ProjectID:           w.projectID,
+		Name:                w.name,
+		URL:                 w.url,
+		Events:              append([]WebhookEventType(nil), w.events...),
+		IsActive:            w.isActive,
+		DisabledReason:      w.disabledReason,
+		ConsecutiveFailures: w.consecutiveFailures,
+		LastDeliveryAt:      w.lastDeliveryAt,
+		CreatedBy:           w.createdBy,
+		CreatedAt:           w.createdAt,
+		UpdatedAt:           w.updatedAt,
+	}
+}
+
+// WebhookSubscriptionSnapshot is a read-only view of a webhook subscription
+type WebhookSubscriptionSnapshot struct {
+	ID                  string
+	ProjectID           string
+	Name                string
+	URL                 string
+	Events              []WebhookEventType
+	IsActive            bool
+	DisabledReason      string
+	ConsecutiveFailures int
+	LastDeliveryAt      *time.Time
+	CreatedBy           string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// ReconstructWebhookSubscription reconstructs a WebhookSubscription from persisted data
+func ReconstructWebhookSubscription(snapshot WebhookSubscriptionSnapshot, encryptedSecret string) *WebhookSubscription {
+	return &WebhookSubscription{
+		id:                  snapshot.ID,
+		projectID:           snapshot.ProjectID,
+		name:                snapshot.Name,
+		url:                 snapshot.URL,
+		events:              append([]WebhookEventType(nil), snapshot.Events...),
+		encryptedSecret:     encryptedSecret,
+		isActive:            snapshot.IsActive,
+		disabledReason:      snapshot.DisabledReason,
+		consecutiveFailures: snapshot.ConsecutiveFailures,
+		lastDeliveryAt:      snapshot.LastDeliveryAt,
+		createdBy:           snapshot.CreatedBy,
+		createdAt:           snapshot.CreatedAt,
+		updatedAt:           snapshot.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryStatus is the state of a delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event queued for, or delivered to, a subscription.
+// Deliveries are kept as a log of what was sent and how the endpoint answered.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      WebhookEventType
+	ProjectID      string
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	ResponseBody   string
+	LastError      string
+	DurationMs     int64
+	RedeliveryOf   string // ID of the delivery this one repeats
+	CreatedAt      time.Time
+}
+
+// recordAttempt records the outcome of a delivery attempt and schedules the
+// next one, or fails the delivery once maxAttempts is reached
+func (d *WebhookDelivery) recordAttempt(at time.Time, statusCode int, body string, duration time.Duration, attemptErr error, maxAttempts int, backoff func(attempt int) time.Duration) {
+	d.ResponseStatus = statusCode
+	d.ResponseBody = body
+	d.DurationMs = duration.Milliseconds()
+
+	attempts := deliveryAttempts{&d.Attempts, &d.NextAttemptAt, &d.LastAttemptAt, &d.LastError}
+	switch attempts.record(at, attemptErr, maxAttempts, backoff) {
+	case attemptSucceeded:
+		d.Status = WebhookDeliverySucceeded
+	case attemptExhausted:
+		d.Status = WebhookDeliveryFailed
+	default:
+		d.Status = WebhookDeliveryPending
+	}
+}
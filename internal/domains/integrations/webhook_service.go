@@ -0,0 +1,443 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// WebhookMaxAttempts is how often a delivery is attempted before it fails
+	WebhookMaxAttempts = 8
+
+	// WebhookRetryBaseDelay is the delay before the first retry; it doubles with every further attempt
+	WebhookRetryBaseDelay = 30 * time.Second
+
+	// WebhookRetryMaxDelay caps the delay between attempts
+	WebhookRetryMaxDelay = time.Hour
+
+	// WebhookDisableAfterFailures is how many deliveries in a row may fail
+	// before the subscription is disabled
+	WebhookDisableAfterFailures = 5
+
+	// WebhookDeliveryTimeout bounds a single delivery attempt
+	WebhookDeliveryTimeout = 10 * time.Second
+
+	// WebhookDeliveryBatchSize is how many due deliveries one DeliverDue call attempts
+	WebhookDeliveryBatchSize = 100
+
+	// WebhookDeliveryRetention is how long finished deliveries are kept in the log
+	WebhookDeliveryRetention = 30 * 24 * time.Hour
+
+	// webhookResponseBodyLimit caps how much of an endpoint's response is kept in the delivery log
+	webhookResponseBodyLimit = 2048
+
+	// webhookClaimLease is how long a claimed delivery is hidden from other workers
+	webhookClaimLease = 2 * WebhookDeliveryTimeout
+)
+
+// Headers sent with every delivery
+const (
+	WebhookEventHeader     = "X-Fern-Event"
+	WebhookEventIDHeader   = "X-Fern-Event-Id"
+	WebhookDeliveryHeader  = "X-Fern-Delivery"
+	WebhookTimestampHeader = "X-Fern-Timestamp"
+	WebhookSignatureHeader = "X-Fern-Signature-256"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a subscription does not exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrWebhookDeliveryNotFound is returned when a delivery does not exist
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// WebhookPayload is the JSON body of a delivery
+type WebhookPayload struct {
+	ID         string                 `json:"id"`
+	Type       WebhookEventType       `json:"type"`
+	ProjectID  string                 `json:"projectId"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// WebhookDeliveryResult summarises a DeliverDue run
+type WebhookDeliveryResult struct {
+	Attempted int
+	Succeeded int
+	Retrying  int
+	Failed    int
+	Disabled  int // Subscriptions disabled for failing too often
+	Errors    []error
+}
+
+// WebhookService manages webhook subscriptions and delivers events to them
+// through a durable queue of deliveries
+type WebhookService struct {
+	subscriptions WebhookSubscriptionRepository
+	deliveries    WebhookDeliveryRepository
+	keys          *KeyRing
+	httpClient    *http.Client
+}
+
+// NewWebhookService creates a new webhook service. Signing secrets are
+// encrypted with the key ring.
+func NewWebhookService(subscriptions WebhookSubscriptionRepository, deliveries WebhookDeliveryRepository, keys *KeyRing) *WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		keys:          keys,
+		httpClient: &http.Client{
+			Timeout: WebhookDeliveryTimeout,
+			// A redirect could send the signed payload elsewhere; it counts as a failed attempt
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// CreateSubscription creates a subscription and returns it with its signing
+// secret, which is not shown again. An empty project ID creates a global subscription.
+func (s *WebhookService) CreateSubscription(ctx context.Context, projectID, name, endpointURL string, events []WebhookEventType, createdBy string) (*WebhookSubscription, string, error) {
+	secret, encryptedSecret, err := s.newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	subscription, err := NewWebhookSubscription(projectID, name, endpointURL, events, encryptedSecret, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, "", fmt.Errorf("failed to save webhook: %w", err)
+	}
+	return subscription, secret, nil
+}
+
+// GetSubscription retrieves a subscription
+func (s *WebhookService) GetSubscription(ctx context.Context, subscriptionID string) (*WebhookSubscription, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, err)
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of a project, or the global
+// subscriptions when projectID is empty
+func (s *WebhookService) ListSubscriptions(ctx context.Context, projectID string) ([]*WebhookSubscription, error) {
+	return s.subscriptions.FindByProjectID(ctx, projectID)
+}
+
+// UpdateSubscription replaces the settings of a subscription
+func (s *WebhookService) UpdateSubscription(ctx context.Context, subscriptionID, name, endpointURL string, events []WebhookEventType) (*WebhookSubscription, error) {
+	return s.changeSubscription(ctx, subscriptionID, func(subscription *WebhookSubscription) error {
+		return subscription.Update(name, endpointURL, events)
+	})
+}
+
+// EnableSubscription resumes deliveries to a subscription, including those queued while it was disabled
+func (s *WebhookService) EnableSubscription(ctx context.Context, subscriptionID string) (*WebhookSubscription, error) {
+	return s.changeSubscription(ctx, subscriptionID, func(subscription *WebhookSubscription) error {
+		subscription.Enable()
+		return nil
+	})
+}
+
+// DisableSubscription stops deliveries to a subscription
+func (s *WebhookService) DisableSubscription(ctx context.Context, subscriptionID string) (*WebhookSubscription, error) {
+	return s.changeSubscription(ctx, subscriptionID, func(subscription *WebhookSubscription) error {
+		subscription.Disable("disabled by a user")
+		return nil
+	})
+}
+
+// RotateSecret replaces the signing secret of a subscription and returns the new secret
+func (s *WebhookService) RotateSecret(ctx context.Context, subscriptionID string) (*WebhookSubscription, string, error) {
+	secret, encryptedSecret, err := s.newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	subscription, err := s.changeSubscription(ctx, subscriptionID, func(subscription *WebhookSubscription) error {
+		return subscription.RotateSecret(encryptedSecret)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return subscription, secret, nil
+}
+
+// DeleteSubscription removes a subscription
+func (s *WebhookService) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return err
+	}
+	return s.subscriptions.Delete(ctx, subscriptionID)
+}
+
+// changeSubscription loads a subscription, applies a change and saves it
+func (s *WebhookService) changeSubscription(ctx context.Context, subscriptionID string, change func(*WebhookSubscription) error) (*WebhookSubscription, error) {
+	subscription, err := s.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(subscription); err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return subscription, nil
+}
+
+// newSecret generates a signing secret and encrypts it
+func (s *WebhookService) newSecret() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate signing secret: %w", err)
+	}
+	secret := "whsec_" + hex.EncodeToString(raw)
+
+	encrypted, err := s.keys.Encrypt(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt signing secret: %w", err)
+	}
+	return secret, encrypted, nil
+}
+
+// Publish queues an event for every active subscription of its project, and
+// every active global subscription, that subscribes to its type. It returns
+// how many deliveries were queued.
+func (s *WebhookService) Publish(ctx context.Context, event WebhookEvent) (int, error) {
+	subscriptions, err := s.subscriptions.FindActiveForEvent(ctx, event.ProjectID, event.Type)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return 0, nil
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if event.Data == nil {
+		event.Data = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(WebhookPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		ProjectID:  event.ProjectID,
+		OccurredAt: event.OccurredAt.UTC(),
+		Data:       event.Data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &WebhookDelivery{
+			SubscriptionID: subscription.ID(),
+			EventID:        event.ID,
+			EventType:      event.Type,
+			ProjectID:      event.ProjectID,
+			Payload:        string(payload),
+			Status:         WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+	}
+	if err := s.deliveries.Create(ctx, deliveries); err != nil {
+		return 0, fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return len(deliveries), nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*WebhookDelivery, error) {
+	return s.deliveries.FindBySubscriptionID(ctx, subscriptionID, limit)
+}
+
+// GetDelivery retrieves a delivery
+func (s *WebhookService) GetDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	delivery, err := s.deliveries.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookDeliveryNotFound, err)
+	}
+	return delivery, nil
+}
+
+// Redeliver queues the payload of an earlier delivery again. The new delivery
+// has its own ID but the same event ID, so endpoints can detect duplicates.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	original, err := s.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	redelivery := &WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		ProjectID:      original.ProjectID,
+		Payload:        original.Payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		RedeliveryOf:   original.ID,
+		CreatedAt:      now,
+	}
+	if err := s.deliveries.Create(ctx, []*WebhookDelivery{redelivery}); err != nil {
+		return nil, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	return redelivery, nil
+}
+
+// DeliverDue attempts the deliveries whose next attempt is due. Failed
+// attempts are retried with exponential backoff. Failures on individual
+// deliveries are collected in the result and do not stop the run.
+func (s *WebhookService) DeliverDue(ctx context.Context) (*WebhookDeliveryResult, error) {
+	now := time.Now()
+	due, err := s.deliveries.FindDue(ctx, now, WebhookDeliveryBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+
+	result := &WebhookDeliveryResult{}
+	for _, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// Other instances deliver from the same queue
+		claimed, err := s.deliveries.Claim(ctx, delivery, time.Now().Add(webhookClaimLease))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("delivery %s: failed to claim: %w", delivery.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.deliver(ctx, delivery, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("delivery %s: %w", delivery.ID, err))
+		}
+	}
+	return result, nil
+}
+
+// deliver makes one attempt at a delivery and records its outcome
+func (s *WebhookService) deliver(ctx context.Context, delivery *WebhookDelivery, result *WebhookDeliveryResult) error {
+	subscription, err := s.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook: %w", err)
+	}
+	// Earlier deliveries of the batch may have disabled the subscription; the
+	// delivery stays queued until it is enabled again
+	if !subscription.IsActive() {
+		return nil
+	}
+	secret, err := s.keys.Decrypt(subscription.EncryptedSecret())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt signing secret: %w", err)
+	}
+
+	result.Attempted++
+	attemptedAt := time.Now()
+	statusCode, body, attemptErr := s.send(ctx, subscription.URL(), secret, delivery, attemptedAt)
+	delivery.recordAttempt(attemptedAt, statusCode, body, time.Since(attemptedAt), attemptErr, WebhookMaxAttempts, WebhookRetryDelay)
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to save delivery attempt: %w", err)
+	}
+
+	switch delivery.Status {
+	case WebhookDeliveryPending:
+		result.Retrying++
+		return nil
+	case WebhookDeliverySucceeded:
+		result.Succeeded++
+	case WebhookDeliveryFailed:
+		result.Failed++
+	}
+
+	if subscription.recordDelivery(delivery.Status == WebhookDeliverySucceeded, WebhookDisableAfterFailures) {
+		result.Disabled++
+	}
+	if err := s.subscriptions.UpdateDeliveryState(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// send posts a delivery's payload to an endpoint. Any response other than
+// 2xx is an error.
+func (s *WebhookService) send(ctx context.Context, endpointURL, secret string, delivery *WebhookDelivery, at time.Time) (int, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, WebhookDeliveryTimeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fern-Platform-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(responseBody), fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(responseBody), nil
+}
+
+// PruneDeliveries removes finished deliveries older than the retention period
+func (s *WebhookService) PruneDeliveries(ctx context.Context) (int64, error) {
+	return s.deliveries.DeleteFinishedBefore(ctx, time.Now().Add(-WebhookDeliveryRetention))
+}
+
+// WebhookRetryDelay returns how long to wait after a failed attempt before the next one
+func WebhookRetryDelay(attempt int) time.Duration {
+	return retryDelay(attempt, WebhookRetryBaseDelay, WebhookRetryMaxDelay)
+}
+
+// SignWebhookPayload returns the signature header value of a payload:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed
+// with the subscription's signing secret
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
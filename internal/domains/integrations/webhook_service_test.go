@@ -0,0 +1,545 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWebhookRepo is an in-memory WebhookSubscriptionRepository and WebhookDeliveryRepository
+type memoryWebhookRepo struct {
+	mu            sync.Mutex
+	nextID        int
+	subscriptions map[string]*integrations.WebhookSubscription
+	deliveries    map[string]*integrations.WebhookDelivery
+}
+
+func newMemoryWebhookRepo() *memoryWebhookRepo {
+	return &memoryWebhookRepo{
+		subscriptions: make(map[string]*integrations.WebhookSubscription),
+		deliveries:    make(map[string]*integrations.WebhookDelivery),
+	}
+}
+
+func (r *memoryWebhookRepo) newID() string {
+	r.nextID++
+	return strconv.Itoa(r.nextID)
+}
+
+// copySubscription returns a detached copy, as a database round trip would
+func copySubscription(subscription *integrations.WebhookSubscription) *integrations.WebhookSubscription {
+	return integrations.ReconstructWebhookSubscription(subscription.Snapshot(), subscription.EncryptedSecret())
+}
+
+func (r *memoryWebhookRepo) Create(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription.AssignID(r.newID())
+	r.subscriptions[subscription.ID()] = copySubscription(subscription)
+	return nil
+}
+
+func (r *memoryWebhookRepo) Update(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[subscription.ID()] = copySubscription(subscription)
+	return nil
+}
+
+func (r *memoryWebhookRepo) UpdateDeliveryState(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	return r.Update(ctx, subscription)
+}
+
+func (r *memoryWebhookRepo) Delete(ctx context.Context, subscriptionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, subscriptionID)
+	return nil
+}
+
+func (r *memoryWebhookRepo) FindByID(ctx context.Context, subscriptionID string) (*integrations.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.subscriptions[subscriptionID]
+	if !ok {
+		return nil, errors.New("webhook subscription not found")
+	}
+	return copySubscription(subscription), nil
+}
+
+func (r *memoryWebhookRepo) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*integrations.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.ProjectID() == projectID {
+			subscriptions = append(subscriptions, copySubscription(subscription))
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepo) FindActiveForEvent(ctx context.Context, projectID string, eventType integrations.WebhookEventType) ([]*integrations.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*integrations.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.IsActive() && subscription.Subscribes(eventType) &&
+			(subscription.IsGlobal() || subscription.ProjectID() == projectID) {
+			subscriptions = append(subscriptions, copySubscription(subscription))
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepo) CreateDeliveries(ctx context.Context, deliveries []*integrations.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range deliveries {
+		delivery.ID = r.newID()
+		stored := *delivery
+		r.deliveries[delivery.ID] = &stored
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepo) UpdateDelivery(ctx context.Context, delivery *integrations.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (r *memoryWebhookRepo) FindDeliveryByID(ctx context.Context, deliveryID string) (*integrations.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, ok := r.deliveries[deliveryID]
+	if !ok {
+		return nil, errors.New("webhook delivery not found")
+	}
+	found := *delivery
+	return &found, nil
+}
+
+func (r *memoryWebhookRepo) FindBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*integrations.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*integrations.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			found := *delivery
+			deliveries = append(deliveries, &found)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, _ := strconv.Atoi(deliveries[i].ID)
+		b, _ := strconv.Atoi(deliveries[j].ID)
+		return a > b
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *memoryWebhookRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*integrations.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		subscription, ok := r.subscriptions[delivery.SubscriptionID]
+		if ok && subscription.IsActive() && delivery.Status == integrations.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			found := *delivery
+			deliveries = append(deliveries, &found)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, _ := strconv.Atoi(deliveries[i].ID)
+		b, _ := strconv.Atoi(deliveries[j].ID)
+		return a < b
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *memoryWebhookRepo) Claim(ctx context.Context, delivery *integrations.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.deliveries[delivery.ID]
+	if !ok || stored.Status != integrations.WebhookDeliveryPending || stored.NextAttemptAt.After(delivery.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+func (r *memoryWebhookRepo) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var removed int64
+	for id, delivery := range r.deliveries {
+		if delivery.Status != integrations.WebhookDeliveryPending && delivery.CreatedAt.Before(before) {
+			delete(r.deliveries, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// makeDue moves the next attempt of every pending delivery into the past
+func (r *memoryWebhookRepo) makeDue() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range r.deliveries {
+		if delivery.Status == integrations.WebhookDeliveryPending {
+			delivery.NextAttemptAt = time.Now().Add(-time.Second)
+		}
+	}
+}
+
+// memoryWebhookDeliveryRepo adapts the delivery methods of memoryWebhookRepo to WebhookDeliveryRepository
+type memoryWebhookDeliveryRepo struct {
+	*memoryWebhookRepo
+}
+
+func (r memoryWebhookDeliveryRepo) Create(ctx context.Context, deliveries []*integrations.WebhookDelivery) error {
+	return r.CreateDeliveries(ctx, deliveries)
+}
+
+func (r memoryWebhookDeliveryRepo) Update(ctx context.Context, delivery *integrations.WebhookDelivery) error {
+	return r.UpdateDelivery(ctx, delivery)
+}
+
+func (r memoryWebhookDeliveryRepo) FindByID(ctx context.Context, deliveryID string) (*integrations.WebhookDelivery, error) {
+	return r.FindDeliveryByID(ctx, deliveryID)
+}
+
+// webhookEndpoint records the requests it receives and answers with status
+type webhookEndpoint struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookEndpoint(t *testing.T, status int) *webhookEndpoint {
+	endpoint := &webhookEndpoint{status: status}
+	endpoint.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		endpoint.mu.Lock()
+		endpoint.requests = append(endpoint.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+		status := endpoint.status
+		endpoint.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(endpoint.Close)
+	return endpoint
+}
+
+func (e *webhookEndpoint) setStatus(status int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status = status
+}
+
+func (e *webhookEndpoint) received() []receivedWebhook {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]receivedWebhook(nil), e.requests...)
+}
+
+func newTestWebhookService() (*integrations.WebhookService, *memoryWebhookRepo) {
+	repo := newMemoryWebhookRepo()
+	return integrations.NewWebhookService(repo, memoryWebhookDeliveryRepo{repo}, testKeyRing), repo
+}
+
+func TestWebhookService_DeliversSignedPayload(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestWebhookService()
+	endpoint := newWebhookEndpoint(t, http.StatusNoContent)
+
+	subscription, secret, err := service.CreateSubscription(ctx, "project-1", "CI", endpoint.URL,
+		[]integrations.WebhookEventType{integrations.WebhookEventTestRunCompleted}, "user-1")
+	require.NoError(t, err)
+	assert.Contains(t, secret, "whsec_")
+	assert.NotContains(t, subscription.EncryptedSecret(), secret)
+
+	queued, err := service.Publish(ctx, integrations.WebhookEvent{
+		Type:      integrations.WebhookEventTestRunCompleted,
+		ProjectID: "project-1",
+		Data:      map[string]interface{}{"runId": "run-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempted)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Empty(t, result.Errors)
+
+	requests := endpoint.received()
+	require.Len(t, requests, 1)
+	header := requests[0].header
+	assert.Equal(t, "test_run.completed", header.Get(integrations.WebhookEventHeader))
+	assert.NotEmpty(t, header.Get(integrations.WebhookDeliveryHeader))
+
+	timestamp, err := strconv.ParseInt(header.Get(integrations.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.SignWebhookPayload(secret, timestamp, requests[0].body), header.Get(integrations.WebhookSignatureHeader))
+	assert.NotEqual(t, integrations.SignWebhookPayload("wrong-secret", timestamp, requests[0].body), header.Get(integrations.WebhookSignatureHeader))
+
+	var payload integrations.WebhookPayload
+	require.NoError(t, json.Unmarshal(requests[0].body, &payload))
+	assert.Equal(t, integrations.WebhookEventTestRunCompleted, payload.Type)
+	assert.Equal(t, "project-1", payload.ProjectID)
+	assert.Equal(t, header.Get(integrations.WebhookEventIDHeader), payload.ID)
+	assert.Equal(t, "run-1", payload.Data["runId"])
+
+	deliveries, err := service.ListDeliveries(ctx, subscription.ID(), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, integrations.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].ResponseStatus)
+
+	// Nothing is left to deliver
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+}
+
+func TestWebhookService_PublishMatchesProjectAndGlobalSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestWebhookService()
+
+	create := func(projectID string, events ...integrations.WebhookEventType) *integrations.WebhookSubscription {
+		subscription, _, err := service.CreateSubscription(ctx, projectID, "hook", "https://example.com/hook", events, "user-1")
+		require.NoError(t, err)
+		return subscription
+	}
+	create("project-1", integrations.WebhookEventTestRunFailed)
+	create("project-2", integrations.WebhookEventTestRunFailed)
+	global := create("", integrations.WebhookEventTestRunFailed, integrations.WebhookEventFlakyDetected)
+	create("project-1", integrations.WebhookEventFlakyResolved)
+	assert.True(t, global.IsGlobal())
+
+	queued, err := service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventTestRunFailed, ProjectID: "project-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, queued)
+
+	queued, err = service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventFlakyDetected, ProjectID: "project-3"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+
+	_, err = service.DisableSubscription(ctx, global.ID())
+	require.NoError(t, err)
+	queued, err = service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventFlakyDetected, ProjectID: "project-3"})
+	require.NoError(t, err)
+	assert.Zero(t, queued)
+}
+
+func TestWebhookService_CreateSubscriptionValidates(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestWebhookService()
+	events := []integrations.WebhookEventType{integrations.WebhookEventTestRunCreated}
+
+	_, _, err := service.CreateSubscription(ctx, "project-1", "", "https://example.com", events, "user-1")
+	assert.ErrorIs(t, err, integrations.ErrInvalidWebhook)
+
+	_, _, err = service.CreateSubscription(ctx, "project-1", "hook", "ftp://example.com", events, "user-1")
+	assert.ErrorIs(t, err, integrations.ErrInvalidWebhook)
+
+	_, _, err = service.CreateSubscription(ctx, "project-1", "hook", "https://example.com", nil, "user-1")
+	assert.ErrorIs(t, err, integrations.ErrInvalidWebhook)
+
+	_, _, err = service.CreateSubscription(ctx, "project-1", "hook", "https://example.com",
+		[]integrations.WebhookEventType{"test_run.deleted"}, "user-1")
+	assert.ErrorIs(t, err, integrations.ErrInvalidWebhook)
+}
+
+func TestWebhookService_RetriesWithBackoffUntilMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestWebhookService()
+	endpoint := newWebhookEndpoint(t, http.StatusInternalServerError)
+
+	subscription, _, err := service.CreateSubscription(ctx, "project-1", "CI", endpoint.URL,
+		[]integrations.WebhookEventType{integrations.WebhookEventTestRunCreated}, "user-1")
+	require.NoError(t, err)
+	_, err = service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventTestRunCreated, ProjectID: "project-1"})
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+
+	deliveries, err := service.ListDeliveries(ctx, subscription.ID(), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	assert.Equal(t, integrations.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.Contains(t, delivery.LastError, "500")
+	assert.False(t, delivery.NextAttemptAt.Before(before.Add(integrations.WebhookRetryBaseDelay)))
+
+	// The retry is not due yet
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	for attempt := 2; attempt <= integrations.WebhookMaxAttempts; attempt++ {
+		repo.makeDue()
+		_, err := service.DeliverDue(ctx)
+		require.NoError(t, err)
+	}
+
+	delivery, err = service.GetDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, integrations.WebhookMaxAttempts, delivery.Attempts)
+	assert.Len(t, endpoint.received(), integrations.WebhookMaxAttempts)
+
+	// One failed delivery does not disable the subscription
+	subscription, err = service.GetSubscription(ctx, subscription.ID())
+	require.NoError(t, err)
+	assert.True(t, subscription.IsActive())
+	assert.Equal(t, 1, subscription.Snapshot().ConsecutiveFailures)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, integrations.WebhookRetryBaseDelay, integrations.WebhookRetryDelay(1))
+	assert.Equal(t, 2*integrations.WebhookRetryBaseDelay, integrations.WebhookRetryDelay(2))
+	assert.Equal(t, 8*integrations.WebhookRetryBaseDelay, integrations.WebhookRetryDelay(4))
+	assert.Equal(t, integrations.WebhookRetryMaxDelay, integrations.WebhookRetryDelay(30))
+}
+
+func TestWebhookService_DisablesFailingEndpoint(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestWebhookService()
+	endpoint := newWebhookEndpoint(t, http.StatusBadGateway)
+
+	subscription, _, err := service.CreateSubscription(ctx, "project-1", "CI", endpoint.URL,
+		[]integrations.WebhookEventType{integrations.WebhookEventTestRunCreated}, "user-1")
+	require.NoError(t, err)
+	for i := 0; i < integrations.WebhookDisableAfterFailures+1; i++ {
+		_, err := service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventTestRunCreated, ProjectID: "project-1"})
+		require.NoError(t, err)
+	}
+
+	disabled := 0
+	for attempt := 1; attempt <= integrations.WebhookMaxAttempts; attempt++ {
+		repo.makeDue()
+		result, err := service.DeliverDue(ctx)
+		require.NoError(t, err)
+		disabled += result.Disabled
+	}
+	assert.Equal(t, 1, disabled)
+
+	subscription, err = service.GetSubscription(ctx, subscription.ID())
+	require.NoError(t, err)
+	assert.False(t, subscription.IsActive())
+	assert.NotEmpty(t, subscription.Snapshot().DisabledReason)
+
+	// The delivery that was still queued waits for the subscription to be enabled
+	repo.makeDue()
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.Attempted)
+
+	endpoint.setStatus(http.StatusOK)
+	subscription, err = service.EnableSubscription(ctx, subscription.ID())
+	require.NoError(t, err)
+	assert.True(t, subscription.IsActive())
+	assert.Zero(t, subscription.Snapshot().ConsecutiveFailures)
+
+	repo.makeDue()
+	result, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestWebhookService()
+	endpoint := newWebhookEndpoint(t, http.StatusOK)
+
+	subscription, _, err := service.CreateSubscription(ctx, "project-1", "CI", endpoint.URL,
+		[]integrations.WebhookEventType{integrations.WebhookEventFlakyResolved}, "user-1")
+	require.NoError(t, err)
+	_, err = service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventFlakyResolved, ProjectID: "project-1"})
+	require.NoError(t, err)
+	_, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	deliveries, err := service.ListDeliveries(ctx, subscription.ID(), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	original := deliveries[0]
+
+	redelivery, err := service.Redeliver(ctx, original.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ID, redelivery.ID)
+	assert.Equal(t, original.EventID, redelivery.EventID)
+	assert.Equal(t, original.Payload, redelivery.Payload)
+	assert.Equal(t, original.ID, redelivery.RedeliveryOf)
+	assert.Equal(t, integrations.WebhookDeliveryPending, redelivery.Status)
+
+	result, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+
+	requests := endpoint.received()
+	require.Len(t, requests, 2)
+	assert.Equal(t, requests[0].body, requests[1].body)
+	assert.Equal(t, requests[0].header.Get(integrations.WebhookEventIDHeader), requests[1].header.Get(integrations.WebhookEventIDHeader))
+	assert.NotEqual(t, requests[0].header.Get(integrations.WebhookDeliveryHeader), requests[1].header.Get(integrations.WebhookDeliveryHeader))
+
+	_, err = service.Redeliver(ctx, "missing")
+	assert.ErrorIs(t, err, integrations.ErrWebhookDeliveryNotFound)
+}
+
+func TestWebhookService_RotateSecret(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestWebhookService()
+	endpoint := newWebhookEndpoint(t, http.StatusOK)
+
+	subscription, oldSecret, err := service.CreateSubscription(ctx, "", "Audit", endpoint.URL,
+		[]integrations.WebhookEventType{integrations.WebhookEventTestRunCreated}, "admin")
+	require.NoError(t, err)
+	_, newSecret, err := service.RotateSecret(ctx, subscription.ID())
+	require.NoError(t, err)
+	assert.NotEqual(t, oldSecret, newSecret)
+
+	_, err = service.Publish(ctx, integrations.WebhookEvent{Type: integrations.WebhookEventTestRunCreated, ProjectID: "project-1"})
+	require.NoError(t, err)
+	_, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	requests := endpoint.received()
+	require.Len(t, requests, 1)
+	timestamp, err := strconv.ParseInt(requests[0].header.Get(integrations.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, integrations.SignWebhookPayload(newSecret, timestamp, requests[0].body), requests[0].header.Get(integrations.WebhookSignatureHeader))
+}
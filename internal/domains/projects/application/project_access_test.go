@@ -0,0 +1,141 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_GrantAccess(t *testing.T) {
+	ctx := context.Background()
+	projectID := domain.ProjectID("shop")
+	project, _ := domain.NewProject(projectID, "Shop", "team-shop")
+
+	t.Run("should grant an expiring permission to a group", func(t *testing.T) {
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+		expiresAt := time.Now().Add(24 * time.Hour)
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		mockPermRepo.On("Save", ctx, mock.MatchedBy(func(p *domain.ProjectPermission) bool {
+			return p.GroupName() == "contractors" && p.UserID() == "" && p.GrantedBy() == "admin-1" &&
+				p.ExpiresAt() != nil && p.ExpiresAt().Equal(expiresAt)
+		})).Return(nil)
+
+		permission, err := service.GrantAccess(ctx, application.GrantAccessRequest{
+			ProjectID:  projectID,
+			GroupName:  "/contractors",
+			Permission: domain.PermissionWrite,
+			ExpiresAt:  &expiresAt,
+			GrantedBy:  "admin-1",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, permission.IsGroupPermission())
+		mockPermRepo.AssertExpectations(t)
+	})
+
+	t.Run("should require exactly one of a user and a group", func(t *testing.T) {
+		service := application.NewProjectService(new(MockProjectRepository), new(MockProjectPermissionRepository))
+
+		_, err := service.GrantAccess(ctx, application.GrantAccessRequest{ProjectID: projectID, Permission: domain.PermissionRead, GrantedBy: "admin-1"})
+		assert.ErrorIs(t, err, application.ErrInvalidGrant)
+
+		_, err = service.GrantAccess(ctx, application.GrantAccessRequest{ProjectID: projectID, UserID: "u1", GroupName: "g1", Permission: domain.PermissionRead, GrantedBy: "admin-1"})
+		assert.ErrorIs(t, err, application.ErrInvalidGrant)
+	})
+
+	t.Run("should reject expiry in the past and unknown permissions", func(t *testing.T) {
+		mockRepo := new(MockProjectRepository)
+		service := application.NewProjectService(mockRepo, new(MockProjectPermissionRepository))
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		past := time.Now().Add(-time.Hour)
+
+		_, err := service.GrantAccess(ctx, application.GrantAccessRequest{ProjectID: projectID, UserID: "u1", Permission: domain.PermissionRead, ExpiresAt: &past, GrantedBy: "admin-1"})
+		assert.ErrorIs(t, err, application.ErrInvalidGrant)
+
+		_, err = service.GrantAccess(ctx, application.GrantAccessRequest{ProjectID: projectID, UserID: "u1", Permission: "owner", GrantedBy: "admin-1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidPermission)
+	})
+
+	t.Run("should report missing projects", func(t *testing.T) {
+		mockRepo := new(MockProjectRepository)
+		service := application.NewProjectService(mockRepo, new(MockProjectPermissionRepository))
+		mockRepo.On("FindByProjectID", ctx, domain.ProjectID("missing")).Return(nil, domain.ErrProjectNotFound)
+
+		_, err := service.GrantAccess(ctx, application.GrantAccessRequest{ProjectID: "missing", UserID: "u1", Permission: domain.PermissionRead, GrantedBy: "admin-1"})
+		assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+	})
+}
+
+func TestProjectService_RevokeUserAccess(t *testing.T) {
+	ctx := context.Background()
+	projectID := domain.ProjectID("shop")
+
+	t.Run("should revoke every permission of the user", func(t *testing.T) {
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(new(MockProjectRepository), mockPermRepo)
+		read, _ := domain.NewProjectPermission(projectID, "u1", domain.PermissionRead, "admin-1")
+		write, _ := domain.NewProjectPermission(projectID, "u1", domain.PermissionWrite, "admin-1")
+
+		mockPermRepo.On("FindByProjectAndUser", ctx, projectID, "u1").Return([]*domain.ProjectPermission{read, write}, nil)
+		mockPermRepo.On("Delete", ctx, projectID, "u1", domain.PermissionRead).Return(nil)
+		mockPermRepo.On("Delete", ctx, projectID, "u1", domain.PermissionWrite).Return(nil)
+
+		require.NoError(t, service.RevokeUserAccess(ctx, projectID, "u1", ""))
+		mockPermRepo.AssertExpectations(t)
+	})
+
+	t.Run("should report users without permissions", func(t *testing.T) {
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(new(MockProjectRepository), mockPermRepo)
+		mockPermRepo.On("FindByProjectAndUser", ctx, projectID, "u2").Return([]*domain.ProjectPermission{}, nil)
+
+		assert.ErrorIs(t, service.RevokeUserAccess(ctx, projectID, "u2", ""), domain.ErrPermissionNotFound)
+	})
+}
+
+func TestProjectService_HasPermission(t *testing.T) {
+	ctx := context.Background()
+	projectID := domain.ProjectID("shop")
+	past := time.Now().Add(-time.Hour)
+
+	direct, _ := domain.NewProjectPermission(projectID, "u1", domain.PermissionRead, "admin-1")
+	expired := domain.ReconstructProjectPermission(projectID, "", "contractors", domain.PermissionAdmin, "admin-1", past.Add(-time.Hour), &past)
+	group, _ := domain.NewProjectGroupPermission(projectID, "qa", domain.PermissionWrite, "admin-1")
+
+	mockPermRepo := new(MockProjectPermissionRepository)
+	service := application.NewProjectService(new(MockProjectRepository), mockPermRepo)
+	mockPermRepo.On("FindByProjectAndUser", ctx, projectID, "u1").Return([]*domain.ProjectPermission{direct}, nil)
+	mockPermRepo.On("FindByProjectAndGroups", ctx, projectID, []string{"contractors"}).Return([]*domain.ProjectPermission{expired}, nil)
+	mockPermRepo.On("FindByProjectAndGroups", ctx, projectID, []string{"qa"}).Return([]*domain.ProjectPermission{group}, nil)
+
+	t.Run("should include lower permissions", func(t *testing.T) {
+		allowed, err := service.HasPermission(ctx, projectID, "u1", []string{"/contractors"}, domain.PermissionRead)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("should ignore expired group permissions", func(t *testing.T) {
+		allowed, err := service.HasPermission(ctx, projectID, "u1", []string{"/contractors"}, domain.PermissionWrite)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("should apply permissions of the user's groups", func(t *testing.T) {
+		allowed, err := service.HasPermission(ctx, projectID, "u1", []string{"qa"}, domain.PermissionWrite)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = service.HasPermission(ctx, projectID, "u1", []string{"qa"}, domain.PermissionDelete)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+}
@@ -108,10 +108,61 @@ func (s *ProjectService) UpdateProject(ctx context.Context, projectID domain.Pro
 	// Update settings
 	if updates.Settings != nil {
 		for key, value := range updates.Settings {
+			if key == domain.FlakyDetectionSettingsKey {
+				// Typed setting, validated below
+				settings, err := domain.ParseFlakyDetectionSettings(value)
+				if err != nil {
+					return fmt.Errorf("%w: %v", domain.ErrInvalidFlakyDetectionSettings, err)
+				}
+				if updates.FlakyDetection == nil {
+					updates.FlakyDetection = &settings
+				}
+				continue
+			}
+			if key == domain.JiraSyncSettingsKey {
+				// Typed setting, validated below
+				settings, err := domain.ParseJiraSyncSettings(value)
+				if err != nil {
+					return fmt.Errorf("%w: %v", domain.ErrInvalidJiraSyncSettings, err)
+				}
+				if updates.JiraSync == nil {
+					updates.JiraSync = &settings
+				}
+				continue
+			}
+			if key == domain.OwnershipRulesSettingsKey {
+				// Typed setting, validated below
+				text, ok := value.(string)
+				if !ok && value != nil {
+					return fmt.Errorf("%w: rules must be a string", domain.ErrInvalidOwnershipRules)
+				}
+				if updates.OwnershipRules == nil {
+					updates.OwnershipRules = &text
+				}
+				continue
+			}
 			project.SetSetting(key, value)
 		}
 	}
 
+	if updates.FlakyDetection != nil {
+		if err := project.UpdateFlakyDetectionSettings(*updates.FlakyDetection); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalidFlakyDetectionSettings, err)
+		}
+	}
+
+	if updates.JiraSync != nil {
+		if err := project.UpdateJiraSyncSettings(*updates.JiraSync); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalidJiraSyncSettings, err)
+		}
+	}
+
+	if updates.OwnershipRules != nil {
+		if err := project.UpdateOwnershipRules(*updates.OwnershipRules); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalidOwnershipRules, err)
+		}
+	}
+
 	// Save the updates
 	if err := s.projectRepo.Update(ctx, project); err != nil {
 		return fmt.Errorf("failed to save project updates: %w", err)
@@ -231,4 +282,13 @@ type UpdateProjectRequest struct {
 	DefaultBranch *string
 	Team          *domain.Team
 	Settings      map[string]interface{}
+
+	// FlakyDetection replaces the project's flaky detection overrides when set
+	FlakyDetection *domain.FlakyDetectionSettings
+
+	// OwnershipRules replaces the project's CODEOWNERS-style ownership rules when set
+	OwnershipRules *string
+
+	// JiraSync replaces the project's JIRA sync settings when set
+	JiraSync *domain.JiraSyncSettings
 }
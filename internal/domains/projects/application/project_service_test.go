@@ -3,6 +3,7 @@ package application_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
 	"github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
@@ -106,11 +107,24 @@ func (m *MockProjectPermissionRepository) FindByUser(ctx context.Context, userID
 	return args.Get(0).([]*domain.ProjectPermission), args.Error(1)
 }
 
-func (m *MockProjectPermissionRepository) DeleteExpired(ctx context.Context) error {
-	args := m.Called(ctx)
+func (m *MockProjectPermissionRepository) FindByProjectAndGroups(ctx context.Context, projectID domain.ProjectID, groupNames []string) ([]*domain.ProjectPermission, error) {
+	args := m.Called(ctx, projectID, groupNames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ProjectPermission), args.Error(1)
+}
+
+func (m *MockProjectPermissionRepository) DeleteGroup(ctx context.Context, projectID domain.ProjectID, groupName string, permission domain.PermissionType) error {
+	args := m.Called(ctx, projectID, groupName, permission)
 	return args.Error(0)
 }
 
+func (m *MockProjectPermissionRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestProjectService_DeleteProject(t *testing.T) {
 	t.Run("should delete project successfully", func(t *testing.T) {
 		// Arrange
@@ -236,3 +250,242 @@ func TestProjectService_CreateProject(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestProjectService_UpdateProject_FlakyDetection(t *testing.T) {
+	t.Run("should store valid flaky detection overrides", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("nightly-project")
+		project, _ := domain.NewProject(projectID, "Nightly", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		mockRepo.On("Update", ctx, project).Return(nil)
+
+		minimumRuns := 3
+		window := 30 * 24 * time.Hour
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			FlakyDetection: &domain.FlakyDetectionSettings{
+				MinimumRuns:    &minimumRuns,
+				AnalysisWindow: &window,
+			},
+		})
+
+		assert.NoError(t, err)
+		settings, err := project.FlakyDetectionSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *settings.MinimumRuns)
+		assert.Equal(t, window, *settings.AnalysisWindow)
+		assert.Nil(t, settings.MinFailureRate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should validate overrides passed through raw settings", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("nightly-project")
+		project, _ := domain.NewProject(projectID, "Nightly", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			Settings: map[string]interface{}{
+				domain.FlakyDetectionSettingsKey: map[string]interface{}{
+					"minFailureRate": 0.5,
+					"maxFailureRate": 0.2,
+				},
+			},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidFlakyDetectionSettings)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an override that empties the default failure-rate range", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("nightly-project")
+		project, _ := domain.NewProject(projectID, "Nightly", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		minFailureRate := 0.96
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			FlakyDetection: &domain.FlakyDetectionSettings{MinFailureRate: &minFailureRate},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidFlakyDetectionSettings)
+		assert.Contains(t, err.Error(), "minFailureRate (0.96) must be less than maxFailureRate (0.95)")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject unknown keys", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("nightly-project")
+		project, _ := domain.NewProject(projectID, "Nightly", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			Settings: map[string]interface{}{
+				domain.FlakyDetectionSettingsKey: map[string]interface{}{"minRuns": 3},
+			},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidFlakyDetectionSettings)
+	})
+
+	t.Run("should clear overrides when empty settings are given", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("nightly-project")
+		project, _ := domain.NewProject(projectID, "Nightly", "fern")
+		minimumRuns := 3
+		_ = project.UpdateFlakyDetectionSettings(domain.FlakyDetectionSettings{MinimumRuns: &minimumRuns})
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		mockRepo.On("Update", ctx, project).Return(nil)
+
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			FlakyDetection: &domain.FlakyDetectionSettings{},
+		})
+
+		assert.NoError(t, err)
+		_, exists := project.GetSetting(domain.FlakyDetectionSettingsKey)
+		assert.False(t, exists)
+	})
+}
+
+func TestProjectService_UpdateProject_OwnershipRules(t *testing.T) {
+	t.Run("should store valid ownership rules", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("checkout-project")
+		project, _ := domain.NewProject(projectID, "Checkout", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		mockRepo.On("Update", ctx, project).Return(nil)
+
+		rulesText := "suite:Checkout* @payments\ntag:smoke @release-team"
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			OwnershipRules: &rulesText,
+		})
+
+		assert.NoError(t, err)
+		rules, err := project.OwnershipRules()
+		assert.NoError(t, err)
+		assert.Len(t, rules.Rules(), 2)
+		assert.Equal(t, []string{"@payments"}, rules.Owners(domain.OwnershipSubject{SuiteName: "Checkout Flow"}))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid ownership rules", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("checkout-project")
+		project, _ := domain.NewProject(projectID, "Checkout", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		rulesText := "suite:Checkout* payments-team"
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			OwnershipRules: &rulesText,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOwnershipRules)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should validate rules passed through raw settings", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("checkout-project")
+		project, _ := domain.NewProject(projectID, "Checkout", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			Settings: map[string]interface{}{
+				domain.OwnershipRulesSettingsKey: []string{"@payments"},
+			},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOwnershipRules)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProjectService_UpdateProject_JiraSync(t *testing.T) {
+	t.Run("should store the re-flake action", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("checkout-project")
+		project, _ := domain.NewProject(projectID, "Checkout", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+		mockRepo.On("Update", ctx, project).Return(nil)
+
+		settings, err := project.JiraSyncSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReflakeActionComment, settings.EffectiveReflakeAction())
+
+		err = service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			JiraSync: &domain.JiraSyncSettings{ReflakeAction: domain.ReflakeActionReopen},
+		})
+
+		assert.NoError(t, err)
+		settings, err = project.JiraSyncSettings()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReflakeActionReopen, settings.EffectiveReflakeAction())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject unsupported actions passed through raw settings", func(t *testing.T) {
+		ctx := context.Background()
+		mockRepo := new(MockProjectRepository)
+		mockPermRepo := new(MockProjectPermissionRepository)
+		service := application.NewProjectService(mockRepo, mockPermRepo)
+
+		projectID := domain.ProjectID("checkout-project")
+		project, _ := domain.NewProject(projectID, "Checkout", "fern")
+
+		mockRepo.On("FindByProjectID", ctx, projectID).Return(project, nil)
+
+		err := service.UpdateProject(ctx, projectID, application.UpdateProjectRequest{
+			Settings: map[string]interface{}{
+				domain.JiraSyncSettingsKey: map[string]interface{}{"reflakeAction": "close"},
+			},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidJiraSyncSettings)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
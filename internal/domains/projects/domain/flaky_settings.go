@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FlakyDetectionSettingsKey is the project settings key holding flaky detection overrides
+const FlakyDetectionSettingsKey = "flakyDetection"
+
+// ErrInvalidFlakyDetectionSettings is returned when overrides fail validation
+var ErrInvalidFlakyDetectionSettings = errors.New("invalid flaky detection settings")
+
+// Bounds applied when validating flaky detection overrides
+const (
+	MinAnalysisWindow = time.Hour
+	MaxAnalysisWindow = 90 * 24 * time.Hour
+	MaxMinimumRuns    = 1000
+)
+
+// Platform default failure-rate range, which applies when a project overrides
+// only one end of it. The analytics domain's default detection config uses it.
+const (
+	DefaultMinFailureRate = 0.05
+	DefaultMaxFailureRate = 0.95
+)
+
+// FlakyDetectionSettings holds per-project overrides for flaky test detection.
+// A nil field means the platform default applies.
+type FlakyDetectionSettings struct {
+	MinimumRuns                    *int
+	MinFailureRate                 *float64
+	MaxFailureRate                 *float64
+	AnalysisWindow                 *time.Duration
+	ConsecutivePassesForResolution *int
+}
+
+// IsEmpty returns true when no overrides are set
+func (s FlakyDetectionSettings) IsEmpty() bool {
+	return s.MinimumRuns == nil &&
+		s.MinFailureRate == nil &&
+		s.MaxFailureRate == nil &&
+		s.AnalysisWindow == nil &&
+		s.ConsecutivePassesForResolution == nil
+}
+
+// Validate checks that all provided overrides are within accepted bounds and
+// that the failure-rate range, merged with the platform defaults, is not empty
+func (s FlakyDetectionSettings) Validate() error {
+	if s.MinimumRuns != nil && (*s.MinimumRuns < 1 || *s.MinimumRuns > MaxMinimumRuns) {
+		return fmt.Errorf("minimumRuns must be between 1 and %d", MaxMinimumRuns)
+	}
+	if s.MinFailureRate != nil && (*s.MinFailureRate <= 0 || *s.MinFailureRate >= 1) {
+		return errors.New("minFailureRate must be greater than 0 and less than 1")
+	}
+	if s.MaxFailureRate != nil && (*s.MaxFailureRate <= 0 || *s.MaxFailureRate > 1) {
+		return errors.New("maxFailureRate must be greater than 0 and at most 1")
+	}
+	minFailureRate, maxFailureRate := DefaultMinFailureRate, DefaultMaxFailureRate
+	if s.MinFailureRate != nil {
+		minFailureRate = *s.MinFailureRate
+	}
+	if s.MaxFailureRate != nil {
+		maxFailureRate = *s.MaxFailureRate
+	}
+	if minFailureRate >= maxFailureRate {
+		return fmt.Errorf("minFailureRate (%g) must be less than maxFailureRate (%g)", minFailureRate, maxFailureRate)
+	}
+	if s.AnalysisWindow != nil && (*s.AnalysisWindow < MinAnalysisWindow || *s.AnalysisWindow > MaxAnalysisWindow) {
+		return fmt.Errorf("analysisWindow must be between %s and %s", MinAnalysisWindow, MaxAnalysisWindow)
+	}
+	if s.ConsecutivePassesForResolution != nil && (*s.ConsecutivePassesForResolution < 1 || *s.ConsecutivePassesForResolution > MaxMinimumRuns) {
+		return fmt.Errorf("consecutivePassesForResolution must be between 1 and %d", MaxMinimumRuns)
+	}
+	return nil
+}
+
+// ToSettingValue converts the overrides to the JSON-friendly form stored in project settings
+func (s FlakyDetectionSettings) ToSettingValue() map[string]interface{} {
+	value := make(map[string]interface{})
+	if s.MinimumRuns != nil {
+		value["minimumRuns"] = *s.MinimumRuns
+	}
+	if s.MinFailureRate != nil {
+		value["minFailureRate"] = *s.MinFailureRate
+	}
+	if s.MaxFailureRate != nil {
+		value["maxFailureRate"] = *s.MaxFailureRate
+	}
+	if s.AnalysisWindow != nil {
+		value["analysisWindow"] = s.AnalysisWindow.String()
+	}
+	if s.ConsecutivePassesForResolution != nil {
+		value["consecutivePassesForResolution"] = *s.ConsecutivePassesForResolution
+	}
+	return value
+}
+
+// ParseFlakyDetectionSettings reads overrides from a raw project setting value.
+// It accepts the map produced by ToSettingValue as well as its JSON-decoded form.
+func ParseFlakyDetectionSettings(raw interface{}) (FlakyDetectionSettings, error) {
+	var settings FlakyDetectionSettings
+	if raw == nil {
+		return settings, nil
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return settings, errors.New("flaky detection settings must be an object")
+	}
+
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		switch key {
+		case "minimumRuns":
+			n, err := toInt(value)
+			if err != nil {
+				return settings, fmt.Errorf("minimumRuns: %w", err)
+			}
+			settings.MinimumRuns = &n
+		case "minFailureRate":
+			f, err := toFloat(value)
+			if err != nil {
+				return settings, fmt.Errorf("minFailureRate: %w", err)
+			}
+			settings.MinFailureRate = &f
+		case "maxFailureRate":
+			f, err := toFloat(value)
+			if err != nil {
+				return settings, fmt.Errorf("maxFailureRate: %w", err)
+			}
+			settings.MaxFailureRate = &f
+		case "analysisWindow":
+			s, ok := value.(string)
+			if !ok {
+				return settings, errors.New("analysisWindow must be a duration string such as \"168h\"")
+			}
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return settings, fmt.Errorf("analysisWindow: %w", err)
+			}
+			settings.AnalysisWindow = &d
+		case "consecutivePassesForResolution":
+			n, err := toInt(value)
+			if err != nil {
+				return settings, fmt.Errorf("consecutivePassesForResolution: %w", err)
+			}
+			settings.ConsecutivePassesForResolution = &n
+		default:
+			return settings, fmt.Errorf("unknown flaky detection setting %q", key)
+		}
+	}
+
+	return settings, nil
+}
+
+// FlakyDetectionSettings returns the project's flaky detection overrides
+func (p *Project) FlakyDetectionSettings() (FlakyDetectionSettings, error) {
+	raw, exists := p.settings[FlakyDetectionSettingsKey]
+	if !exists {
+		return FlakyDetectionSettings{}, nil
+	}
+	return ParseFlakyDetectionSettings(raw)
+}
+
+// UpdateFlakyDetectionSettings validates and stores flaky detection overrides.
+// Passing empty settings clears all overrides.
+func (p *Project) UpdateFlakyDetectionSettings(settings FlakyDetectionSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	if settings.IsEmpty() {
+		delete(p.settings, FlakyDetectionSettingsKey)
+		p.updatedAt = time.Now()
+		return nil
+	}
+
+	p.SetSetting(FlakyDetectionSettingsKey, settings.ToSettingValue())
+	return nil
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, errors.New("must be a whole number")
+		}
+		return int(v), nil
+	default:
+		return 0, errors.New("must be a number")
+	}
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	default:
+		return 0, errors.New("must be a number")
+	}
+}
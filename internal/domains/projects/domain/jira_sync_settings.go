@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JiraSyncSettingsKey is the project settings key holding JIRA sync settings
+const JiraSyncSettingsKey = "jiraSync"
+
+// ErrInvalidJiraSyncSettings is returned when JIRA sync settings fail validation
+var ErrInvalidJiraSyncSettings = errors.New("invalid JIRA sync settings")
+
+// ReflakeAction is what Fern does to a linked JIRA issue when a resolved flaky test flakes again
+type ReflakeAction string
+
+const (
+	// ReflakeActionComment adds a comment to the linked issue
+	ReflakeActionComment ReflakeAction = "comment"
+	// ReflakeActionReopen reopens the linked issue when it is closed, and comments otherwise
+	ReflakeActionReopen ReflakeAction = "reopen"
+)
+
+// DefaultReflakeAction is used when a project has not configured one
+const DefaultReflakeAction = ReflakeActionComment
+
+// JiraSyncSettings holds per-project settings for syncing linked JIRA issues
+type JiraSyncSettings struct {
+	ReflakeAction ReflakeAction
+}
+
+// Validate checks that the settings hold supported values
+func (s JiraSyncSettings) Validate() error {
+	switch s.ReflakeAction {
+	case "", ReflakeActionComment, ReflakeActionReopen:
+		return nil
+	default:
+		return fmt.Errorf("reflakeAction must be %q or %q", ReflakeActionComment, ReflakeActionReopen)
+	}
+}
+
+// EffectiveReflakeAction returns the configured re-flake action, or the default when unset
+func (s JiraSyncSettings) EffectiveReflakeAction() ReflakeAction {
+	if s.ReflakeAction == "" {
+		return DefaultReflakeAction
+	}
+	return s.ReflakeAction
+}
+
+// ToSettingValue converts the settings to the JSON-friendly form stored in project settings
+func (s JiraSyncSettings) ToSettingValue() map[string]interface{} {
+	value := make(map[string]interface{})
+	if s.ReflakeAction != "" {
+		value["reflakeAction"] = string(s.ReflakeAction)
+	}
+	return value
+}
+
+// ParseJiraSyncSettings reads settings from a raw project setting value.
+// It accepts the map produced by ToSettingValue as well as its JSON-decoded form.
+func ParseJiraSyncSettings(raw interface{}) (JiraSyncSettings, error) {
+	var settings JiraSyncSettings
+	if raw == nil {
+		return settings, nil
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return settings, errors.New("JIRA sync settings must be an object")
+	}
+
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		switch key {
+		case "reflakeAction":
+			s, ok := value.(string)
+			if !ok {
+				return settings, errors.New("reflakeAction must be a string")
+			}
+			settings.ReflakeAction = ReflakeAction(s)
+		default:
+			return settings, fmt.Errorf("unknown JIRA sync setting %q", key)
+		}
+	}
+
+	return settings, nil
+}
+
+// JiraSyncSettings returns the project's JIRA sync settings
+func (p *Project) JiraSyncSettings() (JiraSyncSettings, error) {
+	raw, exists := p.settings[JiraSyncSettingsKey]
+	if !exists {
+		return JiraSyncSettings{}, nil
+	}
+	return ParseJiraSyncSettings(raw)
+}
+
+// UpdateJiraSyncSettings validates and stores JIRA sync settings.
+// Passing empty settings restores the defaults.
+func (p *Project) UpdateJiraSyncSettings(settings JiraSyncSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	if settings.ReflakeAction == "" {
+		delete(p.settings, JiraSyncSettingsKey)
+		p.updatedAt = time.Now()
+		return nil
+	}
+
+	p.SetSetting(JiraSyncSettingsKey, settings.ToSettingValue())
+	return nil
+}
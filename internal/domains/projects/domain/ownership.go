@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OwnershipRulesSettingsKey is the project settings key holding the raw ownership rules
+const OwnershipRulesSettingsKey = "ownershipRules"
+
+// ErrInvalidOwnershipRules is returned when ownership rules fail to parse
+var ErrInvalidOwnershipRules = errors.New("invalid ownership rules")
+
+// Limits applied when parsing ownership rules
+const (
+	MaxOwnershipRulesSize = 64 * 1024
+	MaxOwnershipRules     = 1000
+)
+
+// OwnershipField is the part of a spec an ownership rule is matched against
+type OwnershipField string
+
+const (
+	// OwnershipFieldAny matches the suite, spec, package or class name
+	OwnershipFieldAny     OwnershipField = ""
+	OwnershipFieldSuite   OwnershipField = "suite"
+	OwnershipFieldSpec    OwnershipField = "spec"
+	OwnershipFieldPackage OwnershipField = "package"
+	OwnershipFieldClass   OwnershipField = "class"
+	OwnershipFieldTag     OwnershipField = "tag"
+)
+
+// OwnershipRule assigns owners to specs whose field matches a glob pattern
+type OwnershipRule struct {
+	Field   OwnershipField
+	Pattern string
+	Owners  []string
+	Line    int
+
+	matcher *regexp.Regexp
+}
+
+// OwnershipSubject describes a spec being matched against ownership rules
+type OwnershipSubject struct {
+	SuiteName   string
+	SpecName    string
+	PackageName string
+	ClassName   string
+	Tags        []string
+}
+
+// OwnershipRules is a parsed set of CODEOWNERS-style rules.
+//
+// Each non-empty line holds a pattern followed by zero or more owners:
+//
+//	# comment
+//	*                          @qa-team
+//	suite:Checkout*            @payments
+//	package:com.acme.billing.* @billing @alice
+//	tag:smoke                  @release-team
+//
+// A pattern may be prefixed with suite:, spec:, package:, class: or tag:;
+// without a prefix it matches the suite, spec, package or class name.
+// Patterns support * (any characters) and ? (one character); spaces in a
+// pattern are escaped with a backslash, e.g. suite:Checkout\ Flow.
+// As with CODEOWNERS, the last matching rule wins and a rule without owners
+// leaves matching specs unowned.
+type OwnershipRules struct {
+	source string
+	rules  []OwnershipRule
+}
+
+// ParseOwnershipRules parses ownership rules from CODEOWNERS-style text
+func ParseOwnershipRules(text string) (*OwnershipRules, error) {
+	if len(text) > MaxOwnershipRulesSize {
+		return nil, fmt.Errorf("ownership rules must be at most %d bytes", MaxOwnershipRulesSize)
+	}
+
+	rules := &OwnershipRules{source: text}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 4096), MaxOwnershipRulesSize)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Strip trailing comments
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		rule, err := parseOwnershipRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		rule.Line = lineNumber
+
+		rules.rules = append(rules.rules, rule)
+		if len(rules.rules) > MaxOwnershipRules {
+			return nil, fmt.Errorf("at most %d ownership rules are allowed", MaxOwnershipRules)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ownership rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func parseOwnershipRule(line string) (OwnershipRule, error) {
+	fields := splitOwnershipRuleFields(line)
+	rule := OwnershipRule{Pattern: fields[0]}
+
+	if prefix, pattern, found := strings.Cut(fields[0], ":"); found {
+		switch field := OwnershipField(strings.ToLower(prefix)); field {
+		case OwnershipFieldSuite, OwnershipFieldSpec, OwnershipFieldPackage, OwnershipFieldClass, OwnershipFieldTag:
+			rule.Field = field
+			rule.Pattern = pattern
+		}
+	}
+	if rule.Pattern == "" {
+		return rule, errors.New("pattern is empty")
+	}
+
+	for _, owner := range fields[1:] {
+		if !isValidOwner(owner) {
+			return rule, fmt.Errorf("invalid owner %q: owners must be @team, @user or an email address", owner)
+		}
+		rule.Owners = append(rule.Owners, owner)
+	}
+
+	rule.matcher = globToRegexp(rule.Pattern)
+	return rule, nil
+}
+
+// splitOwnershipRuleFields splits a rule line on whitespace that is not escaped with a backslash
+func splitOwnershipRuleFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ' ' || r == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+func isValidOwner(owner string) bool {
+	if strings.HasPrefix(owner, "@") {
+		return len(owner) > 1
+	}
+	local, domain, found := strings.Cut(owner, "@")
+	return found && local != "" && strings.Contains(domain, ".")
+}
+
+func globToRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// Source returns the raw rules text
+func (r *OwnershipRules) Source() string { return r.source }
+
+// Rules returns the parsed rules in file order
+func (r *OwnershipRules) Rules() []OwnershipRule {
+	rules := make([]OwnershipRule, len(r.rules))
+	copy(rules, r.rules)
+	return rules
+}
+
+// IsEmpty returns true when no rules are defined
+func (r *OwnershipRules) IsEmpty() bool { return r == nil || len(r.rules) == 0 }
+
+// Owners returns the owners of the last rule matching the subject, or nil
+func (r *OwnershipRules) Owners(subject OwnershipSubject) []string {
+	if r == nil {
+		return nil
+	}
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if r.rules[i].Matches(subject) {
+			owners := make([]string, len(r.rules[i].Owners))
+			copy(owners, r.rules[i].Owners)
+			return owners
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to the subject
+func (rule OwnershipRule) Matches(subject OwnershipSubject) bool {
+	matcher := rule.matcher
+	if matcher == nil {
+		matcher = globToRegexp(rule.Pattern)
+	}
+
+	switch rule.Field {
+	case OwnershipFieldSuite:
+		return matcher.MatchString(subject.SuiteName)
+	case OwnershipFieldSpec:
+		return matcher.MatchString(subject.SpecName)
+	case OwnershipFieldPackage:
+		return subject.PackageName != "" && matcher.MatchString(subject.PackageName)
+	case OwnershipFieldClass:
+		return subject.ClassName != "" && matcher.MatchString(subject.ClassName)
+	case OwnershipFieldTag:
+		for _, tag := range subject.Tags {
+			if matcher.MatchString(tag) {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, name := range []string{subject.SuiteName, subject.SpecName, subject.PackageName, subject.ClassName} {
+			if name != "" && matcher.MatchString(name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OwnershipRules returns the project's parsed ownership rules, or nil when none are set
+func (p *Project) OwnershipRules() (*OwnershipRules, error) {
+	raw, exists := p.settings[OwnershipRulesSettingsKey]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+	text, ok := raw.(string)
+	if !ok {
+		return nil, errors.New("ownership rules must be a string")
+	}
+	return ParseOwnershipRules(text)
+}
+
+// UpdateOwnershipRules validates and stores ownership rules.
+// Passing rules without any entries clears them.
+func (p *Project) UpdateOwnershipRules(text string) error {
+	rules, err := ParseOwnershipRules(text)
+	if err != nil {
+		return err
+	}
+
+	if rules.IsEmpty() {
+		delete(p.settings, OwnershipRulesSettingsKey)
+		p.updatedAt = time.Now()
+		return nil
+	}
+
+	p.SetSetting(OwnershipRulesSettingsKey, text)
+	return nil
+}
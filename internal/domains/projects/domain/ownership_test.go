@@ -0,0 +1,128 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+)
+
+func TestProjectsDomain(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Projects Domain Suite")
+}
+
+var _ = Describe("OwnershipRules", Label("unit", "domain", "projects"), func() {
+	const rulesText = `
+# Default owners
+*                          @qa-team
+
+suite:Checkout*            @payments
+package:com.acme.billing.* @billing @alice  # billing code
+class:*IntegrationTest     @platform
+spec:*should\ retry*       @resilience
+tag:smoke                  @release-team
+suite:Checkout\ Legacy*
+`
+
+	var rules *domain.OwnershipRules
+
+	BeforeEach(func() {
+		var err error
+		rules, err = domain.ParseOwnershipRules(rulesText)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ParseOwnershipRules", func() {
+		It("should parse fields, patterns, owners and line numbers", func() {
+			parsed := rules.Rules()
+			Expect(parsed).To(HaveLen(7))
+			Expect(parsed[0].Field).To(Equal(domain.OwnershipFieldAny))
+			Expect(parsed[2].Field).To(Equal(domain.OwnershipFieldPackage))
+			Expect(parsed[2].Pattern).To(Equal("com.acme.billing.*"))
+			Expect(parsed[2].Owners).To(Equal([]string{"@billing", "@alice"}))
+			Expect(parsed[2].Line).To(Equal(6))
+			Expect(parsed[6].Owners).To(BeEmpty())
+		})
+
+		It("should accept email owners", func() {
+			parsed, err := domain.ParseOwnershipRules("spec:* dev@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Rules()[0].Owners).To(Equal([]string{"dev@example.com"}))
+		})
+
+		It("should reject invalid owners with the line number", func() {
+			_, err := domain.ParseOwnershipRules("*  @qa\nsuite:Login team-a")
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+			Expect(err).To(MatchError(ContainSubstring(`invalid owner "team-a"`)))
+		})
+
+		It("should reject an empty pattern", func() {
+			_, err := domain.ParseOwnershipRules("suite: @qa")
+			Expect(err).To(MatchError(ContainSubstring("pattern is empty")))
+		})
+
+		It("should reject oversized rules", func() {
+			_, err := domain.ParseOwnershipRules(strings.Repeat("a", domain.MaxOwnershipRulesSize+1))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Owners", func() {
+		It("should fall back to the catch-all rule", func() {
+			Expect(rules.Owners(domain.OwnershipSubject{SuiteName: "Login", SpecName: "logs in"})).
+				To(Equal([]string{"@qa-team"}))
+		})
+
+		It("should let the last matching rule win", func() {
+			subject := domain.OwnershipSubject{
+				SuiteName:   "Checkout Flow",
+				SpecName:    "pays",
+				PackageName: "com.acme.billing.invoices",
+			}
+			Expect(rules.Owners(subject)).To(Equal([]string{"@billing", "@alice"}))
+
+			subject.Tags = []string{"smoke"}
+			Expect(rules.Owners(subject)).To(Equal([]string{"@release-team"}))
+		})
+
+		It("should match spec and class names", func() {
+			Expect(rules.Owners(domain.OwnershipSubject{SpecName: "it should retry on timeout"})).
+				To(Equal([]string{"@resilience"}))
+			Expect(rules.Owners(domain.OwnershipSubject{ClassName: "OrderIntegrationTest"})).
+				To(Equal([]string{"@platform"}))
+		})
+
+		It("should leave specs unowned when the last matching rule has no owners", func() {
+			Expect(rules.Owners(domain.OwnershipSubject{SuiteName: "Checkout Legacy v1"})).To(BeEmpty())
+		})
+
+		It("should treat ? as a single character", func() {
+			parsed, err := domain.ParseOwnershipRules("suite:v? @versions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Owners(domain.OwnershipSubject{SuiteName: "v1"})).To(Equal([]string{"@versions"}))
+			Expect(parsed.Owners(domain.OwnershipSubject{SuiteName: "v10"})).To(BeNil())
+		})
+	})
+
+	Describe("Project ownership rules", func() {
+		It("should store and clear rules on the project", func() {
+			project, err := domain.NewProject("proj-1", "Project", "fern")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(project.UpdateOwnershipRules("suite:* @qa")).To(Succeed())
+			stored, err := project.OwnershipRules()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Source()).To(Equal("suite:* @qa"))
+
+			Expect(project.UpdateOwnershipRules("# nothing here\n")).To(Succeed())
+			stored, err = project.OwnershipRules()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(BeNil())
+			Expect(project.ToSnapshot().Settings).NotTo(HaveKey(domain.OwnershipRulesSettingsKey))
+		})
+	})
+})
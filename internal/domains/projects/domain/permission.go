@@ -2,9 +2,19 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+var (
+	// ErrInvalidPermission is returned for unknown permission types
+	ErrInvalidPermission = errors.New("invalid permission type")
+
+	// ErrPermissionNotFound is returned when revoking a permission that was not granted
+	ErrPermissionNotFound = errors.New("permission not found")
+)
+
 // PermissionType represents the type of permission
 type PermissionType string
 
@@ -15,10 +25,29 @@ const (
 	PermissionAdmin  PermissionType = "admin"
 )
 
-// ProjectPermission represents a permission granted to a user for a project
+// permissionRanks orders permission types; each includes the ones below it
+var permissionRanks = map[PermissionType]int{
+	PermissionRead:   1,
+	PermissionWrite:  2,
+	PermissionDelete: 3,
+	PermissionAdmin:  4,
+}
+
+// ParsePermissionType parses a permission type
+func ParsePermissionType(s string) (PermissionType, error) {
+	p := PermissionType(strings.ToLower(strings.TrimSpace(s)))
+	if !isValidPermission(p) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPermission, s)
+	}
+	return p, nil
+}
+
+// ProjectPermission represents a permission granted for a project to a user,
+// or to every member of a group
 type ProjectPermission struct {
 	projectID  ProjectID
 	userID     string
+	groupName  string
 	permission PermissionType
 	grantedBy  string
 	grantedAt  time.Time
@@ -37,7 +66,7 @@ func NewProjectPermission(projectID ProjectID, userID string, permission Permiss
 		return nil, errors.New("granted by cannot be empty")
 	}
 	if !isValidPermission(permission) {
-		return nil, errors.New("invalid permission type")
+		return nil, ErrInvalidPermission
 	}
 
 	return &ProjectPermission{
@@ -49,16 +78,85 @@ func NewProjectPermission(projectID ProjectID, userID string, permission Permiss
 	}, nil
 }
 
+// NewProjectGroupPermission creates a new project permission for the members of a group
+func NewProjectGroupPermission(projectID ProjectID, groupName string, permission PermissionType, grantedBy string) (*ProjectPermission, error) {
+	groupName = NormalizeGroupName(groupName)
+	if projectID == "" {
+		return nil, errors.New("project ID cannot be empty")
+	}
+	if groupName == "" {
+		return nil, errors.New("group name cannot be empty")
+	}
+	if grantedBy == "" {
+		return nil, errors.New("granted by cannot be empty")
+	}
+	if !isValidPermission(permission) {
+		return nil, ErrInvalidPermission
+	}
+
+	return &ProjectPermission{
+		projectID:  projectID,
+		groupName:  groupName,
+		permission: permission,
+		grantedBy:  grantedBy,
+		grantedAt:  time.Now(),
+	}, nil
+}
+
+// ReconstructProjectPermission rebuilds a stored permission, including one that has expired.
+// Exactly one of userID and groupName is set.
+func ReconstructProjectPermission(projectID ProjectID, userID, groupName string, permission PermissionType, grantedBy string, grantedAt time.Time, expiresAt *time.Time) *ProjectPermission {
+	return &ProjectPermission{
+		projectID:  projectID,
+		userID:     userID,
+		groupName:  groupName,
+		permission: permission,
+		grantedBy:  grantedBy,
+		grantedAt:  grantedAt,
+		expiresAt:  expiresAt,
+	}
+}
+
+// NormalizeGroupName strips the leading slash identity providers put on group paths
+func NormalizeGroupName(groupName string) string {
+	return strings.TrimPrefix(strings.TrimSpace(groupName), "/")
+}
+
 // ProjectID returns the project ID
 func (pp *ProjectPermission) ProjectID() ProjectID {
 	return pp.projectID
 }
 
-// UserID returns the user ID
+// UserID returns the user ID, empty for group permissions
 func (pp *ProjectPermission) UserID() string {
 	return pp.userID
 }
 
+// GroupName returns the group name, empty for user permissions
+func (pp *ProjectPermission) GroupName() string {
+	return pp.groupName
+}
+
+// IsGroupPermission checks if the permission is granted to a group
+func (pp *ProjectPermission) IsGroupPermission() bool {
+	return pp.groupName != ""
+}
+
+// GrantedBy returns who granted the permission
+func (pp *ProjectPermission) GrantedBy() string {
+	return pp.grantedBy
+}
+
+// GrantedAt returns when the permission was granted
+func (pp *ProjectPermission) GrantedAt() time.Time {
+	return pp.grantedAt
+}
+
+// ExpiresAt returns when the permission expires, nil if it does not
+func (pp *ProjectPermission) ExpiresAt() *time.Time {
+	return pp.expiresAt
+}
+
 // Permission returns the permission type
 func (pp *ProjectPermission) Permission() PermissionType {
 	return pp.permission
@@ -81,6 +179,15 @@ func (pp *ProjectPermission) IsExpired() bool {
 	return time.Now().After(*pp.expiresAt)
 }
 
+// Allows checks if the permission includes the required permission and has not expired
+func (pp *ProjectPermission) Allows(required PermissionType) bool {
+	if pp.IsExpired() {
+		return false
+	}
+	rank, ok := permissionRanks[required]
+	return ok && permissionRanks[pp.permission] >= rank
+}
+
 // CanRead checks if the permission allows reading
 func (pp *ProjectPermission) CanRead() bool {
 	if pp.IsExpired() {
@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// ErrProjectNotFound is returned when a project does not exist
+var ErrProjectNotFound = errors.New("project not found")
+
 // ProjectID represents a unique identifier for a project
 type ProjectID string
 
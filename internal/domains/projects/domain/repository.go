@@ -33,21 +33,28 @@ type ProjectRepository interface {
 
 // ProjectPermissionRepository defines the interface for project permission persistence
 type ProjectPermissionRepository interface {
-	// Save persists a project permission
+	// Save persists a user or group permission, replacing the grant and
+	// expiry of the same permission if it was already granted
 	Save(ctx context.Context, permission *ProjectPermission) error
 
 	// FindByProjectAndUser retrieves permissions for a specific project and user
 	FindByProjectAndUser(ctx context.Context, projectID ProjectID, userID string) ([]*ProjectPermission, error)
 
+	// FindByProjectAndGroups retrieves permissions granted to any of the groups for a project
+	FindByProjectAndGroups(ctx context.Context, projectID ProjectID, groupNames []string) ([]*ProjectPermission, error)
+
 	// FindByUser retrieves all permissions for a user
 	FindByUser(ctx context.Context, userID string) ([]*ProjectPermission, error)
 
-	// FindByProject retrieves all permissions for a project
+	// FindByProject retrieves all user and group permissions for a project
 	FindByProject(ctx context.Context, projectID ProjectID) ([]*ProjectPermission, error)
 
 	// Delete removes a permission
 	Delete(ctx context.Context, projectID ProjectID, userID string, permission PermissionType) error
 
-	// DeleteExpired removes all expired permissions
-	DeleteExpired(ctx context.Context) error
+	// DeleteGroup removes a permission granted to a group
+	DeleteGroup(ctx context.Context, projectID ProjectID, groupName string, permission PermissionType) error
+
+	// DeleteExpired removes all expired user and group permissions and returns how many were removed
+	DeleteExpired(ctx context.Context) (int64, error)
 }
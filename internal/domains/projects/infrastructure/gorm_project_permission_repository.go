@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,6 +21,8 @@ type ProjectPermissionDB struct {
 	ExpiresAt  *time.Time
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	// DeletedAt is set when the user is deleted
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName specifies the table name
@@ -27,6 +30,24 @@ func (ProjectPermissionDB) TableName() string {
 	return "project_permissions"
 }
 
+// ProjectGroupPermissionDB represents the database model for project permissions granted to groups
+type ProjectGroupPermissionDB struct {
+	ID         uint   `gorm:"primaryKey"`
+	ProjectID  string `gorm:"index:idx_project_group_permission,unique"`
+	GroupName  string `gorm:"index:idx_project_group_permission,unique"`
+	Permission string `gorm:"index:idx_project_group_permission,unique"`
+	GrantedBy  string
+	GrantedAt  time.Time
+	ExpiresAt  *time.Time `gorm:"index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName specifies the table name
+func (ProjectGroupPermissionDB) TableName() string {
+	return "project_group_permissions"
+}
+
 // GormProjectPermissionRepository is a GORM implementation of ProjectPermissionRepository
 type GormProjectPermissionRepository struct {
 	db *gorm.DB
@@ -37,24 +58,57 @@ func NewGormProjectPermissionRepository(db *gorm.DB) *GormProjectPermissionRepos
 	return &GormProjectPermissionRepository{db: db}
 }
 
-// Save persists a project permission
+// Save persists a project permission. Granting a permission again replaces
+// who granted it and when it expires.
 func (r *GormProjectPermissionRepository) Save(ctx context.Context, permission *domain.ProjectPermission) error {
-	dbPermission := &ProjectPermissionDB{
-		ProjectID:  string(permission.ProjectID()),
-		UserID:     permission.UserID(),
-		Permission: string(permission.Permission()),
-		GrantedBy:  permission.UserID(), // Note: domain model doesn't expose GrantedBy
-		GrantedAt:  time.Now(),
+	if permission.IsGroupPermission() {
+		return r.saveGroupPermission(ctx, permission)
 	}
 
-	// Check if permission is expired and set expiration
-	// Note: This is a limitation of the current domain model which doesn't expose expiration directly
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing ProjectPermissionDB
+		err := tx.Where("project_id = ? AND user_id = ? AND permission = ?",
+			string(permission.ProjectID()), permission.UserID(), string(permission.Permission())).
+			First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find project permission: %w", err)
+		}
+
+		existing.ProjectID = string(permission.ProjectID())
+		existing.UserID = permission.UserID()
+		existing.Permission = string(permission.Permission())
+		existing.GrantedBy = permission.GrantedBy()
+		existing.GrantedAt = permission.GrantedAt()
+		existing.ExpiresAt = permission.ExpiresAt()
+		if err := tx.Save(&existing).Error; err != nil {
+			return fmt.Errorf("failed to save project permission: %w", err)
+		}
+		return nil
+	})
+}
 
-	if err := r.db.WithContext(ctx).Create(dbPermission).Error; err != nil {
-		return fmt.Errorf("failed to save project permission: %w", err)
-	}
+// saveGroupPermission persists a permission granted to a group
+func (r *GormProjectPermissionRepository) saveGroupPermission(ctx context.Context, permission *domain.ProjectPermission) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing ProjectGroupPermissionDB
+		err := tx.Where("project_id = ? AND group_name = ? AND permission = ?",
+			string(permission.ProjectID()), permission.GroupName(), string(permission.Permission())).
+			First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find project group permission: %w", err)
+		}
 
-	return nil
+		existing.ProjectID = string(permission.ProjectID())
+		existing.GroupName = permission.GroupName()
+		existing.Permission = string(permission.Permission())
+		existing.GrantedBy = permission.GrantedBy()
+		existing.GrantedAt = permission.GrantedAt()
+		existing.ExpiresAt = permission.ExpiresAt()
+		if err := tx.Save(&existing).Error; err != nil {
+			return fmt.Errorf("failed to save project group permission: %w", err)
+		}
+		return nil
+	})
 }
 
 // FindByProjectAndUser retrieves permissions for a specific project and user
@@ -66,16 +120,23 @@ func (r *GormProjectPermissionRepository) FindByProjectAndUser(ctx context.Conte
 		return nil, fmt.Errorf("failed to find permissions: %w", err)
 	}
 
-	permissions := make([]*domain.ProjectPermission, 0, len(dbPermissions))
-	for _, dbPerm := range dbPermissions {
-		perm, err := r.toDomainModel(&dbPerm)
-		if err != nil {
-			return nil, err
-		}
-		permissions = append(permissions, perm)
+	return r.toDomainModels(dbPermissions, nil), nil
+}
+
+// FindByProjectAndGroups retrieves permissions granted to any of the groups for a project
+func (r *GormProjectPermissionRepository) FindByProjectAndGroups(ctx context.Context, projectID domain.ProjectID, groupNames []string) ([]*domain.ProjectPermission, error) {
+	if len(groupNames) == 0 {
+		return []*domain.ProjectPermission{}, nil
 	}
 
-	return permissions, nil
+	var dbPermissions []ProjectGroupPermissionDB
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND group_name IN ?", string(projectID), groupNames).
+		Find(&dbPermissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find group permissions: %w", err)
+	}
+
+	return r.toDomainModels(nil, dbPermissions), nil
 }
 
 // FindByUser retrieves all permissions for a user
@@ -87,78 +148,103 @@ func (r *GormProjectPermissionRepository) FindByUser(ctx context.Context, userID
 		return nil, fmt.Errorf("failed to find user permissions: %w", err)
 	}
 
-	permissions := make([]*domain.ProjectPermission, 0, len(dbPermissions))
-	for _, dbPerm := range dbPermissions {
-		perm, err := r.toDomainModel(&dbPerm)
-		if err != nil {
-			return nil, err
-		}
-		permissions = append(permissions, perm)
-	}
-
-	return permissions, nil
+	return r.toDomainModels(dbPermissions, nil), nil
 }
 
-// FindByProject retrieves all permissions for a project
+// FindByProject retrieves all user and group permissions for a project
 func (r *GormProjectPermissionRepository) FindByProject(ctx context.Context, projectID domain.ProjectID) ([]*domain.ProjectPermission, error) {
 	var dbPermissions []ProjectPermissionDB
 	if err := r.db.WithContext(ctx).
 		Where("project_id = ?", string(projectID)).
+		Order("user_id, permission").
 		Find(&dbPermissions).Error; err != nil {
 		return nil, fmt.Errorf("failed to find project permissions: %w", err)
 	}
 
-	permissions := make([]*domain.ProjectPermission, 0, len(dbPermissions))
-	for _, dbPerm := range dbPermissions {
-		perm, err := r.toDomainModel(&dbPerm)
-		if err != nil {
-			return nil, err
-		}
-		permissions = append(permissions, perm)
+	var dbGroupPermissions []ProjectGroupPermissionDB
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", string(projectID)).
+		Order("group_name, permission").
+		Find(&dbGroupPermissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find project group permissions: %w", err)
 	}
 
-	return permissions, nil
+	return r.toDomainModels(dbPermissions, dbGroupPermissions), nil
 }
 
 // Delete removes a permission
 func (r *GormProjectPermissionRepository) Delete(ctx context.Context, projectID domain.ProjectID, userID string, permission domain.PermissionType) error {
-	if err := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).Unscoped().
 		Where("project_id = ? AND user_id = ? AND permission = ?", string(projectID), userID, string(permission)).
-		Delete(&ProjectPermissionDB{}).Error; err != nil {
-		return fmt.Errorf("failed to delete permission: %w", err)
+		Delete(&ProjectPermissionDB{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete permission: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPermissionNotFound
 	}
 	return nil
 }
 
-// DeleteExpired removes all expired permissions
-func (r *GormProjectPermissionRepository) DeleteExpired(ctx context.Context) error {
-	if err := r.db.WithContext(ctx).
-		Where("expires_at IS NOT NULL AND expires_at < ?", time.Now()).
-		Delete(&ProjectPermissionDB{}).Error; err != nil {
-		return fmt.Errorf("failed to delete expired permissions: %w", err)
+// DeleteGroup removes a permission granted to a group
+func (r *GormProjectPermissionRepository) DeleteGroup(ctx context.Context, projectID domain.ProjectID, groupName string, permission domain.PermissionType) error {
+	result := r.db.WithContext(ctx).
+		Where("project_id = ? AND group_name = ? AND permission = ?", string(projectID), groupName, string(permission)).
+		Delete(&ProjectGroupPermissionDB{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete group permission: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPermissionNotFound
 	}
 	return nil
 }
 
-// toDomainModel converts a database model to a domain model
-func (r *GormProjectPermissionRepository) toDomainModel(dbPerm *ProjectPermissionDB) (*domain.ProjectPermission, error) {
-	perm, err := domain.NewProjectPermission(
-		domain.ProjectID(dbPerm.ProjectID),
-		dbPerm.UserID,
-		domain.PermissionType(dbPerm.Permission),
-		dbPerm.GrantedBy,
-	)
-	if err != nil {
-		return nil, err
+// DeleteExpired removes all expired user and group permissions
+func (r *GormProjectPermissionRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	now := time.Now()
+
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("expires_at IS NOT NULL AND expires_at < ?", now).
+		Delete(&ProjectPermissionDB{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired permissions: %w", result.Error)
 	}
+	removed := result.RowsAffected
 
-	// Set expiration if exists
-	if dbPerm.ExpiresAt != nil {
-		if err := perm.SetExpiration(*dbPerm.ExpiresAt); err != nil {
-			// Ignore error if expiration is in the past
-			// The domain model will handle this appropriately
-		}
+	result = r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at < ?", now).
+		Delete(&ProjectGroupPermissionDB{})
+	if result.Error != nil {
+		return removed, fmt.Errorf("failed to delete expired group permissions: %w", result.Error)
 	}
+	return removed + result.RowsAffected, nil
+}
 
-	return perm, nil
+// toDomainModels converts user and group permission database models to domain models
+func (r *GormProjectPermissionRepository) toDomainModels(dbPermissions []ProjectPermissionDB, dbGroupPermissions []ProjectGroupPermissionDB) []*domain.ProjectPermission {
+	permissions := make([]*domain.ProjectPermission, 0, len(dbPermissions)+len(dbGroupPermissions))
+	for _, dbPerm := range dbPermissions {
+		permissions = append(permissions, domain.ReconstructProjectPermission(
+			domain.ProjectID(dbPerm.ProjectID),
+			dbPerm.UserID,
+			"",
+			domain.PermissionType(dbPerm.Permission),
+			dbPerm.GrantedBy,
+			dbPerm.GrantedAt,
+			dbPerm.ExpiresAt,
+		))
+	}
+	for _, dbPerm := range dbGroupPermissions {
+		permissions = append(permissions, domain.ReconstructProjectPermission(
+			domain.ProjectID(dbPerm.ProjectID),
+			"",
+			dbPerm.GroupName,
+			domain.PermissionType(dbPerm.Permission),
+			dbPerm.GrantedBy,
+			dbPerm.GrantedAt,
+			dbPerm.ExpiresAt,
+		))
+	}
+	return permissions
 }
@@ -0,0 +1,101 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/projects/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupPermissionRepository(t *testing.T) (*infrastructure.GormProjectPermissionRepository, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&infrastructure.ProjectPermissionDB{}, &infrastructure.ProjectGroupPermissionDB{}))
+	return infrastructure.NewGormProjectPermissionRepository(db), db
+}
+
+func TestGormProjectPermissionRepository_Save(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupPermissionRepository(t)
+
+	permission, err := domain.NewProjectPermission("shop", "u1", domain.PermissionWrite, "admin-1")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, permission))
+
+	// Granting again replaces the expiry and who granted it
+	regrant, err := domain.NewProjectPermission("shop", "u1", domain.PermissionWrite, "admin-2")
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(t, regrant.SetExpiration(expiresAt))
+	require.NoError(t, repo.Save(ctx, regrant))
+
+	var count int64
+	require.NoError(t, db.Model(&infrastructure.ProjectPermissionDB{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	permissions, err := repo.FindByProjectAndUser(ctx, "shop", "u1")
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, "admin-2", permissions[0].GrantedBy())
+	require.NotNil(t, permissions[0].ExpiresAt())
+	assert.WithinDuration(t, expiresAt, *permissions[0].ExpiresAt(), time.Second)
+}
+
+func TestGormProjectPermissionRepository_GroupPermissions(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := setupPermissionRepository(t)
+
+	user, _ := domain.NewProjectPermission("shop", "u1", domain.PermissionRead, "admin-1")
+	group, _ := domain.NewProjectGroupPermission("shop", "/qa", domain.PermissionWrite, "admin-1")
+	other, _ := domain.NewProjectGroupPermission("search", "qa", domain.PermissionAdmin, "admin-1")
+	for _, permission := range []*domain.ProjectPermission{user, group, other} {
+		require.NoError(t, repo.Save(ctx, permission))
+	}
+
+	permissions, err := repo.FindByProjectAndGroups(ctx, "shop", []string{"qa", "ops"})
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, "qa", permissions[0].GroupName())
+	assert.Equal(t, domain.PermissionWrite, permissions[0].Permission())
+
+	permissions, err = repo.FindByProject(ctx, "shop")
+	require.NoError(t, err)
+	assert.Len(t, permissions, 2)
+
+	require.NoError(t, repo.DeleteGroup(ctx, "shop", "qa", domain.PermissionWrite))
+	assert.ErrorIs(t, repo.DeleteGroup(ctx, "shop", "qa", domain.PermissionWrite), domain.ErrPermissionNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, "shop", "u1", domain.PermissionAdmin), domain.ErrPermissionNotFound)
+}
+
+func TestGormProjectPermissionRepository_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupPermissionRepository(t)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	require.NoError(t, db.Create(&infrastructure.ProjectPermissionDB{ProjectID: "shop", UserID: "u1", Permission: "read", ExpiresAt: &past}).Error)
+	require.NoError(t, db.Create(&infrastructure.ProjectPermissionDB{ProjectID: "shop", UserID: "u2", Permission: "read", ExpiresAt: &future}).Error)
+	require.NoError(t, db.Create(&infrastructure.ProjectPermissionDB{ProjectID: "shop", UserID: "u3", Permission: "read"}).Error)
+	require.NoError(t, db.Create(&infrastructure.ProjectGroupPermissionDB{ProjectID: "shop", GroupName: "qa", Permission: "write", ExpiresAt: &past}).Error)
+
+	// Expired permissions no longer apply before they are removed
+	permissions, err := repo.FindByProjectAndUser(ctx, "shop", "u1")
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.False(t, permissions[0].Allows(domain.PermissionRead))
+
+	removed, err := repo.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	permissions, err = repo.FindByProject(ctx, "shop")
+	require.NoError(t, err)
+	assert.Len(t, permissions, 2)
+}
@@ -55,7 +55,7 @@ func (r *GormProjectRepository) FindByID(ctx context.Context, id uint) (*domain.
 	var dbProject database.ProjectDetails
 	if err := r.db.WithContext(ctx).First(&dbProject, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("project not found")
+			return nil, domain.ErrProjectNotFound
 		}
 		return nil, fmt.Errorf("failed to find project: %w", err)
 	}
@@ -68,7 +68,7 @@ func (r *GormProjectRepository) FindByProjectID(ctx context.Context, projectID d
 	var dbProject database.ProjectDetails
 	if err := r.db.WithContext(ctx).Where("project_id = ?", string(projectID)).First(&dbProject).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("project not found")
+			return nil, domain.ErrProjectNotFound
 		}
 		return nil, fmt.Errorf("failed to find project: %w", err)
 	}
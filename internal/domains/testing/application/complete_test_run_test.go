@@ -53,6 +53,21 @@ func (m *mockFlakyRepo) FindByTestName(ctx context.Context, projectID, testName
 	return nil, nil
 }
 func (m *mockFlakyRepo) Update(ctx context.Context, flakyTest *domain.FlakyTest) error { return nil }
+func (m *mockFlakyRepo) FindByID(ctx context.Context, id uint) (*domain.FlakyTest, error) {
+	return nil, nil
+}
+func (m *mockFlakyRepo) FindByStatus(ctx context.Context, statuses ...domain.FlakyStatus) ([]*domain.FlakyTest, error) {
+	return nil, nil
+}
+func (m *mockFlakyRepo) GetStatusHistory(ctx context.Context, flakyTestID uint) ([]domain.FlakyStatusChange, error) {
+	return nil, nil
+}
+func (m *mockFlakyRepo) FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]domain.SpecExecution, error) {
+	return nil, nil
+}
+func (m *mockFlakyRepo) FindByFilter(ctx context.Context, filter domain.FlakyTestFilter) ([]*domain.FlakyTest, error) {
+	return nil, nil
+}
 
 // Note: Suite entry point defined in test_run_service_test.go (TestApplication)
 
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// EventPublisher is told about test runs and flaky test status changes, e.g.
+// to notify webhook subscribers. Publishing must not fail the change itself,
+// so implementations handle their own errors.
+type EventPublisher interface {
+	// TestRunCreated is called once a test run is saved
+	TestRunCreated(ctx context.Context, testRun *domain.TestRun)
+
+	// TestRunCompleted is called once a test run has finished
+	TestRunCompleted(ctx context.Context, testRun *domain.TestRun)
+
+	// FlakyTestStatusChanged is called once a status change of a flaky test is saved
+	FlakyTestStatusChanged(ctx context.Context, flakyTest *domain.FlakyTest, change domain.FlakyStatusChange)
+}
+
+// SetEventPublisher enables publishing test runs as they are created and completed
+func (s *TestRunService) SetEventPublisher(publisher EventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// publishCreated publishes a newly created test run. Runs ingested with their
+// results are already finished, so they are published as completed too.
+func (s *TestRunService) publishCreated(ctx context.Context, testRun *domain.TestRun) {
+	if s.eventPublisher == nil {
+		return
+	}
+	s.eventPublisher.TestRunCreated(ctx, testRun)
+	if isFinishedStatus(testRun.Status) {
+		s.eventPublisher.TestRunCompleted(ctx, testRun)
+	}
+}
+
+// publishCompleted publishes a test run that has finished
+func (s *TestRunService) publishCompleted(ctx context.Context, testRun *domain.TestRun) {
+	if s.eventPublisher != nil && isFinishedStatus(testRun.Status) {
+		s.eventPublisher.TestRunCompleted(ctx, testRun)
+	}
+}
+
+// isFinishedStatus returns whether a test run with the status has finished
+func isFinishedStatus(status string) bool {
+	return status != "" && status != "running" && status != "pending"
+}
+
+// publishingFlakyTestRepository publishes the status changes of flaky tests once they are saved
+type publishingFlakyTestRepository struct {
+	domain.FlakyTestRepository
+	publisher EventPublisher
+}
+
+// NewPublishingFlakyTestRepository wraps a flaky test repository so every
+// saved status change is published, whichever service made it
+func NewPublishingFlakyTestRepository(repo domain.FlakyTestRepository, publisher EventPublisher) domain.FlakyTestRepository {
+	return &publishingFlakyTestRepository{FlakyTestRepository: repo, publisher: publisher}
+}
+
+// Update saves the flaky test and publishes its pending status changes
+func (r *publishingFlakyTestRepository) Update(ctx context.Context, flakyTest *domain.FlakyTest) error {
+	// The repository clears pending changes once they are saved
+	changes := append([]domain.FlakyStatusChange(nil), flakyTest.PendingStatusChanges()...)
+	if err := r.FlakyTestRepository.Update(ctx, flakyTest); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		r.publisher.FlakyTestStatusChanged(ctx, flakyTest, change)
+	}
+	return nil
+}
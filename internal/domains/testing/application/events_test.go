@@ -0,0 +1,128 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// recordingEventPublisher records the events it is told about
+type recordingEventPublisher struct {
+	created      []*domain.TestRun
+	completed    []*domain.TestRun
+	flakyChanges []domain.FlakyStatusChange
+}
+
+func (p *recordingEventPublisher) TestRunCreated(ctx context.Context, testRun *domain.TestRun) {
+	p.created = append(p.created, testRun)
+}
+
+func (p *recordingEventPublisher) TestRunCompleted(ctx context.Context, testRun *domain.TestRun) {
+	p.completed = append(p.completed, testRun)
+}
+
+func (p *recordingEventPublisher) FlakyTestStatusChanged(ctx context.Context, flakyTest *domain.FlakyTest, change domain.FlakyStatusChange) {
+	p.flakyChanges = append(p.flakyChanges, change)
+}
+
+var _ = Describe("Event publishing", Label("unit", "application", "testing"), func() {
+	var (
+		ctx       context.Context
+		publisher *recordingEventPublisher
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		publisher = &recordingEventPublisher{}
+	})
+
+	Describe("TestRunService", func() {
+		var (
+			service         *application.TestRunService
+			mockTestRunRepo *MockTestRunRepository
+		)
+
+		BeforeEach(func() {
+			mockTestRunRepo = new(MockTestRunRepository)
+			service = application.NewTestRunService(mockTestRunRepo, new(MockSuiteRunRepository), new(MockSpecRunRepository))
+			service.SetEventPublisher(publisher)
+		})
+
+		It("should publish a running test run as created only", func() {
+			testRun := &domain.TestRun{RunID: "run-1", ProjectID: "proj-1", Status: "running", StartTime: time.Now()}
+			mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+			_, _, err := service.CreateTestRun(ctx, testRun)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(publisher.created).To(ConsistOf(testRun))
+			Expect(publisher.completed).To(BeEmpty())
+		})
+
+		It("should publish a finished test run as created and completed", func() {
+			testRun := &domain.TestRun{RunID: "run-1", ProjectID: "proj-1", Status: "failed", FailedTests: 2, StartTime: time.Now()}
+			mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+			_, _, err := service.CreateTestRun(ctx, testRun)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(publisher.created).To(ConsistOf(testRun))
+			Expect(publisher.completed).To(ConsistOf(testRun))
+		})
+
+		It("should not publish a test run that was not saved", func() {
+			testRun := &domain.TestRun{RunID: "run-1", ProjectID: "proj-1", Status: "passed"}
+			mockTestRunRepo.On("Create", ctx, testRun).Return(errors.New("database error"))
+
+			_, _, err := service.CreateTestRun(ctx, testRun)
+
+			Expect(err).To(HaveOccurred())
+			Expect(publisher.created).To(BeEmpty())
+			Expect(publisher.completed).To(BeEmpty())
+		})
+	})
+
+	Describe("FlakyLifecycleService", func() {
+		var (
+			repo    *mockLifecycleFlakyRepo
+			service *application.FlakyLifecycleService
+		)
+
+		BeforeEach(func() {
+			repo = new(mockLifecycleFlakyRepo)
+			service = application.NewFlakyLifecycleService(repo, func(ctx context.Context, projectID string) int { return 10 })
+			service.SetEventPublisher(publisher)
+		})
+
+		It("should publish a status change once it is saved", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusActive, time.Now())
+			repo.On("FindByID", ctx, uint(5)).Return(flakyTest, nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			_, err := service.ResolveFlakyTest(ctx, 5, "user-1", "fixed race condition")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(publisher.flakyChanges).To(HaveLen(1))
+			Expect(publisher.flakyChanges[0].FromStatus).To(Equal(domain.FlakyStatusActive))
+			Expect(publisher.flakyChanges[0].ToStatus).To(Equal(domain.FlakyStatusResolved))
+			Expect(publisher.flakyChanges[0].Actor).To(Equal(domain.UserActor("user-1")))
+		})
+
+		It("should not publish a status change that failed to save", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusActive, time.Now())
+			repo.On("FindByID", ctx, uint(5)).Return(flakyTest, nil)
+			repo.On("Update", ctx, flakyTest).Return(errors.New("database error"))
+
+			_, err := service.ResolveFlakyTest(ctx, 5, "user-1", "")
+
+			Expect(err).To(HaveOccurred())
+			Expect(publisher.flakyChanges).To(BeEmpty())
+		})
+	})
+})
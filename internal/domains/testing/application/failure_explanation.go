@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
+)
+
+const (
+	// explanationHistoryLimit is the number of previous executions included as context
+	explanationHistoryLimit = 10
+
+	// MaxExplanationClusterSize bounds the number of spec runs explained together
+	MaxExplanationClusterSize = 50
+)
+
+// FailureExplainer generates natural language explanations of failures
+type FailureExplainer interface {
+	ExplainCluster(ctx context.Context, failures []llm.FailureContext) (*llm.Explanation, error)
+}
+
+// FailureExplanationService explains failed spec runs on demand using the
+// failure details and the recent history of the test
+type FailureExplanationService struct {
+	testRunRepo  domain.TestRunRepository
+	suiteRunRepo domain.SuiteRunRepository
+	specRunRepo  domain.SpecRunRepository
+	flakyRepo    domain.FlakyTestRepository
+	explainer    FailureExplainer
+}
+
+// NewFailureExplanationService creates a new failure explanation service
+func NewFailureExplanationService(
+	testRunRepo domain.TestRunRepository,
+	suiteRunRepo domain.SuiteRunRepository,
+	specRunRepo domain.SpecRunRepository,
+	flakyRepo domain.FlakyTestRepository,
+	explainer FailureExplainer,
+) *FailureExplanationService {
+	return &FailureExplanationService{
+		testRunRepo:  testRunRepo,
+		suiteRunRepo: suiteRunRepo,
+		specRunRepo:  specRunRepo,
+		flakyRepo:    flakyRepo,
+		explainer:    explainer,
+	}
+}
+
+// GetFailures loads spec runs together with their suite and test run context.
+// It does not check project access: callers must check that the user may read
+// each failure's project before requesting an explanation.
+func (s *FailureExplanationService) GetFailures(ctx context.Context, specRunIDs []uint) ([]*domain.SpecFailure, error) {
+	if len(specRunIDs) == 0 {
+		return nil, fmt.Errorf("at least one spec run is required")
+	}
+	if len(specRunIDs) > MaxExplanationClusterSize {
+		return nil, fmt.Errorf("at most %d spec runs can be explained together", MaxExplanationClusterSize)
+	}
+
+	suites := make(map[uint]*domain.SuiteRun)
+	testRuns := make(map[uint]*domain.TestRun)
+	failures := make([]*domain.SpecFailure, 0, len(specRunIDs))
+	for _, id := range specRunIDs {
+		specRun, err := s.specRunRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spec run %d: %w", id, err)
+		}
+		if specRun.Status != "failed" && !specRun.IsFlaky {
+			return nil, fmt.Errorf("spec run %d did not fail", id)
+		}
+
+		suite, ok := suites[specRun.SuiteRunID]
+		if !ok {
+			if suite, err = s.suiteRunRepo.GetByID(ctx, specRun.SuiteRunID); err != nil {
+				return nil, fmt.Errorf("failed to get suite run %d: %w", specRun.SuiteRunID, err)
+			}
+			suites[specRun.SuiteRunID] = suite
+		}
+
+		testRun, ok := testRuns[suite.TestRunID]
+		if !ok {
+			if testRun, err = s.testRunRepo.GetByID(ctx, suite.TestRunID); err != nil {
+				return nil, fmt.Errorf("failed to get test run %d: %w", suite.TestRunID, err)
+			}
+			testRuns[suite.TestRunID] = testRun
+		}
+
+		failures = append(failures, &domain.SpecFailure{
+			SpecRun:   specRun,
+			SuiteName: suite.Name,
+			TestRunID: testRun.RunID,
+			ProjectID: testRun.ProjectID,
+			Branch:    testRun.Branch,
+		})
+	}
+	return failures, nil
+}
+
+// Explain generates an explanation for one failure, or for a cluster of related
+// failures with the first treated as representative
+func (s *FailureExplanationService) Explain(ctx context.Context, failures []*domain.SpecFailure) (*llm.Explanation, error) {
+	if len(failures) == 0 {
+		return nil, fmt.Errorf("at least one failure is required")
+	}
+
+	contexts := make([]llm.FailureContext, len(failures))
+	for i, failure := range failures {
+		contexts[i] = failureContext(failure)
+	}
+
+	// History is only gathered for the representative failure
+	history, err := s.flakyRepo.FindRecentExecutions(ctx, failures[0].ProjectID, failures[0].SpecRun.Name, failures[0].SuiteName, explanationHistoryLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test history: %w", err)
+	}
+	for _, execution := range history {
+		if execution.SpecRunID == failures[0].SpecRun.ID || len(contexts[0].History) == explanationHistoryLimit {
+			continue
+		}
+		contexts[0].History = append(contexts[0].History, llm.HistoryEntry{
+			Status:       historyStatus(execution),
+			ErrorMessage: execution.ErrorMessage,
+			ExecutedAt:   execution.ExecutedAt,
+		})
+	}
+
+	return s.explainer.ExplainCluster(ctx, contexts)
+}
+
+func failureContext(failure *domain.SpecFailure) llm.FailureContext {
+	errorMessage := failure.SpecRun.ErrorMessage
+	if failure.SpecRun.FailureMessage != "" && failure.SpecRun.FailureMessage != errorMessage {
+		if errorMessage != "" {
+			errorMessage += "\n"
+		}
+		errorMessage += failure.SpecRun.FailureMessage
+	}
+
+	return llm.FailureContext{
+		TestName:     failure.SpecRun.Name,
+		SuiteName:    failure.SuiteName,
+		ErrorMessage: errorMessage,
+		StackTrace:   failure.SpecRun.StackTrace,
+	}
+}
+
+func historyStatus(execution domain.SpecExecution) string {
+	if execution.IsFlaky && execution.Status != "failed" {
+		return execution.Status + " after retry"
+	}
+	return execution.Status
+}
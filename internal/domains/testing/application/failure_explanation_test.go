@@ -0,0 +1,137 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
+)
+
+type recordingExplainer struct {
+	failures []llm.FailureContext
+	err      error
+}
+
+func (e *recordingExplainer) ExplainCluster(_ context.Context, failures []llm.FailureContext) (*llm.Explanation, error) {
+	e.failures = failures
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &llm.Explanation{Summary: "explained", Provider: "fake"}, nil
+}
+
+var _ = Describe("FailureExplanationService", Label("unit", "application", "testing"), func() {
+	var (
+		ctx          context.Context
+		testRunRepo  *MockTestRunRepository
+		suiteRunRepo *MockSuiteRunRepository
+		specRunRepo  *MockSpecRunRepository
+		flakyRepo    *mockLifecycleFlakyRepo
+		explainer    *recordingExplainer
+		service      *application.FailureExplanationService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testRunRepo = new(MockTestRunRepository)
+		suiteRunRepo = new(MockSuiteRunRepository)
+		specRunRepo = new(MockSpecRunRepository)
+		flakyRepo = new(mockLifecycleFlakyRepo)
+		explainer = &recordingExplainer{}
+		service = application.NewFailureExplanationService(testRunRepo, suiteRunRepo, specRunRepo, flakyRepo, explainer)
+
+		testRunRepo.On("GetByID", ctx, uint(1)).Return(&domain.TestRun{ID: 1, RunID: "run-1", ProjectID: "project-1", Branch: "main"}, nil)
+		suiteRunRepo.On("GetByID", ctx, uint(10)).Return(&domain.SuiteRun{ID: 10, TestRunID: 1, Name: "Checkout"}, nil)
+		specRunRepo.On("GetByID", ctx, uint(100)).Return(&domain.SpecRun{
+			ID: 100, SuiteRunID: 10, Name: "should pay", Status: "failed",
+			ErrorMessage: "timeout", FailureMessage: "expected 200 got 504", StackTrace: "checkout_test.go:12",
+		}, nil)
+		specRunRepo.On("GetByID", ctx, uint(101)).Return(&domain.SpecRun{
+			ID: 101, SuiteRunID: 10, Name: "should refund", Status: "failed", ErrorMessage: "timeout",
+		}, nil)
+		specRunRepo.On("GetByID", ctx, uint(102)).Return(&domain.SpecRun{
+			ID: 102, SuiteRunID: 10, Name: "should list", Status: "passed",
+		}, nil)
+	})
+
+	Describe("GetFailures", func() {
+		It("should load suite and test run context once per parent", func() {
+			failures, err := service.GetFailures(ctx, []uint{100, 101})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures).To(HaveLen(2))
+			Expect(failures[0].SuiteName).To(Equal("Checkout"))
+			Expect(failures[0].ProjectID).To(Equal("project-1"))
+			Expect(failures[0].TestRunID).To(Equal("run-1"))
+			Expect(failures[1].Branch).To(Equal("main"))
+
+			suiteRunRepo.AssertNumberOfCalls(GinkgoT(), "GetByID", 1)
+			testRunRepo.AssertNumberOfCalls(GinkgoT(), "GetByID", 1)
+		})
+
+		It("should reject spec runs that did not fail", func() {
+			_, err := service.GetFailures(ctx, []uint{102})
+			Expect(err).To(MatchError(ContainSubstring("did not fail")))
+		})
+
+		It("should reject empty and oversized clusters", func() {
+			_, err := service.GetFailures(ctx, nil)
+			Expect(err).To(HaveOccurred())
+
+			_, err = service.GetFailures(ctx, make([]uint, application.MaxExplanationClusterSize+1))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should propagate lookup errors", func() {
+			specRunRepo.On("GetByID", ctx, uint(999)).Return(nil, errors.New("spec run not found"))
+			_, err := service.GetFailures(ctx, []uint{999})
+			Expect(err).To(MatchError(ContainSubstring("spec run not found")))
+		})
+	})
+
+	Describe("Explain", func() {
+		It("should include the error details and recent history of the representative failure", func() {
+			executedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+			flakyRepo.On("FindRecentExecutions", ctx, "project-1", "should pay", "Checkout", 11).Return([]domain.SpecExecution{
+				{SpecRunID: 100, Status: "failed", ErrorMessage: "timeout", ExecutedAt: executedAt},
+				{SpecRunID: 90, Status: "passed", IsFlaky: true, ExecutedAt: executedAt.Add(-time.Hour)},
+				{SpecRunID: 80, Status: "failed", ErrorMessage: "connection reset", ExecutedAt: executedAt.Add(-2 * time.Hour)},
+			}, nil)
+
+			failures, err := service.GetFailures(ctx, []uint{100, 101})
+			Expect(err).NotTo(HaveOccurred())
+
+			explanation, err := service.Explain(ctx, failures)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(explanation.Summary).To(Equal("explained"))
+
+			Expect(explainer.failures).To(HaveLen(2))
+			primary := explainer.failures[0]
+			Expect(primary.TestName).To(Equal("should pay"))
+			Expect(primary.SuiteName).To(Equal("Checkout"))
+			Expect(primary.ErrorMessage).To(Equal("timeout\nexpected 200 got 504"))
+			Expect(primary.StackTrace).To(Equal("checkout_test.go:12"))
+			Expect(primary.History).To(HaveLen(2))
+			Expect(primary.History[0].Status).To(Equal("passed after retry"))
+			Expect(primary.History[1].ErrorMessage).To(Equal("connection reset"))
+			Expect(explainer.failures[1].History).To(BeEmpty())
+		})
+
+		It("should propagate explainer errors", func() {
+			explainer.err = errors.New("provider unavailable")
+			flakyRepo.On("FindRecentExecutions", ctx, "project-1", "should pay", "Checkout", mock.Anything).Return([]domain.SpecExecution{}, nil)
+
+			failures, err := service.GetFailures(ctx, []uint{100})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = service.Explain(ctx, failures)
+			Expect(err).To(MatchError(ContainSubstring("provider unavailable")))
+		})
+	})
+})
@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+)
+
+// DefaultConsecutivePassesForResolution is used when no threshold is configured for a project
+const DefaultConsecutivePassesForResolution = 10
+
+// ResolutionThresholdFunc returns the number of consecutive passes required to
+// auto-resolve a flaky test in the given project
+type ResolutionThresholdFunc func(ctx context.Context, projectID string) int
+
+// LifecycleResult summarises a single lifecycle run
+type LifecycleResult struct {
+	Checked     int
+	Resolved    int
+	Reactivated int
+	Errors      []error
+}
+
+// FlakyLifecycleService moves flaky tests between statuses based on recent executions
+// and records who changed a status and why
+type FlakyLifecycleService struct {
+	flakyRepo domain.FlakyTestRepository
+	threshold ResolutionThresholdFunc
+
+	// Optional recording of how long lifecycle runs take
+	analysisRecorder AnalysisRecorder
+}
+
+// AnalysisRecorder is told how long each flaky test analysis took
+type AnalysisRecorder interface {
+	FlakyAnalysisCompleted(kind string, duration time.Duration, err error)
+}
+
+// NewFlakyLifecycleService creates a new flaky lifecycle service.
+// A nil threshold func uses DefaultConsecutivePassesForResolution for every project.
+func NewFlakyLifecycleService(flakyRepo domain.FlakyTestRepository, threshold ResolutionThresholdFunc) *FlakyLifecycleService {
+	if threshold == nil {
+		threshold = func(context.Context, string) int { return DefaultConsecutivePassesForResolution }
+	}
+	return &FlakyLifecycleService{
+		flakyRepo: flakyRepo,
+		threshold: threshold,
+	}
+}
+
+// SetEventPublisher enables publishing every status change the service saves
+func (s *FlakyLifecycleService) SetEventPublisher(publisher EventPublisher) {
+	s.flakyRepo = NewPublishingFlakyTestRepository(s.flakyRepo, publisher)
+}
+
+// SetAnalysisRecorder enables recording how long lifecycle runs take
+func (s *FlakyLifecycleService) SetAnalysisRecorder(recorder AnalysisRecorder) {
+	s.analysisRecorder = recorder
+}
+
+// RunLifecycle auto-resolves active flaky tests that have passed enough consecutive
+// runs and reactivates resolved flaky tests that have flaked again.
+// Failures on individual tests are collected in the result and do not stop the run.
+func (s *FlakyLifecycleService) RunLifecycle(ctx context.Context) (*LifecycleResult, error) {
+	ctx, span := tracing.Start(ctx, "FlakyLifecycleService.RunLifecycle")
+	start := time.Now()
+	result, err := s.runLifecycle(ctx)
+	tracing.End(span, err)
+	if s.analysisRecorder != nil {
+		s.analysisRecorder.FlakyAnalysisCompleted("lifecycle", time.Since(start), err)
+	}
+	return result, err
+}
+
+// runLifecycle checks every active and resolved flaky test once
+func (s *FlakyLifecycleService) runLifecycle(ctx context.Context) (*LifecycleResult, error) {
+	flakyTests, err := s.flakyRepo.FindByStatus(ctx, domain.FlakyStatusActive, domain.FlakyStatusResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flaky tests: %w", err)
+	}
+
+	result := &LifecycleResult{}
+	for _, flakyTest := range flakyTests {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		result.Checked++
+		var changed bool
+		var err error
+		switch flakyTest.Status() {
+		case domain.FlakyStatusActive:
+			changed, err = s.checkResolution(ctx, flakyTest)
+			if changed {
+				result.Resolved++
+			}
+		case domain.FlakyStatusResolved:
+			changed, err = s.checkReactivation(ctx, flakyTest)
+			if changed {
+				result.Reactivated++
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("flaky test %d: %w", flakyTest.ID(), err))
+		}
+	}
+
+	return result, nil
+}
+
+// checkResolution updates the pass streak of an active flaky test and resolves it
+// once the project's threshold is reached
+func (s *FlakyLifecycleService) checkResolution(ctx context.Context, flakyTest *domain.FlakyTest) (bool, error) {
+	required := s.threshold(ctx, flakyTest.ProjectID())
+	if required <= 0 {
+		return false, nil
+	}
+
+	executions, err := s.flakyRepo.FindRecentExecutions(ctx, flakyTest.ProjectID(), flakyTest.TestName(), flakyTest.SuiteName(), required*2)
+	if err != nil {
+		return false, fmt.Errorf("failed to get recent executions: %w", err)
+	}
+
+	passes := countConsecutivePasses(executions)
+	if passes == flakyTest.ConsecutivePasses() && !flakyTest.ShouldAutoResolve(required) {
+		return false, nil
+	}
+
+	flakyTest.UpdateConsecutivePasses(passes)
+	resolved := false
+	if flakyTest.ShouldAutoResolve(required) {
+		if err := flakyTest.ResolveBy(domain.SystemActor(), fmt.Sprintf("passed %d consecutive runs", passes)); err != nil {
+			return false, err
+		}
+		resolved = true
+	}
+
+	if err := s.flakyRepo.Update(ctx, flakyTest); err != nil {
+		return false, fmt.Errorf("failed to update flaky test: %w", err)
+	}
+	return resolved, nil
+}
+
+// checkReactivation reactivates a resolved flaky test if it flaked after being resolved
+func (s *FlakyLifecycleService) checkReactivation(ctx context.Context, flakyTest *domain.FlakyTest) (bool, error) {
+	required := s.threshold(ctx, flakyTest.ProjectID())
+	if required <= 0 {
+		required = DefaultConsecutivePassesForResolution
+	}
+
+	executions, err := s.flakyRepo.FindRecentExecutions(ctx, flakyTest.ProjectID(), flakyTest.TestName(), flakyTest.SuiteName(), required)
+	if err != nil {
+		return false, fmt.Errorf("failed to get recent executions: %w", err)
+	}
+
+	for _, execution := range executions {
+		if !execution.ExecutedAt.After(flakyTest.StatusChangedAt()) {
+			break
+		}
+		if !execution.Flaked() {
+			continue
+		}
+
+		flakyTest.ReactivateBy(domain.SystemActor(), fmt.Sprintf("flaked again in test run %s", execution.TestRunID))
+		if err := s.flakyRepo.Update(ctx, flakyTest); err != nil {
+			return false, fmt.Errorf("failed to update flaky test: %w", err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// GetFlakyTest retrieves a flaky test by ID
+func (s *FlakyLifecycleService) GetFlakyTest(ctx context.Context, id uint) (*domain.FlakyTest, error) {
+	return s.flakyRepo.FindByID(ctx, id)
+}
+
+// ListFlakyTests retrieves flaky tests matching the filter, highest flake rate first
+func (s *FlakyLifecycleService) ListFlakyTests(ctx context.Context, filter domain.FlakyTestFilter) ([]*domain.FlakyTest, error) {
+	return s.flakyRepo.FindByFilter(ctx, filter)
+}
+
+// GetStatusHistory retrieves the status changes of a flaky test, newest first
+func (s *FlakyLifecycleService) GetStatusHistory(ctx context.Context, id uint) ([]domain.FlakyStatusChange, error) {
+	return s.flakyRepo.GetStatusHistory(ctx, id)
+}
+
+// ResolveFlakyTest marks a flaky test as resolved on behalf of a user
+func (s *FlakyLifecycleService) ResolveFlakyTest(ctx context.Context, id uint, userID, reason string) (*domain.FlakyTest, error) {
+	return s.changeStatus(ctx, id, func(ft *domain.FlakyTest) error {
+		return ft.ResolveBy(domain.UserActor(userID), reason)
+	})
+}
+
+// IgnoreFlakyTest marks a flaky test as ignored on behalf of a user
+func (s *FlakyLifecycleService) IgnoreFlakyTest(ctx context.Context, id uint, userID, reason string) (*domain.FlakyTest, error) {
+	return s.changeStatus(ctx, id, func(ft *domain.FlakyTest) error {
+		return ft.IgnoreBy(domain.UserActor(userID), reason)
+	})
+}
+
+// ReactivateFlakyTest marks a flaky test as active again on behalf of a user
+func (s *FlakyLifecycleService) ReactivateFlakyTest(ctx context.Context, id uint, userID, reason string) (*domain.FlakyTest, error) {
+	return s.changeStatus(ctx, id, func(ft *domain.FlakyTest) error {
+		if ft.Status() == domain.FlakyStatusActive {
+			return fmt.Errorf("flaky test is already active")
+		}
+		ft.ReactivateBy(domain.UserActor(userID), reason)
+		return nil
+	})
+}
+
+// ChangeTestStatus changes the status of a project's flaky test, found by its
+// test name, on behalf of the given actor
+func (s *FlakyLifecycleService) ChangeTestStatus(ctx context.Context, projectID, testName string, status domain.FlakyStatus, actor domain.FlakyActor, reason string) (*domain.FlakyTest, error) {
+	flakyTest, err := s.flakyRepo.FindByTestName(ctx, projectID, testName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveStatusChange(ctx, flakyTest, func(ft *domain.FlakyTest) error {
+		switch status {
+		case domain.FlakyStatusResolved:
+			return ft.ResolveBy(actor, reason)
+		case domain.FlakyStatusIgnored:
+			return ft.IgnoreBy(actor, reason)
+		case domain.FlakyStatusActive:
+			if ft.Status() == domain.FlakyStatusActive {
+				return fmt.Errorf("flaky test is already active")
+			}
+			ft.ReactivateBy(actor, reason)
+			return nil
+		}
+		return fmt.Errorf("unknown flaky test status %q", status)
+	})
+}
+
+func (s *FlakyLifecycleService) changeStatus(ctx context.Context, id uint, change func(*domain.FlakyTest) error) (*domain.FlakyTest, error) {
+	flakyTest, err := s.flakyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveStatusChange(ctx, flakyTest, change)
+}
+
+// saveStatusChange applies a status change and saves it with its history
+func (s *FlakyLifecycleService) saveStatusChange(ctx context.Context, flakyTest *domain.FlakyTest, change func(*domain.FlakyTest) error) (*domain.FlakyTest, error) {
+	if err := change(flakyTest); err != nil {
+		return nil, err
+	}
+
+	if err := s.flakyRepo.Update(ctx, flakyTest); err != nil {
+		return nil, fmt.Errorf("failed to update flaky test: %w", err)
+	}
+	return flakyTest, nil
+}
+
+// countConsecutivePasses counts passing executions before the most recent flaky one.
+// Skipped and pending executions neither extend nor break the streak.
+func countConsecutivePasses(executions []domain.SpecExecution) int {
+	passes := 0
+	for _, execution := range executions {
+		if execution.Flaked() {
+			break
+		}
+		if execution.Status == "passed" {
+			passes++
+		}
+	}
+	return passes
+}
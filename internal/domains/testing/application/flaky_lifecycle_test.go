@@ -0,0 +1,295 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+type mockLifecycleFlakyRepo struct{ mock.Mock }
+
+func (m *mockLifecycleFlakyRepo) Save(ctx context.Context, flakyTest *domain.FlakyTest) error {
+	args := m.Called(ctx, flakyTest)
+	return args.Error(0)
+}
+func (m *mockLifecycleFlakyRepo) FindByProject(ctx context.Context, projectID string) ([]*domain.FlakyTest, error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]*domain.FlakyTest), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) FindByTestName(ctx context.Context, projectID, testName string) (*domain.FlakyTest, error) {
+	args := m.Called(ctx, projectID, testName)
+	return args.Get(0).(*domain.FlakyTest), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) Update(ctx context.Context, flakyTest *domain.FlakyTest) error {
+	args := m.Called(ctx, flakyTest)
+	return args.Error(0)
+}
+func (m *mockLifecycleFlakyRepo) FindByID(ctx context.Context, id uint) (*domain.FlakyTest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlakyTest), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) FindByStatus(ctx context.Context, statuses ...domain.FlakyStatus) ([]*domain.FlakyTest, error) {
+	args := m.Called(ctx, statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlakyTest), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) GetStatusHistory(ctx context.Context, flakyTestID uint) ([]domain.FlakyStatusChange, error) {
+	args := m.Called(ctx, flakyTestID)
+	return args.Get(0).([]domain.FlakyStatusChange), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]domain.SpecExecution, error) {
+	args := m.Called(ctx, projectID, testName, suiteName, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SpecExecution), args.Error(1)
+}
+func (m *mockLifecycleFlakyRepo) FindByFilter(ctx context.Context, filter domain.FlakyTestFilter) ([]*domain.FlakyTest, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlakyTest), args.Error(1)
+}
+
+func newFlakyTestWithStatus(id uint, status domain.FlakyStatus, statusChangedAt time.Time) *domain.FlakyTest {
+	return domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+		ID:              id,
+		ProjectID:       "project-1",
+		TestName:        "test-login",
+		SuiteName:       "auth-suite",
+		Status:          status,
+		StatusChangedAt: statusChangedAt,
+		Severity:        domain.FlakySeverityMedium,
+	})
+}
+
+func executions(start time.Time, statuses ...string) []domain.SpecExecution {
+	result := make([]domain.SpecExecution, len(statuses))
+	for i, status := range statuses {
+		result[i] = domain.SpecExecution{
+			SpecRunID:  uint(100 - i),
+			TestRunID:  "run-" + status,
+			Status:     status,
+			ExecutedAt: start.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return result
+}
+
+var _ = Describe("FlakyLifecycleService", Label("unit", "application", "testing"), func() {
+	var (
+		ctx     context.Context
+		repo    *mockLifecycleFlakyRepo
+		service *application.FlakyLifecycleService
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = new(mockLifecycleFlakyRepo)
+		service = application.NewFlakyLifecycleService(repo, func(context.Context, string) int { return 3 })
+		now = time.Now()
+	})
+
+	Describe("RunLifecycle", func() {
+		It("should resolve an active test after enough consecutive passes", func() {
+			flakyTest := newFlakyTestWithStatus(1, domain.FlakyStatusActive, now.Add(-time.Hour))
+			repo.On("FindByStatus", ctx, []domain.FlakyStatus{domain.FlakyStatusActive, domain.FlakyStatusResolved}).
+				Return([]*domain.FlakyTest{flakyTest}, nil)
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 6).
+				Return(executions(now, "passed", "skipped", "passed", "passed", "failed"), nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Checked).To(Equal(1))
+			Expect(result.Resolved).To(Equal(1))
+			Expect(flakyTest.Status()).To(Equal(domain.FlakyStatusResolved))
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(3))
+
+			changes := flakyTest.PendingStatusChanges()
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Actor).To(Equal(domain.SystemActor()))
+			Expect(changes[0].Reason).To(Equal("passed 3 consecutive runs"))
+			repo.AssertExpectations(GinkgoT())
+		})
+
+		It("should only update the pass streak when below the threshold", func() {
+			flakyTest := newFlakyTestWithStatus(1, domain.FlakyStatusActive, now.Add(-time.Hour))
+			repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{flakyTest}, nil)
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 6).
+				Return(executions(now, "passed", "failed", "passed"), nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Resolved).To(Equal(0))
+			Expect(flakyTest.Status()).To(Equal(domain.FlakyStatusActive))
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(1))
+			Expect(flakyTest.PendingStatusChanges()).To(BeEmpty())
+		})
+
+		It("should not write when nothing changed", func() {
+			flakyTest := newFlakyTestWithStatus(1, domain.FlakyStatusActive, now.Add(-time.Hour))
+			repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{flakyTest}, nil)
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 6).
+				Return(executions(now, "failed", "passed"), nil)
+
+			_, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			repo.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything)
+		})
+
+		It("should reactivate a resolved test that flaked after resolution", func() {
+			flakyTest := newFlakyTestWithStatus(2, domain.FlakyStatusResolved, now.Add(-30*time.Minute))
+			repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{flakyTest}, nil)
+			recent := executions(now, "passed", "failed")
+			recent[1].TestRunID = "run-42"
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 3).Return(recent, nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Reactivated).To(Equal(1))
+			Expect(flakyTest.Status()).To(Equal(domain.FlakyStatusActive))
+			changes := flakyTest.PendingStatusChanges()
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].FromStatus).To(Equal(domain.FlakyStatusResolved))
+			Expect(changes[0].Reason).To(Equal("flaked again in test run run-42"))
+		})
+
+		It("should ignore failures from before the test was resolved", func() {
+			flakyTest := newFlakyTestWithStatus(2, domain.FlakyStatusResolved, now.Add(-90*time.Second))
+			repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{flakyTest}, nil)
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 3).
+				Return(executions(now, "passed", "passed", "failed"), nil)
+
+			result, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Reactivated).To(Equal(0))
+			Expect(flakyTest.Status()).To(Equal(domain.FlakyStatusResolved))
+		})
+
+		It("should collect per-test errors and continue", func() {
+			failing := newFlakyTestWithStatus(1, domain.FlakyStatusActive, now)
+			other := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+				ID: 2, ProjectID: "project-1", TestName: "test-other", Status: domain.FlakyStatusActive,
+			})
+			repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{failing, other}, nil)
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-login", "auth-suite", 6).
+				Return(nil, errors.New("db down"))
+			repo.On("FindRecentExecutions", ctx, "project-1", "test-other", "", 6).
+				Return(executions(now, "passed", "passed", "passed"), nil)
+			repo.On("Update", ctx, other).Return(nil)
+
+			result, err := service.RunLifecycle(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Checked).To(Equal(2))
+			Expect(result.Resolved).To(Equal(1))
+			Expect(result.Errors).To(HaveLen(1))
+		})
+
+		It("should return an error when flaky tests cannot be listed", func() {
+			repo.On("FindByStatus", ctx, mock.Anything).Return(nil, errors.New("db down"))
+
+			_, err := service.RunLifecycle(ctx)
+
+			Expect(err).To(MatchError(ContainSubstring("failed to list flaky tests")))
+		})
+	})
+
+	Describe("user status changes", func() {
+		It("should record the user as actor when resolving", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusActive, now)
+			repo.On("FindByID", ctx, uint(5)).Return(flakyTest, nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.ResolveFlakyTest(ctx, 5, "user-1", "fixed race condition")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status()).To(Equal(domain.FlakyStatusResolved))
+			changes := result.PendingStatusChanges()
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Actor).To(Equal(domain.UserActor("user-1")))
+			Expect(changes[0].Reason).To(Equal("fixed race condition"))
+		})
+
+		It("should reject reactivating an active test", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusActive, now)
+			repo.On("FindByID", ctx, uint(5)).Return(flakyTest, nil)
+
+			_, err := service.ReactivateFlakyTest(ctx, 5, "user-1", "")
+
+			Expect(err).To(MatchError("flaky test is already active"))
+			repo.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything)
+		})
+
+		It("should not update when the transition is invalid", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusResolved, now)
+			repo.On("FindByID", ctx, uint(5)).Return(flakyTest, nil)
+
+			_, err := service.IgnoreFlakyTest(ctx, 5, "user-1", "")
+
+			Expect(err).To(HaveOccurred())
+			repo.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything)
+		})
+	})
+
+	Describe("ChangeTestStatus", func() {
+		It("should record the change for a test found by name", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusActive, now.Add(-time.Hour))
+			repo.On("FindByTestName", ctx, "project-1", "test-login").Return(flakyTest, nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.ChangeTestStatus(ctx, "project-1", "test-login", domain.FlakyStatusResolved,
+				domain.SystemActor(), "passed 10 consecutive runs")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status()).To(Equal(domain.FlakyStatusResolved))
+			Expect(result.StatusChangedAt()).To(BeTemporally(">", now.Add(-time.Hour)))
+			changes := result.PendingStatusChanges()
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Actor).To(Equal(domain.SystemActor()))
+			Expect(changes[0].FromStatus).To(Equal(domain.FlakyStatusActive))
+		})
+
+		It("should reactivate ignored tests on behalf of a user", func() {
+			flakyTest := newFlakyTestWithStatus(5, domain.FlakyStatusIgnored, now)
+			repo.On("FindByTestName", ctx, "project-1", "test-login").Return(flakyTest, nil)
+			repo.On("Update", ctx, flakyTest).Return(nil)
+
+			result, err := service.ChangeTestStatus(ctx, "project-1", "test-login", domain.FlakyStatusActive, domain.UserActor("user-1"), "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.PendingStatusChanges()[0].Actor).To(Equal(domain.UserActor("user-1")))
+		})
+
+		It("should report tests that are not found", func() {
+			repo.On("FindByTestName", ctx, "project-1", "missing").Return((*domain.FlakyTest)(nil), errors.New("flaky test not found"))
+
+			_, err := service.ChangeTestStatus(ctx, "project-1", "missing", domain.FlakyStatusIgnored, domain.UserActor("user-1"), "")
+
+			Expect(err).To(MatchError("flaky test not found"))
+			repo.AssertNotCalled(GinkgoT(), "Update", mock.Anything, mock.Anything)
+		})
+	})
+})
@@ -0,0 +1,176 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// ingestionRecord is one call to an ingestion recorder
+type ingestionRecord struct {
+	projectID                     string
+	testRuns, suiteRuns, specRuns int
+}
+
+// recordingIngestionRecorder records the ingested test data it is told about
+type recordingIngestionRecorder struct {
+	records []ingestionRecord
+}
+
+func (r *recordingIngestionRecorder) RecordIngestion(projectID string, testRuns, suiteRuns, specRuns int) {
+	r.records = append(r.records, ingestionRecord{projectID, testRuns, suiteRuns, specRuns})
+}
+
+// analysisRecord is one call to an analysis recorder
+type analysisRecord struct {
+	kind string
+	err  error
+}
+
+// recordingAnalysisRecorder records the analyses it is told about
+type recordingAnalysisRecorder struct {
+	records []analysisRecord
+}
+
+func (r *recordingAnalysisRecorder) FlakyAnalysisCompleted(kind string, duration time.Duration, err error) {
+	r.records = append(r.records, analysisRecord{kind, err})
+}
+
+var _ = Describe("Ingestion recording", Label("unit", "application", "testing"), func() {
+	var (
+		ctx             context.Context
+		recorder        *recordingIngestionRecorder
+		service         *application.TestRunService
+		mockTestRunRepo *MockTestRunRepository
+		mockSuiteRepo   *MockSuiteRunRepository
+		mockSpecRepo    *MockSpecRunRepository
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recorder = &recordingIngestionRecorder{}
+		mockTestRunRepo = new(MockTestRunRepository)
+		mockSuiteRepo = new(MockSuiteRunRepository)
+		mockSpecRepo = new(MockSpecRunRepository)
+		service = application.NewTestRunService(mockTestRunRepo, mockSuiteRepo, mockSpecRepo)
+		service.SetIngestionRecorder(recorder)
+	})
+
+	It("should count a new test run with its suites and specs", func() {
+		testRun := &domain.TestRun{
+			RunID:     "run-1",
+			ProjectID: "proj-1",
+			Status:    "passed",
+			SuiteRuns: []domain.SuiteRun{
+				{Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}, {Name: "refunds"}}},
+				{Name: "Cart", SpecRuns: []*domain.SpecRun{{Name: "adds"}}},
+			},
+		}
+		mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+		_, _, err := service.CreateTestRun(ctx, testRun)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.records).To(ConsistOf(ingestionRecord{"proj-1", 1, 2, 3}))
+	})
+
+	It("should not count a test run that already existed", func() {
+		testRun := &domain.TestRun{RunID: "run-1", ProjectID: "proj-1"}
+		existing := &domain.TestRun{ID: 7, RunID: "run-1", ProjectID: "proj-1"}
+		mockTestRunRepo.On("Create", ctx, testRun).Return(errors.New("duplicate key value violates unique constraint"))
+		mockTestRunRepo.On("GetByRunID", ctx, "run-1").Return(existing, nil)
+
+		_, existed, err := service.CreateTestRun(ctx, testRun)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(existed).To(BeTrue())
+		Expect(recorder.records).To(BeEmpty())
+	})
+
+	It("should count the suites submitted with a test run", func() {
+		testRun := &domain.TestRun{ProjectID: "proj-1", RunID: "run-1", Status: "running"}
+		suites := []domain.SuiteRun{
+			{Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}, {Name: "refunds"}}},
+		}
+		mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+		mockSuiteRepo.On("Create", ctx, mock.Anything).Return(nil)
+		mockSpecRepo.On("CreateBatch", ctx, mock.Anything).Return(nil)
+		mockTestRunRepo.On("GetByID", ctx, mock.Anything).Return(testRun, nil)
+		mockSuiteRepo.On("FindByTestRunID", ctx, mock.Anything).Return([]*domain.SuiteRun{}, nil)
+		mockTestRunRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		Expect(service.CreateTestRunWithSuites(ctx, testRun, suites)).To(Succeed())
+
+		Expect(recorder.records).To(ConsistOf(
+			ingestionRecord{"proj-1", 1, 0, 0},
+			ingestionRecord{"proj-1", 0, 1, 2},
+		))
+	})
+
+	It("should count a suite added to a test run under the run's project", func() {
+		suiteRun := &domain.SuiteRun{TestRunID: 7, Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}}}
+		mockTestRunRepo.On("GetByID", ctx, uint(7)).Return(&domain.TestRun{ID: 7, ProjectID: "proj-1"}, nil)
+		mockSuiteRepo.On("Create", ctx, suiteRun).Return(nil)
+
+		Expect(service.CreateSuiteRun(ctx, suiteRun)).To(Succeed())
+
+		Expect(recorder.records).To(ConsistOf(ingestionRecord{"proj-1", 0, 1, 1}))
+	})
+
+	It("should count a spec added to a suite on its own", func() {
+		specRun := &domain.SpecRun{SuiteRunID: 3, Name: "pays", Status: "passed"}
+		mockSpecRepo.On("Create", ctx, specRun).Return(nil)
+		mockSuiteRepo.On("GetByID", ctx, uint(3)).Return(&domain.SuiteRun{ID: 3, TestRunID: 7, StartTime: time.Now()}, nil)
+		mockTestRunRepo.On("GetByID", ctx, uint(7)).Return(&domain.TestRun{ID: 7, ProjectID: "proj-1"}, nil)
+		mockSpecRepo.On("FindBySuiteRunID", ctx, uint(3)).Return([]*domain.SpecRun{specRun}, nil)
+		mockSuiteRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		Expect(service.AddSpecRun(ctx, 3, specRun)).To(Succeed())
+
+		Expect(recorder.records).To(ConsistOf(ingestionRecord{"proj-1", 0, 0, 1}))
+	})
+})
+
+var _ = Describe("Lifecycle analysis recording", Label("unit", "application", "testing"), func() {
+	var (
+		ctx      context.Context
+		repo     *mockLifecycleFlakyRepo
+		recorder *recordingAnalysisRecorder
+		service  *application.FlakyLifecycleService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		repo = new(mockLifecycleFlakyRepo)
+		recorder = &recordingAnalysisRecorder{}
+		service = application.NewFlakyLifecycleService(repo, nil)
+		service.SetAnalysisRecorder(recorder)
+	})
+
+	It("should record each lifecycle run", func() {
+		repo.On("FindByStatus", ctx, mock.Anything).Return([]*domain.FlakyTest{}, nil)
+
+		_, err := service.RunLifecycle(ctx)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.records).To(ConsistOf(analysisRecord{"lifecycle", nil}))
+	})
+
+	It("should record a failed lifecycle run with its error", func() {
+		repo.On("FindByStatus", ctx, mock.Anything).Return(nil, errors.New("database error"))
+
+		_, err := service.RunLifecycle(ctx)
+
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.records).To(HaveLen(1))
+		Expect(recorder.records[0].kind).To(Equal("lifecycle"))
+		Expect(recorder.records[0].err).To(MatchError(ContainSubstring("database error")))
+	})
+})
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/similarity"
+)
+
+const (
+	// DefaultSimilarFailureLimit is used when no limit is requested
+	DefaultSimilarFailureLimit = 10
+
+	// MaxSimilarFailureLimit caps the number of similar failures returned
+	MaxSimilarFailureLimit = 100
+
+	// DefaultSimilarityWindow is how far back failures are loaded into an empty index
+	DefaultSimilarityWindow = 90 * 24 * time.Hour
+
+	// minSimilarityScore drops matches that only share incidental terms
+	minSimilarityScore = 0.2
+
+	// maxIndexedStackTrace bounds the stack trace text indexed per failure
+	maxIndexedStackTrace = 4000
+
+	// refreshOverlap re-reads failures near the previous refresh to cover clock skew
+	refreshOverlap = time.Minute
+)
+
+// SimilarFailure is a past failure and its similarity to the queried failure
+type SimilarFailure struct {
+	Failure *domain.SpecFailure
+	Score   float64
+}
+
+// SimilarFailureService finds past failures with similar error messages and
+// stack traces across projects using an in-process similarity index
+type SimilarFailureService struct {
+	specRunRepo domain.SpecRunRepository
+	index       *similarity.Index
+	window      time.Duration
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+}
+
+// NewSimilarFailureService creates a new similar failure service. Failures
+// newer than window are loaded on the first refresh.
+func NewSimilarFailureService(specRunRepo domain.SpecRunRepository, index *similarity.Index, window time.Duration) *SimilarFailureService {
+	if window <= 0 {
+		window = DefaultSimilarityWindow
+	}
+	return &SimilarFailureService{
+		specRunRepo: specRunRepo,
+		index:       index,
+		window:      window,
+	}
+}
+
+// IndexFailures adds failed spec runs of a project to the index
+func (s *SimilarFailureService) IndexFailures(projectID string, specRuns []*domain.SpecRun) {
+	for _, specRun := range specRuns {
+		s.index.Add(specRun.ID, projectID, failureText(specRun))
+	}
+}
+
+// Refresh indexes failures recorded since the previous refresh, or within the
+// window on the first call. This keeps the index complete when failures are
+// ingested by other instances. It returns the number of failures indexed.
+func (s *SimilarFailureService) Refresh(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := time.Now()
+	since := started.Add(-s.window)
+	if !s.lastRefresh.IsZero() {
+		since = s.lastRefresh.Add(-refreshOverlap)
+	}
+
+	failures, err := s.specRunRepo.FindFailures(ctx, domain.FailureFilter{
+		Since: since,
+		Limit: s.index.Capacity(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load failures: %w", err)
+	}
+
+	// Failures come newest first; add oldest first so eviction drops the oldest
+	for i := len(failures) - 1; i >= 0; i-- {
+		s.index.Add(failures[i].SpecRun.ID, failures[i].ProjectID, failureText(failures[i].SpecRun))
+	}
+
+	s.lastRefresh = started
+	return len(failures), nil
+}
+
+// FindSimilar returns past failures similar to a failed spec run, most similar
+// first. canAccess limits both the queried spec run and the results to the
+// projects the caller may read; a nil canAccess allows every project.
+func (s *SimilarFailureService) FindSimilar(ctx context.Context, specRunID uint, limit int, canAccess func(projectID string) bool) ([]*SimilarFailure, error) {
+	if limit <= 0 {
+		limit = DefaultSimilarFailureLimit
+	}
+	if limit > MaxSimilarFailureLimit {
+		limit = MaxSimilarFailureLimit
+	}
+
+	sources, err := s.specRunRepo.FindFailures(ctx, domain.FailureFilter{SpecRunIDs: []uint{specRunID}, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec run: %w", err)
+	}
+	// Report inaccessible spec runs as missing so their existence is not revealed
+	if len(sources) == 0 || (canAccess != nil && !canAccess(sources[0].ProjectID)) {
+		return nil, fmt.Errorf("failed spec run %d not found", specRunID)
+	}
+
+	matches := s.index.Query(failureText(sources[0].SpecRun), similarity.QueryOptions{
+		Limit:    limit,
+		MinScore: minSimilarityScore,
+		Exclude:  []uint{specRunID},
+		Allow:    canAccess,
+	})
+	if len(matches) == 0 {
+		return []*SimilarFailure{}, nil
+	}
+
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+	failures, err := s.specRunRepo.FindFailures(ctx, domain.FailureFilter{SpecRunIDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load similar failures: %w", err)
+	}
+
+	byID := make(map[uint]*domain.SpecFailure, len(failures))
+	for _, failure := range failures {
+		byID[failure.SpecRun.ID] = failure
+	}
+
+	result := make([]*SimilarFailure, 0, len(matches))
+	for _, match := range matches {
+		failure, ok := byID[match.ID]
+		if !ok {
+			// Deleted since it was indexed
+			s.index.Remove(match.ID)
+			continue
+		}
+		result = append(result, &SimilarFailure{Failure: failure, Score: match.Score})
+	}
+	return result, nil
+}
+
+// failureText is the text compared between failures
+func failureText(specRun *domain.SpecRun) string {
+	text := specRun.ErrorMessage
+	if specRun.FailureMessage != "" && specRun.FailureMessage != specRun.ErrorMessage {
+		text += "\n" + specRun.FailureMessage
+	}
+
+	stackTrace := specRun.StackTrace
+	if len(stackTrace) > maxIndexedStackTrace {
+		stackTrace = stackTrace[:maxIndexedStackTrace]
+	}
+	return text + "\n" + stackTrace
+}
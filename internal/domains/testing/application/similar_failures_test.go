@@ -0,0 +1,172 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/similarity"
+)
+
+func specFailure(id uint, projectID, name, errorMessage string) *domain.SpecFailure {
+	return &domain.SpecFailure{
+		SpecRun:   &domain.SpecRun{ID: id, Name: name, Status: "failed", ErrorMessage: errorMessage},
+		SuiteName: "Suite",
+		TestRunID: "run",
+		ProjectID: projectID,
+	}
+}
+
+var _ = Describe("SimilarFailureService", Label("unit", "application", "testing"), func() {
+	var (
+		ctx          context.Context
+		mockSpecRepo *MockSpecRunRepository
+		index        *similarity.Index
+		service      *application.SimilarFailureService
+		source       *domain.SpecFailure
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockSpecRepo = new(MockSpecRunRepository)
+		index = similarity.NewIndex(100)
+		service = application.NewSimilarFailureService(mockSpecRepo, index, 0)
+
+		source = specFailure(1, "payments", "charges", "connection refused: dial tcp db:5432 in PaymentRepository.save")
+		mockSpecRepo.On("FindFailures", ctx, domain.FailureFilter{SpecRunIDs: []uint{1}, Limit: 1}).
+			Return([]*domain.SpecFailure{source}, nil)
+
+		service.IndexFailures("payments", []*domain.SpecRun{source.SpecRun})
+		service.IndexFailures("payments", []*domain.SpecRun{
+			{ID: 2, Status: "failed", ErrorMessage: "connection refused: dial tcp db:5432 in PaymentRepository.load"},
+			{ID: 3, Status: "failed", ErrorMessage: "expected 3 items in cart but found 2"},
+		})
+		service.IndexFailures("orders", []*domain.SpecRun{
+			{ID: 4, Status: "failed", ErrorMessage: "connection refused: dial tcp db:5432 in OrderRepository.save"},
+		})
+	})
+
+	It("should return similar failures across projects with scores", func() {
+		mockSpecRepo.On("FindFailures", ctx, mock.MatchedBy(func(filter domain.FailureFilter) bool {
+			return len(filter.SpecRunIDs) == 2
+		})).Return([]*domain.SpecFailure{
+			specFailure(4, "orders", "creates order", "connection refused"),
+			specFailure(2, "payments", "loads payment", "connection refused"),
+		}, nil)
+
+		results, err := service.FindSimilar(ctx, 1, 5, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Failure.SpecRun.ID).To(Equal(uint(2)))
+		Expect(results[1].Failure.SpecRun.ID).To(Equal(uint(4)))
+		Expect(results[0].Score).To(BeNumerically(">", results[1].Score))
+		Expect(results[1].Score).To(BeNumerically(">", 0))
+	})
+
+	It("should only return failures from accessible projects", func() {
+		mockSpecRepo.On("FindFailures", ctx, domain.FailureFilter{SpecRunIDs: []uint{2}}).
+			Return([]*domain.SpecFailure{specFailure(2, "payments", "loads payment", "connection refused")}, nil)
+
+		results, err := service.FindSimilar(ctx, 1, 5, func(projectID string) bool { return projectID == "payments" })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Failure.ProjectID).To(Equal("payments"))
+	})
+
+	It("should hide spec runs from inaccessible projects", func() {
+		_, err := service.FindSimilar(ctx, 1, 5, func(string) bool { return false })
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("should report missing spec runs", func() {
+		mockSpecRepo.On("FindFailures", ctx, domain.FailureFilter{SpecRunIDs: []uint{99}, Limit: 1}).
+			Return([]*domain.SpecFailure{}, nil)
+
+		_, err := service.FindSimilar(ctx, 99, 5, nil)
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("should drop deleted failures from the index", func() {
+		mockSpecRepo.On("FindFailures", ctx, mock.MatchedBy(func(filter domain.FailureFilter) bool {
+			return len(filter.SpecRunIDs) == 2
+		})).Return([]*domain.SpecFailure{specFailure(2, "payments", "loads payment", "connection refused")}, nil)
+
+		results, err := service.FindSimilar(ctx, 1, 5, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(index.Contains(4)).To(BeFalse())
+	})
+
+	Describe("Refresh", func() {
+		It("should load the window first and only newer failures afterwards", func() {
+			fresh := similarity.NewIndex(50)
+			service = application.NewSimilarFailureService(mockSpecRepo, fresh, 0)
+
+			var filters []domain.FailureFilter
+			mockSpecRepo.On("FindFailures", ctx, mock.MatchedBy(func(filter domain.FailureFilter) bool {
+				return len(filter.SpecRunIDs) == 0
+			})).Run(func(args mock.Arguments) {
+				filters = append(filters, args.Get(1).(domain.FailureFilter))
+			}).Return([]*domain.SpecFailure{
+				specFailure(11, "payments", "a", "timeout waiting for gateway"),
+				specFailure(10, "orders", "b", "timeout waiting for queue"),
+			}, nil)
+
+			count, err := service.Refresh(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+			Expect(fresh.Contains(10)).To(BeTrue())
+			Expect(fresh.Contains(11)).To(BeTrue())
+
+			_, err = service.Refresh(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filters).To(HaveLen(2))
+			Expect(filters[0].Limit).To(Equal(50))
+			Expect(filters[1].Since).To(BeTemporally(">", filters[0].Since))
+		})
+
+		It("should return repository errors", func() {
+			mockSpecRepo.On("FindFailures", ctx, mock.MatchedBy(func(filter domain.FailureFilter) bool {
+				return len(filter.SpecRunIDs) == 0
+			})).Return(nil, errors.New("db down"))
+
+			_, err := service.Refresh(ctx)
+			Expect(err).To(MatchError(ContainSubstring("db down")))
+		})
+	})
+})
+
+var _ = Describe("TestRunService failure indexing", Label("unit", "application", "testing"), func() {
+	It("should index failed specs of new test runs", func() {
+		ctx := context.Background()
+		mockTestRunRepo := new(MockTestRunRepository)
+		index := similarity.NewIndex(10)
+		service := application.NewTestRunService(mockTestRunRepo, new(MockSuiteRunRepository), new(MockSpecRunRepository))
+		service.SetFailureIndexer(application.NewSimilarFailureService(new(MockSpecRunRepository), index, 0))
+
+		testRun := &domain.TestRun{
+			ProjectID: "payments",
+			SuiteRuns: []domain.SuiteRun{{Name: "Checkout", SpecRuns: []*domain.SpecRun{
+				{Name: "pays", Status: "failed", ErrorMessage: "gateway timeout"},
+				{Name: "refunds", Status: "passed"},
+			}}},
+		}
+		mockTestRunRepo.On("Create", ctx, testRun).Run(func(args mock.Arguments) {
+			run := args.Get(1).(*domain.TestRun)
+			run.ID = 1
+			run.SuiteRuns[0].SpecRuns[0].ID = 7
+			run.SuiteRuns[0].SpecRuns[1].ID = 8
+		}).Return(nil)
+
+		_, _, err := service.CreateTestRun(ctx, testRun)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(index.Contains(7)).To(BeTrue())
+		Expect(index.Contains(8)).To(BeFalse())
+	})
+})
@@ -0,0 +1,129 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// suiteOwnerMatcher assigns owners by suite name
+type suiteOwnerMatcher map[string][]string
+
+func (m suiteOwnerMatcher) MatchOwners(target domain.OwnershipTarget) []string {
+	return m[target.SuiteName]
+}
+
+type fakeOwnershipProvider struct {
+	matcher domain.OwnerMatcher
+	err     error
+}
+
+func (p *fakeOwnershipProvider) MatcherForProject(ctx context.Context, projectID string) (domain.OwnerMatcher, error) {
+	return p.matcher, p.err
+}
+
+var _ = Describe("TestRunService ownership", Label("unit", "application", "testing"), func() {
+	var (
+		ctx             context.Context
+		mockTestRunRepo *MockTestRunRepository
+		mockSuiteRepo   *MockSuiteRunRepository
+		mockSpecRepo    *MockSpecRunRepository
+		flakyRepo       *mockLifecycleFlakyRepo
+		provider        *fakeOwnershipProvider
+		service         *application.TestRunService
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockTestRunRepo = new(MockTestRunRepository)
+		mockSuiteRepo = new(MockSuiteRunRepository)
+		mockSpecRepo = new(MockSpecRunRepository)
+		flakyRepo = new(mockLifecycleFlakyRepo)
+		provider = &fakeOwnershipProvider{matcher: suiteOwnerMatcher{"Checkout": {"@payments"}}}
+		service = application.NewTestRunService(mockTestRunRepo, mockSuiteRepo, mockSpecRepo)
+		service.SetOwnership(provider, flakyRepo)
+	})
+
+	It("should assign owners to specs and update matching flaky tests on ingestion", func() {
+		flaky := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+			ID: 1, ProjectID: "proj-1", TestName: "pays", SuiteName: "Checkout", Status: domain.FlakyStatusActive,
+		})
+		unrelated := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+			ID: 2, ProjectID: "proj-1", TestName: "logs in", SuiteName: "Login", Status: domain.FlakyStatusActive,
+		})
+		testRun := &domain.TestRun{
+			ProjectID: "proj-1",
+			SuiteRuns: []domain.SuiteRun{
+				{Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}}},
+				{Name: "Search", SpecRuns: []*domain.SpecRun{{Name: "finds"}}},
+			},
+		}
+		flakyRepo.On("FindByProject", ctx, "proj-1").Return([]*domain.FlakyTest{flaky, unrelated}, nil)
+		flakyRepo.On("Update", ctx, flaky).Return(nil)
+		mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+		_, _, err := service.CreateTestRun(ctx, testRun)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Owners).To(Equal([]string{"@payments"}))
+		Expect(testRun.SuiteRuns[1].SpecRuns[0].Owners).To(BeEmpty())
+		Expect(flaky.Owners()).To(Equal([]string{"@payments"}))
+		flakyRepo.AssertNotCalled(GinkgoT(), "Update", ctx, unrelated)
+	})
+
+	It("should resolve the project through the test run when adding a suite", func() {
+		suite := &domain.SuiteRun{TestRunID: 7, Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}}}
+		mockTestRunRepo.On("GetByID", ctx, uint(7)).Return(&domain.TestRun{ID: 7, ProjectID: "proj-1"}, nil)
+		flakyRepo.On("FindByProject", ctx, "proj-1").Return([]*domain.FlakyTest{}, nil)
+		mockSuiteRepo.On("Create", ctx, suite).Return(nil)
+
+		Expect(service.CreateSuiteRun(ctx, suite)).To(Succeed())
+		Expect(suite.SpecRuns[0].Owners).To(Equal([]string{"@payments"}))
+	})
+
+	It("should not block ingestion when ownership rules cannot be loaded", func() {
+		provider.err = errors.New("project not found")
+		testRun := &domain.TestRun{
+			ProjectID: "proj-1",
+			SuiteRuns: []domain.SuiteRun{{Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}}}},
+		}
+		mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+		_, _, err := service.CreateTestRun(ctx, testRun)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testRun.SuiteRuns[0].SpecRuns[0].Owners).To(BeNil())
+		flakyRepo.AssertNotCalled(GinkgoT(), "FindByProject", mock.Anything, mock.Anything)
+	})
+
+	It("should skip projects without ownership rules", func() {
+		provider.matcher = nil
+		testRun := &domain.TestRun{
+			ProjectID: "proj-1",
+			SuiteRuns: []domain.SuiteRun{{Name: "Checkout", SpecRuns: []*domain.SpecRun{{Name: "pays"}}}},
+		}
+		mockTestRunRepo.On("Create", ctx, testRun).Return(nil)
+
+		_, _, err := service.CreateTestRun(ctx, testRun)
+
+		Expect(err).NotTo(HaveOccurred())
+		flakyRepo.AssertNotCalled(GinkgoT(), "FindByProject", mock.Anything, mock.Anything)
+	})
+
+	It("should list failures through the spec run repository", func() {
+		filter := domain.FailureFilter{ProjectID: "proj-1", Owner: "@payments", Limit: 10}
+		failures := []*domain.SpecFailure{{SpecRun: &domain.SpecRun{ID: 3, Status: "failed"}, SuiteName: "Checkout"}}
+		mockSpecRepo.On("FindFailures", ctx, filter).Return(failures, nil)
+
+		result, err := service.ListFailures(ctx, filter)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(failures))
+	})
+})
@@ -8,6 +8,8 @@ import (
 
 	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
 	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +18,29 @@ type TestRunService struct {
 	testRunRepo  domain.TestRunRepository
 	suiteRunRepo domain.SuiteRunRepository
 	specRunRepo  domain.SpecRunRepository
+
+	// Optional ownership resolution on ingestion
+	ownershipProvider domain.OwnershipRulesProvider
+	flakyRepo         domain.FlakyTestRepository
+
+	// Optional indexing of ingested failures
+	failureIndexer FailureIndexer
+
+	// Optional publishing of created and completed test runs
+	eventPublisher EventPublisher
+
+	// Optional counting of ingested test data
+	ingestionRecorder IngestionRecorder
+}
+
+// FailureIndexer receives failed spec runs as they are ingested
+type FailureIndexer interface {
+	IndexFailures(projectID string, specRuns []*domain.SpecRun)
+}
+
+// IngestionRecorder counts the test runs, suite runs and spec runs ingested per project
+type IngestionRecorder interface {
+	RecordIngestion(projectID string, testRuns, suiteRuns, specRuns int)
 }
 
 // NewTestRunService creates a new test run service
@@ -31,9 +56,38 @@ func NewTestRunService(
 	}
 }
 
+// SetOwnership enables resolving spec owners from project ownership rules on ingestion.
+// When flakyRepo is set, the owners of matching flaky tests are kept up to date as well.
+func (s *TestRunService) SetOwnership(provider domain.OwnershipRulesProvider, flakyRepo domain.FlakyTestRepository) {
+	s.ownershipProvider = provider
+	s.flakyRepo = flakyRepo
+}
+
+// SetFailureIndexer enables passing failed spec runs to the indexer on ingestion
+func (s *TestRunService) SetFailureIndexer(indexer FailureIndexer) {
+	s.failureIndexer = indexer
+}
+
+// SetIngestionRecorder enables counting the test data ingested per project
+func (s *TestRunService) SetIngestionRecorder(recorder IngestionRecorder) {
+	s.ingestionRecorder = recorder
+}
+
 // CreateTestRun creates a new test run
 // Returns the test run (existing or newly created), a flag indicating if it already existed, and any error
-func (s *TestRunService) CreateTestRun(ctx context.Context, testRun *domain.TestRun) (*domain.TestRun, bool, error) {
+func (s *TestRunService) CreateTestRun(ctx context.Context, testRun *domain.TestRun) (created *domain.TestRun, existed bool, err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.CreateTestRun", attribute.String("fern.project_id", testRun.ProjectID))
+	defer func() { tracing.End(span, err) }()
+
+	created, existed, err = s.createTestRun(ctx, testRun)
+	if err == nil && !existed {
+		s.publishCreated(ctx, created)
+	}
+	return created, existed, err
+}
+
+// createTestRun creates a new test run without publishing it
+func (s *TestRunService) createTestRun(ctx context.Context, testRun *domain.TestRun) (*domain.TestRun, bool, error) {
 	// Validate test run
 	if testRun.ProjectID == "" {
 		return nil, false, fmt.Errorf("project ID is required")
@@ -44,6 +98,12 @@ func (s *TestRunService) CreateTestRun(ctx context.Context, testRun *domain.Test
 		testRun.Status = "running"
 	}
 
+	suites := make([]*domain.SuiteRun, len(testRun.SuiteRuns))
+	for i := range testRun.SuiteRuns {
+		suites[i] = &testRun.SuiteRuns[i]
+	}
+	s.assignOwners(ctx, testRun.ProjectID, suites)
+
 	// Create the test run
 	if err := s.testRunRepo.Create(ctx, testRun); err != nil {
 		// Check if it's a unique constraint violation (concurrent thread created it)
@@ -64,11 +124,18 @@ func (s *TestRunService) CreateTestRun(ctx context.Context, testRun *domain.Test
 	}
 
 	fmt.Println("New test run created with ID:", testRun.ID)
+	for _, suite := range suites {
+		s.indexFailures(testRun.ProjectID, suite.SpecRuns)
+	}
+	s.recordIngestion(testRun.ProjectID, 1, suites)
 	return testRun, false, nil // false = newly created
 }
 
 // CompleteTestRun marks a test run as completed
-func (s *TestRunService) CompleteTestRun(ctx context.Context, testRunID uint, status string) error {
+func (s *TestRunService) CompleteTestRun(ctx context.Context, testRunID uint, status string) (err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.CompleteTestRun", attribute.Int("fern.test_run.id", int(testRunID)))
+	defer func() { tracing.End(span, err) }()
+
 	// Get the test run
 	testRun, err := s.testRunRepo.GetByID(ctx, testRunID)
 	if err != nil {
@@ -102,6 +169,7 @@ func (s *TestRunService) CompleteTestRun(ctx context.Context, testRunID uint, st
 		return fmt.Errorf("failed to update test run: %w", err)
 	}
 
+	s.publishCompleted(ctx, testRun)
 	return nil
 }
 
@@ -121,7 +189,10 @@ func (s *TestRunService) AddSuiteRun(ctx context.Context, testRunID uint, suiteR
 }
 
 // AddSpecRun adds a spec run to a suite
-func (s *TestRunService) AddSpecRun(ctx context.Context, suiteRunID uint, specRun *domain.SpecRun) error {
+func (s *TestRunService) AddSpecRun(ctx context.Context, suiteRunID uint, specRun *domain.SpecRun) (err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.AddSpecRun", attribute.Int("fern.suite_run.id", int(suiteRunID)))
+	defer func() { tracing.End(span, err) }()
+
 	// Validate
 	if specRun.SuiteRunID != suiteRunID {
 		return fmt.Errorf("spec run suite ID mismatch")
@@ -131,6 +202,8 @@ func (s *TestRunService) AddSpecRun(ctx context.Context, suiteRunID uint, specRu
 	if err := s.specRunRepo.Create(ctx, specRun); err != nil {
 		return fmt.Errorf("failed to create spec run: %w", err)
 	}
+	s.indexSpecRun(ctx, specRun)
+	s.recordSpecRun(ctx, specRun)
 
 	// Update suite statistics
 	if err := s.updateSuiteStatistics(ctx, suiteRunID); err != nil {
@@ -216,12 +289,21 @@ func (s *TestRunService) updateSuiteStatistics(ctx context.Context, suiteRunID u
 }
 
 // CreateTestRunWithSuites creates a test run with all its suites and specs in one transaction
-func (s *TestRunService) CreateTestRunWithSuites(ctx context.Context, testRun *domain.TestRun, suites []domain.SuiteRun) error {
-	// Create the test run
-	createdTestRun, _, err := s.CreateTestRun(ctx, testRun)
+func (s *TestRunService) CreateTestRunWithSuites(ctx context.Context, testRun *domain.TestRun, suites []domain.SuiteRun) (err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.CreateTestRunWithSuites",
+		attribute.String("fern.project_id", testRun.ProjectID),
+		attribute.Int("fern.suite_runs", len(suites)),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// Create the test run; it is published as completed once its suites are saved
+	createdTestRun, existed, err := s.createTestRun(ctx, testRun)
 	if err != nil {
 		return err
 	}
+	if !existed && s.eventPublisher != nil {
+		s.eventPublisher.TestRunCreated(ctx, createdTestRun)
+	}
 
 	// Use the returned test run (either new or existing)
 	if createdTestRun != nil {
@@ -231,6 +313,12 @@ func (s *TestRunService) CreateTestRunWithSuites(ctx context.Context, testRun *d
 	// Always add the suite runs, whether test run is new or existing
 	// This handles the concurrent creation case where another thread created the test run
 
+	suitePtrs := make([]*domain.SuiteRun, len(suites))
+	for i := range suites {
+		suitePtrs[i] = &suites[i]
+	}
+	s.assignOwners(ctx, testRun.ProjectID, suitePtrs)
+
 	// Create all suites
 	for _, suite := range suites {
 		suite.TestRunID = testRun.ID
@@ -246,8 +334,10 @@ func (s *TestRunService) CreateTestRunWithSuites(ctx context.Context, testRun *d
 			if err := s.specRunRepo.CreateBatch(ctx, suite.SpecRuns); err != nil {
 				return fmt.Errorf("failed to create spec runs: %w", err)
 			}
+			s.indexFailures(testRun.ProjectID, suite.SpecRuns)
 		}
 	}
+	s.recordIngestion(testRun.ProjectID, 0, suitePtrs)
 
 	// Update test run statistics
 	return s.CompleteTestRun(ctx, testRun.ID, testRun.Status)
@@ -258,13 +348,24 @@ func (s *TestRunService) GetTestRunByRunID(ctx context.Context, runID string) (*
 	return s.testRunRepo.GetByRunID(ctx, runID)
 }
 
+// ListFailures retrieves failed spec runs matching the filter, newest first
+func (s *TestRunService) ListFailures(ctx context.Context, filter domain.FailureFilter) ([]*domain.SpecFailure, error) {
+	return s.specRunRepo.FindFailures(ctx, filter)
+}
+
 // GetRecentTestRuns retrieves recent test runs across all projects
 func (s *TestRunService) GetRecentTestRuns(ctx context.Context, limit int) ([]*domain.TestRun, error) {
 	return s.testRunRepo.GetRecent(ctx, limit)
 }
 
 // CreateSuiteRun creates a new suite run
-func (s *TestRunService) CreateSuiteRun(ctx context.Context, suiteRun *domain.SuiteRun) error {
+func (s *TestRunService) CreateSuiteRun(ctx context.Context, suiteRun *domain.SuiteRun) (err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.CreateSuiteRun",
+		attribute.Int("fern.test_run.id", int(suiteRun.TestRunID)),
+		attribute.Int("fern.spec_runs", len(suiteRun.SpecRuns)),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	if suiteRun.TestRunID == 0 {
 		return fmt.Errorf("test run ID is required")
 	}
@@ -278,11 +379,96 @@ func (s *TestRunService) CreateSuiteRun(ctx context.Context, suiteRun *domain.Su
 		suiteRun.StartTime = time.Now()
 	}
 
-	return s.suiteRunRepo.Create(ctx, suiteRun)
+	resolveOwners := s.ownershipProvider != nil && len(suiteRun.SpecRuns) > 0
+	var projectID string
+	if resolveOwners || s.ingestionRecorder != nil {
+		if testRun, err := s.testRunRepo.GetByID(ctx, suiteRun.TestRunID); err != nil {
+			fmt.Printf("failed to resolve project of suite run: %v\n", err)
+		} else {
+			projectID = testRun.ProjectID
+		}
+	}
+	if resolveOwners && projectID != "" {
+		s.assignOwners(ctx, projectID, []*domain.SuiteRun{suiteRun})
+	}
+
+	if err := s.suiteRunRepo.Create(ctx, suiteRun); err != nil {
+		return err
+	}
+	if projectID != "" {
+		// The specs of the suite are counted with it, including those created separately afterwards
+		s.recordIngestion(projectID, 0, []*domain.SuiteRun{suiteRun})
+	}
+	return nil
+}
+
+// assignOwners resolves the owners of the given suites' specs from the project's ownership rules
+// and updates the owners of matching flaky tests. Failures are logged and never block ingestion.
+func (s *TestRunService) assignOwners(ctx context.Context, projectID string, suites []*domain.SuiteRun) {
+	if s.ownershipProvider == nil || len(suites) == 0 {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "TestRunService.assignOwners")
+	defer span.End()
+
+	matcher, err := s.ownershipProvider.MatcherForProject(ctx, projectID)
+	if err != nil {
+		fmt.Printf("failed to load ownership rules for project %s: %v\n", projectID, err)
+		return
+	}
+	if matcher == nil {
+		return
+	}
+
+	owners := make(map[string][]string)
+	for _, suite := range suites {
+		suite.AssignOwners(matcher)
+		for _, spec := range suite.SpecRuns {
+			if spec != nil {
+				owners[ownerKey(suite.Name, spec.Name)] = spec.Owners
+				owners[ownerKey("", spec.Name)] = spec.Owners
+			}
+		}
+	}
+
+	if err := s.updateFlakyTestOwners(ctx, projectID, owners); err != nil {
+		fmt.Printf("failed to update flaky test owners: %v\n", err)
+	}
+}
+
+// ownerKey identifies a spec by suite and name; flaky tests recorded without a suite match on name only
+func ownerKey(suiteName, specName string) string {
+	return suiteName + "\x00" + specName
+}
+
+// updateFlakyTestOwners stores newly resolved owners on the project's flaky tests
+func (s *TestRunService) updateFlakyTestOwners(ctx context.Context, projectID string, owners map[string][]string) error {
+	if s.flakyRepo == nil || len(owners) == 0 {
+		return nil
+	}
+
+	flakyTests, err := s.flakyRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	for _, flakyTest := range flakyTests {
+		specOwners, ok := owners[ownerKey(flakyTest.SuiteName(), flakyTest.TestName())]
+		if !ok || !flakyTest.AssignOwners(specOwners) {
+			continue
+		}
+		if err := s.flakyRepo.Update(ctx, flakyTest); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // CreateSpecRun creates a new spec run
-func (s *TestRunService) CreateSpecRun(ctx context.Context, specRun *domain.SpecRun) error {
+func (s *TestRunService) CreateSpecRun(ctx context.Context, specRun *domain.SpecRun) (err error) {
+	ctx, span := tracing.Start(ctx, "TestRunService.CreateSpecRun", attribute.Int("fern.suite_run.id", int(specRun.SuiteRunID)))
+	defer func() { tracing.End(span, err) }()
+
 	if specRun.SuiteRunID == 0 {
 		return fmt.Errorf("suite run ID is required")
 	}
@@ -304,7 +490,82 @@ func (s *TestRunService) CreateSpecRun(ctx context.Context, specRun *domain.Spec
 		}
 	}
 
-	return s.specRunRepo.Create(ctx, specRun)
+	if err := s.specRunRepo.Create(ctx, specRun); err != nil {
+		return err
+	}
+	s.indexSpecRun(ctx, specRun)
+	return nil
+}
+
+// recordIngestion counts a project's newly ingested test runs, and suites with their specs
+func (s *TestRunService) recordIngestion(projectID string, testRuns int, suites []*domain.SuiteRun) {
+	if s.ingestionRecorder == nil {
+		return
+	}
+	specRuns := 0
+	for _, suite := range suites {
+		specRuns += len(suite.SpecRuns)
+	}
+	s.ingestionRecorder.RecordIngestion(projectID, testRuns, len(suites), specRuns)
+}
+
+// indexFailures passes the failed spec runs of a project to the failure indexer
+func (s *TestRunService) indexFailures(projectID string, specRuns []*domain.SpecRun) {
+	if s.failureIndexer == nil {
+		return
+	}
+
+	var failed []*domain.SpecRun
+	for _, spec := range specRuns {
+		if spec != nil && spec.ID != 0 && spec.Status == "failed" {
+			failed = append(failed, spec)
+		}
+	}
+	if len(failed) > 0 {
+		s.failureIndexer.IndexFailures(projectID, failed)
+	}
+}
+
+// indexSpecRun indexes a single failed spec run, looking up its project.
+// Failures are logged and never block ingestion.
+func (s *TestRunService) indexSpecRun(ctx context.Context, specRun *domain.SpecRun) {
+	if s.failureIndexer == nil || specRun.Status != "failed" {
+		return
+	}
+
+	projectID, err := s.projectOfSuiteRun(ctx, specRun.SuiteRunID)
+	if err != nil {
+		fmt.Printf("failed to index spec run %d: %v\n", specRun.ID, err)
+		return
+	}
+	s.indexFailures(projectID, []*domain.SpecRun{specRun})
+}
+
+// recordSpecRun counts a spec run added on its own to a suite
+func (s *TestRunService) recordSpecRun(ctx context.Context, specRun *domain.SpecRun) {
+	if s.ingestionRecorder == nil {
+		return
+	}
+
+	projectID, err := s.projectOfSuiteRun(ctx, specRun.SuiteRunID)
+	if err != nil {
+		fmt.Printf("failed to record spec run %d: %v\n", specRun.ID, err)
+		return
+	}
+	s.ingestionRecorder.RecordIngestion(projectID, 0, 0, 1)
+}
+
+// projectOfSuiteRun returns the project a suite run was ingested for
+func (s *TestRunService) projectOfSuiteRun(ctx context.Context, suiteRunID uint) (string, error) {
+	suiteRun, err := s.suiteRunRepo.GetByID(ctx, suiteRunID)
+	if err != nil {
+		return "", err
+	}
+	testRun, err := s.testRunRepo.GetByID(ctx, suiteRun.TestRunID)
+	if err != nil {
+		return "", err
+	}
+	return testRun.ProjectID, nil
 }
 
 // DeleteTestRun deletes a test run by ID
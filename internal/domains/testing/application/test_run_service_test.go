@@ -201,6 +201,14 @@ func (m *MockSpecRunRepository) FindBySuiteRunID(ctx context.Context, suiteRunID
 	return args.Get(0).([]*domain.SpecRun), args.Error(1)
 }
 
+func (m *MockSpecRunRepository) FindFailures(ctx context.Context, filter domain.FailureFilter) ([]*domain.SpecFailure, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SpecFailure), args.Error(1)
+}
+
 func (m *MockSpecRunRepository) CreateBatch(ctx context.Context, specRuns []*domain.SpecRun) error {
 	args := m.Called(ctx, specRuns)
 	return args.Error(0)
@@ -24,19 +24,81 @@ const (
 	FlakyStatusIgnored  FlakyStatus = "ignored"
 )
 
+// FlakyActorType identifies who changed the status of a flaky test
+type FlakyActorType string
+
+const (
+	FlakyActorSystem FlakyActorType = "system"
+	FlakyActorUser   FlakyActorType = "user"
+)
+
+// FlakyActor is the system or user responsible for a status change
+type FlakyActor struct {
+	Type FlakyActorType
+	ID   string
+}
+
+// SystemActor returns the actor used for automatic lifecycle transitions
+func SystemActor() FlakyActor {
+	return FlakyActor{Type: FlakyActorSystem, ID: "system"}
+}
+
+// IntegrationActor returns the actor used for transitions driven by an
+// external integration such as JIRA. It is recorded as a system change.
+func IntegrationActor(name string) FlakyActor {
+	return FlakyActor{Type: FlakyActorSystem, ID: name}
+}
+
+// UserActor returns an actor for a change made by a user
+func UserActor(userID string) FlakyActor {
+	return FlakyActor{Type: FlakyActorUser, ID: userID}
+}
+
+// FlakyStatusChange records a single status transition of a flaky test
+type FlakyStatusChange struct {
+	FlakyTestID uint
+	FromStatus  FlakyStatus
+	ToStatus    FlakyStatus
+	Actor       FlakyActor
+	Reason      string
+	ChangedAt   time.Time
+}
+
+// SpecExecution is a single recorded execution of a test used for lifecycle decisions
+type SpecExecution struct {
+	SpecRunID    uint
+	TestRunID    string
+	Status       string
+	IsFlaky      bool
+	ErrorMessage string
+	ExecutedAt   time.Time
+}
+
+// Flaked reports whether the execution failed or only passed after retrying
+func (e SpecExecution) Flaked() bool {
+	return e.Status == "failed" || e.IsFlaky
+}
+
 // FlakyTest represents test flakiness analysis data
 type FlakyTest struct {
-	projectID        string
-	testName         string
-	suiteName        string
-	flakeRate        float64
-	totalExecutions  int
-	flakyExecutions  int
-	lastSeenAt       time.Time
-	firstSeenAt      time.Time
-	status           FlakyStatus
-	severity         FlakySeverity
-	lastErrorMessage string
+	id                uint
+	projectID         string
+	testName          string
+	suiteName         string
+	flakeRate         float64
+	totalExecutions   int
+	flakyExecutions   int
+	consecutivePasses int
+	lastSeenAt        time.Time
+	firstSeenAt       time.Time
+	status            FlakyStatus
+	statusChangedAt   time.Time
+	severity          FlakySeverity
+	lastErrorMessage  string
+	owners            []string
+
+	// Status changes not yet persisted
+	pendingChanges []FlakyStatusChange
 }
 
 // NewFlakyTest creates a new flaky test record
@@ -50,13 +112,14 @@ func NewFlakyTest(projectID, testName, suiteName string) (*FlakyTest, error) {
 
 	now := time.Now()
 	return &FlakyTest{
-		projectID:   projectID,
-		testName:    testName,
-		suiteName:   suiteName,
-		status:      FlakyStatusActive,
-		severity:    FlakySeverityLow,
-		firstSeenAt: now,
-		lastSeenAt:  now,
+		projectID:       projectID,
+		testName:        testName,
+		suiteName:       suiteName,
+		status:          FlakyStatusActive,
+		statusChangedAt: now,
+		severity:        FlakySeverityLow,
+		firstSeenAt:     now,
+		lastSeenAt:      now,
 	}, nil
 }
 
@@ -66,6 +129,9 @@ func (ft *FlakyTest) RecordExecution(isFlaky bool, errorMessage string) {
 	if isFlaky {
 		ft.flakyExecutions++
 		ft.lastErrorMessage = errorMessage
+		ft.consecutivePasses = 0
+	} else {
+		ft.consecutivePasses++
 	}
 
 	ft.lastSeenAt = time.Now()
@@ -100,36 +166,161 @@ func (ft *FlakyTest) updateSeverity() {
 
 // Resolve marks the flaky test as resolved
 func (ft *FlakyTest) Resolve() error {
+	return ft.ResolveBy(SystemActor(), "")
+}
+
+// ResolveBy marks the flaky test as resolved and records who resolved it and why
+func (ft *FlakyTest) ResolveBy(actor FlakyActor, reason string) error {
 	if ft.status != FlakyStatusActive {
 		return errors.New("can only resolve active flaky tests")
 	}
-	ft.status = FlakyStatusResolved
+	ft.changeStatus(FlakyStatusResolved, actor, reason)
 	return nil
 }
 
 // Ignore marks the flaky test as ignored
 func (ft *FlakyTest) Ignore() error {
+	return ft.IgnoreBy(SystemActor(), "")
+}
+
+// IgnoreBy marks the flaky test as ignored and records who ignored it and why
+func (ft *FlakyTest) IgnoreBy(actor FlakyActor, reason string) error {
 	if ft.status == FlakyStatusResolved {
 		return errors.New("cannot ignore resolved flaky tests")
 	}
-	ft.status = FlakyStatusIgnored
+	ft.changeStatus(FlakyStatusIgnored, actor, reason)
 	return nil
 }
 
 // Reactivate marks the flaky test as active again
 func (ft *FlakyTest) Reactivate() {
-	ft.status = FlakyStatusActive
+	ft.ReactivateBy(SystemActor(), "")
+}
+
+// ReactivateBy marks the flaky test as active again and records who reactivated it and why
+func (ft *FlakyTest) ReactivateBy(actor FlakyActor, reason string) {
+	ft.consecutivePasses = 0
+	ft.changeStatus(FlakyStatusActive, actor, reason)
+}
+
+// UpdateConsecutivePasses sets the number of passes since the last flaky execution
+func (ft *FlakyTest) UpdateConsecutivePasses(passes int) {
+	if passes < 0 {
+		passes = 0
+	}
+	ft.consecutivePasses = passes
+}
+
+// ShouldAutoResolve reports whether an active test has passed enough times in a row
+func (ft *FlakyTest) ShouldAutoResolve(requiredPasses int) bool {
+	return ft.status == FlakyStatusActive && requiredPasses > 0 && ft.consecutivePasses >= requiredPasses
+}
+
+// PendingStatusChanges returns status changes made since the test was loaded
+func (ft *FlakyTest) PendingStatusChanges() []FlakyStatusChange {
+	return ft.pendingChanges
+}
+
+// ClearPendingStatusChanges marks pending status changes as persisted
+func (ft *FlakyTest) ClearPendingStatusChanges() {
+	ft.pendingChanges = nil
+}
+
+// SetID sets the internal database ID (used when loading from repository)
+func (ft *FlakyTest) SetID(id uint) {
+	ft.id = id
+	for i := range ft.pendingChanges {
+		ft.pendingChanges[i].FlakyTestID = id
+	}
+}
+
+func (ft *FlakyTest) changeStatus(to FlakyStatus, actor FlakyActor, reason string) {
+	now := time.Now()
+	ft.pendingChanges = append(ft.pendingChanges, FlakyStatusChange{
+		FlakyTestID: ft.id,
+		FromStatus:  ft.status,
+		ToStatus:    to,
+		Actor:       actor,
+		Reason:      reason,
+		ChangedAt:   now,
+	})
+	ft.status = to
+	ft.statusChangedAt = now
 }
 
 // Getters for read-only access
-func (ft *FlakyTest) ProjectID() string        { return ft.projectID }
-func (ft *FlakyTest) TestName() string         { return ft.testName }
-func (ft *FlakyTest) SuiteName() string        { return ft.suiteName }
-func (ft *FlakyTest) FlakeRate() float64       { return ft.flakeRate }
-func (ft *FlakyTest) TotalExecutions() int     { return ft.totalExecutions }
-func (ft *FlakyTest) FlakyExecutions() int     { return ft.flakyExecutions }
-func (ft *FlakyTest) LastSeenAt() time.Time    { return ft.lastSeenAt }
-func (ft *FlakyTest) FirstSeenAt() time.Time   { return ft.firstSeenAt }
-func (ft *FlakyTest) Status() FlakyStatus      { return ft.status }
-func (ft *FlakyTest) Severity() FlakySeverity  { return ft.severity }
-func (ft *FlakyTest) LastErrorMessage() string { return ft.lastErrorMessage }
+func (ft *FlakyTest) ID() uint                   { return ft.id }
+func (ft *FlakyTest) ProjectID() string          { return ft.projectID }
+func (ft *FlakyTest) TestName() string           { return ft.testName }
+func (ft *FlakyTest) SuiteName() string          { return ft.suiteName }
+func (ft *FlakyTest) FlakeRate() float64         { return ft.flakeRate }
+func (ft *FlakyTest) TotalExecutions() int       { return ft.totalExecutions }
+func (ft *FlakyTest) FlakyExecutions() int       { return ft.flakyExecutions }
+func (ft *FlakyTest) ConsecutivePasses() int     { return ft.consecutivePasses }
+func (ft *FlakyTest) LastSeenAt() time.Time      { return ft.lastSeenAt }
+func (ft *FlakyTest) FirstSeenAt() time.Time     { return ft.firstSeenAt }
+func (ft *FlakyTest) Status() FlakyStatus        { return ft.status }
+func (ft *FlakyTest) StatusChangedAt() time.Time { return ft.statusChangedAt }
+func (ft *FlakyTest) Severity() FlakySeverity    { return ft.severity }
+func (ft *FlakyTest) LastErrorMessage() string   { return ft.lastErrorMessage }
+
+// FlakyTestSnapshot is a plain representation of a flaky test used for persistence
+type FlakyTestSnapshot struct {
+	ID                uint
+	ProjectID         string
+	TestName          string
+	SuiteName         string
+	FlakeRate         float64
+	TotalExecutions   int
+	FlakyExecutions   int
+	ConsecutivePasses int
+	LastSeenAt        time.Time
+	FirstSeenAt       time.Time
+	Status            FlakyStatus
+	StatusChangedAt   time.Time
+	Severity          FlakySeverity
+	LastErrorMessage  string
+	Owners            []string
+}
+
+// ToSnapshot returns a snapshot of the flaky test
+func (ft *FlakyTest) ToSnapshot() FlakyTestSnapshot {
+	return FlakyTestSnapshot{
+		ID:                ft.id,
+		ProjectID:         ft.projectID,
+		TestName:          ft.testName,
+		SuiteName:         ft.suiteName,
+		FlakeRate:         ft.flakeRate,
+		TotalExecutions:   ft.totalExecutions,
+		FlakyExecutions:   ft.flakyExecutions,
+		ConsecutivePasses: ft.consecutivePasses,
+		LastSeenAt:        ft.lastSeenAt,
+		FirstSeenAt:       ft.firstSeenAt,
+		Status:            ft.status,
+		StatusChangedAt:   ft.statusChangedAt,
+		Severity:          ft.severity,
+		LastErrorMessage:  ft.lastErrorMessage,
+		Owners:            ft.Owners(),
+	}
+}
+
+// ReconstructFlakyTest rebuilds a flaky test from persisted data
+func ReconstructFlakyTest(s FlakyTestSnapshot) *FlakyTest {
+	return &FlakyTest{
+		id:                s.ID,
+		projectID:         s.ProjectID,
+		testName:          s.TestName,
+		suiteName:         s.SuiteName,
+		flakeRate:         s.FlakeRate,
+		totalExecutions:   s.TotalExecutions,
+		flakyExecutions:   s.FlakyExecutions,
+		consecutivePasses: s.ConsecutivePasses,
+		lastSeenAt:        s.LastSeenAt,
+		firstSeenAt:       s.FirstSeenAt,
+		status:            s.Status,
+		statusChangedAt:   s.StatusChangedAt,
+		severity:          s.Severity,
+		lastErrorMessage:  s.LastErrorMessage,
+		owners:            append([]string(nil), s.Owners...),
+	}
+}
@@ -241,6 +241,101 @@ var _ = Describe("FlakyTest", Label("unit", "domain", "testing"), func() {
 		})
 	})
 
+	Describe("Status History", func() {
+		var flakyTest *domain.FlakyTest
+
+		BeforeEach(func() {
+			var err error
+			flakyTest, err = domain.NewFlakyTest("project-123", "test-name", "suite-name")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should record status changes with actor and reason", func() {
+			err := flakyTest.ResolveBy(domain.UserActor("user-1"), "fixed timing issue")
+			Expect(err).NotTo(HaveOccurred())
+			flakyTest.ReactivateBy(domain.SystemActor(), "flaked again")
+
+			changes := flakyTest.PendingStatusChanges()
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].FromStatus).To(Equal(domain.FlakyStatusActive))
+			Expect(changes[0].ToStatus).To(Equal(domain.FlakyStatusResolved))
+			Expect(changes[0].Actor).To(Equal(domain.FlakyActor{Type: domain.FlakyActorUser, ID: "user-1"}))
+			Expect(changes[0].Reason).To(Equal("fixed timing issue"))
+			Expect(changes[1].ToStatus).To(Equal(domain.FlakyStatusActive))
+			Expect(changes[1].Actor.Type).To(Equal(domain.FlakyActorSystem))
+			Expect(flakyTest.StatusChangedAt()).To(Equal(changes[1].ChangedAt))
+		})
+
+		It("should not record a change when the transition is rejected", func() {
+			Expect(flakyTest.Resolve()).To(Succeed())
+			flakyTest.ClearPendingStatusChanges()
+
+			Expect(flakyTest.Ignore()).To(HaveOccurred())
+			Expect(flakyTest.PendingStatusChanges()).To(BeEmpty())
+		})
+
+		It("should stamp pending changes with the ID once assigned", func() {
+			Expect(flakyTest.Resolve()).To(Succeed())
+			flakyTest.SetID(42)
+
+			Expect(flakyTest.PendingStatusChanges()[0].FlakyTestID).To(Equal(uint(42)))
+		})
+	})
+
+	Describe("Auto-resolution", func() {
+		var flakyTest *domain.FlakyTest
+
+		BeforeEach(func() {
+			var err error
+			flakyTest, err = domain.NewFlakyTest("project-123", "test-name", "suite-name")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should track consecutive passes and reset them on a flaky execution", func() {
+			flakyTest.RecordExecution(false, "")
+			flakyTest.RecordExecution(false, "")
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(2))
+
+			flakyTest.RecordExecution(true, "timeout")
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(0))
+		})
+
+		It("should only auto-resolve active tests that reached the threshold", func() {
+			flakyTest.UpdateConsecutivePasses(4)
+			Expect(flakyTest.ShouldAutoResolve(5)).To(BeFalse())
+
+			flakyTest.UpdateConsecutivePasses(5)
+			Expect(flakyTest.ShouldAutoResolve(5)).To(BeTrue())
+			Expect(flakyTest.ShouldAutoResolve(0)).To(BeFalse())
+
+			Expect(flakyTest.Ignore()).To(Succeed())
+			Expect(flakyTest.ShouldAutoResolve(5)).To(BeFalse())
+		})
+
+		It("should reset consecutive passes when reactivated", func() {
+			flakyTest.UpdateConsecutivePasses(5)
+			Expect(flakyTest.Resolve()).To(Succeed())
+
+			flakyTest.Reactivate()
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(0))
+		})
+	})
+
+	Describe("Snapshot", func() {
+		It("should round-trip through ToSnapshot and ReconstructFlakyTest", func() {
+			flakyTest, err := domain.NewFlakyTest("project-123", "test-name", "suite-name")
+			Expect(err).NotTo(HaveOccurred())
+			flakyTest.SetID(7)
+			flakyTest.RecordExecution(true, "boom")
+			flakyTest.RecordExecution(false, "")
+
+			rebuilt := domain.ReconstructFlakyTest(flakyTest.ToSnapshot())
+
+			Expect(rebuilt.ToSnapshot()).To(Equal(flakyTest.ToSnapshot()))
+			Expect(rebuilt.PendingStatusChanges()).To(BeEmpty())
+		})
+	})
+
 	Describe("Edge Cases", func() {
 		It("should handle zero executions without division by zero", func() {
 			flakyTest, err := domain.NewFlakyTest("project-123", "test-name", "suite-name")
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OwnershipTarget describes a spec whose owners are being resolved
+type OwnershipTarget struct {
+	SuiteName   string
+	SpecName    string
+	PackageName string
+	ClassName   string
+	Tags        []string
+}
+
+// OwnerMatcher resolves the owners of a spec
+type OwnerMatcher interface {
+	// MatchOwners returns the owners of the target, or nil when it is unowned
+	MatchOwners(target OwnershipTarget) []string
+}
+
+// OwnershipRulesProvider loads the ownership rules configured for a project
+type OwnershipRulesProvider interface {
+	// MatcherForProject returns the project's owner matcher, or nil when the project has no rules
+	MatcherForProject(ctx context.Context, projectID string) (OwnerMatcher, error)
+}
+
+// FlakyTestFilter narrows a flaky test query; zero values are ignored
+type FlakyTestFilter struct {
+	ProjectID    string
+	Owner        string
+	Statuses     []FlakyStatus
+	Severities   []FlakySeverity
+	MinFlakeRate float64
+	Limit        int
+	Offset       int
+}
+
+// FailureFilter narrows a failed spec run query; zero values are ignored
+type FailureFilter struct {
+	ProjectID  string
+	Owner      string
+	SpecRunIDs []uint
+	Since      time.Time
+	Limit      int
+	Offset     int
+}
+
+// SpecFailure is a failed spec run with the context of its suite and test run
+type SpecFailure struct {
+	SpecRun   *SpecRun
+	SuiteName string
+	TestRunID string
+	ProjectID string
+	Branch    string
+}
+
+// AssignOwners resolves and stores the owners of every spec in the suite
+func (s *SuiteRun) AssignOwners(matcher OwnerMatcher) {
+	if matcher == nil {
+		return
+	}
+	suiteTags := tagNames(s.Tags)
+	for _, spec := range s.SpecRuns {
+		if spec == nil {
+			continue
+		}
+		className := spec.ClassName
+		if className == "" {
+			className = s.ClassName
+		}
+		spec.Owners = matcher.MatchOwners(OwnershipTarget{
+			SuiteName:   s.Name,
+			SpecName:    spec.Name,
+			PackageName: s.PackageName,
+			ClassName:   className,
+			Tags:        append(append([]string{}, suiteTags...), tagNames(spec.Tags)...),
+		})
+	}
+}
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// AssignOwners replaces the owners of the flaky test and reports whether they changed
+func (ft *FlakyTest) AssignOwners(owners []string) bool {
+	if equalOwners(ft.owners, owners) {
+		return false
+	}
+	ft.owners = append([]string(nil), owners...)
+	return true
+}
+
+// Owners returns the owners of the flaky test
+func (ft *FlakyTest) Owners() []string {
+	return append([]string(nil), ft.owners...)
+}
+
+// HasOwner reports whether the owner is one of the flaky test's owners
+func (ft *FlakyTest) HasOwner(owner string) bool {
+	for _, o := range ft.owners {
+		if o == owner {
+			return true
+		}
+	}
+	return false
+}
+
+func equalOwners(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// recordingMatcher returns owners by suite name and records the targets it was asked about
+type recordingMatcher struct {
+	owners  map[string][]string
+	targets []domain.OwnershipTarget
+}
+
+func (m *recordingMatcher) MatchOwners(target domain.OwnershipTarget) []string {
+	m.targets = append(m.targets, target)
+	return m.owners[target.SuiteName]
+}
+
+var _ = Describe("Ownership", Label("unit", "domain", "testing"), func() {
+	Describe("SuiteRun.AssignOwners", func() {
+		It("should resolve owners for every spec using suite and spec details", func() {
+			matcher := &recordingMatcher{owners: map[string][]string{"Checkout": {"@payments"}}}
+			suite := &domain.SuiteRun{
+				Name:        "Checkout",
+				PackageName: "com.acme.checkout",
+				ClassName:   "CheckoutTest",
+				Tags:        []domain.Tag{{Name: "smoke"}},
+				SpecRuns: []*domain.SpecRun{
+					{Name: "pays by card", Tags: []domain.Tag{{Name: "priority:high"}}},
+					{Name: "pays by invoice", ClassName: "InvoiceTest"},
+				},
+			}
+
+			suite.AssignOwners(matcher)
+
+			Expect(suite.SpecRuns[0].Owners).To(Equal([]string{"@payments"}))
+			Expect(suite.SpecRuns[1].Owners).To(Equal([]string{"@payments"}))
+			Expect(matcher.targets).To(HaveLen(2))
+			Expect(matcher.targets[0]).To(Equal(domain.OwnershipTarget{
+				SuiteName:   "Checkout",
+				SpecName:    "pays by card",
+				PackageName: "com.acme.checkout",
+				ClassName:   "CheckoutTest",
+				Tags:        []string{"smoke", "priority:high"},
+			}))
+			Expect(matcher.targets[1].ClassName).To(Equal("InvoiceTest"))
+		})
+
+		It("should leave specs untouched without a matcher", func() {
+			suite := &domain.SuiteRun{SpecRuns: []*domain.SpecRun{{Name: "spec", Owners: []string{"@old"}}}}
+
+			suite.AssignOwners(nil)
+
+			Expect(suite.SpecRuns[0].Owners).To(Equal([]string{"@old"}))
+		})
+	})
+
+	Describe("FlakyTest.AssignOwners", func() {
+		It("should report whether the owners changed", func() {
+			flakyTest, err := domain.NewFlakyTest("project-1", "test-login", "auth")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(flakyTest.AssignOwners([]string{"@auth"})).To(BeTrue())
+			Expect(flakyTest.AssignOwners([]string{"@auth"})).To(BeFalse())
+			Expect(flakyTest.HasOwner("@auth")).To(BeTrue())
+			Expect(flakyTest.ToSnapshot().Owners).To(Equal([]string{"@auth"}))
+
+			Expect(flakyTest.AssignOwners(nil)).To(BeTrue())
+			Expect(flakyTest.Owners()).To(BeEmpty())
+		})
+
+		It("should restore owners from a snapshot", func() {
+			flakyTest := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{ID: 1, Owners: []string{"@qa", "dev@example.com"}})
+
+			Expect(flakyTest.Owners()).To(Equal([]string{"@qa", "dev@example.com"}))
+		})
+	})
+})
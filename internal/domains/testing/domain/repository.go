@@ -71,6 +71,9 @@ type SpecRunRepository interface {
 
 	// FindBySuiteRunID retrieves all spec runs for a suite
 	FindBySuiteRunID(ctx context.Context, suiteRunID uint) ([]*SpecRun, error)
+
+	// FindFailures retrieves failed spec runs, newest first
+	FindFailures(ctx context.Context, filter FailureFilter) ([]*SpecFailure, error)
 }
 
 // FlakyTestRepository defines the interface for flaky test persistence
@@ -86,4 +89,19 @@ type FlakyTestRepository interface {
 
 	// Update updates flaky test statistics
 	Update(ctx context.Context, flakyTest *FlakyTest) error
+
+	// FindByID retrieves a flaky test by its database ID
+	FindByID(ctx context.Context, id uint) (*FlakyTest, error)
+
+	// FindByStatus retrieves flaky tests across all projects with any of the given statuses
+	FindByStatus(ctx context.Context, statuses ...FlakyStatus) ([]*FlakyTest, error)
+
+	// GetStatusHistory retrieves the status changes of a flaky test, newest first
+	GetStatusHistory(ctx context.Context, flakyTestID uint) ([]FlakyStatusChange, error)
+
+	// FindRecentExecutions retrieves the latest executions of a test, newest first
+	FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]SpecExecution, error)
+
+	// FindByFilter retrieves flaky tests matching the filter, highest flake rate first
+	FindByFilter(ctx context.Context, filter FlakyTestFilter) ([]*FlakyTest, error)
 }
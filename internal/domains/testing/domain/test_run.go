@@ -63,6 +63,7 @@ type SpecRun struct {
 	StackTrace     string        `json:"stack_trace"`
 	RetryCount     int           `json:"retry_count"`
 	IsFlaky        bool          `json:"is_flaky"`
+	Owners         []string      `json:"owners"`
 	Tags           []Tag         `json:"tags"`
 }
 
@@ -97,6 +97,7 @@ func (c *DatabaseConverter) ConvertDomainSpecRunsToDatabase(domainSpecRuns []*do
 			StackTrace:   domainSpec.StackTrace,
 			RetryCount:   domainSpec.RetryCount,
 			IsFlaky:      domainSpec.IsFlaky,
+			Owners:       database.StringList(domainSpec.Owners),
 			Tags:         dbTags,
 		}
 	}
@@ -197,6 +198,7 @@ func (c *DatabaseConverter) ConvertSpecRunToDomain(dbSpec *database.SpecRun) *do
 		StackTrace:     dbSpec.StackTrace,
 		RetryCount:     dbSpec.RetryCount,
 		IsFlaky:        dbSpec.IsFlaky,
+		Owners:         dbSpec.Owners,
 		Tags:           tags,
 	}
 }
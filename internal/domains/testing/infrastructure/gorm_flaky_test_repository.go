@@ -0,0 +1,332 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormFlakyTestRepository implements domain.FlakyTestRepository using GORM
+type GormFlakyTestRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFlakyTestRepository creates a new GORM-based flaky test repository
+func NewGormFlakyTestRepository(db *gorm.DB) *GormFlakyTestRepository {
+	return &GormFlakyTestRepository{db: db}
+}
+
+// Save persists a new flaky test and any pending status changes
+func (r *GormFlakyTestRepository) Save(ctx context.Context, flakyTest *domain.FlakyTest) error {
+	dbFlaky := r.toModel(flakyTest)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbFlaky).Error; err != nil {
+			return fmt.Errorf("failed to save flaky test: %w", err)
+		}
+		flakyTest.SetID(dbFlaky.ID)
+		return r.saveStatusChanges(tx, flakyTest)
+	})
+	if err != nil {
+		return err
+	}
+
+	flakyTest.ClearPendingStatusChanges()
+	return nil
+}
+
+// Update updates flaky test statistics and appends any pending status changes
+func (r *GormFlakyTestRepository) Update(ctx context.Context, flakyTest *domain.FlakyTest) error {
+	snapshot := flakyTest.ToSnapshot()
+	if snapshot.ID == 0 {
+		return fmt.Errorf("flaky test has no ID")
+	}
+
+	updates := map[string]interface{}{
+		"flake_rate":         snapshot.FlakeRate,
+		"total_executions":   snapshot.TotalExecutions,
+		"flaky_executions":   snapshot.FlakyExecutions,
+		"consecutive_passes": snapshot.ConsecutivePasses,
+		"last_seen_at":       snapshot.LastSeenAt,
+		"status":             string(snapshot.Status),
+		"status_changed_at":  snapshot.StatusChangedAt,
+		"severity":           string(snapshot.Severity),
+		"last_error_message": snapshot.LastErrorMessage,
+		"owners":             database.StringList(snapshot.Owners),
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&database.FlakyTest{}).Where("id = ?", snapshot.ID).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update flaky test: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("flaky test not found")
+		}
+		return r.saveStatusChanges(tx, flakyTest)
+	})
+	if err != nil {
+		return err
+	}
+
+	flakyTest.ClearPendingStatusChanges()
+	return nil
+}
+
+// FindByID retrieves a flaky test by its database ID
+func (r *GormFlakyTestRepository) FindByID(ctx context.Context, id uint) (*domain.FlakyTest, error) {
+	var dbFlaky database.FlakyTest
+	if err := r.db.WithContext(ctx).First(&dbFlaky, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("flaky test not found")
+		}
+		return nil, fmt.Errorf("failed to find flaky test: %w", err)
+	}
+
+	return r.toDomain(&dbFlaky), nil
+}
+
+// FindByProject retrieves flaky tests for a project
+func (r *GormFlakyTestRepository) FindByProject(ctx context.Context, projectID string) ([]*domain.FlakyTest, error) {
+	var dbFlakyTests []database.FlakyTest
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("flake_rate DESC").
+		Find(&dbFlakyTests).Error; err != nil {
+		return nil, fmt.Errorf("failed to find flaky tests: %w", err)
+	}
+
+	return r.toDomainList(dbFlakyTests), nil
+}
+
+// FindByTestName retrieves the flaky test record for a test in a project
+func (r *GormFlakyTestRepository) FindByTestName(ctx context.Context, projectID, testName string) (*domain.FlakyTest, error) {
+	var dbFlaky database.FlakyTest
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND test_name = ?", projectID, testName).
+		First(&dbFlaky).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("flaky test not found")
+		}
+		return nil, fmt.Errorf("failed to find flaky test: %w", err)
+	}
+
+	return r.toDomain(&dbFlaky), nil
+}
+
+// FindByStatus retrieves flaky tests across all projects with any of the given statuses
+func (r *GormFlakyTestRepository) FindByStatus(ctx context.Context, statuses ...domain.FlakyStatus) ([]*domain.FlakyTest, error) {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	var dbFlakyTests []database.FlakyTest
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", values).
+		Order("project_id, id").
+		Find(&dbFlakyTests).Error; err != nil {
+		return nil, fmt.Errorf("failed to find flaky tests by status: %w", err)
+	}
+
+	return r.toDomainList(dbFlakyTests), nil
+}
+
+// FindByFilter retrieves flaky tests matching the filter, highest flake rate first
+func (r *GormFlakyTestRepository) FindByFilter(ctx context.Context, filter domain.FlakyTestFilter) ([]*domain.FlakyTest, error) {
+	query := r.db.WithContext(ctx).Model(&database.FlakyTest{})
+	if filter.ProjectID != "" {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Owner != "" {
+		query = query.Where("owners @> ?::jsonb", ownerContainment(filter.Owner))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+	if len(filter.Severities) > 0 {
+		severities := make([]string, len(filter.Severities))
+		for i, severity := range filter.Severities {
+			severities[i] = string(severity)
+		}
+		query = query.Where("severity IN ?", severities)
+	}
+	if filter.MinFlakeRate > 0 {
+		query = query.Where("flake_rate >= ?", filter.MinFlakeRate)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var dbFlakyTests []database.FlakyTest
+	if err := query.Order("flake_rate DESC, id").Find(&dbFlakyTests).Error; err != nil {
+		return nil, fmt.Errorf("failed to find flaky tests: %w", err)
+	}
+
+	return r.toDomainList(dbFlakyTests), nil
+}
+
+// GetStatusHistory retrieves the status changes of a flaky test, newest first
+func (r *GormFlakyTestRepository) GetStatusHistory(ctx context.Context, flakyTestID uint) ([]domain.FlakyStatusChange, error) {
+	var dbChanges []database.FlakyTestStatusChange
+	if err := r.db.WithContext(ctx).
+		Where("flaky_test_id = ?", flakyTestID).
+		Order("changed_at DESC, id DESC").
+		Find(&dbChanges).Error; err != nil {
+		return nil, fmt.Errorf("failed to get flaky test status history: %w", err)
+	}
+
+	changes := make([]domain.FlakyStatusChange, len(dbChanges))
+	for i, c := range dbChanges {
+		changes[i] = domain.FlakyStatusChange{
+			FlakyTestID: c.FlakyTestID,
+			FromStatus:  domain.FlakyStatus(c.FromStatus),
+			ToStatus:    domain.FlakyStatus(c.ToStatus),
+			Actor: domain.FlakyActor{
+				Type: domain.FlakyActorType(c.ActorType),
+				ID:   c.ActorID,
+			},
+			Reason:    c.Reason,
+			ChangedAt: c.ChangedAt,
+		}
+	}
+
+	return changes, nil
+}
+
+// FindRecentExecutions retrieves the latest executions of a test, newest first
+func (r *GormFlakyTestRepository) FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]domain.SpecExecution, error) {
+	var rows []struct {
+		SpecRunID    uint
+		TestRunID    string
+		Status       string
+		IsFlaky      bool
+		ErrorMessage string
+		ExecutedAt   time.Time
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("spec_runs").
+		Select("spec_runs.id AS spec_run_id, test_runs.run_id AS test_run_id, spec_runs.status, spec_runs.is_flaky, spec_runs.error_message, spec_runs.created_at AS executed_at").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_run_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND spec_runs.spec_name = ?", projectID, testName).
+		Where("spec_runs.deleted_at IS NULL")
+	if suiteName != "" {
+		query = query.Where("suite_runs.suite_name = ?", suiteName)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Order("spec_runs.created_at DESC, spec_runs.id DESC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find recent executions: %w", err)
+	}
+
+	executions := make([]domain.SpecExecution, len(rows))
+	for i, row := range rows {
+		executions[i] = domain.SpecExecution{
+			SpecRunID:    row.SpecRunID,
+			TestRunID:    row.TestRunID,
+			Status:       row.Status,
+			IsFlaky:      row.IsFlaky,
+			ErrorMessage: row.ErrorMessage,
+			ExecutedAt:   row.ExecutedAt,
+		}
+	}
+
+	return executions, nil
+}
+
+// saveStatusChanges appends pending status changes within a transaction
+func (r *GormFlakyTestRepository) saveStatusChanges(tx *gorm.DB, flakyTest *domain.FlakyTest) error {
+	for _, change := range flakyTest.PendingStatusChanges() {
+		dbChange := &database.FlakyTestStatusChange{
+			FlakyTestID: flakyTest.ID(),
+			FromStatus:  string(change.FromStatus),
+			ToStatus:    string(change.ToStatus),
+			ActorType:   string(change.Actor.Type),
+			ActorID:     change.Actor.ID,
+			Reason:      change.Reason,
+			ChangedAt:   change.ChangedAt,
+		}
+		if err := tx.Create(dbChange).Error; err != nil {
+			return fmt.Errorf("failed to record flaky test status change: %w", err)
+		}
+	}
+	return nil
+}
+
+// ownerContainment returns the JSON array used to match an owner with the jsonb containment operator
+func ownerContainment(owner string) string {
+	data, _ := json.Marshal([]string{owner})
+	return string(data)
+}
+
+func (r *GormFlakyTestRepository) toModel(flakyTest *domain.FlakyTest) *database.FlakyTest {
+	snapshot := flakyTest.ToSnapshot()
+	statusChangedAt := snapshot.StatusChangedAt
+
+	return &database.FlakyTest{
+		BaseModel:         database.BaseModel{ID: snapshot.ID},
+		ProjectID:         snapshot.ProjectID,
+		TestName:          snapshot.TestName,
+		SuiteName:         snapshot.SuiteName,
+		FlakeRate:         snapshot.FlakeRate,
+		TotalExecutions:   snapshot.TotalExecutions,
+		FlakyExecutions:   snapshot.FlakyExecutions,
+		ConsecutivePasses: snapshot.ConsecutivePasses,
+		LastSeenAt:        snapshot.LastSeenAt,
+		FirstSeenAt:       snapshot.FirstSeenAt,
+		Status:            string(snapshot.Status),
+		StatusChangedAt:   &statusChangedAt,
+		Severity:          string(snapshot.Severity),
+		LastErrorMessage:  snapshot.LastErrorMessage,
+		Owners:            database.StringList(snapshot.Owners),
+	}
+}
+
+func (r *GormFlakyTestRepository) toDomain(dbFlaky *database.FlakyTest) *domain.FlakyTest {
+	statusChangedAt := dbFlaky.UpdatedAt
+	if dbFlaky.StatusChangedAt != nil {
+		statusChangedAt = *dbFlaky.StatusChangedAt
+	}
+
+	return domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+		ID:                dbFlaky.ID,
+		ProjectID:         dbFlaky.ProjectID,
+		TestName:          dbFlaky.TestName,
+		SuiteName:         dbFlaky.SuiteName,
+		FlakeRate:         dbFlaky.FlakeRate,
+		TotalExecutions:   dbFlaky.TotalExecutions,
+		FlakyExecutions:   dbFlaky.FlakyExecutions,
+		ConsecutivePasses: dbFlaky.ConsecutivePasses,
+		LastSeenAt:        dbFlaky.LastSeenAt,
+		FirstSeenAt:       dbFlaky.FirstSeenAt,
+		Status:            domain.FlakyStatus(dbFlaky.Status),
+		StatusChangedAt:   statusChangedAt,
+		Severity:          domain.FlakySeverity(dbFlaky.Severity),
+		LastErrorMessage:  dbFlaky.LastErrorMessage,
+		Owners:            dbFlaky.Owners,
+	})
+}
+
+func (r *GormFlakyTestRepository) toDomainList(dbFlakyTests []database.FlakyTest) []*domain.FlakyTest {
+	flakyTests := make([]*domain.FlakyTest, len(dbFlakyTests))
+	for i := range dbFlakyTests {
+		flakyTests[i] = r.toDomain(&dbFlakyTests[i])
+	}
+	return flakyTests
+}
@@ -0,0 +1,179 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/infrastructure"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("GormFlakyTestRepository", func() {
+	var (
+		repository *infrastructure.GormFlakyTestRepository
+		db         *gorm.DB
+		sqlDB      *sql.DB
+		mock       sqlmock.Sqlmock
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		sqlDB, mock, err = sqlmock.New()
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err = gorm.Open(postgres.New(postgres.Config{
+			Conn: sqlDB,
+		}), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		repository = infrastructure.NewGormFlakyTestRepository(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		err := mock.ExpectationsWereMet()
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB.Close()
+	})
+
+	Describe("FindByID", func() {
+		It("should map the row to a domain flaky test", func() {
+			now := time.Now()
+			rows := sqlmock.NewRows([]string{"id", "project_id", "test_name", "suite_name", "flake_rate", "total_executions",
+				"flaky_executions", "consecutive_passes", "last_seen_at", "first_seen_at", "status", "status_changed_at", "severity"}).
+				AddRow(3, "project-1", "test-login", "auth", 25.0, 8, 2, 4, now, now, "active", now, "medium")
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "flaky_tests" WHERE "flaky_tests"."id" = $1`)).
+				WithArgs(3, 1).
+				WillReturnRows(rows)
+
+			flakyTest, err := repository.FindByID(ctx, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flakyTest.ID()).To(Equal(uint(3)))
+			Expect(flakyTest.TestName()).To(Equal("test-login"))
+			Expect(flakyTest.ConsecutivePasses()).To(Equal(4))
+			Expect(flakyTest.Status()).To(Equal(domain.FlakyStatusActive))
+		})
+
+		It("should return not found when no row matches", func() {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "flaky_tests"`)).
+				WillReturnError(gorm.ErrRecordNotFound)
+
+			_, err := repository.FindByID(ctx, 99)
+
+			Expect(err).To(MatchError("flaky test not found"))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should update the flaky test and append pending status changes in one transaction", func() {
+			flakyTest := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+				ID:        3,
+				ProjectID: "project-1",
+				TestName:  "test-login",
+				Status:    domain.FlakyStatusActive,
+			})
+			Expect(flakyTest.ResolveBy(domain.UserActor("user-1"), "fixed")).To(Succeed())
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "flaky_tests" SET`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "flaky_test_status_changes"`)).
+				WithArgs(3, "active", "resolved", "user", "user-1", "fixed", AnyTime{}, AnyTime{}).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectCommit()
+
+			Expect(repository.Update(ctx, flakyTest)).To(Succeed())
+			Expect(flakyTest.PendingStatusChanges()).To(BeEmpty())
+		})
+
+		It("should keep pending changes when the update fails", func() {
+			flakyTest := domain.ReconstructFlakyTest(domain.FlakyTestSnapshot{
+				ID:     3,
+				Status: domain.FlakyStatusActive,
+			})
+			Expect(flakyTest.Resolve()).To(Succeed())
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "flaky_tests" SET`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectRollback()
+
+			Expect(repository.Update(ctx, flakyTest)).To(MatchError("flaky test not found"))
+			Expect(flakyTest.PendingStatusChanges()).To(HaveLen(1))
+		})
+	})
+
+	Describe("FindByFilter", func() {
+		It("should filter by project, owner and status", func() {
+			now := time.Now()
+			rows := sqlmock.NewRows([]string{"id", "project_id", "test_name", "status", "severity", "flake_rate", "last_seen_at", "owners"}).
+				AddRow(3, "project-1", "test-login", "active", "high", 40.0, now, []byte(`["@auth","@qa"]`))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "flaky_tests" WHERE project_id = $1 AND owners @> $2::jsonb AND status IN ($3,$4) AND "flaky_tests"."deleted_at" IS NULL ORDER BY flake_rate DESC, id LIMIT $5`)).
+				WithArgs("project-1", `["@auth"]`, "active", "resolved", 20).
+				WillReturnRows(rows)
+
+			flakyTests, err := repository.FindByFilter(ctx, domain.FlakyTestFilter{
+				ProjectID: "project-1",
+				Owner:     "@auth",
+				Statuses:  []domain.FlakyStatus{domain.FlakyStatusActive, domain.FlakyStatusResolved},
+				Limit:     20,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flakyTests).To(HaveLen(1))
+			Expect(flakyTests[0].Owners()).To(Equal([]string{"@auth", "@qa"}))
+		})
+	})
+
+	Describe("GetStatusHistory", func() {
+		It("should return status changes newest first", func() {
+			now := time.Now()
+			rows := sqlmock.NewRows([]string{"id", "flaky_test_id", "from_status", "to_status", "actor_type", "actor_id", "reason", "changed_at"}).
+				AddRow(2, 3, "resolved", "active", "system", "system", "flaked again", now).
+				AddRow(1, 3, "active", "resolved", "user", "user-1", "fixed", now.Add(-time.Hour))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "flaky_test_status_changes" WHERE flaky_test_id = $1 ORDER BY changed_at DESC, id DESC`)).
+				WithArgs(3).
+				WillReturnRows(rows)
+
+			changes, err := repository.GetStatusHistory(ctx, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].ToStatus).To(Equal(domain.FlakyStatusActive))
+			Expect(changes[0].Actor).To(Equal(domain.SystemActor()))
+			Expect(changes[1].Actor).To(Equal(domain.UserActor("user-1")))
+		})
+	})
+
+	Describe("FindRecentExecutions", func() {
+		It("should scope executions to the project, test and suite", func() {
+			now := time.Now()
+			rows := sqlmock.NewRows([]string{"spec_run_id", "test_run_id", "status", "is_flaky", "error_message", "executed_at"}).
+				AddRow(10, "run-2", "passed", false, "", now).
+				AddRow(9, "run-1", "failed", false, "timeout", now.Add(-time.Hour))
+			mock.ExpectQuery(`SELECT .* FROM "spec_runs" JOIN suite_runs .* JOIN test_runs .* WHERE \(test_runs.project_id = \$1 AND spec_runs.spec_name = \$2\) AND spec_runs.deleted_at IS NULL AND suite_runs.suite_name = \$3 ORDER BY .* LIMIT \$4`).
+				WithArgs("project-1", "test-login", "auth", 5).
+				WillReturnRows(rows)
+
+			executions, err := repository.FindRecentExecutions(ctx, "project-1", "test-login", "auth", 5)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(executions).To(HaveLen(2))
+			Expect(executions[0].Flaked()).To(BeFalse())
+			Expect(executions[1].Flaked()).To(BeTrue())
+			Expect(executions[1].TestRunID).To(Equal("run-1"))
+		})
+	})
+})
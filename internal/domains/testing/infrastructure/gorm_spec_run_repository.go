@@ -36,6 +36,7 @@ func (r *GormSpecRunRepository) Create(ctx context.Context, specRun *domain.Spec
 		StackTrace:   specRun.StackTrace,
 		RetryCount:   specRun.RetryCount,
 		IsFlaky:      specRun.IsFlaky,
+		Owners:       database.StringList(specRun.Owners),
 		Tags:         r.converter.ConvertDomainTagsToDatabase(specRun.Tags),
 	}
 
@@ -66,6 +67,7 @@ func (r *GormSpecRunRepository) CreateBatch(ctx context.Context, specRuns []*dom
 			StackTrace:   specRun.StackTrace,
 			RetryCount:   specRun.RetryCount,
 			IsFlaky:      specRun.IsFlaky,
+			Owners:       database.StringList(specRun.Owners),
 			Tags:         r.converter.ConvertDomainTagsToDatabase(specRun.Tags),
 		}
 	}
@@ -97,6 +99,60 @@ func (r *GormSpecRunRepository) FindBySuiteRunID(ctx context.Context, suiteRunID
 	return specRuns, nil
 }
 
+// FindFailures retrieves failed spec runs with their suite and test run, newest first
+func (r *GormSpecRunRepository) FindFailures(ctx context.Context, filter domain.FailureFilter) ([]*domain.SpecFailure, error) {
+	var rows []struct {
+		database.SpecRun
+		SuiteName string
+		RunID     string
+		ProjectID string
+		Branch    string
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("spec_runs").
+		Select("spec_runs.*, suite_runs.suite_name, test_runs.run_id, test_runs.project_id, test_runs.branch").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_run_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("spec_runs.status = ?", "failed").
+		Where("spec_runs.deleted_at IS NULL")
+	if filter.ProjectID != "" {
+		query = query.Where("test_runs.project_id = ?", filter.ProjectID)
+	}
+	if filter.Owner != "" {
+		query = query.Where("spec_runs.owners @> ?::jsonb", ownerContainment(filter.Owner))
+	}
+	if len(filter.SpecRunIDs) > 0 {
+		query = query.Where("spec_runs.id IN ?", filter.SpecRunIDs)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("spec_runs.created_at >= ?", filter.Since)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	if err := query.Order("spec_runs.created_at DESC, spec_runs.id DESC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find failures: %w", err)
+	}
+
+	failures := make([]*domain.SpecFailure, len(rows))
+	for i := range rows {
+		failures[i] = &domain.SpecFailure{
+			SpecRun:   r.toDomainSpecRun(&rows[i].SpecRun),
+			SuiteName: rows[i].SuiteName,
+			TestRunID: rows[i].RunID,
+			ProjectID: rows[i].ProjectID,
+			Branch:    rows[i].Branch,
+		}
+	}
+
+	return failures, nil
+}
+
 // Update updates an existing spec run
 func (r *GormSpecRunRepository) Update(ctx context.Context, specRun *domain.SpecRun) error {
 	// Not implemented - not used in the application
@@ -157,5 +213,6 @@ func (r *GormSpecRunRepository) toDomainSpecRun(dbSpecRun *database.SpecRun) *do
 		StackTrace:     dbSpecRun.StackTrace,
 		RetryCount:     dbSpecRun.RetryCount,
 		IsFlaky:        dbSpecRun.IsFlaky,
+		Owners:         dbSpecRun.Owners,
 	}
 }
@@ -286,6 +286,47 @@ var _ = Describe("GormSpecRunRepository", func() {
 		})
 	})
 
+	Describe("FindFailures", func() {
+		It("should return failed specs with suite and run context filtered by owner", func() {
+			since := time.Now().Add(-24 * time.Hour)
+			rows := sqlmock.NewRows([]string{"id", "suite_run_id", "spec_name", "status", "error_message", "owners", "suite_name", "run_id", "project_id", "branch"}).
+				AddRow(9, 4, "pays", "failed", "timeout", []byte(`["@payments"]`), "Checkout", "run-1", "project-1", "main")
+			mock.ExpectQuery(`SELECT spec_runs\.\*, suite_runs\.suite_name, test_runs\.run_id, test_runs\.project_id, test_runs\.branch FROM "spec_runs" JOIN suite_runs .* JOIN test_runs .* WHERE spec_runs\.status = \$1 AND spec_runs\.deleted_at IS NULL AND test_runs\.project_id = \$2 AND spec_runs\.owners @> \$3::jsonb AND spec_runs\.created_at >= \$4 ORDER BY .* LIMIT \$5`).
+				WithArgs("failed", "project-1", `["@payments"]`, AnyTime{}, 10).
+				WillReturnRows(rows)
+
+			failures, err := repository.FindFailures(ctx, domain.FailureFilter{
+				ProjectID: "project-1",
+				Owner:     "@payments",
+				Since:     since,
+				Limit:     10,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].SpecRun.Name).To(Equal("pays"))
+			Expect(failures[0].SpecRun.Owners).To(Equal([]string{"@payments"}))
+			Expect(failures[0].SuiteName).To(Equal("Checkout"))
+			Expect(failures[0].TestRunID).To(Equal("run-1"))
+			Expect(failures[0].Branch).To(Equal("main"))
+		})
+
+		It("should restrict results to the given spec run IDs", func() {
+			rows := sqlmock.NewRows([]string{"id", "suite_run_id", "spec_name", "status", "suite_name", "run_id", "project_id", "branch"}).
+				AddRow(9, 4, "pays", "failed", "Checkout", "run-1", "project-1", "main")
+			mock.ExpectQuery(`SELECT .* FROM "spec_runs" JOIN suite_runs .* JOIN test_runs .* WHERE spec_runs\.status = \$1 AND spec_runs\.deleted_at IS NULL AND spec_runs\.id IN \(\$2,\$3\) ORDER BY`).
+				WithArgs("failed", 9, 12).
+				WillReturnRows(rows)
+
+			failures, err := repository.FindFailures(ctx, domain.FailureFilter{SpecRunIDs: []uint{9, 12}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].SpecRun.ID).To(Equal(uint(9)))
+			Expect(failures[0].ProjectID).To(Equal("project-1"))
+		})
+	})
+
 	Describe("Update", func() {
 		It("should return not implemented error", func() {
 			specRun := &domain.SpecRun{ID: 1}
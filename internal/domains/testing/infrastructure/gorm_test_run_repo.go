@@ -33,6 +33,17 @@ func (r *GormTestRunRepository) Create(ctx context.Context, testRun *domain.Test
 	}
 
 	testRun.ID = dbTestRun.ID
+
+	// Propagate generated IDs so callers can refer to the new suites and specs
+	for i := range testRun.SuiteRuns {
+		dbSuite := &dbTestRun.SuiteRuns[i]
+		testRun.SuiteRuns[i].ID = dbSuite.ID
+		testRun.SuiteRuns[i].TestRunID = dbTestRun.ID
+		for j, spec := range testRun.SuiteRuns[i].SpecRuns {
+			spec.ID = dbSuite.SpecRuns[j].ID
+			spec.SuiteRunID = dbSuite.ID
+		}
+	}
 	return nil
 }
 
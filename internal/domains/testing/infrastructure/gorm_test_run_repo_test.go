@@ -73,6 +73,33 @@ var _ = Describe("GormTestRunRepository", func() {
 			Expect(testRun.ID).NotTo(BeZero())
 		})
 
+		It("should propagate generated suite and spec IDs", func() {
+			testRun.SuiteRuns = []domain.SuiteRun{
+				{Name: "suite-a", Status: "failed", StartTime: time.Now(), SpecRuns: []*domain.SpecRun{
+					{Name: "spec-1", Status: "passed", StartTime: time.Now()},
+					{Name: "spec-2", Status: "failed", StartTime: time.Now()},
+				}},
+				{Name: "suite-b", Status: "passed", StartTime: time.Now(), SpecRuns: []*domain.SpecRun{
+					{Name: "spec-3", Status: "passed", StartTime: time.Now()},
+				}},
+			}
+
+			Expect(repo.Create(ctx, testRun)).To(Succeed())
+
+			for _, suite := range testRun.SuiteRuns {
+				Expect(suite.ID).NotTo(BeZero())
+				Expect(suite.TestRunID).To(Equal(testRun.ID))
+				for _, spec := range suite.SpecRuns {
+					Expect(spec.ID).NotTo(BeZero())
+					Expect(spec.SuiteRunID).To(Equal(suite.ID))
+
+					var stored database.SpecRun
+					Expect(db.First(&stored, spec.ID).Error).To(Succeed())
+					Expect(stored.SpecName).To(Equal(spec.Name))
+				}
+			}
+		})
+
 		It("should return error when database fails", func() {
 			// Close the database to simulate error
 			sqlDB, _ := db.DB()
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// ProjectOwnershipProvider resolves owner matchers from project ownership rules
+type ProjectOwnershipProvider struct {
+	projectRepo projectsDomain.ProjectRepository
+}
+
+// NewProjectOwnershipProvider creates a provider backed by the project repository
+func NewProjectOwnershipProvider(projectRepo projectsDomain.ProjectRepository) *ProjectOwnershipProvider {
+	return &ProjectOwnershipProvider{projectRepo: projectRepo}
+}
+
+// MatcherForProject returns a matcher for the project's ownership rules, or nil when none are set
+func (p *ProjectOwnershipProvider) MatcherForProject(ctx context.Context, projectID string) (domain.OwnerMatcher, error) {
+	project, err := p.projectRepo.FindByProjectID(ctx, projectsDomain.ProjectID(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	rules, err := project.OwnershipRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ownership rules: %w", err)
+	}
+	if rules.IsEmpty() {
+		return nil, nil
+	}
+
+	return ownershipRulesMatcher{rules: rules}, nil
+}
+
+// ownershipRulesMatcher adapts project ownership rules to the testing domain
+type ownershipRulesMatcher struct {
+	rules *projectsDomain.OwnershipRules
+}
+
+func (m ownershipRulesMatcher) MatchOwners(target domain.OwnershipTarget) []string {
+	return m.rules.Owners(projectsDomain.OwnershipSubject{
+		SuiteName:   target.SuiteName,
+		SpecName:    target.SpecName,
+		PackageName: target.PackageName,
+		ClassName:   target.ClassName,
+		Tags:        target.Tags,
+	})
+}
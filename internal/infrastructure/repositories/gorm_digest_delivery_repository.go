@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormDigestDeliveryRepository implements DigestDeliveryRepository using GORM
+type GormDigestDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDigestDeliveryRepository creates a new GORM-based digest delivery repository
+func NewGormDigestDeliveryRepository(db *gorm.DB) integrations.DigestDeliveryRepository {
+	return &GormDigestDeliveryRepository{db: db}
+}
+
+// Reserve creates a delivery unless the user already has one for its period
+func (r *GormDigestDeliveryRepository) Reserve(ctx context.Context, delivery *integrations.DigestDelivery) (bool, error) {
+	model := r.toModel(delivery)
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create digest delivery: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	delivery.ID = strconv.FormatUint(uint64(model.ID), 10)
+	return true, nil
+}
+
+// FindByPeriod retrieves the deliveries of one scheduled time
+func (r *GormDigestDeliveryRepository) FindByPeriod(ctx context.Context, periodEnd time.Time) ([]*integrations.DigestDelivery, error) {
+	var models []database.DigestDelivery
+
+	if err := r.db.WithContext(ctx).Where("period_end = ?", periodEnd).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find digest deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindByUserID retrieves the deliveries of a user, newest first
+func (r *GormDigestDeliveryRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*integrations.DigestDelivery, error) {
+	var models []database.DigestDelivery
+
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("period_end DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find digest deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// Update saves the outcome of a delivery attempt
+func (r *GormDigestDeliveryRepository) Update(ctx context.Context, delivery *integrations.DigestDelivery) error {
+	err := r.db.WithContext(ctx).Model(&database.DigestDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"email":           delivery.Email,
+			"status":          string(delivery.Status),
+			"projects":        delivery.Projects,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_attempt_at": delivery.LastAttemptAt,
+			"last_error":      delivery.LastError,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update digest delivery: %w", err)
+	}
+
+	return nil
+}
+
+// Claim moves the next attempt of a due delivery to leaseUntil, unless
+// another instance claimed it first
+func (r *GormDigestDeliveryRepository) Claim(ctx context.Context, delivery *integrations.DigestDelivery, leaseUntil time.Time) (bool, error) {
+	claimed, err := claimLease(ctx, r.db, &database.DigestDelivery{}, delivery.ID, string(integrations.DigestPending), delivery.NextAttemptAt, leaseUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest delivery: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+// DeleteBefore removes deliveries of periods ending before a time
+func (r *GormDigestDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("period_end < ?", before).Delete(&database.DigestDelivery{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete digest deliveries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// toModel converts a domain delivery to a database model
+func (r *GormDigestDeliveryRepository) toModel(delivery *integrations.DigestDelivery) *database.DigestDelivery {
+	return &database.DigestDelivery{
+		UserID:        delivery.UserID,
+		Email:         delivery.Email,
+		PeriodEnd:     delivery.PeriodEnd,
+		Status:        string(delivery.Status),
+		Projects:      delivery.Projects,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastAttemptAt: delivery.LastAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+	}
+}
+
+// toDomain converts a database model to a domain delivery
+func (r *GormDigestDeliveryRepository) toDomain(model *database.DigestDelivery) *integrations.DigestDelivery {
+	return &integrations.DigestDelivery{
+		ID:            strconv.FormatUint(uint64(model.ID), 10),
+		UserID:        model.UserID,
+		Email:         model.Email,
+		PeriodEnd:     model.PeriodEnd,
+		Status:        integrations.DigestDeliveryStatus(model.Status),
+		Projects:      model.Projects,
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastAttemptAt: model.LastAttemptAt,
+		LastError:     model.LastError,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// toDomainList converts database models to domain deliveries
+func (r *GormDigestDeliveryRepository) toDomainList(models []database.DigestDelivery) []*integrations.DigestDelivery {
+	deliveries := make([]*integrations.DigestDelivery, len(models))
+	for i := range models {
+		deliveries[i] = r.toDomain(&models[i])
+	}
+	return deliveries
+}
+
+// GormDigestPreferenceRepository implements DigestPreferenceRepository on
+// the user_preferences table
+type GormDigestPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDigestPreferenceRepository creates a new GORM-based digest preference repository
+func NewGormDigestPreferenceRepository(db *gorm.DB) integrations.DigestPreferenceRepository {
+	return &GormDigestPreferenceRepository{db: db}
+}
+
+// IsOptedOut reports whether a user unsubscribed from digests; users without
+// preferences are subscribed
+func (r *GormDigestPreferenceRepository) IsOptedOut(ctx context.Context, userID string) (bool, error) {
+	var prefs database.UserPreferences
+
+	err := r.db.WithContext(ctx).Select("email_digest_opt_out").Where("user_id = ?", userID).First(&prefs).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+	return prefs.EmailDigestOptOut, nil
+}
+
+// SetOptedOut subscribes or unsubscribes a user, creating their preferences if needed
+func (r *GormDigestPreferenceRepository) SetOptedOut(ctx context.Context, userID string, optedOut bool) error {
+	result := r.db.WithContext(ctx).Model(&database.UserPreferences{}).
+		Where("user_id = ?", userID).
+		Update("email_digest_opt_out", optedOut)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user preferences: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	prefs := &database.UserPreferences{
+		UserID:            userID,
+		Favorites:         []byte("[]"),
+		Preferences:       []byte("{}"),
+		EmailDigestOptOut: optedOut,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"email_digest_opt_out": optedOut}),
+	}).Create(prefs).Error
+	if err != nil {
+		return fmt.Errorf("failed to create user preferences: %w", err)
+	}
+	return nil
+}
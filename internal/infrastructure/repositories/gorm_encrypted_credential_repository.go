@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// encryptedCredentialColumn locates the stored credentials of one kind
+type encryptedCredentialColumn struct {
+	model  interface{}
+	column string
+}
+
+// encryptedCredentialColumns maps each credential kind to where it is stored
+var encryptedCredentialColumns = map[string]encryptedCredentialColumn{
+	integrations.CredentialKindJiraCredential:        {model: &database.JiraConnection{}, column: "encrypted_credential"},
+	integrations.CredentialKindJiraOAuthRefreshToken: {model: &database.JiraConnection{}, column: "encrypted_oauth_refresh_token"},
+	integrations.CredentialKindTrackerCredential:     {model: &database.TrackerConnection{}, column: "encrypted_credential"},
+	integrations.CredentialKindWebhookSecret:         {model: &database.WebhookSubscription{}, column: "encrypted_secret"},
+	integrations.CredentialKindNotificationWebhook:   {model: &database.NotificationRule{}, column: "encrypted_webhook_url"},
+	integrations.CredentialKindVCSToken:              {model: &database.VCSConnection{}, column: "encrypted_token"},
+}
+
+// credentialKinds lists the credential kinds in the order they are re-encrypted
+var credentialKinds = []string{
+	integrations.CredentialKindJiraCredential,
+	integrations.CredentialKindJiraOAuthRefreshToken,
+	integrations.CredentialKindTrackerCredential,
+	integrations.CredentialKindWebhookSecret,
+	integrations.CredentialKindNotificationWebhook,
+	integrations.CredentialKindVCSToken,
+}
+
+// GormEncryptedCredentialRepository implements EncryptedCredentialRepository using GORM
+type GormEncryptedCredentialRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEncryptedCredentialRepository creates a new GORM-based encrypted credential repository
+func NewGormEncryptedCredentialRepository(db *gorm.DB) integrations.EncryptedCredentialRepository {
+	return &GormEncryptedCredentialRepository{db: db}
+}
+
+// FindAll retrieves every non-empty encrypted credential. Deleted connections
+// are included, since restoring one must not bring back a retired key.
+func (r *GormEncryptedCredentialRepository) FindAll(ctx context.Context) ([]integrations.EncryptedCredential, error) {
+	var credentials []integrations.EncryptedCredential
+	for _, kind := range credentialKinds {
+		location := encryptedCredentialColumns[kind]
+
+		var rows []struct {
+			ID         uint
+			Ciphertext string
+		}
+		err := r.db.WithContext(ctx).Unscoped().Model(location.model).
+			Select("id, " + location.column + " AS ciphertext").
+			Where(location.column + " <> ''").
+			Order("id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s credentials: %w", kind, err)
+		}
+
+		for _, row := range rows {
+			credentials = append(credentials, integrations.EncryptedCredential{
+				Kind:       kind,
+				OwnerID:    strconv.FormatUint(uint64(row.ID), 10),
+				Ciphertext: row.Ciphertext,
+			})
+		}
+	}
+	return credentials, nil
+}
+
+// Replace swaps the ciphertext of a credential unless it changed since it was retrieved
+func (r *GormEncryptedCredentialRepository) Replace(ctx context.Context, credential integrations.EncryptedCredential, ciphertext string) (bool, error) {
+	location, ok := encryptedCredentialColumns[credential.Kind]
+	if !ok {
+		return false, fmt.Errorf("unknown credential kind %q", credential.Kind)
+	}
+
+	// UpdateColumn leaves updated_at alone; the connection itself did not change
+	result := r.db.WithContext(ctx).Unscoped().Model(location.model).
+		Where("id = ? AND "+location.column+" = ?", credential.OwnerID, credential.Ciphertext).
+		UpdateColumn(location.column, ciphertext)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to replace %s credential: %w", credential.Kind, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -96,9 +96,26 @@ func (r *GormJiraConnectionRepository) FindActiveByProjectID(ctx context.Context
 	return connections, nil
 }
 
+// FindAllActive retrieves the active connections of every project
+func (r *GormJiraConnectionRepository) FindAllActive(ctx context.Context) ([]*integrations.JiraConnection, error) {
+	var models []database.JiraConnection
+	
+	if err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active JIRA connections: %w", err)
+	}
+	
+	connections := make([]*integrations.JiraConnection, len(models))
+	for i, model := range models {
+		connections[i] = r.toDomain(&model)
+	}
+	
+	return connections, nil
+}
+
 // toModel converts a domain entity to a database model
 func (r *GormJiraConnectionRepository) toModel(conn *integrations.JiraConnection) *database.JiraConnection {
 	snapshot := conn.Snapshot()
+	oauth := conn.OAuthState()
 	model := &database.JiraConnection{
 		ProjectID:           snapshot.ProjectID,
 		Name:                snapshot.Name,
@@ -108,8 +125,13 @@ func (r *GormJiraConnectionRepository) toModel(conn *integrations.JiraConnection
 		Username:            snapshot.Username,
 		EncryptedCredential: conn.GetEncryptedCredentialDirect(), // This needs to be added to domain
 		Status:              string(snapshot.Status),
+		StatusReason:        snapshot.StatusReason,
 		IsActive:            snapshot.IsActive,
 		LastTestedAt:        snapshot.LastTestedAt,
+
+		OAuthCloudID:               oauth.CloudID,
+		EncryptedOAuthRefreshToken: oauth.EncryptedRefreshToken,
+		OAuthTokenExpiresAt:        oauth.TokenExpiresAt,
 	}
 	
 	// CRITICAL: Set the ID to ensure updates work correctly
@@ -140,7 +162,13 @@ func (r *GormJiraConnectionRepository) toDomain(model *database.JiraConnection)
 		model.Username,
 		model.EncryptedCredential,
 		integrations.ConnectionStatus(model.Status),
+		model.StatusReason,
 		model.IsActive,
+		integrations.JiraOAuthState{
+			CloudID:               model.OAuthCloudID,
+			EncryptedRefreshToken: model.EncryptedOAuthRefreshToken,
+			TokenExpiresAt:        model.OAuthTokenExpiresAt,
+		},
 		model.LastTestedAt,
 		model.CreatedAt,
 		model.UpdatedAt,
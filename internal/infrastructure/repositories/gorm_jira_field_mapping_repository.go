@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormJiraFieldMappingRepository implements JiraFieldMappingRepository using GORM
+type GormJiraFieldMappingRepository struct {
+	db *gorm.DB
+}
+
+// NewGormJiraFieldMappingRepository creates a new GORM-based JIRA field mapping repository
+func NewGormJiraFieldMappingRepository(db *gorm.DB) integrations.JiraFieldMappingRepository {
+	return &GormJiraFieldMappingRepository{db: db}
+}
+
+// Save creates or replaces the mapping of a connection
+func (r *GormJiraFieldMappingRepository) Save(ctx context.Context, mapping *integrations.JiraFieldMapping) error {
+	fields := make(database.JSONMap, len(mapping.Fields))
+	for fernField, jiraFieldID := range mapping.Fields {
+		fields[string(fernField)] = jiraFieldID
+	}
+
+	var model database.JiraFieldMapping
+	err := r.db.WithContext(ctx).
+		Where("connection_id = ?", mapping.ConnectionID).
+		Assign(database.JiraFieldMapping{
+			ProjectID:     mapping.ProjectID,
+			IssueType:     mapping.IssueType,
+			FieldMappings: fields,
+			UpdatedBy:     mapping.UpdatedBy,
+		}).
+		FirstOrCreate(&model, database.JiraFieldMapping{ConnectionID: mapping.ConnectionID}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save JIRA field mapping: %w", err)
+	}
+
+	mapping.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindByConnectionID retrieves the mapping of a connection, or nil when none was saved
+func (r *GormJiraFieldMappingRepository) FindByConnectionID(ctx context.Context, connectionID string) (*integrations.JiraFieldMapping, error) {
+	var model database.JiraFieldMapping
+
+	if err := r.db.WithContext(ctx).First(&model, "connection_id = ?", connectionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find JIRA field mapping: %w", err)
+	}
+
+	fields := make(map[integrations.FernField]string, len(model.FieldMappings))
+	for fernField, jiraFieldID := range model.FieldMappings {
+		if id, ok := jiraFieldID.(string); ok {
+			fields[integrations.FernField(fernField)] = id
+		}
+	}
+
+	return &integrations.JiraFieldMapping{
+		ConnectionID: model.ConnectionID,
+		ProjectID:    model.ProjectID,
+		IssueType:    model.IssueType,
+		Fields:       fields,
+		UpdatedBy:    model.UpdatedBy,
+		UpdatedAt:    model.UpdatedAt,
+	}, nil
+}
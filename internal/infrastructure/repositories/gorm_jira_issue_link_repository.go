@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormJiraIssueLinkRepository implements JiraIssueLinkRepository using GORM
+type GormJiraIssueLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewGormJiraIssueLinkRepository creates a new GORM-based JIRA issue link repository
+func NewGormJiraIssueLinkRepository(db *gorm.DB) integrations.JiraIssueLinkRepository {
+	return &GormJiraIssueLinkRepository{db: db}
+}
+
+// Create saves issue links
+func (r *GormJiraIssueLinkRepository) Create(ctx context.Context, links []*integrations.JiraIssueLink) error {
+	models := make([]*database.JiraIssueLink, len(links))
+	for i, link := range links {
+		models[i] = r.toModel(link)
+	}
+
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create JIRA issue links: %w", err)
+	}
+
+	for i, model := range models {
+		links[i].ID = model.ID
+		links[i].CreatedAt = model.CreatedAt
+	}
+	return nil
+}
+
+// FindByTargets retrieves the links of any of the given targets in a project
+func (r *GormJiraIssueLinkRepository) FindByTargets(ctx context.Context, projectID string, targets []integrations.IssueTarget) ([]*integrations.JiraIssueLink, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	conditions := r.db.Where("target_type = ? AND target_id = ?", string(targets[0].Type), targets[0].ID)
+	for _, target := range targets[1:] {
+		conditions = conditions.Or("target_type = ? AND target_id = ?", string(target.Type), target.ID)
+	}
+
+	var models []database.JiraIssueLink
+	if err := query.Where(conditions).Order("created_at ASC, id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find JIRA issue links: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindByProjectID retrieves all issue links for a project, newest first
+func (r *GormJiraIssueLinkRepository) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.JiraIssueLink, error) {
+	var models []database.JiraIssueLink
+
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find JIRA issue links: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindByConnectionID retrieves all issue links filed through a connection
+func (r *GormJiraIssueLinkRepository) FindByConnectionID(ctx context.Context, connectionID string) ([]*integrations.JiraIssueLink, error) {
+	var models []database.JiraIssueLink
+
+	if err := r.db.WithContext(ctx).Where("connection_id = ?", connectionID).Order("id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find JIRA issue links: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// UpdateSync saves the sync state of an issue link
+func (r *GormJiraIssueLinkRepository) UpdateSync(ctx context.Context, link *integrations.JiraIssueLink) error {
+	err := r.db.WithContext(ctx).Model(&database.JiraIssueLink{}).Where("id = ?", link.ID).Updates(map[string]interface{}{
+		"issue_status":          link.IssueStatus,
+		"issue_status_category": link.IssueStatusCategory,
+		"issue_resolved_at":     link.IssueResolvedAt,
+		"synced_at":             link.SyncedAt,
+		"reflake_notified_at":   link.ReflakeNotifiedAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update JIRA issue link sync state: %w", err)
+	}
+	return nil
+}
+
+// toModel converts a domain issue link to a database model
+func (r *GormJiraIssueLinkRepository) toModel(link *integrations.JiraIssueLink) *database.JiraIssueLink {
+	return &database.JiraIssueLink{
+		ProjectID:    link.ProjectID,
+		ConnectionID: link.ConnectionID,
+		IssueKey:     link.IssueKey,
+		IssueURL:     link.IssueURL,
+		SourceType:   string(link.SourceType),
+		TargetType:   string(link.Target.Type),
+		TargetID:     link.Target.ID,
+		TestName:     link.Target.TestName,
+		SuiteName:    link.Target.SuiteName,
+		CreatedBy:    link.CreatedBy,
+	}
+}
+
+// toDomainList converts database models to domain issue links
+func (r *GormJiraIssueLinkRepository) toDomainList(models []database.JiraIssueLink) []*integrations.JiraIssueLink {
+	links := make([]*integrations.JiraIssueLink, len(models))
+	for i, model := range models {
+		links[i] = &integrations.JiraIssueLink{
+			ID:           model.ID,
+			ProjectID:    model.ProjectID,
+			ConnectionID: model.ConnectionID,
+			IssueKey:     model.IssueKey,
+			IssueURL:     model.IssueURL,
+			SourceType:   integrations.IssueSourceType(model.SourceType),
+			Target: integrations.IssueTarget{
+				Type:      integrations.IssueTargetType(model.TargetType),
+				ID:        model.TargetID,
+				TestName:  model.TestName,
+				SuiteName: model.SuiteName,
+			},
+			CreatedBy:           model.CreatedBy,
+			CreatedAt:           model.CreatedAt,
+			IssueStatus:         model.IssueStatus,
+			IssueStatusCategory: model.IssueStatusCategory,
+			IssueResolvedAt:     model.IssueResolvedAt,
+			SyncedAt:            model.SyncedAt,
+			ReflakeNotifiedAt:   model.ReflakeNotifiedAt,
+		}
+	}
+	return links
+}
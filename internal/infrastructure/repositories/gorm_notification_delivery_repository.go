@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormNotificationDeliveryRepository implements NotificationDeliveryRepository using GORM
+type GormNotificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationDeliveryRepository creates a new GORM-based notification delivery repository
+func NewGormNotificationDeliveryRepository(db *gorm.DB) integrations.NotificationDeliveryRepository {
+	return &GormNotificationDeliveryRepository{db: db}
+}
+
+// Create queues or logs a notification and assigns its ID
+func (r *GormNotificationDeliveryRepository) Create(ctx context.Context, delivery *integrations.NotificationDelivery) error {
+	model := r.toModel(delivery)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+
+	delivery.ID = strconv.FormatUint(uint64(model.ID), 10)
+	return nil
+}
+
+// Update saves the outcome of a delivery attempt
+func (r *GormNotificationDeliveryRepository) Update(ctx context.Context, delivery *integrations.NotificationDelivery) error {
+	err := r.db.WithContext(ctx).Model(&database.NotificationDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          string(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_attempt_at": delivery.LastAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindByRuleID retrieves the notifications of a rule, newest first
+func (r *GormNotificationDeliveryRepository) FindByRuleID(ctx context.Context, ruleID string, limit int) ([]*integrations.NotificationDelivery, error) {
+	var models []database.NotificationDelivery
+
+	query := r.db.WithContext(ctx).Where("rule_id = ?", ruleID).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find notification deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindLatestByDedupKey retrieves the newest notification of a rule with a
+// deduplication key created since a time, ignoring suppressed ones. It
+// returns nil when there is none.
+func (r *GormNotificationDeliveryRepository) FindLatestByDedupKey(ctx context.Context, ruleID, dedupKey string, since time.Time) (*integrations.NotificationDelivery, error) {
+	var models []database.NotificationDelivery
+
+	err := r.db.WithContext(ctx).
+		Where("rule_id = ? AND dedup_key = ? AND created_at >= ?", ruleID, dedupKey, since).
+		Where("status <> ?", string(integrations.NotificationSuppressed)).
+		Order("created_at DESC, id DESC").
+		Limit(1).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification delivery: %w", err)
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	return r.toDomain(&models[0]), nil
+}
+
+// FindDue retrieves pending notifications of active rules whose next attempt is due, oldest first
+func (r *GormNotificationDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.NotificationDelivery, error) {
+	var models []database.NotificationDelivery
+
+	err := r.db.WithContext(ctx).
+		Joins("JOIN notification_rules ON notification_rules.id = notification_deliveries.rule_id").
+		Where("notification_rules.is_active = ? AND notification_rules.deleted_at IS NULL", true).
+		Where("notification_deliveries.status = ? AND notification_deliveries.next_attempt_at <= ?", string(integrations.NotificationPending), now).
+		Order("notification_deliveries.next_attempt_at, notification_deliveries.id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due notification deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// Claim moves the next attempt of a due notification to leaseUntil, unless
+// another worker claimed it first
+func (r *GormNotificationDeliveryRepository) Claim(ctx context.Context, delivery *integrations.NotificationDelivery, leaseUntil time.Time) (bool, error) {
+	claimed, err := claimLease(ctx, r.db, &database.NotificationDelivery{}, delivery.ID, string(integrations.NotificationPending), delivery.NextAttemptAt, leaseUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification delivery: %w", err)
+	}
+	return claimed, nil
+}
+
+// DeleteFinishedBefore removes sent, failed and suppressed notifications created before a time
+func (r *GormNotificationDeliveryRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := deleteFinishedBefore(ctx, r.db, &database.NotificationDelivery{}, []string{
+		string(integrations.NotificationSent),
+		string(integrations.NotificationFailed),
+		string(integrations.NotificationSuppressed),
+	}, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete notification deliveries: %w", err)
+	}
+	return deleted, nil
+}
+
+// toModel converts a domain notification to a database model
+func (r *GormNotificationDeliveryRepository) toModel(delivery *integrations.NotificationDelivery) *database.NotificationDelivery {
+	model := &database.NotificationDelivery{
+		ProjectID:      delivery.ProjectID,
+		EventType:      string(delivery.EventType),
+		DedupKey:       delivery.DedupKey,
+		Title:          delivery.Title,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		HeldUntil:      delivery.HeldUntil,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if id, err := strconv.ParseUint(delivery.RuleID, 10, 64); err == nil {
+		model.RuleID = uint(id)
+	}
+	if id, err := strconv.ParseUint(delivery.SuppressedBy, 10, 64); err == nil {
+		suppressedBy := uint(id)
+		model.SuppressedBy = &suppressedBy
+	}
+
+	return model
+}
+
+// toDomain converts a database model to a domain notification
+func (r *GormNotificationDeliveryRepository) toDomain(model *database.NotificationDelivery) *integrations.NotificationDelivery {
+	delivery := &integrations.NotificationDelivery{
+		ID:             strconv.FormatUint(uint64(model.ID), 10),
+		RuleID:         strconv.FormatUint(uint64(model.RuleID), 10),
+		ProjectID:      model.ProjectID,
+		EventType:      integrations.WebhookEventType(model.EventType),
+		DedupKey:       model.DedupKey,
+		Title:          model.Title,
+		Payload:        model.Payload,
+		Status:         integrations.NotificationDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		LastAttemptAt:  model.LastAttemptAt,
+		ResponseStatus: model.ResponseStatus,
+		LastError:      model.LastError,
+		HeldUntil:      model.HeldUntil,
+		CreatedAt:      model.CreatedAt,
+	}
+	if model.SuppressedBy != nil {
+		delivery.SuppressedBy = strconv.FormatUint(uint64(*model.SuppressedBy), 10)
+	}
+
+	return delivery
+}
+
+// toDomainList converts database models to domain notifications
+func (r *GormNotificationDeliveryRepository) toDomainList(models []database.NotificationDelivery) []*integrations.NotificationDelivery {
+	deliveries := make([]*integrations.NotificationDelivery, len(models))
+	for i := range models {
+		deliveries[i] = r.toDomain(&models[i])
+	}
+	return deliveries
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormNotificationRuleRepository implements NotificationRuleRepository using GORM
+type GormNotificationRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRuleRepository creates a new GORM-based notification rule repository
+func NewGormNotificationRuleRepository(db *gorm.DB) integrations.NotificationRuleRepository {
+	return &GormNotificationRuleRepository{db: db}
+}
+
+// Create saves a new rule and assigns its ID
+func (r *GormNotificationRuleRepository) Create(ctx context.Context, rule *integrations.NotificationRule) error {
+	model := r.toModel(rule)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create notification rule: %w", err)
+	}
+
+	rule.AssignID(strconv.FormatUint(uint64(model.ID), 10))
+	return nil
+}
+
+// Update updates an existing rule
+func (r *GormNotificationRuleRepository) Update(ctx context.Context, rule *integrations.NotificationRule) error {
+	model := r.toModel(rule)
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update notification rule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a rule
+func (r *GormNotificationRuleRepository) Delete(ctx context.Context, ruleID string) error {
+	if err := r.db.WithContext(ctx).Delete(&database.NotificationRule{}, "id = ?", ruleID).Error; err != nil {
+		return fmt.Errorf("failed to delete notification rule: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a rule by ID
+func (r *GormNotificationRuleRepository) FindByID(ctx context.Context, ruleID string) (*integrations.NotificationRule, error) {
+	var model database.NotificationRule
+
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", ruleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("notification rule not found")
+		}
+		return nil, fmt.Errorf("failed to find notification rule: %w", err)
+	}
+
+	return r.toDomain(&model), nil
+}
+
+// FindByProjectID retrieves the rules of a project, newest first
+func (r *GormNotificationRuleRepository) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.NotificationRule, error) {
+	var models []database.NotificationRule
+
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find notification rules: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindActiveForEvent retrieves the active rules of a project that post an event type
+func (r *GormNotificationRuleRepository) FindActiveForEvent(ctx context.Context, projectID string, eventType integrations.WebhookEventType) ([]*integrations.NotificationRule, error) {
+	var models []database.NotificationRule
+
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND project_id = ?", true, projectID).
+		Where("events LIKE ?", "%"+string(eventType)+"%").
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification rules: %w", err)
+	}
+
+	// LIKE narrows the candidates; the exact match is on the parsed event list
+	var rules []*integrations.NotificationRule
+	for _, rule := range r.toDomainList(models) {
+		for _, event := range rule.Settings().Events {
+			if event == eventType {
+				rules = append(rules, rule)
+				break
+			}
+		}
+	}
+	return rules, nil
+}
+
+// toModel converts a domain entity to a database model
+func (r *GormNotificationRuleRepository) toModel(rule *integrations.NotificationRule) *database.NotificationRule {
+	snapshot := rule.Snapshot()
+	settings := snapshot.Settings
+
+	events := make([]string, len(settings.Events))
+	for i, event := range settings.Events {
+		events[i] = string(event)
+	}
+
+	model := &database.NotificationRule{
+		ProjectID:           snapshot.ProjectID,
+		Name:                settings.Name,
+		ChannelType:         string(settings.ChannelType),
+		EncryptedWebhookURL: rule.EncryptedWebhookURL(),
+		Events:              strings.Join(events, ","),
+		Branches:            database.StringList(settings.Filter.Branches),
+		Environments:        database.StringList(settings.Filter.Environments),
+		Statuses:            database.StringList(settings.Filter.Statuses),
+		Tags:                database.StringList(settings.Filter.Tags),
+		Severities:          database.StringList(settings.Filter.Severities),
+		DedupWindowSeconds:  int64(settings.DedupWindow / time.Second),
+		TitleTemplate:       settings.TitleTemplate,
+		TextTemplate:        settings.TextTemplate,
+		IsActive:            snapshot.IsActive,
+		CreatedBy:           snapshot.CreatedBy,
+	}
+	if settings.QuietHours != nil {
+		model.QuietHoursStart = settings.QuietHours.Start
+		model.QuietHoursEnd = settings.QuietHours.End
+		model.QuietHoursTimeZone = settings.QuietHours.TimeZone
+	}
+	if id, err := strconv.ParseUint(snapshot.ID, 10, 64); err == nil {
+		model.ID = uint(id)
+	}
+	model.CreatedAt = snapshot.CreatedAt
+	model.UpdatedAt = snapshot.UpdatedAt
+
+	return model
+}
+
+// toDomain converts a database model to a domain entity
+func (r *GormNotificationRuleRepository) toDomain(model *database.NotificationRule) *integrations.NotificationRule {
+	var events []integrations.WebhookEventType
+	for _, event := range strings.Split(model.Events, ",") {
+		if event != "" {
+			events = append(events, integrations.WebhookEventType(event))
+		}
+	}
+
+	settings := integrations.NotificationRuleSettings{
+		Name:        model.Name,
+		ChannelType: integrations.NotificationChannelType(model.ChannelType),
+		Events:      events,
+		Filter: integrations.NotificationFilter{
+			Branches:     []string(model.Branches),
+			Environments: []string(model.Environments),
+			Statuses:     []string(model.Statuses),
+			Tags:         []string(model.Tags),
+			Severities:   []string(model.Severities),
+		},
+		DedupWindow:   time.Duration(model.DedupWindowSeconds) * time.Second,
+		TitleTemplate: model.TitleTemplate,
+		TextTemplate:  model.TextTemplate,
+	}
+	if model.QuietHoursStart != "" && model.QuietHoursEnd != "" {
+		settings.QuietHours = &integrations.QuietHours{
+			Start:    model.QuietHoursStart,
+			End:      model.QuietHoursEnd,
+			TimeZone: model.QuietHoursTimeZone,
+		}
+	}
+
+	return integrations.ReconstructNotificationRule(integrations.NotificationRuleSnapshot{
+		ID:        strconv.FormatUint(uint64(model.ID), 10),
+		ProjectID: model.ProjectID,
+		Settings:  settings,
+		IsActive:  model.IsActive,
+		CreatedBy: model.CreatedBy,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}, model.EncryptedWebhookURL)
+}
+
+// toDomainList converts database models to domain entities
+func (r *GormNotificationRuleRepository) toDomainList(models []database.NotificationRule) []*integrations.NotificationRule {
+	rules := make([]*integrations.NotificationRule, len(models))
+	for i := range models {
+		rules[i] = r.toDomain(&models[i])
+	}
+	return rules
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormTrackerConnectionRepository implements TrackerConnectionRepository using GORM
+type GormTrackerConnectionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTrackerConnectionRepository creates a new GORM-based issue tracker connection repository
+func NewGormTrackerConnectionRepository(db *gorm.DB) integrations.TrackerConnectionRepository {
+	return &GormTrackerConnectionRepository{db: db}
+}
+
+// Create saves a new connection and assigns its ID
+func (r *GormTrackerConnectionRepository) Create(ctx context.Context, connection *integrations.TrackerConnection) error {
+	model := r.toModel(connection)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create tracker connection: %w", err)
+	}
+
+	connection.AssignID(strconv.FormatUint(uint64(model.ID), 10))
+	return nil
+}
+
+// Update updates an existing connection
+func (r *GormTrackerConnectionRepository) Update(ctx context.Context, connection *integrations.TrackerConnection) error {
+	model := r.toModel(connection)
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update tracker connection: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a connection
+func (r *GormTrackerConnectionRepository) Delete(ctx context.Context, connectionID string) error {
+	if err := r.db.WithContext(ctx).Delete(&database.TrackerConnection{}, "id = ?", connectionID).Error; err != nil {
+		return fmt.Errorf("failed to delete tracker connection: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a connection by ID
+func (r *GormTrackerConnectionRepository) FindByID(ctx context.Context, connectionID string) (*integrations.TrackerConnection, error) {
+	var model database.TrackerConnection
+
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", connectionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("tracker connection not found")
+		}
+		return nil, fmt.Errorf("failed to find tracker connection: %w", err)
+	}
+
+	return r.toDomain(&model), nil
+}
+
+// FindByProjectID retrieves all connections for a project, newest first
+func (r *GormTrackerConnectionRepository) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.TrackerConnection, error) {
+	var models []database.TrackerConnection
+
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find tracker connections: %w", err)
+	}
+
+	connections := make([]*integrations.TrackerConnection, len(models))
+	for i := range models {
+		connections[i] = r.toDomain(&models[i])
+	}
+
+	return connections, nil
+}
+
+// toModel converts a domain entity to a database model
+func (r *GormTrackerConnectionRepository) toModel(conn *integrations.TrackerConnection) *database.TrackerConnection {
+	snapshot := conn.Snapshot()
+
+	config := make(database.JSONMap, len(snapshot.Config))
+	for key, value := range snapshot.Config {
+		config[key] = value
+	}
+
+	model := &database.TrackerConnection{
+		ProjectID:           snapshot.ProjectID,
+		Name:                snapshot.Name,
+		ConnectorType:       string(snapshot.ConnectorType),
+		Config:              config,
+		EncryptedCredential: conn.EncryptedCredential(),
+		Status:              string(snapshot.Status),
+		LastError:           snapshot.LastError,
+		IsActive:            snapshot.IsActive,
+		LastTestedAt:        snapshot.LastTestedAt,
+	}
+	if id, err := strconv.ParseUint(snapshot.ID, 10, 64); err == nil {
+		model.ID = uint(id)
+	}
+	model.CreatedAt = snapshot.CreatedAt
+	model.UpdatedAt = snapshot.UpdatedAt
+
+	return model
+}
+
+// toDomain converts a database model to a domain entity
+func (r *GormTrackerConnectionRepository) toDomain(model *database.TrackerConnection) *integrations.TrackerConnection {
+	config := make(integrations.ConnectorConfig, len(model.Config))
+	for key, value := range model.Config {
+		if s, ok := value.(string); ok {
+			config[key] = s
+		}
+	}
+
+	return integrations.ReconstructTrackerConnection(
+		strconv.FormatUint(uint64(model.ID), 10),
+		model.ProjectID,
+		model.Name,
+		integrations.ConnectorType(model.ConnectorType),
+		config,
+		model.EncryptedCredential,
+		integrations.ConnectionStatus(model.Status),
+		model.LastError,
+		model.IsActive,
+		model.LastTestedAt,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+}
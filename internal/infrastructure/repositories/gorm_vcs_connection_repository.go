@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormVCSConnectionRepository implements VCSConnectionRepository using GORM
+type GormVCSConnectionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVCSConnectionRepository creates a new GORM-based VCS connection repository
+func NewGormVCSConnectionRepository(db *gorm.DB) integrations.VCSConnectionRepository {
+	return &GormVCSConnectionRepository{db: db}
+}
+
+// Create saves a new connection and assigns its ID
+func (r *GormVCSConnectionRepository) Create(ctx context.Context, connection *integrations.VCSConnection) error {
+	model := r.toModel(connection)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create VCS connection: %w", err)
+	}
+
+	connection.AssignID(strconv.FormatUint(uint64(model.ID), 10))
+	return nil
+}
+
+// Update updates an existing connection
+func (r *GormVCSConnectionRepository) Update(ctx context.Context, connection *integrations.VCSConnection) error {
+	model := r.toModel(connection)
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update VCS connection: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a connection
+func (r *GormVCSConnectionRepository) Delete(ctx context.Context, connectionID string) error {
+	if err := r.db.WithContext(ctx).Delete(&database.VCSConnection{}, "id = ?", connectionID).Error; err != nil {
+		return fmt.Errorf("failed to delete VCS connection: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a connection by ID
+func (r *GormVCSConnectionRepository) FindByID(ctx context.Context, connectionID string) (*integrations.VCSConnection, error) {
+	var model database.VCSConnection
+
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", connectionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("VCS connection not found")
+		}
+		return nil, fmt.Errorf("failed to find VCS connection: %w", err)
+	}
+
+	return r.toDomain(&model), nil
+}
+
+// FindByProjectID retrieves the connections of a project, newest first
+func (r *GormVCSConnectionRepository) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.VCSConnection, error) {
+	var models []database.VCSConnection
+
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find VCS connections: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindActiveByProjectID retrieves the active connections of a project
+func (r *GormVCSConnectionRepository) FindActiveByProjectID(ctx context.Context, projectID string) ([]*integrations.VCSConnection, error) {
+	var models []database.VCSConnection
+
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND is_active = ?", projectID, true).Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active VCS connections: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// toModel converts a domain entity to a database model
+func (r *GormVCSConnectionRepository) toModel(connection *integrations.VCSConnection) *database.VCSConnection {
+	snapshot := connection.Snapshot()
+	settings := snapshot.Settings
+
+	model := &database.VCSConnection{
+		ProjectID:             snapshot.ProjectID,
+		Name:                  settings.Name,
+		Provider:              string(settings.Provider),
+		APIURL:                settings.APIURL,
+		Repository:            settings.Repository,
+		StatusContext:         settings.StatusContext,
+		StatusMode:            string(settings.StatusMode),
+		CommentOnPullRequests: settings.CommentOnPullRequests,
+		EncryptedToken:        connection.EncryptedToken(),
+		Status:                string(snapshot.Status),
+		LastError:             snapshot.LastError,
+		IsActive:              snapshot.IsActive,
+		LastTestedAt:          snapshot.LastTestedAt,
+		CreatedBy:             snapshot.CreatedBy,
+	}
+	if id, err := strconv.ParseUint(snapshot.ID, 10, 64); err == nil {
+		model.ID = uint(id)
+	}
+	model.CreatedAt = snapshot.CreatedAt
+	model.UpdatedAt = snapshot.UpdatedAt
+
+	return model
+}
+
+// toDomain converts a database model to a domain entity
+func (r *GormVCSConnectionRepository) toDomain(model *database.VCSConnection) *integrations.VCSConnection {
+	return integrations.ReconstructVCSConnection(integrations.VCSConnectionSnapshot{
+		ID:        strconv.FormatUint(uint64(model.ID), 10),
+		ProjectID: model.ProjectID,
+		Settings: integrations.VCSConnectionSettings{
+			Name:                  model.Name,
+			Provider:              integrations.VCSProviderType(model.Provider),
+			APIURL:                model.APIURL,
+			Repository:            model.Repository,
+			StatusContext:         model.StatusContext,
+			StatusMode:            integrations.VCSStatusMode(model.StatusMode),
+			CommentOnPullRequests: model.CommentOnPullRequests,
+		},
+		Status:       integrations.ConnectionStatus(model.Status),
+		LastError:    model.LastError,
+		IsActive:     model.IsActive,
+		LastTestedAt: model.LastTestedAt,
+		CreatedBy:    model.CreatedBy,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}, model.EncryptedToken)
+}
+
+// toDomainList converts database models to domain entities
+func (r *GormVCSConnectionRepository) toDomainList(models []database.VCSConnection) []*integrations.VCSConnection {
+	connections := make([]*integrations.VCSConnection, len(models))
+	for i := range models {
+		connections[i] = r.toDomain(&models[i])
+	}
+	return connections
+}
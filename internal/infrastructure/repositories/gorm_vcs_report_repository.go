@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormVCSReportRepository implements VCSReportRepository using GORM
+type GormVCSReportRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVCSReportRepository creates a new GORM-based VCS report repository
+func NewGormVCSReportRepository(db *gorm.DB) integrations.VCSReportRepository {
+	return &GormVCSReportRepository{db: db}
+}
+
+// Create queues a report and assigns its ID
+func (r *GormVCSReportRepository) Create(ctx context.Context, report *integrations.VCSReport) error {
+	model := r.toModel(report)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create VCS report: %w", err)
+	}
+
+	report.ID = strconv.FormatUint(uint64(model.ID), 10)
+	return nil
+}
+
+// Update saves the outcome of a report attempt
+func (r *GormVCSReportRepository) Update(ctx context.Context, report *integrations.VCSReport) error {
+	err := r.db.WithContext(ctx).Model(&database.VCSReport{}).
+		Where("id = ?", report.ID).
+		Updates(map[string]interface{}{
+			"status":          string(report.Status),
+			"attempts":        report.Attempts,
+			"next_attempt_at": report.NextAttemptAt,
+			"last_attempt_at": report.LastAttemptAt,
+			"last_error":      report.LastError,
+			"state":           string(report.State),
+			"comment_urls":    database.StringList(report.CommentURLs),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update VCS report: %w", err)
+	}
+
+	return nil
+}
+
+// FindByConnectionID retrieves the reports of a connection, newest first
+func (r *GormVCSReportRepository) FindByConnectionID(ctx context.Context, connectionID string, limit int) ([]*integrations.VCSReport, error) {
+	var models []database.VCSReport
+
+	query := r.db.WithContext(ctx).Where("connection_id = ?", connectionID).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find VCS reports: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// HasPending reports whether a report of a test run queued at the same stage
+// of the run is still pending for a connection
+func (r *GormVCSReportRepository) HasPending(ctx context.Context, connectionID string, testRunID uint, final bool) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&database.VCSReport{}).
+		Where("connection_id = ? AND test_run_id = ? AND final = ? AND status = ?", connectionID, testRunID, final, string(integrations.VCSReportPending)).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to count pending VCS reports: %w", err)
+	}
+	return count > 0, nil
+}
+
+// FindDue retrieves pending reports of active connections whose next attempt is due, oldest first
+func (r *GormVCSReportRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.VCSReport, error) {
+	var models []database.VCSReport
+
+	err := r.db.WithContext(ctx).
+		Joins("JOIN vcs_connections ON vcs_connections.id = vcs_reports.connection_id").
+		Where("vcs_connections.is_active = ? AND vcs_connections.deleted_at IS NULL", true).
+		Where("vcs_reports.status = ? AND vcs_reports.next_attempt_at <= ?", string(integrations.VCSReportPending), now).
+		Order("vcs_reports.next_attempt_at, vcs_reports.id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due VCS reports: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// Claim moves the next attempt of a due report to leaseUntil, unless another
+// worker claimed it first
+func (r *GormVCSReportRepository) Claim(ctx context.Context, report *integrations.VCSReport, leaseUntil time.Time) (bool, error) {
+	claimed, err := claimLease(ctx, r.db, &database.VCSReport{}, report.ID, string(integrations.VCSReportPending), report.NextAttemptAt, leaseUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim VCS report: %w", err)
+	}
+	return claimed, nil
+}
+
+// DeleteFinishedBefore removes sent and failed reports created before a time
+func (r *GormVCSReportRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := deleteFinishedBefore(ctx, r.db, &database.VCSReport{}, []string{
+		string(integrations.VCSReportSent),
+		string(integrations.VCSReportFailed),
+	}, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete VCS reports: %w", err)
+	}
+	return deleted, nil
+}
+
+// toModel converts a domain report to a database model
+func (r *GormVCSReportRepository) toModel(report *integrations.VCSReport) *database.VCSReport {
+	model := &database.VCSReport{
+		ProjectID:     report.ProjectID,
+		TestRunID:     report.TestRunID,
+		RunID:         report.RunID,
+		CommitSHA:     report.CommitSHA,
+		Final:         report.Final,
+		Status:        string(report.Status),
+		Attempts:      report.Attempts,
+		NextAttemptAt: report.NextAttemptAt,
+		LastAttemptAt: report.LastAttemptAt,
+		LastError:     report.LastError,
+		State:         string(report.State),
+		CommentURLs:   database.StringList(report.CommentURLs),
+		CreatedAt:     report.CreatedAt,
+	}
+	if id, err := strconv.ParseUint(report.ConnectionID, 10, 64); err == nil {
+		model.ConnectionID = uint(id)
+	}
+
+	return model
+}
+
+// toDomain converts a database model to a domain report
+func (r *GormVCSReportRepository) toDomain(model *database.VCSReport) *integrations.VCSReport {
+	return &integrations.VCSReport{
+		ID:            strconv.FormatUint(uint64(model.ID), 10),
+		ConnectionID:  strconv.FormatUint(uint64(model.ConnectionID), 10),
+		ProjectID:     model.ProjectID,
+		TestRunID:     model.TestRunID,
+		RunID:         model.RunID,
+		CommitSHA:     model.CommitSHA,
+		Final:         model.Final,
+		Status:        integrations.VCSReportStatus(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastAttemptAt: model.LastAttemptAt,
+		LastError:     model.LastError,
+		State:         integrations.VCSCommitState(model.State),
+		CommentURLs:   []string(model.CommentURLs),
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// toDomainList converts database models to domain reports
+func (r *GormVCSReportRepository) toDomainList(models []database.VCSReport) []*integrations.VCSReport {
+	reports := make([]*integrations.VCSReport, len(models))
+	for i := range models {
+		reports[i] = r.toDomain(&models[i])
+	}
+	return reports
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormWebhookDeliveryRepository implements WebhookDeliveryRepository using GORM
+type GormWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookDeliveryRepository creates a new GORM-based webhook delivery repository
+func NewGormWebhookDeliveryRepository(db *gorm.DB) integrations.WebhookDeliveryRepository {
+	return &GormWebhookDeliveryRepository{db: db}
+}
+
+// Create queues deliveries and assigns their IDs
+func (r *GormWebhookDeliveryRepository) Create(ctx context.Context, deliveries []*integrations.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	models := make([]*database.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		models[i] = r.toModel(delivery)
+	}
+	if err := r.db.WithContext(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	for i, model := range models {
+		deliveries[i].ID = strconv.FormatUint(uint64(model.ID), 10)
+	}
+	return nil
+}
+
+// Update saves the outcome of a delivery attempt
+func (r *GormWebhookDeliveryRepository) Update(ctx context.Context, delivery *integrations.WebhookDelivery) error {
+	err := r.db.WithContext(ctx).Model(&database.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          string(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_attempt_at": delivery.LastAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"response_body":   delivery.ResponseBody,
+			"last_error":      delivery.LastError,
+			"duration_ms":     delivery.DurationMs,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a delivery by ID
+func (r *GormWebhookDeliveryRepository) FindByID(ctx context.Context, deliveryID string) (*integrations.WebhookDelivery, error) {
+	var model database.WebhookDelivery
+
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", deliveryID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+
+	return r.toDomain(&model), nil
+}
+
+// FindBySubscriptionID retrieves the deliveries of a subscription, newest first
+func (r *GormWebhookDeliveryRepository) FindBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*integrations.WebhookDelivery, error) {
+	var models []database.WebhookDelivery
+
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindDue retrieves pending deliveries of active subscriptions whose next attempt is due, oldest first
+func (r *GormWebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*integrations.WebhookDelivery, error) {
+	var models []database.WebhookDelivery
+
+	err := r.db.WithContext(ctx).
+		Joins("JOIN webhook_subscriptions ON webhook_subscriptions.id = webhook_deliveries.subscription_id").
+		Where("webhook_subscriptions.is_active = ? AND webhook_subscriptions.deleted_at IS NULL", true).
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", string(integrations.WebhookDeliveryPending), now).
+		Order("webhook_deliveries.next_attempt_at, webhook_deliveries.id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// Claim moves the next attempt of a due delivery to leaseUntil, unless another
+// worker claimed it first
+func (r *GormWebhookDeliveryRepository) Claim(ctx context.Context, delivery *integrations.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	claimed, err := claimLease(ctx, r.db, &database.WebhookDelivery{}, delivery.ID, string(integrations.WebhookDeliveryPending), delivery.NextAttemptAt, leaseUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+	return claimed, nil
+}
+
+// DeleteFinishedBefore removes succeeded and failed deliveries created before a time
+func (r *GormWebhookDeliveryRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := deleteFinishedBefore(ctx, r.db, &database.WebhookDelivery{}, []string{
+		string(integrations.WebhookDeliverySucceeded),
+		string(integrations.WebhookDeliveryFailed),
+	}, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+	return deleted, nil
+}
+
+// toModel converts a domain delivery to a database model
+func (r *GormWebhookDeliveryRepository) toModel(delivery *integrations.WebhookDelivery) *database.WebhookDelivery {
+	model := &database.WebhookDelivery{
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		ProjectID:      delivery.ProjectID,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DurationMs:     delivery.DurationMs,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if id, err := strconv.ParseUint(delivery.SubscriptionID, 10, 64); err == nil {
+		model.SubscriptionID = uint(id)
+	}
+	if id, err := strconv.ParseUint(delivery.RedeliveryOf, 10, 64); err == nil {
+		redeliveryOf := uint(id)
+		model.RedeliveryOf = &redeliveryOf
+	}
+
+	return model
+}
+
+// toDomain converts a database model to a domain delivery
+func (r *GormWebhookDeliveryRepository) toDomain(model *database.WebhookDelivery) *integrations.WebhookDelivery {
+	delivery := &integrations.WebhookDelivery{
+		ID:             strconv.FormatUint(uint64(model.ID), 10),
+		SubscriptionID: strconv.FormatUint(uint64(model.SubscriptionID), 10),
+		EventID:        model.EventID,
+		EventType:      integrations.WebhookEventType(model.EventType),
+		ProjectID:      model.ProjectID,
+		Payload:        model.Payload,
+		Status:         integrations.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		LastAttemptAt:  model.LastAttemptAt,
+		ResponseStatus: model.ResponseStatus,
+		ResponseBody:   model.ResponseBody,
+		LastError:      model.LastError,
+		DurationMs:     model.DurationMs,
+		CreatedAt:      model.CreatedAt,
+	}
+	if model.RedeliveryOf != nil {
+		delivery.RedeliveryOf = strconv.FormatUint(uint64(*model.RedeliveryOf), 10)
+	}
+
+	return delivery
+}
+
+// toDomainList converts database models to domain deliveries
+func (r *GormWebhookDeliveryRepository) toDomainList(models []database.WebhookDelivery) []*integrations.WebhookDelivery {
+	deliveries := make([]*integrations.WebhookDelivery, len(models))
+	for i := range models {
+		deliveries[i] = r.toDomain(&models[i])
+	}
+	return deliveries
+}
@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// GormWebhookSubscriptionRepository implements WebhookSubscriptionRepository using GORM
+type GormWebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookSubscriptionRepository creates a new GORM-based webhook subscription repository
+func NewGormWebhookSubscriptionRepository(db *gorm.DB) integrations.WebhookSubscriptionRepository {
+	return &GormWebhookSubscriptionRepository{db: db}
+}
+
+// Create saves a new subscription and assigns its ID
+func (r *GormWebhookSubscriptionRepository) Create(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	model := r.toModel(subscription)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	subscription.AssignID(strconv.FormatUint(uint64(model.ID), 10))
+	return nil
+}
+
+// Update updates an existing subscription
+func (r *GormWebhookSubscriptionRepository) Update(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	model := r.toModel(subscription)
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDeliveryState saves only the delivery outcome of a subscription, so
+// settings changed while an event was being delivered are kept
+func (r *GormWebhookSubscriptionRepository) UpdateDeliveryState(ctx context.Context, subscription *integrations.WebhookSubscription) error {
+	snapshot := subscription.Snapshot()
+
+	err := r.db.WithContext(ctx).Model(&database.WebhookSubscription{}).
+		Where("id = ?", snapshot.ID).
+		Updates(map[string]interface{}{
+			"is_active":            snapshot.IsActive,
+			"disabled_reason":      snapshot.DisabledReason,
+			"consecutive_failures": snapshot.ConsecutiveFailures,
+			"last_delivery_at":     snapshot.LastDeliveryAt,
+			"updated_at":           snapshot.UpdatedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a subscription
+func (r *GormWebhookSubscriptionRepository) Delete(ctx context.Context, subscriptionID string) error {
+	if err := r.db.WithContext(ctx).Delete(&database.WebhookSubscription{}, "id = ?", subscriptionID).Error; err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a subscription by ID
+func (r *GormWebhookSubscriptionRepository) FindByID(ctx context.Context, subscriptionID string) (*integrations.WebhookSubscription, error) {
+	var model database.WebhookSubscription
+
+	if err := r.db.WithContext(ctx).First(&model, "id = ?", subscriptionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook subscription not found")
+		}
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+
+	return r.toDomain(&model), nil
+}
+
+// FindByProjectID retrieves the subscriptions of a project, or the global
+// subscriptions when projectID is empty, newest first
+func (r *GormWebhookSubscriptionRepository) FindByProjectID(ctx context.Context, projectID string) ([]*integrations.WebhookSubscription, error) {
+	query := r.db.WithContext(ctx)
+	if projectID == "" {
+		query = query.Where("project_id IS NULL")
+	} else {
+		query = query.Where("project_id = ?", projectID)
+	}
+
+	var models []database.WebhookSubscription
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	return r.toDomainList(models), nil
+}
+
+// FindActiveForEvent retrieves the active subscriptions of a project and the
+// active global subscriptions that subscribe to an event type
+func (r *GormWebhookSubscriptionRepository) FindActiveForEvent(ctx context.Context, projectID string, eventType integrations.WebhookEventType) ([]*integrations.WebhookSubscription, error) {
+	var models []database.WebhookSubscription
+
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("project_id = ? OR project_id IS NULL", projectID).
+		Where("events LIKE ?", "%"+string(eventType)+"%").
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	// LIKE narrows the candidates; the exact match is on the parsed event list
+	var subscriptions []*integrations.WebhookSubscription
+	for _, subscription := range r.toDomainList(models) {
+		if subscription.Subscribes(eventType) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+// toModel converts a domain entity to a database model
+func (r *GormWebhookSubscriptionRepository) toModel(subscription *integrations.WebhookSubscription) *database.WebhookSubscription {
+	snapshot := subscription.Snapshot()
+
+	events := make([]string, len(snapshot.Events))
+	for i, event := range snapshot.Events {
+		events[i] = string(event)
+	}
+
+	model := &database.WebhookSubscription{
+		Name:                snapshot.Name,
+		URL:                 snapshot.URL,
+		Events:              strings.Join(events, ","),
+		EncryptedSecret:     subscription.EncryptedSecret(),
+		IsActive:            snapshot.IsActive,
+		DisabledReason:      snapshot.DisabledReason,
+		ConsecutiveFailures: snapshot.ConsecutiveFailures,
+		LastDeliveryAt:      snapshot.LastDeliveryAt,
+		CreatedBy:           snapshot.CreatedBy,
+	}
+	if snapshot.ProjectID != "" {
+		projectID := snapshot.ProjectID
+		model.ProjectID = &projectID
+	}
+	if id, err := strconv.ParseUint(snapshot.ID, 10, 64); err == nil {
+		model.ID = uint(id)
+	}
+	model.CreatedAt = snapshot.CreatedAt
+	model.UpdatedAt = snapshot.UpdatedAt
+
+	return model
+}
+
+// toDomain converts a database model to a domain entity
+func (r *GormWebhookSubscriptionRepository) toDomain(model *database.WebhookSubscription) *integrations.WebhookSubscription {
+	var events []integrations.WebhookEventType
+	for _, event := range strings.Split(model.Events, ",") {
+		if event != "" {
+			events = append(events, integrations.WebhookEventType(event))
+		}
+	}
+
+	var projectID string
+	if model.ProjectID != nil {
+		projectID = *model.ProjectID
+	}
+
+	return integrations.ReconstructWebhookSubscription(integrations.WebhookSubscriptionSnapshot{
+		ID:                  strconv.FormatUint(uint64(model.ID), 10),
+		ProjectID:           projectID,
+		Name:                model.Name,
+		URL:                 model.URL,
+		Events:              events,
+		IsActive:            model.IsActive,
+		DisabledReason:      model.DisabledReason,
+		ConsecutiveFailures: model.ConsecutiveFailures,
+		LastDeliveryAt:      model.LastDeliveryAt,
+		CreatedBy:           model.CreatedBy,
+		CreatedAt:           model.CreatedAt,
+		UpdatedAt:           model.UpdatedAt,
+	}, model.EncryptedSecret)
+}
+
+// toDomainList converts database models to domain entities
+func (r *GormWebhookSubscriptionRepository) toDomainList(models []database.WebhookSubscription) []*integrations.WebhookSubscription {
+	subscriptions := make([]*integrations.WebhookSubscription, len(models))
+	for i := range models {
+		subscriptions[i] = r.toDomain(&models[i])
+	}
+	return subscriptions
+}
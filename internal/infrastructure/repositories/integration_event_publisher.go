@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	analyticsDomain "github.com/guidewire-oss/fern-platform/internal/domains/analytics/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// IntegrationEventPublisher turns test runs and flaky test changes from the
+// testing and analytics domains into integration events, delivered to
+// webhooks and to chat notification rules, and reports test runs of commits
+// to their GitHub or GitLab repositories
+type IntegrationEventPublisher struct {
+	webhooks      *integrations.WebhookService
+	notifications *integrations.NotificationService
+	vcsReports    *integrations.VCSReportService
+	flakyTests    testingDomain.FlakyTestRepository
+	fernURL       string
+	logger        *logging.Logger
+}
+
+// NewIntegrationEventPublisher creates a publisher that queues events on the
+// webhook and notification services and test run reports on the VCS report
+// service. Flaky tests found by flaky detection are described with their
+// severity from flakyTests. Payloads link back to pages under fernURL.
+func NewIntegrationEventPublisher(webhooks *integrations.WebhookService, notifications *integrations.NotificationService, vcsReports *integrations.VCSReportService, flakyTests testingDomain.FlakyTestRepository, fernURL string, logger *logging.Logger) *IntegrationEventPublisher {
+	return &IntegrationEventPublisher{
+		webhooks:      webhooks,
+		notifications: notifications,
+		vcsReports:    vcsReports,
+		flakyTests:    flakyTests,
+		fernURL:       strings.TrimRight(fernURL, "/"),
+		logger:        logger,
+	}
+}
+
+// TestRunCreated publishes a test_run.created event
+func (p *IntegrationEventPublisher) TestRunCreated(ctx context.Context, testRun *testingDomain.TestRun) {
+	p.publish(ctx, integrations.WebhookEventTestRunCreated, testRun.ProjectID, p.testRunData(testRun))
+	p.queueVCSReport(ctx, testRun, false)
+}
+
+// TestRunCompleted publishes a test_run.completed event, and a test_run.failed
+// event when the run failed or has failed tests
+func (p *IntegrationEventPublisher) TestRunCompleted(ctx context.Context, testRun *testingDomain.TestRun) {
+	data := p.testRunData(testRun)
+	p.publish(ctx, integrations.WebhookEventTestRunCompleted, testRun.ProjectID, data)
+	if testRun.Status == "failed" || testRun.FailedTests > 0 {
+		p.publish(ctx, integrations.WebhookEventTestRunFailed, testRun.ProjectID, data)
+	}
+	p.queueVCSReport(ctx, testRun, true)
+}
+
+// FlakyTestStatusChanged publishes flaky_test.detected when a flaky test is
+// reactivated and flaky_test.resolved when it is resolved. Ignoring a flaky
+// test publishes nothing.
+func (p *IntegrationEventPublisher) FlakyTestStatusChanged(ctx context.Context, flakyTest *testingDomain.FlakyTest, change testingDomain.FlakyStatusChange) {
+	var eventType integrations.WebhookEventType
+	switch change.ToStatus {
+	case testingDomain.FlakyStatusActive:
+		eventType = integrations.WebhookEventFlakyDetected
+	case testingDomain.FlakyStatusResolved:
+		eventType = integrations.WebhookEventFlakyResolved
+	default:
+		return
+	}
+
+	data := map[string]interface{}{
+		"id":              flakyTest.ID(),
+		"testName":        flakyTest.TestName(),
+		"suiteName":       flakyTest.SuiteName(),
+		"status":          string(flakyTest.Status()),
+		"previousStatus":  string(change.FromStatus),
+		"severity":        string(flakyTest.Severity()),
+		"flakeRate":       flakyTest.FlakeRate(),
+		"totalExecutions": flakyTest.TotalExecutions(),
+		"flakyExecutions": flakyTest.FlakyExecutions(),
+		"firstSeenAt":     flakyTest.FirstSeenAt(),
+		"lastSeenAt":      flakyTest.LastSeenAt(),
+		"reason":          change.Reason,
+		"actor": map[string]interface{}{
+			"type": string(change.Actor.Type),
+			"id":   change.Actor.ID,
+		},
+	}
+	if p.fernURL != "" {
+		data["url"] = fmt.Sprintf("%s/flaky-tests/%d", p.fernURL, flakyTest.ID())
+	}
+	p.publish(ctx, eventType, flakyTest.ProjectID(), data)
+}
+
+// FlakyTestDetected publishes flaky_test.detected for a test first found to be flaky
+func (p *IntegrationEventPublisher) FlakyTestDetected(ctx context.Context, flakyTest *analyticsDomain.FlakyTest) {
+	p.publish(ctx, integrations.WebhookEventFlakyDetected, flakyTest.ProjectID, p.analyticsFlakyTestData(ctx, flakyTest))
+}
+
+// FlakyTestResolved publishes flaky_test.resolved for a test that is no longer flaky
+func (p *IntegrationEventPublisher) FlakyTestResolved(ctx context.Context, flakyTest *analyticsDomain.FlakyTest) {
+	p.publish(ctx, integrations.WebhookEventFlakyResolved, flakyTest.ProjectID, p.analyticsFlakyTestData(ctx, flakyTest))
+}
+
+// publish queues an event, logging failures so they never fail the change that raised it
+func (p *IntegrationEventPublisher) publish(ctx context.Context, eventType integrations.WebhookEventType, projectID string, data map[string]interface{}) {
+	event := integrations.WebhookEvent{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		ProjectID:  projectID,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+	fields := map[string]interface{}{
+		"event_id":   event.ID,
+		"event_type": string(eventType),
+		"project_id": projectID,
+	}
+
+	if _, err := p.webhooks.Publish(ctx, event); err != nil {
+		p.logger.WithService("webhooks").WithFields(fields).WithError(err).Error("Failed to publish webhook event")
+	}
+	if p.notifications != nil {
+		if _, err := p.notifications.Notify(ctx, event); err != nil {
+			p.logger.WithService("notifications").WithFields(fields).WithError(err).Error("Failed to queue notifications")
+		}
+	}
+}
+
+// queueVCSReport queues a report of a test run of a commit to the project's
+// VCS connections, logging failures so they never fail the run
+func (p *IntegrationEventPublisher) queueVCSReport(ctx context.Context, testRun *testingDomain.TestRun, finished bool) {
+	if p.vcsReports == nil || testRun.GitCommit == "" {
+		return
+	}
+
+	repository, _ := testRun.Metadata["repository"].(string)
+	ref := integrations.VCSRunRef{
+		ProjectID:  testRun.ProjectID,
+		TestRunID:  testRun.ID,
+		RunID:      testRun.RunID,
+		CommitSHA:  testRun.GitCommit,
+		Repository: repository,
+		Finished:   finished,
+	}
+	if _, err := p.vcsReports.QueueTestRun(ctx, ref); err != nil {
+		p.logger.WithService("vcs-reports").WithFields(map[string]interface{}{
+			"run_id":     testRun.RunID,
+			"project_id": testRun.ProjectID,
+		}).WithError(err).Error("Failed to queue VCS reports")
+	}
+}
+
+// testRunData describes a test run in an event payload
+func (p *IntegrationEventPublisher) testRunData(testRun *testingDomain.TestRun) map[string]interface{} {
+	branch := testRun.GitBranch
+	if branch == "" {
+		branch = testRun.Branch
+	}
+
+	data := map[string]interface{}{
+		"id":           testRun.ID,
+		"runId":        testRun.RunID,
+		"name":         testRun.Name,
+		"status":       testRun.Status,
+		"branch":       branch,
+		"commit":       testRun.GitCommit,
+		"environment":  testRun.Environment,
+		"source":       testRun.Source,
+		"totalTests":   testRun.TotalTests,
+		"passedTests":  testRun.PassedTests,
+		"failedTests":  testRun.FailedTests,
+		"skippedTests": testRun.SkippedTests,
+		"durationMs":   testRun.Duration.Milliseconds(),
+		"startTime":    testRun.StartTime,
+		"endTime":      testRun.EndTime,
+	}
+	tags := make([]string, len(testRun.Tags))
+	for i, tag := range testRun.Tags {
+		tags[i] = tag.Name
+	}
+	data["tags"] = tags
+	if p.fernURL != "" {
+		data["url"] = fmt.Sprintf("%s/test-runs/%s", p.fernURL, url.PathEscape(testRun.RunID))
+	}
+	return data
+}
+
+// analyticsFlakyTestData describes a flaky test found by flaky detection in an
+// event payload, adding the ID and severity of the tracked flaky test so rules
+// can route by severity
+func (p *IntegrationEventPublisher) analyticsFlakyTestData(ctx context.Context, flakyTest *analyticsDomain.FlakyTest) map[string]interface{} {
+	data := map[string]interface{}{
+		"testId":       flakyTest.TestID,
+		"testName":     flakyTest.TestName,
+		"suiteName":    flakyTest.SuiteName,
+		"packageName":  flakyTest.PackageName,
+		"status":       string(flakyTest.Status),
+		"flakeScore":   flakyTest.FlakeScore,
+		"totalRuns":    flakyTest.TotalRuns,
+		"failureCount": flakyTest.FailureCount,
+		"firstSeenAt":  flakyTest.FirstSeen,
+		"lastSeenAt":   flakyTest.LastSeen,
+	}
+	if p.flakyTests == nil {
+		return data
+	}
+
+	tracked, err := p.flakyTests.FindByTestName(ctx, flakyTest.ProjectID, flakyTest.TestName)
+	if err != nil || tracked == nil {
+		return data
+	}
+	data["id"] = tracked.ID()
+	data["severity"] = string(tracked.Severity())
+	data["flakeRate"] = tracked.FlakeRate()
+	if p.fernURL != "" {
+		data["url"] = fmt.Sprintf("%s/flaky-tests/%d", p.fernURL, tracked.ID())
+	}
+	return data
+}
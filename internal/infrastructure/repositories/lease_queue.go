@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Delivery tables such as webhook_deliveries are queues: each row has a status
+// and the time of its next attempt. Workers lease a due row by moving its next
+// attempt forward, so that only one of them sends it.
+
+// claimLease moves the next attempt of a pending row of model's table from
+// dueAt to leaseUntil. It returns false if another worker claimed it first.
+func claimLease(ctx context.Context, db *gorm.DB, model interface{}, id, pendingStatus string, dueAt, leaseUntil time.Time) (bool, error) {
+	result := db.WithContext(ctx).Model(model).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, pendingStatus, dueAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// deleteFinishedBefore removes rows of model's table in one of the finished
+// statuses that were created before a time, returning how many it removed
+func deleteFinishedBefore(ctx context.Context, db *gorm.DB, model interface{}, finishedStatuses []string, before time.Time) (int64, error) {
+	result := db.WithContext(ctx).
+		Where("status IN ? AND created_at < ?", finishedStatuses, before).
+		Delete(model)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/internal/infrastructure/repositories"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupWebhookDeliveryRepository(t *testing.T) integrations.WebhookDeliveryRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.WebhookDelivery{}))
+	return repositories.NewGormWebhookDeliveryRepository(db)
+}
+
+func TestLeaseQueue_Claim(t *testing.T) {
+	ctx := context.Background()
+	repo := setupWebhookDeliveryRepository(t)
+
+	now := time.Now()
+	delivery := &integrations.WebhookDelivery{
+		SubscriptionID: "1",
+		EventID:        "event-1",
+		EventType:      "test_run.completed",
+		Payload:        "{}",
+		Status:         integrations.WebhookDeliveryPending,
+		NextAttemptAt:  now.Add(-time.Minute),
+		CreatedAt:      now,
+	}
+	require.NoError(t, repo.Create(ctx, []*integrations.WebhookDelivery{delivery}))
+
+	leaseUntil := now.Add(5 * time.Minute)
+	claimed, err := repo.Claim(ctx, delivery, leaseUntil)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A second worker holding the same due delivery loses the race
+	claimed, err = repo.Claim(ctx, delivery, leaseUntil)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	saved, err := repo.FindByID(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.WithinDuration(t, leaseUntil, saved.NextAttemptAt, time.Second)
+}
+
+func TestLeaseQueue_DeleteFinishedBefore(t *testing.T) {
+	ctx := context.Background()
+	repo := setupWebhookDeliveryRepository(t)
+
+	now := time.Now()
+	delivery := func(status integrations.WebhookDeliveryStatus, createdAt time.Time) *integrations.WebhookDelivery {
+		return &integrations.WebhookDelivery{
+			SubscriptionID: "1",
+			EventID:        "event-1",
+			EventType:      "test_run.completed",
+			Payload:        "{}",
+			Status:         status,
+			NextAttemptAt:  createdAt,
+			CreatedAt:      createdAt,
+		}
+	}
+	old := now.Add(-48 * time.Hour)
+	deliveries := []*integrations.WebhookDelivery{
+		delivery(integrations.WebhookDeliverySucceeded, old),
+		delivery(integrations.WebhookDeliveryFailed, old),
+		delivery(integrations.WebhookDeliveryPending, old),
+		delivery(integrations.WebhookDeliverySucceeded, now),
+	}
+	require.NoError(t, repo.Create(ctx, deliveries))
+
+	deleted, err := repo.DeleteFinishedBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	remaining, err := repo.FindBySubscriptionID(ctx, "1", 0)
+	require.NoError(t, err)
+	var remainingIDs []string
+	for _, d := range remaining {
+		remainingIDs = append(remainingIDs, d.ID)
+	}
+	assert.ElementsMatch(t, []string{deliveries[2].ID, deliveries[3].ID}, remainingIDs)
+}
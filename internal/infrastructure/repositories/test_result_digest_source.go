@@ -0,0 +1,279 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// TestResultDigestSource loads digest recipients from the users and their
+// preferences, and project test health from the test results
+type TestResultDigestSource struct {
+	db      *gorm.DB
+	fernURL string
+}
+
+// NewTestResultDigestSource creates a digest source. Digests link back to
+// pages under fernURL.
+func NewTestResultDigestSource(db *gorm.DB, fernURL string) *TestResultDigestSource {
+	return &TestResultDigestSource{
+		db:      db,
+		fernURL: strings.TrimRight(fernURL, "/"),
+	}
+}
+
+// Recipients returns the active users with an email address, with the
+// projects they favorite or manage
+func (s *TestResultDigestSource) Recipients(ctx context.Context) ([]integrations.DigestRecipient, error) {
+	var users []database.User
+
+	err := s.db.WithContext(ctx).Where("status = ? AND email <> ''", "active").Order("user_id").Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	recipients := make([]integrations.DigestRecipient, 0, len(users))
+	for i := range users {
+		recipient, err := s.recipient(ctx, &users[i])
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, *recipient)
+	}
+	return recipients, nil
+}
+
+// Recipient returns one user as a recipient
+func (s *TestResultDigestSource) Recipient(ctx context.Context, userID string) (*integrations.DigestRecipient, error) {
+	var user database.User
+
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to find user %s: %w", userID, err)
+	}
+	return s.recipient(ctx, &user)
+}
+
+// recipient finds the projects a user favorites or manages, and whether
+// they opted out of digests. A user manages the projects of the teams whose
+// manager group they belong to, and the projects they were granted write or
+// admin permission on.
+func (s *TestResultDigestSource) recipient(ctx context.Context, user *database.User) (*integrations.DigestRecipient, error) {
+	recipient := &integrations.DigestRecipient{
+		UserID: user.UserID,
+		Email:  user.Email,
+		Name:   user.Name,
+	}
+	db := s.db.WithContext(ctx)
+	candidates := make(map[string]bool)
+
+	var prefs []database.UserPreferences
+	if err := db.Where("user_id = ?", user.UserID).Limit(1).Find(&prefs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get preferences of user %s: %w", user.UserID, err)
+	}
+	if len(prefs) > 0 {
+		recipient.OptedOut = prefs[0].EmailDigestOptOut
+		var favorites []string
+		if len(prefs[0].Favorites) > 0 {
+			// Unreadable favorites only drop the favorites, not the user
+			_ = json.Unmarshal(prefs[0].Favorites, &favorites)
+		}
+		for _, projectID := range favorites {
+			candidates[projectID] = true
+		}
+	}
+
+	var groups []string
+	if err := db.Model(&database.UserGroup{}).Where("user_id = ?", user.UserID).Pluck("group_name", &groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get groups of user %s: %w", user.UserID, err)
+	}
+	var teams []string
+	for _, group := range groups {
+		group = strings.TrimPrefix(group, "/")
+		if team, ok := strings.CutSuffix(group, "-managers"); ok && team != "" {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) > 0 {
+		var teamProjects []string
+		if err := db.Model(&database.ProjectDetails{}).Where("team IN ?", teams).Pluck("project_id", &teamProjects).Error; err != nil {
+			return nil, fmt.Errorf("failed to get team projects: %w", err)
+		}
+		for _, projectID := range teamProjects {
+			candidates[projectID] = true
+		}
+	}
+
+	var granted []string
+	err := db.Model(&database.ProjectPermission{}).
+		Where("user_id = ? AND permission IN ?", user.UserID, []string{"write", "admin"}).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Pluck("project_id", &granted).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project permissions of user %s: %w", user.UserID, err)
+	}
+	for _, projectID := range granted {
+		candidates[projectID] = true
+	}
+
+	if len(candidates) == 0 {
+		return recipient, nil
+	}
+	ids := make([]string, 0, len(candidates))
+	for projectID := range candidates {
+		ids = append(ids, projectID)
+	}
+	// Favorites may name projects that were deleted since
+	if err := db.Model(&database.ProjectDetails{}).Where("project_id IN ?", ids).Pluck("project_id", &recipient.ProjectIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	sort.Strings(recipient.ProjectIDs)
+	return recipient, nil
+}
+
+// ProjectHealth loads the test health of a project from from to to, and the
+// runs of the equally long period before
+func (s *TestResultDigestSource) ProjectHealth(ctx context.Context, projectID string, from, to time.Time) (*integrations.DigestProject, error) {
+	db := s.db.WithContext(ctx)
+
+	var details database.ProjectDetails
+	if err := db.Where("project_id = ?", projectID).First(&details).Error; err != nil {
+		return nil, fmt.Errorf("failed to find project: %w", err)
+	}
+	project := &integrations.DigestProject{
+		ID:   details.ProjectID,
+		Name: details.Name,
+		URL:  fernLink(s.fernURL, "projects", details.ProjectID),
+	}
+	if project.Name == "" {
+		project.Name = details.ProjectID
+	}
+
+	var runs []database.TestRun
+	err := db.Select("start_time", "passed_tests", "failed_tests").
+		Where("project_id = ? AND start_time >= ? AND start_time < ?", projectID, from.Add(-to.Sub(from)), to).
+		Where("status NOT IN ?", []string{"running", "pending"}).
+		Order("start_time").
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find test runs: %w", err)
+	}
+	for _, run := range runs {
+		digestRun := integrations.DigestRun{StartTime: run.StartTime, PassedTests: run.PassedTests, FailedTests: run.FailedTests}
+		if run.StartTime.Before(from) {
+			project.PreviousRuns = append(project.PreviousRuns, digestRun)
+		} else {
+			project.Runs = append(project.Runs, digestRun)
+		}
+	}
+
+	if project.TopFailingSpecs, err = s.topFailingSpecs(ctx, projectID, from, to); err != nil {
+		return nil, err
+	}
+	if project.NewFlakyTests, err = s.flakyTests(ctx, projectID, "created_at", from, to, "active"); err != nil {
+		return nil, err
+	}
+	if project.ResolvedFlakyTests, err = s.flakyTests(ctx, projectID, "status_changed_at", from, to, "resolved"); err != nil {
+		return nil, err
+	}
+	if project.SlowestSuites, err = s.slowestSuites(ctx, projectID, from, to); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// topFailingSpecs returns the specs that failed most often in runs of the period
+func (s *TestResultDigestSource) topFailingSpecs(ctx context.Context, projectID string, from, to time.Time) ([]integrations.DigestFailingSpec, error) {
+	var rows []struct {
+		SuiteName string
+		SpecName  string
+		Failures  int
+	}
+
+	err := s.db.WithContext(ctx).Table("spec_runs").
+		Select("suite_runs.suite_name, spec_runs.spec_name, COUNT(*) AS failures").
+		Joins("JOIN suite_runs ON suite_runs.id = spec_runs.suite_run_id").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND test_runs.start_time >= ? AND test_runs.start_time < ?", projectID, from, to).
+		Where("spec_runs.status = ? AND spec_runs.deleted_at IS NULL AND test_runs.deleted_at IS NULL", "failed").
+		Group("suite_runs.suite_name, spec_runs.spec_name").
+		Order("failures DESC, suite_runs.suite_name, spec_runs.spec_name").
+		Limit(integrations.DigestListLimit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find failing specs: %w", err)
+	}
+
+	specs := make([]integrations.DigestFailingSpec, len(rows))
+	for i, row := range rows {
+		specs[i] = integrations.DigestFailingSpec{SuiteName: row.SuiteName, SpecName: row.SpecName, Failures: row.Failures}
+	}
+	return specs, nil
+}
+
+// flakyTests returns the flaky tests of a project with a status whose
+// timestamp column falls in the period, flakiest first. Tests detected and
+// resolved in the same period only count as resolved.
+func (s *TestResultDigestSource) flakyTests(ctx context.Context, projectID, column string, from, to time.Time, status string) ([]integrations.DigestFlakyTest, error) {
+	var models []database.FlakyTest
+
+	err := s.db.WithContext(ctx).
+		Where("project_id = ? AND status = ?", projectID, status).
+		Where(column+" >= ? AND "+column+" < ?", from, to).
+		Order("flake_rate DESC, id").
+		Limit(integrations.DigestListLimit).
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find flaky tests: %w", err)
+	}
+
+	tests := make([]integrations.DigestFlakyTest, len(models))
+	for i, model := range models {
+		tests[i] = integrations.DigestFlakyTest{
+			SuiteName: model.SuiteName,
+			TestName:  model.TestName,
+			FlakeRate: model.FlakeRate / 100, // Stored as a percentage
+			Severity:  model.Severity,
+			URL:       fernLink(s.fernURL, "flaky-tests", fmt.Sprintf("%d", model.ID)),
+		}
+	}
+	return tests, nil
+}
+
+// slowestSuites returns the suites with the longest average duration in runs of the period
+func (s *TestResultDigestSource) slowestSuites(ctx context.Context, projectID string, from, to time.Time) ([]integrations.DigestSuite, error) {
+	var rows []struct {
+		SuiteName  string
+		AverageMS  float64
+		Executions int
+	}
+
+	err := s.db.WithContext(ctx).Table("suite_runs").
+		Select("suite_runs.suite_name, AVG(suite_runs.duration_ms) AS average_ms, COUNT(*) AS executions").
+		Joins("JOIN test_runs ON test_runs.id = suite_runs.test_run_id").
+		Where("test_runs.project_id = ? AND test_runs.start_time >= ? AND test_runs.start_time < ?", projectID, from, to).
+		Where("suite_runs.deleted_at IS NULL AND test_runs.deleted_at IS NULL").
+		Group("suite_runs.suite_name").
+		Order("average_ms DESC, suite_runs.suite_name").
+		Limit(integrations.DigestListLimit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find slowest suites: %w", err)
+	}
+
+	suites := make([]integrations.DigestSuite, len(rows))
+	for i, row := range rows {
+		suites[i] = integrations.DigestSuite{
+			Name:            row.SuiteName,
+			AverageDuration: time.Duration(row.AverageMS * float64(time.Millisecond)),
+			Runs:            row.Executions,
+		}
+	}
+	return suites, nil
+}
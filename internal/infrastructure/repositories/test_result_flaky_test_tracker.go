@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// jiraSyncActorID identifies the JIRA issue sync in flaky test status history
+const jiraSyncActorID = "jira"
+
+// TestResultFlakyTestTracker reads and resolves flaky tests in the testing
+// domain on behalf of the JIRA issue sync
+type TestResultFlakyTestTracker struct {
+	flakyRepo testingDomain.FlakyTestRepository
+	fernURL   string
+}
+
+// NewTestResultFlakyTestTracker creates a tracker backed by the flaky test
+// repository. Comments link back to pages under fernURL.
+func NewTestResultFlakyTestTracker(flakyRepo testingDomain.FlakyTestRepository, fernURL string) *TestResultFlakyTestTracker {
+	return &TestResultFlakyTestTracker{
+		flakyRepo: flakyRepo,
+		fernURL:   strings.TrimRight(fernURL, "/"),
+	}
+}
+
+// FlakyTestState loads a flaky test, returning nil when it no longer exists
+func (t *TestResultFlakyTestTracker) FlakyTestState(ctx context.Context, flakyTestID uint) (*integrations.FlakyTestState, error) {
+	flakyTest, err := t.flakyRepo.FindByID(ctx, flakyTestID)
+	if err != nil {
+		if err.Error() == "flaky test not found" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get flaky test: %w", err)
+	}
+
+	state := &integrations.FlakyTestState{
+		Status:           string(flakyTest.Status()),
+		FlakeRate:        flakyTest.FlakeRate() / 100, // Stored as a percentage
+		TotalExecutions:  flakyTest.TotalExecutions(),
+		FlakyExecutions:  flakyTest.FlakyExecutions(),
+		LastSeenAt:       flakyTest.LastSeenAt(),
+		LastErrorMessage: flakyTest.LastErrorMessage(),
+	}
+	if t.fernURL != "" {
+		state.URL = fmt.Sprintf("%s/flaky-tests/%d", t.fernURL, flakyTest.ID())
+	}
+
+	history, err := t.flakyRepo.GetStatusHistory(ctx, flakyTestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flaky test status history: %w", err)
+	}
+	// History is newest first, so the first reactivation is the latest
+	for _, change := range history {
+		if change.FromStatus == testingDomain.FlakyStatusResolved && change.ToStatus == testingDomain.FlakyStatusActive {
+			reactivatedAt := change.ChangedAt
+			state.ReactivatedAt = &reactivatedAt
+			break
+		}
+	}
+
+	return state, nil
+}
+
+// ResolveFlakyTest marks an active flaky test resolved, recording the JIRA sync as the actor
+func (t *TestResultFlakyTestTracker) ResolveFlakyTest(ctx context.Context, flakyTestID uint, reason string) error {
+	flakyTest, err := t.flakyRepo.FindByID(ctx, flakyTestID)
+	if err != nil {
+		return fmt.Errorf("failed to get flaky test: %w", err)
+	}
+
+	if err := flakyTest.ResolveBy(testingDomain.IntegrationActor(jiraSyncActorID), reason); err != nil {
+		return err
+	}
+	if err := t.flakyRepo.Update(ctx, flakyTest); err != nil {
+		return fmt.Errorf("failed to update flaky test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestResultFlakyTestTracker_FlakeRateAsFraction(t *testing.T) {
+	tracker := repositories.NewTestResultFlakyTestTracker(newStoredFlakyTests(), "https://fern.example/")
+
+	state, err := tracker.FlakyTestState(context.Background(), 7)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, state.FlakeRate, 1e-9)
+	assert.Equal(t, 5, state.FlakyExecutions)
+	assert.Equal(t, 20, state.TotalExecutions)
+	assert.Equal(t, "https://fern.example/flaky-tests/7", state.URL)
+}
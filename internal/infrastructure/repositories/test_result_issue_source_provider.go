@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+)
+
+// issueHistoryLimit is the number of previous executions included in an issue
+const issueHistoryLimit = 10
+
+// TestResultIssueSourceProvider loads issue content from the testing domain
+type TestResultIssueSourceProvider struct {
+	specRunRepo testingDomain.SpecRunRepository
+	flakyRepo   testingDomain.FlakyTestRepository
+	fernURL     string
+}
+
+// NewTestResultIssueSourceProvider creates a provider backed by the testing
+// repositories. Issues link back to pages under fernURL.
+func NewTestResultIssueSourceProvider(specRunRepo testingDomain.SpecRunRepository, flakyRepo testingDomain.FlakyTestRepository, fernURL string) *TestResultIssueSourceProvider {
+	return &TestResultIssueSourceProvider{
+		specRunRepo: specRunRepo,
+		flakyRepo:   flakyRepo,
+		fernURL:     strings.TrimRight(fernURL, "/"),
+	}
+}
+
+// FailureSource loads one or more failed spec runs, treating the first as representative
+func (p *TestResultIssueSourceProvider) FailureSource(ctx context.Context, specRunIDs []uint) (*integrations.IssueSource, error) {
+	found, err := p.specRunRepo.FindFailures(ctx, testingDomain.FailureFilter{SpecRunIDs: specRunIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failures: %w", err)
+	}
+	byID := make(map[uint]*testingDomain.SpecFailure, len(found))
+	for _, failure := range found {
+		byID[failure.SpecRun.ID] = failure
+	}
+
+	source := &integrations.IssueSource{}
+	seen := make(map[uint]bool, len(specRunIDs))
+	for _, id := range specRunIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		failure, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("spec run %d not found or did not fail", id)
+		}
+		if source.ProjectID == "" {
+			source.ProjectID = failure.ProjectID
+			source.TestName = failure.SpecRun.Name
+			source.SuiteName = failure.SuiteName
+			source.Owners = failure.SpecRun.Owners
+			source.URL = fernLink(p.fernURL, "test-runs", failure.TestRunID)
+		} else if failure.ProjectID != source.ProjectID {
+			return nil, fmt.Errorf("spec runs belong to different projects")
+		}
+
+		source.Failures = append(source.Failures, integrations.IssueFailure{
+			SpecRunID:    failure.SpecRun.ID,
+			TestName:     failure.SpecRun.Name,
+			SuiteName:    failure.SuiteName,
+			TestRunID:    failure.TestRunID,
+			Branch:       failure.Branch,
+			ErrorMessage: errorMessage(failure.SpecRun),
+			StackTrace:   failure.SpecRun.StackTrace,
+			FailedAt:     failure.SpecRun.StartTime,
+		})
+		source.Targets = appendTarget(source.Targets, integrations.FailureTarget(failure.SuiteName, failure.SpecRun.Name))
+	}
+
+	// History is only gathered for the representative failure
+	representative := source.Failures[0]
+	executions, err := p.flakyRepo.FindRecentExecutions(ctx, source.ProjectID, representative.TestName, representative.SuiteName, issueHistoryLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test history: %w", err)
+	}
+	for _, execution := range executions {
+		if execution.SpecRunID == representative.SpecRunID || len(source.History) == issueHistoryLimit {
+			continue
+		}
+		source.History = append(source.History, historyEntry(execution))
+	}
+
+	return source, nil
+}
+
+// FlakyTestSource loads a flaky test with its most recent failure
+func (p *TestResultIssueSourceProvider) FlakyTestSource(ctx context.Context, flakyTestID uint) (*integrations.IssueSource, error) {
+	flakyTest, err := p.flakyRepo.FindByID(ctx, flakyTestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flaky test: %w", err)
+	}
+
+	source := &integrations.IssueSource{
+		Type:      integrations.IssueSourceFlakyTest,
+		ProjectID: flakyTest.ProjectID(),
+		TestName:  flakyTest.TestName(),
+		SuiteName: flakyTest.SuiteName(),
+		Owners:    flakyTest.Owners(),
+		Flaky: &integrations.IssueFlakyStats{
+			FlakeRate:       flakyTest.FlakeRate() / 100, // Stored as a percentage
+			TotalExecutions: flakyTest.TotalExecutions(),
+			FlakyExecutions: flakyTest.FlakyExecutions(),
+			Severity:        string(flakyTest.Severity()),
+			Status:          string(flakyTest.Status()),
+			FirstSeenAt:     flakyTest.FirstSeenAt(),
+			LastSeenAt:      flakyTest.LastSeenAt(),
+		},
+		URL:     fernLink(p.fernURL, "flaky-tests", fmt.Sprintf("%d", flakyTest.ID())),
+		Targets: []integrations.IssueTarget{integrations.FlakyTestTarget(flakyTest.ID(), flakyTest.SuiteName(), flakyTest.TestName())},
+	}
+
+	executions, err := p.flakyRepo.FindRecentExecutions(ctx, source.ProjectID, source.TestName, source.SuiteName, issueHistoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test history: %w", err)
+	}
+	for _, execution := range executions {
+		source.History = append(source.History, historyEntry(execution))
+	}
+
+	// Describe the most recent execution that flaked, falling back to the
+	// last recorded error when none is in the recent history
+	for _, execution := range executions {
+		if !execution.Flaked() {
+			continue
+		}
+		specRun, err := p.specRunRepo.GetByID(ctx, execution.SpecRunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spec run %d: %w", execution.SpecRunID, err)
+		}
+		source.Failures = append(source.Failures, integrations.IssueFailure{
+			SpecRunID:    specRun.ID,
+			TestName:     flakyTest.TestName(),
+			SuiteName:    flakyTest.SuiteName(),
+			TestRunID:    execution.TestRunID,
+			ErrorMessage: errorMessage(specRun),
+			StackTrace:   specRun.StackTrace,
+			FailedAt:     execution.ExecutedAt,
+		})
+		break
+	}
+	if len(source.Failures) == 0 && flakyTest.LastErrorMessage() != "" {
+		source.Failures = append(source.Failures, integrations.IssueFailure{
+			TestName:     flakyTest.TestName(),
+			SuiteName:    flakyTest.SuiteName(),
+			ErrorMessage: flakyTest.LastErrorMessage(),
+			FailedAt:     flakyTest.LastSeenAt(),
+		})
+	}
+
+	return source, nil
+}
+
+// fernLink builds a URL to a Fern page, or returns an empty string when no Fern URL is configured
+func fernLink(baseURL, page, id string) string {
+	if baseURL == "" || id == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s", baseURL, page, url.PathEscape(id))
+}
+
+func appendTarget(targets []integrations.IssueTarget, target integrations.IssueTarget) []integrations.IssueTarget {
+	for _, existing := range targets {
+		if existing.Type == target.Type && existing.ID == target.ID {
+			return targets
+		}
+	}
+	return append(targets, target)
+}
+
+func errorMessage(specRun *testingDomain.SpecRun) string {
+	message := specRun.ErrorMessage
+	if specRun.FailureMessage != "" && specRun.FailureMessage != message {
+		if message != "" {
+			message += "\n"
+		}
+		message += specRun.FailureMessage
+	}
+	return message
+}
+
+func historyEntry(execution testingDomain.SpecExecution) integrations.IssueHistoryEntry {
+	status := execution.Status
+	if execution.IsFlaky && execution.Status != "failed" {
+		status += " after retry"
+	}
+	return integrations.IssueHistoryEntry{
+		TestRunID:    execution.TestRunID,
+		Status:       status,
+		ErrorMessage: execution.ErrorMessage,
+		ExecutedAt:   execution.ExecutedAt,
+	}
+}
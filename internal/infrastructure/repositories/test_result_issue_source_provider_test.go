@@ -0,0 +1,23 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	"github.com/guidewire-oss/fern-platform/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestResultIssueSourceProvider_FlakeRateAsPercentage(t *testing.T) {
+	provider := repositories.NewTestResultIssueSourceProvider(nil, newStoredFlakyTests(), "")
+
+	source, err := provider.FlakyTestSource(context.Background(), 7)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, source.Flaky.FlakeRate, 1e-9)
+
+	_, description, err := integrations.DefaultIssueTemplate().Render(source)
+	require.NoError(t, err)
+	assert.Contains(t, description, "||Flake rate|25.0%|")
+}
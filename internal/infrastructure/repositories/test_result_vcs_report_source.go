@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"gorm.io/gorm"
+)
+
+// TestResultVCSReportSource loads test runs for VCS reports from the testing domain
+type TestResultVCSReportSource struct {
+	db          *gorm.DB
+	testRunRepo testingDomain.TestRunRepository
+	flakyRepo   testingDomain.FlakyTestRepository
+	fernURL     string
+}
+
+// NewTestResultVCSReportSource creates a source backed by the testing
+// repositories. Reports link back to pages under fernURL.
+func NewTestResultVCSReportSource(db *gorm.DB, testRunRepo testingDomain.TestRunRepository, flakyRepo testingDomain.FlakyTestRepository, fernURL string) *TestResultVCSReportSource {
+	return &TestResultVCSReportSource{
+		db:          db,
+		testRunRepo: testRunRepo,
+		flakyRepo:   flakyRepo,
+		fernURL:     strings.TrimRight(fernURL, "/"),
+	}
+}
+
+// TestRun loads a test run with its failures and the flaky tests it executed:
+// active flaky tests of the project, and tests that passed on retry
+func (s *TestResultVCSReportSource) TestRun(ctx context.Context, testRunID uint) (*integrations.VCSTestRun, error) {
+	testRun, err := s.testRunRepo.GetWithDetails(ctx, testRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test run %d: %w", testRunID, err)
+	}
+
+	flakyTests, err := s.flakyRepo.FindByFilter(ctx, testingDomain.FlakyTestFilter{
+		ProjectID: testRun.ProjectID,
+		Statuses:  []testingDomain.FlakyStatus{testingDomain.FlakyStatusActive},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flaky tests: %w", err)
+	}
+	known := make(map[string]*testingDomain.FlakyTest, len(flakyTests))
+	for _, flakyTest := range flakyTests {
+		known[flakyTest.SuiteName()+"\x00"+flakyTest.TestName()] = flakyTest
+	}
+
+	run := s.convert(testRun)
+	for _, suiteRun := range testRun.SuiteRuns {
+		for _, specRun := range suiteRun.SpecRuns {
+			if specRun == nil {
+				continue
+			}
+			if specRun.Status == "failed" {
+				run.Failures = append(run.Failures, integrations.VCSFailedTest{
+					SuiteName:    suiteRun.Name,
+					TestName:     specRun.Name,
+					ErrorMessage: errorMessage(specRun),
+				})
+			}
+
+			flakyTest, isKnown := known[suiteRun.Name+"\x00"+specRun.Name]
+			if !isKnown && !specRun.IsFlaky {
+				continue
+			}
+			flaky := integrations.VCSFlakyTest{
+				SuiteName: suiteRun.Name,
+				TestName:  specRun.Name,
+				Result:    specRun.Status,
+			}
+			if specRun.IsFlaky && specRun.Status != "failed" {
+				flaky.Result += " after retry"
+			}
+			if isKnown {
+				flaky.FlakeRate = flakyTest.FlakeRate() / 100 // Stored as a percentage
+				flaky.Severity = string(flakyTest.Severity())
+				flaky.URL = fernLink(s.fernURL, "flaky-tests", fmt.Sprintf("%d", flakyTest.ID()))
+			}
+			run.FlakyTests = append(run.FlakyTests, flaky)
+		}
+	}
+	return run, nil
+}
+
+// BaseRun loads the newest finished run of a project on a branch that started
+// before the given run, or returns nil when there is none
+func (s *TestResultVCSReportSource) BaseRun(ctx context.Context, projectID, branch string, before uint) (*integrations.VCSTestRun, error) {
+	var model database.TestRun
+
+	err := s.db.WithContext(ctx).
+		Where("project_id = ? AND branch = ? AND id < ?", projectID, branch, before).
+		Where("status NOT IN ?", []string{"running", "pending"}).
+		Order("id DESC").
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find base run: %w", err)
+	}
+
+	return s.TestRun(ctx, model.ID)
+}
+
+// DefaultBranch returns the default branch of a project
+func (s *TestResultVCSReportSource) DefaultBranch(ctx context.Context, projectID string) (string, error) {
+	var project database.ProjectDetails
+
+	err := s.db.WithContext(ctx).Select("default_branch").Where("project_id = ?", projectID).First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find project: %w", err)
+	}
+	return project.DefaultBranch, nil
+}
+
+// convert describes a test run without its tests
+func (s *TestResultVCSReportSource) convert(testRun *testingDomain.TestRun) *integrations.VCSTestRun {
+	branch := testRun.GitBranch
+	if branch == "" {
+		branch = testRun.Branch
+	}
+
+	return &integrations.VCSTestRun{
+		ID:           testRun.ID,
+		RunID:        testRun.RunID,
+		ProjectID:    testRun.ProjectID,
+		Name:         testRun.Name,
+		Status:       testRun.Status,
+		Branch:       branch,
+		CommitSHA:    testRun.GitCommit,
+		Finished:     testRun.Status != "" && testRun.Status != "running" && testRun.Status != "pending",
+		TotalTests:   testRun.TotalTests,
+		PassedTests:  testRun.PassedTests,
+		FailedTests:  testRun.FailedTests,
+		SkippedTests: testRun.SkippedTests,
+		Duration:     testRun.Duration,
+		URL:          fernLink(s.fernURL, "test-runs", testRun.RunID),
+	}
+}
@@ -0,0 +1,90 @@
+package repositories_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/guidewire-oss/fern-platform/internal/infrastructure/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedFlakyTests serves flaky tests as they are stored, with flake rates
+// as percentages
+type storedFlakyTests struct {
+	testingDomain.FlakyTestRepository
+	tests []*testingDomain.FlakyTest
+}
+
+func (r *storedFlakyTests) FindByID(ctx context.Context, id uint) (*testingDomain.FlakyTest, error) {
+	for _, flakyTest := range r.tests {
+		if flakyTest.ID() == id {
+			return flakyTest, nil
+		}
+	}
+	return nil, fmt.Errorf("flaky test not found")
+}
+
+func (r *storedFlakyTests) FindByFilter(ctx context.Context, filter testingDomain.FlakyTestFilter) ([]*testingDomain.FlakyTest, error) {
+	return r.tests, nil
+}
+
+func (r *storedFlakyTests) FindRecentExecutions(ctx context.Context, projectID, testName, suiteName string, limit int) ([]testingDomain.SpecExecution, error) {
+	return nil, nil
+}
+
+func (r *storedFlakyTests) GetStatusHistory(ctx context.Context, flakyTestID uint) ([]testingDomain.FlakyStatusChange, error) {
+	return nil, nil
+}
+
+// newStoredFlakyTests stores a test that flaked in 5 of 20 runs
+func newStoredFlakyTests() *storedFlakyTests {
+	return &storedFlakyTests{tests: []*testingDomain.FlakyTest{
+		testingDomain.ReconstructFlakyTest(testingDomain.FlakyTestSnapshot{
+			ID:              7,
+			ProjectID:       "proj-123",
+			TestName:        "pays with card",
+			SuiteName:       "Checkout",
+			FlakeRate:       25,
+			TotalExecutions: 20,
+			FlakyExecutions: 5,
+			Status:          testingDomain.FlakyStatusActive,
+			Severity:        testingDomain.FlakySeverityMedium,
+		}),
+	}}
+}
+
+// detailedTestRuns serves a single test run with its suites and specs
+type detailedTestRuns struct {
+	testingDomain.TestRunRepository
+	testRun *testingDomain.TestRun
+}
+
+func (r *detailedTestRuns) GetWithDetails(ctx context.Context, id uint) (*testingDomain.TestRun, error) {
+	return r.testRun, nil
+}
+
+func TestTestResultVCSReportSource_FlakeRateAsPercentage(t *testing.T) {
+	testRuns := &detailedTestRuns{testRun: &testingDomain.TestRun{
+		ID:        1,
+		RunID:     "run-1",
+		ProjectID: "proj-123",
+		Status:    "passed",
+		SuiteRuns: []testingDomain.SuiteRun{{
+			Name:     "Checkout",
+			SpecRuns: []*testingDomain.SpecRun{{Name: "pays with card", Status: "passed", IsFlaky: true}},
+		}},
+	}}
+	source := repositories.NewTestResultVCSReportSource(nil, testRuns, newStoredFlakyTests(), "")
+
+	run, err := source.TestRun(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, run.FlakyTests, 1)
+	assert.InDelta(t, 0.25, run.FlakyTests[0].FlakeRate, 1e-9)
+
+	comment := integrations.RenderVCSComment(integrations.CompareVCSTestRun(run, nil, ""), "")
+	assert.Contains(t, comment, "| passed after retry | 25% | medium |")
+}
@@ -0,0 +1,201 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+)
+
+// auditTarget describes what a mutation acts on. The target is identified by
+// the argument named Arg, or by the mutation's result when Arg is empty.
+type auditTarget struct {
+	Type string
+	Arg  string
+}
+
+// auditTargets are the targets of mutations by mutation name. Mutations that
+// are not listed are recorded without a target.
+var auditTargets = map[string]auditTarget{
+	"createTestRun":                   {Type: auditDomain.TargetTestRun},
+	"updateTestRunStatus":             {Type: auditDomain.TargetTestRun, Arg: "runId"},
+	"deleteTestRun":                   {Type: auditDomain.TargetTestRun, Arg: "id"},
+	"assignTagsToTestRun":             {Type: auditDomain.TargetTestRun, Arg: "testRunId"},
+	"createProject":                   {Type: auditDomain.TargetProject},
+	"updateProject":                   {Type: auditDomain.TargetProject, Arg: "id"},
+	"deleteProject":                   {Type: auditDomain.TargetProject, Arg: "id"},
+	"activateProject":                 {Type: auditDomain.TargetProject, Arg: "projectId"},
+	"deactivateProject":               {Type: auditDomain.TargetProject, Arg: "projectId"},
+	"createTag":                       {Type: auditDomain.TargetTag},
+	"updateTag":                       {Type: auditDomain.TargetTag, Arg: "id"},
+	"deleteTag":                       {Type: auditDomain.TargetTag, Arg: "id"},
+	"markFlakyTestResolved":           {Type: auditDomain.TargetFlakyTest, Arg: "id"},
+	"reactivateFlakyTest":             {Type: auditDomain.TargetFlakyTest, Arg: "id"},
+	"markSpecAsFlaky":                 {Type: auditDomain.TargetSpecRun, Arg: "specRunId"},
+	"updateUserRole":                  {Type: auditDomain.TargetUser, Arg: "userId"},
+	"suspendUser":                     {Type: auditDomain.TargetUser, Arg: "userId"},
+	"activateUser":                    {Type: auditDomain.TargetUser, Arg: "userId"},
+	"deleteUser":                      {Type: auditDomain.TargetUser, Arg: "userId"},
+	"grantProjectAccess":              {Type: auditDomain.TargetProjectAccess, Arg: "input"},
+	"revokeProjectAccess":             {Type: auditDomain.TargetProjectAccess},
+	"createJiraConnection":            {Type: auditDomain.TargetJiraConnection},
+	"updateJiraConnection":            {Type: auditDomain.TargetJiraConnection, Arg: "id"},
+	"updateJiraCredentials":           {Type: auditDomain.TargetJiraConnection, Arg: "id"},
+	"testJiraConnection":              {Type: auditDomain.TargetJiraConnection, Arg: "id"},
+	"deleteJiraConnection":            {Type: auditDomain.TargetJiraConnection, Arg: "id"},
+	"updateJiraFieldMapping":          {Type: auditDomain.TargetJiraConnection, Arg: "connectionId"},
+	"createIssueTrackerConnection":    {Type: auditDomain.TargetTrackerConnection},
+	"updateIssueTrackerConnection":    {Type: auditDomain.TargetTrackerConnection, Arg: "id"},
+	"updateIssueTrackerCredential":    {Type: auditDomain.TargetTrackerConnection, Arg: "id"},
+	"testIssueTrackerConnection":      {Type: auditDomain.TargetTrackerConnection, Arg: "id"},
+	"setIssueTrackerConnectionActive": {Type: auditDomain.TargetTrackerConnection, Arg: "id"},
+	"deleteIssueTrackerConnection":    {Type: auditDomain.TargetTrackerConnection, Arg: "id"},
+}
+
+// auditLog is a gqlgen extension recording successful mutations in the audit log
+type auditLog struct {
+	service *auditApp.AuditService
+	logger  *logging.Logger
+}
+
+var (
+	_ graphql.HandlerExtension = auditLog{}
+	_ graphql.FieldInterceptor = auditLog{}
+)
+
+// AuditExtension returns a gqlgen extension recording each successful
+// mutation in the audit log. The action is named after the mutation, and
+// changes are read from the target before and after the mutation when the
+// service can snapshot it, and are otherwise the mutation's result.
+func AuditExtension(service *auditApp.AuditService, logger *logging.Logger) graphql.HandlerExtension {
+	return auditLog{service: service, logger: logger}
+}
+
+// ExtensionName identifies the extension
+func (auditLog) ExtensionName() string {
+	return "AuditLog"
+}
+
+// Validate accepts any schema
+func (auditLog) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField records mutations
+func (a auditLog) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	field := graphql.GetFieldContext(ctx)
+	if field == nil || field.Object != "Mutation" {
+		return next(ctx)
+	}
+
+	name := field.Field.Name
+	spec := auditTargets[name]
+	targetID := auditTargetID(spec, field.Args)
+	log := a.logger.WithFields(map[string]interface{}{"mutation": name})
+
+	before, err := a.service.Snapshot(ctx, spec.Type, targetID)
+	if err != nil {
+		log.WithError(err).Debug("Failed to snapshot audit target")
+	}
+	// GraphQL may identify projects by database ID; record their project ID
+	if projectID, ok := before["ProjectID"].(string); ok && spec.Type == auditDomain.TargetProject {
+		targetID = projectID
+	}
+
+	result, err := next(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	resultSnapshot, _ := auditDomain.NewSnapshot(result)
+	if targetID == "" {
+		targetID = resultID(resultSnapshot, spec.Type)
+	}
+
+	var after auditDomain.Snapshot
+	switch _, isBool := result.(bool); {
+	case a.service.CanSnapshot(spec.Type):
+		if after, err = a.service.Snapshot(ctx, spec.Type, targetID); err != nil {
+			log.WithError(err).Debug("Failed to snapshot audit target")
+		}
+	case spec.Type == "":
+		// Mutations without a target record their arguments instead
+		after, _ = auditDomain.NewSnapshot(map[string]interface{}(field.Args))
+	case !isBool:
+		after = resultSnapshot
+	}
+
+	entry := &auditDomain.Entry{
+		Action:     auditInterfaces.SnakeCase(name),
+		TargetType: spec.Type,
+		TargetID:   targetID,
+		Changes:    auditDomain.Diff(before, after),
+		Source:     auditDomain.SourceGraphQL,
+	}
+	if err := a.service.Record(ctx, entry); err != nil {
+		log.WithError(err).Error("Failed to record audit entry")
+	}
+	return result, nil
+}
+
+// auditTargetID returns the ID of a mutation's target from its arguments, or
+// an empty string when the mutation creates the target
+func auditTargetID(spec auditTarget, args map[string]interface{}) string {
+	if spec.Type == auditDomain.TargetProjectAccess {
+		return projectAccessID(args)
+	}
+	if spec.Arg == "" {
+		return ""
+	}
+	return argString(args[spec.Arg])
+}
+
+// projectAccessID identifies the access of a user or group to a project as the
+// REST API does, from the arguments or the input of a project access mutation
+func projectAccessID(args map[string]interface{}) string {
+	fields := args
+	if input, ok := args["input"]; ok {
+		snapshot, _ := auditDomain.NewSnapshot(input)
+		fields = snapshot
+	}
+
+	projectID := argString(fields["projectId"])
+	if userID := argString(fields["userId"]); userID != "" {
+		return projectID + "/users/" + userID
+	}
+	if groupName := argString(fields["groupName"]); groupName != "" {
+		return projectID + "/groups/" + groupName
+	}
+	return ""
+}
+
+// resultID reads the ID of a mutation's target from its result
+func resultID(result auditDomain.Snapshot, targetType string) string {
+	if targetType == auditDomain.TargetProject {
+		if projectID := argString(result["projectId"]); projectID != "" {
+			return projectID
+		}
+	}
+	return argString(result["id"])
+}
+
+// argString formats an ID argument, which may be optional
+func argString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	auditApp "github.com/guidewire-oss/fern-platform/internal/domains/audit/application"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/model"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// memoryAuditRepository keeps audit entries in memory
+type memoryAuditRepository struct {
+	entries []*auditDomain.Entry
+}
+
+func (r *memoryAuditRepository) Append(ctx context.Context, entry *auditDomain.Entry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) Find(ctx context.Context, filter auditDomain.Filter) ([]*auditDomain.Entry, int64, error) {
+	return r.entries, int64(len(r.entries)), nil
+}
+
+func (r *memoryAuditRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+// interceptMutation runs a mutation resolver through the audit extension
+func interceptMutation(t *testing.T, service *auditApp.AuditService, name string, args map[string]interface{}, resolver graphql.Resolver) error {
+	logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+	require.NoError(t, err)
+
+	ctx := auditDomain.WithRequestInfo(context.Background(), auditDomain.RequestInfo{ActorID: "admin-1", RequestID: "req-1"})
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Mutation",
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: name}},
+		Args:   args,
+	})
+	_, err = AuditExtension(service, logger).(graphql.FieldInterceptor).InterceptField(ctx, resolver)
+	return err
+}
+
+func TestAuditExtension_RecordsMutations(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	service := auditApp.NewAuditService(repo, 0)
+	tagName := "smoke"
+	service.RegisterSnapshotter(auditDomain.TargetTag, func(ctx context.Context, id string) (interface{}, error) {
+		return map[string]interface{}{"id": id, "name": tagName}, nil
+	})
+
+	err := interceptMutation(t, service, "updateTag", map[string]interface{}{"id": "t1"}, func(ctx context.Context) (interface{}, error) {
+		tagName = "regression"
+		return &model.Tag{ID: "t1", Name: tagName}, nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, "update_tag", entry.Action)
+	assert.Equal(t, auditDomain.TargetTag, entry.TargetType)
+	assert.Equal(t, "t1", entry.TargetID)
+	assert.Equal(t, auditDomain.SourceGraphQL, entry.Source)
+	assert.Equal(t, "admin-1", entry.ActorID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, auditDomain.Changes{"name": {Before: "smoke", After: "regression"}}, entry.Changes)
+}
+
+func TestAuditExtension_IdentifiesTargets(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	service := auditApp.NewAuditService(repo, 0)
+
+	userID := "dana"
+	require.NoError(t, interceptMutation(t, service, "revokeProjectAccess",
+		map[string]interface{}{"projectId": "shop", "userId": &userID, "groupName": (*string)(nil)},
+		func(ctx context.Context) (interface{}, error) { return true, nil }))
+
+	require.NoError(t, interceptMutation(t, service, "grantProjectAccess",
+		map[string]interface{}{"input": model.GrantProjectAccessInput{ProjectID: "shop", GroupName: &userID, Permission: "read"}},
+		func(ctx context.Context) (interface{}, error) { return &model.ProjectAccess{}, nil }))
+
+	require.NoError(t, interceptMutation(t, service, "createIssueTrackerConnection", map[string]interface{}{},
+		func(ctx context.Context) (interface{}, error) {
+			return &model.IssueTrackerConnection{ID: "conn-7"}, nil
+		}))
+
+	require.Len(t, repo.entries, 3)
+	assert.Equal(t, "shop/users/dana", repo.entries[0].TargetID)
+	assert.Empty(t, repo.entries[0].Changes, "boolean results carry no state")
+	assert.Equal(t, "shop/groups/dana", repo.entries[1].TargetID)
+	assert.Equal(t, auditDomain.TargetTrackerConnection, repo.entries[2].TargetType)
+	assert.Equal(t, "conn-7", repo.entries[2].TargetID)
+}
+
+func TestAuditExtension_SkipsFailuresAndQueries(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	service := auditApp.NewAuditService(repo, 0)
+
+	err := interceptMutation(t, service, "deleteTag", map[string]interface{}{"id": "t1"}, func(ctx context.Context) (interface{}, error) {
+		return false, errors.New("forbidden")
+	})
+	assert.Error(t, err)
+
+	logger, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+	require.NoError(t, err)
+	ctx := graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+		Object: "Query",
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: "tags"}},
+	})
+	_, err = AuditExtension(service, logger).(graphql.FieldInterceptor).InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, repo.entries)
+}
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,9 +10,11 @@ import (
 
 	"github.com/google/uuid"
 	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
 	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
 	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
 	tagsDomain "github.com/guidewire-oss/fern-platform/internal/domains/tags/domain"
+	testingApp "github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
 	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/model"
 )
@@ -168,12 +171,21 @@ func (r *Resolver) convertSpecRunToGraphQL(spec *testingDomain.SpecRun) *model.S
 		StackTrace:   stackTrace,
 		RetryCount:   spec.RetryCount,
 		IsFlaky:      spec.IsFlaky,
+		Owners:       convertOwners(spec.Owners),
 		Tags:         tags,
 		CreatedAt:    spec.StartTime,
 		UpdatedAt:    spec.StartTime,
 	}
 }
 
+// convertOwners returns a non-nil owner list for GraphQL
+func convertOwners(owners []string) []string {
+	if owners == nil {
+		return []string{}
+	}
+	return owners
+}
+
 // RecentTestRuns_domain retrieves recent test runs using domain service
 func (r *queryResolver) RecentTestRuns_domain(ctx context.Context, projectID *string, limit *int) ([]*model.TestRun, error) {
 	limitVal := 10
@@ -219,7 +231,68 @@ func (r *Resolver) convertProjectToGraphQL(project *projectsDomain.Project) *mod
 		Team:          convertStringPtr(string(snapshot.Team)),
 		CreatedAt:     snapshot.CreatedAt,
 		UpdatedAt:     snapshot.UpdatedAt,
+
+		FlakyDetectionSettings: convertFlakyDetectionSettingsToGraphQL(project),
+		OwnershipRules:         convertOwnershipRulesToGraphQL(project),
+		JiraSync:               convertJiraSyncSettingsToGraphQL(project),
+	}
+}
+
+// convertJiraSyncSettingsToGraphQL returns the project's effective JIRA sync settings
+func convertJiraSyncSettingsToGraphQL(project *projectsDomain.Project) *model.JiraSyncSettings {
+	// Unreadable settings fall back to the defaults, as the sync job does
+	settings, _ := project.JiraSyncSettings()
+	return &model.JiraSyncSettings{
+		ReflakeAction: string(settings.EffectiveReflakeAction()),
+	}
+}
+
+// convertOwnershipRulesToGraphQL returns the project's raw ownership rules, if any
+func convertOwnershipRulesToGraphQL(project *projectsDomain.Project) *string {
+	rules, err := project.OwnershipRules()
+	if err != nil || rules.IsEmpty() {
+		return nil
+	}
+	source := rules.Source()
+	return &source
+}
+
+// convertFlakyDetectionSettingsToGraphQL converts a project's flaky detection overrides
+func convertFlakyDetectionSettingsToGraphQL(project *projectsDomain.Project) *model.FlakyDetectionSettings {
+	settings, err := project.FlakyDetectionSettings()
+	if err != nil || settings.IsEmpty() {
+		return nil
+	}
+
+	result := &model.FlakyDetectionSettings{
+		MinimumRuns:                    settings.MinimumRuns,
+		MinFailureRate:                 settings.MinFailureRate,
+		MaxFailureRate:                 settings.MaxFailureRate,
+		ConsecutivePassesForResolution: settings.ConsecutivePassesForResolution,
+	}
+	if settings.AnalysisWindow != nil {
+		window := settings.AnalysisWindow.String()
+		result.AnalysisWindow = &window
 	}
+	return result
+}
+
+// convertFlakyDetectionSettingsInput converts GraphQL input to domain flaky detection overrides
+func convertFlakyDetectionSettingsInput(input *model.FlakyDetectionSettingsInput) (*projectsDomain.FlakyDetectionSettings, error) {
+	settings := &projectsDomain.FlakyDetectionSettings{
+		MinimumRuns:                    input.MinimumRuns,
+		MinFailureRate:                 input.MinFailureRate,
+		MaxFailureRate:                 input.MaxFailureRate,
+		ConsecutivePassesForResolution: input.ConsecutivePassesForResolution,
+	}
+	if input.AnalysisWindow != nil {
+		window, err := time.ParseDuration(*input.AnalysisWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid analysisWindow: %w", err)
+		}
+		settings.AnalysisWindow = &window
+	}
+	return settings, nil
 }
 
 // convertTagToGraphQL converts a domain tag to GraphQL model
@@ -520,6 +593,14 @@ func (r *mutationResolver) UpdateProject_domain(ctx context.Context, id string,
 		}
 	}
 
+	// Then check permissions granted on the project
+	if !canUpdate {
+		canUpdate, err = r.hasProjectPermission(ctx, user, projectID, projectsDomain.PermissionWrite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check project permissions: %w", err)
+		}
+	}
+
 	if !canUpdate {
 		r.logger.WithFields(map[string]interface{}{
 			"user_id":      user.UserID,
@@ -549,6 +630,23 @@ func (r *mutationResolver) UpdateProject_domain(ctx context.Context, id string,
 		team := projectsDomain.Team(*input.Team)
 		updateReq.Team = &team
 	}
+	if input.FlakyDetection != nil {
+		flakyDetection, err := convertFlakyDetectionSettingsInput(input.FlakyDetection)
+		if err != nil {
+			return nil, err
+		}
+		updateReq.FlakyDetection = flakyDetection
+	}
+	if input.OwnershipRules != nil {
+		updateReq.OwnershipRules = input.OwnershipRules
+	}
+	if input.JiraSync != nil {
+		jiraSync := projectsDomain.JiraSyncSettings{}
+		if input.JiraSync.ReflakeAction != nil {
+			jiraSync.ReflakeAction = projectsDomain.ReflakeAction(*input.JiraSync.ReflakeAction)
+		}
+		updateReq.JiraSync = &jiraSync
+	}
 
 	// Update the project
 	r.logger.WithFields(map[string]interface{}{
@@ -680,6 +778,14 @@ func (r *mutationResolver) DeleteProject_domain(ctx context.Context, id string)
 		}
 	}
 
+	// Then check permissions granted on the project
+	if !canDelete {
+		canDelete, err = r.hasProjectPermission(ctx, user, projectID, projectsDomain.PermissionDelete)
+		if err != nil {
+			return false, fmt.Errorf("failed to check project permissions: %w", err)
+		}
+	}
+
 	if !canDelete {
 		return false, fmt.Errorf("insufficient permissions to delete project")
 	}
@@ -1207,3 +1313,1045 @@ func (r *queryResolver) Tags_domain(ctx context.Context, filter *model.TagFilter
 		TotalCount: len(filteredTags),
 	}, nil
 }
+
+// convertFlakyTestToGraphQL converts a domain flaky test to GraphQL model
+func (r *Resolver) convertFlakyTestToGraphQL(flakyTest *testingDomain.FlakyTest) *model.FlakyTest {
+	snapshot := flakyTest.ToSnapshot()
+	statusChangedAt := snapshot.StatusChangedAt
+
+	updatedAt := snapshot.LastSeenAt
+	if statusChangedAt.After(updatedAt) {
+		updatedAt = statusChangedAt
+	}
+
+	return &model.FlakyTest{
+		ID:                strconv.FormatUint(uint64(snapshot.ID), 10),
+		ProjectID:         snapshot.ProjectID,
+		TestName:          snapshot.TestName,
+		SuiteName:         convertStringPtr(snapshot.SuiteName),
+		FlakeRate:         snapshot.FlakeRate,
+		TotalExecutions:   snapshot.TotalExecutions,
+		FlakyExecutions:   snapshot.FlakyExecutions,
+		LastSeenAt:        snapshot.LastSeenAt,
+		FirstSeenAt:       snapshot.FirstSeenAt,
+		Status:            string(snapshot.Status),
+		Severity:          string(snapshot.Severity),
+		LastErrorMessage:  convertStringPtr(snapshot.LastErrorMessage),
+		ConsecutivePasses: snapshot.ConsecutivePasses,
+		StatusChangedAt:   &statusChangedAt,
+		Owners:            convertOwners(snapshot.Owners),
+		CreatedAt:         snapshot.FirstSeenAt,
+		UpdatedAt:         updatedAt,
+	}
+}
+
+// parseFlakyTestID parses a GraphQL flaky test ID
+func parseFlakyTestID(id string) (uint, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid flaky test ID: %w", err)
+	}
+	return uint(idUint), nil
+}
+
+// FlakyTest implementation using the flaky lifecycle service
+func (r *queryResolver) FlakyTest_domain(ctx context.Context, id string) (*model.FlakyTest, error) {
+	flakyTestID, err := parseFlakyTestID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	flakyTest, err := r.flakyLifecycleService.GetFlakyTest(ctx, flakyTestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.convertFlakyTestToGraphQL(flakyTest), nil
+}
+
+// FlakyTests implementation using the flaky lifecycle service with pagination
+func (r *queryResolver) FlakyTests_domain(ctx context.Context, filter *model.FlakyTestFilter, first *int, after *string) (*model.FlakyTestConnection, error) {
+	domainFilter := testingDomain.FlakyTestFilter{}
+	var maxFlakeRate *float64
+	if filter != nil {
+		domainFilter.ProjectID = getStringValue(filter.ProjectID)
+		domainFilter.Owner = getStringValue(filter.Owner)
+		if filter.Status != nil && *filter.Status != "" {
+			domainFilter.Statuses = []testingDomain.FlakyStatus{testingDomain.FlakyStatus(*filter.Status)}
+		}
+		if filter.Severity != nil && *filter.Severity != "" {
+			domainFilter.Severities = []testingDomain.FlakySeverity{testingDomain.FlakySeverity(*filter.Severity)}
+		}
+		if filter.MinFlakeRate != nil {
+			domainFilter.MinFlakeRate = *filter.MinFlakeRate
+		}
+		maxFlakeRate = filter.MaxFlakeRate
+	}
+
+	flakyTests, err := r.flakyLifecycleService.ListFlakyTests(ctx, domainFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flaky tests: %w", err)
+	}
+
+	if maxFlakeRate != nil {
+		filtered := make([]*testingDomain.FlakyTest, 0, len(flakyTests))
+		for _, flakyTest := range flakyTests {
+			if flakyTest.FlakeRate() <= *maxFlakeRate {
+				filtered = append(filtered, flakyTest)
+			}
+		}
+		flakyTests = filtered
+	}
+
+	// Apply pagination
+	pageSize := 20
+	if first != nil && *first > 0 && *first <= 100 {
+		pageSize = *first
+	}
+
+	offset := 0
+	if after != nil && *after != "" {
+		// Simple cursor: just the index
+		if idx, err := strconv.Atoi(*after); err == nil && idx >= 0 {
+			offset = idx + 1
+		}
+	}
+
+	start := offset
+	end := offset + pageSize
+	if start > len(flakyTests) {
+		start = len(flakyTests)
+	}
+	if end > len(flakyTests) {
+		end = len(flakyTests)
+	}
+
+	// Build edges
+	edges := make([]*model.FlakyTestEdge, 0, end-start)
+	for i, flakyTest := range flakyTests[start:end] {
+		edges = append(edges, &model.FlakyTestEdge{
+			Node:   r.convertFlakyTestToGraphQL(flakyTest),
+			Cursor: fmt.Sprintf("%d", start+i),
+		})
+	}
+
+	// Build page info
+	pageInfo := &model.PageInfo{
+		HasNextPage:     end < len(flakyTests),
+		HasPreviousPage: offset > 0,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.FlakyTestConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: len(flakyTests),
+	}, nil
+}
+
+// Failures implementation using the testing service
+func (r *queryResolver) Failures_domain(ctx context.Context, projectID string, owner *string, days *int, limit *int) ([]*model.SpecFailure, error) {
+	daysVal := 7
+	if days != nil && *days > 0 {
+		daysVal = *days
+	}
+	limitVal := 50
+	if limit != nil && *limit > 0 && *limit <= 500 {
+		limitVal = *limit
+	}
+
+	failures, err := r.testingService.ListFailures(ctx, testingDomain.FailureFilter{
+		ProjectID: projectID,
+		Owner:     getStringValue(owner),
+		Since:     time.Now().AddDate(0, 0, -daysVal),
+		Limit:     limitVal,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failures: %w", err)
+	}
+
+	result := make([]*model.SpecFailure, len(failures))
+	for i, failure := range failures {
+		result[i] = &model.SpecFailure{
+			SpecRun:   r.convertSpecRunToGraphQL(failure.SpecRun),
+			SuiteName: failure.SuiteName,
+			TestRunID: failure.TestRunID,
+			ProjectID: failure.ProjectID,
+			Branch:    convertStringPtr(failure.Branch),
+		}
+	}
+	return result, nil
+}
+
+// SimilarFailures implementation using the similar failure service
+func (r *queryResolver) SimilarFailures_domain(ctx context.Context, specRunID string, limit *int) ([]*model.SimilarFailure, error) {
+	canRead, err := r.projectReadChecker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.similarFailureService == nil {
+		return nil, fmt.Errorf("similar failure search is not available")
+	}
+
+	id, err := strconv.ParseUint(specRunID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec run ID: %w", err)
+	}
+
+	limitVal := testingApp.DefaultSimilarFailureLimit
+	if limit != nil && *limit > 0 {
+		limitVal = *limit
+	}
+
+	similar, err := r.similarFailureService.FindSimilar(ctx, uint(id), limitVal, canRead)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.SimilarFailure, len(similar))
+	for i, match := range similar {
+		result[i] = &model.SimilarFailure{
+			Failure: &model.SpecFailure{
+				SpecRun:   r.convertSpecRunToGraphQL(match.Failure.SpecRun),
+				SuiteName: match.Failure.SuiteName,
+				TestRunID: match.Failure.TestRunID,
+				ProjectID: match.Failure.ProjectID,
+				Branch:    convertStringPtr(match.Failure.Branch),
+			},
+			Score: match.Score,
+		}
+	}
+	return result, nil
+}
+
+// projectReadChecker returns a check of whether the current user may read a project.
+// Admins read every project; others need a matching team group or a project read scope.
+// Results are memoized, so the check is meant for the lifetime of a single request.
+func (r *Resolver) projectReadChecker(ctx context.Context) (func(projectID string) bool, error) {
+	user, err := getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == authDomain.RoleAdmin {
+		return func(string) bool { return true }, nil
+	}
+
+	teams := make(map[string]bool)
+	for _, team := range getUserTeamsFromContext(ctx) {
+		teams[team] = true
+	}
+	scopes := getUserScopesFromContext(ctx)
+
+	decisions := make(map[string]bool)
+	return func(projectID string) bool {
+		if allowed, ok := decisions[projectID]; ok {
+			return allowed
+		}
+
+		allowed := false
+		for _, scope := range scopes {
+			if matchScope(scope, fmt.Sprintf("project:read:%s", projectID)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			project, err := r.projectService.GetProject(ctx, projectsDomain.ProjectID(projectID))
+			allowed = err == nil && teams[string(project.Team())]
+		}
+		if !allowed {
+			granted, err := r.hasProjectPermission(ctx, user, projectsDomain.ProjectID(projectID), projectsDomain.PermissionRead)
+			allowed = err == nil && granted
+		}
+
+		decisions[projectID] = allowed
+		return allowed
+	}, nil
+}
+
+// ExplainFailure implementation using the failure explanation service
+func (r *queryResolver) ExplainFailure_domain(ctx context.Context, specRunID string) (*model.FailureExplanation, error) {
+	return r.explainFailures(ctx, []string{specRunID})
+}
+
+// ExplainFailureCluster implementation using the failure explanation service
+func (r *queryResolver) ExplainFailureCluster_domain(ctx context.Context, specRunIDs []string) (*model.FailureExplanation, error) {
+	return r.explainFailures(ctx, specRunIDs)
+}
+
+func (r *queryResolver) explainFailures(ctx context.Context, specRunIDs []string) (*model.FailureExplanation, error) {
+	canRead, err := r.projectReadChecker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.explanationService == nil {
+		return nil, fmt.Errorf("failure explanations are not configured")
+	}
+
+	ids := make([]uint, len(specRunIDs))
+	for i, id := range specRunIDs {
+		idUint, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec run ID: %w", err)
+		}
+		ids[i] = uint(idUint)
+	}
+
+	failures, err := r.explanationService.GetFailures(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	// Report inaccessible spec runs as missing so their existence is not revealed
+	for _, failure := range failures {
+		if !canRead(failure.ProjectID) {
+			return nil, fmt.Errorf("spec run %d not found", failure.SpecRun.ID)
+		}
+	}
+
+	explanation, err := r.explanationService.Explain(ctx, failures)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to explain failure")
+		return nil, err
+	}
+
+	return &model.FailureExplanation{
+		SpecRunIds:  specRunIDs,
+		Summary:     explanation.Summary,
+		Provider:    explanation.Provider,
+		Model:       explanation.Model,
+		Cached:      explanation.Cached,
+		Truncated:   explanation.Truncated,
+		GeneratedAt: explanation.GeneratedAt,
+	}, nil
+}
+
+// StatusHistory implementation using the flaky lifecycle service
+func (r *flakyTestResolver) StatusHistory_domain(ctx context.Context, obj *model.FlakyTest) ([]*model.FlakyTestStatusChange, error) {
+	flakyTestID, err := parseFlakyTestID(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := r.flakyLifecycleService.GetStatusHistory(ctx, flakyTestID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.FlakyTestStatusChange, len(changes))
+	for i, change := range changes {
+		result[i] = &model.FlakyTestStatusChange{
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			ActorType:  string(change.Actor.Type),
+			ActorID:    change.Actor.ID,
+			Reason:     convertStringPtr(change.Reason),
+			ChangedAt:  change.ChangedAt,
+		}
+	}
+	return result, nil
+}
+
+// MarkFlakyTestResolved implementation using the flaky lifecycle service
+func (r *mutationResolver) MarkFlakyTestResolved_domain(ctx context.Context, id string, reason *string) (*model.FlakyTest, error) {
+	user, flakyTestID, err := r.authorizeFlakyTestChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	flakyTest, err := r.flakyLifecycleService.ResolveFlakyTest(ctx, flakyTestID, user.UserID, getStringValue(reason))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.convertFlakyTestToGraphQL(flakyTest), nil
+}
+
+// ReactivateFlakyTest implementation using the flaky lifecycle service
+func (r *mutationResolver) ReactivateFlakyTest_domain(ctx context.Context, id string, reason *string) (*model.FlakyTest, error) {
+	user, flakyTestID, err := r.authorizeFlakyTestChange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	flakyTest, err := r.flakyLifecycleService.ReactivateFlakyTest(ctx, flakyTestID, user.UserID, getStringValue(reason))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.convertFlakyTestToGraphQL(flakyTest), nil
+}
+
+// authorizeFlakyTestChange checks that the current user can manage the flaky test's project
+func (r *mutationResolver) authorizeFlakyTestChange(ctx context.Context, id string) (*authDomain.User, uint, error) {
+	user, err := getCurrentUser(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	flakyTestID, err := parseFlakyTestID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	flakyTest, err := r.flakyLifecycleService.GetFlakyTest(ctx, flakyTestID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	project, err := r.projectService.GetProject(ctx, projectsDomain.ProjectID(flakyTest.ProjectID()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("project not found")
+	}
+
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canManage {
+		return nil, 0, fmt.Errorf("insufficient permissions to manage flaky tests in this project")
+	}
+
+	return user, flakyTestID, nil
+}
+
+// CreateJiraIssue implementation using the JIRA connection service
+func (r *mutationResolver) CreateJiraIssue_domain(ctx context.Context, input model.CreateJiraIssueInput) (*model.JiraIssueCreation, error) {
+	user, err := getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := integrations.IssueSourceRequest{Type: integrations.IssueSourceType(input.SourceType)}
+	for _, id := range input.SpecRunIds {
+		idUint, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec run ID: %w", err)
+		}
+		req.SpecRunIDs = append(req.SpecRunIDs, uint(idUint))
+	}
+	if input.FlakyTestID != nil {
+		if req.FlakyTestID, err = parseFlakyTestID(*input.FlakyTestID); err != nil {
+			return nil, err
+		}
+	}
+
+	project, err := r.projectService.GetProject(ctx, projectsDomain.ProjectID(input.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, fmt.Errorf("insufficient permissions to file issues in this project")
+	}
+
+	source, err := r.jiraConnectionService.LoadIssueSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if source.ProjectID != input.ProjectID {
+		return nil, fmt.Errorf("test results not found in project")
+	}
+
+	creation, err := r.jiraConnectionService.CreateIssue(ctx, source, user.UserID)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to create JIRA issue")
+		return nil, err
+	}
+
+	links := make([]*model.JiraIssueLink, len(creation.Links))
+	for i, link := range creation.Links {
+		links[i] = r.convertJiraIssueLinkToModel(link)
+	}
+	return &model.JiraIssueCreation{
+		IssueKey: creation.IssueKey,
+		IssueURL: creation.IssueURL,
+		Created:  creation.Created,
+		Links:    links,
+	}, nil
+}
+
+// JiraIssueLinks implementation using the JIRA connection service
+func (r *queryResolver) JiraIssueLinks_domain(ctx context.Context, projectID string) ([]*model.JiraIssueLink, error) {
+	canRead, err := r.projectReadChecker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead(projectID) {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	links, err := r.jiraConnectionService.GetIssueLinks(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.JiraIssueLink, len(links))
+	for i, link := range links {
+		result[i] = r.convertJiraIssueLinkToModel(link)
+	}
+	return result, nil
+}
+
+// JiraIssue implementation returning the issue filed for a flaky test, if any
+func (r *flakyTestResolver) JiraIssue_domain(ctx context.Context, obj *model.FlakyTest) (*model.JiraIssueLink, error) {
+	if r.jiraConnectionService == nil {
+		return nil, nil
+	}
+
+	flakyTestID, err := parseFlakyTestID(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := integrations.FlakyTestTarget(flakyTestID, getStringValue(obj.SuiteName), obj.TestName)
+	link, err := r.jiraConnectionService.GetIssueLink(ctx, obj.ProjectID, target)
+	if errors.Is(err, integrations.ErrIssueCreationNotConfigured) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.convertJiraIssueLinkToModel(link), nil
+}
+
+// JiraFields implementation listing the fields of a connection's JIRA instance
+func (r *queryResolver) JiraFields_domain(ctx context.Context, connectionID string, refresh *bool) ([]*model.JiraField, error) {
+	if _, err := r.authorizeJiraConnection(ctx, connectionID, true); err != nil {
+		return nil, err
+	}
+
+	fields, err := r.jiraConnectionService.GetJiraFields(ctx, connectionID, refresh != nil && *refresh)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to get JIRA fields")
+		return nil, err
+	}
+
+	result := make([]*model.JiraField, len(fields))
+	for i, field := range fields {
+		result[i] = &model.JiraField{
+			ID:          field.ID,
+			Name:        field.Name,
+			Custom:      field.Custom,
+			SchemaType:  field.SchemaType,
+			SchemaItems: convertStringPtr(field.SchemaItems),
+		}
+	}
+	return result, nil
+}
+
+// JiraIssueTypes implementation listing the issue types of a connection's JIRA instance
+func (r *queryResolver) JiraIssueTypes_domain(ctx context.Context, connectionID string, refresh *bool) ([]*model.JiraIssueType, error) {
+	if _, err := r.authorizeJiraConnection(ctx, connectionID, true); err != nil {
+		return nil, err
+	}
+
+	issueTypes, err := r.jiraConnectionService.GetJiraIssueTypes(ctx, connectionID, refresh != nil && *refresh)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to get JIRA issue types")
+		return nil, err
+	}
+
+	result := make([]*model.JiraIssueType, len(issueTypes))
+	for i, issueType := range issueTypes {
+		result[i] = &model.JiraIssueType{
+			ID:          issueType.ID,
+			Name:        issueType.Name,
+			Description: convertStringPtr(issueType.Description),
+			IconURL:     convertStringPtr(issueType.IconURL),
+			Subtask:     issueType.Subtask,
+		}
+	}
+	return result, nil
+}
+
+// JiraFieldMapping implementation returning the saved or default field mapping of a connection
+func (r *queryResolver) JiraFieldMapping_domain(ctx context.Context, connectionID string) (*model.JiraFieldMapping, error) {
+	if _, err := r.authorizeJiraConnection(ctx, connectionID, false); err != nil {
+		return nil, err
+	}
+
+	mapping, err := r.jiraConnectionService.GetFieldMapping(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	return r.convertJiraFieldMappingToModel(mapping), nil
+}
+
+// UpdateJiraFieldMapping implementation validating and saving a connection's field mapping
+func (r *mutationResolver) UpdateJiraFieldMapping_domain(ctx context.Context, connectionID string, input model.UpdateJiraFieldMappingInput) (*model.JiraFieldMapping, error) {
+	user, err := r.authorizeJiraConnection(ctx, connectionID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[integrations.FernField]string, len(input.Fields))
+	for _, entry := range input.Fields {
+		if _, ok := fields[integrations.FernField(entry.FernField)]; ok {
+			return nil, fmt.Errorf("Fern field %q is mapped more than once", entry.FernField)
+		}
+		fields[integrations.FernField(entry.FernField)] = entry.JiraFieldID
+	}
+
+	mapping, err := r.jiraConnectionService.UpdateFieldMapping(ctx, connectionID, input.IssueType, fields, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return r.convertJiraFieldMappingToModel(mapping), nil
+}
+
+// authorizeJiraConnection checks that the current user can read, or with
+// manage set manage, the project a JIRA connection belongs to
+func (r *Resolver) authorizeJiraConnection(ctx context.Context, connectionID string, manage bool) (*authDomain.User, error) {
+	user, err := getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err := r.jiraConnectionService.GetConnection(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("connection not found")
+	}
+
+	if !manage {
+		canRead, err := r.projectReadChecker(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !canRead(connection.ProjectID()) {
+			return nil, fmt.Errorf("connection not found")
+		}
+		return user, nil
+	}
+
+	project, err := r.projectService.GetProject(ctx, projectsDomain.ProjectID(connection.ProjectID()))
+	if err != nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, fmt.Errorf("insufficient permissions to manage JIRA integrations in this project")
+	}
+	return user, nil
+}
+
+// IssueTrackerTypes implementation listing the registered connector types
+func (r *queryResolver) IssueTrackerTypes_domain(ctx context.Context) ([]*model.IssueTrackerType, error) {
+	if _, err := getCurrentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	definitions := r.trackerConnectionService.ConnectorTypes()
+	result := make([]*model.IssueTrackerType, len(definitions))
+	for i, definition := range definitions {
+		result[i] = convertConnectorDefinitionToModel(definition)
+	}
+	return result, nil
+}
+
+// IssueTrackerConnections implementation listing the issue tracker connections of a project
+func (r *queryResolver) IssueTrackerConnections_domain(ctx context.Context, projectID string) ([]*model.IssueTrackerConnection, error) {
+	if err := r.authorizeTrackerProject(ctx, projectID, false); err != nil {
+		return nil, err
+	}
+
+	connections, err := r.trackerConnectionService.GetProjectConnections(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.IssueTrackerConnection, len(connections))
+	for i, conn := range connections {
+		result[i] = convertTrackerConnectionToModel(conn)
+	}
+	return result, nil
+}
+
+// CreateIssueTrackerConnection implementation creating a connection of any registered connector type
+func (r *mutationResolver) CreateIssueTrackerConnection_domain(ctx context.Context, input model.CreateIssueTrackerConnectionInput) (*model.IssueTrackerConnection, error) {
+	if err := r.authorizeTrackerProject(ctx, input.ProjectID, true); err != nil {
+		return nil, err
+	}
+
+	config, err := trackerConfigFromInput(input.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := r.trackerConnectionService.CreateConnection(
+		ctx,
+		input.ProjectID,
+		input.Name,
+		integrations.ConnectorType(input.ConnectorType),
+		config,
+		input.Credential,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return convertTrackerConnectionToModel(conn), nil
+}
+
+// UpdateIssueTrackerConnection implementation renaming a connection and replacing its config
+func (r *mutationResolver) UpdateIssueTrackerConnection_domain(ctx context.Context, id string, input model.UpdateIssueTrackerConnectionInput) (*model.IssueTrackerConnection, error) {
+	if _, err := r.authorizeTrackerConnection(ctx, id, true); err != nil {
+		return nil, err
+	}
+
+	config, err := trackerConfigFromInput(input.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := r.trackerConnectionService.UpdateConnection(ctx, id, input.Name, config)
+	if err != nil {
+		return nil, err
+	}
+	return convertTrackerConnectionToModel(conn), nil
+}
+
+// UpdateIssueTrackerCredential implementation replacing the credential of a connection
+func (r *mutationResolver) UpdateIssueTrackerCredential_domain(ctx context.Context, id string, credential string) (*model.IssueTrackerConnection, error) {
+	if _, err := r.authorizeTrackerConnection(ctx, id, true); err != nil {
+		return nil, err
+	}
+
+	conn, err := r.trackerConnectionService.UpdateCredential(ctx, id, credential)
+	if err != nil {
+		return nil, err
+	}
+	return convertTrackerConnectionToModel(conn), nil
+}
+
+// TestIssueTrackerConnection implementation testing a connection. A failed
+// test is reported through the returned connection's status and lastError.
+func (r *mutationResolver) TestIssueTrackerConnection_domain(ctx context.Context, id string) (*model.IssueTrackerConnection, error) {
+	if _, err := r.authorizeTrackerConnection(ctx, id, true); err != nil {
+		return nil, err
+	}
+
+	if err := r.trackerConnectionService.TestConnection(ctx, id); err != nil {
+		r.logger.Errorf("TestIssueTrackerConnection failed: %v", err)
+	}
+
+	conn, err := r.trackerConnectionService.GetConnection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return convertTrackerConnectionToModel(conn), nil
+}
+
+// SetIssueTrackerConnectionActive implementation activating or deactivating a connection
+func (r *mutationResolver) SetIssueTrackerConnectionActive_domain(ctx context.Context, id string, active bool) (*model.IssueTrackerConnection, error) {
+	if _, err := r.authorizeTrackerConnection(ctx, id, true); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if active {
+		err = r.trackerConnectionService.ActivateConnection(ctx, id)
+	} else {
+		err = r.trackerConnectionService.DeactivateConnection(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := r.trackerConnectionService.GetConnection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return convertTrackerConnectionToModel(conn), nil
+}
+
+// DeleteIssueTrackerConnection implementation deleting a connection
+func (r *mutationResolver) DeleteIssueTrackerConnection_domain(ctx context.Context, id string) (bool, error) {
+	if _, err := r.authorizeTrackerConnection(ctx, id, true); err != nil {
+		return false, err
+	}
+
+	if err := r.trackerConnectionService.DeleteConnection(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// authorizeTrackerConnection loads a connection and checks that the current
+// user can read, or with manage set manage, the project it belongs to
+func (r *Resolver) authorizeTrackerConnection(ctx context.Context, connectionID string, manage bool) (*integrations.TrackerConnection, error) {
+	if _, err := getCurrentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	conn, err := r.trackerConnectionService.GetConnection(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("connection not found")
+	}
+	if err := r.authorizeTrackerProject(ctx, conn.ProjectID(), manage); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// authorizeTrackerProject checks that the current user can read, or with
+// manage set manage, the issue tracker connections of a project
+func (r *Resolver) authorizeTrackerProject(ctx context.Context, projectID string, manage bool) error {
+	if !manage {
+		canRead, err := r.projectReadChecker(ctx)
+		if err != nil {
+			return err
+		}
+		if !canRead(projectID) {
+			return fmt.Errorf("project not found")
+		}
+		return nil
+	}
+
+	if _, err := getCurrentUser(ctx); err != nil {
+		return err
+	}
+	project, err := r.projectService.GetProject(ctx, projectsDomain.ProjectID(projectID))
+	if err != nil {
+		return fmt.Errorf("project not found")
+	}
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return fmt.Errorf("insufficient permissions to manage issue tracker integrations in this project")
+	}
+	return nil
+}
+
+// requireAdmin returns the current user if they are an admin
+func requireAdmin(ctx context.Context) (*authDomain.User, error) {
+	user, err := getCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin() {
+		return nil, fmt.Errorf("admin access required")
+	}
+	return user, nil
+}
+
+// Users implementation listing user accounts for admins
+func (r *queryResolver) Users_domain(ctx context.Context, filter *model.UserFilter, first *int, after *string) (*model.UserConnection, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	userFilter := authDomain.UserFilter{Limit: 20}
+	if first != nil && *first > 0 {
+		userFilter.Limit = *first
+	}
+	if after != nil && *after != "" {
+		// Simple cursor: just the index
+		if idx, err := strconv.Atoi(*after); err == nil && idx >= 0 {
+			userFilter.Offset = idx + 1
+		}
+	}
+	if filter != nil {
+		userFilter.Search = convertPtrString(filter.Search)
+		userFilter.Role = authDomain.UserRole(convertPtrString(filter.Role))
+		userFilter.Status = authDomain.UserStatus(convertPtrString(filter.Status))
+	}
+
+	users, total, err := r.userAdminService.ListUsers(ctx, userFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.UserEdge, len(users))
+	for i, user := range users {
+		edges[i] = &model.UserEdge{
+			Node:   convertUserToModel(user),
+			Cursor: strconv.Itoa(userFilter.Offset + i),
+		}
+	}
+
+	pageInfo := &model.PageInfo{
+		HasNextPage:     int64(userFilter.Offset+len(users)) < total,
+		HasPreviousPage: userFilter.Offset > 0,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.UserConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: int(total),
+	}, nil
+}
+
+// User implementation getting a user account for admins
+func (r *queryResolver) User_domain(ctx context.Context, userID string) (*model.User, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := r.userAdminService.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, authDomain.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return convertUserToModel(user), nil
+}
+
+// UpdateUserRole implementation assigning a role to a user
+func (r *mutationResolver) UpdateUserRole_domain(ctx context.Context, userID string, role string) (*model.User, error) {
+	admin, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userAdminService.UpdateUserRole(ctx, admin.UserID, userID, authDomain.UserRole(role))
+	if err != nil {
+		return nil, err
+	}
+	return convertUserToModel(user), nil
+}
+
+// SuspendUser implementation suspending a user and ending their sessions
+func (r *mutationResolver) SuspendUser_domain(ctx context.Context, userID string) (*model.User, error) {
+	admin, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userAdminService.SuspendUser(ctx, admin.UserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertUserToModel(user), nil
+}
+
+// ActivateUser implementation reactivating a user
+func (r *mutationResolver) ActivateUser_domain(ctx context.Context, userID string) (*model.User, error) {
+	admin, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userAdminService.ActivateUser(ctx, admin.UserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertUserToModel(user), nil
+}
+
+// DeleteUser implementation soft-deleting a user and reassigning their resources
+func (r *mutationResolver) DeleteUser_domain(ctx context.Context, userID string, reassignTo *string) (bool, error) {
+	admin, err := requireAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.userAdminService.DeleteUser(ctx, admin.UserID, userID, convertPtrString(reassignTo)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// hasProjectPermission checks whether the user has been granted at least the
+// required permission on the project, directly or through one of their groups
+func (r *Resolver) hasProjectPermission(ctx context.Context, user *authDomain.User, projectID projectsDomain.ProjectID, required projectsDomain.PermissionType) (bool, error) {
+	groups := make([]string, len(user.Groups))
+	for i, group := range user.Groups {
+		groups[i] = group.GroupName
+	}
+	return r.projectService.HasPermission(ctx, projectID, user.UserID, groups, required)
+}
+
+// ProjectAccess implementation listing the permissions granted on a project for admins
+func (r *queryResolver) ProjectAccess_domain(ctx context.Context, projectID string) ([]*model.ProjectAccess, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	permissions, err := r.projectService.ListAccess(ctx, projectsDomain.ProjectID(projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ProjectAccess, len(permissions))
+	for i, permission := range permissions {
+		result[i] = convertProjectAccessToModel(permission)
+	}
+	return result, nil
+}
+
+// GrantProjectAccess implementation granting a permission on a project to a user or a group
+func (r *mutationResolver) GrantProjectAccess_domain(ctx context.Context, input model.GrantProjectAccessInput) (*model.ProjectAccess, error) {
+	admin, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, err := projectsDomain.ParsePermissionType(input.Permission)
+	if err != nil {
+		return nil, err
+	}
+
+	req := projectsApp.GrantAccessRequest{
+		ProjectID:  projectsDomain.ProjectID(input.ProjectID),
+		Permission: permission,
+		ExpiresAt:  input.ExpiresAt,
+		GrantedBy:  admin.UserID,
+	}
+	if input.UserID != nil {
+		req.UserID = *input.UserID
+	}
+	if input.GroupName != nil {
+		req.GroupName = *input.GroupName
+	}
+
+	granted, err := r.projectService.GrantAccess(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return convertProjectAccessToModel(granted), nil
+}
+
+// RevokeProjectAccess implementation revoking permissions on a project from a user or a group
+func (r *mutationResolver) RevokeProjectAccess_domain(ctx context.Context, projectID string, userID *string, groupName *string, permission *string) (bool, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return false, err
+	}
+	if (userID == nil) == (groupName == nil) {
+		return false, fmt.Errorf("%w: revoke from either a user or a group", projectsApp.ErrInvalidGrant)
+	}
+
+	var permissionType projectsDomain.PermissionType
+	if permission != nil {
+		parsed, err := projectsDomain.ParsePermissionType(*permission)
+		if err != nil {
+			return false, err
+		}
+		permissionType = parsed
+	}
+
+	var err error
+	if userID != nil {
+		err = r.projectService.RevokeUserAccess(ctx, projectsDomain.ProjectID(projectID), *userID, permissionType)
+	} else {
+		err = r.projectService.RevokeGroupAccess(ctx, projectsDomain.ProjectID(projectID), *groupName, permissionType)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
 	tagsDomain "github.com/guidewire-oss/fern-platform/internal/domains/tags/domain"
 	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/model"
@@ -675,3 +676,67 @@ func TestConvertTestRunToGraphQL_IDConversion(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestConvertJiraFieldMappingToModel(t *testing.T) {
+	resolver := setupTestResolver(t)
+
+	t.Run("default mapping", func(t *testing.T) {
+		result := resolver.convertJiraFieldMappingToModel(integrations.DefaultFieldMapping("conn-1", "proj-1"))
+
+		assert.Equal(t, "conn-1", result.ConnectionID)
+		assert.Equal(t, "Bug", result.IssueType)
+		assert.True(t, result.IsDefault)
+		assert.Nil(t, result.UpdatedAt)
+		assert.Nil(t, result.UpdatedBy)
+		assert.Equal(t, []*model.JiraFieldMappingEntry{
+			{FernField: "error", JiraFieldID: "description"},
+			{FernField: "test_name", JiraFieldID: "summary"},
+		}, result.Fields)
+
+		require.Len(t, result.FernFields, len(integrations.FernFields()))
+		assert.Equal(t, "test_name", result.FernFields[0].ID)
+		assert.True(t, result.FernFields[0].Required)
+	})
+
+	t.Run("saved mapping", func(t *testing.T) {
+		updatedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		result := resolver.convertJiraFieldMappingToModel(&integrations.JiraFieldMapping{
+			ConnectionID: "conn-1",
+			IssueType:    "Task",
+			Fields:       map[integrations.FernField]string{integrations.FernFieldOwner: "customfield_10010"},
+			UpdatedBy:    "admin-1",
+			UpdatedAt:    updatedAt,
+		})
+
+		assert.False(t, result.IsDefault)
+		require.NotNil(t, result.UpdatedAt)
+		assert.Equal(t, updatedAt, *result.UpdatedAt)
+		assert.Equal(t, "admin-1", *result.UpdatedBy)
+		assert.Equal(t, []*model.JiraFieldMappingEntry{{FernField: "owner", JiraFieldID: "customfield_10010"}}, result.Fields)
+	})
+}
+
+func TestConvertJiraIssueLinkToModel_SyncState(t *testing.T) {
+	resolver := setupTestResolver(t)
+	link := &integrations.JiraIssueLink{
+		ID:        3,
+		IssueKey:  "FERN-7",
+		Target:    integrations.FlakyTestTarget(7, "Checkout", "pays with card"),
+		CreatedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	result := resolver.convertJiraIssueLinkToModel(link)
+	assert.Nil(t, result.IssueStatus, "never synced")
+	assert.Nil(t, result.SyncedAt)
+
+	syncedAt := time.Date(2026, 5, 2, 8, 0, 0, 0, time.UTC)
+	link.IssueStatus = "Closed"
+	link.IssueStatusCategory = integrations.JiraStatusCategoryDone
+	link.SyncedAt = &syncedAt
+
+	result = resolver.convertJiraIssueLinkToModel(link)
+	require.NotNil(t, result.IssueStatus)
+	assert.Equal(t, "Closed", *result.IssueStatus)
+	assert.Equal(t, "done", *result.IssueStatusCategory)
+	assert.Equal(t, &syncedAt, result.SyncedAt)
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/gorilla/websocket"
 
 	// "github.com/guidewire-oss/fern-platform/internal/reporter/graphql/dataloader"
+	auditDomain "github.com/guidewire-oss/fern-platform/internal/domains/audit/domain"
+	auditInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/audit/interfaces"
 	authInterfaces "github.com/guidewire-oss/fern-platform/internal/domains/auth/interfaces"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/generated"
 	"github.com/vektah/gqlparser/v2/gqlerror"
@@ -80,7 +82,7 @@ func NewHandler(resolver *Resolver, roleGroupNames *RoleGroupNames) *Handler {
 
 		// Log errors
 		if resolver.logger != nil {
-			resolver.logger.WithError(e).Error("GraphQL error")
+			resolver.logger.WithError(e).WithContext(ctx).Error("GraphQL error")
 		}
 
 		// Don't expose internal errors in production
@@ -134,6 +136,11 @@ func NewHandler(resolver *Resolver, roleGroupNames *RoleGroupNames) *Handler {
 	}
 }
 
+// Use adds an extension to the GraphQL server, such as metrics
+func (h *Handler) Use(extension graphql.HandlerExtension) {
+	h.server.Use(extension)
+}
+
 // RegisterRoutes registers GraphQL routes with the Gin router
 func (h *Handler) RegisterRoutes(router *gin.Engine, authMiddleware *authInterfaces.AuthMiddlewareAdapter) {
 	// GraphQL playground (development only)
@@ -173,6 +180,9 @@ func (h *Handler) graphqlHandler() gin.HandlerFunc {
 			ctx = context.WithValue(ctx, "user", user)
 		}
 
+		// Identify who made the request in audit log entries
+		ctx = auditDomain.WithRequestInfo(ctx, auditInterfaces.RequestInfo(c))
+
 		// Update request with new context
 		c.Request = c.Request.WithContext(ctx)
 
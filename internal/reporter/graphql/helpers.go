@@ -3,11 +3,13 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
 	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/dataloader"
 	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/model"
 )
@@ -228,6 +230,12 @@ func (r *Resolver) convertJiraConnectionToModel(conn *integrations.JiraConnectio
 		lastTestedAt = &t
 	}
 
+	snapshot := conn.Snapshot()
+	var statusReason *string
+	if snapshot.StatusReason != "" {
+		statusReason = &snapshot.StatusReason
+	}
+
 	createdAt := conn.CreatedAt()
 	updatedAt := conn.UpdatedAt()
 
@@ -240,9 +248,192 @@ func (r *Resolver) convertJiraConnectionToModel(conn *integrations.JiraConnectio
 		ProjectKey:         conn.ProjectKey(),
 		Username:           conn.Username(),
 		Status:             string(conn.Status()),
+		StatusReason:       statusReason,
 		IsActive:           conn.IsActive(),
+		OauthAuthorized:    conn.IsOAuthAuthorized(),
+		TokenExpiresAt:     snapshot.TokenExpiresAt,
 		LastTestedAt:       lastTestedAt,
 		CreatedAt:          createdAt,
 		UpdatedAt:          updatedAt,
 	}
 }
+
+// convertConnectorDefinitionToModel converts a registered connector type to GraphQL model
+func convertConnectorDefinitionToModel(definition integrations.ConnectorDefinition) *model.IssueTrackerType {
+	fields := make([]*model.IssueTrackerConfigField, len(definition.ConfigFields))
+	for i, field := range definition.ConfigFields {
+		options := field.Options
+		if options == nil {
+			options = []string{}
+		}
+		fields[i] = &model.IssueTrackerConfigField{
+			Key:      field.Key,
+			Label:    field.Label,
+			Type:     string(field.Type),
+			Required: field.Required,
+			Options:  options,
+		}
+		if field.Description != "" {
+			description := field.Description
+			fields[i].Description = &description
+		}
+		if field.Default != "" {
+			defaultValue := field.Default
+			fields[i].Default = &defaultValue
+		}
+	}
+
+	return &model.IssueTrackerType{
+		Type:            string(definition.Type),
+		DisplayName:     definition.DisplayName,
+		Description:     definition.Description,
+		CredentialLabel: definition.CredentialLabel,
+		ConfigFields:    fields,
+	}
+}
+
+// convertTrackerConnectionToModel converts a domain issue tracker connection to GraphQL model.
+// Config values are sorted by key so responses are stable.
+func convertTrackerConnectionToModel(conn *integrations.TrackerConnection) *model.IssueTrackerConnection {
+	snapshot := conn.Snapshot()
+
+	keys := make([]string, 0, len(snapshot.Config))
+	for key := range snapshot.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	config := make([]*model.IssueTrackerConfigValue, len(keys))
+	for i, key := range keys {
+		config[i] = &model.IssueTrackerConfigValue{Key: key, Value: snapshot.Config[key]}
+	}
+
+	result := &model.IssueTrackerConnection{
+		ID:            snapshot.ID,
+		ProjectID:     snapshot.ProjectID,
+		Name:          snapshot.Name,
+		ConnectorType: string(snapshot.ConnectorType),
+		Config:        config,
+		Status:        string(snapshot.Status),
+		IsActive:      snapshot.IsActive,
+		LastTestedAt:  snapshot.LastTestedAt,
+		CreatedAt:     snapshot.CreatedAt,
+		UpdatedAt:     snapshot.UpdatedAt,
+	}
+	if snapshot.LastError != "" {
+		lastError := snapshot.LastError
+		result.LastError = &lastError
+	}
+	return result
+}
+
+// trackerConfigFromInput converts connector settings from GraphQL input
+func trackerConfigFromInput(values []*model.IssueTrackerConfigValueInput) (integrations.ConnectorConfig, error) {
+	config := make(integrations.ConnectorConfig, len(values))
+	for _, value := range values {
+		if _, ok := config[value.Key]; ok {
+			return nil, fmt.Errorf("setting %q is given more than once", value.Key)
+		}
+		config[value.Key] = value.Value
+	}
+	return config, nil
+}
+
+// convertJiraIssueLinkToModel converts a filed JIRA issue link to GraphQL model
+func (r *Resolver) convertJiraIssueLinkToModel(link *integrations.JiraIssueLink) *model.JiraIssueLink {
+	if link == nil {
+		return nil
+	}
+
+	return &model.JiraIssueLink{
+		ID:           fmt.Sprintf("%d", link.ID),
+		ProjectID:    link.ProjectID,
+		ConnectionID: link.ConnectionID,
+		IssueKey:     link.IssueKey,
+		IssueURL:     link.IssueURL,
+		SourceType:   string(link.SourceType),
+		TargetType:   string(link.Target.Type),
+		TestName:     link.Target.TestName,
+		SuiteName:    convertStringPtr(link.Target.SuiteName),
+		CreatedBy:    convertStringPtr(link.CreatedBy),
+		CreatedAt:    link.CreatedAt,
+
+		IssueStatus:         convertStringPtr(link.IssueStatus),
+		IssueStatusCategory: convertStringPtr(link.IssueStatusCategory),
+		SyncedAt:            link.SyncedAt,
+	}
+}
+
+// convertJiraFieldMappingToModel converts a JIRA field mapping to the GraphQL model
+func (r *Resolver) convertJiraFieldMappingToModel(mapping *integrations.JiraFieldMapping) *model.JiraFieldMapping {
+	result := &model.JiraFieldMapping{
+		ConnectionID: mapping.ConnectionID,
+		IssueType:    mapping.IssueType,
+		Fields:       make([]*model.JiraFieldMappingEntry, 0, len(mapping.Fields)),
+		IsDefault:    mapping.IsDefault,
+		UpdatedBy:    convertStringPtr(mapping.UpdatedBy),
+	}
+	for fernField, jiraFieldID := range mapping.Fields {
+		result.Fields = append(result.Fields, &model.JiraFieldMappingEntry{
+			FernField:   string(fernField),
+			JiraFieldID: jiraFieldID,
+		})
+	}
+	sort.Slice(result.Fields, func(i, j int) bool {
+		return result.Fields[i].FernField < result.Fields[j].FernField
+	})
+	for _, field := range integrations.FernFields() {
+		result.FernFields = append(result.FernFields, &model.FernField{
+			ID:          string(field.Field),
+			Name:        field.Name,
+			Description: field.Description,
+			Required:    field.Required,
+		})
+	}
+	if !mapping.UpdatedAt.IsZero() {
+		updatedAt := mapping.UpdatedAt
+		result.UpdatedAt = &updatedAt
+	}
+	return result
+}
+
+// convertUserToModel converts an auth user to its GraphQL model
+func convertUserToModel(user *authDomain.User) *model.User {
+	groups := make([]string, len(user.Groups))
+	for i, g := range user.Groups {
+		groups[i] = g.GroupName
+	}
+
+	firstName, lastName := user.FirstName, user.LastName
+	return &model.User{
+		ID:             user.UserID,
+		UserID:         user.UserID,
+		Email:          user.Email,
+		Name:           user.Name,
+		FirstName:      &firstName,
+		LastName:       &lastName,
+		Role:           string(user.Role),
+		ProfileURL:     convertStringPtr(user.ProfileURL),
+		Groups:         groups,
+		Status:         string(user.Status),
+		EmailVerified:  user.EmailVerified,
+		CreatedAt:      user.CreatedAt,
+		UpdatedAt:      user.UpdatedAt,
+		LastLoginAt:    user.LastLoginAt,
+		UpdatedBy:      convertStringPtr(user.UpdatedBy),
+		RoleAssignedBy: convertStringPtr(user.RoleAssignedBy),
+	}
+}
+
+// convertProjectAccessToModel converts a project permission to its GraphQL model
+func convertProjectAccessToModel(permission *projectsDomain.ProjectPermission) *model.ProjectAccess {
+	return &model.ProjectAccess{
+		ProjectID:  string(permission.ProjectID()),
+		UserID:     convertStringPtr(permission.UserID()),
+		GroupName:  convertStringPtr(permission.GroupName()),
+		Permission: string(permission.Permission()),
+		GrantedBy:  permission.GrantedBy(),
+		GrantedAt:  permission.GrantedAt(),
+		ExpiresAt:  permission.ExpiresAt(),
+		Expired:    permission.IsExpired(),
+	}
+}
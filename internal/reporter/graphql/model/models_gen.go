@@ -10,6 +10,14 @@ import (
 	"time"
 )
 
+type CreateIssueTrackerConnectionInput struct {
+	ProjectID     string                          `json:"projectId"`
+	Name          string                          `json:"name"`
+	ConnectorType string                          `json:"connectorType"`
+	Config        []*IssueTrackerConfigValueInput `json:"config,omitempty"`
+	Credential    string                          `json:"credential"`
+}
+
 type CreateJiraConnectionInput struct {
 	ProjectID          string `json:"projectId"`
 	Name               string `json:"name"`
@@ -20,6 +28,13 @@ type CreateJiraConnectionInput struct {
 	Credential         string `json:"credential"`
 }
 
+type CreateJiraIssueInput struct {
+	ProjectID   string   `json:"projectId"`
+	SourceType  string   `json:"sourceType"`
+	SpecRunIds  []string `json:"specRunIds,omitempty"`
+	FlakyTestID *string  `json:"flakyTestId,omitempty"`
+}
+
 type CreateProjectInput struct {
 	ProjectID     string         `json:"projectId"`
 	Name          string         `json:"name"`
@@ -57,21 +72,59 @@ type DashboardSummary struct {
 	AverageTestDuration int           `json:"averageTestDuration"`
 }
 
+type FailureExplanation struct {
+	SpecRunIds  []string  `json:"specRunIds"`
+	Summary     string    `json:"summary"`
+	Provider    string    `json:"provider"`
+	Model       string    `json:"model"`
+	Cached      bool      `json:"cached"`
+	Truncated   bool      `json:"truncated"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+type FernField struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+type FlakyDetectionSettings struct {
+	MinimumRuns                    *int     `json:"minimumRuns,omitempty"`
+	MinFailureRate                 *float64 `json:"minFailureRate,omitempty"`
+	MaxFailureRate                 *float64 `json:"maxFailureRate,omitempty"`
+	AnalysisWindow                 *string  `json:"analysisWindow,omitempty"`
+	ConsecutivePassesForResolution *int     `json:"consecutivePassesForResolution,omitempty"`
+}
+
+type FlakyDetectionSettingsInput struct {
+	MinimumRuns                    *int     `json:"minimumRuns,omitempty"`
+	MinFailureRate                 *float64 `json:"minFailureRate,omitempty"`
+	MaxFailureRate                 *float64 `json:"maxFailureRate,omitempty"`
+	AnalysisWindow                 *string  `json:"analysisWindow,omitempty"`
+	ConsecutivePassesForResolution *int     `json:"consecutivePassesForResolution,omitempty"`
+}
+
 type FlakyTest struct {
-	ID               string    `json:"id"`
-	ProjectID        string    `json:"projectId"`
-	TestName         string    `json:"testName"`
-	SuiteName        *string   `json:"suiteName,omitempty"`
-	FlakeRate        float64   `json:"flakeRate"`
-	TotalExecutions  int       `json:"totalExecutions"`
-	FlakyExecutions  int       `json:"flakyExecutions"`
-	LastSeenAt       time.Time `json:"lastSeenAt"`
-	FirstSeenAt      time.Time `json:"firstSeenAt"`
-	Status           string    `json:"status"`
-	Severity         string    `json:"severity"`
-	LastErrorMessage *string   `json:"lastErrorMessage,omitempty"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID                string                   `json:"id"`
+	ProjectID         string                   `json:"projectId"`
+	TestName          string                   `json:"testName"`
+	SuiteName         *string                  `json:"suiteName,omitempty"`
+	FlakeRate         float64                  `json:"flakeRate"`
+	TotalExecutions   int                      `json:"totalExecutions"`
+	FlakyExecutions   int                      `json:"flakyExecutions"`
+	LastSeenAt        time.Time                `json:"lastSeenAt"`
+	FirstSeenAt       time.Time                `json:"firstSeenAt"`
+	Status            string                   `json:"status"`
+	Severity          string                   `json:"severity"`
+	LastErrorMessage  *string                  `json:"lastErrorMessage,omitempty"`
+	ConsecutivePasses int                      `json:"consecutivePasses"`
+	StatusChangedAt   *time.Time               `json:"statusChangedAt,omitempty"`
+	StatusHistory     []*FlakyTestStatusChange `json:"statusHistory"`
+	JiraIssue         *JiraIssueLink           `json:"jiraIssue,omitempty"`
+	Owners            []string                 `json:"owners"`
+	CreatedAt         time.Time                `json:"createdAt"`
+	UpdatedAt         time.Time                `json:"updatedAt"`
 }
 
 type FlakyTestConnection struct {
@@ -91,6 +144,7 @@ type FlakyTestFilter struct {
 	Status       *string  `json:"status,omitempty"`
 	MinFlakeRate *float64 `json:"minFlakeRate,omitempty"`
 	MaxFlakeRate *float64 `json:"maxFlakeRate,omitempty"`
+	Owner        *string  `json:"owner,omitempty"`
 }
 
 type FlakyTestStats struct {
@@ -100,6 +154,23 @@ type FlakyTestStats struct {
 	MostFlakyTest    *FlakyTest       `json:"mostFlakyTest,omitempty"`
 }
 
+type FlakyTestStatusChange struct {
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	ActorType  string    `json:"actorType"`
+	ActorID    string    `json:"actorId"`
+	Reason     *string   `json:"reason,omitempty"`
+	ChangedAt  time.Time `json:"changedAt"`
+}
+
+type GrantProjectAccessInput struct {
+	ProjectID  string     `json:"projectId"`
+	UserID     *string    `json:"userId,omitempty"`
+	GroupName  *string    `json:"groupName,omitempty"`
+	Permission string     `json:"permission"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+}
+
 type HealthStatus struct {
 	Status    string    `json:"status"`
 	Service   string    `json:"service"`
@@ -107,6 +178,48 @@ type HealthStatus struct {
 	Version   *string   `json:"version,omitempty"`
 }
 
+type IssueTrackerConfigField struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Description *string  `json:"description,omitempty"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Default     *string  `json:"default,omitempty"`
+	Options     []string `json:"options"`
+}
+
+type IssueTrackerConfigValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type IssueTrackerConfigValueInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type IssueTrackerConnection struct {
+	ID            string                     `json:"id"`
+	ProjectID     string                     `json:"projectId"`
+	Name          string                     `json:"name"`
+	ConnectorType string                     `json:"connectorType"`
+	Config        []*IssueTrackerConfigValue `json:"config"`
+	Status        string                     `json:"status"`
+	LastError     *string                    `json:"lastError,omitempty"`
+	IsActive      bool                       `json:"isActive"`
+	LastTestedAt  *time.Time                 `json:"lastTestedAt,omitempty"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	UpdatedAt     time.Time                  `json:"updatedAt"`
+}
+
+type IssueTrackerType struct {
+	Type            string                     `json:"type"`
+	DisplayName     string                     `json:"displayName"`
+	Description     string                     `json:"description"`
+	CredentialLabel string                     `json:"credentialLabel"`
+	ConfigFields    []*IssueTrackerConfigField `json:"configFields"`
+}
+
 type JiraConnection struct {
 	ID                 string     `json:"id"`
 	ProjectID          string     `json:"projectId"`
@@ -116,12 +229,83 @@ type JiraConnection struct {
 	ProjectKey         string     `json:"projectKey"`
 	Username           string     `json:"username"`
 	Status             string     `json:"status"`
+	StatusReason       *string    `json:"statusReason,omitempty"`
 	IsActive           bool       `json:"isActive"`
+	OauthAuthorized    bool       `json:"oauthAuthorized"`
+	TokenExpiresAt     *time.Time `json:"tokenExpiresAt,omitempty"`
 	LastTestedAt       *time.Time `json:"lastTestedAt,omitempty"`
 	CreatedAt          time.Time  `json:"createdAt"`
 	UpdatedAt          time.Time  `json:"updatedAt"`
 }
 
+type JiraField struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Custom      bool    `json:"custom"`
+	SchemaType  string  `json:"schemaType"`
+	SchemaItems *string `json:"schemaItems,omitempty"`
+}
+
+type JiraFieldMapping struct {
+	ConnectionID string                   `json:"connectionId"`
+	IssueType    string                   `json:"issueType"`
+	Fields       []*JiraFieldMappingEntry `json:"fields"`
+	FernFields   []*FernField             `json:"fernFields"`
+	IsDefault    bool                     `json:"isDefault"`
+	UpdatedBy    *string                  `json:"updatedBy,omitempty"`
+	UpdatedAt    *time.Time               `json:"updatedAt,omitempty"`
+}
+
+type JiraFieldMappingEntry struct {
+	FernField   string `json:"fernField"`
+	JiraFieldID string `json:"jiraFieldId"`
+}
+
+type JiraFieldMappingEntryInput struct {
+	FernField   string `json:"fernField"`
+	JiraFieldID string `json:"jiraFieldId"`
+}
+
+type JiraIssueCreation struct {
+	IssueKey string           `json:"issueKey"`
+	IssueURL string           `json:"issueUrl"`
+	Created  bool             `json:"created"`
+	Links    []*JiraIssueLink `json:"links"`
+}
+
+type JiraIssueLink struct {
+	ID                  string     `json:"id"`
+	ProjectID           string     `json:"projectId"`
+	ConnectionID        string     `json:"connectionId"`
+	IssueKey            string     `json:"issueKey"`
+	IssueURL            string     `json:"issueUrl"`
+	SourceType          string     `json:"sourceType"`
+	TargetType          string     `json:"targetType"`
+	TestName            string     `json:"testName"`
+	SuiteName           *string    `json:"suiteName,omitempty"`
+	CreatedBy           *string    `json:"createdBy,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	IssueStatus         *string    `json:"issueStatus,omitempty"`
+	IssueStatusCategory *string    `json:"issueStatusCategory,omitempty"`
+	SyncedAt            *time.Time `json:"syncedAt,omitempty"`
+}
+
+type JiraIssueType struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	IconURL     *string `json:"iconUrl,omitempty"`
+	Subtask     bool    `json:"subtask"`
+}
+
+type JiraSyncSettings struct {
+	ReflakeAction string `json:"reflakeAction"`
+}
+
+type JiraSyncSettingsInput struct {
+	ReflakeAction *string `json:"reflakeAction,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -133,19 +317,33 @@ type PageInfo struct {
 }
 
 type Project struct {
-	ID            string         `json:"id"`
-	ProjectID     string         `json:"projectId"`
-	Name          string         `json:"name"`
-	Description   *string        `json:"description,omitempty"`
-	Repository    *string        `json:"repository,omitempty"`
-	DefaultBranch string         `json:"defaultBranch"`
-	Settings      map[string]any `json:"settings,omitempty"`
-	IsActive      bool           `json:"isActive"`
-	Team          *string        `json:"team,omitempty"`
-	CanManage     bool           `json:"canManage"`
-	Stats         *ProjectStats  `json:"stats,omitempty"`
-	CreatedAt     time.Time      `json:"createdAt"`
-	UpdatedAt     time.Time      `json:"updatedAt"`
+	ID                     string                  `json:"id"`
+	ProjectID              string                  `json:"projectId"`
+	Name                   string                  `json:"name"`
+	Description            *string                 `json:"description,omitempty"`
+	Repository             *string                 `json:"repository,omitempty"`
+	DefaultBranch          string                  `json:"defaultBranch"`
+	Settings               map[string]any          `json:"settings,omitempty"`
+	IsActive               bool                    `json:"isActive"`
+	Team                   *string                 `json:"team,omitempty"`
+	CanManage              bool                    `json:"canManage"`
+	Stats                  *ProjectStats           `json:"stats,omitempty"`
+	FlakyDetectionSettings *FlakyDetectionSettings `json:"flakyDetectionSettings,omitempty"`
+	OwnershipRules         *string                 `json:"ownershipRules,omitempty"`
+	JiraSync               *JiraSyncSettings       `json:"jiraSync"`
+	CreatedAt              time.Time               `json:"createdAt"`
+	UpdatedAt              time.Time               `json:"updatedAt"`
+}
+
+type ProjectAccess struct {
+	ProjectID  string     `json:"projectId"`
+	UserID     *string    `json:"userId,omitempty"`
+	GroupName  *string    `json:"groupName,omitempty"`
+	Permission string     `json:"permission"`
+	GrantedBy  string     `json:"grantedBy"`
+	GrantedAt  time.Time  `json:"grantedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	Expired    bool       `json:"expired"`
 }
 
 type ProjectConnection struct {
@@ -198,6 +396,19 @@ type SeverityCount struct {
 	Count    int    `json:"count"`
 }
 
+type SimilarFailure struct {
+	Failure *SpecFailure `json:"failure"`
+	Score   float64      `json:"score"`
+}
+
+type SpecFailure struct {
+	SpecRun   *SpecRun `json:"specRun"`
+	SuiteName string   `json:"suiteName"`
+	TestRunID string   `json:"testRunId"`
+	ProjectID string   `json:"projectId"`
+	Branch    *string  `json:"branch,omitempty"`
+}
+
 type SpecRun struct {
 	ID           string     `json:"id"`
 	SuiteRunID   string     `json:"suiteRunId"`
@@ -210,6 +421,7 @@ type SpecRun struct {
 	StackTrace   *string    `json:"stackTrace,omitempty"`
 	RetryCount   int        `json:"retryCount"`
 	IsFlaky      bool       `json:"isFlaky"`
+	Owners       []string   `json:"owners"`
 	Tags         []*Tag     `json:"tags"`
 	CreatedAt    time.Time  `json:"createdAt"`
 	UpdatedAt    time.Time  `json:"updatedAt"`
@@ -354,6 +566,11 @@ type TreemapData struct {
 	OverallPassRate float64               `json:"overallPassRate"`
 }
 
+type UpdateIssueTrackerConnectionInput struct {
+	Name   string                          `json:"name"`
+	Config []*IssueTrackerConfigValueInput `json:"config,omitempty"`
+}
+
 type UpdateJiraConnectionInput struct {
 	Name       string `json:"name"`
 	JiraURL    string `json:"jiraUrl"`
@@ -366,13 +583,21 @@ type UpdateJiraCredentialsInput struct {
 	Credential         string `json:"credential"`
 }
 
+type UpdateJiraFieldMappingInput struct {
+	IssueType string                        `json:"issueType"`
+	Fields    []*JiraFieldMappingEntryInput `json:"fields"`
+}
+
 type UpdateProjectInput struct {
-	Name          *string        `json:"name,omitempty"`
-	Description   *string        `json:"description,omitempty"`
-	Repository    *string        `json:"repository,omitempty"`
-	DefaultBranch *string        `json:"defaultBranch,omitempty"`
-	Settings      map[string]any `json:"settings,omitempty"`
-	Team          *string        `json:"team,omitempty"`
+	Name           *string                      `json:"name,omitempty"`
+	Description    *string                      `json:"description,omitempty"`
+	Repository     *string                      `json:"repository,omitempty"`
+	DefaultBranch  *string                      `json:"defaultBranch,omitempty"`
+	Settings       map[string]any               `json:"settings,omitempty"`
+	Team           *string                      `json:"team,omitempty"`
+	FlakyDetection *FlakyDetectionSettingsInput `json:"flakyDetection,omitempty"`
+	OwnershipRules *string                      `json:"ownershipRules,omitempty"`
+	JiraSync       *JiraSyncSettingsInput       `json:"jiraSync,omitempty"`
 }
 
 type UpdateTagInput struct {
@@ -390,17 +615,39 @@ type UpdateUserPreferencesInput struct {
 }
 
 type User struct {
-	ID          string     `json:"id"`
-	UserID      string     `json:"userId"`
-	Email       string     `json:"email"`
-	Name        string     `json:"name"`
-	FirstName   *string    `json:"firstName,omitempty"`
-	LastName    *string    `json:"lastName,omitempty"`
-	Role        string     `json:"role"`
-	ProfileURL  *string    `json:"profileUrl,omitempty"`
-	Groups      []string   `json:"groups"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+	ID             string     `json:"id"`
+	UserID         string     `json:"userId"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	FirstName      *string    `json:"firstName,omitempty"`
+	LastName       *string    `json:"lastName,omitempty"`
+	Role           string     `json:"role"`
+	ProfileURL     *string    `json:"profileUrl,omitempty"`
+	Groups         []string   `json:"groups"`
+	Status         string     `json:"status"`
+	EmailVerified  bool       `json:"emailVerified"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	LastLoginAt    *time.Time `json:"lastLoginAt,omitempty"`
+	UpdatedBy      *string    `json:"updatedBy,omitempty"`
+	RoleAssignedBy *string    `json:"roleAssignedBy,omitempty"`
+}
+
+type UserConnection struct {
+	Edges      []*UserEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount int         `json:"totalCount"`
+}
+
+type UserEdge struct {
+	Node   *User  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type UserFilter struct {
+	Search *string `json:"search,omitempty"`
+	Role   *string `json:"role,omitempty"`
+	Status *string `json:"status,omitempty"`
 }
 
 type UserPreferences struct {
@@ -0,0 +1,175 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
+	projectsDomain "github.com/guidewire-oss/fern-platform/internal/domains/projects/domain"
+	testingApp "github.com/guidewire-oss/fern-platform/internal/domains/testing/application"
+	testingDomain "github.com/guidewire-oss/fern-platform/internal/domains/testing/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// teamProjectRepository serves projects by ID and counts lookups
+type teamProjectRepository struct {
+	projectsDomain.ProjectRepository
+	projects map[string]*projectsDomain.Project
+	lookups  int
+}
+
+func (r *teamProjectRepository) FindByProjectID(ctx context.Context, projectID projectsDomain.ProjectID) (*projectsDomain.Project, error) {
+	r.lookups++
+	project, ok := r.projects[string(projectID)]
+	if !ok {
+		return nil, fmt.Errorf("project not found")
+	}
+	return project, nil
+}
+
+func newTeamProjectRepository(t *testing.T, teams map[string]string) *teamProjectRepository {
+	repo := &teamProjectRepository{projects: make(map[string]*projectsDomain.Project)}
+	for projectID, team := range teams {
+		project, err := projectsDomain.NewProject(projectsDomain.ProjectID(projectID), projectID, projectsDomain.Team(team))
+		require.NoError(t, err)
+		repo.projects[projectID] = project
+	}
+	return repo
+}
+
+// grantedPermissionRepository serves permissions granted to users and groups
+type grantedPermissionRepository struct {
+	projectsDomain.ProjectPermissionRepository
+	permissions []*projectsDomain.ProjectPermission
+}
+
+func (r *grantedPermissionRepository) FindByProjectAndUser(ctx context.Context, projectID projectsDomain.ProjectID, userID string) ([]*projectsDomain.ProjectPermission, error) {
+	var found []*projectsDomain.ProjectPermission
+	for _, p := range r.permissions {
+		if p.ProjectID() == projectID && p.UserID() == userID {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (r *grantedPermissionRepository) FindByProjectAndGroups(ctx context.Context, projectID projectsDomain.ProjectID, groupNames []string) ([]*projectsDomain.ProjectPermission, error) {
+	var found []*projectsDomain.ProjectPermission
+	for _, p := range r.permissions {
+		for _, group := range groupNames {
+			if p.ProjectID() == projectID && p.IsGroupPermission() && p.GroupName() == group {
+				found = append(found, p)
+			}
+		}
+	}
+	return found, nil
+}
+
+func TestProjectReadChecker(t *testing.T) {
+	repo := newTeamProjectRepository(t, map[string]string{
+		"payments": "team-payments",
+		"search":   "team-search",
+		"orders":   "team-orders",
+		"billing":  "team-billing",
+	})
+	grant, err := projectsDomain.NewProjectGroupPermission("billing", "/contractors", projectsDomain.PermissionRead, "admin")
+	require.NoError(t, err)
+	resolver := setupTestResolver(t)
+	resolver.projectService = projectsApp.NewProjectService(repo, &grantedPermissionRepository{permissions: []*projectsDomain.ProjectPermission{grant}})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := resolver.projectReadChecker(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("admin reads every project", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user", &authDomain.User{UserID: "admin", Role: authDomain.RoleAdmin})
+		canRead, err := resolver.projectReadChecker(ctx)
+		require.NoError(t, err)
+		assert.True(t, canRead("anything"))
+	})
+
+	t.Run("users read team projects and scoped projects", func(t *testing.T) {
+		repo.lookups = 0
+		ctx := context.WithValue(context.Background(), "user", &authDomain.User{
+			UserID: "u1",
+			Role:   authDomain.RoleUser,
+			Groups: []authDomain.UserGroup{{GroupName: "/team-payments"}, {GroupName: "user"}},
+			Scopes: []authDomain.UserScope{{Scope: "project:read:orders"}},
+		})
+		canRead, err := resolver.projectReadChecker(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, canRead("payments"))
+		assert.True(t, canRead("orders"))
+		assert.False(t, canRead("search"))
+		assert.False(t, canRead("missing"))
+
+		// Decisions are memoized per checker
+		assert.False(t, canRead("search"))
+		assert.Equal(t, 3, repo.lookups)
+	})
+
+	t.Run("users read projects granted to their groups", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user", &authDomain.User{
+			UserID: "u2",
+			Role:   authDomain.RoleUser,
+			Groups: []authDomain.UserGroup{{GroupName: "/contractors"}},
+		})
+		canRead, err := resolver.projectReadChecker(ctx)
+		require.NoError(t, err)
+
+		assert.True(t, canRead("billing"))
+		assert.False(t, canRead("payments"))
+	})
+}
+
+// failedTestRuns serves test runs of a single project
+type failedTestRuns struct {
+	testingDomain.TestRunRepository
+	projectID string
+}
+
+func (r *failedTestRuns) GetByID(ctx context.Context, id uint) (*testingDomain.TestRun, error) {
+	return &testingDomain.TestRun{ID: id, RunID: "run-1", ProjectID: r.projectID}, nil
+}
+
+// failedSuiteRuns serves suite runs of test run 1
+type failedSuiteRuns struct {
+	testingDomain.SuiteRunRepository
+}
+
+func (r *failedSuiteRuns) GetByID(ctx context.Context, id uint) (*testingDomain.SuiteRun, error) {
+	return &testingDomain.SuiteRun{ID: id, TestRunID: 1, Name: "Checkout"}, nil
+}
+
+// failedSpecRuns serves failed spec runs of suite run 10
+type failedSpecRuns struct {
+	testingDomain.SpecRunRepository
+}
+
+func (r *failedSpecRuns) GetByID(ctx context.Context, id uint) (*testingDomain.SpecRun, error) {
+	return &testingDomain.SpecRun{ID: id, SuiteRunID: 10, Name: "pays", Status: "failed"}, nil
+}
+
+func TestExplainFailuresProjectAccess(t *testing.T) {
+	resolver := setupTestResolver(t)
+	resolver.projectService = projectsApp.NewProjectService(
+		newTeamProjectRepository(t, map[string]string{"search": "team-search"}),
+		&grantedPermissionRepository{},
+	)
+	resolver.explanationService = testingApp.NewFailureExplanationService(
+		&failedTestRuns{projectID: "search"}, &failedSuiteRuns{}, &failedSpecRuns{}, nil, nil)
+
+	ctx := context.WithValue(context.Background(), "user", &authDomain.User{
+		UserID: "u1",
+		Role:   authDomain.RoleUser,
+		Groups: []authDomain.UserGroup{{GroupName: "/team-payments"}},
+	})
+	_, err := (&queryResolver{resolver}).ExplainFailure_domain(ctx, "100")
+
+	assert.EqualError(t, err, "spec run 100 not found")
+}
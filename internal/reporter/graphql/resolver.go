@@ -3,6 +3,7 @@ package graphql
 
 import (
 	analyticsApp "github.com/guidewire-oss/fern-platform/internal/domains/analytics/application"
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
 	"github.com/guidewire-oss/fern-platform/internal/domains/integrations"
 	projectsApp "github.com/guidewire-oss/fern-platform/internal/domains/projects/application"
 	tagsApp "github.com/guidewire-oss/fern-platform/internal/domains/tags/application"
@@ -18,34 +19,49 @@ import (
 
 // Resolver is the root GraphQL resolver
 type Resolver struct {
-	testingService        *testingApp.TestRunService
-	projectService        *projectsApp.ProjectService
-	tagService            *tagsApp.TagService
-	flakyDetectionService *analyticsApp.FlakyDetectionService
-	jiraConnectionService *integrations.JiraConnectionService
-	loaders               *dataloader.Loaders
-	db                    *gorm.DB
-	logger                *logging.Logger
+	testingService           *testingApp.TestRunService
+	flakyLifecycleService    *testingApp.FlakyLifecycleService
+	projectService           *projectsApp.ProjectService
+	tagService               *tagsApp.TagService
+	flakyDetectionService    *analyticsApp.FlakyDetectionService
+	jiraConnectionService    *integrations.JiraConnectionService
+	trackerConnectionService *integrations.TrackerConnectionService
+	similarFailureService    *testingApp.SimilarFailureService
+	explanationService       *testingApp.FailureExplanationService
+	userAdminService         *authApp.UserAdminService
+	loaders                  *dataloader.Loaders
+	db                       *gorm.DB
+	logger                   *logging.Logger
 }
 
 // NewResolver creates a new GraphQL resolver
 func NewResolver(
 	testingService *testingApp.TestRunService,
+	flakyLifecycleService *testingApp.FlakyLifecycleService,
 	projectService *projectsApp.ProjectService,
 	tagService *tagsApp.TagService,
 	flakyDetectionService *analyticsApp.FlakyDetectionService,
 	jiraConnectionService *integrations.JiraConnectionService,
+	trackerConnectionService *integrations.TrackerConnectionService,
+	similarFailureService *testingApp.SimilarFailureService,
+	explanationService *testingApp.FailureExplanationService,
+	userAdminService *authApp.UserAdminService,
 	db *gorm.DB,
 	logger *logging.Logger,
 ) *Resolver {
 	return &Resolver{
-		testingService:        testingService,
-		projectService:        projectService,
-		tagService:            tagService,
-		flakyDetectionService: flakyDetectionService,
-		jiraConnectionService: jiraConnectionService,
-		loaders:               dataloader.NewLoaders(db),
-		db:                    db,
-		logger:                logger,
+		testingService:           testingService,
+		flakyLifecycleService:    flakyLifecycleService,
+		projectService:           projectService,
+		tagService:               tagService,
+		flakyDetectionService:    flakyDetectionService,
+		jiraConnectionService:    jiraConnectionService,
+		trackerConnectionService: trackerConnectionService,
+		similarFailureService:    similarFailureService,
+		explanationService:       explanationService,
+		userAdminService:         userAdminService,
+		loaders:                  dataloader.NewLoaders(db),
+		db:                       db,
+		logger:                   logger,
 	}
 }
@@ -21,6 +21,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// StatusHistory is the resolver for the statusHistory field.
+func (r *flakyTestResolver) StatusHistory(ctx context.Context, obj *model.FlakyTest) ([]*model.FlakyTestStatusChange, error) {
+	return r.StatusHistory_domain(ctx, obj)
+}
+
+// JiraIssue is the resolver for the jiraIssue field.
+func (r *flakyTestResolver) JiraIssue(ctx context.Context, obj *model.FlakyTest) (*model.JiraIssueLink, error) {
+	return r.JiraIssue_domain(ctx, obj)
+}
+
 // CreateTestRun is the resolver for the createTestRun field.
 func (r *mutationResolver) CreateTestRun(ctx context.Context, input model.CreateTestRunInput) (*model.TestRun, error) {
 	return nil, fmt.Errorf("CreateTestRun not yet implemented")
@@ -86,8 +96,13 @@ func (r *mutationResolver) DeleteTag(ctx context.Context, id string) (bool, erro
 }
 
 // MarkFlakyTestResolved is the resolver for the markFlakyTestResolved field.
-func (r *mutationResolver) MarkFlakyTestResolved(ctx context.Context, id string) (*model.FlakyTest, error) {
-	return nil, fmt.Errorf("MarkFlakyTestResolved not yet implemented")
+func (r *mutationResolver) MarkFlakyTestResolved(ctx context.Context, id string, reason *string) (*model.FlakyTest, error) {
+	return r.MarkFlakyTestResolved_domain(ctx, id, reason)
+}
+
+// ReactivateFlakyTest is the resolver for the reactivateFlakyTest field.
+func (r *mutationResolver) ReactivateFlakyTest(ctx context.Context, id string, reason *string) (*model.FlakyTest, error) {
+	return r.ReactivateFlakyTest_domain(ctx, id, reason)
 }
 
 // MarkSpecAsFlaky is the resolver for the markSpecAsFlaky field.
@@ -95,6 +110,36 @@ func (r *mutationResolver) MarkSpecAsFlaky(ctx context.Context, specRunID string
 	return nil, fmt.Errorf("MarkSpecAsFlaky not yet implemented")
 }
 
+// UpdateUserRole is the resolver for the updateUserRole field.
+func (r *mutationResolver) UpdateUserRole(ctx context.Context, userID string, role string) (*model.User, error) {
+	return r.UpdateUserRole_domain(ctx, userID, role)
+}
+
+// SuspendUser is the resolver for the suspendUser field.
+func (r *mutationResolver) SuspendUser(ctx context.Context, userID string) (*model.User, error) {
+	return r.SuspendUser_domain(ctx, userID)
+}
+
+// ActivateUser is the resolver for the activateUser field.
+func (r *mutationResolver) ActivateUser(ctx context.Context, userID string) (*model.User, error) {
+	return r.ActivateUser_domain(ctx, userID)
+}
+
+// DeleteUser is the resolver for the deleteUser field.
+func (r *mutationResolver) DeleteUser(ctx context.Context, userID string, reassignTo *string) (bool, error) {
+	return r.DeleteUser_domain(ctx, userID, reassignTo)
+}
+
+// GrantProjectAccess is the resolver for the grantProjectAccess field.
+func (r *mutationResolver) GrantProjectAccess(ctx context.Context, input model.GrantProjectAccessInput) (*model.ProjectAccess, error) {
+	return r.GrantProjectAccess_domain(ctx, input)
+}
+
+// RevokeProjectAccess is the resolver for the revokeProjectAccess field.
+func (r *mutationResolver) RevokeProjectAccess(ctx context.Context, projectID string, userID *string, groupName *string, permission *string) (bool, error) {
+	return r.RevokeProjectAccess_domain(ctx, projectID, userID, groupName, permission)
+}
+
 // UpdateUserPreferences is the resolver for the updateUserPreferences field.
 func (r *mutationResolver) UpdateUserPreferences(ctx context.Context, input model.UpdateUserPreferencesInput) (*model.UserPreferences, error) {
 	// Get current user
@@ -300,8 +345,8 @@ func (r *mutationResolver) CreateJiraConnection(ctx context.Context, input model
 	}
 
 	// Check if user has manager permissions for this project
-	permissions, err := r.projectService.GetUserPermissions(ctx, project.ProjectID(), user.UserID)
-	if err != nil || len(permissions) == 0 {
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil || !canManage {
 		return nil, fmt.Errorf("forbidden")
 	}
 
@@ -341,8 +386,8 @@ func (r *mutationResolver) UpdateJiraConnection(ctx context.Context, id string,
 	}
 
 	// Check if user has manager permissions for this project
-	permissions, err := r.projectService.GetUserPermissions(ctx, project.ProjectID(), user.UserID)
-	if err != nil || len(permissions) == 0 {
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil || !canManage {
 		return nil, fmt.Errorf("forbidden")
 	}
 
@@ -379,8 +424,8 @@ func (r *mutationResolver) UpdateJiraCredentials(ctx context.Context, id string,
 	}
 
 	// Check if user has manager permissions for this project
-	permissions, err := r.projectService.GetUserPermissions(ctx, project.ProjectID(), user.UserID)
-	if err != nil || len(permissions) == 0 {
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil || !canManage {
 		return nil, fmt.Errorf("forbidden")
 	}
 
@@ -420,8 +465,8 @@ func (r *mutationResolver) TestJiraConnection(ctx context.Context, id string) (b
 	}
 
 	// Check if user has manager permissions for this project
-	permissions, err := r.projectService.GetUserPermissions(ctx, project.ProjectID(), user.UserID)
-	if err != nil || len(permissions) == 0 {
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil || !canManage {
 		r.logger.Errorf("TestJiraConnection: forbidden for user %s on project %s", user.UserID, project.ProjectID())
 		return false, fmt.Errorf("forbidden")
 	}
@@ -455,8 +500,8 @@ func (r *mutationResolver) DeleteJiraConnection(ctx context.Context, id string)
 	}
 
 	// Check if user has manager permissions for this project
-	permissions, err := r.projectService.GetUserPermissions(ctx, project.ProjectID(), user.UserID)
-	if err != nil || len(permissions) == 0 {
+	canManage, err := r.Project().CanManage(ctx, r.convertProjectToGraphQL(project))
+	if err != nil || !canManage {
 		return false, fmt.Errorf("forbidden")
 	}
 
@@ -467,6 +512,46 @@ func (r *mutationResolver) DeleteJiraConnection(ctx context.Context, id string)
 	return true, nil
 }
 
+// CreateJiraIssue is the resolver for the createJiraIssue field.
+func (r *mutationResolver) CreateJiraIssue(ctx context.Context, input model.CreateJiraIssueInput) (*model.JiraIssueCreation, error) {
+	return r.CreateJiraIssue_domain(ctx, input)
+}
+
+// UpdateJiraFieldMapping is the resolver for the updateJiraFieldMapping field.
+func (r *mutationResolver) UpdateJiraFieldMapping(ctx context.Context, connectionID string, input model.UpdateJiraFieldMappingInput) (*model.JiraFieldMapping, error) {
+	return r.UpdateJiraFieldMapping_domain(ctx, connectionID, input)
+}
+
+// CreateIssueTrackerConnection is the resolver for the createIssueTrackerConnection field.
+func (r *mutationResolver) CreateIssueTrackerConnection(ctx context.Context, input model.CreateIssueTrackerConnectionInput) (*model.IssueTrackerConnection, error) {
+	return r.CreateIssueTrackerConnection_domain(ctx, input)
+}
+
+// UpdateIssueTrackerConnection is the resolver for the updateIssueTrackerConnection field.
+func (r *mutationResolver) UpdateIssueTrackerConnection(ctx context.Context, id string, input model.UpdateIssueTrackerConnectionInput) (*model.IssueTrackerConnection, error) {
+	return r.UpdateIssueTrackerConnection_domain(ctx, id, input)
+}
+
+// UpdateIssueTrackerCredential is the resolver for the updateIssueTrackerCredential field.
+func (r *mutationResolver) UpdateIssueTrackerCredential(ctx context.Context, id string, credential string) (*model.IssueTrackerConnection, error) {
+	return r.UpdateIssueTrackerCredential_domain(ctx, id, credential)
+}
+
+// TestIssueTrackerConnection is the resolver for the testIssueTrackerConnection field.
+func (r *mutationResolver) TestIssueTrackerConnection(ctx context.Context, id string) (*model.IssueTrackerConnection, error) {
+	return r.TestIssueTrackerConnection_domain(ctx, id)
+}
+
+// SetIssueTrackerConnectionActive is the resolver for the setIssueTrackerConnectionActive field.
+func (r *mutationResolver) SetIssueTrackerConnectionActive(ctx context.Context, id string, active bool) (*model.IssueTrackerConnection, error) {
+	return r.SetIssueTrackerConnectionActive_domain(ctx, id, active)
+}
+
+// DeleteIssueTrackerConnection is the resolver for the deleteIssueTrackerConnection field.
+func (r *mutationResolver) DeleteIssueTrackerConnection(ctx context.Context, id string) (bool, error) {
+	return r.DeleteIssueTrackerConnection_domain(ctx, id)
+}
+
 // CanManage is the resolver for the canManage field.
 func (r *projectResolver) CanManage(ctx context.Context, obj *model.Project) (bool, error) {
 	// Get current user from context
@@ -529,21 +614,8 @@ func (r *projectResolver) CanManage(ctx context.Context, obj *model.Project) (bo
 		}
 	}
 
-	// Check explicit project permissions in database
-	// TODO: Migrate to domain permission model
-	type ProjectPermission struct {
-		ID         uint
-		ProjectID  string
-		UserID     string
-		Permission string
-		ExpiresAt  *time.Time
-	}
-	var perm ProjectPermission
-	now := time.Now()
-	err = r.db.Where("project_id = ? AND user_id = ? AND permission IN ? AND (expires_at IS NULL OR expires_at > ?)",
-		obj.ProjectID, user.UserID, []string{"write", "delete", "admin"}, now).First(&perm).Error
-
-	return err == nil, nil
+	// Check permissions granted on the project
+	return r.hasProjectPermission(ctx, user, projectsDomain.ProjectID(obj.ProjectID), projectsDomain.PermissionWrite)
 }
 
 // Stats is the resolver for the stats field.
@@ -559,25 +631,7 @@ func (r *queryResolver) CurrentUser(ctx context.Context) (*model.User, error) {
 		return nil, fmt.Errorf("user not authenticated")
 	}
 
-	// Extract group names from Groups
-	groups := make([]string, len(user.Groups))
-	for i, g := range user.Groups {
-		groups[i] = g.GroupName
-	}
-
-	return &model.User{
-		ID:          user.UserID,
-		UserID:      user.UserID,
-		Email:       user.Email,
-		Name:        user.Name,
-		FirstName:   &user.FirstName,
-		LastName:    &user.LastName,
-		Role:        string(user.Role),
-		ProfileURL:  convertStringPtr(user.ProfileURL),
-		Groups:      groups,
-		CreatedAt:   user.CreatedAt,
-		LastLoginAt: user.LastLoginAt,
-	}, nil
+	return convertUserToModel(user), nil
 }
 
 // UserPreferences is the resolver for the userPreferences field.
@@ -658,6 +712,21 @@ func (r *queryResolver) SystemConfig(ctx context.Context) (*model.SystemConfig,
 	}, nil
 }
 
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, filter *model.UserFilter, first *int, after *string) (*model.UserConnection, error) {
+	return r.Users_domain(ctx, filter, first, after)
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, userID string) (*model.User, error) {
+	return r.User_domain(ctx, userID)
+}
+
+// ProjectAccess is the resolver for the projectAccess field.
+func (r *queryResolver) ProjectAccess(ctx context.Context, projectID string) ([]*model.ProjectAccess, error) {
+	return r.ProjectAccess_domain(ctx, projectID)
+}
+
 // DashboardSummary is the resolver for the dashboardSummary field.
 func (r *queryResolver) DashboardSummary(ctx context.Context) (*model.DashboardSummary, error) {
 	// Use domain service implementation
@@ -769,12 +838,12 @@ func (r *queryResolver) PopularTags(ctx context.Context, limit *int) ([]*model.T
 
 // FlakyTest is the resolver for the flakyTest field.
 func (r *queryResolver) FlakyTest(ctx context.Context, id string) (*model.FlakyTest, error) {
-	return nil, fmt.Errorf("FlakyTest not yet implemented")
+	return r.FlakyTest_domain(ctx, id)
 }
 
 // FlakyTests is the resolver for the flakyTests field.
 func (r *queryResolver) FlakyTests(ctx context.Context, filter *model.FlakyTestFilter, first *int, after *string, orderBy *string, orderDirection *model.OrderDirection) (*model.FlakyTestConnection, error) {
-	return nil, fmt.Errorf("FlakyTests not yet implemented")
+	return r.FlakyTests_domain(ctx, filter, first, after)
 }
 
 // FlakyTestStats is the resolver for the flakyTestStats field.
@@ -787,6 +856,26 @@ func (r *queryResolver) RecentlyAddedFlakyTests(ctx context.Context, projectID *
 	return nil, fmt.Errorf("RecentlyAddedFlakyTests not yet implemented")
 }
 
+// Failures is the resolver for the failures field.
+func (r *queryResolver) Failures(ctx context.Context, projectID string, owner *string, days *int, limit *int) ([]*model.SpecFailure, error) {
+	return r.Failures_domain(ctx, projectID, owner, days, limit)
+}
+
+// SimilarFailures is the resolver for the similarFailures field.
+func (r *queryResolver) SimilarFailures(ctx context.Context, specRunID string, limit *int) ([]*model.SimilarFailure, error) {
+	return r.SimilarFailures_domain(ctx, specRunID, limit)
+}
+
+// ExplainFailure is the resolver for the explainFailure field.
+func (r *queryResolver) ExplainFailure(ctx context.Context, specRunID string) (*model.FailureExplanation, error) {
+	return r.ExplainFailure_domain(ctx, specRunID)
+}
+
+// ExplainFailureCluster is the resolver for the explainFailureCluster field.
+func (r *queryResolver) ExplainFailureCluster(ctx context.Context, specRunIds []string) (*model.FailureExplanation, error) {
+	return r.ExplainFailureCluster_domain(ctx, specRunIds)
+}
+
 // JiraConnection is the resolver for the jiraConnection field.
 func (r *queryResolver) JiraConnection(ctx context.Context, id string) (*model.JiraConnection, error) {
 	conn, err := r.jiraConnectionService.GetConnection(ctx, id)
@@ -818,6 +907,36 @@ func (r *queryResolver) JiraConnections(ctx context.Context, projectID string) (
 	return models, nil
 }
 
+// JiraIssueLinks is the resolver for the jiraIssueLinks field.
+func (r *queryResolver) JiraIssueLinks(ctx context.Context, projectID string) ([]*model.JiraIssueLink, error) {
+	return r.JiraIssueLinks_domain(ctx, projectID)
+}
+
+// JiraFields is the resolver for the jiraFields field.
+func (r *queryResolver) JiraFields(ctx context.Context, connectionID string, refresh *bool) ([]*model.JiraField, error) {
+	return r.JiraFields_domain(ctx, connectionID, refresh)
+}
+
+// JiraIssueTypes is the resolver for the jiraIssueTypes field.
+func (r *queryResolver) JiraIssueTypes(ctx context.Context, connectionID string, refresh *bool) ([]*model.JiraIssueType, error) {
+	return r.JiraIssueTypes_domain(ctx, connectionID, refresh)
+}
+
+// JiraFieldMapping is the resolver for the jiraFieldMapping field.
+func (r *queryResolver) JiraFieldMapping(ctx context.Context, connectionID string) (*model.JiraFieldMapping, error) {
+	return r.JiraFieldMapping_domain(ctx, connectionID)
+}
+
+// IssueTrackerTypes is the resolver for the issueTrackerTypes field.
+func (r *queryResolver) IssueTrackerTypes(ctx context.Context) ([]*model.IssueTrackerType, error) {
+	return r.IssueTrackerTypes_domain(ctx)
+}
+
+// IssueTrackerConnections is the resolver for the issueTrackerConnections field.
+func (r *queryResolver) IssueTrackerConnections(ctx context.Context, projectID string) ([]*model.IssueTrackerConnection, error) {
+	return r.IssueTrackerConnections_domain(ctx, projectID)
+}
+
 // TestRunCreated is the resolver for the testRunCreated field.
 func (r *subscriptionResolver) TestRunCreated(ctx context.Context, projectID *string) (<-chan *model.TestRun, error) {
 	ch := make(chan *model.TestRun)
@@ -993,6 +1112,9 @@ func (r *testRunResolver) SuiteRuns(ctx context.Context, obj *model.TestRun) ([]
 	return result, nil
 }
 
+// FlakyTest returns generated.FlakyTestResolver implementation.
+func (r *Resolver) FlakyTest() generated.FlakyTestResolver { return &flakyTestResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -1011,6 +1133,7 @@ func (r *Resolver) SuiteRun() generated.SuiteRunResolver { return &suiteRunResol
 // TestRun returns generated.TestRunResolver implementation.
 func (r *Resolver) TestRun() generated.TestRunResolver { return &testRunResolver{r} }
 
+type flakyTestResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type projectResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	authApp "github.com/guidewire-oss/fern-platform/internal/domains/auth/application"
+	authDomain "github.com/guidewire-oss/fern-platform/internal/domains/auth/domain"
+	authInfra "github.com/guidewire-oss/fern-platform/internal/domains/auth/infrastructure"
+	"github.com/guidewire-oss/fern-platform/internal/reporter/graphql/model"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupUserAdminResolver returns a resolver managing three users, alice being an admin
+func setupUserAdminResolver(t *testing.T) *Resolver {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&database.User{}, &database.UserGroup{}, &database.UserScope{}, &database.UserSession{},
+		&database.ProjectAccess{}, &database.ProjectPermission{},
+		&database.JiraIssueLink{}, &database.JiraFieldMapping{},
+		&database.WebhookSubscription{}, &database.NotificationRule{}, &database.VCSConnection{},
+	))
+
+	userRepo := authInfra.NewGormUserRepository(db)
+	for _, user := range []*authDomain.User{
+		{UserID: "alice", Email: "alice@example.com", Name: "Alice", Role: authDomain.RoleAdmin, Status: authDomain.StatusActive},
+		{UserID: "bob", Email: "bob@example.com", Name: "Bob", Role: authDomain.RoleUser, Status: authDomain.StatusActive},
+		{UserID: "carol", Email: "carol@example.com", Name: "Carol", Role: authDomain.RoleUser, Status: authDomain.StatusActive},
+	} {
+		require.NoError(t, userRepo.Create(context.Background(), user))
+	}
+
+	resolver := setupTestResolver(t)
+	resolver.userAdminService = authApp.NewUserAdminService(userRepo, authInfra.NewGormSessionRepository(db))
+	return resolver
+}
+
+func userContext(userID string, role authDomain.UserRole) context.Context {
+	return context.WithValue(context.Background(), "user", &authDomain.User{UserID: userID, Role: role})
+}
+
+func TestUsers_PagesThroughUsersForAdmins(t *testing.T) {
+	resolver := setupUserAdminResolver(t)
+	ctx := userContext("alice", authDomain.RoleAdmin)
+	first := 2
+
+	page, err := (&queryResolver{resolver}).Users_domain(ctx, nil, &first, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.TotalCount)
+	require.Len(t, page.Edges, 2)
+	assert.Equal(t, "alice", page.Edges[0].Node.UserID)
+	assert.True(t, page.PageInfo.HasNextPage)
+
+	page, err = (&queryResolver{resolver}).Users_domain(ctx, nil, &first, page.PageInfo.EndCursor)
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 1)
+	assert.Equal(t, "carol", page.Edges[0].Node.UserID)
+	assert.False(t, page.PageInfo.HasNextPage)
+}
+
+func TestUsers_FiltersBySearch(t *testing.T) {
+	resolver := setupUserAdminResolver(t)
+	search := "BOB"
+
+	page, err := (&queryResolver{resolver}).Users_domain(userContext("alice", authDomain.RoleAdmin), &model.UserFilter{Search: &search}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 1)
+	assert.Equal(t, "bob", page.Edges[0].Node.UserID)
+}
+
+func TestUserAdministration_RequiresAdmin(t *testing.T) {
+	resolver := setupUserAdminResolver(t)
+	ctx := userContext("bob", authDomain.RoleUser)
+
+	_, err := (&queryResolver{resolver}).Users_domain(ctx, nil, nil, nil)
+	assert.EqualError(t, err, "admin access required")
+	_, err = (&mutationResolver{resolver}).SuspendUser_domain(ctx, "carol")
+	assert.EqualError(t, err, "admin access required")
+}
+
+func TestUserAdministration_ChangesUsersOnBehalfOfTheAdmin(t *testing.T) {
+	resolver := setupUserAdminResolver(t)
+	ctx := userContext("alice", authDomain.RoleAdmin)
+	mutations := &mutationResolver{resolver}
+
+	user, err := mutations.UpdateUserRole_domain(ctx, "bob", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", user.Role)
+	require.NotNil(t, user.RoleAssignedBy)
+	assert.Equal(t, "alice", *user.RoleAssignedBy)
+
+	user, err = mutations.SuspendUser_domain(ctx, "carol")
+	require.NoError(t, err)
+	assert.Equal(t, "suspended", user.Status)
+
+	deleted, err := mutations.DeleteUser_domain(ctx, "carol", nil)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	missing, err := (&queryResolver{resolver}).User_domain(ctx, "carol")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,3 @@
+-- Drop flaky_test_analyses table
+DROP TRIGGER IF EXISTS update_flaky_test_analyses_updated_at ON flaky_test_analyses;
+DROP TABLE IF EXISTS flaky_test_analyses CASCADE;
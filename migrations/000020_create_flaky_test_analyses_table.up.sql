@@ -0,0 +1,29 @@
+-- Create flaky_test_analyses table
+CREATE TABLE IF NOT EXISTS flaky_test_analyses (
+    id BIGSERIAL PRIMARY KEY,
+    project_id VARCHAR(255) NOT NULL,
+    test_run_id VARCHAR(255),
+    analyzed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_tests INTEGER DEFAULT 0,
+    new_flaky_count INTEGER DEFAULT 0,
+    still_flaky_count INTEGER DEFAULT 0,
+    resolved_flaky_count INTEGER DEFAULT 0,
+    -- Effective detection config used for this analysis
+    minimum_runs INTEGER NOT NULL,
+    min_failure_rate DECIMAL(5,4) NOT NULL,
+    max_failure_rate DECIMAL(5,4) NOT NULL,
+    analysis_window_seconds BIGINT NOT NULL,
+    consecutive_passes_for_resolution INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for flaky_test_analyses
+CREATE INDEX IF NOT EXISTS idx_flaky_test_analyses_project_id ON flaky_test_analyses(project_id);
+CREATE INDEX IF NOT EXISTS idx_flaky_test_analyses_test_run_id ON flaky_test_analyses(test_run_id);
+CREATE INDEX IF NOT EXISTS idx_flaky_test_analyses_analyzed_at ON flaky_test_analyses(analyzed_at);
+CREATE INDEX IF NOT EXISTS idx_flaky_test_analyses_deleted_at ON flaky_test_analyses(deleted_at);
+
+-- Add updated_at trigger
+CREATE TRIGGER update_flaky_test_analyses_updated_at BEFORE UPDATE ON flaky_test_analyses FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
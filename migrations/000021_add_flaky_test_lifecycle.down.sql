@@ -0,0 +1,6 @@
+-- Drop flaky_test_status_changes table
+DROP TABLE IF EXISTS flaky_test_status_changes CASCADE;
+
+-- Remove lifecycle columns from flaky_tests
+ALTER TABLE flaky_tests DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE flaky_tests DROP COLUMN IF EXISTS consecutive_passes;
@@ -0,0 +1,20 @@
+-- Track consecutive passes and the time of the last status change on flaky tests
+ALTER TABLE flaky_tests ADD COLUMN IF NOT EXISTS consecutive_passes INTEGER DEFAULT 0;
+ALTER TABLE flaky_tests ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE;
+
+-- Create flaky_test_status_changes table (append-only status history)
+CREATE TABLE IF NOT EXISTS flaky_test_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    flaky_test_id BIGINT NOT NULL REFERENCES flaky_tests(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL CHECK (actor_type IN ('system', 'user')),
+    actor_id VARCHAR(255),
+    reason TEXT,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for flaky_test_status_changes
+CREATE INDEX IF NOT EXISTS idx_flaky_test_status_changes_flaky_test_id ON flaky_test_status_changes(flaky_test_id);
+CREATE INDEX IF NOT EXISTS idx_flaky_test_status_changes_changed_at ON flaky_test_status_changes(changed_at);
@@ -0,0 +1,7 @@
+-- Drop owner indexes
+DROP INDEX IF EXISTS idx_flaky_tests_owners;
+DROP INDEX IF EXISTS idx_spec_runs_owners;
+
+-- Remove owner columns
+ALTER TABLE flaky_tests DROP COLUMN IF EXISTS owners;
+ALTER TABLE spec_runs DROP COLUMN IF EXISTS owners;
@@ -0,0 +1,7 @@
+-- Store the owners resolved from project ownership rules
+ALTER TABLE spec_runs ADD COLUMN IF NOT EXISTS owners JSONB DEFAULT '[]';
+ALTER TABLE flaky_tests ADD COLUMN IF NOT EXISTS owners JSONB DEFAULT '[]';
+
+-- Create indexes for owner filtering
+CREATE INDEX IF NOT EXISTS idx_spec_runs_owners ON spec_runs USING GIN (owners);
+CREATE INDEX IF NOT EXISTS idx_flaky_tests_owners ON flaky_tests USING GIN (owners);
@@ -0,0 +1,2 @@
+-- Drop jira_issue_links table
+DROP TABLE IF EXISTS jira_issue_links CASCADE;
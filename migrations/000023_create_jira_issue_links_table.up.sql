@@ -0,0 +1,25 @@
+-- Create jira_issue_links table (JIRA issues filed for failures and flaky tests)
+CREATE TABLE IF NOT EXISTS jira_issue_links (
+    id BIGSERIAL PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL REFERENCES project_details(project_id) ON DELETE CASCADE,
+    connection_id VARCHAR(36) NOT NULL,
+    issue_key VARCHAR(50) NOT NULL,
+    issue_url VARCHAR(600) NOT NULL,
+    source_type VARCHAR(50) NOT NULL CHECK (source_type IN ('spec_run', 'failure_cluster', 'flaky_test')),
+    target_type VARCHAR(50) NOT NULL CHECK (target_type IN ('failure', 'flaky_test')),
+    target_id VARCHAR(64) NOT NULL,
+    test_name TEXT,
+    suite_name TEXT,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A failure or flaky test is filed at most once per project
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jira_issue_link_target ON jira_issue_links(project_id, target_type, target_id) WHERE deleted_at IS NULL;
+
+-- Create indexes for jira_issue_links
+CREATE INDEX IF NOT EXISTS idx_jira_issue_links_project_id ON jira_issue_links(project_id);
+CREATE INDEX IF NOT EXISTS idx_jira_issue_links_issue_key ON jira_issue_links(issue_key);
+CREATE INDEX IF NOT EXISTS idx_jira_issue_links_deleted_at ON jira_issue_links(deleted_at);
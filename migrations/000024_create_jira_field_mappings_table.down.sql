@@ -0,0 +1,2 @@
+-- Drop jira_field_mappings table
+DROP TABLE IF EXISTS jira_field_mappings CASCADE;
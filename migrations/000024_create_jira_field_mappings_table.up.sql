@@ -0,0 +1,19 @@
+-- Create jira_field_mappings table (Fern fields mapped onto the JIRA fields of a connection)
+CREATE TABLE IF NOT EXISTS jira_field_mappings (
+    id BIGSERIAL PRIMARY KEY,
+    connection_id VARCHAR(36) NOT NULL,
+    project_id VARCHAR(36) NOT NULL REFERENCES project_details(project_id) ON DELETE CASCADE,
+    issue_type VARCHAR(255) NOT NULL,
+    field_mappings JSONB NOT NULL DEFAULT '{}',
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A connection has at most one mapping
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jira_field_mapping_connection ON jira_field_mappings(connection_id) WHERE deleted_at IS NULL;
+
+-- Create indexes for jira_field_mappings
+CREATE INDEX IF NOT EXISTS idx_jira_field_mappings_project_id ON jira_field_mappings(project_id);
+CREATE INDEX IF NOT EXISTS idx_jira_field_mappings_deleted_at ON jira_field_mappings(deleted_at);
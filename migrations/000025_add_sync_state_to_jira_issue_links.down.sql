@@ -0,0 +1,7 @@
+-- Remove sync state columns from jira_issue_links table
+DROP INDEX IF EXISTS idx_jira_issue_links_connection_id;
+ALTER TABLE jira_issue_links DROP COLUMN IF EXISTS reflake_notified_at;
+ALTER TABLE jira_issue_links DROP COLUMN IF EXISTS synced_at;
+ALTER TABLE jira_issue_links DROP COLUMN IF EXISTS issue_resolved_at;
+ALTER TABLE jira_issue_links DROP COLUMN IF EXISTS issue_status_category;
+ALTER TABLE jira_issue_links DROP COLUMN IF EXISTS issue_status;
@@ -0,0 +1,9 @@
+-- Add sync state columns to jira_issue_links table for the issue sync job
+ALTER TABLE jira_issue_links ADD COLUMN IF NOT EXISTS issue_status VARCHAR(255);
+ALTER TABLE jira_issue_links ADD COLUMN IF NOT EXISTS issue_status_category VARCHAR(50);
+ALTER TABLE jira_issue_links ADD COLUMN IF NOT EXISTS issue_resolved_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jira_issue_links ADD COLUMN IF NOT EXISTS synced_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE jira_issue_links ADD COLUMN IF NOT EXISTS reflake_notified_at TIMESTAMP WITH TIME ZONE;
+
+-- The sync job loads links per connection
+CREATE INDEX IF NOT EXISTS idx_jira_issue_links_connection_id ON jira_issue_links(connection_id);
@@ -0,0 +1,2 @@
+-- Drop tracker_connections table
+DROP TABLE IF EXISTS tracker_connections CASCADE;
@@ -0,0 +1,24 @@
+-- Create tracker_connections table (issue tracker connections of any registered connector type)
+CREATE TABLE IF NOT EXISTS tracker_connections (
+    id BIGSERIAL PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL REFERENCES project_details(project_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    connector_type VARCHAR(50) NOT NULL,
+    config JSONB NOT NULL DEFAULT '{}',
+    encrypted_credential TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'connected', 'failed')),
+    last_error TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT FALSE,
+    last_tested_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for tracker_connections
+CREATE INDEX IF NOT EXISTS idx_tracker_connections_project_id ON tracker_connections(project_id);
+CREATE INDEX IF NOT EXISTS idx_tracker_connections_deleted_at ON tracker_connections(deleted_at);
+
+COMMENT ON TABLE tracker_connections IS 'Stores issue tracker connections (JIRA, GitHub Issues, Linear) for projects';
+COMMENT ON COLUMN tracker_connections.config IS 'Non-secret connector settings, validated against the connector type schema';
+COMMENT ON COLUMN tracker_connections.encrypted_credential IS 'Encrypted authentication credential (token/API key)';
@@ -0,0 +1,5 @@
+-- Remove OAuth token state and the failure reason from jira_connections
+ALTER TABLE jira_connections DROP COLUMN IF EXISTS oauth_token_expires_at;
+ALTER TABLE jira_connections DROP COLUMN IF EXISTS encrypted_oauth_refresh_token;
+ALTER TABLE jira_connections DROP COLUMN IF EXISTS oauth_cloud_id;
+ALTER TABLE jira_connections DROP COLUMN IF EXISTS status_reason;
@@ -0,0 +1,11 @@
+-- Add OAuth 2.0 (3LO) token state and a failure reason to jira_connections.
+-- The OAuth access token is stored encrypted in encrypted_credential.
+ALTER TABLE jira_connections ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE jira_connections ADD COLUMN IF NOT EXISTS oauth_cloud_id VARCHAR(100);
+ALTER TABLE jira_connections ADD COLUMN IF NOT EXISTS encrypted_oauth_refresh_token TEXT;
+ALTER TABLE jira_connections ADD COLUMN IF NOT EXISTS oauth_token_expires_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN jira_connections.status_reason IS 'Why the connection failed, shown to users';
+COMMENT ON COLUMN jira_connections.oauth_cloud_id IS 'Atlassian site the OAuth authorization was granted for';
+COMMENT ON COLUMN jira_connections.encrypted_oauth_refresh_token IS 'Encrypted OAuth refresh token';
+COMMENT ON COLUMN jira_connections.oauth_token_expires_at IS 'When the OAuth access token expires';
@@ -0,0 +1,3 @@
+-- Drop notification tables
+DROP TABLE IF EXISTS notification_deliveries CASCADE;
+DROP TABLE IF EXISTS notification_rules CASCADE;
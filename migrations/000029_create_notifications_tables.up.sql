@@ -0,0 +1,58 @@
+-- Create notification_rules table (Slack and Microsoft Teams routing rules)
+CREATE TABLE IF NOT EXISTS notification_rules (
+    id BIGSERIAL PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL REFERENCES project_details(project_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    channel_type VARCHAR(20) NOT NULL CHECK (channel_type IN ('slack', 'teams')),
+    encrypted_webhook_url TEXT NOT NULL,
+    events VARCHAR(500) NOT NULL,
+    branches JSONB DEFAULT '[]',
+    environments JSONB DEFAULT '[]',
+    statuses JSONB DEFAULT '[]',
+    tags JSONB DEFAULT '[]',
+    severities JSONB DEFAULT '[]',
+    quiet_hours_start VARCHAR(5),
+    quiet_hours_end VARCHAR(5),
+    quiet_hours_timezone VARCHAR(64),
+    dedup_window_seconds BIGINT NOT NULL DEFAULT 0,
+    title_template TEXT,
+    text_template TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_rules_project_id ON notification_rules(project_id);
+CREATE INDEX IF NOT EXISTS idx_notification_rules_deleted_at ON notification_rules(deleted_at);
+
+-- Create notification_deliveries table (notification queue and log)
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES notification_rules(id) ON DELETE CASCADE,
+    project_id VARCHAR(36),
+    event_type VARCHAR(50) NOT NULL,
+    dedup_key VARCHAR(500) NOT NULL,
+    title TEXT,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'suppressed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER,
+    last_error TEXT,
+    held_until TIMESTAMP WITH TIME ZONE,
+    suppressed_by BIGINT REFERENCES notification_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_rule_id ON notification_deliveries(rule_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_dedup ON notification_deliveries(rule_id, dedup_key);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_created_at ON notification_deliveries(created_at);
+
+COMMENT ON TABLE notification_rules IS 'Per-project rules posting matching events to Slack or Microsoft Teams channels';
+COMMENT ON COLUMN notification_rules.encrypted_webhook_url IS 'Encrypted incoming webhook URL of the channel';
+COMMENT ON COLUMN notification_rules.dedup_window_seconds IS 'Repeats of a notification within this window are suppressed; 0 disables deduplication';
+COMMENT ON TABLE notification_deliveries IS 'Queue of pending notifications and log of sent, failed and suppressed ones';
@@ -0,0 +1,3 @@
+-- Drop VCS reporting tables
+DROP TABLE IF EXISTS vcs_reports CASCADE;
+DROP TABLE IF EXISTS vcs_connections CASCADE;
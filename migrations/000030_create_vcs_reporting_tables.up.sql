@@ -0,0 +1,54 @@
+-- Create vcs_connections table (GitHub and GitLab repositories test runs are reported to)
+CREATE TABLE IF NOT EXISTS vcs_connections (
+    id BIGSERIAL PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL REFERENCES project_details(project_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    api_url TEXT NOT NULL,
+    repository VARCHAR(500) NOT NULL,
+    status_context VARCHAR(255) NOT NULL,
+    status_mode VARCHAR(20) NOT NULL DEFAULT 'commit_status' CHECK (status_mode IN ('commit_status', 'check_run')),
+    comment_on_pull_requests BOOLEAN NOT NULL DEFAULT TRUE,
+    encrypted_token TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    last_error TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_tested_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_vcs_connections_project_id ON vcs_connections(project_id);
+CREATE INDEX IF NOT EXISTS idx_vcs_connections_deleted_at ON vcs_connections(deleted_at);
+
+-- Create vcs_reports table (report queue and log)
+CREATE TABLE IF NOT EXISTS vcs_reports (
+    id BIGSERIAL PRIMARY KEY,
+    connection_id BIGINT NOT NULL REFERENCES vcs_connections(id) ON DELETE CASCADE,
+    project_id VARCHAR(36),
+    test_run_id BIGINT NOT NULL,
+    run_id VARCHAR(255),
+    commit_sha VARCHAR(64) NOT NULL,
+    final BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    state VARCHAR(20),
+    comment_urls JSONB DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vcs_reports_connection_id ON vcs_reports(connection_id);
+CREATE INDEX IF NOT EXISTS idx_vcs_reports_run ON vcs_reports(connection_id, test_run_id);
+CREATE INDEX IF NOT EXISTS idx_vcs_reports_due ON vcs_reports(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_vcs_reports_created_at ON vcs_reports(created_at);
+
+COMMENT ON TABLE vcs_connections IS 'Per-project GitHub and GitLab repositories test runs are reported to as commit statuses and pull request comments';
+COMMENT ON COLUMN vcs_connections.encrypted_token IS 'Encrypted access token of the repository';
+COMMENT ON COLUMN vcs_connections.status_mode IS 'commit_status sets commit statuses; check_run creates GitHub check runs';
+COMMENT ON TABLE vcs_reports IS 'Queue of pending test run reports and log of sent and failed ones';
+COMMENT ON COLUMN vcs_reports.final IS 'Queued when the test run finished rather than when it started';
@@ -0,0 +1,3 @@
+-- Drop email digest tables and preferences
+DROP TABLE IF EXISTS digest_deliveries CASCADE;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS email_digest_opt_out;
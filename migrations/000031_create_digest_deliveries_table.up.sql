@@ -0,0 +1,24 @@
+-- Let users opt out of email digests
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS email_digest_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create digest_deliveries table (email digests sent to users, one per user and scheduled time)
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+    projects INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_deliveries_user_period ON digest_deliveries(user_id, period_end);
+CREATE INDEX IF NOT EXISTS idx_digest_deliveries_period_end ON digest_deliveries(period_end);
+
+COMMENT ON COLUMN user_preferences.email_digest_opt_out IS 'Set when the user unsubscribed from email digests';
+COMMENT ON TABLE digest_deliveries IS 'Email digests of project test health, so each user gets at most one per scheduled time';
+COMMENT ON COLUMN digest_deliveries.period_end IS 'Scheduled time the digest was sent for; it covers the period before';
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE users DROP COLUMN IF EXISTS role_assigned_by;
+ALTER TABLE users DROP COLUMN IF EXISTS updated_by;
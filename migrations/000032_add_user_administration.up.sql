@@ -0,0 +1,8 @@
+-- Record which admin changed, pinned the role of, or deleted a user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role_assigned_by VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(255);
+
+COMMENT ON COLUMN users.updated_by IS 'Admin who last changed the role or status of the user';
+COMMENT ON COLUMN users.role_assigned_by IS 'Admin who assigned the role; while set, sign-in keeps the role instead of deriving it from groups';
+COMMENT ON COLUMN users.deleted_by IS 'Admin who deleted the user';
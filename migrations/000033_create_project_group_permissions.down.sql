@@ -0,0 +1,3 @@
+-- Drop project group permissions
+DROP INDEX IF EXISTS idx_project_permissions_expires_at;
+DROP TABLE IF EXISTS project_group_permissions CASCADE;
@@ -0,0 +1,23 @@
+-- Create project_group_permissions table (project permissions granted to every member of a group)
+CREATE TABLE IF NOT EXISTS project_group_permissions (
+    id SERIAL PRIMARY KEY,
+    project_id VARCHAR(255) NOT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    permission VARCHAR(50) NOT NULL CHECK (permission IN ('read', 'write', 'delete', 'admin')),
+    granted_by VARCHAR(255),
+    granted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_group_permission ON project_group_permissions(project_id, group_name, permission);
+CREATE INDEX IF NOT EXISTS idx_project_group_permissions_group_name ON project_group_permissions(group_name);
+CREATE INDEX IF NOT EXISTS idx_project_group_permissions_expires_at ON project_group_permissions(expires_at);
+
+-- Expired user permissions are removed in the background
+CREATE INDEX IF NOT EXISTS idx_project_permissions_expires_at ON project_permissions(expires_at);
+
+COMMENT ON TABLE project_group_permissions IS 'Project permissions granted to every member of an identity provider group';
+COMMENT ON COLUMN project_group_permissions.group_name IS 'Group name without the leading slash some identity providers add';
+COMMENT ON COLUMN project_group_permissions.expires_at IS 'Permission stops applying at this time and is then removed; NULL never expires';
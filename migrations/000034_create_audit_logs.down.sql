@@ -0,0 +1,4 @@
+-- Drop audit_logs table
+DROP TRIGGER IF EXISTS prevent_audit_logs_update ON audit_logs;
+DROP FUNCTION IF EXISTS prevent_audit_log_update();
+DROP TABLE IF EXISTS audit_logs CASCADE;
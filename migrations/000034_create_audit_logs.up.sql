@@ -0,0 +1,34 @@
+-- Create audit_logs table (who changed what, and sign-ins and sign-outs)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor_id VARCHAR(255),
+    actor_email VARCHAR(255),
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id VARCHAR(255),
+    changes JSONB,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('rest', 'graphql', 'auth')),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    request_id VARCHAR(100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_occurred_at ON audit_logs(occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action, occurred_at);
+
+-- Entries are append-only: they can be removed by the retention job, never changed
+CREATE OR REPLACE FUNCTION prevent_audit_log_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log entries cannot be changed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER prevent_audit_logs_update BEFORE UPDATE ON audit_logs FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_update();
+
+COMMENT ON TABLE audit_logs IS 'Append-only record of changes made through the REST and GraphQL APIs, and of sign-ins and sign-outs';
+COMMENT ON COLUMN audit_logs.changes IS 'Fields of the target that changed, with their values before and after; secrets are redacted';
+COMMENT ON COLUMN audit_logs.source IS 'rest, graphql, or auth for sign-ins and sign-outs';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_sessions_user_active;
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS token_expires_at;
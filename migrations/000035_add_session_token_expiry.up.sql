@@ -0,0 +1,5 @@
+-- Record when each session's OAuth access token expires so it can be refreshed
+ALTER TABLE user_sessions ADD COLUMN token_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Sessions are listed per user, most recently used first
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_active ON user_sessions(user_id, is_active, last_activity DESC);
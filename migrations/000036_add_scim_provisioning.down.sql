@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS groups;
+
+DROP INDEX IF EXISTS idx_users_external_id;
+DROP INDEX IF EXISTS idx_users_user_name;
+ALTER TABLE users DROP COLUMN IF EXISTS external_id;
+ALTER TABLE users DROP COLUMN IF EXISTS user_name;
@@ -0,0 +1,27 @@
+-- Identify users provisioned by the identity provider through SCIM
+ALTER TABLE users ADD COLUMN IF NOT EXISTS user_name VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_users_user_name ON users(LOWER(user_name));
+CREATE INDEX IF NOT EXISTS idx_users_external_id ON users(external_id);
+
+COMMENT ON COLUMN users.user_name IS 'SCIM user name; users without one are known by their email';
+COMMENT ON COLUMN users.external_id IS 'ID of the user in the identity provider, set through SCIM';
+
+-- Create groups table (groups provisioned through SCIM, whose members are the user_groups of the same name)
+CREATE TABLE IF NOT EXISTS groups (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    group_id VARCHAR(255) NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL UNIQUE,
+    external_id VARCHAR(255)
+);
+
+CREATE INDEX IF NOT EXISTS idx_groups_deleted_at ON groups(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_groups_external_id ON groups(external_id);
+
+COMMENT ON TABLE groups IS 'Identity provider groups provisioned through SCIM';
+COMMENT ON COLUMN groups.group_id IS 'SCIM ID, which stays the same when the group is renamed';
+COMMENT ON COLUMN groups.display_name IS 'Group name, matching user_groups.group_name of its members';
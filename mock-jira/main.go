@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -109,6 +111,86 @@ type Health struct {
 	Passed      bool   `json:"passed"`
 }
 
+type IssueFields struct {
+	Project     ProjectRef `json:"project"`
+	IssueType   IssueRef   `json:"issuetype"`
+	Summary     string     `json:"summary"`
+	Description string     `json:"description,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Created     string     `json:"created,omitempty"`
+	Status      *Status    `json:"status,omitempty"`
+	// ResolutionDate is set while the issue is in a done status
+	ResolutionDate string `json:"resolutiondate,omitempty"`
+}
+
+type Status struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	StatusCategory StatusCategory `json:"statusCategory"`
+}
+
+type StatusCategory struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   Status `json:"to"`
+}
+
+type TransitionRequest struct {
+	Transition struct {
+		ID string `json:"id"`
+	} `json:"transition"`
+}
+
+type Comment struct {
+	ID      string `json:"id"`
+	Body    string `json:"body"`
+	Created string `json:"created"`
+}
+
+type SearchRequest struct {
+	JQL        string `json:"jql"`
+	MaxResults int    `json:"maxResults"`
+}
+
+type SearchResponse struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+type ProjectRef struct {
+	ID  string `json:"id,omitempty"`
+	Key string `json:"key,omitempty"`
+}
+
+type IssueRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type CreateIssueRequest struct {
+	Fields IssueFields `json:"fields"`
+}
+
+type CreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Self   string      `json:"self"`
+	Fields IssueFields `json:"fields"`
+}
+
 type ErrorResponse struct {
 	ErrorMessages []string          `json:"errorMessages"`
 	Errors        map[string]string `json:"errors"`
@@ -177,6 +259,40 @@ var projects = map[string]JiraProject{
 	},
 }
 
+// Issues created through the API, kept in memory for the lifetime of the server
+var (
+	issuesMu      sync.Mutex
+	issues        = map[string]Issue{}
+	issueComments = map[string][]Comment{}
+	issueCounter  = map[string]int{}
+	nextIssueID   = 10000
+	nextCommentID = 20000
+)
+
+// A simple workflow: Open -> In Progress -> Closed, and Closed -> Reopened
+var (
+	statusOpen       = Status{ID: "1", Name: "Open", StatusCategory: StatusCategory{Key: "new", Name: "To Do"}}
+	statusInProgress = Status{ID: "3", Name: "In Progress", StatusCategory: StatusCategory{Key: "indeterminate", Name: "In Progress"}}
+	statusClosed     = Status{ID: "6", Name: "Closed", StatusCategory: StatusCategory{Key: "done", Name: "Done"}}
+	statusReopened   = Status{ID: "4", Name: "Reopened", StatusCategory: StatusCategory{Key: "new", Name: "To Do"}}
+)
+
+// transitionsFor returns the transitions available from a status
+func transitionsFor(status *Status) []Transition {
+	if status != nil && status.StatusCategory.Key == "done" {
+		return []Transition{{ID: "31", Name: "Reopen Issue", To: statusReopened}}
+	}
+	return []Transition{
+		{ID: "11", Name: "Start Progress", To: statusInProgress},
+		{ID: "21", Name: "Close Issue", To: statusClosed},
+	}
+}
+
+// jiraTimestamp formats a time the way the JIRA REST API does
+func jiraTimestamp(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05.000-0700")
+}
+
 func authenticate(r *http.Request) bool {
 	auth := r.Header.Get("Authorization")
 	if auth == "" {
@@ -341,6 +457,28 @@ func handleFields(w http.ResponseWriter, r *http.Request) {
 			ClauseNames: []string{"summary"},
 			Schema:     FieldSchema{Type: "string", System: "summary"},
 		},
+		{
+			ID:         "description",
+			Key:        "description",
+			Name:       "Description",
+			Custom:     false,
+			Orderable:  true,
+			Navigable:  true,
+			Searchable: true,
+			ClauseNames: []string{"description"},
+			Schema:     FieldSchema{Type: "string", System: "description"},
+		},
+		{
+			ID:         "labels",
+			Key:        "labels",
+			Name:       "Labels",
+			Custom:     false,
+			Orderable:  true,
+			Navigable:  true,
+			Searchable: true,
+			ClauseNames: []string{"labels"},
+			Schema:     FieldSchema{Type: "array", Items: "string", System: "labels"},
+		},
 		{
 			ID:         "issuetype",
 			Key:        "issuetype",
@@ -401,6 +539,239 @@ func handleIssueTypes(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(getIssueTypes())
 }
 
+func handleCreateIssue(w http.ResponseWriter, r *http.Request) {
+	if !authenticate(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req CreateIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	fieldErrors := make(map[string]string)
+	project, exists := projects[req.Fields.Project.Key]
+	if !exists {
+		fieldErrors["project"] = "valid project is required"
+	}
+	validType := false
+	for _, issueType := range getIssueTypes() {
+		if issueType.Name == req.Fields.IssueType.Name || (req.Fields.IssueType.ID != "" && issueType.ID == req.Fields.IssueType.ID) {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		fieldErrors["issuetype"] = "valid issue type is required"
+	}
+	if strings.TrimSpace(req.Fields.Summary) == "" {
+		fieldErrors["summary"] = "You must specify a summary of the issue."
+	}
+	if len(fieldErrors) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			ErrorMessages: []string{},
+			Errors:        fieldErrors,
+		})
+		return
+	}
+
+	issuesMu.Lock()
+	issueCounter[project.Key]++
+	nextIssueID++
+	req.Fields.Created = jiraTimestamp(time.Now())
+	status := statusOpen
+	req.Fields.Status = &status
+	issue := Issue{
+		ID:     fmt.Sprintf("%d", nextIssueID),
+		Key:    fmt.Sprintf("%s-%d", project.Key, issueCounter[project.Key]),
+		Fields: req.Fields,
+	}
+	issue.Self = fmt.Sprintf("https://fern-platform.atlassian.net/rest/api/2/issue/%s", issue.ID)
+	issues[issue.Key] = issue
+	issuesMu.Unlock()
+
+	log.Printf("Created issue %s: %s", issue.Key, issue.Fields.Summary)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CreatedIssue{ID: issue.ID, Key: issue.Key, Self: issue.Self})
+}
+
+func handleIssue(w http.ResponseWriter, r *http.Request) {
+	if !authenticate(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+	issueKey, resource, _ := strings.Cut(path, "/")
+
+	issuesMu.Lock()
+	defer issuesMu.Unlock()
+
+	issue, exists := issues[issueKey]
+	if !exists {
+		writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+
+	switch resource {
+	case "":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(issue)
+	case "comment":
+		handleComment(w, r, issue)
+	case "transitions":
+		handleTransitions(w, r, issue)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// handleComment lists or adds comments; the caller holds issuesMu
+func handleComment(w http.ResponseWriter, r *http.Request, issue Issue) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodGet {
+		comments := issueComments[issue.Key]
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt":    0,
+			"maxResults": len(comments),
+			"total":      len(comments),
+			"comments":   comments,
+		})
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var comment Comment
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil || strings.TrimSpace(comment.Body) == "" {
+		writeError(w, http.StatusBadRequest, "Comment body can not be empty!")
+		return
+	}
+	nextCommentID++
+	comment.ID = fmt.Sprintf("%d", nextCommentID)
+	comment.Created = jiraTimestamp(time.Now())
+	issueComments[issue.Key] = append(issueComments[issue.Key], comment)
+
+	log.Printf("Commented on issue %s", issue.Key)
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleTransitions lists or performs workflow transitions; the caller holds issuesMu
+func handleTransitions(w http.ResponseWriter, r *http.Request, issue Issue) {
+	available := transitionsFor(issue.Fields.Status)
+
+	if r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"transitions": available})
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	for _, transition := range available {
+		if transition.ID != req.Transition.ID {
+			continue
+		}
+		status := transition.To
+		issue.Fields.Status = &status
+		issue.Fields.ResolutionDate = ""
+		if status.StatusCategory.Key == "done" {
+			issue.Fields.ResolutionDate = jiraTimestamp(time.Now())
+		}
+		issues[issue.Key] = issue
+
+		log.Printf("Moved issue %s to %s", issue.Key, status.Name)
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("Transition id '%s' is not valid for this issue.", req.Transition.ID))
+}
+
+// handleSearch supports "key in (...)" JQL queries; any other query matches every issue
+func handleSearch(w http.ResponseWriter, r *http.Request) {
+	if !authenticate(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req SearchRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.JQL = r.URL.Query().Get("jql")
+		fmt.Sscanf(r.URL.Query().Get("maxResults"), "%d", &req.MaxResults)
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if req.MaxResults <= 0 {
+		req.MaxResults = 50
+	}
+
+	var keys []string
+	jql := strings.TrimSpace(req.JQL)
+	if strings.HasPrefix(strings.ToLower(jql), "key in (") {
+		list := jql[len("key in ("):]
+		if end := strings.Index(list, ")"); end >= 0 {
+			list = list[:end]
+		}
+		for _, key := range strings.Split(list, ",") {
+			keys = append(keys, strings.Trim(strings.TrimSpace(key), `"`))
+		}
+	}
+
+	issuesMu.Lock()
+	var found []Issue
+	if keys == nil {
+		for _, issue := range issues {
+			found = append(found, issue)
+		}
+	} else {
+		for _, key := range keys {
+			if issue, ok := issues[key]; ok {
+				found = append(found, issue)
+			}
+		}
+	}
+	issuesMu.Unlock()
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Key < found[j].Key })
+	total := len(found)
+	if len(found) > req.MaxResults {
+		found = found[:req.MaxResults]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SearchResponse{StartAt: 0, MaxResults: req.MaxResults, Total: total, Issues: found})
+}
+
 func handleServerInfo(w http.ResponseWriter, r *http.Request) {
 	serverInfo := ServerInfo{
 		BaseUrl:        "https://fern-platform.atlassian.net",
@@ -435,6 +806,11 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 			"/rest/api/2/project",
 			"/rest/api/2/field",
 			"/rest/api/2/issuetype",
+			"/rest/api/2/issue",
+			"/rest/api/2/issue/{issueKey}",
+			"/rest/api/2/issue/{issueKey}/comment",
+			"/rest/api/2/issue/{issueKey}/transitions",
+			"/rest/api/2/search",
 			"/rest/api/2/serverInfo",
 		},
 		"authentication": map[string]interface{}{
@@ -477,6 +853,9 @@ func main() {
 	mux.HandleFunc("/rest/api/2/project", enableCORS(handleProjects))
 	mux.HandleFunc("/rest/api/2/field", enableCORS(handleFields))
 	mux.HandleFunc("/rest/api/2/issuetype", enableCORS(handleIssueTypes))
+	mux.HandleFunc("/rest/api/2/issue", enableCORS(handleCreateIssue))
+	mux.HandleFunc("/rest/api/2/issue/", enableCORS(handleIssue))
+	mux.HandleFunc("/rest/api/2/search", enableCORS(handleSearch))
 	mux.HandleFunc("/rest/api/2/serverInfo", enableCORS(handleServerInfo))
 
 	log.Println("Mock JIRA Cloud Server starting on :8080")
@@ -12,14 +12,17 @@ import (
 
 // Config represents the complete platform configuration
 type Config struct {
-	Server     ServerConfig     `mapstructure:"server"`
-	Database   DatabaseConfig   `mapstructure:"database"`
-	Auth       AuthConfig       `mapstructure:"auth"`
-	Logging    LoggingConfig    `mapstructure:"logging"`
-	Services   ServicesConfig   `mapstructure:"services"`
-	Redis      RedisConfig      `mapstructure:"redis"`
-	LLM        LLMConfig        `mapstructure:"llm"`
-	Monitoring MonitoringConfig `mapstructure:"monitoring"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Database     DatabaseConfig     `mapstructure:"database"`
+	Auth         AuthConfig         `mapstructure:"auth"`
+	Logging      LoggingConfig      `mapstructure:"logging"`
+	Services     ServicesConfig     `mapstructure:"services"`
+	Redis        RedisConfig        `mapstructure:"redis"`
+	LLM          LLMConfig          `mapstructure:"llm"`
+	Integrations IntegrationsConfig `mapstructure:"integrations"`
+	Monitoring   MonitoringConfig   `mapstructure:"monitoring"`
+	Audit        AuditConfig        `mapstructure:"audit"`
+	RateLimit    RateLimitConfig    `mapstructure:"rateLimit"`
 }
 
 type ServerConfig struct {
@@ -62,6 +65,30 @@ type AuthConfig struct {
 	TokenExpiry   time.Duration `mapstructure:"tokenExpiry"`
 	RefreshExpiry time.Duration `mapstructure:"refreshExpiry"`
 	OAuth         OAuthConfig   `mapstructure:"oauth"`
+	Session       SessionConfig `mapstructure:"session"`
+	SCIM          SCIMConfig    `mapstructure:"scim"`
+}
+
+// SCIMConfig lets the identity provider provision users and groups through
+// the SCIM 2.0 API at /scim/v2
+type SCIMConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Token is the bearer token the identity provider authenticates with
+	Token string `mapstructure:"token"`
+}
+
+// minSCIMTokenLength is the length of the shortest SCIM token accepted
+const minSCIMTokenLength = 32
+
+// SessionConfig bounds how long browser sessions last after sign-in
+type SessionConfig struct {
+	// IdleTimeout ends sessions unused for this long; zero disables it
+	IdleTimeout time.Duration `mapstructure:"idleTimeout"`
+	// AbsoluteLifetime ends sessions this long after sign-in, however active
+	AbsoluteLifetime time.Duration `mapstructure:"absoluteLifetime"`
+	// RefreshBefore is how long before the OAuth access token expires it is
+	// refreshed with the session's refresh token
+	RefreshBefore time.Duration `mapstructure:"refreshBefore"`
 }
 
 type OAuthConfig struct {
@@ -143,6 +170,76 @@ type LLMProvider struct {
 	Enabled bool   `mapstructure:"enabled"`
 }
 
+type IntegrationsConfig struct {
+	Jira       JiraIntegrationConfig      `mapstructure:"jira"`
+	Encryption CredentialEncryptionConfig `mapstructure:"encryption"`
+	Email      EmailConfig                `mapstructure:"email"`
+}
+
+// EmailConfig configures outgoing email and the digests sent with it
+type EmailConfig struct {
+	SMTP   SMTPConfig   `mapstructure:"smtp"`
+	Digest DigestConfig `mapstructure:"digest"`
+}
+
+// SMTPConfig configures the SMTP server email is sent through
+type SMTPConfig struct {
+	Host     string        `mapstructure:"host"`
+	Port     int           `mapstructure:"port"`
+	Username string        `mapstructure:"username"` // Leave empty for servers without authentication
+	Password string        `mapstructure:"password"`
+	From     string        `mapstructure:"from"` // Sender address, optionally with a name: "Fern <fern@example.com>"
+	TLS      string        `mapstructure:"tls"`  // none, starttls or tls (implicit TLS, usually port 465)
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// DigestConfig schedules the email digests of project test health. Digests
+// are sent once per period, at the first check after the scheduled time.
+type DigestConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Weekday  string `mapstructure:"weekday"`  // Day of the week, such as "monday"; empty sends daily digests
+	Hour     int    `mapstructure:"hour"`     // Hour of the day, 0-23
+	TimeZone string `mapstructure:"timeZone"` // IANA time zone of the schedule (default: UTC)
+}
+
+// CredentialEncryptionConfig configures the key ring integration credentials
+// are encrypted with. Key material is loaded from files or environment
+// variables, never from the config itself.
+type CredentialEncryptionConfig struct {
+	PrimaryKeyID string                `mapstructure:"primaryKeyId"` // New credentials are encrypted with this key
+	LegacyKeyID  string                `mapstructure:"legacyKeyId"`  // Decrypts credentials stored before key IDs were recorded
+	Keys         []EncryptionKeyConfig `mapstructure:"keys"`
+}
+
+// EncryptionKeyConfig says where an encryption key is loaded from. The file or
+// environment variable holds either the raw key or "base64:" followed by its
+// base64 encoding.
+type EncryptionKeyConfig struct {
+	ID   string `mapstructure:"id"`
+	File string `mapstructure:"file"`
+	Env  string `mapstructure:"env"`
+}
+
+type JiraIntegrationConfig struct {
+	OAuth JiraOAuthConfig `mapstructure:"oauth"`
+}
+
+// JiraOAuthConfig configures the Atlassian OAuth 2.0 (3LO) app that JIRA
+// connections are authorized with. OAuth connections are unavailable when no
+// client ID is set.
+type JiraOAuthConfig struct {
+	ClientID     string   `mapstructure:"clientId"`
+	ClientSecret string   `mapstructure:"clientSecret"`
+	RedirectURL  string   `mapstructure:"redirectUrl"` // Must point at /api/v1/integrations/jira/oauth/callback
+	Scopes       []string `mapstructure:"scopes"`
+
+	// Endpoints (default to Atlassian's)
+	AuthURL      string `mapstructure:"authUrl"`
+	TokenURL     string `mapstructure:"tokenUrl"`
+	ResourcesURL string `mapstructure:"resourcesUrl"`
+	APIURL       string `mapstructure:"apiUrl"`
+}
+
 type MonitoringConfig struct {
 	Metrics MetricsConfig `mapstructure:"metrics"`
 	Tracing TracingConfig `mapstructure:"tracing"`
@@ -158,13 +255,51 @@ type MetricsConfig struct {
 type TracingConfig struct {
 	Enabled     bool   `mapstructure:"enabled"`
 	ServiceName string `mapstructure:"serviceName"`
-	Endpoint    string `mapstructure:"endpoint"`
+	// Endpoint is the OTLP/HTTP collector URL, such as http://otel-collector:4318.
+	// When empty the OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string `mapstructure:"endpoint"`
+	// Exporter is "otlp", or "stdout" to print spans for local debugging
+	Exporter string `mapstructure:"exporter"`
+	// SampleRatio is the fraction of traces started here that are recorded.
+	// Traces started by a CI client follow the client's sampling decision.
+	SampleRatio float64 `mapstructure:"sampleRatio"`
 }
 
 type HealthConfig struct {
-	Path     string        `mapstructure:"path"`
+	// Path serves the readiness checks, next to /healthz/live and /healthz/ready
+	Path string `mapstructure:"path"`
+	// Interval is how long check results are cached; zero runs checks on every probe
 	Interval time.Duration `mapstructure:"interval"`
-	Timeout  time.Duration `mapstructure:"timeout"`
+	// Timeout bounds each check
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// AuditConfig configures the audit log of changes, sign-ins and sign-outs
+type AuditConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Retention is how long entries are kept; zero keeps them forever
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// RateLimitConfig configures per-client rate limits, with separate budgets
+// for test result ingestion, GraphQL, sign-in and other API requests
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend is memory, for budgets per replica, or redis, for budgets
+	// shared between replicas through the Redis configuration
+	Backend   string          `mapstructure:"backend"`
+	API       RateLimitBudget `mapstructure:"api"`
+	Ingestion RateLimitBudget `mapstructure:"ingestion"`
+	GraphQL   RateLimitBudget `mapstructure:"graphql"`
+	Auth      RateLimitBudget `mapstructure:"auth"`
+}
+
+// RateLimitBudget is the token bucket of a client: it refills
+// RequestsPerMinute tokens a minute and holds up to Burst tokens.
+// Zero RequestsPerMinute leaves requests unlimited.
+type RateLimitBudget struct {
+	RequestsPerMinute int `mapstructure:"requestsPerMinute"`
+	Burst             int `mapstructure:"burst"`
 }
 
 var globalConfig *Config
@@ -255,6 +390,10 @@ func (m *Manager) setDefaults() {
 	viper.SetDefault("auth.enabled", false)
 	viper.SetDefault("auth.tokenExpiry", "24h")
 	viper.SetDefault("auth.refreshExpiry", "168h")
+	viper.SetDefault("auth.session.idleTimeout", "8h")
+	viper.SetDefault("auth.session.absoluteLifetime", "24h")
+	viper.SetDefault("auth.session.refreshBefore", "1m")
+	viper.SetDefault("auth.scim.enabled", false)
 
 	// OAuth defaults
 	viper.SetDefault("auth.oauth.enabled", false)
@@ -290,13 +429,48 @@ func (m *Manager) setDefaults() {
 	viper.SetDefault("llm.maxTokens", 4000)
 	viper.SetDefault("llm.temperature", 0.7)
 
+	// Integration defaults
+	viper.SetDefault("integrations.jira.oauth.scopes", []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"})
+	viper.SetDefault("integrations.jira.oauth.authUrl", "https://auth.atlassian.com/authorize")
+	viper.SetDefault("integrations.jira.oauth.tokenUrl", "https://auth.atlassian.com/oauth/token")
+	viper.SetDefault("integrations.jira.oauth.resourcesUrl", "https://api.atlassian.com/oauth/token/accessible-resources")
+	viper.SetDefault("integrations.jira.oauth.apiUrl", "https://api.atlassian.com")
+	viper.SetDefault("integrations.email.smtp.port", 587)
+	viper.SetDefault("integrations.email.smtp.tls", "starttls")
+	viper.SetDefault("integrations.email.smtp.timeout", "30s")
+	viper.SetDefault("integrations.email.digest.enabled", false)
+	viper.SetDefault("integrations.email.digest.weekday", "monday")
+	viper.SetDefault("integrations.email.digest.hour", 8)
+	viper.SetDefault("integrations.email.digest.timeZone", "UTC")
+
 	// Monitoring defaults
 	viper.SetDefault("monitoring.metrics.enabled", true)
 	viper.SetDefault("monitoring.metrics.path", "/metrics")
 	viper.SetDefault("monitoring.metrics.port", 9090)
+	viper.SetDefault("monitoring.tracing.enabled", false)
+	viper.SetDefault("monitoring.tracing.serviceName", "fern-platform")
+	viper.SetDefault("monitoring.tracing.endpoint", "")
+	viper.SetDefault("monitoring.tracing.exporter", "otlp")
+	viper.SetDefault("monitoring.tracing.sampleRatio", 1.0)
 	viper.SetDefault("monitoring.health.path", "/health")
 	viper.SetDefault("monitoring.health.interval", "30s")
 	viper.SetDefault("monitoring.health.timeout", "5s")
+
+	// Audit defaults
+	viper.SetDefault("audit.enabled", true)
+	viper.SetDefault("audit.retention", "8760h")
+
+	// Rate limit defaults
+	viper.SetDefault("rateLimit.enabled", true)
+	viper.SetDefault("rateLimit.backend", "memory")
+	viper.SetDefault("rateLimit.api.requestsPerMinute", 600)
+	viper.SetDefault("rateLimit.api.burst", 100)
+	viper.SetDefault("rateLimit.ingestion.requestsPerMinute", 6000)
+	viper.SetDefault("rateLimit.ingestion.burst", 1000)
+	viper.SetDefault("rateLimit.graphql.requestsPerMinute", 300)
+	viper.SetDefault("rateLimit.graphql.burst", 60)
+	viper.SetDefault("rateLimit.auth.requestsPerMinute", 120)
+	viper.SetDefault("rateLimit.auth.burst", 60)
 }
 
 func (m *Manager) bindEnvVars() error {
@@ -344,6 +518,18 @@ func (m *Manager) bindEnvVars() error {
 	if err := viper.BindEnv("auth.audience", "AUTH_AUDIENCE"); err != nil {
 		return err
 	}
+	if err := viper.BindEnv("auth.session.idleTimeout", "AUTH_SESSION_IDLE_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("auth.session.absoluteLifetime", "AUTH_SESSION_ABSOLUTE_LIFETIME"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("auth.scim.enabled", "SCIM_ENABLED"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("auth.scim.token", "SCIM_TOKEN"); err != nil {
+		return err
+	}
 
 	// OAuth
 	if err := viper.BindEnv("auth.oauth.enabled", "OAUTH_ENABLED"); err != nil {
@@ -433,6 +619,54 @@ func (m *Manager) bindEnvVars() error {
 		return err
 	}
 
+	// Integrations
+	if err := viper.BindEnv("integrations.jira.oauth.clientId", "JIRA_OAUTH_CLIENT_ID"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.jira.oauth.clientSecret", "JIRA_OAUTH_CLIENT_SECRET"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.jira.oauth.redirectUrl", "JIRA_OAUTH_REDIRECT_URL"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.encryption.primaryKeyId", "CREDENTIAL_ENCRYPTION_PRIMARY_KEY_ID"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.encryption.legacyKeyId", "CREDENTIAL_ENCRYPTION_LEGACY_KEY_ID"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.smtp.host", "SMTP_HOST"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.smtp.port", "SMTP_PORT"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.smtp.username", "SMTP_USERNAME"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.smtp.password", "SMTP_PASSWORD"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.smtp.from", "SMTP_FROM"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("integrations.email.digest.enabled", "EMAIL_DIGEST_ENABLED"); err != nil {
+		return err
+	}
+
+	// Audit
+	if err := viper.BindEnv("audit.retention", "AUDIT_RETENTION"); err != nil {
+		return err
+	}
+
+	// Rate limiting
+	if err := viper.BindEnv("rateLimit.enabled", "RATE_LIMIT_ENABLED"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("rateLimit.backend", "RATE_LIMIT_BACKEND"); err != nil {
+		return err
+	}
+
 	// Logging
 	if err := viper.BindEnv("logging.level", "LOG_LEVEL"); err != nil {
 		return err
@@ -480,6 +714,73 @@ func (m *Manager) validate(config *Config) error {
 		}
 	}
 
+	// Session validation
+	if session := config.Auth.Session; session.AbsoluteLifetime <= 0 {
+		return fmt.Errorf("session absolute lifetime must be positive")
+	} else if session.IdleTimeout < 0 || session.RefreshBefore < 0 {
+		return fmt.Errorf("session idle timeout and refresh window cannot be negative")
+	}
+
+	// SCIM validation
+	if scim := config.Auth.SCIM; scim.Enabled && len(scim.Token) < minSCIMTokenLength {
+		return fmt.Errorf("SCIM is enabled but the SCIM token is shorter than %d characters", minSCIMTokenLength)
+	}
+
+	// Integrations validation
+	if jiraOAuth := config.Integrations.Jira.OAuth; jiraOAuth.ClientID != "" {
+		if jiraOAuth.ClientSecret == "" {
+			return fmt.Errorf("JIRA OAuth client ID is set but client secret is missing")
+		}
+		if jiraOAuth.RedirectURL == "" {
+			return fmt.Errorf("JIRA OAuth client ID is set but redirect URL is missing")
+		}
+	}
+	if encryption := config.Integrations.Encryption; len(encryption.Keys) > 0 && encryption.PrimaryKeyID == "" {
+		return fmt.Errorf("credential encryption keys are configured but no primary key ID is set")
+	}
+	if email := config.Integrations.Email; email.Digest.Enabled {
+		if email.SMTP.Host == "" || email.SMTP.From == "" {
+			return fmt.Errorf("email digests are enabled but the SMTP host or sender address is missing")
+		}
+		if email.Digest.Hour < 0 || email.Digest.Hour > 23 {
+			return fmt.Errorf("email digest hour must be between 0 and 23")
+		}
+	}
+
+	// Monitoring validation
+	if metrics := config.Monitoring.Metrics; metrics.Enabled {
+		if metrics.Port <= 0 || metrics.Port > 65535 {
+			return fmt.Errorf("metrics port must be between 1 and 65535")
+		}
+	}
+	if tracing := config.Monitoring.Tracing; tracing.Enabled {
+		if tracing.Exporter != "otlp" && tracing.Exporter != "stdout" {
+			return fmt.Errorf("tracing exporter must be otlp or stdout, got %q", tracing.Exporter)
+		}
+		if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+			return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+		}
+	}
+
+	// Audit validation
+	if config.Audit.Retention < 0 {
+		return fmt.Errorf("audit retention cannot be negative")
+	}
+
+	// Rate limit validation
+	if rateLimit := config.RateLimit; rateLimit.Enabled {
+		if rateLimit.Backend != "memory" && rateLimit.Backend != "redis" {
+			return fmt.Errorf("rate limit backend must be memory or redis, got %q", rateLimit.Backend)
+		}
+		for name, budget := range map[string]RateLimitBudget{
+			"api": rateLimit.API, "ingestion": rateLimit.Ingestion, "graphql": rateLimit.GraphQL, "auth": rateLimit.Auth,
+		} {
+			if budget.RequestsPerMinute < 0 || budget.Burst < 0 {
+				return fmt.Errorf("rate limit %s budget cannot be negative", name)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -456,6 +456,288 @@ auth:
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("email digest validation", func() {
+			It("should require an SMTP host when digests are enabled", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+integrations:
+  email:
+    smtp:
+      host: ""
+      from: "fern@example.com"
+    digest:
+      enabled: true
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("email digests are enabled but the SMTP host or sender address is missing"))
+			})
+
+			It("should reject an hour outside the day", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+integrations:
+  email:
+    smtp:
+      host: "smtp.example.com"
+      from: "fern@example.com"
+    digest:
+      enabled: true
+      hour: 24
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("email digest hour must be between 0 and 23"))
+			})
+
+			It("should pass validation with an SMTP server", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+integrations:
+  email:
+    smtp:
+      host: "smtp.example.com"
+      from: "Fern <fern@example.com>"
+    digest:
+      enabled: true
+      weekday: "monday"
+      hour: 8
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("tracing validation", func() {
+			It("should reject an unknown exporter", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+monitoring:
+  tracing:
+    enabled: true
+    exporter: "zipkin"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("tracing exporter must be otlp or stdout"))
+			})
+
+			It("should default to the OTLP exporter sampling every trace", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+monitoring:
+  tracing:
+    enabled: true
+    endpoint: "http://otel-collector:4318"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).NotTo(HaveOccurred())
+				tracing := config.GetConfig().Monitoring.Tracing
+				Expect(tracing.Exporter).To(Equal("otlp"))
+				Expect(tracing.SampleRatio).To(Equal(1.0))
+				Expect(tracing.ServiceName).To(Equal("fern-platform"))
+			})
+		})
+
+		Context("audit validation", func() {
+			It("should keep audit log entries for a year by default", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).NotTo(HaveOccurred())
+				audit := config.GetConfig().Audit
+				Expect(audit.Enabled).To(BeTrue())
+				Expect(audit.Retention).To(Equal(365 * 24 * time.Hour))
+			})
+
+			It("should reject a negative retention", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+audit:
+  retention: "-1h"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("audit retention cannot be negative"))
+			})
+		})
+
+		Context("rate limit validation", func() {
+			It("should limit requests in memory by default", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+rateLimit:
+  graphql:
+    requestsPerMinute: 120
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).NotTo(HaveOccurred())
+				rateLimit := config.GetConfig().RateLimit
+				Expect(rateLimit.Enabled).To(BeTrue())
+				Expect(rateLimit.Backend).To(Equal("memory"))
+				Expect(rateLimit.GraphQL).To(Equal(config.RateLimitBudget{RequestsPerMinute: 120, Burst: 60}))
+				Expect(rateLimit.Auth.RequestsPerMinute).To(BeNumerically(">", 0))
+			})
+
+			It("should reject an unknown backend", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+rateLimit:
+  backend: "memcached"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("rate limit backend must be memory or redis"))
+			})
+
+			It("should reject negative budgets", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+rateLimit:
+  auth:
+    burst: -1
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("rate limit auth budget cannot be negative"))
+			})
+		})
+
+		Context("session validation", func() {
+			It("should default to 24 hour sessions refreshed a minute ahead", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+auth:
+  session:
+    idleTimeout: "30m"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).NotTo(HaveOccurred())
+				session := config.GetConfig().Auth.Session
+				Expect(session.IdleTimeout).To(Equal(30 * time.Minute))
+				Expect(session.AbsoluteLifetime).To(Equal(24 * time.Hour))
+				Expect(session.RefreshBefore).To(Equal(time.Minute))
+			})
+
+			It("should require a positive absolute lifetime", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+auth:
+  session:
+    absoluteLifetime: "0s"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("session absolute lifetime must be positive"))
+			})
+		})
+
+		Context("SCIM validation", func() {
+			It("should require a long SCIM token when SCIM is enabled", func() {
+				configContent := `
+database:
+  host: "localhost"
+  user: "testuser"
+  dbname: "testdb"
+auth:
+  scim:
+    enabled: true
+    token: "short"
+`
+				err := os.WriteFile(configFile, []byte(configContent), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = manager.Load(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("SCIM token is shorter than 32 characters"))
+			})
+
+			It("should accept a SCIM token from the environment", func() {
+				os.Setenv("SCIM_ENABLED", "true")
+				os.Setenv("SCIM_TOKEN", "0123456789abcdef0123456789abcdef")
+				defer os.Unsetenv("SCIM_ENABLED")
+				defer os.Unsetenv("SCIM_TOKEN")
+
+				err := manager.Load("")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.GetConfig().Auth.SCIM.Enabled).To(BeTrue())
+				Expect(config.GetConfig().Auth.SCIM.Token).To(Equal("0123456789abcdef0123456789abcdef"))
+			})
+		})
 	})
 
 	Describe("Global Getter Functions", func() {
@@ -2,8 +2,12 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -147,6 +151,51 @@ func (db *DB) MigrateDown(migrationsPath string, steps int) error {
 	return nil
 }
 
+// MigrationVersion returns the version of the last applied migration, and
+// whether that migration failed partway and left the schema dirty
+func (db *DB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	result := db.DB.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row)
+	if result.Error != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, false, fmt.Errorf("no migrations have been applied")
+	}
+	return row.Version, row.Dirty, nil
+}
+
+// LatestMigration returns the version of the newest migration in migrationsPath
+func LatestMigration(migrationsPath string) (uint, error) {
+	entries, err := os.ReadDir(migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", migrationsPath)
+	}
+	return latest, nil
+}
+
 // Transaction executes a function within a database transaction
 func (db *DB) Transaction(fn func(*gorm.DB) error) error {
 	return db.DB.Transaction(fn)
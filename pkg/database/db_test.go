@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"000001_create_tables.up.sql",
+		"000001_create_tables.down.sql",
+		"000012_add_index.up.sql",
+		"000013_drop_index.down.sql",
+		"README.md",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	version, err := LatestMigration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, uint(12), version)
+
+	_, err = LatestMigration(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLatestMigration_Repository(t *testing.T) {
+	version, err := LatestMigration("../../migrations")
+	require.NoError(t, err)
+	assert.Greater(t, version, uint(0))
+}
+
+func TestDB_MigrationVersion(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	db := &DB{DB: gormDB}
+	ctx := context.Background()
+
+	_, _, err = db.MigrationVersion(ctx)
+	assert.Error(t, err, "no schema_migrations table")
+
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version bigint, dirty boolean)").Error)
+	_, _, err = db.MigrationVersion(ctx)
+	assert.EqualError(t, err, "no migrations have been applied")
+
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (31, true)").Error)
+	version, dirty, err := db.MigrationVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(31), version)
+	assert.True(t, dirty)
+}
@@ -41,6 +41,37 @@ func (j *JSONMap) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, j)
 }
 
+// StringList is a list of strings stored as a JSON array
+type StringList []string
+
+// Value implements the driver.Valuer interface for StringList
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements the sql.Scanner interface for StringList
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan StringList: invalid type")
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
 // BaseModel provides common fields for all database models
 type BaseModel struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
@@ -51,16 +82,17 @@ type BaseModel struct {
 
 // UserPreferences stores user-specific preferences
 type UserPreferences struct {
-	ID          uint            `gorm:"primarykey" json:"id"`
-	UserID      string          `gorm:"uniqueIndex;not null" json:"user_id"`
-	Theme       string          `gorm:"default:'light'" json:"theme"`
-	Timezone    string          `gorm:"default:'UTC'" json:"timezone"`
-	Language    string          `gorm:"default:'en'" json:"language"`
-	Favorites   json.RawMessage `gorm:"type:jsonb" json:"favorites"`
-	Preferences json.RawMessage `gorm:"type:jsonb" json:"preferences"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+	ID                uint            `gorm:"primarykey" json:"id"`
+	UserID            string          `gorm:"uniqueIndex;not null" json:"user_id"`
+	Theme             string          `gorm:"default:'light'" json:"theme"`
+	Timezone          string          `gorm:"default:'UTC'" json:"timezone"`
+	Language          string          `gorm:"default:'en'" json:"language"`
+	Favorites         json.RawMessage `gorm:"type:jsonb" json:"favorites"`
+	Preferences       json.RawMessage `gorm:"type:jsonb" json:"preferences"`
+	EmailDigestOptOut bool            `gorm:"column:email_digest_opt_out;not null" json:"email_digest_opt_out"` // Unsubscribed from email digests
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName returns the table name for UserPreferences
@@ -119,6 +151,7 @@ type SpecRun struct {
 	StackTrace   string     `gorm:"type:text" json:"stack_trace,omitempty"`
 	RetryCount   int        `json:"retry_count"`
 	IsFlaky      bool       `gorm:"index" json:"is_flaky"`
+	Owners       StringList `gorm:"type:jsonb;default:'[]'" json:"owners,omitempty"`
 	Tags         []Tag      `gorm:"many2many:spec_run_tags;" json:"tags,omitempty"`
 }
 
@@ -177,8 +210,192 @@ type JiraConnection struct {
 	Username            string    `gorm:"type:varchar(255);not null" json:"username"`
 	EncryptedCredential string    `gorm:"type:text;not null" json:"-"`
 	Status              string    `gorm:"type:varchar(50);not null;default:'pending'" json:"status"`
+	StatusReason        string    `gorm:"type:text" json:"status_reason,omitempty"`
 	IsActive            bool      `gorm:"not null;default:false" json:"is_active"`
 	LastTestedAt        *time.Time `json:"last_tested_at,omitempty"`
+
+	// OAuth 2.0 (3LO) state; the access token is stored as the encrypted credential
+	OAuthCloudID               string     `gorm:"column:oauth_cloud_id;type:varchar(100)" json:"oauth_cloud_id,omitempty"`
+	EncryptedOAuthRefreshToken string     `gorm:"column:encrypted_oauth_refresh_token;type:text" json:"-"`
+	OAuthTokenExpiresAt        *time.Time `gorm:"column:oauth_token_expires_at" json:"oauth_token_expires_at,omitempty"`
+}
+
+// JiraIssueLink records a JIRA issue filed for a failure or flaky test
+type JiraIssueLink struct {
+	BaseModel
+	ProjectID    string `gorm:"type:varchar(36);not null;index;uniqueIndex:idx_jira_issue_link_target,where:deleted_at IS NULL" json:"project_id"`
+	ConnectionID string `gorm:"type:varchar(36);not null;index" json:"connection_id"`
+	IssueKey     string `gorm:"type:varchar(50);not null;index" json:"issue_key"`
+	IssueURL     string `gorm:"type:varchar(600);not null" json:"issue_url"`
+	SourceType   string `gorm:"type:varchar(50);not null" json:"source_type"`
+	TargetType   string `gorm:"type:varchar(50);not null;uniqueIndex:idx_jira_issue_link_target" json:"target_type"`
+	TargetID     string `gorm:"type:varchar(64);not null;uniqueIndex:idx_jira_issue_link_target" json:"target_id"`
+	TestName     string `gorm:"type:text" json:"test_name"`
+	SuiteName    string `gorm:"type:text" json:"suite_name"`
+	CreatedBy    string `gorm:"type:varchar(255)" json:"created_by"`
+
+	// Sync state, maintained by the issue sync job
+	IssueStatus         string     `gorm:"type:varchar(255)" json:"issue_status"`
+	IssueStatusCategory string     `gorm:"type:varchar(50)" json:"issue_status_category"`
+	IssueResolvedAt     *time.Time `json:"issue_resolved_at"`
+	SyncedAt            *time.Time `json:"synced_at"`
+	ReflakeNotifiedAt   *time.Time `json:"reflake_notified_at"`
+}
+
+// JiraFieldMapping stores how Fern fields map onto the JIRA fields of a connection
+type JiraFieldMapping struct {
+	BaseModel
+	ConnectionID  string  `gorm:"type:varchar(36);not null;uniqueIndex:idx_jira_field_mapping_connection,where:deleted_at IS NULL" json:"connection_id"`
+	ProjectID     string  `gorm:"type:varchar(36);not null;index" json:"project_id"`
+	IssueType     string  `gorm:"type:varchar(255);not null" json:"issue_type"`
+	FieldMappings JSONMap `gorm:"type:jsonb" json:"field_mappings"`
+	UpdatedBy     string  `gorm:"type:varchar(255)" json:"updated_by"`
+}
+
+// TrackerConnection connects a project to an issue tracker through a registered connector
+type TrackerConnection struct {
+	BaseModel
+	ProjectID           string     `gorm:"type:varchar(36);not null;index" json:"project_id"`
+	Name                string     `gorm:"type:varchar(255);not null" json:"name"`
+	ConnectorType       string     `gorm:"type:varchar(50);not null" json:"connector_type"`
+	Config              JSONMap    `gorm:"type:jsonb" json:"config"`
+	EncryptedCredential string     `gorm:"type:text;not null" json:"-"`
+	Status              string     `gorm:"type:varchar(50);not null;default:'pending'" json:"status"`
+	LastError           string     `gorm:"type:text" json:"last_error,omitempty"`
+	IsActive            bool       `gorm:"not null;default:false" json:"is_active"`
+	LastTestedAt        *time.Time `json:"last_tested_at,omitempty"`
+}
+
+// WebhookSubscription delivers events of the subscribed types to an endpoint.
+// Global subscriptions have no project and receive the events of every project.
+type WebhookSubscription struct {
+	BaseModel
+	ProjectID           *string    `gorm:"type:varchar(36);index" json:"project_id,omitempty"`
+	Name                string     `gorm:"type:varchar(255);not null" json:"name"`
+	URL                 string     `gorm:"type:text;not null" json:"url"`
+	Events              string     `gorm:"type:varchar(500);not null" json:"events"` // Comma-separated event types
+	EncryptedSecret     string     `gorm:"type:text;not null" json:"-"`
+	IsActive            bool       `gorm:"not null;default:true" json:"is_active"`
+	DisabledReason      string     `gorm:"type:text" json:"disabled_reason,omitempty"`
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	LastDeliveryAt      *time.Time `json:"last_delivery_at,omitempty"`
+	CreatedBy           string     `gorm:"type:varchar(255)" json:"created_by"`
+}
+
+// WebhookDelivery is one event queued for, or delivered to, a webhook subscription
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	SubscriptionID uint       `gorm:"not null;index" json:"subscription_id"`
+	EventID        string     `gorm:"type:varchar(36);not null;index" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	ProjectID      string     `gorm:"type:varchar(36)" json:"project_id"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `gorm:"type:text" json:"response_body"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	DurationMs     int64      `json:"duration_ms"`
+	RedeliveryOf   *uint      `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+// NotificationRule posts a project's matching events to a Slack or Microsoft Teams channel
+type NotificationRule struct {
+	BaseModel
+	ProjectID           string     `gorm:"type:varchar(36);not null;index" json:"project_id"`
+	Name                string     `gorm:"type:varchar(255);not null" json:"name"`
+	ChannelType         string     `gorm:"type:varchar(20);not null" json:"channel_type"` // slack, teams
+	EncryptedWebhookURL string     `gorm:"type:text;not null" json:"-"`
+	Events              string     `gorm:"type:varchar(500);not null" json:"events"` // Comma-separated event types
+	Branches            StringList `gorm:"type:jsonb;default:'[]'" json:"branches,omitempty"`
+	Environments        StringList `gorm:"type:jsonb;default:'[]'" json:"environments,omitempty"`
+	Statuses            StringList `gorm:"type:jsonb;default:'[]'" json:"statuses,omitempty"`
+	Tags                StringList `gorm:"type:jsonb;default:'[]'" json:"tags,omitempty"`
+	Severities          StringList `gorm:"type:jsonb;default:'[]'" json:"severities,omitempty"`
+	QuietHoursStart     string     `gorm:"type:varchar(5)" json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd       string     `gorm:"type:varchar(5)" json:"quiet_hours_end,omitempty"`
+	QuietHoursTimeZone  string     `gorm:"type:varchar(64)" json:"quiet_hours_timezone,omitempty"`
+	DedupWindowSeconds  int64      `gorm:"not null;default:0" json:"dedup_window_seconds"`
+	TitleTemplate       string     `gorm:"type:text" json:"title_template,omitempty"`
+	TextTemplate        string     `gorm:"type:text" json:"text_template,omitempty"`
+	IsActive            bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedBy           string     `gorm:"type:varchar(255)" json:"created_by"`
+}
+
+// NotificationDelivery is one notification queued for, posted to, or suppressed for a notification rule
+type NotificationDelivery struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	RuleID         uint       `gorm:"not null;index;index:idx_notification_deliveries_dedup,priority:1" json:"rule_id"`
+	ProjectID      string     `gorm:"type:varchar(36)" json:"project_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	DedupKey       string     `gorm:"type:varchar(500);not null;index:idx_notification_deliveries_dedup,priority:2" json:"dedup_key"`
+	Title          string     `gorm:"type:text" json:"title"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_notification_deliveries_due,priority:1" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_notification_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	HeldUntil      *time.Time `json:"held_until,omitempty"`
+	SuppressedBy   *uint      `json:"suppressed_by,omitempty"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+// VCSConnection reports a project's test runs to the commits and pull requests of a GitHub or GitLab repository
+type VCSConnection struct {
+	BaseModel
+	ProjectID             string     `gorm:"type:varchar(36);not null;index" json:"project_id"`
+	Name                  string     `gorm:"type:varchar(255);not null" json:"name"`
+	Provider              string     `gorm:"type:varchar(20);not null" json:"provider"` // github, gitlab
+	APIURL                string     `gorm:"column:api_url;type:text;not null" json:"api_url"`
+	Repository            string     `gorm:"type:varchar(500);not null" json:"repository"`
+	StatusContext         string     `gorm:"type:varchar(255);not null" json:"status_context"`
+	StatusMode            string     `gorm:"type:varchar(20);not null;default:'commit_status'" json:"status_mode"` // commit_status, check_run
+	CommentOnPullRequests bool       `gorm:"not null" json:"comment_on_pull_requests"`
+	EncryptedToken        string     `gorm:"type:text;not null" json:"-"`
+	Status                string     `gorm:"type:varchar(50);not null;default:'pending'" json:"status"`
+	LastError             string     `gorm:"type:text" json:"last_error,omitempty"`
+	IsActive              bool       `gorm:"not null;default:true" json:"is_active"`
+	LastTestedAt          *time.Time `json:"last_tested_at,omitempty"`
+	CreatedBy             string     `gorm:"type:varchar(255)" json:"created_by"`
+}
+
+// VCSReport is one report of a test run queued for, or sent to, a VCS connection
+type VCSReport struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	ConnectionID  uint       `gorm:"not null;index;index:idx_vcs_reports_run,priority:1" json:"connection_id"`
+	ProjectID     string     `gorm:"type:varchar(36)" json:"project_id"`
+	TestRunID     uint       `gorm:"not null;index:idx_vcs_reports_run,priority:2" json:"test_run_id"`
+	RunID         string     `gorm:"type:varchar(255)" json:"run_id"`
+	CommitSHA     string     `gorm:"type:varchar(64);not null" json:"commit_sha"`
+	Final         bool       `gorm:"not null;default:false" json:"final"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_vcs_reports_due,priority:1" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_vcs_reports_due,priority:2" json:"next_attempt_at"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	State         string     `gorm:"type:varchar(20)" json:"state,omitempty"`
+	CommentURLs   StringList `gorm:"column:comment_urls;type:jsonb;default:'[]'" json:"comment_urls,omitempty"`
+	CreatedAt     time.Time  `gorm:"index" json:"created_at"`
+}
+
+// DigestDelivery is the email digest of one user for one scheduled time
+type DigestDelivery struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	UserID        string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_digest_deliveries_user_period,priority:1" json:"user_id"`
+	Email         string     `gorm:"type:varchar(255);not null" json:"email"`
+	PeriodEnd     time.Time  `gorm:"not null;uniqueIndex:idx_digest_deliveries_user_period,priority:2;index" json:"period_end"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Projects      int        `gorm:"not null;default:0" json:"projects"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null" json:"next_attempt_at"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // ProjectPermission represents explicit project permissions for a user
@@ -212,24 +429,63 @@ type FlakyTest struct {
 	Status           string    `gorm:"default:'active'" json:"status"`
 	Severity         string    `json:"severity"` // low, medium, high, critical
 	LastErrorMessage string    `gorm:"type:text" json:"last_error_message,omitempty"`
+
+	ConsecutivePasses int        `gorm:"default:0" json:"consecutive_passes"`
+	StatusChangedAt   *time.Time `json:"status_changed_at,omitempty"`
+	Owners            StringList `gorm:"type:jsonb;default:'[]'" json:"owners,omitempty"`
+}
+
+// FlakyTestStatusChange is an append-only record of a flaky test status transition
+type FlakyTestStatusChange struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	FlakyTestID uint      `gorm:"not null;index" json:"flaky_test_id"`
+	FromStatus  string    `json:"from_status"`
+	ToStatus    string    `gorm:"not null" json:"to_status"`
+	ActorType   string    `gorm:"not null" json:"actor_type"` // system, user
+	ActorID     string    `json:"actor_id"`
+	Reason      string    `gorm:"type:text" json:"reason,omitempty"`
+	ChangedAt   time.Time `gorm:"not null;index" json:"changed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FlakyTestAnalysis records a flaky detection run and the config it used
+type FlakyTestAnalysis struct {
+	BaseModel
+	ProjectID                      string    `gorm:"not null;index" json:"project_id"`
+	TestRunID                      string    `gorm:"index" json:"test_run_id"`
+	AnalyzedAt                     time.Time `gorm:"not null" json:"analyzed_at"`
+	TotalTests                     int       `json:"total_tests"`
+	NewFlakyCount                  int       `json:"new_flaky_count"`
+	StillFlakyCount                int       `json:"still_flaky_count"`
+	ResolvedFlakyCount             int       `json:"resolved_flaky_count"`
+	MinimumRuns                    int       `json:"minimum_runs"`
+	MinFailureRate                 float64   `json:"min_failure_rate"`
+	MaxFailureRate                 float64   `json:"max_failure_rate"`
+	AnalysisWindowSeconds          int64     `json:"analysis_window_seconds"`
+	ConsecutivePassesForResolution int       `json:"consecutive_passes_for_resolution"`
 }
 
 // User represents a system user with OAuth authentication
 type User struct {
 	BaseModel
-	UserID        string          `gorm:"uniqueIndex;not null" json:"user_id"`  // OAuth provider user ID
-	Email         string          `gorm:"uniqueIndex;not null" json:"email"`    // User email
-	Name          string          `gorm:"not null" json:"name"`                 // Display name
-	Role          string          `gorm:"default:'user';index" json:"role"`     // user, admin
-	Status        string          `gorm:"default:'active';index" json:"status"` // active, suspended, inactive
-	LastLoginAt   *time.Time      `json:"last_login_at,omitempty"`
-	ProfileURL    string          `json:"profile_url,omitempty"`               // Avatar/profile picture URL
-	FirstName     string          `json:"first_name,omitempty"`                // First name from OAuth
-	LastName      string          `json:"last_name,omitempty"`                 // Last name from OAuth
-	EmailVerified bool            `gorm:"default:false" json:"email_verified"` // Email verification status
-	ProjectAccess []ProjectAccess `gorm:"foreignKey:UserID;references:UserID" json:"project_access,omitempty"`
-	UserGroups    []UserGroup     `gorm:"foreignKey:UserID;references:UserID" json:"user_groups,omitempty"`
-	UserScopes    []UserScope     `gorm:"foreignKey:UserID;references:UserID" json:"user_scopes,omitempty"`
+	UserID         string          `gorm:"uniqueIndex;not null" json:"user_id"`  // OAuth provider user ID
+	Email          string          `gorm:"uniqueIndex;not null" json:"email"`    // User email
+	Name           string          `gorm:"not null" json:"name"`                 // Display name
+	Role           string          `gorm:"default:'user';index" json:"role"`     // user, admin
+	Status         string          `gorm:"default:'active';index" json:"status"` // active, suspended, inactive
+	LastLoginAt    *time.Time      `json:"last_login_at,omitempty"`
+	ProfileURL     string          `json:"profile_url,omitempty"`                               // Avatar/profile picture URL
+	FirstName      string          `json:"first_name,omitempty"`                                // First name from OAuth
+	LastName       string          `json:"last_name,omitempty"`                                 // Last name from OAuth
+	EmailVerified  bool            `gorm:"default:false" json:"email_verified"`                 // Email verification status
+	UpdatedBy      string          `gorm:"type:varchar(255)" json:"updated_by,omitempty"`       // Admin who last changed role or status
+	RoleAssignedBy string          `gorm:"type:varchar(255)" json:"role_assigned_by,omitempty"` // Admin who pinned the role
+	DeletedBy      string          `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`       // Admin who deleted the user
+	UserName       string          `gorm:"index" json:"user_name,omitempty"`                    // SCIM user name, if provisioned
+	ExternalID     string          `gorm:"index" json:"external_id,omitempty"`                  // Identity provider ID, if provisioned
+	ProjectAccess  []ProjectAccess `gorm:"foreignKey:UserID;references:UserID" json:"project_access,omitempty"`
+	UserGroups     []UserGroup     `gorm:"foreignKey:UserID;references:UserID" json:"user_groups,omitempty"`
+	UserScopes     []UserScope     `gorm:"foreignKey:UserID;references:UserID" json:"user_scopes,omitempty"`
 }
 
 // UserGroup represents a user's group membership
@@ -239,19 +495,29 @@ type UserGroup struct {
 	GroupName string `gorm:"not null;index" json:"group_name"`
 }
 
+// Group represents an identity provider group provisioned through SCIM. Its
+// members are the UserGroups with the same group name.
+type Group struct {
+	BaseModel
+	GroupID     string `gorm:"uniqueIndex;not null" json:"group_id"`
+	DisplayName string `gorm:"uniqueIndex;not null" json:"display_name"`
+	ExternalID  string `gorm:"type:varchar(255);index" json:"external_id,omitempty"`
+}
+
 // UserSession represents an active user session
 type UserSession struct {
 	BaseModel
-	UserID       string    `gorm:"not null;index;references:users(user_id)" json:"user_id"`
-	SessionID    string    `gorm:"uniqueIndex;not null" json:"session_id"`
-	AccessToken  string    `gorm:"type:text" json:"-"` // Don't serialize to JSON
-	RefreshToken string    `gorm:"type:text" json:"-"` // Don't serialize to JSON
-	IDToken      string    `gorm:"type:text" json:"-"` // ID token for logout
-	ExpiresAt    time.Time `gorm:"index" json:"expires_at"`
-	IsActive     bool      `gorm:"default:true;index" json:"is_active"`
-	IPAddress    string    `json:"ip_address,omitempty"`
-	UserAgent    string    `gorm:"type:text" json:"user_agent,omitempty"`
-	LastActivity time.Time `gorm:"index" json:"last_activity"`
+	UserID         string     `gorm:"not null;index;references:users(user_id)" json:"user_id"`
+	SessionID      string     `gorm:"uniqueIndex;not null" json:"session_id"`
+	AccessToken    string     `gorm:"type:text" json:"-"` // Don't serialize to JSON
+	RefreshToken   string     `gorm:"type:text" json:"-"` // Don't serialize to JSON
+	IDToken        string     `gorm:"type:text" json:"-"` // ID token for logout
+	TokenExpiresAt *time.Time `json:"-"`                  // When the access token needs refreshing
+	ExpiresAt      time.Time  `gorm:"index" json:"expires_at"`
+	IsActive       bool       `gorm:"default:true;index" json:"is_active"`
+	IPAddress      string     `json:"ip_address,omitempty"`
+	UserAgent      string     `gorm:"type:text" json:"user_agent,omitempty"`
+	LastActivity   time.Time  `gorm:"index" json:"last_activity"`
 }
 
 // ProjectAccess represents user access permissions for specific projects
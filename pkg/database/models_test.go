@@ -165,9 +165,21 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&Tag{},
 		&ProjectDetails{},
 		&JiraConnection{},
+		&JiraIssueLink{},
+		&JiraFieldMapping{},
+		&TrackerConnection{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+		&NotificationRule{},
+		&NotificationDelivery{},
+		&VCSConnection{},
+		&VCSReport{},
+		&DigestDelivery{},
 		&ProjectPermission{},
 		&TestRunTag{},
 		&FlakyTest{},
+		&FlakyTestAnalysis{},
+		&FlakyTestStatusChange{},
 		&User{},
 		&UserGroup{},
 		&UserSession{},
@@ -579,6 +591,24 @@ func TestJSONMap_RoundTrip(t *testing.T) {
 	assert.Equal(t, original["bool"], result["bool"])
 }
 
+func TestStringList_RoundTrip(t *testing.T) {
+	original := StringList{"@payments", "dev@example.com"}
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var result StringList
+	require.NoError(t, result.Scan(value))
+	assert.Equal(t, original, result)
+
+	// A nil list is stored as an empty JSON array
+	value, err = StringList(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[]"), value)
+
+	assert.EqualError(t, result.Scan(42), "failed to scan StringList: invalid type")
+}
+
 func TestBaseRepository_Integration(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewBaseRepository(db)
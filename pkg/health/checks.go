@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/scheduler"
+)
+
+// stallGrace is added to the limits of a background job before it is
+// considered stalled, so a slow run or a busy host does not restart the process
+const stallGrace = 5 * time.Minute
+
+// Database pings the database
+func Database(db *database.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.Health()
+	}
+}
+
+// Migrations fails while the database schema is behind the migrations the
+// binary was built with, or a migration failed partway. A newer schema is
+// accepted, as during a rolling upgrade.
+func Migrations(db *database.DB, expected uint) CheckFunc {
+	return func(ctx context.Context) error {
+		version, dirty, err := db.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("migration %d failed partway and must be fixed manually", version)
+		}
+		if version < expected {
+			return fmt.Errorf("database is at migration %d, expected %d", version, expected)
+		}
+		return nil
+	}
+}
+
+// Workers fails when a background job has stalled: a run has lasted more
+// than two intervals, or the job is idle an interval past its next run
+func Workers(statuses func() []scheduler.JobStatus) CheckFunc {
+	return func(ctx context.Context) error {
+		now := time.Now()
+		var stalled []string
+		for _, job := range statuses() {
+			switch {
+			case job.Running && !job.StartedAt.IsZero():
+				if running := now.Sub(job.StartedAt); running > 2*job.Interval+stallGrace {
+					stalled = append(stalled, fmt.Sprintf("%s has been running for %s", job.Name, running.Round(time.Second)))
+				}
+			case !job.NextRunAt.IsZero():
+				if overdue := now.Sub(job.NextRunAt); overdue > job.Interval+stallGrace {
+					stalled = append(stalled, fmt.Sprintf("%s is %s overdue", job.Name, overdue.Round(time.Second)))
+				}
+			}
+		}
+		if len(stalled) > 0 {
+			return fmt.Errorf("background jobs stalled: %s", strings.Join(stalled, "; "))
+		}
+		return nil
+	}
+}
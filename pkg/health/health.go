@@ -0,0 +1,233 @@
+// Package health checks the dependencies of the Fern Platform for liveness
+// and readiness probes
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+const (
+	// DefaultTimeout bounds a check when no timeout is configured
+	DefaultTimeout = 5 * time.Second
+)
+
+// Status is the health of a check or of the instance
+type Status string
+
+const (
+	// StatusHealthy means every check passed
+	StatusHealthy Status = "healthy"
+	// StatusDegraded means only optional checks failed; the instance still serves traffic
+	StatusDegraded Status = "degraded"
+	// StatusUnhealthy means a critical or liveness check failed
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// Kind decides which probe a check belongs to and what its failure means
+type Kind string
+
+const (
+	// Critical checks must pass for the instance to be ready for traffic
+	Critical Kind = "critical"
+	// Optional checks degrade readiness without taking the instance out of service
+	Optional Kind = "optional"
+	// Liveness checks must pass for the process to be kept running
+	Liveness Kind = "liveness"
+)
+
+// CheckFunc checks a dependency, returning an error when it is unhealthy
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of the last run of a check
+type Result struct {
+	Name        string     `json:"name"`
+	Kind        Kind       `json:"kind"`
+	Status      Status     `json:"status"`
+	LatencyMs   float64    `json:"latency_ms"`
+	Error       string     `json:"error,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	CheckedAt   time.Time  `json:"checked_at"`
+}
+
+// Report is the combined result of a set of checks
+type Report struct {
+	Status    Status    `json:"status"`
+	Checks    []Result  `json:"checks"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// WithoutErrors returns the report without error messages, which may name
+// hosts or credentials, for unauthenticated probes
+func (r Report) WithoutErrors() Report {
+	checks := make([]Result, len(r.Checks))
+	for i, result := range r.Checks {
+		result.Error = ""
+		result.LastError = ""
+		result.LastErrorAt = nil
+		checks[i] = result
+	}
+	r.Checks = checks
+	return r
+}
+
+// check is a registered check with its cached result
+type check struct {
+	name string
+	kind Kind
+	fn   CheckFunc
+
+	// mu serializes runs, so concurrent probes share one run
+	mu     sync.Mutex
+	result *Result
+}
+
+// Registry runs the registered checks with a timeout and caches their
+// results for an interval, so frequent probes do not load the dependencies
+type Registry struct {
+	timeout  time.Duration
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	checks []*check
+}
+
+// NewRegistry creates a registry timing out checks after cfg.Timeout and
+// caching their results for cfg.Interval. A zero interval disables caching.
+func NewRegistry(cfg *config.HealthConfig) *Registry {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{
+		timeout:  timeout,
+		interval: cfg.Interval,
+		now:      time.Now,
+	}
+}
+
+// Register adds a check. Checks are reported in the order they are registered.
+func (r *Registry) Register(name string, kind Kind, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, &check{name: name, kind: kind, fn: fn})
+}
+
+// Live runs the liveness checks
+func (r *Registry) Live(ctx context.Context) Report {
+	return r.report(ctx, false, Liveness)
+}
+
+// Ready runs the critical and optional checks
+func (r *Registry) Ready(ctx context.Context) Report {
+	return r.report(ctx, false, Critical, Optional)
+}
+
+// All runs every check. With refresh, cached results are ignored.
+func (r *Registry) All(ctx context.Context, refresh bool) Report {
+	return r.report(ctx, refresh, Critical, Optional, Liveness)
+}
+
+// report runs the checks of the given kinds concurrently
+func (r *Registry) report(ctx context.Context, refresh bool, kinds ...Kind) Report {
+	r.mu.RLock()
+	var selected []*check
+	for _, c := range r.checks {
+		for _, kind := range kinds {
+			if c.kind == kind {
+				selected = append(selected, c)
+				break
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(selected))
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = r.result(ctx, c, refresh)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusHealthy, Checks: results, CheckedAt: r.now().UTC()}
+	for _, result := range results {
+		if result.Status == StatusHealthy {
+			continue
+		}
+		if result.Kind == Optional {
+			if report.Status == StatusHealthy {
+				report.Status = StatusDegraded
+			}
+			continue
+		}
+		report.Status = StatusUnhealthy
+	}
+	return report
+}
+
+// result returns the cached result of a check, running it when stale
+func (r *Registry) result(ctx context.Context, c *check, refresh bool) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !refresh && c.result != nil && r.now().Sub(c.result.CheckedAt) < r.interval {
+		return *c.result
+	}
+
+	started := r.now()
+	err := r.run(ctx, c.fn)
+	result := Result{
+		Name:      c.name,
+		Kind:      c.kind,
+		Status:    StatusHealthy,
+		LatencyMs: float64(r.now().Sub(started).Microseconds()) / 1000,
+		CheckedAt: started.UTC(),
+	}
+	if c.result != nil {
+		result.LastError = c.result.LastError
+		result.LastErrorAt = c.result.LastErrorAt
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+		result.LastError = err.Error()
+		result.LastErrorAt = &result.CheckedAt
+	}
+	c.result = &result
+	return result
+}
+
+// run runs a check, failing it when it outlasts the timeout even if it
+// ignores its context. The probe's cancellation does not fail the check, so
+// a client going away never caches a failure.
+func (r *Registry) run(ctx context.Context, fn CheckFunc) (err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("check panicked: %v", recovered)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", r.timeout)
+	}
+}
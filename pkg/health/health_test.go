@@ -0,0 +1,343 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
+	"github.com/guidewire-oss/fern-platform/pkg/middleware"
+	"github.com/guidewire-oss/fern-platform/pkg/scheduler"
+)
+
+func TestHealth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Health Suite")
+}
+
+// countingCheck counts its runs and fails while err is set
+type countingCheck struct {
+	mu   sync.Mutex
+	runs int
+	err  error
+}
+
+func (c *countingCheck) check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs++
+	return c.err
+}
+
+func (c *countingCheck) setError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func (c *countingCheck) runCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runs
+}
+
+var _ = Describe("Registry", Label("unit"), func() {
+	var (
+		ctx      context.Context
+		registry *health.Registry
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registry = health.NewRegistry(&config.HealthConfig{Interval: time.Minute, Timeout: time.Second})
+	})
+
+	It("should report every check healthy", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { return nil })
+
+		report := registry.Ready(ctx)
+
+		Expect(report.Status).To(Equal(health.StatusHealthy))
+		Expect(report.Checks).To(HaveLen(1))
+		Expect(report.Checks[0].Name).To(Equal("database"))
+		Expect(report.Checks[0].Status).To(Equal(health.StatusHealthy))
+		Expect(report.Checks[0].Error).To(BeEmpty())
+	})
+
+	It("should be unhealthy when a critical check fails", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { return errors.New("connection refused") })
+		registry.Register("jira", health.Optional, func(context.Context) error { return nil })
+
+		report := registry.Ready(ctx)
+
+		Expect(report.Status).To(Equal(health.StatusUnhealthy))
+		Expect(report.Checks[0].Error).To(Equal("connection refused"))
+	})
+
+	It("should only be degraded when an optional check fails", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { return nil })
+		registry.Register("jira", health.Optional, func(context.Context) error { return errors.New("jira is down") })
+
+		Expect(registry.Ready(ctx).Status).To(Equal(health.StatusDegraded))
+	})
+
+	It("should keep liveness and readiness checks apart", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { return errors.New("connection refused") })
+		registry.Register("background-jobs", health.Liveness, func(context.Context) error { return nil })
+
+		live := registry.Live(ctx)
+		Expect(live.Status).To(Equal(health.StatusHealthy))
+		Expect(live.Checks).To(HaveLen(1))
+		Expect(live.Checks[0].Name).To(Equal("background-jobs"))
+
+		ready := registry.Ready(ctx)
+		Expect(ready.Checks).To(HaveLen(1))
+		Expect(ready.Checks[0].Name).To(Equal("database"))
+
+		Expect(registry.All(ctx, false).Checks).To(HaveLen(2))
+	})
+
+	It("should cache results for the interval", func() {
+		check := &countingCheck{}
+		registry.Register("database", health.Critical, check.check)
+
+		registry.Ready(ctx)
+		registry.Ready(ctx)
+		registry.All(ctx, false)
+		Expect(check.runCount()).To(Equal(1))
+
+		registry.All(ctx, true)
+		Expect(check.runCount()).To(Equal(2))
+	})
+
+	It("should run checks on every probe without an interval", func() {
+		registry = health.NewRegistry(&config.HealthConfig{})
+		check := &countingCheck{}
+		registry.Register("database", health.Critical, check.check)
+
+		registry.Ready(ctx)
+		registry.Ready(ctx)
+
+		Expect(check.runCount()).To(Equal(2))
+	})
+
+	It("should fail checks that outlast the timeout", func() {
+		registry = health.NewRegistry(&config.HealthConfig{Timeout: 20 * time.Millisecond})
+		release := make(chan struct{})
+		defer close(release)
+		registry.Register("database", health.Critical, func(context.Context) error {
+			<-release
+			return nil
+		})
+
+		report := registry.Ready(ctx)
+
+		Expect(report.Status).To(Equal(health.StatusUnhealthy))
+		Expect(report.Checks[0].Error).To(Equal("timed out after 20ms"))
+		Expect(report.Checks[0].LatencyMs).To(BeNumerically(">=", 20))
+	})
+
+	It("should not cache a failure when the probe is canceled", func() {
+		registry.Register("database", health.Critical, func(ctx context.Context) error { return ctx.Err() })
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		Expect(registry.Ready(canceled).Status).To(Equal(health.StatusHealthy))
+	})
+
+	It("should report a check that panics as failed", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { panic("nil connection") })
+
+		report := registry.Ready(ctx)
+
+		Expect(report.Status).To(Equal(health.StatusUnhealthy))
+		Expect(report.Checks[0].Error).To(Equal("check panicked: nil connection"))
+	})
+
+	It("should remember the last error after a check recovers", func() {
+		registry = health.NewRegistry(&config.HealthConfig{})
+		check := &countingCheck{}
+		registry.Register("database", health.Critical, check.check)
+
+		check.setError(errors.New("connection refused"))
+		failed := registry.All(ctx, false).Checks[0]
+		check.setError(nil)
+		recovered := registry.All(ctx, false).Checks[0]
+
+		Expect(recovered.Status).To(Equal(health.StatusHealthy))
+		Expect(recovered.Error).To(BeEmpty())
+		Expect(recovered.LastError).To(Equal("connection refused"))
+		Expect(recovered.LastErrorAt).NotTo(BeNil())
+		Expect(*recovered.LastErrorAt).To(Equal(failed.CheckedAt))
+	})
+
+	It("should hide errors from unauthenticated probes", func() {
+		registry.Register("database", health.Critical, func(context.Context) error { return errors.New("dial tcp db.internal:5432") })
+
+		report := registry.Ready(ctx).WithoutErrors()
+
+		Expect(report.Status).To(Equal(health.StatusUnhealthy))
+		Expect(report.Checks[0].Error).To(BeEmpty())
+		Expect(report.Checks[0].LastError).To(BeEmpty())
+		Expect(report.Checks[0].LastErrorAt).To(BeNil())
+	})
+})
+
+var _ = Describe("Checks", Label("unit"), func() {
+	var (
+		ctx context.Context
+		db  *database.DB
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		Expect(err).NotTo(HaveOccurred())
+		db = &database.DB{DB: gormDB}
+	})
+
+	Describe("Database", func() {
+		It("should ping the database", func() {
+			check := health.Database(db)
+			Expect(check(ctx)).To(Succeed())
+
+			Expect(db.Close()).To(Succeed())
+			Expect(check(ctx)).To(HaveOccurred())
+		})
+	})
+
+	Describe("Migrations", func() {
+		migrated := func(version int, dirty bool) {
+			Expect(db.Exec("CREATE TABLE schema_migrations (version bigint, dirty boolean)").Error).To(Succeed())
+			Expect(db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, dirty).Error).To(Succeed())
+		}
+
+		It("should pass when the schema is current or newer", func() {
+			migrated(31, false)
+
+			Expect(health.Migrations(db, 31)(ctx)).To(Succeed())
+			Expect(health.Migrations(db, 30)(ctx)).To(Succeed())
+		})
+
+		It("should fail when migrations are pending", func() {
+			migrated(30, false)
+
+			Expect(health.Migrations(db, 31)(ctx)).To(MatchError("database is at migration 30, expected 31"))
+		})
+
+		It("should fail when a migration failed partway", func() {
+			migrated(31, true)
+
+			Expect(health.Migrations(db, 31)(ctx)).To(MatchError(ContainSubstring("migration 31 failed partway")))
+		})
+
+		It("should fail when no migration was applied", func() {
+			Expect(health.Migrations(db, 31)(ctx)).To(HaveOccurred())
+		})
+	})
+
+	Describe("Workers", func() {
+		check := func(statuses ...scheduler.JobStatus) error {
+			return health.Workers(func() []scheduler.JobStatus { return statuses })(context.Background())
+		}
+
+		It("should pass for jobs on schedule", func() {
+			Expect(check(
+				scheduler.JobStatus{Name: "webhook-delivery", Interval: 10 * time.Second, NextRunAt: time.Now().Add(5 * time.Second)},
+				scheduler.JobStatus{Name: "flaky-lifecycle", Interval: 15 * time.Minute, Running: true, StartedAt: time.Now().Add(-20 * time.Minute)},
+				scheduler.JobStatus{Name: "not-started", Interval: time.Minute},
+			)).To(Succeed())
+		})
+
+		It("should fail for a run that does not finish", func() {
+			err := check(scheduler.JobStatus{Name: "jira-issue-sync", Interval: 5 * time.Minute, Running: true, StartedAt: time.Now().Add(-time.Hour)})
+
+			Expect(err).To(MatchError(ContainSubstring("jira-issue-sync has been running for 1h0m0s")))
+		})
+
+		It("should fail for a job that missed its schedule", func() {
+			err := check(scheduler.JobStatus{Name: "vcs-reports", Interval: 10 * time.Second, NextRunAt: time.Now().Add(-10 * time.Minute)})
+
+			Expect(err).To(MatchError(ContainSubstring("vcs-reports is 10m0s overdue")))
+		})
+	})
+})
+
+var _ = Describe("HealthCheckMiddleware", Label("unit"), func() {
+	var (
+		router      *gin.Engine
+		databaseErr error
+		jobsErr     error
+	)
+
+	BeforeEach(func() {
+		databaseErr, jobsErr = nil, nil
+		registry := health.NewRegistry(&config.HealthConfig{})
+		registry.Register("database", health.Critical, func(context.Context) error { return databaseErr })
+		registry.Register("background-jobs", health.Liveness, func(context.Context) error { return jobsErr })
+
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(middleware.HealthCheckMiddleware(registry, "/health"))
+		router.GET("/api/v1/projects", func(c *gin.Context) { c.Status(http.StatusOK) })
+	})
+
+	get := func(path string) (int, health.Report) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var report health.Report
+		if strings.HasPrefix(path, "/health") {
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &report)).To(Succeed())
+		}
+		return recorder.Code, report
+	}
+
+	It("should answer probes while the instance is healthy", func() {
+		for _, path := range []string{"/healthz/live", "/healthz/ready", "/health"} {
+			code, report := get(path)
+			Expect(code).To(Equal(http.StatusOK), path)
+			Expect(report.Status).To(Equal(health.StatusHealthy), path)
+		}
+	})
+
+	It("should take an instance without its database out of service without restarting it", func() {
+		databaseErr = errors.New("dial tcp db.internal:5432: connection refused")
+
+		code, report := get("/healthz/ready")
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(report.Checks[0].Error).To(BeEmpty())
+
+		code, _ = get("/health")
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+
+		code, _ = get("/healthz/live")
+		Expect(code).To(Equal(http.StatusOK))
+	})
+
+	It("should fail liveness when background jobs stall", func() {
+		jobsErr = errors.New("background jobs stalled")
+
+		code, _ := get("/healthz/live")
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should pass other requests through", func() {
+		code, _ := get("/api/v1/projects")
+		Expect(code).To(Equal(http.StatusOK))
+	})
+})
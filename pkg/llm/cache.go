@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	explanation Explanation
+	expiresAt   time.Time
+}
+
+// cache is an in-memory TTL cache of explanations keyed by prompt hash
+type cache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func cacheKey(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *cache) get(key string) (Explanation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return Explanation{}, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return Explanation{}, false
+	}
+	return entry.explanation, true
+}
+
+func (c *cache) set(key string, explanation Explanation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	// Drop expired entries on write so the cache cannot grow without bound
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{explanation: explanation, expiresAt: now.Add(c.ttl)}
+}
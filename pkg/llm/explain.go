@@ -0,0 +1,260 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+const (
+	defaultMaxTokens = 4000
+
+	// charsPerToken is a conservative estimate that holds for English text and code
+	charsPerToken = 4
+
+	minResponseTokens = 128
+	maxResponseTokens = 1024
+
+	maxHistoryEntries = 20
+	maxClusterMembers = 25
+	truncationMarker  = "\n...[truncated]"
+)
+
+const systemPrompt = `You are a senior engineer helping a team triage automated test failures.
+Given a failing test, its error, stack trace and recent history, explain the most likely root cause,
+say whether it looks like a product bug, a test bug, an environment issue or flakiness, and suggest
+concrete next steps. Be concise and use short paragraphs or bullet points.`
+
+// HistoryEntry is a previous execution of the failing test
+type HistoryEntry struct {
+	Status       string
+	Branch       string
+	ErrorMessage string
+	ExecutedAt   time.Time
+}
+
+// FailureContext describes a single failed test execution
+type FailureContext struct {
+	TestName     string
+	SuiteName    string
+	ErrorMessage string
+	StackTrace   string
+	History      []HistoryEntry
+}
+
+// Explanation is a generated summary of a failure or failure cluster
+type Explanation struct {
+	Summary     string
+	Provider    string
+	Model       string
+	Cached      bool
+	Truncated   bool
+	GeneratedAt time.Time
+}
+
+// Explainer produces failure explanations using a provider. Input is redacted
+// and bounded before it leaves the server, and results are cached when enabled.
+type Explainer struct {
+	provider       Provider
+	redactor       *Redactor
+	cache          *cache
+	promptChars    int
+	responseTokens int
+	temperature    float32
+}
+
+// NewExplainer creates an explainer using the limits and cache settings in cfg
+func NewExplainer(provider Provider, cfg config.LLMConfig) *Explainer {
+	maxTokens := cfg.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+
+	// Reserve a quarter of the budget for the answer; the rest bounds the prompt
+	responseTokens := maxTokens / 4
+	if responseTokens < minResponseTokens {
+		responseTokens = minResponseTokens
+	}
+	if responseTokens > maxResponseTokens {
+		responseTokens = maxResponseTokens
+	}
+	promptTokens := maxTokens - responseTokens - utf8.RuneCountInString(systemPrompt)/charsPerToken
+	if promptTokens < minResponseTokens {
+		promptTokens = minResponseTokens
+	}
+
+	explainer := &Explainer{
+		provider:       provider,
+		redactor:       NewRedactor(),
+		promptChars:    promptTokens * charsPerToken,
+		responseTokens: responseTokens,
+		temperature:    cfg.Temperature,
+	}
+	if cfg.CacheEnabled && cfg.CacheTTL > 0 {
+		explainer.cache = newCache(cfg.CacheTTL)
+	}
+	return explainer
+}
+
+// WithRedactor replaces the default redactor
+func (e *Explainer) WithRedactor(redactor *Redactor) *Explainer {
+	e.redactor = redactor
+	return e
+}
+
+// ExplainFailure explains a single failed test execution
+func (e *Explainer) ExplainFailure(ctx context.Context, failure FailureContext) (*Explanation, error) {
+	return e.ExplainCluster(ctx, []FailureContext{failure})
+}
+
+// ExplainCluster explains a group of related failures. The first failure is
+// treated as representative and described in full; the rest are summarized.
+func (e *Explainer) ExplainCluster(ctx context.Context, failures []FailureContext) (*Explanation, error) {
+	if len(failures) == 0 {
+		return nil, fmt.Errorf("at least one failure is required")
+	}
+
+	prompt, truncated := e.BuildPrompt(failures)
+
+	var key string
+	if e.cache != nil {
+		key = cacheKey(e.provider.Name(), e.provider.Model(), prompt)
+		if cached, ok := e.cache.get(key); ok {
+			cached.Cached = true
+			return &cached, nil
+		}
+	}
+
+	resp, err := e.provider.Complete(ctx, CompletionRequest{
+		Messages: []Message{
+			{Role: RoleSystem, Content: systemPrompt},
+			{Role: RoleUser, Content: prompt},
+		},
+		MaxTokens:   e.responseTokens,
+		Temperature: e.temperature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate explanation: %w", err)
+	}
+	if resp.Content == "" {
+		return nil, fmt.Errorf("failed to generate explanation: empty response")
+	}
+
+	explanation := Explanation{
+		Summary:     resp.Content,
+		Provider:    e.provider.Name(),
+		Model:       resp.Model,
+		Truncated:   truncated,
+		GeneratedAt: time.Now(),
+	}
+	if e.cache != nil {
+		e.cache.set(key, explanation)
+	}
+	return &explanation, nil
+}
+
+// BuildPrompt renders the redacted, size-bounded user prompt for the failures
+// and reports whether any input was truncated to fit
+func (e *Explainer) BuildPrompt(failures []FailureContext) (string, bool) {
+	budget := e.promptChars
+	truncated := false
+	fit := func(text string, limit int) string {
+		text = e.redactor.Redact(strings.TrimSpace(text))
+		result, cut := truncate(text, limit)
+		truncated = truncated || cut
+		return result
+	}
+
+	primary := failures[0]
+	var b strings.Builder
+
+	if len(failures) > 1 {
+		fmt.Fprintf(&b, "A cluster of %d similar test failures was observed. Representative failure:\n\n", len(failures))
+	} else {
+		b.WriteString("A test failed.\n\n")
+	}
+
+	fmt.Fprintf(&b, "Test: %s\n", fit(primary.TestName, budget/20))
+	if primary.SuiteName != "" {
+		fmt.Fprintf(&b, "Suite: %s\n", fit(primary.SuiteName, budget/20))
+	}
+	if primary.ErrorMessage != "" {
+		fmt.Fprintf(&b, "\nError message:\n%s\n", fit(primary.ErrorMessage, budget/4))
+	}
+	if primary.StackTrace != "" {
+		fmt.Fprintf(&b, "\nStack trace:\n%s\n", fit(primary.StackTrace, budget*3/10))
+	}
+
+	if len(failures) > 1 {
+		section := renderClusterMembers(failures[1:], e.redactor)
+		fmt.Fprintf(&b, "\nOther failures in the cluster:\n%s", fit(section, budget/10))
+	}
+
+	if len(primary.History) > 0 {
+		section := renderHistory(primary.History, e.redactor)
+		fmt.Fprintf(&b, "\nRecent history (newest first):\n%s", fit(section, budget/5))
+	}
+
+	// Guard the overall budget; the per-section limits above normally keep us under it
+	prompt, cut := truncate(b.String(), budget)
+	return prompt, truncated || cut
+}
+
+func renderClusterMembers(failures []FailureContext, redactor *Redactor) string {
+	var b strings.Builder
+	for i, failure := range failures {
+		if i == maxClusterMembers {
+			fmt.Fprintf(&b, "- ... and %d more\n", len(failures)-maxClusterMembers)
+			break
+		}
+		name := failure.TestName
+		if failure.SuiteName != "" {
+			name = failure.SuiteName + " / " + name
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", redactor.Redact(name), redactor.Redact(firstLine(failure.ErrorMessage)))
+	}
+	return b.String()
+}
+
+func renderHistory(history []HistoryEntry, redactor *Redactor) string {
+	var b strings.Builder
+	for i, entry := range history {
+		if i == maxHistoryEntries {
+			break
+		}
+		fmt.Fprintf(&b, "- %s %s", entry.ExecutedAt.UTC().Format(time.RFC3339), entry.Status)
+		if entry.Branch != "" {
+			fmt.Fprintf(&b, " on %s", redactor.Redact(entry.Branch))
+		}
+		if entry.ErrorMessage != "" {
+			fmt.Fprintf(&b, ": %s", redactor.Redact(firstLine(entry.ErrorMessage)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.IndexByte(text, '\n'); idx >= 0 {
+		return strings.TrimSpace(text[:idx])
+	}
+	return text
+}
+
+// truncate shortens text to at most limit runes, marking the cut
+func truncate(text string, limit int) (string, bool) {
+	if utf8.RuneCountInString(text) <= limit {
+		return text, false
+	}
+	keep := limit - len(truncationMarker)
+	if keep < 0 {
+		keep = 0
+	}
+	runes := []rune(text)
+	return string(runes[:keep]) + truncationMarker, true
+}
@@ -0,0 +1,96 @@
+// Package llm provides pluggable large language model providers and
+// failure summarization built on top of them
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+// Message roles used in completion requests
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Provider types that speak the OpenAI chat completions protocol
+const (
+	ProviderTypeOpenAI           = "openai"
+	ProviderTypeOllama           = "ollama"
+	ProviderTypeLlamaCpp         = "llamacpp"
+	ProviderTypeOpenAICompatible = "openai-compatible"
+)
+
+var (
+	// ErrNotConfigured is returned when no usable provider is configured
+	ErrNotConfigured = errors.New("llm provider not configured")
+
+	// ErrUnsupportedProvider is returned for provider types without an implementation
+	ErrUnsupportedProvider = errors.New("unsupported llm provider type")
+)
+
+// Message is a single chat message sent to a provider
+type Message struct {
+	Role    string
+	Content string
+}
+
+// CompletionRequest is a chat completion request
+type CompletionRequest struct {
+	Messages    []Message
+	MaxTokens   int
+	Temperature float32
+}
+
+// CompletionResponse is the result of a chat completion
+type CompletionResponse struct {
+	Content          string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Provider generates chat completions
+type Provider interface {
+	// Name identifies the provider in logs and cache keys
+	Name() string
+
+	// Model returns the model used for completions
+	Model() string
+
+	// Complete generates a completion for the request
+	Complete(ctx context.Context, req CompletionRequest) (*CompletionResponse, error)
+}
+
+// NewProvider creates a provider from its configuration
+func NewProvider(name string, cfg config.LLMProvider) (Provider, error) {
+	if !cfg.Enabled {
+		return nil, fmt.Errorf("%w: provider %s is disabled", ErrNotConfigured, name)
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case ProviderTypeOpenAI, ProviderTypeOllama, ProviderTypeLlamaCpp, ProviderTypeOpenAICompatible:
+		return NewOpenAIProvider(name, cfg.BaseURL, cfg.APIKey, cfg.Model)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, cfg.Type)
+	}
+}
+
+// NewDefaultProvider creates the configured default provider
+func NewDefaultProvider(cfg config.LLMConfig) (Provider, error) {
+	if cfg.DefaultProvider == "" {
+		return nil, fmt.Errorf("%w: no default provider set", ErrNotConfigured)
+	}
+
+	providerCfg, ok := cfg.Providers[cfg.DefaultProvider]
+	if !ok {
+		return nil, fmt.Errorf("%w: provider %s not found", ErrNotConfigured, cfg.DefaultProvider)
+	}
+
+	return NewProvider(cfg.DefaultProvider, providerCfg)
+}
@@ -0,0 +1,144 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/llm"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLLM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LLM Suite")
+}
+
+var _ = Describe("NewProvider", Label("unit"), func() {
+	It("should create an OpenAI-compatible provider for local servers", func() {
+		for _, providerType := range []string{"openai", "ollama", "llamacpp", "openai-compatible"} {
+			provider, err := llm.NewProvider("local", config.LLMProvider{
+				Type:    providerType,
+				BaseURL: "http://localhost:8080",
+				Model:   "llama3",
+				Enabled: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.Name()).To(Equal("local"))
+			Expect(provider.Model()).To(Equal("llama3"))
+		}
+	})
+
+	It("should reject disabled and unsupported providers", func() {
+		_, err := llm.NewProvider("ollama", config.LLMProvider{Type: "ollama", Model: "llama3"})
+		Expect(err).To(MatchError(llm.ErrNotConfigured))
+
+		_, err = llm.NewProvider("hf", config.LLMProvider{Type: "huggingface", Model: "x", Enabled: true})
+		Expect(err).To(MatchError(llm.ErrUnsupportedProvider))
+	})
+
+	It("should resolve the default provider", func() {
+		cfg := config.LLMConfig{
+			DefaultProvider: "ollama",
+			Providers: map[string]config.LLMProvider{
+				"ollama": {Type: "ollama", BaseURL: "http://localhost:11434", Model: "llama3", Enabled: true},
+			},
+		}
+		provider, err := llm.NewDefaultProvider(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.Name()).To(Equal("ollama"))
+
+		cfg.DefaultProvider = "missing"
+		_, err = llm.NewDefaultProvider(cfg)
+		Expect(err).To(MatchError(llm.ErrNotConfigured))
+	})
+})
+
+var _ = Describe("OpenAIProvider", Label("unit"), func() {
+	var (
+		server   *httptest.Server
+		received map[string]interface{}
+		authz    string
+		path     string
+	)
+
+	BeforeEach(func() {
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			authz = r.Header.Get("Authorization")
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"model": "llama3:8b",
+				"choices": [{"message": {"role": "assistant", "content": "  The database was unavailable.  "}}],
+				"usage": {"prompt_tokens": 42, "completion_tokens": 7}
+			}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send a chat completion request and parse the response", func() {
+		provider, err := llm.NewOpenAIProvider("local", server.URL, "secret-key", "llama3")
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := provider.Complete(context.Background(), llm.CompletionRequest{
+			Messages:    []llm.Message{{Role: llm.RoleUser, Content: "why?"}},
+			MaxTokens:   256,
+			Temperature: 0.2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(path).To(Equal("/v1/chat/completions"))
+		Expect(authz).To(Equal("Bearer secret-key"))
+		Expect(received["model"]).To(Equal("llama3"))
+		Expect(received["max_tokens"]).To(BeNumerically("==", 256))
+		Expect(received["stream"]).To(BeFalse())
+		Expect(received["messages"]).To(HaveLen(1))
+
+		Expect(resp.Content).To(Equal("The database was unavailable."))
+		Expect(resp.Model).To(Equal("llama3:8b"))
+		Expect(resp.PromptTokens).To(Equal(42))
+		Expect(resp.CompletionTokens).To(Equal(7))
+	})
+
+	It("should accept base URLs that already include /v1 and omit auth without a key", func() {
+		provider, err := llm.NewOpenAIProvider("local", server.URL+"/v1/", "", "llama3")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = provider.Complete(context.Background(), llm.CompletionRequest{
+			Messages: []llm.Message{{Role: llm.RoleUser, Content: "why?"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/v1/chat/completions"))
+		Expect(authz).To(BeEmpty())
+	})
+
+	It("should surface provider errors", func() {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "model not loaded", http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		provider, err := llm.NewOpenAIProvider("local", failing.URL, "", "llama3")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = provider.Complete(context.Background(), llm.CompletionRequest{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("503"))
+		Expect(err.Error()).To(ContainSubstring("model not loaded"))
+	})
+
+	It("should require a model", func() {
+		_, err := llm.NewOpenAIProvider("local", server.URL, "", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
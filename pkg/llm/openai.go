@@ -0,0 +1,152 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRequestTimeout = 60 * time.Second
+	maxErrorBodyBytes     = 1024
+)
+
+// OpenAIProvider talks to any server implementing the OpenAI chat completions
+// API, such as OpenAI itself, Ollama, llama.cpp or vLLM
+type OpenAIProvider struct {
+	name       string
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOpenAIProvider creates a provider for an OpenAI-compatible endpoint
+func NewOpenAIProvider(name, baseURL, apiKey, model string) (*OpenAIProvider, error) {
+	if baseURL == "" {
+		baseURL = "https://api.openai.com"
+	}
+	if model == "" {
+		return nil, fmt.Errorf("model is required for provider %s", name)
+	}
+
+	return &OpenAIProvider{
+		name:       name,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: defaultRequestTimeout},
+	}, nil
+}
+
+// WithHTTPClient replaces the HTTP client used for requests
+func (p *OpenAIProvider) WithHTTPClient(client *http.Client) *OpenAIProvider {
+	p.httpClient = client
+	return p
+}
+
+// Name returns the provider name
+func (p *OpenAIProvider) Name() string {
+	return p.name
+}
+
+// Model returns the model used for completions
+func (p *OpenAIProvider) Model() string {
+	return p.model
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature float32       `json:"temperature"`
+	Stream      bool          `json:"stream"`
+}
+
+type chatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Complete sends a chat completion request
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (*CompletionResponse, error) {
+	messages := make([]chatMessage, len(req.Messages))
+	for i, msg := range req.Messages {
+		messages[i] = chatMessage{Role: msg.Role, Content: msg.Content}
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model:       p.model,
+		Messages:    messages,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal completion request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.completionsURL(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create completion request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call provider %s: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return nil, fmt.Errorf("provider %s returned status %d: %s", p.name, resp.StatusCode, strings.TrimSpace(string(errBody)))
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode completion response: %w", err)
+	}
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("provider %s returned no choices", p.name)
+	}
+
+	model := result.Model
+	if model == "" {
+		model = p.model
+	}
+
+	return &CompletionResponse{
+		Content:          strings.TrimSpace(result.Choices[0].Message.Content),
+		Model:            model,
+		PromptTokens:     result.Usage.PromptTokens,
+		CompletionTokens: result.Usage.CompletionTokens,
+	}, nil
+}
+
+// completionsURL accepts base URLs with or without the /v1 suffix
+func (p *OpenAIProvider) completionsURL() string {
+	if strings.HasSuffix(p.baseURL, "/v1") {
+		return p.baseURL + "/chat/completions"
+	}
+	return p.baseURL + "/v1/chat/completions"
+}
@@ -0,0 +1,49 @@
+package llm
+
+import (
+	"regexp"
+)
+
+type redactionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor removes secrets and personal data from text before it is sent to
+// a provider
+type Redactor struct {
+	rules []redactionRule
+}
+
+// NewRedactor creates a redactor with the default rules; extra patterns are
+// replaced with [REDACTED]
+func NewRedactor(extraPatterns ...*regexp.Regexp) *Redactor {
+	// Order matters: broader structures are redacted before the pieces they contain
+	rules := []redactionRule{
+		{regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`), "[REDACTED_PRIVATE_KEY]"},
+		{regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s:@]+:[^/\s@]+@`), "${1}[REDACTED_CREDENTIALS]@"},
+		{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), "[REDACTED_JWT]"},
+		{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`), "$1 [REDACTED]"},
+		{regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`), "[REDACTED_AWS_KEY]"},
+		{regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|xox[abpr]-[A-Za-z0-9-]{10,}|sk-[A-Za-z0-9_-]{20,})\b`), "[REDACTED_TOKEN]"},
+		{regexp.MustCompile(`(?i)\b([a-z0-9_.-]*(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|client[_-]?secret|credentials?)[a-z0-9_.-]*)(["']?\s*[:=]\s*["']?)[^\s"',;&]+`), "${1}${2}[REDACTED]"},
+		{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[REDACTED_EMAIL]"},
+		{regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`), "[REDACTED_IP]"},
+	}
+	for _, pattern := range extraPatterns {
+		rules = append(rules, redactionRule{pattern: pattern, replacement: "[REDACTED]"})
+	}
+
+	return &Redactor{rules: rules}
+}
+
+// Redact returns text with all sensitive matches replaced
+func (r *Redactor) Redact(text string) string {
+	if text == "" {
+		return text
+	}
+	for _, rule := range r.rules {
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+	return text
+}
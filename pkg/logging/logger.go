@@ -55,6 +55,9 @@ func NewLogger(cfg *config.LoggingConfig) (*Logger, error) {
 		})
 	}
 
+	// Add trace and span IDs to entries logged with a traced context
+	logger.AddHook(traceHook{})
+
 	return &Logger{
 		Logger: logger,
 		config: cfg,
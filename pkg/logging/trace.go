@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHook adds the IDs of the active span to entries logged with a context,
+// so log lines can be joined to their trace
+type traceHook struct{}
+
+// Levels applies the hook to every level
+func (traceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds trace_id and span_id when the entry's context carries a span
+func (traceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	entry.Data["trace_id"] = spanContext.TraceID().String()
+	entry.Data["span_id"] = spanContext.SpanID().String()
+	return nil
+}
+
+// WithTrace returns an entry carrying ctx, logged with the trace and span IDs
+// of its active span. Entries built otherwise can use Entry.WithContext.
+func (l *Logger) WithTrace(ctx context.Context) *logrus.Entry {
+	return l.Logger.WithContext(ctx)
+}
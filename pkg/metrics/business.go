@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+)
+
+// businessQueryTimeout bounds the queries of a scrape, so a slow database
+// does not hang the metrics endpoint
+const businessQueryTimeout = 5 * time.Second
+
+// businessCollector loads gauges of the test data from the database on each
+// scrape. Every instance reports the same values, so aggregate them across
+// instances with max rather than sum.
+type businessCollector struct {
+	db *gorm.DB
+
+	activeFlakyTests *prometheus.Desc
+	runningTestRuns  *prometheus.Desc
+	scrapeErrors     *prometheus.Desc
+}
+
+func newBusinessCollector(db *gorm.DB) *businessCollector {
+	return &businessCollector{
+		db: db,
+		activeFlakyTests: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "flaky", "active_tests"),
+			"Active flaky tests by project.",
+			[]string{"project_id"}, nil,
+		),
+		runningTestRuns: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "test_runs", "running"),
+			"Test runs in running state by project.",
+			[]string{"project_id"}, nil,
+		),
+		scrapeErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "business_metrics", "scrape_error"),
+			"1 if the gauges of the test data could not be loaded on this scrape.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe sends the descriptors of the gauges
+func (c *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeFlakyTests
+	ch <- c.runningTestRuns
+	ch <- c.scrapeErrors
+}
+
+// Collect loads the gauges from the database
+func (c *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessQueryTimeout)
+	defer cancel()
+
+	failed := 0.0
+	if err := c.collectByProject(ctx, ch, c.activeFlakyTests, &database.FlakyTest{}, "active"); err != nil {
+		failed = 1
+	}
+	if err := c.collectByProject(ctx, ch, c.runningTestRuns, &database.TestRun{}, "running"); err != nil {
+		failed = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.scrapeErrors, prometheus.GaugeValue, failed)
+}
+
+// collectByProject sends the number of rows of a model with a status by project
+func (c *businessCollector) collectByProject(ctx context.Context, ch chan<- prometheus.Metric, desc *prometheus.Desc, model interface{}, status string) error {
+	var rows []struct {
+		ProjectID string
+		Count     int64
+	}
+
+	err := c.db.WithContext(ctx).Model(model).
+		Select("project_id, COUNT(*) AS count").
+		Where("status = ?", status).
+		Group("project_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(row.Count), row.ProjectID)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// graphqlMetrics is a gqlgen extension recording the duration and errors of
+// each GraphQL response
+type graphqlMetrics struct {
+	registry *Registry
+}
+
+var (
+	_ graphql.HandlerExtension    = graphqlMetrics{}
+	_ graphql.ResponseInterceptor = graphqlMetrics{}
+)
+
+// ExtensionName identifies the extension
+func (graphqlMetrics) ExtensionName() string {
+	return "Metrics"
+}
+
+// Validate accepts any schema
+func (graphqlMetrics) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse times the response of an operation and counts its errors.
+// Subscriptions are timed per event they send.
+func (m graphqlMetrics) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	start := time.Now()
+	response := next(ctx)
+
+	name, kind := operationLabels(ctx)
+	m.registry.graphqlOperationDuration.WithLabelValues(name, kind).Observe(time.Since(start).Seconds())
+	if response != nil && len(response.Errors) > 0 {
+		m.registry.graphqlErrors.WithLabelValues(name, kind).Add(float64(len(response.Errors)))
+	}
+	return response
+}
+
+// operationLabels returns the name and type of the operation of a response.
+// Requests that could not be parsed have no operation.
+func operationLabels(ctx context.Context) (string, string) {
+	if !graphql.HasOperationContext(ctx) {
+		return "invalid", "unknown"
+	}
+	operation := graphql.GetOperationContext(ctx)
+	if operation.Operation == nil {
+		return "invalid", "unknown"
+	}
+
+	name := operation.OperationName
+	if name == "" {
+		name = operation.Operation.Name
+	}
+	if name == "" {
+		name = "anonymous"
+	}
+	return name, string(operation.Operation.Operation)
+}
@@ -0,0 +1,210 @@
+// Package metrics exports Prometheus metrics of the Fern Platform
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+)
+
+// namespace prefixes the name of every metric
+const namespace = "fern"
+
+// Registry holds the metrics of the platform and records them for the HTTP
+// server, GraphQL, ingestion and flaky test analysis
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequestDuration  *prometheus.HistogramVec
+	httpRequestsInFlight prometheus.Gauge
+
+	graphqlOperationDuration *prometheus.HistogramVec
+	graphqlErrors            *prometheus.CounterVec
+
+	ingestedTestRuns  *prometheus.CounterVec
+	ingestedSuiteRuns *prometheus.CounterVec
+	ingestedSpecRuns  *prometheus.CounterVec
+
+	flakyAnalysisDuration *prometheus.HistogramVec
+}
+
+// NewRegistry creates a registry with the platform metrics and the Go
+// runtime and process metrics
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpRequestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		graphqlOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of GraphQL operations by operation name and type.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "type"}),
+		graphqlErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "errors_total",
+			Help:      "Errors returned by GraphQL operations by operation name and type.",
+		}, []string{"operation", "type"}),
+		ingestedTestRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "test_runs_total",
+			Help:      "Test runs ingested by project.",
+		}, []string{"project_id"}),
+		ingestedSuiteRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "suite_runs_total",
+			Help:      "Suite runs ingested by project.",
+		}, []string{"project_id"}),
+		ingestedSpecRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ingestion",
+			Name:      "spec_runs_total",
+			Help:      "Spec runs ingested by project.",
+		}, []string{"project_id"}),
+		flakyAnalysisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "flaky",
+			Name:      "analysis_duration_seconds",
+			Help:      "Duration of flaky test analyses by kind and result.",
+			Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 120, 300},
+		}, []string{"kind", "result"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequestDuration,
+		r.httpRequestsInFlight,
+		r.graphqlOperationDuration,
+		r.graphqlErrors,
+		r.ingestedTestRuns,
+		r.ingestedSuiteRuns,
+		r.ingestedSpecRuns,
+		r.flakyAnalysisDuration,
+	)
+	return r
+}
+
+// RegisterDatabase adds the connection pool stats of the database and the
+// gauges loaded from it: active flaky tests and running test runs by project
+func (r *Registry) RegisterDatabase(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	if err := r.registry.Register(collectors.NewDBStatsCollector(sqlDB, namespace)); err != nil {
+		return fmt.Errorf("failed to register database pool metrics: %w", err)
+	}
+	if err := r.registry.Register(newBusinessCollector(db)); err != nil {
+		return fmt.Errorf("failed to register business metrics: %w", err)
+	}
+	return nil
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// HTTPMiddleware records the duration of HTTP requests. Requests are labelled
+// with their route pattern rather than their path, so that IDs in paths do
+// not create a series per request.
+func (r *Registry) HTTPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		r.httpRequestsInFlight.Inc()
+		defer r.httpRequestsInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		r.httpRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// GraphQLExtension returns a gqlgen extension recording the duration and
+// errors of GraphQL operations
+func (r *Registry) GraphQLExtension() graphql.HandlerExtension {
+	return graphqlMetrics{registry: r}
+}
+
+// RecordIngestion counts the test runs, suite runs and spec runs ingested for a project
+func (r *Registry) RecordIngestion(projectID string, testRuns, suiteRuns, specRuns int) {
+	if testRuns > 0 {
+		r.ingestedTestRuns.WithLabelValues(projectID).Add(float64(testRuns))
+	}
+	if suiteRuns > 0 {
+		r.ingestedSuiteRuns.WithLabelValues(projectID).Add(float64(suiteRuns))
+	}
+	if specRuns > 0 {
+		r.ingestedSpecRuns.WithLabelValues(projectID).Add(float64(specRuns))
+	}
+}
+
+// FlakyAnalysisCompleted records how long a flaky test analysis took. Kind
+// names the analysis, such as test_run or lifecycle.
+func (r *Registry) FlakyAnalysisCompleted(kind string, duration time.Duration, err error) {
+	r.flakyAnalysisDuration.WithLabelValues(kind, result(err)).Observe(duration.Seconds())
+}
+
+// NewServer creates the HTTP server exposing the metrics on the port and path
+// of the config, separately from the API
+func NewServer(cfg *config.MetricsConfig, registry *Registry) *http.Server {
+	path := cfg.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, registry.Handler())
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// result labels the outcome of an operation
+func result(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,207 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metrics Suite")
+}
+
+// scrape returns the metrics exposed by the registry
+func scrape(registry *metrics.Registry) string {
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	Expect(recorder.Code).To(Equal(http.StatusOK))
+	return recorder.Body.String()
+}
+
+var _ = Describe("Registry", Label("unit"), func() {
+	var registry *metrics.Registry
+
+	BeforeEach(func() {
+		registry = metrics.NewRegistry()
+	})
+
+	It("should expose the Go runtime metrics", func() {
+		Expect(scrape(registry)).To(ContainSubstring("go_goroutines"))
+	})
+
+	Describe("HTTPMiddleware", func() {
+		var router *gin.Engine
+
+		BeforeEach(func() {
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.Use(registry.HTTPMiddleware())
+			router.GET("/api/v1/projects/:projectId", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			router.POST("/api/v1/projects/:projectId", func(c *gin.Context) {
+				c.Status(http.StatusForbidden)
+			})
+		})
+
+		It("should label requests with their route rather than their path", func() {
+			for _, path := range []string{"/api/v1/projects/shop", "/api/v1/projects/payments"} {
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+			}
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/projects/shop", nil))
+
+			exposed := scrape(registry)
+			Expect(exposed).To(ContainSubstring(`fern_http_request_duration_seconds_count{method="GET",route="/api/v1/projects/:projectId",status="200"} 2`))
+			Expect(exposed).To(ContainSubstring(`fern_http_request_duration_seconds_count{method="POST",route="/api/v1/projects/:projectId",status="403"} 1`))
+			Expect(exposed).NotTo(ContainSubstring("/api/v1/projects/shop"))
+			Expect(exposed).To(ContainSubstring("fern_http_requests_in_flight 0"))
+		})
+
+		It("should group requests without a route", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/123", nil))
+
+			Expect(scrape(registry)).To(ContainSubstring(`fern_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`))
+		})
+	})
+
+	Describe("GraphQLExtension", func() {
+		var server *testserver.TestServer
+
+		BeforeEach(func() {
+			server = testserver.New()
+			server.AddTransport(transport.POST{})
+			server.Use(registry.GraphQLExtension())
+		})
+
+		post := func(body string) {
+			request := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			server.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		It("should time operations by name and type", func() {
+			post(`{"query":"query ProjectName { name }"}`)
+			post(`{"query":"{ name }"}`)
+
+			exposed := scrape(registry)
+			Expect(exposed).To(ContainSubstring(`fern_graphql_operation_duration_seconds_count{operation="ProjectName",type="query"} 1`))
+			Expect(exposed).To(ContainSubstring(`fern_graphql_operation_duration_seconds_count{operation="anonymous",type="query"} 1`))
+			Expect(exposed).NotTo(ContainSubstring("fern_graphql_errors_total{"))
+		})
+
+		It("should count errors of failed operations", func() {
+			post(`{"query":"mutation Rename { name }"}`)
+			post(`{"query":"{ name"}`)
+
+			exposed := scrape(registry)
+			Expect(exposed).To(ContainSubstring(`fern_graphql_errors_total{operation="Rename",type="mutation"} 1`))
+			Expect(exposed).To(ContainSubstring(`fern_graphql_errors_total{operation="invalid",type="unknown"} 1`))
+		})
+	})
+
+	It("should count ingested test data by project", func() {
+		registry.RecordIngestion("shop", 1, 3, 40)
+		registry.RecordIngestion("shop", 0, 1, 5)
+		registry.RecordIngestion("payments", 1, 0, 0)
+
+		exposed := scrape(registry)
+		Expect(exposed).To(ContainSubstring(`fern_ingestion_test_runs_total{project_id="shop"} 1`))
+		Expect(exposed).To(ContainSubstring(`fern_ingestion_suite_runs_total{project_id="shop"} 4`))
+		Expect(exposed).To(ContainSubstring(`fern_ingestion_spec_runs_total{project_id="shop"} 45`))
+		Expect(exposed).To(ContainSubstring(`fern_ingestion_test_runs_total{project_id="payments"} 1`))
+		Expect(exposed).NotTo(ContainSubstring(`fern_ingestion_spec_runs_total{project_id="payments"}`))
+	})
+
+	It("should record flaky analysis durations by kind and result", func() {
+		registry.FlakyAnalysisCompleted("lifecycle", 2*time.Second, nil)
+		registry.FlakyAnalysisCompleted("test_run", 300*time.Millisecond, errors.New("database is down"))
+
+		exposed := scrape(registry)
+		Expect(exposed).To(ContainSubstring(`fern_flaky_analysis_duration_seconds_sum{kind="lifecycle",result="success"} 2`))
+		Expect(exposed).To(ContainSubstring(`fern_flaky_analysis_duration_seconds_count{kind="test_run",result="error"} 1`))
+	})
+
+	Describe("RegisterDatabase", func() {
+		var db *gorm.DB
+
+		BeforeEach(func() {
+			var err error
+			db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(db.AutoMigrate(&database.TestRun{}, &database.FlakyTest{})).To(Succeed())
+		})
+
+		It("should expose pool stats and gauges of the test data", func() {
+			Expect(db.Create([]database.TestRun{
+				{ProjectID: "shop", RunID: "run-1", Status: "running"},
+				{ProjectID: "shop", RunID: "run-2", Status: "running"},
+				{ProjectID: "shop", RunID: "run-3", Status: "passed"},
+				{ProjectID: "payments", RunID: "run-4", Status: "running"},
+			}).Error).To(Succeed())
+			Expect(db.Create([]database.FlakyTest{
+				{ProjectID: "shop", TestName: "checkout", Status: "active"},
+				{ProjectID: "shop", TestName: "cart", Status: "resolved"},
+				{ProjectID: "payments", TestName: "refund", Status: "active"},
+				{ProjectID: "payments", TestName: "charge", Status: "active"},
+			}).Error).To(Succeed())
+
+			Expect(registry.RegisterDatabase(db)).To(Succeed())
+
+			exposed := scrape(registry)
+			Expect(exposed).To(ContainSubstring("go_sql_open_connections"))
+			Expect(exposed).To(ContainSubstring(`fern_test_runs_running{project_id="shop"} 2`))
+			Expect(exposed).To(ContainSubstring(`fern_test_runs_running{project_id="payments"} 1`))
+			Expect(exposed).To(ContainSubstring(`fern_flaky_active_tests{project_id="shop"} 1`))
+			Expect(exposed).To(ContainSubstring(`fern_flaky_active_tests{project_id="payments"} 2`))
+			Expect(exposed).To(ContainSubstring("fern_business_metrics_scrape_error 0"))
+		})
+
+		It("should report failed queries instead of failing the scrape", func() {
+			Expect(registry.RegisterDatabase(db)).To(Succeed())
+			Expect(db.Migrator().DropTable(&database.FlakyTest{})).To(Succeed())
+
+			Expect(scrape(registry)).To(ContainSubstring("fern_business_metrics_scrape_error 1"))
+		})
+	})
+
+	Describe("NewServer", func() {
+		It("should serve the metrics on the configured path", func() {
+			server := metrics.NewServer(&config.MetricsConfig{Enabled: true, Path: "/internal/metrics", Port: 9464}, registry)
+			Expect(server.Addr).To(Equal(":9464"))
+
+			listener := httptest.NewServer(server.Handler)
+			defer listener.Close()
+
+			response, err := http.Get(listener.URL + "/internal/metrics")
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring("go_goroutines"))
+
+			missing, err := http.Get(listener.URL + "/api/v1/projects")
+			Expect(err).NotTo(HaveOccurred())
+			missing.Body.Close()
+			Expect(missing.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+})
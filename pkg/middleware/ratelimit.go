@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/ratelimit"
+)
+
+// Rate limit budgets requests are counted against
+const (
+	RateLimitAPI       = "api"
+	RateLimitIngestion = "ingestion"
+	RateLimitGraphQL   = "graphql"
+	RateLimitAuth      = "auth"
+)
+
+// ingestionRoutes are the routes test results are reported to
+var ingestionRoutes = map[string]bool{
+	"POST /api/v1/test-runs":          true,
+	"POST /api/v1/test-runs/start":    true,
+	"POST /api/v1/test-runs/complete": true,
+	"PUT /api/v1/test-runs/:id":       true,
+	"POST /api/v1/suite-runs":         true,
+	"POST /api/v1/spec-runs":          true,
+	"POST /api/v1/admin/test-runs":    true,
+}
+
+// UserIdentifier returns the ID of the authenticated user making a request, if
+// any, having validated their session or token
+type UserIdentifier func(c *gin.Context) (string, bool)
+
+// RateLimitMiddleware limits how often each client can call the API, with
+// separate token buckets for ingestion, GraphQL, sign-in and other API
+// requests. Clients are identified by their authenticated user, then by IP
+// address. Responses carry RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers, and limited requests are
+// answered 429 with Retry-After. Requests are allowed if the limiter fails.
+func RateLimitMiddleware(limiter ratelimit.Limiter, cfg config.RateLimitConfig, identifyUser UserIdentifier, logger *logging.Logger) gin.HandlerFunc {
+	budgets := map[string]ratelimit.Budget{
+		RateLimitAPI:       budget(cfg.API),
+		RateLimitIngestion: budget(cfg.Ingestion),
+		RateLimitGraphQL:   budget(cfg.GraphQL),
+		RateLimitAuth:      budget(cfg.Auth),
+	}
+
+	return func(c *gin.Context) {
+		class := RateLimitClass(c)
+		limit, ok := budgets[class]
+		if !ok || limit.Unlimited() {
+			c.Next()
+			return
+		}
+
+		key := class + ":" + rateLimitClient(c, identifyUser)
+		result, err := limiter.Allow(c.Request.Context(), key, limit)
+		if err != nil {
+			logger.WithRequest(c.GetString("request_id"), c.Request.Method, c.Request.URL.Path).
+				WithError(err).Warn("Rate limiter unavailable, allowing request")
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", seconds(result.ResetAfter))
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":      "Rate limit exceeded",
+				"request_id": c.GetString("request_id"),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RateLimitClass returns the budget a request is counted against, or an
+// empty string for requests that are not limited, such as the web interface
+func RateLimitClass(c *gin.Context) string {
+	path := c.Request.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/auth/"):
+		return RateLimitAuth
+	case path == "/query":
+		return RateLimitGraphQL
+	case ingestionRoutes[c.Request.Method+" "+c.FullPath()]:
+		return RateLimitIngestion
+	case strings.HasPrefix(path, "/api/"):
+		return RateLimitAPI
+	default:
+		return ""
+	}
+}
+
+// rateLimitClient identifies the client making a request: the user whose
+// session or bearer token was validated, or else the IP address. Unvalidated
+// keys and tokens are not trusted, so clients cannot get a fresh budget by
+// sending a new one with each request.
+func rateLimitClient(c *gin.Context, identifyUser UserIdentifier) string {
+	if identifyUser != nil {
+		if userID, ok := identifyUser(c); ok && userID != "" {
+			return "user:" + userID
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// budget converts a configured budget to a token bucket
+func budget(b config.RateLimitBudget) ratelimit.Budget {
+	return ratelimit.PerMinute(b.RequestsPerMinute, b.Burst)
+}
+
+// seconds formats a duration as whole seconds, rounding up
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -2,10 +2,13 @@
 package middleware
 
 import (
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/guidewire-oss/fern-platform/pkg/health"
 	"github.com/guidewire-oss/fern-platform/pkg/logging"
 )
 
@@ -28,7 +31,7 @@ func LoggingMiddleware(logger *logging.Logger) gin.HandlerFunc {
 	return gin.LoggerWithConfig(gin.LoggerConfig{
 		Formatter: func(param gin.LogFormatterParams) string {
 			// Skip logging for health check endpoints
-			if param.Path == "/health" || param.Path == "/api/v1/health" {
+			if param.Path == "/health" || param.Path == "/api/v1/health" || strings.HasPrefix(param.Path, "/healthz/") {
 				return ""
 			}
 
@@ -42,7 +45,7 @@ func LoggingMiddleware(logger *logging.Logger) gin.HandlerFunc {
 				"user_agent":    param.Request.UserAgent(),
 				"request_id":    param.Keys["request_id"],
 				"response_size": param.BodySize,
-			})
+			}).WithContext(param.Request.Context())
 
 			if param.ErrorMessage != "" {
 				entry = entry.WithField("error", param.ErrorMessage)
@@ -68,6 +71,7 @@ func RecoveryMiddleware(logger *logging.Logger) gin.HandlerFunc {
 		requestID := c.GetString("request_id")
 		logger.WithRequest(requestID, c.Request.Method, c.Request.URL.Path).
 			WithField("panic", recovered).
+			WithContext(c.Request.Context()).
 			Error("Panic recovered")
 
 		c.JSON(500, gin.H{
@@ -89,28 +93,33 @@ func SecurityHeadersMiddleware() gin.HandlerFunc {
 	}
 }
 
-// HealthCheckMiddleware provides a health check endpoint
-func HealthCheckMiddleware() gin.HandlerFunc {
+// HealthCheckMiddleware serves the health checks of the registry ahead of
+// routing and authentication: /healthz/live for liveness probes, and
+// /healthz/ready and the configured path for readiness probes. Unhealthy
+// instances answer 503. Error details are only shown in the admin view.
+func HealthCheckMiddleware(checks *health.Registry, path string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.Request.URL.Path == "/health" && c.Request.Method == "GET" {
-			c.JSON(200, gin.H{
-				"status":    "healthy",
-				"timestamp": time.Now().UTC().Format(time.RFC3339),
-				"service":   "fern-platform",
-			})
-			c.Abort()
+		if c.Request.Method != http.MethodGet {
+			c.Next()
 			return
 		}
-		c.Next()
-	}
-}
 
-// RateLimitMiddleware provides basic rate limiting (simplified implementation)
-func RateLimitMiddleware() gin.HandlerFunc {
-	// In a production environment, you would use a proper rate limiter like redis-based
-	// This is a simplified in-memory implementation for basic protection
-	return func(c *gin.Context) {
-		// For now, just pass through - implement proper rate limiting as needed
-		c.Next()
+		var report health.Report
+		switch c.Request.URL.Path {
+		case "/healthz/live":
+			report = checks.Live(c.Request.Context())
+		case "/healthz/ready", path:
+			report = checks.Ready(c.Request.Context())
+		default:
+			c.Next()
+			return
+		}
+
+		code := http.StatusOK
+		if report.Status == health.StatusUnhealthy {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, report.WithoutErrors())
+		c.Abort()
 	}
 }
@@ -0,0 +1,20 @@
+package ratelimit
+
+import "time"
+
+// SetClock replaces the clock a limiter refills buckets with
+func SetClock(limiter Limiter, now func() time.Time) {
+	switch l := limiter.(type) {
+	case *MemoryLimiter:
+		l.now = now
+	case *RedisLimiter:
+		l.now = now
+	}
+}
+
+// Buckets returns the number of buckets a memory limiter holds
+func (l *MemoryLimiter) Buckets() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have refilled are forgotten
+const sweepInterval = time.Minute
+
+// MemoryLimiter keeps token buckets in memory. Each replica has its own
+// buckets, so budgets apply per replica.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// bucket is the state of a token bucket
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	budget  Budget
+}
+
+var _ Limiter = (*MemoryLimiter)(nil)
+
+// NewMemoryLimiter creates a limiter keeping buckets in memory
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket if one is available
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, budget Budget) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = refill(b.tokens, now.Sub(b.updated), budget)
+	b.updated = now
+	b.budget = budget
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return newResult(allowed, b.tokens, budget), nil
+}
+
+// sweep forgets buckets that have refilled, which behave like new buckets,
+// so memory use follows the number of recently active keys
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if refill(b.tokens, now.Sub(b.updated), b.budget) >= float64(b.budget.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
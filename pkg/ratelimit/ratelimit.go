@@ -0,0 +1,82 @@
+// Package ratelimit provides token bucket rate limiting, in memory for a
+// single instance or in Redis for budgets shared between replicas
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Budget is the size and refill rate of a token bucket. A request takes one
+// token; buckets start full.
+type Budget struct {
+	// Rate is the number of tokens added per second
+	Rate float64
+	// Burst is the capacity of the bucket
+	Burst int
+}
+
+// PerMinute returns a budget refilling requests tokens a minute, holding up
+// to burst tokens, or requests if burst is not positive
+func PerMinute(requests, burst int) Budget {
+	if burst <= 0 {
+		burst = requests
+	}
+	return Budget{Rate: float64(requests) / 60, Burst: burst}
+}
+
+// Unlimited reports whether the budget allows every request
+func (b Budget) Unlimited() bool {
+	return b.Rate <= 0 || b.Burst <= 0
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	// Allowed reports whether a token was taken
+	Allowed bool
+	// Limit is the capacity of the bucket
+	Limit int
+	// Remaining is the number of whole tokens left
+	Remaining int
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is how long until the next token is available, when none is left
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from buckets identified by key
+type Limiter interface {
+	// Allow takes a token from the key's bucket if one is available
+	Allow(ctx context.Context, key string, budget Budget) (Result, error)
+}
+
+// newResult describes a bucket left with tokens after a request
+func newResult(allowed bool, tokens float64, budget Budget) Result {
+	result := Result{
+		Allowed:    allowed,
+		Limit:      budget.Burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: refillTime(float64(budget.Burst)-tokens, budget.Rate),
+	}
+	if !allowed {
+		result.RetryAfter = refillTime(1-tokens, budget.Rate)
+	}
+	return result
+}
+
+// refillTime returns how long it takes to add tokens at rate
+func refillTime(tokens, rate float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / rate * float64(time.Second)))
+}
+
+// refill returns the tokens of a bucket after elapsed time, capped at its burst
+func refill(tokens float64, elapsed time.Duration, budget Budget) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * budget.Rate
+	}
+	return math.Min(tokens, float64(budget.Burst))
+}
@@ -0,0 +1,329 @@
+package ratelimit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/middleware"
+	"github.com/guidewire-oss/fern-platform/pkg/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rate Limit Suite")
+}
+
+// clock is a settable time source
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// startRedis runs an in-process Redis for the spec
+func startRedis() (*miniredis.Miniredis, *ratelimit.RedisLimiter) {
+	server := miniredis.RunT(GinkgoT())
+	port, err := strconv.Atoi(server.Port())
+	Expect(err).NotTo(HaveOccurred())
+
+	limiter := ratelimit.NewRedisLimiter(&config.RedisConfig{Host: server.Host(), Port: port, PoolSize: 2})
+	DeferCleanup(limiter.Close)
+	return server, limiter
+}
+
+var _ = Describe("Limiters", func() {
+	backends := map[string]func() ratelimit.Limiter{
+		"memory": func() ratelimit.Limiter { return ratelimit.NewMemoryLimiter() },
+		"redis": func() ratelimit.Limiter {
+			_, limiter := startRedis()
+			return limiter
+		},
+	}
+
+	for name, newLimiter := range backends {
+		Context("in "+name, func() {
+			var (
+				limiter ratelimit.Limiter
+				now     *clock
+				ctx     context.Context
+			)
+			// 1 request a second with bursts of 3
+			budget := ratelimit.PerMinute(60, 3)
+
+			BeforeEach(func() {
+				limiter = newLimiter()
+				now = &clock{now: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)}
+				ratelimit.SetClock(limiter, now.Now)
+				ctx = context.Background()
+			})
+
+			It("should allow a burst, then refill at the budget's rate", func() {
+				for remaining := 2; remaining >= 0; remaining-- {
+					result, err := limiter.Allow(ctx, "ip:10.0.0.1", budget)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Allowed).To(BeTrue())
+					Expect(result.Limit).To(Equal(3))
+					Expect(result.Remaining).To(Equal(remaining))
+				}
+
+				result, err := limiter.Allow(ctx, "ip:10.0.0.1", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Allowed).To(BeFalse())
+				Expect(result.Remaining).To(Equal(0))
+				Expect(result.RetryAfter).To(Equal(time.Second))
+				Expect(result.ResetAfter).To(Equal(3 * time.Second))
+
+				now.Advance(500 * time.Millisecond)
+				result, err = limiter.Allow(ctx, "ip:10.0.0.1", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Allowed).To(BeFalse())
+				Expect(result.RetryAfter).To(Equal(500 * time.Millisecond))
+
+				now.Advance(500 * time.Millisecond)
+				result, err = limiter.Allow(ctx, "ip:10.0.0.1", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Allowed).To(BeTrue())
+
+				// Idle buckets fill up to the burst and no further
+				now.Advance(time.Hour)
+				result, err = limiter.Allow(ctx, "ip:10.0.0.1", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Remaining).To(Equal(2))
+			})
+
+			It("should keep a bucket per key", func() {
+				for i := 0; i < 3; i++ {
+					_, err := limiter.Allow(ctx, "user:alice", budget)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				result, err := limiter.Allow(ctx, "user:alice", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Allowed).To(BeFalse())
+
+				result, err = limiter.Allow(ctx, "user:bob", budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Allowed).To(BeTrue())
+			})
+		})
+	}
+
+	It("should share buckets between replicas through Redis", func() {
+		server, first := startRedis()
+		port, _ := strconv.Atoi(server.Port())
+		second := ratelimit.NewRedisLimiter(&config.RedisConfig{Host: server.Host(), Port: port})
+		DeferCleanup(second.Close)
+		budget := ratelimit.PerMinute(1, 2)
+
+		for _, limiter := range []ratelimit.Limiter{first, second} {
+			result, err := limiter.Allow(context.Background(), "ip:10.0.0.1", budget)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+		}
+		result, err := first.Allow(context.Background(), "ip:10.0.0.1", budget)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Allowed).To(BeFalse())
+
+		// Buckets expire once they would have refilled
+		Expect(server.Exists("fern:ratelimit:ip:10.0.0.1")).To(BeTrue())
+		server.FastForward(3 * time.Minute)
+		Expect(server.Exists("fern:ratelimit:ip:10.0.0.1")).To(BeFalse())
+		Expect(first.Ping(context.Background())).To(Succeed())
+	})
+
+	It("should forget refilled buckets in memory", func() {
+		limiter := ratelimit.NewMemoryLimiter()
+		now := &clock{now: time.Now()}
+		ratelimit.SetClock(limiter, now.Now)
+		budget := ratelimit.PerMinute(60, 3)
+
+		_, err := limiter.Allow(context.Background(), "ip:10.0.0.1", budget)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limiter.Buckets()).To(Equal(1))
+
+		now.Advance(2 * time.Minute)
+		_, err = limiter.Allow(context.Background(), "ip:10.0.0.2", budget)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limiter.Buckets()).To(Equal(1))
+	})
+})
+
+// failingLimiter is a limiter whose backend is unavailable
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, ratelimit.Budget) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+var _ = Describe("RateLimitMiddleware", func() {
+	var (
+		cfg     config.RateLimitConfig
+		limiter ratelimit.Limiter
+		users   map[string]string
+		logger  *logging.Logger
+	)
+
+	// serve sends a request from the client IP with the given headers
+	serve := func(method, path, ip string, headers map[string]string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(middleware.RateLimitMiddleware(limiter, cfg, func(c *gin.Context) (string, bool) {
+			if userID, ok := users[c.GetHeader("Authorization")]; ok {
+				return userID, true
+			}
+			userID, ok := users[c.GetHeader("Cookie")]
+			return userID, ok
+		}, logger))
+		ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+		router.POST("/api/v1/test-runs", ok)
+		router.POST("/api/v1/spec-runs", ok)
+		router.GET("/api/v1/projects", ok)
+		router.POST("/query", ok)
+		router.GET("/auth/login", ok)
+		router.GET("/web/index.html", ok)
+
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":40000"
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		var err error
+		logger, err = logging.NewLogger(&config.LoggingConfig{Level: "error", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+
+		limiter = ratelimit.NewMemoryLimiter()
+		users = map[string]string{"session_id=s1": "alice", "session_id=s2": "bob", "Bearer token-1": "ci-bot"}
+		cfg = config.RateLimitConfig{
+			Enabled:   true,
+			Backend:   "memory",
+			API:       config.RateLimitBudget{RequestsPerMinute: 60, Burst: 2},
+			Ingestion: config.RateLimitBudget{RequestsPerMinute: 60, Burst: 3},
+			GraphQL:   config.RateLimitBudget{RequestsPerMinute: 60, Burst: 1},
+			Auth:      config.RateLimitBudget{RequestsPerMinute: 60, Burst: 1},
+		}
+	})
+
+	It("should answer 429 with rate limit headers once the budget is spent", func() {
+		recorder := serve(http.MethodGet, "/api/v1/projects", "10.0.0.1", nil)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("RateLimit-Limit")).To(Equal("2"))
+		Expect(recorder.Header().Get("RateLimit-Remaining")).To(Equal("1"))
+		Expect(recorder.Header().Get("RateLimit-Reset")).To(Equal("1"))
+		Expect(recorder.Header().Get("Retry-After")).To(BeEmpty())
+
+		Expect(serve(http.MethodGet, "/api/v1/projects", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+
+		recorder = serve(http.MethodGet, "/api/v1/projects", "10.0.0.1", nil)
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("RateLimit-Remaining")).To(Equal("0"))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("1"))
+		var body map[string]interface{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+		Expect(body["error"]).To(Equal("Rate limit exceeded"))
+
+		// Other clients have their own budget
+		Expect(serve(http.MethodGet, "/api/v1/projects", "10.0.0.2", nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should keep separate budgets for ingestion, GraphQL, auth and other API requests", func() {
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusTooManyRequests))
+
+		Expect(serve(http.MethodGet, "/auth/login", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodGet, "/auth/login", "10.0.0.1", nil).Code).To(Equal(http.StatusTooManyRequests))
+
+		for i := 0; i < 3; i++ {
+			Expect(serve(http.MethodPost, "/api/v1/spec-runs", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		}
+		recorder := serve(http.MethodPost, "/api/v1/test-runs", "10.0.0.1", nil)
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("RateLimit-Limit")).To(Equal("3"))
+
+		Expect(serve(http.MethodGet, "/api/v1/projects", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+
+		// The web interface is not limited
+		for i := 0; i < 5; i++ {
+			recorder := serve(http.MethodGet, "/web/index.html", "10.0.0.1", nil)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("RateLimit-Limit")).To(BeEmpty())
+		}
+	})
+
+	It("should identify clients by authenticated user, then IP", func() {
+		bearer := map[string]string{"Authorization": "Bearer token-1"}
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", bearer).Code).To(Equal(http.StatusOK))
+		// The same user from another address shares their budget
+		Expect(serve(http.MethodPost, "/query", "10.0.0.2", bearer).Code).To(Equal(http.StatusTooManyRequests))
+
+		// Keys and tokens that are not validated do not get their own budget
+		Expect(serve(http.MethodPost, "/query", "10.0.0.4", map[string]string{"X-API-Key": "random-1"}).Code).
+			To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, "/query", "10.0.0.4", map[string]string{"Authorization": "Bearer random-2"}).Code).
+			To(Equal(http.StatusTooManyRequests))
+
+		alice := map[string]string{"Cookie": "session_id=s1"}
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", alice).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, "/query", "10.0.0.3", alice).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", map[string]string{"Cookie": "session_id=s2"}).Code).
+			To(Equal(http.StatusOK))
+
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("should not limit budgets without a rate", func() {
+		cfg.GraphQL = config.RateLimitBudget{}
+		for i := 0; i < 5; i++ {
+			Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		}
+	})
+
+	It("should allow requests when the limiter is unavailable", func() {
+		limiter = failingLimiter{}
+		for i := 0; i < 3; i++ {
+			recorder := serve(http.MethodPost, "/query", "10.0.0.1", nil)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("RateLimit-Limit")).To(BeEmpty())
+		}
+	})
+
+	It("should share budgets between replicas with Redis", func() {
+		_, redisLimiter := startRedis()
+		limiter = redisLimiter
+
+		Expect(serve(http.MethodPost, "/query", "10.0.0.1", nil).Code).To(Equal(http.StatusOK))
+		recorder := serve(http.MethodPost, "/query", "10.0.0.1", nil)
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("1"))
+	})
+})
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces rate limit buckets in Redis
+const redisKeyPrefix = "fern:ratelimit:"
+
+// takeToken refills a bucket stored as a hash of its tokens and the time it
+// was updated in milliseconds, then takes a token if one is available. Buckets
+// expire once they would have refilled. Tokens are returned as a string, as
+// Lua numbers are truncated to integers in replies.
+var takeToken = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+  tokens = burst
+  updated = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - updated) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps token buckets in Redis, so replicas share budgets.
+// Buckets are refilled using the clock of the replica taking a token, so
+// replica clocks should be synchronized.
+type RedisLimiter struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+var _ Limiter = (*RedisLimiter)(nil)
+
+// NewRedisLimiter creates a limiter keeping buckets in the configured Redis.
+// Redis is connected to on first use.
+func NewRedisLimiter(cfg *config.RedisConfig) *RedisLimiter {
+	return &RedisLimiter{
+		client: redis.NewClient(&redis.Options{
+			Addr:            fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Password:        cfg.Password,
+			DB:              cfg.DB,
+			PoolSize:        cfg.PoolSize,
+			ConnMaxIdleTime: cfg.IdleTimeout,
+		}),
+		now: time.Now,
+	}
+}
+
+// Allow takes a token from the key's bucket if one is available
+func (l *RedisLimiter) Allow(ctx context.Context, key string, budget Budget) (Result, error) {
+	reply, err := takeToken.Run(ctx, l.client, []string{redisKeyPrefix + key},
+		budget.Rate, budget.Burst, l.now().UnixMilli()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensText, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid rate limit tokens %q: %w", tokensText, err)
+	}
+	return newResult(allowed == 1, tokens, budget), nil
+}
+
+// Ping checks that Redis is reachable
+func (l *RedisLimiter) Ping(ctx context.Context) error {
+	return l.client.Ping(ctx).Err()
+}
+
+// Close closes the connections to Redis
+func (l *RedisLimiter) Close() error {
+	return l.client.Close()
+}
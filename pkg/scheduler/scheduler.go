@@ -0,0 +1,233 @@
+// Package scheduler runs named background jobs at fixed intervals
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// JobFunc is the work performed by a scheduled job
+type JobFunc func(ctx context.Context) error
+
+// JobStatus reports the state of a scheduled job
+type JobStatus struct {
+	Name         string
+	Interval     time.Duration
+	Running      bool
+	StartedAt    time.Time // Start of the current run; zero when idle
+	RunCount     int
+	LastRunAt    time.Time
+	LastDuration time.Duration
+	LastError    string
+	NextRunAt    time.Time
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       JobFunc
+	trigger  chan struct{}
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// Scheduler runs registered jobs periodically until stopped
+type Scheduler struct {
+	logger *logging.Logger
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// New creates a new scheduler
+func New(logger *logging.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		jobs:   make(map[string]*job),
+	}
+}
+
+// Register adds a job that runs every interval once the scheduler is started
+func (s *Scheduler) Register(name string, interval time.Duration, fn JobFunc) error {
+	if name == "" {
+		return fmt.Errorf("job name is required")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("job interval must be positive")
+	}
+	if fn == nil {
+		return fmt.Errorf("job function is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("cannot register job %s after scheduler has started", name)
+	}
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job %s already registered", name)
+	}
+
+	s.jobs[name] = &job{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		trigger:  make(chan struct{}, 1),
+		status:   JobStatus{Name: name, Interval: interval},
+	}
+	return nil
+}
+
+// Start begins running all registered jobs in the background
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop cancels all jobs and waits for in-flight runs to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
+
+// RunNow requests an immediate run of a job. It does not wait for the run to complete.
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.Lock()
+	j, exists := s.jobs[name]
+	started := s.started
+	s.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("job %s not found", name)
+	}
+	if !started {
+		return fmt.Errorf("scheduler is not running")
+	}
+
+	select {
+	case j.trigger <- struct{}{}:
+	default:
+		// A run is already pending
+	}
+	return nil
+}
+
+// Status returns the status of all registered jobs sorted by name
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	jobs := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	s.mu.Unlock()
+
+	statuses := make([]JobStatus, len(jobs))
+	for i, j := range jobs {
+		j.mu.Lock()
+		statuses[i] = j.status
+		j.mu.Unlock()
+	}
+
+	sort.Slice(statuses, func(a, b int) bool { return statuses[a].Name < statuses[b].Name })
+	return statuses
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.mu.Lock()
+	j.status.NextRunAt = time.Now().Add(j.interval)
+	j.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-j.trigger:
+		}
+		s.run(ctx, j)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, j *job) {
+	started := time.Now()
+	j.mu.Lock()
+	j.status.Running = true
+	j.status.StartedAt = started
+	j.mu.Unlock()
+
+	// Each run starts its own trace
+	spanCtx, span := tracing.Start(ctx, "job "+j.name, attribute.String("fern.job", j.name))
+	err := s.safeRun(spanCtx, j)
+	tracing.End(span, err)
+	duration := time.Since(started)
+
+	j.mu.Lock()
+	j.status.Running = false
+	j.status.StartedAt = time.Time{}
+	j.status.RunCount++
+	j.status.LastRunAt = started
+	j.status.LastDuration = duration
+	j.status.LastError = ""
+	if err != nil {
+		j.status.LastError = err.Error()
+	}
+	j.status.NextRunAt = time.Now().Add(j.interval)
+	j.mu.Unlock()
+
+	if s.logger == nil {
+		return
+	}
+	entry := s.logger.WithFields(map[string]interface{}{
+		"job":      j.name,
+		"duration": duration.String(),
+	}).WithContext(spanCtx)
+	if err != nil {
+		entry.WithError(err).Warn("Scheduled job failed")
+	} else {
+		entry.Debug("Scheduled job completed")
+	}
+}
+
+// safeRun executes the job and converts a panic into an error
+func (s *Scheduler) safeRun(ctx context.Context, j *job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return j.fn(ctx)
+}
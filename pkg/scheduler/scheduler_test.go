@@ -0,0 +1,111 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidewire-oss/fern-platform/pkg/scheduler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestScheduler(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Scheduler Suite")
+}
+
+var _ = Describe("Scheduler", Label("unit"), func() {
+	var s *scheduler.Scheduler
+
+	BeforeEach(func() {
+		s = scheduler.New(nil)
+	})
+
+	AfterEach(func() {
+		s.Stop()
+	})
+
+	Describe("Register", func() {
+		It("should reject invalid jobs", func() {
+			noop := func(context.Context) error { return nil }
+			Expect(s.Register("", time.Second, noop)).To(HaveOccurred())
+			Expect(s.Register("job", 0, noop)).To(HaveOccurred())
+			Expect(s.Register("job", time.Second, nil)).To(HaveOccurred())
+		})
+
+		It("should reject duplicate job names", func() {
+			noop := func(context.Context) error { return nil }
+			Expect(s.Register("job", time.Second, noop)).To(Succeed())
+			Expect(s.Register("job", time.Second, noop)).To(MatchError("job job already registered"))
+		})
+	})
+
+	Describe("Start", func() {
+		It("should run jobs periodically and record status", func() {
+			var runs int32
+			Expect(s.Register("tick", 10*time.Millisecond, func(context.Context) error {
+				atomic.AddInt32(&runs, 1)
+				return nil
+			})).To(Succeed())
+
+			s.Start(context.Background())
+
+			Eventually(func() int32 { return atomic.LoadInt32(&runs) }).Should(BeNumerically(">=", 2))
+			status := s.Status()
+			Expect(status).To(HaveLen(1))
+			Expect(status[0].Name).To(Equal("tick"))
+			Expect(status[0].RunCount).To(BeNumerically(">=", 1))
+			Expect(status[0].LastError).To(BeEmpty())
+		})
+
+		It("should record errors and recover from panics", func() {
+			Expect(s.Register("failing", time.Hour, func(context.Context) error {
+				return errors.New("boom")
+			})).To(Succeed())
+			Expect(s.Register("panicking", time.Hour, func(context.Context) error {
+				panic("oops")
+			})).To(Succeed())
+
+			s.Start(context.Background())
+			Expect(s.RunNow("failing")).To(Succeed())
+			Expect(s.RunNow("panicking")).To(Succeed())
+
+			Eventually(func() []scheduler.JobStatus { return s.Status() }).Should(SatisfyAll(
+				HaveLen(2),
+				WithTransform(func(st []scheduler.JobStatus) string { return st[0].LastError }, Equal("boom")),
+				WithTransform(func(st []scheduler.JobStatus) string { return st[1].LastError }, ContainSubstring("oops")),
+			))
+		})
+	})
+
+	Describe("RunNow", func() {
+		It("should fail for unknown jobs or a stopped scheduler", func() {
+			Expect(s.Register("job", time.Hour, func(context.Context) error { return nil })).To(Succeed())
+			Expect(s.RunNow("job")).To(MatchError("scheduler is not running"))
+
+			s.Start(context.Background())
+			Expect(s.RunNow("missing")).To(MatchError("job missing not found"))
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should cancel the context of running jobs", func() {
+			cancelled := make(chan struct{})
+			Expect(s.Register("blocking", time.Hour, func(ctx context.Context) error {
+				<-ctx.Done()
+				close(cancelled)
+				return ctx.Err()
+			})).To(Succeed())
+
+			s.Start(context.Background())
+			Expect(s.RunNow("blocking")).To(Succeed())
+			Eventually(func() bool { return s.Status()[0].Running }).Should(BeTrue())
+
+			s.Stop()
+			Eventually(cancelled).Should(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,310 @@
+// Package similarity provides an in-process TF-IDF index for finding similar
+// pieces of text such as test failure messages and stack traces
+package similarity
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxDocuments bounds the index size when no limit is given
+const DefaultMaxDocuments = 50000
+
+// Once the index holds minDocumentsForPruning documents, terms found in more
+// than commonTermRatio of them no longer select candidates; such terms still
+// contribute to the score
+const (
+	minDocumentsForPruning = 100
+	commonTermRatio        = 0.5
+)
+
+var (
+	volatilePattern = regexp.MustCompile(`(?i)\b(?:0x[0-9a-f]+|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|[0-9a-f]{16,})\b`)
+	tokenPattern    = regexp.MustCompile(`[a-z][a-z0-9_]*`)
+	stopWords       = map[string]bool{
+		"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+		"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+		"the": true, "to": true, "was": true, "with": true,
+	}
+)
+
+// Match is a document similar to a query
+type Match struct {
+	ID    uint
+	Group string
+	Score float64
+}
+
+// QueryOptions controls which documents a query returns
+type QueryOptions struct {
+	// Limit caps the number of matches; zero returns all
+	Limit int
+	// MinScore drops matches with a lower cosine similarity
+	MinScore float64
+	// Exclude lists document IDs to leave out, such as the query document itself
+	Exclude []uint
+	// Allow restricts matches to permitted groups; nil allows every group
+	Allow func(group string) bool
+}
+
+type document struct {
+	group string
+	terms map[string]float64
+	seq   uint64
+}
+
+type entry struct {
+	id  uint
+	seq uint64
+}
+
+// Index is a bounded, concurrency-safe TF-IDF index with cosine similarity.
+// When full, the least recently added documents are evicted first.
+type Index struct {
+	maxDocuments int
+
+	mu       sync.RWMutex
+	docs     map[uint]*document
+	postings map[string]map[uint]struct{}
+	order    []entry
+	seq      uint64
+}
+
+// NewIndex creates an index holding at most maxDocuments documents
+func NewIndex(maxDocuments int) *Index {
+	if maxDocuments <= 0 {
+		maxDocuments = DefaultMaxDocuments
+	}
+	return &Index{
+		maxDocuments: maxDocuments,
+		docs:         make(map[uint]*document),
+		postings:     make(map[string]map[uint]struct{}),
+	}
+}
+
+// Tokenize normalizes text and returns its terms. Numbers, hex values and IDs
+// are dropped so that the same failure in different runs yields the same terms,
+// and adjacent words are paired to capture phrases.
+func Tokenize(text string) []string {
+	text = volatilePattern.ReplaceAllString(strings.ToLower(text), " ")
+
+	var words []string
+	for _, word := range tokenPattern.FindAllString(text, -1) {
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	terms := make([]string, 0, len(words)*2)
+	terms = append(terms, words...)
+	for i := 1; i < len(words); i++ {
+		terms = append(terms, words[i-1]+" "+words[i])
+	}
+	return terms
+}
+
+func termFrequencies(text string) map[string]float64 {
+	counts := make(map[string]int)
+	for _, term := range Tokenize(text) {
+		counts[term]++
+	}
+	weights := make(map[string]float64, len(counts))
+	for term, count := range counts {
+		weights[term] = 1 + math.Log(float64(count))
+	}
+	return weights
+}
+
+// Add indexes text under id, replacing any previous document with that id.
+// Text without any terms is not indexed.
+func (i *Index) Add(id uint, group, text string) {
+	terms := termFrequencies(text)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(id)
+	if len(terms) == 0 {
+		return
+	}
+
+	i.seq++
+	i.docs[id] = &document{group: group, terms: terms, seq: i.seq}
+	for term := range terms {
+		posting, ok := i.postings[term]
+		if !ok {
+			posting = make(map[uint]struct{})
+			i.postings[term] = posting
+		}
+		posting[id] = struct{}{}
+	}
+	i.order = append(i.order, entry{id: id, seq: i.seq})
+
+	i.evict()
+}
+
+// Remove drops a document from the index
+func (i *Index) Remove(id uint) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+}
+
+// Contains reports whether a document is indexed
+func (i *Index) Contains(id uint) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	_, ok := i.docs[id]
+	return ok
+}
+
+// Capacity returns the maximum number of documents the index holds
+func (i *Index) Capacity() int {
+	return i.maxDocuments
+}
+
+// Len returns the number of indexed documents
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+func (i *Index) remove(id uint) {
+	doc, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		posting := i.postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docs, id)
+}
+
+// evict drops the oldest documents beyond capacity. Entries for documents that
+// were replaced or removed are skipped and compacted away.
+func (i *Index) evict() {
+	for len(i.docs) > i.maxDocuments && len(i.order) > 0 {
+		oldest := i.order[0]
+		i.order = i.order[1:]
+		if doc, ok := i.docs[oldest.id]; ok && doc.seq == oldest.seq {
+			i.remove(oldest.id)
+		}
+	}
+
+	if len(i.order) > 2*i.maxDocuments {
+		live := make([]entry, 0, len(i.docs))
+		for _, e := range i.order {
+			if doc, ok := i.docs[e.id]; ok && doc.seq == e.seq {
+				live = append(live, e)
+			}
+		}
+		i.order = live
+	}
+}
+
+// Query returns indexed documents similar to text, most similar first
+func (i *Index) Query(text string, opts QueryOptions) []Match {
+	queryTerms := termFrequencies(text)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	excluded := make(map[uint]bool, len(opts.Exclude))
+	for _, id := range opts.Exclude {
+		excluded[id] = true
+	}
+
+	matches := i.score(queryTerms, excluded, opts.MinScore)
+
+	// Group checks may be slow, so they run outside the lock
+	if opts.Allow != nil {
+		allowed := make(map[string]bool)
+		filtered := matches[:0]
+		for _, match := range matches {
+			ok, seen := allowed[match.Group]
+			if !seen {
+				ok = opts.Allow(match.Group)
+				allowed[match.Group] = ok
+			}
+			if ok {
+				filtered = append(filtered, match)
+			}
+		}
+		matches = filtered
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		return matches[a].ID > matches[b].ID
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches
+}
+
+// score computes the cosine similarity of every candidate document sharing a
+// selective term with the query
+func (i *Index) score(queryTerms map[string]float64, excluded map[uint]bool, minScore float64) []Match {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	total := float64(len(i.docs))
+	idf := func(term string) float64 {
+		return math.Log((total+1)/(float64(len(i.postings[term]))+1)) + 1
+	}
+
+	queryWeights := make(map[string]float64, len(queryTerms))
+	var queryNorm float64
+	candidates := make(map[uint]struct{})
+	for term, tf := range queryTerms {
+		weight := tf * idf(term)
+		queryWeights[term] = weight
+		queryNorm += weight * weight
+
+		posting := i.postings[term]
+		if total >= minDocumentsForPruning && float64(len(posting)) > commonTermRatio*total {
+			continue
+		}
+		for id := range posting {
+			candidates[id] = struct{}{}
+		}
+	}
+	queryNorm = math.Sqrt(queryNorm)
+
+	var matches []Match
+	for id := range candidates {
+		if excluded[id] {
+			continue
+		}
+		doc := i.docs[id]
+
+		var dot, docNorm float64
+		for term, tf := range doc.terms {
+			weight := tf * idf(term)
+			docNorm += weight * weight
+			dot += weight * queryWeights[term]
+		}
+		if dot == 0 || docNorm == 0 {
+			continue
+		}
+
+		score := dot / (queryNorm * math.Sqrt(docNorm))
+		if score < minScore {
+			continue
+		}
+		matches = append(matches, Match{ID: id, Group: doc.group, Score: score})
+	}
+	return matches
+}
@@ -0,0 +1,127 @@
+package similarity_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/guidewire-oss/fern-platform/pkg/similarity"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSimilarity(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Similarity Suite")
+}
+
+var _ = Describe("Tokenize", Label("unit"), func() {
+	It("should drop volatile values and stop words and add word pairs", func() {
+		terms := similarity.Tokenize("Timeout after 3000ms at 0xdeadbeef waiting for the order 3f2b1c4d-1111-2222-3333-444455556666")
+		Expect(terms).To(ContainElements("timeout", "after", "waiting", "order", "timeout after", "waiting order"))
+		Expect(terms).NotTo(ContainElement("the"))
+		Expect(terms).NotTo(ContainElement(ContainSubstring("dead")))
+		Expect(terms).NotTo(ContainElement(ContainSubstring("3000")))
+	})
+
+	It("should normalize the same failure from different runs identically", func() {
+		a := similarity.Tokenize("connection refused: dial tcp 10.0.0.12:5432 after 3 attempts")
+		b := similarity.Tokenize("Connection refused: dial tcp 10.0.0.99:5432 after 5 attempts")
+		Expect(a).To(Equal(b))
+	})
+})
+
+var _ = Describe("Index", Label("unit"), func() {
+	var index *similarity.Index
+
+	BeforeEach(func() {
+		index = similarity.NewIndex(100)
+		index.Add(1, "payments", "NullPointerException in PaymentProcessor.charge at PaymentProcessor.java:42")
+		index.Add(2, "payments", "Timeout waiting for payment gateway response after 30s")
+		index.Add(3, "checkout", "NullPointerException in PaymentProcessor.refund at PaymentProcessor.java:88")
+		index.Add(4, "search", "expected 10 results but got 0 from search index")
+	})
+
+	It("should rank the most similar documents first", func() {
+		matches := index.Query("NullPointerException in PaymentProcessor.charge at PaymentProcessor.java:57", similarity.QueryOptions{})
+		Expect(len(matches)).To(BeNumerically(">=", 2))
+		Expect(matches[0].ID).To(Equal(uint(1)))
+		Expect(matches[0].Group).To(Equal("payments"))
+		Expect(matches[0].Score).To(BeNumerically("~", 1.0, 0.0001))
+		Expect(matches[1].ID).To(Equal(uint(3)))
+		Expect(matches[1].Score).To(BeNumerically("<", matches[0].Score))
+		for _, match := range matches {
+			Expect(match.ID).NotTo(Equal(uint(4)))
+		}
+	})
+
+	It("should apply limit, minimum score, exclusions and group filters", func() {
+		query := "NullPointerException in PaymentProcessor"
+
+		Expect(index.Query(query, similarity.QueryOptions{Limit: 1})).To(HaveLen(1))
+		Expect(index.Query(query, similarity.QueryOptions{MinScore: 0.99})).To(BeEmpty())
+
+		matches := index.Query(query, similarity.QueryOptions{Exclude: []uint{1}})
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].ID).To(Equal(uint(3)))
+
+		calls := 0
+		matches = index.Query(query, similarity.QueryOptions{Allow: func(group string) bool {
+			calls++
+			return group == "checkout"
+		}})
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].ID).To(Equal(uint(3)))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("should replace and remove documents", func() {
+		index.Add(4, "search", "NullPointerException in PaymentProcessor")
+		Expect(index.Len()).To(Equal(4))
+		Expect(index.Query("search index results", similarity.QueryOptions{})).To(BeEmpty())
+
+		index.Remove(4)
+		Expect(index.Contains(4)).To(BeFalse())
+		Expect(index.Len()).To(Equal(3))
+	})
+
+	It("should not index text without terms", func() {
+		index.Add(5, "misc", "1234 !!")
+		Expect(index.Contains(5)).To(BeFalse())
+		Expect(index.Query("", similarity.QueryOptions{})).To(BeNil())
+	})
+
+	It("should evict the oldest documents when full", func() {
+		small := similarity.NewIndex(3)
+		for id := uint(1); id <= 5; id++ {
+			small.Add(id, "p", fmt.Sprintf("failure number %c", 'a'+rune(id)))
+		}
+		small.Add(4, "p", "failure replaced")
+		Expect(small.Len()).To(Equal(3))
+		Expect(small.Contains(1)).To(BeFalse())
+		Expect(small.Contains(2)).To(BeFalse())
+		Expect(small.Contains(3)).To(BeTrue())
+		Expect(small.Contains(4)).To(BeTrue())
+
+		small.Add(6, "p", "another failure")
+		Expect(small.Contains(3)).To(BeFalse())
+		Expect(small.Contains(4)).To(BeTrue())
+	})
+
+	It("should be safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for n := 0; n < 50; n++ {
+					index.Add(uint(100+w*50+n), "load", fmt.Sprintf("worker failure timeout %d", n))
+					index.Query("worker failure timeout", similarity.QueryOptions{Limit: 5})
+				}
+			}(w)
+		}
+		wg.Wait()
+		Expect(index.Len()).To(Equal(100))
+	})
+})
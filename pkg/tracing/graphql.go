@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// graphqlTracing is a gqlgen extension tracing each GraphQL response and the
+// resolvers that compute it
+type graphqlTracing struct{}
+
+var (
+	_ graphql.HandlerExtension    = graphqlTracing{}
+	_ graphql.ResponseInterceptor = graphqlTracing{}
+	_ graphql.FieldInterceptor    = graphqlTracing{}
+)
+
+// GraphQLExtension returns a gqlgen extension starting a span for each
+// operation, with a child span for each field computed by a resolver. Fields
+// read from an already loaded object are not traced.
+func GraphQLExtension() graphql.HandlerExtension {
+	return graphqlTracing{}
+}
+
+// ExtensionName identifies the extension
+func (graphqlTracing) ExtensionName() string {
+	return "Tracing"
+}
+
+// Validate accepts any schema
+func (graphqlTracing) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse traces the response of an operation. Subscriptions are
+// traced per event they send.
+func (graphqlTracing) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	name, kind := operation(ctx)
+	ctx, span := tracer.Start(ctx, kind+" "+name,
+		trace.WithAttributes(
+			attribute.String("graphql.operation.name", name),
+			attribute.String("graphql.operation.type", kind),
+		),
+	)
+	defer span.End()
+
+	response := next(ctx)
+	if response != nil && len(response.Errors) > 0 {
+		span.SetStatus(codes.Error, response.Errors.Error())
+	}
+	return response
+}
+
+// InterceptField traces fields computed by a resolver
+func (graphqlTracing) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	field := graphql.GetFieldContext(ctx)
+	if field == nil || !field.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := tracer.Start(ctx, field.Object+"."+field.Field.Name,
+		trace.WithAttributes(attribute.String("graphql.field.path", field.Path().String())),
+	)
+	result, err := next(ctx)
+	End(span, err)
+	return result, err
+}
+
+// operation returns the name and type of the operation of a response.
+// Requests that could not be parsed have no operation.
+func operation(ctx context.Context) (string, string) {
+	if !graphql.HasOperationContext(ctx) {
+		return "invalid", "unknown"
+	}
+	operation := graphql.GetOperationContext(ctx)
+	if operation.Operation == nil {
+		return "invalid", "unknown"
+	}
+
+	name := operation.OperationName
+	if name == "" {
+		name = operation.Operation.Name
+	}
+	if name == "" {
+		name = "anonymous"
+	}
+	return name, string(operation.Operation.Operation)
+}
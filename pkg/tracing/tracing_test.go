@@ -0,0 +1,213 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/guidewire-oss/fern-platform/pkg/config"
+	"github.com/guidewire-oss/fern-platform/pkg/database"
+	"github.com/guidewire-oss/fern-platform/pkg/logging"
+	"github.com/guidewire-oss/fern-platform/pkg/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tracing Suite")
+}
+
+// exporter collects the spans ended by the tests
+var exporter = tracetest.NewInMemoryExporter()
+
+var _ = BeforeSuite(func() {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+})
+
+// spanNamed returns the ended span with the given name
+func spanNamed(name string) tracetest.SpanStub {
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	Fail("no span named " + name)
+	return tracetest.SpanStub{}
+}
+
+var _ = Describe("Tracing", Label("unit"), func() {
+	BeforeEach(func() {
+		exporter.Reset()
+	})
+
+	It("should discard spans when disabled", func() {
+		shutdown, err := tracing.Init(context.Background(), &config.TracingConfig{Enabled: false})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("should mark spans ending with an error as failed", func() {
+		ctx, parent := tracing.Start(context.Background(), "parent")
+		_, child := tracing.Start(ctx, "child")
+		tracing.End(child, errors.New("database is down"))
+		tracing.End(parent, nil)
+
+		failed := spanNamed("child")
+		Expect(failed.Status.Code).To(Equal(codes.Error))
+		Expect(failed.Status.Description).To(Equal("database is down"))
+		Expect(failed.Parent.SpanID()).To(Equal(spanNamed("parent").SpanContext.SpanID()))
+		Expect(spanNamed("parent").Status.Code).To(Equal(codes.Unset))
+	})
+
+	It("should keep the context of a trace that is not sampled", func() {
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+
+		spanCtx, span := tracing.Start(ctx, "unsampled")
+		tracing.End(span, nil)
+
+		Expect(spanCtx).To(BeIdenticalTo(ctx))
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+
+	Describe("Middleware", func() {
+		var (
+			router *gin.Engine
+			output *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			output = &bytes.Buffer{}
+			log, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+			Expect(err).NotTo(HaveOccurred())
+			log.SetOutput(output)
+
+			gin.SetMode(gin.TestMode)
+			router = gin.New()
+			router.Use(tracing.Middleware("fern-platform"))
+			router.POST("/api/v1/test-runs", func(c *gin.Context) {
+				_, span := tracing.Start(c.Request.Context(), "TestRunService.CreateTestRun")
+				tracing.End(span, nil)
+				log.WithTrace(c.Request.Context()).Info("Test run created")
+				c.Status(http.StatusCreated)
+			})
+			router.GET("/health", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+		})
+
+		It("should continue the trace of a CI client", func() {
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/test-runs", nil)
+			request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			router.ServeHTTP(httptest.NewRecorder(), request)
+
+			server := spanNamed("POST /api/v1/test-runs")
+			Expect(server.SpanContext.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(server.Parent.SpanID().String()).To(Equal("00f067aa0ba902b7"))
+			Expect(spanNamed("TestRunService.CreateTestRun").Parent.SpanID()).To(Equal(server.SpanContext.SpanID()))
+
+			var entry map[string]interface{}
+			Expect(json.Unmarshal(output.Bytes(), &entry)).To(Succeed())
+			Expect(entry).To(HaveKeyWithValue("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(entry).To(HaveKeyWithValue("span_id", server.SpanContext.SpanID().String()))
+		})
+
+		It("should start a trace for requests without one", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/test-runs", nil))
+
+			server := spanNamed("POST /api/v1/test-runs")
+			Expect(server.SpanContext.TraceID().IsValid()).To(BeTrue())
+			Expect(server.Parent.IsValid()).To(BeFalse())
+		})
+
+		It("should not trace health checks", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			Expect(exporter.GetSpans()).To(BeEmpty())
+		})
+	})
+
+	It("should not add trace fields to entries logged without a span", func() {
+		output := &bytes.Buffer{}
+		log, err := logging.NewLogger(&config.LoggingConfig{Level: "info", Format: "json"})
+		Expect(err).NotTo(HaveOccurred())
+		log.SetOutput(output)
+
+		log.WithTrace(context.Background()).Info("Starting")
+
+		Expect(output.String()).NotTo(ContainSubstring("trace_id"))
+	})
+
+	Describe("GraphQLExtension", func() {
+		var server *testserver.TestServer
+
+		BeforeEach(func() {
+			server = testserver.New()
+			server.AddTransport(transport.POST{})
+			server.Use(tracing.GraphQLExtension())
+		})
+
+		post := func(body string) {
+			request := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			server.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		It("should trace operations by type and name", func() {
+			post(`{"query":"query ProjectName { name }"}`)
+
+			span := spanNamed("query ProjectName")
+			Expect(span.Status.Code).To(Equal(codes.Unset))
+		})
+
+		It("should mark failed operations", func() {
+			post(`{"query":"mutation Rename { name }"}`)
+
+			Expect(spanNamed("mutation Rename").Status.Code).To(Equal(codes.Error))
+		})
+	})
+
+	Describe("InstrumentDatabase", func() {
+		It("should trace queries without their parameters", func() {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(db.AutoMigrate(&database.TestRun{})).To(Succeed())
+			Expect(tracing.InstrumentDatabase(db)).To(Succeed())
+
+			ctx, parent := tracing.Start(context.Background(), "ingest")
+			var runs []database.TestRun
+			Expect(db.WithContext(ctx).Where("project_id = ?", "secret-project").Find(&runs).Error).To(Succeed())
+			tracing.End(parent, nil)
+
+			query := spanNamed("select test_runs")
+			Expect(query.Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			for _, attr := range query.Attributes {
+				Expect(attr.Value.Emit()).NotTo(ContainSubstring("secret-project"))
+			}
+		})
+	})
+})
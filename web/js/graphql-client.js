@@ -447,6 +447,98 @@ const QUERIES = {
         mutation DeleteJiraConnection($id: ID!) {
             deleteJiraConnection(id: $id)
         }
+    `,
+
+    LIST_USERS: `
+        query ListUsers($filter: UserFilter, $first: Int, $after: String) {
+            users(filter: $filter, first: $first, after: $after) {
+                edges {
+                    node {
+                        userId
+                        email
+                        name
+                        role
+                        status
+                        profileUrl
+                        lastLoginAt
+                        roleAssignedBy
+                        updatedBy
+                    }
+                    cursor
+                }
+                pageInfo {
+                    hasNextPage
+                    endCursor
+                }
+                totalCount
+            }
+        }
+    `,
+
+    UPDATE_USER_ROLE: `
+        mutation UpdateUserRole($userId: String!, $role: String!) {
+            updateUserRole(userId: $userId, role: $role) {
+                userId
+                role
+            }
+        }
+    `,
+
+    SUSPEND_USER: `
+        mutation SuspendUser($userId: String!) {
+            suspendUser(userId: $userId) {
+                userId
+                status
+            }
+        }
+    `,
+
+    ACTIVATE_USER: `
+        mutation ActivateUser($userId: String!) {
+            activateUser(userId: $userId) {
+                userId
+                status
+            }
+        }
+    `,
+
+    DELETE_USER: `
+        mutation DeleteUser($userId: String!, $reassignTo: String) {
+            deleteUser(userId: $userId, reassignTo: $reassignTo)
+        }
+    `,
+
+    GET_PROJECT_ACCESS: `
+        query GetProjectAccess($projectId: String!) {
+            projectAccess(projectId: $projectId) {
+                projectId
+                userId
+                groupName
+                permission
+                grantedBy
+                grantedAt
+                expiresAt
+                expired
+            }
+        }
+    `,
+
+    GRANT_PROJECT_ACCESS: `
+        mutation GrantProjectAccess($input: GrantProjectAccessInput!) {
+            grantProjectAccess(input: $input) {
+                projectId
+                userId
+                groupName
+                permission
+                expiresAt
+            }
+        }
+    `,
+
+    REVOKE_PROJECT_ACCESS: `
+        mutation RevokeProjectAccess($projectId: String!, $userId: String, $groupName: String, $permission: String) {
+            revokeProjectAccess(projectId: $projectId, userId: $userId, groupName: $groupName, permission: $permission)
+        }
     `
 };
 